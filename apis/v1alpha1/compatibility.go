@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// versionRequirement is the minimum collector or target allocator version a feature of the instances requires.
+type versionRequirement struct {
+	// attribute is the attribute enabling the feature, as reported to the users.
+	attribute string
+	// collector and targetAllocator are the minimum versions, empty when the feature doesn't depend on the component.
+	collector       string
+	targetAllocator string
+	// requested returns whether the instance uses the feature.
+	requested func(spec OpenTelemetryCollectorSpec) bool
+	// rejected fails the validation of the instances whose images are too old, rather than warning about them, for the
+	// features the collector doesn't start with.
+	rejected bool
+}
+
+// versionRequirements is the compatibility table of the features of the instances with the versions of the images
+// they run. New entries are added along with the features depending on a collector or target allocator release.
+var versionRequirements = []versionRequirement{
+	{
+		attribute: "targetAllocator",
+		collector: "0.61.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			// the target_allocator setting of the prometheus receiver is only used when the configuration is rewritten
+			return spec.TargetAllocator.Enabled && featuregate.EnableTargetAllocatorRewrite.IsEnabled()
+		},
+	},
+	{
+		attribute:       "targetAllocator.prometheusCR",
+		targetAllocator: "0.50.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return spec.TargetAllocator.Enabled && spec.TargetAllocator.PrometheusCR.Enabled
+		},
+	},
+	{
+		attribute:       "targetAllocator.allocationStrategy",
+		targetAllocator: "0.60.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return spec.TargetAllocator.Enabled && spec.TargetAllocator.AllocationStrategy == OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing
+		},
+	},
+	{
+		attribute:       "targetAllocator.filterStrategy",
+		targetAllocator: "0.64.1",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return spec.TargetAllocator.Enabled && len(spec.TargetAllocator.FilterStrategy) > 0
+		},
+	},
+	{
+		// the validate command of the collector, the sidecars are injected without being validated
+		attribute: "configValidation",
+		collector: "0.86.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return spec.ConfigValidation != nil && spec.Mode != ModeSidecar
+		},
+	},
+	{
+		// the metric readers and span processors of service.telemetry
+		attribute: "selfTelemetry.otlp",
+		collector: "0.88.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return selfTelemetryPushes(spec, SelfTelemetrySignalMetrics) || selfTelemetryPushes(spec, SelfTelemetrySignalTraces)
+		},
+		// the collector fails on the unknown settings of service.telemetry
+		rejected: true,
+	},
+	{
+		// the log processors of service.telemetry
+		attribute: "selfTelemetry.otlp.signals: [logs]",
+		collector: "0.108.0",
+		requested: func(spec OpenTelemetryCollectorSpec) bool {
+			return selfTelemetryPushes(spec, SelfTelemetrySignalLogs)
+		},
+		rejected: true,
+	},
+}
+
+// defaultCollectorImage is the image of the instances that don't set one.
+var defaultCollectorImage string
+
+// SetDefaultCollectorImage sets the image of the instances that don't set one, which the webhook checks the features
+// of these instances against. The operator's packages building the objects of the instances use the image of the
+// operator's configuration instead, as they may run before it's set, e.g. when rendering the collectors.
+func SetDefaultCollectorImage(image string) {
+	defaultCollectorImage = image
+}
+
+// collectorImage returns the image the collector of the instance runs.
+func (r *OpenTelemetryCollector) collectorImage() string {
+	if len(r.Spec.Image) > 0 {
+		return r.Spec.Image
+	}
+	return defaultCollectorImage
+}
+
+// CollectorVersion returns the version of the collector image of the instance, defaulting to the operator's, or nil
+// when its tag isn't a version.
+func (r *OpenTelemetryCollector) CollectorVersion() *semver.Version {
+	return ImageVersion(r.collectorImage())
+}
+
+// selfTelemetryPushes returns whether the collector pushes the given signal of its own telemetry to an OTLP endpoint.
+func selfTelemetryPushes(spec OpenTelemetryCollectorSpec, signal SelfTelemetrySignal) bool {
+	if spec.SelfTelemetry.OTLP == nil {
+		return false
+	}
+	// all the signals are pushed when none is listed
+	if len(spec.SelfTelemetry.OTLP.Signals) == 0 {
+		return true
+	}
+	for _, s := range spec.SelfTelemetry.OTLP.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// versionWarnings returns the warnings for the features the images of the instance are too old for, except for the
+// rejected ones. The versions are read from the image tags, the collector image defaulting to the operator's, and
+// images without a version aren't checked.
+func (r *OpenTelemetryCollector) versionWarnings() admission.Warnings {
+	var warnings admission.Warnings
+	for _, requirement := range versionRequirements {
+		if !requirement.rejected {
+			warnings = append(warnings, r.unsupportedVersions(requirement)...)
+		}
+	}
+	return warnings
+}
+
+// versionError returns the error for the first rejected feature the images of the instance are too old for, checked
+// like versionWarnings.
+func (r *OpenTelemetryCollector) versionError() error {
+	for _, requirement := range versionRequirements {
+		if unsupported := r.unsupportedVersions(requirement); requirement.rejected && len(unsupported) > 0 {
+			return errors.New(unsupported[0])
+		}
+	}
+	return nil
+}
+
+// unsupportedVersions returns the messages for the images of the instance which are too old for the given feature,
+// when the instance uses it.
+func (r *OpenTelemetryCollector) unsupportedVersions(requirement versionRequirement) []string {
+	if !requirement.requested(r.Spec) {
+		return nil
+	}
+	var unsupported []string
+	collectorVersion := r.CollectorVersion()
+	if collectorVersion != nil && len(requirement.collector) > 0 && collectorVersion.LessThan(semver.MustParse(requirement.collector)) {
+		unsupported = append(unsupported, fmt.Sprintf("the OpenTelemetry Collector version %s doesn't support the attribute '%s', which requires version %s or later", collectorVersion, requirement.attribute, requirement.collector))
+	}
+	targetAllocatorVersion := ImageVersion(r.Spec.TargetAllocator.Image)
+	if targetAllocatorVersion != nil && len(requirement.targetAllocator) > 0 && targetAllocatorVersion.LessThan(semver.MustParse(requirement.targetAllocator)) {
+		unsupported = append(unsupported, fmt.Sprintf("the OpenTelemetry TargetAllocator version %s doesn't support the attribute '%s', which requires version %s or later", targetAllocatorVersion, requirement.attribute, requirement.targetAllocator))
+	}
+	return unsupported
+}
+
+// ImageVersion returns the version of the tag of the image, or nil when the tag isn't a version.
+func ImageVersion(image string) *semver.Version {
+	// the digest, if any, follows the tag
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return nil
+	}
+	version, err := semver.NewVersion(image[i+1:])
+	if err != nil {
+		return nil
+	}
+	return version
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "strings"
+
+type (
+	// CompatibilityMode represents the restricted platform the collector runs on.
+	// +kubebuilder:validation:Enum=gke-autopilot;eks-fargate
+	CompatibilityMode string
+)
+
+const (
+	// CompatibilityModeGKEAutopilot specifies that the collector runs on GKE Autopilot, which rejects the pods with
+	// host access.
+	CompatibilityModeGKEAutopilot CompatibilityMode = "gke-autopilot"
+	// CompatibilityModeEKSFargate specifies that the collector runs on EKS Fargate, which doesn't run daemonsets and
+	// rejects the pods with host access.
+	CompatibilityModeEKSFargate CompatibilityMode = "eks-fargate"
+)
+
+// ReservedNodeLabel returns whether the given node label is managed by the platform and can't be used in the node
+// selectors of the pods.
+func (m CompatibilityMode) ReservedNodeLabel(key string) bool {
+	switch m {
+	case CompatibilityModeGKEAutopilot:
+		// the labels of the node pools Autopilot manages, except the ones selecting Spot VMs and GPUs
+		return key == "kubernetes.io/hostname" ||
+			(strings.HasPrefix(key, "cloud.google.com/gke-") && key != "cloud.google.com/gke-spot" && key != "cloud.google.com/gke-accelerator")
+	case CompatibilityModeEKSFargate:
+		// each Fargate pod runs on its own node, only the compute type can be selected
+		return key == "kubernetes.io/hostname" ||
+			(strings.HasPrefix(key, "eks.amazonaws.com/") && key != "eks.amazonaws.com/compute-type")
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestImageVersion(t *testing.T) {
+	for _, tt := range []struct {
+		image    string
+		expected string
+	}{
+		{image: "otel/opentelemetry-collector-contrib:0.88.0", expected: "0.88.0"},
+		{image: "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:v0.77.0", expected: "0.77.0"},
+		{image: "registry.local:5000/otelcol:0.60.1@sha256:4bb2a2c5bd2c4e5a8c0c5bd2c4e5a8c04bb2a2c5bd2c4e5a8c0c5bd2c4e5a8c0", expected: "0.60.1"},
+		{image: "registry.local:5000/otelcol"},
+		{image: "otel/opentelemetry-collector:latest"},
+		{image: ""},
+	} {
+		t.Run(tt.image, func(t *testing.T) {
+			version := ImageVersion(tt.image)
+			if tt.expected == "" {
+				assert.Nil(t, version)
+				return
+			}
+			assert.Equal(t, tt.expected, version.String())
+		})
+	}
+}
+
+func TestVersionWarnings(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		spec     OpenTelemetryCollectorSpec
+		expected []string
+	}{
+		{
+			name: "default images",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:            true,
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+				},
+			},
+		},
+		{
+			name: "recent target allocator",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:            true,
+					Image:              "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.77.0",
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+					FilterStrategy:     "relabel-config",
+				},
+			},
+		},
+		{
+			name: "old target allocator",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:            true,
+					Image:              "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.62.0",
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+					FilterStrategy:     "relabel-config",
+				},
+			},
+			expected: []string{
+				"the OpenTelemetry TargetAllocator version 0.62.0 doesn't support the attribute 'targetAllocator.filterStrategy', which requires version 0.64.1 or later",
+			},
+		},
+		{
+			name: "disabled target allocator",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Image:          "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.62.0",
+					FilterStrategy: "relabel-config",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{Spec: tt.spec}
+			assert.Equal(t, tt.expected, []string(otelcol.versionWarnings()))
+		})
+	}
+}
+
+func TestVersionErrorSelfTelemetry(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		image    string
+		signals  []SelfTelemetrySignal
+		expected string
+	}{
+		{
+			name:  "recent collector",
+			image: "otel/opentelemetry-collector-contrib:0.108.0",
+		},
+		{
+			name:     "old collector",
+			image:    "otel/opentelemetry-collector-contrib:0.77.0",
+			expected: "the OpenTelemetry Collector version 0.77.0 doesn't support the attribute 'selfTelemetry.otlp', which requires version 0.88.0 or later",
+		},
+		{
+			name:     "collector without the log processors",
+			image:    "otel/opentelemetry-collector-contrib:0.90.0",
+			signals:  []SelfTelemetrySignal{SelfTelemetrySignalMetrics, SelfTelemetrySignalLogs},
+			expected: "the OpenTelemetry Collector version 0.90.0 doesn't support the attribute 'selfTelemetry.otlp.signals: [logs]', which requires version 0.108.0 or later",
+		},
+		{
+			name:    "collector with the metric readers",
+			image:   "otel/opentelemetry-collector-contrib:0.90.0",
+			signals: []SelfTelemetrySignal{SelfTelemetrySignalMetrics},
+		},
+		{
+			name:  "collector without version",
+			image: "otel/opentelemetry-collector-contrib:latest",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Image: tt.image,
+					SelfTelemetry: SelfTelemetrySpec{
+						OTLP: &SelfTelemetryOTLPSpec{Endpoint: "http://otel-gateway:4318", Signals: tt.signals},
+					},
+				},
+			}
+			// the collector doesn't start with the settings it doesn't support, so the instance is rejected
+			assert.Empty(t, otelcol.versionWarnings())
+			err := otelcol.versionError()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVersionErrorDefaultCollectorImage(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			SelfTelemetry: SelfTelemetrySpec{
+				OTLP: &SelfTelemetryOTLPSpec{Endpoint: "http://otel-gateway:4318", Signals: []SelfTelemetrySignal{SelfTelemetrySignalMetrics}},
+			},
+		},
+	}
+	assert.NoError(t, otelcol.versionError())
+
+	SetDefaultCollectorImage("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.77.0")
+	t.Cleanup(func() {
+		SetDefaultCollectorImage("")
+	})
+	assert.EqualError(t, otelcol.versionError(), "the OpenTelemetry Collector version 0.77.0 doesn't support the attribute 'selfTelemetry.otlp', which requires version 0.88.0 or later")
+	_, err := otelcol.validate()
+	assert.Error(t, err)
+
+	// the image of the instance takes precedence
+	otelcol.Spec.Image = "otel/opentelemetry-collector-contrib:0.90.0"
+	assert.NoError(t, otelcol.versionError())
+}
+
+func TestVersionWarningsConfigValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		image    string
+		mode     Mode
+		expected []string
+	}{
+		{
+			name:  "collector with the validate command",
+			image: "otel/opentelemetry-collector-contrib:0.86.0",
+		},
+		{
+			name:  "old collector",
+			image: "otel/opentelemetry-collector-contrib:0.77.0",
+			expected: []string{
+				"the OpenTelemetry Collector version 0.77.0 doesn't support the attribute 'configValidation', which requires version 0.86.0 or later",
+			},
+		},
+		{
+			name:  "sidecar",
+			image: "otel/opentelemetry-collector-contrib:0.77.0",
+			mode:  ModeSidecar,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:             tt.mode,
+					Image:            tt.image,
+					ConfigValidation: &ConfigValidationSpec{},
+				},
+			}
+			assert.Equal(t, tt.expected, []string(otelcol.versionWarnings()))
+		})
+	}
+}
+
+func TestVersionWarningsTargetAllocatorRewrite(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Image:           "otel/opentelemetry-collector-contrib:0.58.0",
+			TargetAllocator: OpenTelemetryTargetAllocator{Enabled: true},
+		},
+	}
+	assert.Empty(t, otelcol.versionWarnings())
+
+	err := colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRewrite.ID(), true)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRewrite.ID(), false)
+	})
+
+	assert.Equal(t, []string{
+		"the OpenTelemetry Collector version 0.58.0 doesn't support the attribute 'targetAllocator', which requires version 0.61.0 or later",
+	}, []string(otelcol.versionWarnings()))
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/lint"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
+)
+
+// unknownConfigKeys returns the paths of the keys of the collector configuration that aren't known, in the top-level
+// sections and the service of the configuration, and in the Prometheus configuration and the target_allocator section
+// of the Prometheus receivers.
+func unknownConfigKeys(config map[string]interface{}) []string {
+	unknown := adapters.ConfigToUnknownKeys(config)
+
+	receivers, _ := config["receivers"].(map[string]interface{})
+	for _, name := range sortedKeys(receivers) {
+		if receiverType, _, _ := strings.Cut(name, "/"); receiverType != "prometheus" {
+			continue
+		}
+		prometheus, ok := receivers[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range ta.PromConfigToUnknownKeys(prometheus) {
+			unknown = append(unknown, fmt.Sprintf("receivers.%s.%s", name, key))
+		}
+	}
+	return unknown
+}
+
+// configWarnings returns the warnings for the unknown keys of the collector configuration, or an error listing them
+// when the operator.collector.strictconfig feature gate is enabled, and for the settings of the components that don't
+// match their schemas. Configurations that can't be parsed have no warnings, their errors are reported by the other
+// validations.
+func (r *OpenTelemetryCollector) configWarnings() (admission.Warnings, error) {
+	config, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return nil, nil
+	}
+
+	unknown := unknownConfigKeys(config)
+	if len(unknown) > 0 && featuregate.EnableStrictConfig.IsEnabled() {
+		return nil, fmt.Errorf("the OpenTelemetry Collector config has unknown keys: %s", strings.Join(unknown, ", "))
+	}
+
+	var warnings admission.Warnings
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("the OpenTelemetry Collector config has the unknown key '%s'", key))
+	}
+	return append(warnings, lint.Config(config)...), nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
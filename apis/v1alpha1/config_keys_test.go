@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestUnknownConfigKeys(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "known keys",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+    target_allocator:
+      endpoint: http://test-targetallocator:80
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [logging]
+`,
+		},
+		{
+			name: "unknown keys",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_config:
+        job_name: otel-collector
+  prometheus/other:
+    target_allocator:
+      endpoint: http://test-targetallocator:80
+      collectorId: ${POD_NAME}
+  otlp:
+    config:
+      scrape_config:
+exporter:
+  logging:
+service:
+  pipeline:
+`,
+			expected: []string{
+				"exporter",
+				"service.pipeline",
+				"receivers.prometheus.config.scrape_config",
+				"receivers.prometheus/other.target_allocator.collectorId",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, unknownConfigKeys(config))
+		})
+	}
+}
+
+func TestConfigWarnings(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_config:
+        job_name: otel-collector
+processors:
+  batch:
+    timeout: 5
+`,
+		},
+	}
+
+	warnings, err := otelcol.configWarnings()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"the OpenTelemetry Collector config has the unknown key 'receivers.prometheus.config.scrape_config'",
+		"the OpenTelemetry Collector config setting 'processors.batch.timeout' is set to '5', which is a number of nanoseconds rather than a duration like 200ms or 10s",
+	}, []string(warnings))
+
+	err = colfeaturegate.GlobalRegistry().Set(featuregate.EnableStrictConfig.ID(), true)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableStrictConfig.ID(), false)
+	})
+
+	warnings, err = otelcol.configWarnings()
+	assert.Empty(t, warnings)
+	assert.EqualError(t, err, "the OpenTelemetry Collector config has unknown keys: receivers.prometheus.config.scrape_config")
+
+	otelcol.Spec.Config = "🦄"
+	warnings, err = otelcol.configWarnings()
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
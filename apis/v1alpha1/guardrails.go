@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// The processors the guardrails preset adds to the configuration.
+const (
+	GuardrailsMemoryLimiter = "memory_limiter/guardrails"
+	GuardrailsFilter        = "filter/guardrails"
+	GuardrailsSampler       = "probabilistic_sampler/guardrails"
+	GuardrailsBatch         = "batch/guardrails"
+)
+
+const (
+	defaultGuardrailsMemoryLimitPercentage      = 80
+	defaultGuardrailsMemorySpikeLimitPercentage = 25
+)
+
+// GuardrailsProcessors are the processors the guardrails preset adds to the configuration.
+var GuardrailsProcessors = []string{GuardrailsMemoryLimiter, GuardrailsFilter, GuardrailsSampler, GuardrailsBatch}
+
+// EffectiveMemoryLimitPercentage returns the limit_percentage of the memory_limiter of the guardrails preset.
+func (s GuardrailsSpec) EffectiveMemoryLimitPercentage() int32 {
+	if s.MemoryLimitPercentage != nil {
+		return *s.MemoryLimitPercentage
+	}
+	return defaultGuardrailsMemoryLimitPercentage
+}
+
+// EffectiveMemorySpikeLimitPercentage returns the spike_limit_percentage of the memory_limiter of the guardrails
+// preset.
+func (s GuardrailsSpec) EffectiveMemorySpikeLimitPercentage() int32 {
+	if s.MemorySpikeLimitPercentage != nil {
+		return *s.MemorySpikeLimitPercentage
+	}
+	return defaultGuardrailsMemorySpikeLimitPercentage
+}
@@ -46,3 +46,20 @@ const (
 	// and re-encrypt using a new certificate.
 	TLSRouteTerminationTypeReencrypt TLSRouteTerminationType = "reencrypt"
 )
+
+type (
+	// IngressSignal is a signal whose receivers are exposed by the ingress.
+	// +kubebuilder:validation:Enum=traces;metrics;logs;profiles
+	IngressSignal string
+)
+
+const (
+	// IngressSignalTraces exposes the receivers of the traces pipelines.
+	IngressSignalTraces IngressSignal = "traces"
+	// IngressSignalMetrics exposes the receivers of the metrics pipelines.
+	IngressSignalMetrics IngressSignal = "metrics"
+	// IngressSignalLogs exposes the receivers of the logs pipelines.
+	IngressSignalLogs IngressSignal = "logs"
+	// IngressSignalProfiles exposes the receivers of the profiles pipelines.
+	IngressSignalProfiles IngressSignal = "profiles"
+)
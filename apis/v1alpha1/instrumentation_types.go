@@ -45,6 +45,20 @@ type InstrumentationSpec struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Proxy defines the proxy the instrumented containers export their telemetry through. The SDKs read it from the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables, which apply to all the traffic of the containers, so
+	// the proxy of the operator isn't set on the instrumented containers, only this one.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// LegacyAgents point the SDKs of other vendors, still used by some applications, to the host of the exporter
+	// endpoint by setting the environment variables they read the address of their agent from, so that their data
+	// goes through the collector as well.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	LegacyAgents []LegacyAgent `json:"legacyAgents,omitempty"`
+
 	// Java defines configuration for java auto-instrumentation.
 	// +optional
 	Java Java `json:"java,omitempty"`
@@ -86,9 +100,16 @@ type Resource struct {
 	AddK8sUIDAttributes bool `json:"addK8sUIDAttributes,omitempty"`
 }
 
+// ExporterEndpointAuto is the endpoint resolving to the collector sidecar of each pod, or to the collector of the
+// daemonset running on the pod's node otherwise.
+const ExporterEndpointAuto = "auto"
+
 // Exporter defines OTLP exporter configuration.
 type Exporter struct {
 	// Endpoint is address of the collector with OTLP endpoint.
+	// When set to auto, the instrumented pods send their data to their collector sidecar, or else to the collector of
+	// the daemonset running on their node, reached on the node's IP, on the default OTLP port of the protocol of
+	// each language. Without either, the endpoint is left to the default of the SDKs.
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
 }
@@ -210,6 +210,10 @@ func (r *Instrumentation) validate() error {
 	case AlwaysOn, AlwaysOff, JaegerRemote, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
 	}
 
+	if len(r.Spec.LegacyAgents) > 0 && len(r.Spec.Endpoint) == 0 {
+		return fmt.Errorf("spec.legacyAgents requires spec.exporter.endpoint, whose host the agents' environment variables point to")
+	}
+
 	// validate env vars
 	if err := r.validateEnv(r.Spec.Env); err != nil {
 		return err
@@ -92,6 +92,24 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "legacy agents without endpoint",
+			err:  "spec.legacyAgents requires spec.exporter.endpoint",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					LegacyAgents: []LegacyAgent{{Type: LegacyAgentJaeger}},
+				},
+			},
+		},
+		{
+			name: "legacy agents with endpoint",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter:     Exporter{Endpoint: ExporterEndpointAuto},
+					LegacyAgents: []LegacyAgent{{Type: LegacyAgentJaeger}},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// IstioMTLSMode represents the mutual TLS mode of the PeerAuthentication of the collector's receiver ports.
+	// +kubebuilder:validation:Enum=PERMISSIVE;STRICT
+	IstioMTLSMode string
+)
+
+const (
+	// IstioMTLSModePermissive specifies that the receiver ports accept both mutual TLS and plaintext traffic.
+	IstioMTLSModePermissive IstioMTLSMode = "PERMISSIVE"
+	// IstioMTLSModeStrict specifies that the receiver ports only accept mutual TLS traffic.
+	IstioMTLSModeStrict IstioMTLSMode = "STRICT"
+)
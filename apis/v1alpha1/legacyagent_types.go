@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// LegacyAgentType is the vendor of the agent the SDKs of the applications send their data to.
+	// +kubebuilder:validation:Enum=jaeger;datadog
+	LegacyAgentType string
+)
+
+const (
+	// LegacyAgentJaeger points the Jaeger SDKs to the collector, with the JAEGER_AGENT_HOST and JAEGER_AGENT_PORT
+	// environment variables.
+	LegacyAgentJaeger LegacyAgentType = "jaeger"
+	// LegacyAgentDatadog points the Datadog tracers to the collector, with the DD_AGENT_HOST and DD_TRACE_AGENT_PORT
+	// environment variables.
+	LegacyAgentDatadog LegacyAgentType = "datadog"
+)
+
+// LegacyAgent points the SDKs of a vendor to the collector the instrumented containers export their data to, in place
+// of the vendor's agent.
+type LegacyAgent struct {
+	// Type is the vendor of the agent.
+	// +required
+	Type LegacyAgentType `json:"type"`
+
+	// Port is the port of the collector's receiver for the data of the vendor's SDKs, 6831 for jaeger and 8126 for
+	// datadog by default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+}
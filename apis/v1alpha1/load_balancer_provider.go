@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// LoadBalancerProvider represents the cloud provider of the load balancers exposing the collector.
+	// +kubebuilder:validation:Enum=aws;gcp
+	LoadBalancerProvider string
+)
+
+const (
+	// LoadBalancerProviderAWS specifies that the collector is exposed by AWS network or classic load balancers.
+	LoadBalancerProviderAWS LoadBalancerProvider = "aws"
+	// LoadBalancerProviderGCP specifies that the collector is exposed by Google Cloud load balancers.
+	LoadBalancerProviderGCP LoadBalancerProvider = "gcp"
+)
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// HashedNamesAnnotation marks the instances whose generated objects have names ending with a hash when they're longer
+// than 63 characters. The instances are marked as they're created, the objects of the ones created before keeping the
+// names they were created with.
+const HashedNamesAnnotation = "opentelemetry.io/hashed-names"
+
+// BaseName returns the name the names of the objects generated for the instance are based on, its name unless it's
+// overridden.
+func (r *OpenTelemetryCollector) BaseName() string {
+	if len(r.Spec.NameOverride) > 0 {
+		return r.Spec.NameOverride
+	}
+	return r.Name
+}
+
+// HashedNames returns whether the names of the objects generated for the instance end with a hash when they're longer
+// than 63 characters, instead of only being truncated.
+func (r *OpenTelemetryCollector) HashedNames() bool {
+	return r.Annotations[HashedNamesAnnotation] == "true"
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// TestOTELColCRDValidationRules evaluates the CEL rules of the CRD, which the API server enforces from Kubernetes 1.25,
+// and checks that the webhook, which enforces them on the older versions, rejects the same specs.
+func TestOTELColCRDValidationRules(t *testing.T) {
+	structural := collectorCRDSchema(t)
+	validator := cel.NewValidator(structural, true, celconfig.PerCallLimit)
+	require.NotNil(t, validator)
+
+	one, two, three := int32(1), int32(2), int32(3)
+	for _, tt := range []struct {
+		desc        string
+		spec        OpenTelemetryCollectorSpec
+		expectedErr string
+	}{
+		{
+			desc: "no replicas",
+		},
+		{
+			desc: "replicas within the autoscaler bounds",
+			spec: OpenTelemetryCollectorSpec{
+				Replicas:   &two,
+				Autoscaler: &AutoscalerSpec{MinReplicas: &one, MaxReplicas: &three},
+			},
+		},
+		{
+			desc: "replicas greater than maxReplicas",
+			spec: OpenTelemetryCollectorSpec{
+				Replicas:   &three,
+				Autoscaler: &AutoscalerSpec{MaxReplicas: &two},
+			},
+			expectedErr: "replicas must not be greater than",
+		},
+		{
+			desc: "minReplicas greater than maxReplicas",
+			spec: OpenTelemetryCollectorSpec{
+				Autoscaler: &AutoscalerSpec{MinReplicas: &three, MaxReplicas: &two},
+			},
+			expectedErr: "minReplicas must not be greater than maxReplicas",
+		},
+		{
+			desc: "ports within the range",
+			spec: OpenTelemetryCollectorSpec{
+				Ports: []v1.ServicePort{{Name: "custom", Port: 65535}},
+			},
+		},
+		{
+			desc: "port out of the range",
+			spec: OpenTelemetryCollectorSpec{
+				Ports: []v1.ServicePort{{Name: "custom", Port: 65536}},
+			},
+			expectedErr: "must be between 1 and 65535",
+		},
+		{
+			desc: "port zero",
+			spec: OpenTelemetryCollectorSpec{
+				Ports: []v1.ServicePort{{Name: "custom"}},
+			},
+			expectedErr: "must be between 1 and 65535",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := &OpenTelemetryCollector{Spec: tt.spec}
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(otelcol)
+			require.NoError(t, err)
+
+			errs, _ := validator.Validate(context.Background(), field.NewPath(""), structural, obj, nil, celconfig.RuntimeCELCostBudget)
+			webhookErr := otelcol.validateCRDSpec()
+			if len(tt.expectedErr) == 0 {
+				assert.Empty(t, errs)
+				assert.NoError(t, webhookErr)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.expectedErr)
+			assert.ErrorContains(t, webhookErr, tt.expectedErr)
+		})
+	}
+}
+
+func collectorCRDSchema(t *testing.T) *schema.Structural {
+	data, err := os.ReadFile("../../config/crd/bases/opentelemetry.io_opentelemetrycollectors.yaml")
+	require.NoError(t, err)
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(data, crd))
+	require.Len(t, crd.Spec.Versions, 1)
+
+	props := &apiextensions.JSONSchemaProps{}
+	require.NoError(t, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(crd.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil))
+	structural, err := schema.NewStructural(props)
+	require.NoError(t, err)
+	return structural
+}
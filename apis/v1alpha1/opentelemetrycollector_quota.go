@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// CollectorQuota limits the OpenTelemetryCollector instances each namespace may have. The zero values don't limit.
+// +kubebuilder:object:generate=false
+type CollectorQuota struct {
+	// MaxInstances is the number of instances of a namespace.
+	MaxInstances int
+	// MaxReplicas is the total of the replicas of the deployment and statefulset instances of a namespace, counting
+	// the maximum replicas of the autoscaled ones.
+	MaxReplicas int32
+	// MaxRequests are the totals of the resource requests of the collector pods of the deployment and statefulset
+	// instances of a namespace, by resource, counting the maximum replicas of the autoscaled ones.
+	MaxRequests corev1.ResourceList
+}
+
+// Enabled returns whether the quota limits anything.
+func (q CollectorQuota) Enabled() bool {
+	return q.MaxInstances > 0 || q.MaxReplicas > 0 || len(q.MaxRequests) > 0
+}
+
+// namespaceValidator validates the collectors like their own webhook.Validator does, along with the rules spanning the
+// instances of their namespace on the creates and updates: the names of their generated objects are unique, and the
+// quota of the namespace, when enabled, is enforced.
+type namespaceValidator struct {
+	client client.Reader
+	quota  CollectorQuota
+}
+
+var _ webhook.CustomValidator = &namespaceValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *namespaceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	otelcol, ok := obj.(*OpenTelemetryCollector)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenTelemetryCollector, got %T", obj)
+	}
+	warnings, err := otelcol.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validateNames(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	if !v.quota.Enabled() {
+		return warnings, nil
+	}
+	return warnings, v.validateQuota(ctx, otelcol)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *namespaceValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	otelcol, ok := newObj.(*OpenTelemetryCollector)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenTelemetryCollector, got %T", newObj)
+	}
+	warnings, err := otelcol.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	// the instances being deleted, e.g. having their finalizers removed, are let through
+	if otelcol.DeletionTimestamp != nil {
+		return warnings, nil
+	}
+	old, ok := oldObj.(*OpenTelemetryCollector)
+	if !ok || old.BaseName() != otelcol.BaseName() {
+		if err := v.validateNames(ctx, otelcol); err != nil {
+			return warnings, err
+		}
+	}
+	// the updates not growing the usage of the namespace, like scaling down an instance of a namespace over its quota,
+	// are let through
+	if !v.quota.Enabled() || (ok && !v.increasesUsage(*old, *otelcol)) {
+		return warnings, nil
+	}
+	return warnings, v.validateQuota(ctx, otelcol)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *namespaceValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	otelcol, ok := obj.(*OpenTelemetryCollector)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenTelemetryCollector, got %T", obj)
+	}
+	return otelcol.ValidateDelete()
+}
+
+// validateNames checks that the objects generated for the given instance, as it's created or updated, aren't named after
+// the same name as the ones of another instance of its namespace, their name override or their own name.
+func (v *namespaceValidator) validateNames(ctx context.Context, otelcol *OpenTelemetryCollector) error {
+	list := &OpenTelemetryCollectorList{}
+	if err := v.client.List(ctx, list, client.InNamespace(otelcol.Namespace)); err != nil {
+		return fmt.Errorf("the names of the objects of the OpenTelemetry Collector can't be checked, %w", err)
+	}
+	for _, existing := range list.Items {
+		if existing.Name != otelcol.Name && existing.DeletionTimestamp == nil && existing.BaseName() == otelcol.BaseName() {
+			return fmt.Errorf("the OpenTelemetry Spec NameOverride configuration is incorrect, the objects of the instance %s are already named after '%s'", existing.Name, otelcol.BaseName())
+		}
+	}
+	return nil
+}
+
+// validateQuota checks that the namespace of the given instance stays within the quota with the instance, as it's
+// created or updated, along with the other instances of the namespace.
+func (v *namespaceValidator) validateQuota(ctx context.Context, otelcol *OpenTelemetryCollector) error {
+	list := &OpenTelemetryCollectorList{}
+	if err := v.client.List(ctx, list, client.InNamespace(otelcol.Namespace)); err != nil {
+		return fmt.Errorf("the OpenTelemetry Collector quota of the namespace %s can't be checked, %w", otelcol.Namespace, err)
+	}
+	instances := []OpenTelemetryCollector{*otelcol}
+	for _, existing := range list.Items {
+		if existing.Name != otelcol.Name && existing.DeletionTimestamp == nil {
+			instances = append(instances, existing)
+		}
+	}
+
+	if v.quota.MaxInstances > 0 && len(instances) > v.quota.MaxInstances {
+		return fmt.Errorf("the OpenTelemetry Collector quota of the namespace %s is exceeded, it allows %d instances", otelcol.Namespace, v.quota.MaxInstances)
+	}
+
+	var replicas int32
+	requests := corev1.ResourceList{}
+	for _, instance := range instances {
+		replicas += quotaReplicas(instance)
+		for name, request := range v.quotaRequests(instance) {
+			total := requests[name]
+			total.Add(request)
+			requests[name] = total
+		}
+	}
+
+	if v.quota.MaxReplicas > 0 && replicas > v.quota.MaxReplicas {
+		return fmt.Errorf("the OpenTelemetry Collector quota of the namespace %s is exceeded, the instances would have %d replicas while it allows %d", otelcol.Namespace, replicas, v.quota.MaxReplicas)
+	}
+	names := make([]string, 0, len(v.quota.MaxRequests))
+	for name := range v.quota.MaxRequests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		maxRequest := v.quota.MaxRequests[corev1.ResourceName(name)]
+		if total := requests[corev1.ResourceName(name)]; total.Cmp(maxRequest) > 0 {
+			return fmt.Errorf("the OpenTelemetry Collector quota of the namespace %s is exceeded, the instances would request %s %s while it allows %s", otelcol.Namespace, total.String(), name, maxRequest.String())
+		}
+	}
+	return nil
+}
+
+// increasesUsage returns whether the given update of an instance increases its replicas or the requests limited by the
+// quota.
+func (v *namespaceValidator) increasesUsage(old, updated OpenTelemetryCollector) bool {
+	if quotaReplicas(updated) > quotaReplicas(old) {
+		return true
+	}
+	oldRequests := v.quotaRequests(old)
+	for name, request := range v.quotaRequests(updated) {
+		oldRequest := oldRequests[name]
+		if request.Cmp(oldRequest) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaRequests returns the totals of the requests of the pods of the given instance, for the resources limited by
+// the quota.
+func (v *namespaceValidator) quotaRequests(otelcol OpenTelemetryCollector) corev1.ResourceList {
+	replicas := quotaReplicas(otelcol)
+	requests := corev1.ResourceList{}
+	for name := range v.quota.MaxRequests {
+		request, ok := otelcol.Spec.Resources.Requests[name]
+		if !ok {
+			// the requests of the containers default to their limits
+			request, ok = otelcol.Spec.Resources.Limits[name]
+		}
+		if !ok {
+			continue
+		}
+		requests[name] = *resource.NewMilliQuantity(request.MilliValue()*int64(replicas), request.Format)
+	}
+	return requests
+}
+
+// quotaReplicas returns the replicas the given instance counts for in the quota: the maximum replicas it can be
+// scaled to in the deployment and statefulset modes, and none for the daemonsets, sidecars and hibernated instances.
+func quotaReplicas(otelcol OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Hibernate || (otelcol.Spec.Mode != ModeDeployment && otelcol.Spec.Mode != ModeStatefulSet) {
+		return 0
+	}
+	replicas := int32(1)
+	if otelcol.Spec.Replicas != nil {
+		replicas = *otelcol.Spec.Replicas
+	}
+	if otelcol.Spec.MaxReplicas != nil && *otelcol.Spec.MaxReplicas > replicas {
+		replicas = *otelcol.Spec.MaxReplicas
+	}
+	if autoscaler := otelcol.Spec.Autoscaler; autoscaler != nil {
+		if autoscaler.MaxReplicas != nil && *autoscaler.MaxReplicas > replicas {
+			replicas = *autoscaler.MaxReplicas
+		}
+		for _, schedule := range autoscaler.Schedules {
+			if schedule.MaxReplicas != nil && *schedule.MaxReplicas > replicas {
+				replicas = *schedule.MaxReplicas
+			}
+		}
+	}
+	return replicas
+}
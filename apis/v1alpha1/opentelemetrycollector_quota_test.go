@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func quotaInstance(name string, mode Mode, replicas int32, cpu string) *OpenTelemetryCollector {
+	return &OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "tenant",
+		},
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:     mode,
+			Replicas: &replicas,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		},
+	}
+}
+
+func TestQuotaValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	twenty := int32(20)
+	existing := []client.Object{
+		quotaInstance("gateway", ModeDeployment, 3, "500m"),
+		quotaInstance("agent", ModeDaemonSet, 1, "1"),
+		func() client.Object {
+			other := quotaInstance("other", ModeDeployment, 50, "1")
+			other.Namespace = "other-tenant"
+			return other
+		}(),
+	}
+
+	for _, tt := range []struct {
+		name        string
+		quota       CollectorQuota
+		otelcol     *OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:    "within the quota",
+			quota:   CollectorQuota{MaxInstances: 3, MaxReplicas: 5, MaxRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3500m")}},
+			otelcol: quotaInstance("new", ModeStatefulSet, 2, "1"),
+		},
+		{
+			name:        "too many instances",
+			quota:       CollectorQuota{MaxInstances: 2},
+			otelcol:     quotaInstance("new", ModeSidecar, 1, "100m"),
+			expectedErr: "the OpenTelemetry Collector quota of the namespace tenant is exceeded, it allows 2 instances",
+		},
+		{
+			name:    "updated instance",
+			quota:   CollectorQuota{MaxInstances: 2, MaxReplicas: 4},
+			otelcol: quotaInstance("gateway", ModeDeployment, 4, "500m"),
+		},
+		{
+			name:        "too many replicas",
+			quota:       CollectorQuota{MaxReplicas: 10},
+			otelcol:     quotaInstance("new", ModeDeployment, 8, "100m"),
+			expectedErr: "the instances would have 11 replicas while it allows 10",
+		},
+		{
+			name:  "too many autoscaled replicas",
+			quota: CollectorQuota{MaxReplicas: 10},
+			otelcol: func() *OpenTelemetryCollector {
+				otelcol := quotaInstance("new", ModeDeployment, 1, "100m")
+				otelcol.Spec.Autoscaler = &AutoscalerSpec{MaxReplicas: &twenty}
+				return otelcol
+			}(),
+			expectedErr: "the instances would have 23 replicas while it allows 10",
+		},
+		{
+			name:  "hibernated instance",
+			quota: CollectorQuota{MaxReplicas: 5},
+			otelcol: func() *OpenTelemetryCollector {
+				otelcol := quotaInstance("new", ModeDeployment, 60, "1")
+				otelcol.Spec.Hibernate = true
+				return otelcol
+			}(),
+		},
+		{
+			name:        "too many requests",
+			quota:       CollectorQuota{MaxRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+			otelcol:     quotaInstance("new", ModeDeployment, 2, "300m"),
+			expectedErr: "the instances would request 2100m cpu while it allows 2",
+		},
+		{
+			name:  "requests defaulting to the limits",
+			quota: CollectorQuota{MaxRequests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+			otelcol: func() *OpenTelemetryCollector {
+				otelcol := quotaInstance("new", ModeDeployment, 2, "100m")
+				otelcol.Spec.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+				return otelcol
+			}(),
+			expectedErr: "the instances would request 2Gi memory while it allows 1Gi",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &namespaceValidator{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build(),
+				quota:  tt.quota,
+			}
+
+			_, err := validator.ValidateCreate(context.Background(), tt.otelcol)
+
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestQuotaValidatorUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	// the namespace is already over its quota, e.g. the quota was lowered after the instances were created
+	existing := []client.Object{
+		quotaInstance("gateway", ModeDeployment, 4, "1"),
+		quotaInstance("agent", ModeDeployment, 4, "1"),
+	}
+	quota := CollectorQuota{MaxReplicas: 6, MaxRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")}}
+
+	for _, tt := range []struct {
+		name        string
+		updated     func(otelcol *OpenTelemetryCollector)
+		expectedErr string
+	}{
+		{
+			name: "scaled down",
+			updated: func(otelcol *OpenTelemetryCollector) {
+				replicas := int32(3)
+				otelcol.Spec.Replicas = &replicas
+			},
+		},
+		{
+			name: "unchanged usage",
+			updated: func(otelcol *OpenTelemetryCollector) {
+				otelcol.Spec.Config = "receivers:\n  otlp:\n"
+			},
+		},
+		{
+			name: "being deleted",
+			updated: func(otelcol *OpenTelemetryCollector) {
+				now := metav1.Now()
+				otelcol.DeletionTimestamp = &now
+				otelcol.Finalizers = nil
+			},
+		},
+		{
+			name: "scaled up",
+			updated: func(otelcol *OpenTelemetryCollector) {
+				replicas := int32(5)
+				otelcol.Spec.Replicas = &replicas
+			},
+			expectedErr: "the instances would have 9 replicas while it allows 6",
+		},
+		{
+			name: "more requests",
+			updated: func(otelcol *OpenTelemetryCollector) {
+				otelcol.Spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+			},
+			expectedErr: "the instances would have 8 replicas while it allows 6",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &namespaceValidator{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build(),
+				quota:  quota,
+			}
+			old := quotaInstance("gateway", ModeDeployment, 4, "1")
+			updated := old.DeepCopy()
+			tt.updated(updated)
+
+			_, err := validator.ValidateUpdate(context.Background(), old, updated)
+
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNamespaceValidatorNames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	overridden := quotaInstance("gateway", ModeDeployment, 1, "100m")
+	overridden.Spec.NameOverride = "otel"
+	existing := []client.Object{
+		overridden,
+		quotaInstance("agent", ModeDaemonSet, 1, "100m"),
+		func() client.Object {
+			other := quotaInstance("other", ModeDeployment, 1, "100m")
+			other.Namespace = "other-tenant"
+			other.Spec.NameOverride = "shared"
+			return other
+		}(),
+	}
+	instance := func(name, nameOverride string) *OpenTelemetryCollector {
+		otelcol := quotaInstance(name, ModeDeployment, 1, "100m")
+		otelcol.Spec.NameOverride = nameOverride
+		return otelcol
+	}
+
+	for _, tt := range []struct {
+		name        string
+		otelcol     *OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:    "unique name override",
+			otelcol: instance("new", "payments"),
+		},
+		{
+			name:    "name override of another namespace",
+			otelcol: instance("new", "shared"),
+		},
+		{
+			name:    "own name override",
+			otelcol: instance("gateway", "otel"),
+		},
+		{
+			name:        "name override of another instance",
+			otelcol:     instance("new", "otel"),
+			expectedErr: "the objects of the instance gateway are already named after 'otel'",
+		},
+		{
+			name:        "name override being the name of another instance",
+			otelcol:     instance("new", "agent"),
+			expectedErr: "the objects of the instance agent are already named after 'agent'",
+		},
+		{
+			name:        "name being the name override of another instance",
+			otelcol:     instance("otel", ""),
+			expectedErr: "the objects of the instance gateway are already named after 'otel'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &namespaceValidator{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build(),
+			}
+
+			_, err := validator.ValidateCreate(context.Background(), tt.otelcol)
+
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -55,12 +56,46 @@ type Ingress struct {
 	// +optional
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// Ports overrides the host and path of the ports of the collector, by port name. The ports with annotations are
+	// exposed by an ingress of their own, whose annotations are the ones of the ingress merged with the ones of the
+	// port, e.g. to set the backend protocol of a gRPC port.
+	// Only considered when type "ingress" is used.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Ports []IngressPort `json:"ports,omitempty"`
+
+	// Signals limits the ports exposed by the ingress or the route to the ports of the receivers of the pipelines of
+	// these signals, e.g. [traces] to keep the metrics receivers reachable from within the cluster only. The ports
+	// listed in Ports are exposed regardless. All the ports are exposed by default.
+	// +optional
+	Signals []IngressSignal `json:"signals,omitempty"`
+
 	// Route is an OpenShift specific section that is only considered when
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
 }
 
+// IngressPort defines how a port of the collector is exposed by the ingress.
+type IngressPort struct {
+	// Name of the port, e.g. otlp-grpc.
+	Name string `json:"name"`
+
+	// Hostname by which the port can be reached. The hostname of the ingress by default.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Path by which the port can be reached, e.g. "/" to route the requests of a host to the port.
+	// The name of the port prefixed by "/" by default, or "/" for the gRPC ports.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Annotations to add to the ingress of the port, in addition to the annotations of the ingress.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 // OpenShiftRoute defines openshift route specific settings.
 type OpenShiftRoute struct {
 	// Termination indicates termination type. By default "edge" is used.
@@ -68,6 +103,7 @@ type OpenShiftRoute struct {
 }
 
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
+// +kubebuilder:validation:XValidation:rule="!has(self.replicas) || !has(self.autoscaler) || !has(self.autoscaler.maxReplicas) || self.replicas <= self.autoscaler.maxReplicas",message="replicas must not be greater than autoscaler.maxReplicas"
 type OpenTelemetryCollectorSpec struct {
 	// Resources to set on the OpenTelemetry Collector pods.
 	// +optional
@@ -81,20 +117,49 @@ type OpenTelemetryCollectorSpec struct {
 	Args map[string]string `json:"args,omitempty"`
 	// Replicas is the number of pod instances for the underlying OpenTelemetry Collector. Set this if your are not using autoscaling
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	Replicas *int32 `json:"replicas,omitempty"`
+	// Hibernate scales the workloads of the collector and of its TargetAllocator to zero, and removes the DaemonSet
+	// of the daemonset mode, while keeping the other objects, like the ConfigMaps and the Services, unlike deleting
+	// the instance. The Paused condition reports the hibernated instances. Not supported in sidecar mode.
+	// +optional
+	Hibernate bool `json:"hibernate,omitempty"`
 	// MinReplicas sets a lower bound to the autoscaling feature.  Set this if your are using autoscaling. It must be at least 1
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	// Deprecated: use "OpenTelemetryCollector.Spec.Autoscaler.MinReplicas" instead.
 	MinReplicas *int32 `json:"minReplicas,omitempty"`
 	// MaxReplicas sets an upper bound to the autoscaling feature. If MaxReplicas is set autoscaling is enabled.
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	// Deprecated: use "OpenTelemetryCollector.Spec.Autoscaler.MaxReplicas" instead.
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Availability keeps a minimum number of collector replicas serving during the rolling updates of the collector,
+	// like the ones of the operator-driven upgrades, and during voluntary disruptions, like node drains. Only
+	// available when the mode=deployment or mode=statefulset.
+	// +optional
+	Availability *AvailabilitySpec `json:"availability,omitempty"`
 	// Autoscaler specifies the pod autoscaling configuration to use
 	// for the OpenTelemetryCollector workload.
 	//
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// VerticalAutoscaler creates a VerticalPodAutoscaler right-sizing the resources of the collector container.
+	// Requires the VerticalPodAutoscaler to be installed in the cluster. Only available when the mode=deployment,
+	// mode=daemonset or mode=statefulset.
+	// +optional
+	VerticalAutoscaler *VerticalAutoscalerSpec `json:"verticalAutoscaler,omitempty"`
+	// CompatibilityMode adapts the collector to a platform restricting the pods, like GKE Autopilot or EKS Fargate.
+	// The hostNetwork, the privileged security context and the node selectors on the labels managed by the platform
+	// are left out of the collector pods, while the modes and volumes the platform doesn't support are rejected.
+	// +optional
+	CompatibilityMode CompatibilityMode `json:"compatibilityMode,omitempty"`
+	// Istio generates the ServiceEntry letting the collector reach the external hosts of its exporters from the mesh,
+	// the Sidecar scoping the egress of the collector pods when scopeEgress is set, and the PeerAuthentication of the
+	// collector's receiver ports. Requires Istio to be installed in the cluster. Only available when the
+	// mode=deployment, mode=daemonset or mode=statefulset.
+	// +optional
+	Istio *IstioSpec `json:"istio,omitempty"`
 	// SecurityContext will be set as the container security context.
 	// +optional
 	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
@@ -107,18 +172,57 @@ type OpenTelemetryCollectorSpec struct {
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator OpenTelemetryTargetAllocator `json:"targetAllocator,omitempty"`
+	// ReceiverCreator configures a k8s_observer extension along with a receiver_creator receiver, which starts
+	// receivers for the pods and nodes observed in the cluster.
+	// +optional
+	ReceiverCreator ReceiverCreatorSpec `json:"receiverCreator,omitempty"`
+	// SelfTelemetry attributes the telemetry the collector emits about itself to its pod and node, and pushes it to an
+	// OTLP endpoint.
+	// +optional
+	SelfTelemetry SelfTelemetrySpec `json:"selfTelemetry,omitempty"`
+	// RemoteWrite adds a prometheusremotewrite exporter sending the metrics of the collector to a Prometheus remote
+	// write endpoint, with the resource attributes converted to labels and external labels identifying the collector.
+	// +optional
+	RemoteWrite *RemoteWriteSpec `json:"remoteWrite,omitempty"`
+	// Guardrails protects the collector from spikes of telemetry by adding a memory_limiter at the start and a batch
+	// processor at the end of all of its pipelines, along with the filter and the probabilistic sampler of the
+	// guardrails when they are set.
+	// +optional
+	Guardrails *GuardrailsSpec `json:"guardrails,omitempty"`
+	// NameOverride is the name the names of the objects generated for the OpenTelemetryCollector are based on,
+	// instead of its own name, e.g. to keep them short. The names longer than 63 characters are truncated and end with
+	// a hash of the whole name, so that they don't collide.
+	// +optional
+	NameOverride string `json:"nameOverride,omitempty"`
 	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
 	// +optional
+	// +kubebuilder:default=deployment
 	Mode Mode `json:"mode,omitempty"`
 	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
 	// the operator will not automatically create a ServiceAccount for the collector.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// ServiceAccountAnnotations are the annotations to set on the ServiceAccount the operator creates for the collector.
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+	// AWSIdentity gives the collector the identity of an IAM role through IAM roles for service accounts (IRSA), e.g.
+	// for the awsemf and awsxray exporters.
+	// +optional
+	AWSIdentity *AWSIdentitySpec `json:"awsIdentity,omitempty"`
+	// GCPIdentity gives the collector the identity of a Google service account through GKE Workload Identity, e.g.
+	// for the googlecloud exporter.
+	// +optional
+	GCPIdentity *GCPIdentitySpec `json:"gcpIdentity,omitempty"`
+	// AzureIdentity gives the collector the identity of an Azure AD application or managed identity through Azure
+	// Workload Identity, e.g. for the azuremonitor exporter.
+	// +optional
+	AzureIdentity *AzureIdentitySpec `json:"azureIdentity,omitempty"`
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
 	// +optional
+	// +kubebuilder:default=automatic
 	UpgradeStrategy UpgradeStrategy `json:"upgradeStrategy"`
 
 	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
@@ -127,6 +231,25 @@ type OpenTelemetryCollectorSpec struct {
 	// Config is the raw JSON to be used as the collector's configuration. Refer to the OpenTelemetry Collector documentation for details.
 	// +required
 	Config string `json:"config,omitempty"`
+	// ConfigVersions names the config maps of the collector's configuration after the hash of the configuration and
+	// keeps the given number of versions, the current one included, instead of updating the config maps in place. The
+	// collector pods only see a new configuration once they are rolled out, and reverting the configuration references
+	// its retained config maps again. Only available when the mode=deployment, mode=daemonset or mode=statefulset.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConfigVersions *int32 `json:"configVersions,omitempty"`
+	// ConfigValidation validates the rendered configuration with the validate command of the collector image in a
+	// Job before rolling it out. The collector keeps running its last known good configuration and image until the
+	// Job succeeds, and the ConfigValidated condition reports the errors of the failed validations. Requires a
+	// collector image with the validate command, v0.86.0 or later. Not available when the mode=sidecar.
+	// +optional
+	ConfigValidation *ConfigValidationSpec `json:"configValidation,omitempty"`
+	// RolloutStrategy is how the changes of the collector Deployment are rolled out. With blueGreen, a parallel
+	// Deployment is brought up with the changes, the Services are switched to it once all of its replicas are
+	// available, and the previous Deployment is then deleted. Requires configVersions of at least 2, so that the
+	// previous Deployment keeps its configuration. Only available when the mode=deployment. rollingUpdate by default.
+	// +optional
+	RolloutStrategy RolloutStrategy `json:"rolloutStrategy,omitempty"`
 	// VolumeMounts represents the mount points to use in the underlying collector deployment(s)
 	// +optional
 	// +listType=atomic
@@ -136,7 +259,16 @@ type OpenTelemetryCollectorSpec struct {
 	// used to open additional ports that can't be inferred by the operator, like for custom receivers.
 	// +optional
 	// +listType=atomic
+	// +kubebuilder:validation:XValidation:rule="self.all(p, p.port >= 1 && p.port <= 65535)",message="the port numbers must be between 1 and 65535"
 	Ports []v1.ServicePort `json:"ports,omitempty"`
+	// HostPorts binds ports of the collector container, inferred from the config or listed in the ports, to ports of
+	// the node, so that the agents and applications sending from the host, like syslog or statsd clients, reach the
+	// collector of their node at its IP without the host network. The IP of the node is set in the HOST_IP
+	// environment variable of the collector container. Only available when the mode=daemonset.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	HostPorts []HostPortSpec `json:"hostPorts,omitempty"`
 	// ENV vars to set on the OpenTelemetry Collector's Pods. These can then in certain cases be
 	// consumed in the config file for the Collector.
 	// +optional
@@ -145,6 +277,10 @@ type OpenTelemetryCollectorSpec struct {
 	// These can then in certain cases be consumed in the config file for the Collector.
 	// +optional
 	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
+	// Proxy defines the proxy the OpenTelemetry Collector and the TargetAllocator send their outgoing
+	// traffic through, overriding the proxy of the operator. An empty proxy disables the proxy of the operator.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
 	// VolumeClaimTemplates will provide stable storage using PersistentVolumes. Only available when the mode=statefulset.
 	// +optional
 	// +listType=atomic
@@ -153,18 +289,84 @@ type OpenTelemetryCollectorSpec struct {
 	// This is only relevant to daemonset, statefulset, and deployment mode
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// NodeProfiles run the collector differently on some nodes, like with additional receivers on GPU nodes or with
+	// smaller resources on spot nodes. Each profile runs as its own daemonset on the nodes matching its node
+	// selector, while the daemonset of the instance runs on the other nodes. Only available when the mode=daemonset.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NodeProfiles []NodeProfile `json:"nodeProfiles,omitempty"`
+	// SidecarTiers size the sidecar differently for some workloads, like with smaller resources and a slimmer
+	// configuration for low-traffic pods. The pods select their tier with the sidecar.opentelemetry.io/tier
+	// annotation, while the pods without it get the sidecar of the instance. Only available when the mode=sidecar.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SidecarTiers []SidecarTier `json:"sidecarTiers,omitempty"`
+	// SidecarNamespaceSelector selects the other namespaces whose pods may reference the instance by namespace/name
+	// in their sidecar.opentelemetry.io/inject annotation, like a sidecar published by a central team. The namespaces
+	// can be selected by name with the kubernetes.io/metadata.name label. Without it, only the pods of the namespace
+	// of the instance get its sidecar. Only available when the mode=sidecar.
+	// +optional
+	SidecarNamespaceSelector *metav1.LabelSelector `json:"sidecarNamespaceSelector,omitempty"`
 	// Volumes represents which volumes to use in the underlying collector deployment(s).
 	// +optional
 	// +listType=atomic
 	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// SecretProviders mount the secrets fetched by the Secrets Store CSI driver from external secret stores, like
+	// Vault or AWS Secrets Manager, in the collector containers. The configuration can refer to the secrets with
+	// ${file:/etc/otelcol/secrets/<name>/<object>}. Not available when the mode=sidecar.
+	// +optional
+	// +listType=atomic
+	SecretProviders []SecretProvider `json:"secretProviders,omitempty"`
+	// ReceiverTLS mounts the certificates of receivers from secrets, by receiver name, and sets the paths of the
+	// mounted files in the tls settings of the receivers, or of their grpc, http and thrift_http protocols. A secret
+	// missing one of the keys isn't mounted, so the collector pods don't start, and the ReceiverTLSReady condition
+	// reports it. Not available when the mode=sidecar.
+	// +optional
+	ReceiverTLS map[string]ReceiverTLSSpec `json:"receiverTLS,omitempty"`
+	// ReceiverEndpoints rewrites the endpoints of the receivers listening on localhost, 127.0.0.1 or ::1 to listen
+	// on all the interfaces or on the IP of the pod, so that they're reachable through the services. Set as the
+	// instances are created, to keep when the mode=sidecar, as the application containers send over the loopback
+	// interface, and to all-interfaces otherwise. The instances created before keep the endpoints.
+	// +optional
+	ReceiverEndpoints ReceiverEndpointsPolicy `json:"receiverEndpoints,omitempty"`
+	// ExporterTLS mounts the CA and client certificates of exporters from secrets, by exporter name, and sets the
+	// paths of the mounted files in the tls settings of the exporters. A secret missing one of the keys isn't
+	// mounted, so the collector pods don't start. Not available when the mode=sidecar.
+	// +optional
+	ExporterTLS map[string]ExporterTLSSpec `json:"exporterTLS,omitempty"`
+	// ExporterTokens projects ServiceAccount tokens bound to an audience in the collector pods, by exporter name, and
+	// authenticates the exporters with them through a bearertokenauth extension, e.g. for gateways checking the
+	// tokens with kube-rbac-proxy. Not available when the mode=sidecar.
+	// +optional
+	ExporterTokens map[string]ExporterTokenSpec `json:"exporterTokens,omitempty"`
 	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
 	// functionality is only available if one of the valid modes is set.
 	// Valid modes are: deployment, daemonset and statefulset.
 	// +optional
 	Ingress Ingress `json:"ingress,omitempty"`
+	// LoadBalancerHealthCheck exposes the port of the health_check extension on the collector's Service and points the
+	// health checks of the cloud provider load balancers to it, instead of the ports of receivers like OTLP gRPC,
+	// which don't answer the HTTP health checks of the load balancers.
+	// +optional
+	LoadBalancerHealthCheck *LoadBalancerHealthCheckSpec `json:"loadBalancerHealthCheck,omitempty"`
 	// HostNetwork indicates if the pod should run in the host networking namespace.
 	// +optional
 	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// DNSPolicy is the DNS policy of the OpenTelemetry Collector's Pods. Defaults to ClusterFirstWithHostNet
+	// when HostNetwork is set and to ClusterFirst otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// PodDNSConfig defines the DNS parameters of the OpenTelemetry Collector's Pods, merged with the
+	// configuration generated from the DNSPolicy, e.g. custom nameservers or the ndots option.
+	// +optional
+	PodDNSConfig *v1.PodDNSConfig `json:"podDnsConfig,omitempty"`
+	// ShareProcessNamespace makes the containers of the OpenTelemetry Collector's Pods share a single process
+	// namespace, so that the processes of the collector are visible from the other containers of the pod.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
 	// If specified, indicates the pod's priority.
 	// If not specified, the pod priority will be default or zero if there is no
 	// default.
@@ -176,8 +378,11 @@ type OpenTelemetryCollectorSpec struct {
 	// Actions that the management system should take in response to container lifecycle events. Cannot be updated.
 	// +optional
 	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
-	// Duration in seconds the pod needs to terminate gracefully upon probe failure.
+	// TerminationGracePeriodSeconds is the duration in seconds the OpenTelemetry Collector's pods have to terminate
+	// gracefully, e.g. for the preStop hook to run and the exporters to drain their queues. In sidecar mode, the grace
+	// period of the pod is raised to it.
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 	// Liveness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
 	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
@@ -192,6 +397,19 @@ type OpenTelemetryTargetAllocator struct {
 	// that can be run in a high availability mode is consistent-hashing.
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
+	// JobShards is the number of TargetAllocator shards the scrape jobs are partitioned across, based on the hash of
+	// their job names. Each shard runs as its own TargetAllocator deployment and only discovers and allocates the
+	// targets of the jobs it owns. Sharding is not supported when the operator.collector.rewritetargetallocator
+	// feature gate is enabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	JobShards *int32 `json:"jobShards,omitempty"`
+	// TopologyAware runs a group of collectors and a TargetAllocator in each of the given availability zones. Each
+	// TargetAllocator only discovers the targets of its zone and allocates them to the collectors of its zone, so
+	// that the targets are scraped from their zone. Not supported with the jobShards, the autoscaler or the
+	// vertical autoscaler, nor when the operator.collector.rewritetargetallocator feature gate is enabled.
+	// +optional
+	TopologyAware *TopologyAwareSpec `json:"topologyAware,omitempty"`
 	// Resources to set on the OpenTelemetryTargetAllocator containers.
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
@@ -218,6 +436,91 @@ type OpenTelemetryTargetAllocator struct {
 	// All CR instances which the ServiceAccount has access to will be retrieved. This includes other namespaces.
 	// +optional
 	PrometheusCR OpenTelemetryTargetAllocatorPrometheusCR `json:"prometheusCR,omitempty"`
+	// DNSPolicy is the DNS policy of the TargetAllocator's Pods. Defaults to ClusterFirst.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// PodDNSConfig defines the DNS parameters of the TargetAllocator's Pods, merged with the
+	// configuration generated from the DNSPolicy.
+	// +optional
+	PodDNSConfig *v1.PodDNSConfig `json:"podDnsConfig,omitempty"`
+	// ScrapeOverrides are enforced by the TargetAllocator on all the scrape configs it serves to the collectors,
+	// whatever the scrape configs, ServiceMonitors or PodMonitors they come from request, so that a single job can't
+	// overload the collectors.
+	// +optional
+	ScrapeOverrides *TargetAllocatorScrapeOverrides `json:"scrapeOverrides,omitempty"`
+	// MaxTargetsPerCollector is the maximum number of targets the TargetAllocator assigns to each collector. When all the
+	// collectors are at the limit, the other targets are left unassigned, counted by the
+	// opentelemetry_allocator_targets_unassigned metric of the TargetAllocator and reported by the TargetsAssigned
+	// condition, until collectors are added, instead of overloading the collectors during discovery storms. With job
+	// shards, the limit applies to the targets of each shard.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxTargetsPerCollector *int32 `json:"maxTargetsPerCollector,omitempty"`
+	// TargetHandoff keeps serving the targets which move from a collector to another, e.g. when the collectors are
+	// scaled with the consistent-hashing allocation strategy, to the collector they moved from for a scrape interval
+	// after the collector they moved to gets them. Both collectors scrape the targets meanwhile, so that their series
+	// don't go stale between the last scrape of the former collector and the first scrape of the new one.
+	// +optional
+	TargetHandoff bool `json:"targetHandoff,omitempty"`
+	// AllocationState persists the assignment of the targets to the collectors, restored when the TargetAllocator
+	// restarts so that the targets stay on the collectors they were assigned to.
+	// +optional
+	AllocationState *TargetAllocatorAllocationState `json:"allocationState,omitempty"`
+	// WorkItems are allocated among the collectors by the TargetAllocator besides the Prometheus targets, and served by
+	// job the same way, so that receivers other than the Prometheus one can share their work across the collectors,
+	// like SQL queries or SNMP devices to poll.
+	// +optional
+	WorkItems []TargetAllocatorWorkItem `json:"workItems,omitempty"`
+}
+
+// TargetAllocatorWorkItem defines an item of work allocated among the collectors by the TargetAllocator.
+type TargetAllocatorWorkItem struct {
+	// JobName is the name of the job the work item is served for.
+	// +kubebuilder:validation:MinLength=1
+	JobName string `json:"jobName"`
+	// Endpoint is the endpoint of the work item, e.g. the address of the database or the device to poll.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// Labels are the labels of the work item, served along with its endpoint.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// TargetAllocatorAllocationState defines where the TargetAllocator persists its allocation state.
+type TargetAllocatorAllocationState struct {
+	// PersistentVolumeClaim is the name of an existing PersistentVolumeClaim the allocation state is persisted in, which
+	// survives the TargetAllocator pods. When empty, the state is persisted in an emptyDir volume, which only survives
+	// restarts of the TargetAllocator container. It can't be used with more than one replica, jobShards or topologyAware
+	// zones.
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// TargetAllocatorScrapeOverrides defines the limits enforced on the scrape configs served by the TargetAllocator.
+type TargetAllocatorScrapeOverrides struct {
+	// MinScrapeInterval is the shortest scrape interval, the shorter ones are raised to it.
+	// +optional
+	MinScrapeInterval *metav1.Duration `json:"minScrapeInterval,omitempty"`
+	// SampleLimit is the highest number of samples per scrape, set on the scrape configs without a sample limit or
+	// with a higher one.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SampleLimit *int32 `json:"sampleLimit,omitempty"`
+	// LabelLimit is the highest number of labels per sample, set on the scrape configs without a label limit or with
+	// a higher one.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	LabelLimit *int32 `json:"labelLimit,omitempty"`
+}
+
+// TopologyAwareSpec defines the availability zones the collectors and the TargetAllocators are run in.
+type TopologyAwareSpec struct {
+	// Zones are the availability zones, as set in the topology.kubernetes.io/zone label of the nodes. The replicas
+	// of the collector are run in each zone. The targets whose zone isn't known, like the ones of static
+	// configurations, or isn't one of the zones, are scraped from the first zone.
+	// +kubebuilder:validation:MinItems=1
+	Zones []string `json:"zones"`
 }
 
 type OpenTelemetryTargetAllocatorPrometheusCR struct {
@@ -250,6 +553,51 @@ type ScaleSubresourceStatus struct {
 	Replicas int32 `json:"replicas,omitempty"`
 }
 
+// ConfigValidationSpec defines how the configuration is validated before it's rolled out.
+type ConfigValidationSpec struct {
+	// Timeout is the time the collector is given to validate the configuration once its container started, after
+	// which the validation Job is re-created. 2m by default.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RolloutStatus is the state of the blue/green rollouts of the collector Deployment.
+type RolloutStatus struct {
+	// Active is the color of the collector Deployment the Services select, blue or green.
+	// +optional
+	Active string `json:"active,omitempty"`
+	// Revision is the revision of the pod template of the active Deployment.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
+
+// LastKnownGoodStatus is the state of the OpenTelemetryCollector its pods last ran ready with.
+type LastKnownGoodStatus struct {
+	// Config is the rendered configuration of the collector, with the presets applied.
+	Config string `json:"config"`
+	// Image is the image of the collector.
+	Image string `json:"image"`
+	// ObservedGeneration is the generation of the OpenTelemetryCollector the configuration and image come from.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// RecordedTime is when the collector pods were first seen ready with the configuration and image.
+	RecordedTime metav1.Time `json:"recordedTime"`
+}
+
+// ImageStatus is an image run by the pods of the OpenTelemetryCollector.
+type ImageStatus struct {
+	// Component is the component of the pods running the image, e.g. opentelemetry-collector.
+	Component string `json:"component"`
+	// Container is the container running the image.
+	Container string `json:"container"`
+	// Image is the image of the container.
+	Image string `json:"image"`
+	// Digests are the digests the image resolved to in the running pods, more than one while they run different
+	// builds of the same tag.
+	// +optional
+	// +listType=set
+	Digests []string `json:"digests,omitempty"`
+}
+
 // OpenTelemetryCollectorStatus defines the observed state of OpenTelemetryCollector.
 type OpenTelemetryCollectorStatus struct {
 	// Scale is the OpenTelemetryCollector's scale subresource status.
@@ -260,12 +608,47 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	Version string `json:"version,omitempty"`
 
+	// TargetAllocatorVersion is the version of the managed OpenTelemetry TargetAllocator (operand), which follows the
+	// collector version unless the TargetAllocator image is set.
+	// +optional
+	TargetAllocatorVersion string `json:"targetAllocatorVersion,omitempty"`
+
 	// Messages about actions performed by the operator on this resource.
 	// +optional
 	// +listType=atomic
 	// Deprecated: use Kubernetes events instead.
 	Messages []string `json:"messages,omitempty"`
 
+	// Conditions represent the latest observations of the collector's state, e.g. the Healthy condition
+	// aggregating the failures of the collector pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastKnownGood is the configuration and image the collector pods last ran ready with, which the
+	// opentelemetry.io/rollback annotation rolls the collector back to.
+	// +optional
+	LastKnownGood *LastKnownGoodStatus `json:"lastKnownGood,omitempty"`
+
+	// Rollout is the state of the blue/green rollouts of the collector Deployment.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// ValidatedConfig is the digest of the last configuration and image the validate command of the collector
+	// succeeded with.
+	// +optional
+	ValidatedConfig string `json:"validatedConfig,omitempty"`
+
+	// OperatorVersion is the version of the operator that last reconciled the OpenTelemetryCollector.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// Images are the images run by the collector and TargetAllocator pods, along with the digests they resolved to.
+	// +optional
+	// +listType=atomic
+	Images []ImageStatus `json:"images,omitempty"`
+
 	// Replicas is currently not being set and might be removed in the next version.
 	// +optional
 	// Deprecated: use "OpenTelemetryCollector.Status.Scale.Replicas" instead.
@@ -302,14 +685,290 @@ type OpenTelemetryCollectorList struct {
 	Items           []OpenTelemetryCollector `json:"items"`
 }
 
+// ReceiverCreatorSpec defines the receiver_creator preset of the OpenTelemetryCollector.
+type ReceiverCreatorSpec struct {
+	// Enabled adds the k8s_observer extension and the receiver_creator receiver to the collector configuration, along
+	// with the ClusterRole the observer requires. In daemonset mode, each collector only observes its own node.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// ObserveNodes makes the observer report the cluster nodes, besides the pods.
+	// +optional
+	ObserveNodes bool `json:"observeNodes,omitempty"`
+	// Pipelines are the names of the pipelines the receiver_creator receiver is added to.
+	// +optional
+	Pipelines []string `json:"pipelines,omitempty"`
+	// Receivers are the templates of the receivers to start for the observed endpoints, by receiver name.
+	// +optional
+	Receivers map[string]ReceiverCreatorTemplate `json:"receivers,omitempty"`
+}
+
+// SelfTelemetrySpec defines the resource attributes of the collector's own telemetry, and where it's pushed to.
+type SelfTelemetrySpec struct {
+	// ResourceAttributes sets the OTEL_RESOURCE_ATTRIBUTES environment variable of the collector to the service.instance.id,
+	// k8s.pod.name, k8s.pod.uid, k8s.namespace.name and k8s.node.name of its pod, taken from the downward API, and adds
+	// the same attributes to the resource of the collector's internal telemetry, unless the configuration sets them.
+	// +optional
+	ResourceAttributes bool `json:"resourceAttributes,omitempty"`
+	// ResourceDetection adds a resourcedetection/collector processor with the env detector to the configuration, which
+	// the pipelines carrying the collector's own telemetry, e.g. its metrics scraped by a prometheus receiver, can
+	// list to get the attributes of ResourceAttributes. It implies ResourceAttributes.
+	// +optional
+	ResourceDetection bool `json:"resourceDetection,omitempty"`
+	// OTLP pushes the collector's own telemetry to an OTLP endpoint, for the collectors that can't be scraped, like
+	// sidecars and short-lived pods.
+	// +optional
+	OTLP *SelfTelemetryOTLPSpec `json:"otlp,omitempty"`
+}
+
+// SelfTelemetryOTLPSpec defines the OTLP endpoint the collector pushes its own telemetry to.
+type SelfTelemetryOTLPSpec struct {
+	// Endpoint is the URL of the OTLP endpoint, e.g. http://otel-gateway.observability:4318. The http scheme disables
+	// TLS.
+	Endpoint string `json:"endpoint"`
+	// Protocol is the OTLP protocol of the endpoint. Defaults to http/protobuf.
+	// +optional
+	Protocol SelfTelemetryProtocol `json:"protocol,omitempty"`
+	// Signals are the signals of the collector's own telemetry pushed to the endpoint. Defaults to the metrics, logs and
+	// traces.
+	// +optional
+	Signals []SelfTelemetrySignal `json:"signals,omitempty"`
+	// MetricsInterval is the interval the metrics are pushed at. Defaults to 60s.
+	// +optional
+	MetricsInterval *metav1.Duration `json:"metricsInterval,omitempty"`
+}
+
+// GuardrailsSpec defines the thresholds of the processors of the guardrails preset.
+type GuardrailsSpec struct {
+	// MemoryLimitPercentage is the percentage of the memory limit of the collector container the memory_limiter keeps
+	// the memory usage under, by refusing the data it receives. Defaults to 80.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MemoryLimitPercentage *int32 `json:"memoryLimitPercentage,omitempty"`
+	// MemorySpikeLimitPercentage is the percentage of the memory limit kept for the spikes between two checks of the
+	// memory usage, the memory_limiter refusing data above the difference of the two percentages. Defaults to 25.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MemorySpikeLimitPercentage *int32 `json:"memorySpikeLimitPercentage,omitempty"`
+	// SendBatchSize is the number of spans, metric data points or log records a batch is sent at. Defaults to 8192.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SendBatchSize *int32 `json:"sendBatchSize,omitempty"`
+	// SendBatchMaxSize is the maximum size of a batch, the larger batches being split. Defaults to no maximum.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SendBatchMaxSize *int32 `json:"sendBatchMaxSize,omitempty"`
+	// BatchTimeout is the time after which a batch is sent regardless of its size. Defaults to 200ms.
+	// +optional
+	BatchTimeout *metav1.Duration `json:"batchTimeout,omitempty"`
+	// TraceSamplingPercentage adds a probabilistic_sampler keeping the given percentage of the traces to the traces
+	// pipelines.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TraceSamplingPercentage *int32 `json:"traceSamplingPercentage,omitempty"`
+	// Filter adds a filter processor dropping the telemetry matching its OTTL conditions to the pipelines of the
+	// signals it has conditions for.
+	// +optional
+	Filter *GuardrailsFilterSpec `json:"filter,omitempty"`
+}
+
+// GuardrailsFilterSpec defines the OTTL conditions of the telemetry the guardrails preset drops.
+type GuardrailsFilterSpec struct {
+	// Spans are the conditions of the spans to drop, e.g. attributes["http.route"] == "/healthz".
+	// +optional
+	Spans []string `json:"spans,omitempty"`
+	// Metrics are the conditions of the metrics to drop, e.g. IsMatch(name, "^rpc.*").
+	// +optional
+	Metrics []string `json:"metrics,omitempty"`
+	// LogRecords are the conditions of the log records to drop, e.g. severity_number < SEVERITY_NUMBER_INFO.
+	// +optional
+	LogRecords []string `json:"logRecords,omitempty"`
+}
+
+// RemoteWriteSpec defines the prometheusremotewrite exporter of the remote write preset.
+type RemoteWriteSpec struct {
+	// Endpoint is the URL of the remote write endpoint, e.g. http://prometheus:9090/api/v1/write.
+	Endpoint string `json:"endpoint"`
+	// Pipelines are the names of the pipelines the exporter is added to. Defaults to all the metrics pipelines.
+	// +optional
+	Pipelines []string `json:"pipelines,omitempty"`
+	// ClusterName is set as the cluster external label of the metrics, telling apart the collectors of the clusters
+	// writing to the same endpoint.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// ExternalLabels are added to the metrics, besides the cluster label and the collector label set to the
+	// namespace and name of the collector, which they override.
+	// +optional
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// WAL keeps the metrics that couldn't be sent yet in a write-ahead log on a PersistentVolumeClaim of each
+	// collector pod, so that they survive restarts. Only available when the mode=statefulset.
+	// +optional
+	WAL *RemoteWriteWALSpec `json:"wal,omitempty"`
+}
+
+// RemoteWriteWALSpec defines the PersistentVolumeClaim of the write-ahead log of the remote write preset.
+type RemoteWriteWALSpec struct {
+	// Size is the requested size of the volume. Defaults to 1Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName is the storage class of the volume. Defaults to the default storage class of the cluster.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// ReceiverCreatorTemplate defines a receiver started by the receiver_creator for the observed endpoints matching its rule.
+type ReceiverCreatorTemplate struct {
+	// Rule is the expression matching the observed endpoints to start the receiver for, starting with the type of
+	// endpoint, e.g. `type == "pod" && labels["app"] == "redis"`.
+	Rule string `json:"rule"`
+	// Config is the YAML configuration of the receiver, where backtick expressions are expanded for each endpoint,
+	// e.g. "endpoint: '`endpoint`:6379'".
+	// +optional
+	Config string `json:"config,omitempty"`
+}
+
+// AWSIdentitySpec defines the IAM role the collector assumes through IAM roles for service accounts (IRSA).
+type AWSIdentitySpec struct {
+	// RoleARN is the ARN of the IAM role, set on the collector's ServiceAccount with the eks.amazonaws.com/role-arn
+	// annotation.
+	RoleARN string `json:"roleArn"`
+	// Region is the AWS region given to the collector with the AWS_REGION environment variable.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// FSGroup is the group owning the volumes of the collector pods, which has to be allowed to read the web identity
+	// token when the collector doesn't run as root. Defaults to 65534 when the pod security context doesn't set one.
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// GCPIdentitySpec defines the Google service account the collector impersonates through GKE Workload Identity.
+type GCPIdentitySpec struct {
+	// ServiceAccount is the email of the Google service account, set on the collector's ServiceAccount with the
+	// iam.gke.io/gcp-service-account annotation.
+	ServiceAccount string `json:"serviceAccount"`
+}
+
+// AzureIdentitySpec defines the Azure AD application or managed identity the collector uses through Azure Workload
+// Identity. The Azure Workload Identity webhook projects the service account token in the collector pods.
+type AzureIdentitySpec struct {
+	// ClientID is the client ID of the application or managed identity, set on the collector's ServiceAccount with
+	// the azure.workload.identity/client-id annotation.
+	ClientID string `json:"clientId"`
+	// TenantID is the ID of the tenant of the application or managed identity, set on the collector's ServiceAccount
+	// with the azure.workload.identity/tenant-id annotation. Defaults to the tenant of the Azure Workload Identity
+	// webhook.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+// LoadBalancerHealthCheckSpec defines the cloud provider load balancers checking the health of the collector.
+type LoadBalancerHealthCheckSpec struct {
+	// Provider is the cloud provider of the load balancers. The aws provider sets the
+	// service.beta.kubernetes.io/aws-load-balancer-healthcheck-* annotations on the Service, and the gcp provider sets
+	// the cloud.google.com/backend-config annotation.
+	Provider LoadBalancerProvider `json:"provider"`
+	// BackendConfig is the name of the GKE BackendConfig holding the health check of the gcp load balancers, which
+	// has to check the port of the health_check extension. Required with the gcp provider.
+	// +optional
+	BackendConfig string `json:"backendConfig,omitempty"`
+}
+
+// SecretProvider mounts the secrets of a SecretProviderClass of the Secrets Store CSI driver.
+type SecretProvider struct {
+	// Name of the secret provider, whose secrets are mounted in /etc/otelcol/secrets/<name>.
+	Name string `json:"name"`
+	// SecretProviderClass is the name of the SecretProviderClass fetching the secrets, in the namespace of the
+	// collector.
+	SecretProviderClass string `json:"secretProviderClass"`
+}
+
+// HostPortSpec defines the port of the node a port of the collector container is bound to.
+type HostPortSpec struct {
+	// Name is the name of the port of the collector container.
+	Name string `json:"name"`
+	// HostPort is the port of the node. Defaults to the number of the container port.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	HostPort int32 `json:"hostPort,omitempty"`
+}
+
+// ReceiverTLSSpec defines the secret holding the certificate of a receiver.
+type ReceiverTLSSpec struct {
+	// SecretName is the name of the secret holding the certificate, in the namespace of the collector, e.g. a secret
+	// of the kubernetes.io/tls type created by cert-manager.
+	SecretName string `json:"secretName"`
+	// CertKey is the key of the secret holding the certificate.
+	// +optional
+	// +kubebuilder:default=tls.crt
+	CertKey string `json:"certKey,omitempty"`
+	// KeyKey is the key of the secret holding the private key of the certificate.
+	// +optional
+	// +kubebuilder:default=tls.key
+	KeyKey string `json:"keyKey,omitempty"`
+	// ClientCAKey is the key of the secret holding the CA certificate the certificates of the clients are verified
+	// with, e.g. ca.crt. When set, the receiver requires the clients to present a certificate.
+	// +optional
+	ClientCAKey string `json:"clientCAKey,omitempty"`
+}
+
+// ExporterTLSSpec defines the secret holding the CA certificate an exporter verifies the server with, and the client
+// certificate it presents, if any.
+type ExporterTLSSpec struct {
+	// SecretName is the name of the secret holding the certificates, in the namespace of the collector.
+	SecretName string `json:"secretName"`
+	// CAKey is the key of the secret holding the CA certificate the certificate of the server is verified with, e.g.
+	// ca.crt. The system CAs are used when it's not set.
+	// +optional
+	CAKey string `json:"caKey,omitempty"`
+	// CertKey is the key of the secret holding the client certificate, e.g. tls.crt. Requires KeyKey.
+	// +optional
+	CertKey string `json:"certKey,omitempty"`
+	// KeyKey is the key of the secret holding the private key of the client certificate, e.g. tls.key. Requires
+	// CertKey.
+	// +optional
+	KeyKey string `json:"keyKey,omitempty"`
+}
+
+// ExporterTokenSpec defines the ServiceAccount token an exporter authenticates with.
+type ExporterTokenSpec struct {
+	// Audience is the intended audience of the token, checked by the backend.
+	Audience string `json:"audience"`
+	// ExpirationSeconds is the requested duration of validity of the token. The kubelet rotates the token before it
+	// expires, and the extension reads it again. Defaults to 1 hour.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// AvailabilitySpec defines the number of collector replicas kept serving during rolling updates and voluntary
+// disruptions.
+type AvailabilitySpec struct {
+	// MinAvailable is the number of collector replicas that stay available. It sets the minAvailable of the
+	// collector's PodDisruptionBudget and the maxUnavailable of the rolling updates of the collector's deployment,
+	// which surges new replicas when none can be unavailable. It can't be greater than the replicas, or than the
+	// minimum replicas of the autoscaler.
+	// +kubebuilder:validation:Minimum=1
+	MinAvailable int32 `json:"minAvailable"`
+}
+
 // AutoscalerSpec defines the OpenTelemetryCollector's pod autoscaling specification.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || !has(self.maxReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
 type AutoscalerSpec struct {
 	// MinReplicas sets a lower bound to the autoscaling feature.  Set this if your are using autoscaling. It must be at least 1
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	MinReplicas *int32 `json:"minReplicas,omitempty"`
 	// MaxReplicas sets an upper bound to the autoscaling feature. If MaxReplicas is set autoscaling is enabled.
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Behavior configures the scaling behavior of the HorizontalPodAutoscaler in the scale up and scale down
+	// directions, like the stabilization windows and the policies limiting the number of replicas added or removed
+	// over a period. Unset fields use the defaults of the HorizontalPodAutoscaler, e.g. a scale down stabilization
+	// window of 300 seconds.
 	// +optional
 	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
 	// Metrics is meant to provide a customizable way to configure HPA metrics.
@@ -320,10 +979,110 @@ type AutoscalerSpec struct {
 	// TargetCPUUtilization sets the target average CPU used across all replicas.
 	// If average CPU exceeds this value, the HPA will scale up. Defaults to 90 percent.
 	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
 	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+	// TargetMemoryUtilization sets the target average memory utilization across all replicas.
+	// If average memory exceeds this value, the HPA will scale up.
 	// +optional
-	// TargetMemoryUtilization sets the target average memory utilization across all replicas
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
 	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// Schedules override the minimum and maximum replicas during recurring windows, e.g. to scale the collector down
+	// at night. When windows overlap, the first schedule in the list wins. Without autoscaling, the replicas are kept
+	// within the bounds of the active schedule.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Schedules []AutoscalerSchedule `json:"schedules,omitempty"`
+}
+
+// AutoscalerSchedule defines a recurring window overriding the replicas bounds of the autoscaler.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || !has(self.maxReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type AutoscalerSchedule struct {
+	// Name of the schedule.
+	Name string `json:"name"`
+	// Start is the cron expression of the times the window starts at, e.g. "0 20 * * 1-5" for 8 PM on weekdays.
+	Start string `json:"start"`
+	// Duration of the window, e.g. 10h.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA name of the time zone of Start, e.g. Europe/Paris. UTC by default.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// MinReplicas overrides the minimum replicas during the window.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas overrides the maximum replicas during the window.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// NodeProfile defines how the collector runs on the nodes of a node pool.
+type NodeProfile struct {
+	// Name of the profile, which the name of its daemonset is suffixed with.
+	Name string `json:"name"`
+	// NodeSelector selects the nodes of the profile, in addition to the node selector of the collector. The nodes
+	// matching the selectors of several profiles run each of them.
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Config is merged into the configuration of the collector on the nodes of the profile. Its maps are merged with
+	// the ones of the collector's configuration, while its other values, including lists like the receivers of the
+	// pipelines, replace the collector's ones.
+	// +optional
+	Config string `json:"config,omitempty"`
+	// Resources replace the resources of the collector on the nodes of the profile.
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// Tolerations are added to the tolerations of the collector on the nodes of the profile, like the ones of the
+	// taints of the node pool.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// Env are added to the environment variables of the collector on the nodes of the profile.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+}
+
+// SidecarTier defines how the sidecar runs in the pods of a workload class.
+type SidecarTier struct {
+	// Name of the tier, which the pods set in their sidecar.opentelemetry.io/tier annotation.
+	Name string `json:"name"`
+	// Config is merged into the configuration of the sidecar in the pods of the tier. Its maps are merged with the
+	// ones of the collector's configuration, while its other values, including lists like the processors of the
+	// pipelines, replace the collector's ones.
+	// +optional
+	Config string `json:"config,omitempty"`
+	// Resources replace the resources of the sidecar in the pods of the tier.
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// VerticalAutoscalerSpec defines the VerticalPodAutoscaler of the OpenTelemetryCollector.
+type VerticalAutoscalerSpec struct {
+	// UpdateMode is how the recommended resources are applied: Off only computes them, Initial sets them on the
+	// collector pods when they are created, and Auto also evicts the running pods to set them. The resources of the
+	// collector are the ones the pods start with until a recommendation is available.
+	UpdateMode VerticalAutoscalerUpdateMode `json:"updateMode"`
+	// MinAllowed is the lower bound of the resources recommended for the collector container.
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed is the upper bound of the resources recommended for the collector container.
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// IstioSpec defines the Istio objects generated for the OpenTelemetryCollector.
+type IstioSpec struct {
+	// ReceiverMTLSMode is the mutual TLS mode of the receiver ports of the collector. PERMISSIVE, the default, also
+	// accepts the plaintext traffic of the applications outside of the mesh, while STRICT only accepts mutual TLS.
+	// +optional
+	ReceiverMTLSMode IstioMTLSMode `json:"receiverMtlsMode,omitempty"`
+	// ScopeEgress generates the Sidecar scoping the egress of the collector pods to their own namespace, the Istio
+	// control plane, the Kubernetes API and the namespaces of the in-cluster hosts of the exporters. The receivers
+	// pulling data from the other namespaces, like prometheus scraping the pods of the cluster, can't reach them then.
+	// +optional
+	ScopeEgress bool `json:"scopeEgress,omitempty"`
 }
 
 // Probe defines the OpenTelemetry's pod probe config. Only Liveness probe is supported currently.
@@ -16,10 +16,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/cronexpr"
+	"gopkg.in/yaml.v2"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -27,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
@@ -34,9 +44,27 @@ import (
 // log is for logging in this package.
 var opentelemetrycollectorlog = logf.Log.WithName("opentelemetrycollector-resource")
 
+// receiverCreatorRuleRegex matches the endpoint type the receiver creator rules must start with.
+var receiverCreatorRuleRegex = regexp.MustCompile(`^type\s*==\s*"([^"]*)"`)
+
+const (
+	// remoteWriteExporter is the exporter the remote write preset adds to the configuration.
+	remoteWriteExporter = "prometheusremotewrite"
+	// remoteWriteWALVolume is the volume claim template of the write-ahead log of the remote write preset.
+	remoteWriteWALVolume = "remote-write-wal"
+)
+
 func (r *OpenTelemetryCollector) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return r.SetupWebhookWithQuota(mgr, CollectorQuota{})
+}
+
+// SetupWebhookWithQuota registers the webhooks of the type, with the validating webhook also checking the instances
+// against the other instances of their namespaces, and enforcing the given quota in the namespaces.
+func (r *OpenTelemetryCollector) SetupWebhookWithQuota(mgr ctrl.Manager, quota CollectorQuota) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		// the instances are read from the API server, as the cache may not have seen the ones created just before
+		WithValidator(&namespaceValidator{client: mgr.GetAPIReader(), quota: quota}).
 		Complete()
 }
 
@@ -61,6 +89,16 @@ func (r *OpenTelemetryCollector) Default() {
 	if r.Labels["app.kubernetes.io/managed-by"] == "" {
 		r.Labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
 	}
+	// the instances being created don't have a creation timestamp yet, the existing ones keep the names of their objects
+	if r.CreationTimestamp.IsZero() {
+		if r.Annotations == nil {
+			r.Annotations = map[string]string{}
+		}
+		r.Annotations[HashedNamesAnnotation] = "true"
+		if len(r.Spec.ReceiverEndpoints) == 0 {
+			r.Spec.ReceiverEndpoints = DefaultReceiverEndpoints(r.Spec.Mode)
+		}
+	}
 
 	// We can default to one because dependent objects Deployment and HorizontalPodAutoScaler
 	// default to 1 as well.
@@ -119,13 +157,13 @@ var _ webhook.Validator = &OpenTelemetryCollector{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *OpenTelemetryCollector) ValidateCreate() (admission.Warnings, error) {
 	opentelemetrycollectorlog.Info("validate create", "name", r.Name)
-	return nil, r.validateCRDSpec()
+	return r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *OpenTelemetryCollector) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	opentelemetrycollectorlog.Info("validate update", "name", r.Name)
-	return nil, r.validateCRDSpec()
+	return r.validate()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -134,7 +172,34 @@ func (r *OpenTelemetryCollector) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validate returns the warnings of the version and configuration checks, along with the error of the validation of
+// the spec or, in strict mode, of the configuration.
+func (r *OpenTelemetryCollector) validate() (admission.Warnings, error) {
+	warnings := r.versionWarnings()
+	if err := r.versionError(); err != nil {
+		return warnings, err
+	}
+	if err := r.validateCRDSpec(); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, r.ingressWarnings()...)
+	warnings = append(warnings, r.verticalAutoscalerWarnings()...)
+	warnings = append(warnings, r.guardrailsWarnings()...)
+	warnings = append(warnings, r.legacyReceiversWarnings()...)
+	warnings = append(warnings, r.receiverEndpointsWarnings()...)
+	warnings = append(warnings, r.compatibilityWarnings()...)
+	configWarnings, err := r.configWarnings()
+	return append(warnings, configWarnings...), err
+}
+
 func (r *OpenTelemetryCollector) validateCRDSpec() error {
+	// the names of the generated objects are based on the name override, and the ones of services must be DNS-1035 labels
+	if len(r.Spec.NameOverride) > 0 {
+		if errs := validation.IsDNS1035Label(r.Spec.NameOverride); len(errs) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec NameOverride configuration is incorrect, '%s' is invalid: %s", r.Spec.NameOverride, strings.Join(errs, ", "))
+		}
+	}
+
 	// validate volumeClaimTemplates
 	if r.Spec.Mode != ModeStatefulSet && len(r.Spec.VolumeClaimTemplates) > 0 {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'volumeClaimTemplates'", r.Spec.Mode)
@@ -155,6 +220,39 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'affinity'", r.Spec.Mode)
 	}
 
+	// validate shareProcessNamespace
+	if r.Spec.Mode == ModeSidecar && r.Spec.ShareProcessNamespace != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'shareProcessNamespace'", r.Spec.Mode)
+	}
+
+	// validate hibernate
+	if r.Spec.Mode == ModeSidecar && r.Spec.Hibernate {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'hibernate'", r.Spec.Mode)
+	}
+
+	// validate configValidation
+	if r.Spec.Mode == ModeSidecar && r.Spec.ConfigValidation != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'configValidation'", r.Spec.Mode)
+	}
+	if r.Spec.ConfigValidation != nil && r.Spec.ConfigValidation.Timeout != nil && r.Spec.ConfigValidation.Timeout.Duration <= 0 {
+		return fmt.Errorf("the OpenTelemetry Spec ConfigValidation configuration is incorrect, the timeout must be positive")
+	}
+
+	if overrides := r.Spec.TargetAllocator.ScrapeOverrides; overrides != nil && overrides.MinScrapeInterval != nil && overrides.MinScrapeInterval.Duration <= 0 {
+		return fmt.Errorf("the OpenTelemetry Spec TargetAllocator configuration is incorrect, the minScrapeInterval of the scrapeOverrides must be positive")
+	}
+
+	// validate dnsPolicy and podDnsConfig
+	if r.Spec.Mode == ModeSidecar && (r.Spec.DNSPolicy != "" || r.Spec.PodDNSConfig != nil) {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attributes 'dnsPolicy' and 'podDnsConfig'", r.Spec.Mode)
+	}
+	if err := validateDNS(r.Spec.DNSPolicy, r.Spec.PodDNSConfig); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec DNS configuration is incorrect, %w", err)
+	}
+	if err := validateDNS(r.Spec.TargetAllocator.DNSPolicy, r.Spec.TargetAllocator.PodDNSConfig); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec TargetAllocator DNS configuration is incorrect, %w", err)
+	}
+
 	// validate target allocation
 	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode != ModeStatefulSet {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
@@ -172,6 +270,76 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		}
 	}
 
+	// validate target allocator job sharding
+	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.JobShards != nil && *r.Spec.TargetAllocator.JobShards > 1 &&
+		featuregate.EnableTargetAllocatorRewrite.IsEnabled() {
+		return fmt.Errorf("the OpenTelemetry Spec TargetAllocator jobShards can't be used when the %s feature gate is enabled", featuregate.EnableTargetAllocatorRewrite.ID())
+	}
+	// the jobs of the ServiceMonitors and PodMonitors aren't in the collector config, so they can't be sharded
+	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.JobShards != nil && *r.Spec.TargetAllocator.JobShards > 1 &&
+		r.Spec.TargetAllocator.PrometheusCR.Enabled {
+		return fmt.Errorf("the OpenTelemetry Spec TargetAllocator jobShards can't be used with the prometheusCR")
+	}
+	// the jobs of the work items aren't in the collector config either
+	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.JobShards != nil && *r.Spec.TargetAllocator.JobShards > 1 &&
+		len(r.Spec.TargetAllocator.WorkItems) > 0 {
+		return fmt.Errorf("the OpenTelemetry Spec TargetAllocator jobShards can't be used with the workItems")
+	}
+
+	// validate target allocator allocation state, whose claim can only be attached to a single pod
+	if state := r.Spec.TargetAllocator.AllocationState; state != nil && state.PersistentVolumeClaim != "" {
+		if r.Spec.TargetAllocator.Replicas != nil && *r.Spec.TargetAllocator.Replicas > 1 {
+			return fmt.Errorf("the OpenTelemetry Spec TargetAllocator allocationState can't be persisted in a PersistentVolumeClaim with more than one replica")
+		}
+		if r.Spec.TargetAllocator.JobShards != nil && *r.Spec.TargetAllocator.JobShards > 1 {
+			return fmt.Errorf("the OpenTelemetry Spec TargetAllocator allocationState can't be persisted in a PersistentVolumeClaim with jobShards")
+		}
+		if r.Spec.TargetAllocator.TopologyAware != nil {
+			return fmt.Errorf("the OpenTelemetry Spec TargetAllocator allocationState can't be persisted in a PersistentVolumeClaim with topologyAware zones")
+		}
+	}
+
+	// validate target allocator zones
+	if r.Spec.TargetAllocator.TopologyAware != nil {
+		if err := validateTopologyAware(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec TargetAllocator topologyAware configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate receiver creator preset
+	if r.Spec.ReceiverCreator.Enabled {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the receiver creator preset", r.Spec.Mode)
+		}
+		if err := validateReceiverCreator(r.Spec.ReceiverCreator, r.Spec.Config); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec ReceiverCreator configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate guardrails preset
+	if r.Spec.Guardrails != nil {
+		if err := validateGuardrails(*r.Spec.Guardrails, r.Spec.Config); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Guardrails configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate self telemetry OTLP endpoint
+	if r.Spec.SelfTelemetry.OTLP != nil {
+		if err := validateSelfTelemetryOTLP(*r.Spec.SelfTelemetry.OTLP); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec SelfTelemetry configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate remote write preset
+	if r.Spec.RemoteWrite != nil {
+		if r.Spec.RemoteWrite.WAL != nil && r.Spec.Mode != ModeStatefulSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'remoteWrite.wal'", r.Spec.Mode)
+		}
+		if err := validateRemoteWrite(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec RemoteWrite configuration is incorrect, %w", err)
+		}
+	}
+
 	// validator port config
 	for _, p := range r.Spec.Ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -181,6 +349,9 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 				p.Name, nameErrs, p.Port, numErrs)
 		}
 	}
+	if err := validatePortConflicts(r.Spec.Ports, r.Spec.Config); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec Ports configuration is incorrect, %w", err)
+	}
 
 	maxReplicas := new(int32)
 	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
@@ -212,6 +383,13 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		)
 	}
 
+	if err := validateIngressPorts(r.Spec.Ingress); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, %w", err)
+	}
+	if err := r.validateIngressPaths(); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, %w", err)
+	}
+
 	// validate autoscale with horizontal pod autoscaler
 	if maxReplicas != nil {
 		if *maxReplicas < int32(1) {
@@ -231,7 +409,19 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		}
 
 		if r.Spec.Autoscaler != nil {
-			return checkAutoscalerSpec(r.Spec.Autoscaler)
+			if err := checkAutoscalerSpec(r.Spec.Autoscaler); err != nil {
+				return err
+			}
+		}
+	}
+
+	// validate the autoscaler schedules, which also bound the replicas without autoscaling
+	if r.Spec.Autoscaler != nil && len(r.Spec.Autoscaler.Schedules) > 0 {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'autoscaler.schedules'", r.Spec.Mode)
+		}
+		if err := validateSchedules(r.Spec.Autoscaler.Schedules); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, %w", err)
 		}
 	}
 
@@ -241,6 +431,196 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		)
 	}
 
+	// validate the service account settings, which only apply to the service account created by the operator
+	if len(r.Spec.ServiceAccountAnnotations) > 0 || r.Spec.AWSIdentity != nil || r.Spec.GCPIdentity != nil || r.Spec.AzureIdentity != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attributes 'serviceAccountAnnotations', 'awsIdentity', 'gcpIdentity' and 'azureIdentity'", r.Spec.Mode)
+		}
+		if r.Spec.ServiceAccount != "" {
+			return fmt.Errorf("the OpenTelemetry Collector attributes 'serviceAccountAnnotations', 'awsIdentity', 'gcpIdentity' and 'azureIdentity' can't be used with the existing service account %s", r.Spec.ServiceAccount)
+		}
+	}
+	if r.Spec.AWSIdentity != nil && !strings.HasPrefix(r.Spec.AWSIdentity.RoleARN, "arn:") {
+		return fmt.Errorf("the OpenTelemetry Spec AWSIdentity configuration is incorrect, %q isn't the ARN of an IAM role", r.Spec.AWSIdentity.RoleARN)
+	}
+	if r.Spec.GCPIdentity != nil && !strings.Contains(r.Spec.GCPIdentity.ServiceAccount, "@") {
+		return fmt.Errorf("the OpenTelemetry Spec GCPIdentity configuration is incorrect, %q isn't the email of a Google service account", r.Spec.GCPIdentity.ServiceAccount)
+	}
+	if r.Spec.AzureIdentity != nil && r.Spec.AzureIdentity.ClientID == "" {
+		return fmt.Errorf("the OpenTelemetry Spec AzureIdentity configuration is incorrect, the client ID is required")
+	}
+
+	// validate the secret providers, whose SecretProviderClasses are in the namespace of the instance
+	if len(r.Spec.SecretProviders) > 0 {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'secretProviders'", r.Spec.Mode)
+		}
+		if err := validateSecretProviders(r.Spec.SecretProviders); err != nil {
+			return err
+		}
+	}
+
+	// validate the receiver certificates, which are mounted in the collector pods
+	if len(r.Spec.ReceiverTLS) > 0 {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'receiverTLS'", r.Spec.Mode)
+		}
+		if err := validateReceiverTLS(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec ReceiverTLS configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the exporter certificates, which are mounted in the collector pods
+	if len(r.Spec.ExporterTLS) > 0 {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'exporterTLS'", r.Spec.Mode)
+		}
+		if err := validateExporterTLS(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec ExporterTLS configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the exporter tokens, which are projected in the collector pods
+	if len(r.Spec.ExporterTokens) > 0 {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'exporterTokens'", r.Spec.Mode)
+		}
+		if err := validateExporterTokens(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec ExporterTokens configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the availability, which the rolling updates and the voluntary disruptions of the collector keep
+	if r.Spec.Availability != nil {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'availability'", r.Spec.Mode)
+		}
+		if err := validateAvailability(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Availability configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the node profiles, each run as its own daemonset
+	if len(r.Spec.NodeProfiles) > 0 {
+		if r.Spec.Mode != ModeDaemonSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'nodeProfiles'", r.Spec.Mode)
+		}
+		if err := validateNodeProfiles(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec NodeProfiles configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the host ports, which bind ports of the collector container on every node of the daemonset
+	if len(r.Spec.HostPorts) > 0 {
+		if r.Spec.Mode != ModeDaemonSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'hostPorts'", r.Spec.Mode)
+		}
+		if err := validateHostPorts(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec HostPorts configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the sidecar tiers, which the pods select with an annotation
+	if len(r.Spec.SidecarTiers) > 0 {
+		if r.Spec.Mode != ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarTiers'", r.Spec.Mode)
+		}
+		if err := validateSidecarTiers(r.Spec.SidecarTiers); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec SidecarTiers configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the sidecar namespace selector, which allows the pods of other namespaces to reference the instance
+	if r.Spec.SidecarNamespaceSelector != nil {
+		if r.Spec.Mode != ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarNamespaceSelector'", r.Spec.Mode)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.SidecarNamespaceSelector); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec SidecarNamespaceSelector configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the compatibility mode, whose platform rejects the pods with host access
+	if r.Spec.CompatibilityMode != "" {
+		if err := validateCompatibilityMode(r.Spec); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec CompatibilityMode configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the vertical autoscaler, which right-sizes the collector container of the pods of the workload
+	if r.Spec.VerticalAutoscaler != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'verticalAutoscaler'", r.Spec.Mode)
+		}
+		if err := validateVerticalAutoscaler(*r.Spec.VerticalAutoscaler); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec VerticalAutoscaler configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate the config versions, which are referenced by the pod template of the workload
+	if r.Spec.ConfigVersions != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'configVersions'", r.Spec.Mode)
+		}
+		if *r.Spec.ConfigVersions < 1 {
+			return fmt.Errorf("the OpenTelemetry Spec ConfigVersions configuration is incorrect, configVersions should be at least 1")
+		}
+	}
+
+	// validate the rollout strategy, whose blue/green rollouts keep the configuration of the previous deployment
+	if r.Spec.RolloutStrategy == RolloutStrategyBlueGreen {
+		if r.Spec.Mode != ModeDeployment {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the rollout strategy %s", r.Spec.Mode, RolloutStrategyBlueGreen)
+		}
+		if r.Spec.ConfigVersions == nil || *r.Spec.ConfigVersions < 2 {
+			return fmt.Errorf("the OpenTelemetry Spec RolloutStrategy configuration is incorrect, the rollout strategy %s requires configVersions of at least 2", RolloutStrategyBlueGreen)
+		}
+	}
+
+	// validate the istio objects, which select the collector pods of the workload
+	if r.Spec.Istio != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'istio'", r.Spec.Mode)
+		}
+		switch r.Spec.Istio.ReceiverMTLSMode {
+		case "", IstioMTLSModePermissive, IstioMTLSModeStrict:
+		default:
+			return fmt.Errorf("the OpenTelemetry Spec Istio configuration is incorrect, the receiverMtlsMode should be %s or %s", IstioMTLSModePermissive, IstioMTLSModeStrict)
+		}
+	}
+
+	// validate the load balancer health check, which points the load balancers to the port of the collector's service
+	if r.Spec.LoadBalancerHealthCheck != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'loadBalancerHealthCheck'", r.Spec.Mode)
+		}
+		if err := validateLoadBalancerHealthCheck(*r.Spec.LoadBalancerHealthCheck, r.Spec.Config); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec LoadBalancerHealthCheck configuration is incorrect, %w", err)
+		}
+	}
+
+	if err := validateSecretReferences(r.Namespace, r.Spec.Mode, secretReferencesConfig(r.Spec)); err != nil {
+		return err
+	}
+
+	if err := validateConfigSize(r.Spec); err != nil {
+		return err
+	}
+
+	if err := validateConnectors(r.Spec.Config); err != nil {
+		return err
+	}
+
+	if err := validateExtensionReferences(r.Spec.Config); err != nil {
+		return err
+	}
+
+	if featuregate.EnableFIPS.IsEnabled() {
+		if err := validateFIPS(r.Spec); err != nil {
+			return err
+		}
+	}
+
 	if r.Spec.LivenessProbe != nil {
 		if r.Spec.LivenessProbe.InitialDelaySeconds != nil && *r.Spec.LivenessProbe.InitialDelaySeconds < 0 {
 			return fmt.Errorf("the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect. InitialDelaySeconds should be greater than or equal to 0")
@@ -265,16 +645,40 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 	return nil
 }
 
+// validateSchedules checks the cron expressions, time zones, windows and bounds of the autoscaler schedules.
+func validateSchedules(schedules []AutoscalerSchedule) error {
+	for _, schedule := range schedules {
+		if _, err := cronexpr.Parse(schedule.Start); err != nil {
+			return fmt.Errorf("the start %q of the %s schedule isn't a cron expression: %w", schedule.Start, schedule.Name, err)
+		}
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return fmt.Errorf("the time zone %q of the %s schedule is unknown: %w", schedule.TimeZone, schedule.Name, err)
+		}
+		if schedule.Duration.Duration <= 0 {
+			return fmt.Errorf("the duration of the %s schedule must be positive", schedule.Name)
+		}
+		if schedule.MinReplicas == nil && schedule.MaxReplicas == nil {
+			return fmt.Errorf("the %s schedule must set minReplicas or maxReplicas", schedule.Name)
+		}
+		if schedule.MinReplicas != nil && schedule.MaxReplicas != nil && *schedule.MinReplicas > *schedule.MaxReplicas {
+			return fmt.Errorf("the minReplicas of the %s schedule must not be greater than its maxReplicas", schedule.Name)
+		}
+	}
+	return nil
+}
+
 func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	if autoscaler.Behavior != nil {
-		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
-			*autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds < int32(1) {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleDown should be one or more")
+		if autoscaler.Behavior.ScaleDown != nil {
+			if err := checkScalingRules("scaleDown", *autoscaler.Behavior.ScaleDown); err != nil {
+				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, %w", err)
+			}
 		}
 
-		if autoscaler.Behavior.ScaleUp != nil && autoscaler.Behavior.ScaleUp.StabilizationWindowSeconds != nil &&
-			*autoscaler.Behavior.ScaleUp.StabilizationWindowSeconds < int32(1) {
-			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, scaleUp should be one or more")
+		if autoscaler.Behavior.ScaleUp != nil {
+			if err := checkScalingRules("scaleUp", *autoscaler.Behavior.ScaleUp); err != nil {
+				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, %w", err)
+			}
 		}
 	}
 	if autoscaler.TargetCPUUtilization != nil && (*autoscaler.TargetCPUUtilization < int32(1) || *autoscaler.TargetCPUUtilization > int32(99)) {
@@ -305,3 +709,943 @@ func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 
 	return nil
 }
+
+// validateSecretProviders checks that the secret providers can be told apart, as they're mounted by name.
+func validateSecretProviders(providers []SecretProvider) error {
+	names := map[string]bool{}
+	for _, provider := range providers {
+		if errs := validation.IsDNS1123Label(provider.Name); len(errs) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec SecretProviders configuration is incorrect, the name %q is invalid: %s", provider.Name, errs[0])
+		}
+		if names[provider.Name] {
+			return fmt.Errorf("the OpenTelemetry Spec SecretProviders configuration is incorrect, the name %q is used by several secret providers", provider.Name)
+		}
+		names[provider.Name] = true
+		if provider.SecretProviderClass == "" {
+			return fmt.Errorf("the OpenTelemetry Spec SecretProviders configuration is incorrect, the secret provider %q has no secretProviderClass", provider.Name)
+		}
+	}
+	return nil
+}
+
+// validateReceiverTLS checks that the receivers whose certificates are mounted are configured.
+func validateReceiverTLS(spec OpenTelemetryCollectorSpec) error {
+	config, err := adapters.ConfigFromString(spec.Config)
+	if err != nil {
+		return fmt.Errorf("the configuration can't be parsed: %w", err)
+	}
+	receivers, _ := config["receivers"].(map[string]interface{})
+
+	names := make([]string, 0, len(spec.ReceiverTLS))
+	for name := range spec.ReceiverTLS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		receiver, ok := receivers[name]
+		if !ok {
+			return fmt.Errorf("the receiver %q isn't configured", name)
+		}
+		receiverConfig, _ := receiver.(map[string]interface{})
+		if protocols, ok := receiverConfig["protocols"].(map[string]interface{}); ok {
+			_, grpc := protocols["grpc"]
+			_, http := protocols["http"]
+			_, thriftHTTP := protocols["thrift_http"]
+			if !grpc && !http && !thriftHTTP {
+				return fmt.Errorf("the receiver %q has no grpc, http or thrift_http protocol to serve over TLS", name)
+			}
+		}
+		tls := spec.ReceiverTLS[name]
+		if tls.SecretName == "" {
+			return fmt.Errorf("the receiver %q has no secretName", name)
+		}
+		// both keys default to the ones of the kubernetes.io/tls secrets
+		if (tls.CertKey == "") != (tls.KeyKey == "") {
+			return fmt.Errorf("the receiver %q must set both certKey and keyKey for its certificate", name)
+		}
+		if tls.CertKey != "" && tls.CertKey == tls.KeyKey {
+			return fmt.Errorf("the receiver %q reads its certificate and its key from the same key %q of the secret", name, tls.CertKey)
+		}
+	}
+	return nil
+}
+
+// configuredExporters returns the exporters of the configuration, along with the exporter of the remote write preset.
+func configuredExporters(spec OpenTelemetryCollectorSpec) (map[string]interface{}, error) {
+	config, err := adapters.ConfigFromString(spec.Config)
+	if err != nil {
+		return nil, fmt.Errorf("the configuration can't be parsed: %w", err)
+	}
+	exporters, _ := config["exporters"].(map[string]interface{})
+	if spec.RemoteWrite != nil {
+		if exporters == nil {
+			exporters = map[string]interface{}{}
+		}
+		exporters[remoteWriteExporter] = nil
+	}
+	return exporters, nil
+}
+
+// validateExporterTLS checks that the exporters whose certificates are mounted are configured, and that the client
+// certificates are complete.
+func validateExporterTLS(spec OpenTelemetryCollectorSpec) error {
+	exporters, err := configuredExporters(spec)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(spec.ExporterTLS))
+	for name := range spec.ExporterTLS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := exporters[name]; !ok {
+			return fmt.Errorf("the exporter %q isn't configured", name)
+		}
+		tls := spec.ExporterTLS[name]
+		if tls.SecretName == "" {
+			return fmt.Errorf("the exporter %q has no secretName", name)
+		}
+		if (tls.CertKey == "") != (tls.KeyKey == "") {
+			return fmt.Errorf("the exporter %q must set both certKey and keyKey for its client certificate", name)
+		}
+		if tls.CAKey == "" && tls.CertKey == "" {
+			return fmt.Errorf("the exporter %q mounts neither a CA nor a client certificate", name)
+		}
+	}
+	return nil
+}
+
+// validateExporterTokens checks that the exporters authenticating with a token are configured, and don't use another
+// authenticator.
+func validateExporterTokens(spec OpenTelemetryCollectorSpec) error {
+	exporters, err := configuredExporters(spec)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(spec.ExporterTokens))
+	for name := range spec.ExporterTokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exporter, ok := exporters[name]
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't configured", name)
+		}
+		if spec.ExporterTokens[name].Audience == "" {
+			return fmt.Errorf("the exporter %q has no audience", name)
+		}
+		exporterConfig, _ := exporter.(map[string]interface{})
+		auth, _ := exporterConfig["auth"].(map[string]interface{})
+		if authenticator, ok := auth["authenticator"]; ok {
+			return fmt.Errorf("the exporter %q already authenticates with %v", name, authenticator)
+		}
+	}
+	return nil
+}
+
+// validateSecretReferences checks that the secrets referenced by the config are in the namespace of the instance, as
+// the collector can only be given the values of the secrets of its own namespace. This also prevents the config from
+// exposing the secrets of other namespaces to the collector.
+// The sidecars run in the pods of the applications, possibly in other namespaces, where the secrets can't be referenced.
+func validateSecretReferences(namespace string, mode Mode, config string) error {
+	envVars := map[string]adapters.SecretReference{}
+	for _, reference := range adapters.ConfigToSecretReferences(config) {
+		if mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the secret references of the config", mode)
+		}
+		if reference.Namespace != namespace {
+			return fmt.Errorf("the OpenTelemetry Collector config references the secret %s, which isn't in the %s namespace of the collector", reference, namespace)
+		}
+		if other, ok := envVars[reference.EnvVar()]; ok {
+			return fmt.Errorf("the OpenTelemetry Collector config references the secrets %s and %s, which can't be told apart", other, reference)
+		}
+		envVars[reference.EnvVar()] = reference
+	}
+	return nil
+}
+
+// secretReferencesConfig returns the parts of the spec the secret references are resolved in: the config, and the
+// templates the receiver creator preset adds to it.
+func secretReferencesConfig(spec OpenTelemetryCollectorSpec) string {
+	if !spec.ReceiverCreator.Enabled {
+		return spec.Config
+	}
+	configs := []string{spec.Config}
+	for _, template := range spec.ReceiverCreator.Receivers {
+		configs = append(configs, template.Config)
+	}
+	return strings.Join(configs, "\n")
+}
+
+// validateConfigSize checks that the config fits in config maps. Configs too large for a single config map are split
+// in parts holding whole components, which can't be split any further.
+func validateConfigSize(spec OpenTelemetryCollectorSpec) error {
+	if len(spec.Config) <= adapters.MaxConfigSize {
+		return nil
+	}
+	if spec.Mode == ModeSidecar {
+		return fmt.Errorf("the OpenTelemetry Collector config is %d bytes, the config of a sidecar can't be more than %d bytes", len(spec.Config), adapters.MaxConfigSize)
+	}
+
+	config, err := adapters.ConfigFromString(spec.Config)
+	if err != nil {
+		return err
+	}
+	for _, unit := range adapters.ConfigToUnits(config) {
+		size, err := unit.Size()
+		if err != nil {
+			return err
+		}
+		if size > adapters.MaxConfigSize {
+			return fmt.Errorf("the OpenTelemetry Collector config can't be split in config maps, the %s config is %d bytes, more than the %d bytes a config map can hold", unit, size, adapters.MaxConfigSize)
+		}
+	}
+	return nil
+}
+
+// validateConnectors checks that the connectors of the config connect pipelines of signals they support, as the
+// collector would refuse to start otherwise.
+func validateConnectors(config string) error {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		// the config is checked elsewhere
+		return nil
+	}
+	if errs := adapters.ConfigToConnectorErrors(cfg); len(errs) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector config connectors are incorrect: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateExtensionReferences checks that the extensions the receivers and exporters of the config authenticate with
+// or store their state in are declared and enabled, as the collector would refuse to start otherwise.
+func validateExtensionReferences(config string) error {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		// the config is checked elsewhere
+		return nil
+	}
+	if errs := adapters.ConfigToExtensionErrors(cfg); len(errs) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector config extensions are incorrect: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateFIPS checks that the configurations of the collector, its node profiles and sidecar tiers only allow the
+// TLS versions and cipher suites approved by FIPS 140, which the operator enforces in FIPS mode.
+func validateFIPS(spec OpenTelemetryCollectorSpec) error {
+	configs := []string{spec.Config}
+	for _, profile := range spec.NodeProfiles {
+		configs = append(configs, profile.Config)
+	}
+	for _, tier := range spec.SidecarTiers {
+		configs = append(configs, tier.Config)
+	}
+
+	var errs []string
+	for _, config := range configs {
+		cfg, err := adapters.ConfigFromString(config)
+		if err != nil {
+			// the configs are checked elsewhere
+			continue
+		}
+		errs = append(errs, adapters.ConfigToFIPSErrors(cfg)...)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector config isn't FIPS compliant: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateReceiverCreator(spec ReceiverCreatorSpec, config string) error {
+	if len(spec.Receivers) == 0 {
+		return fmt.Errorf("at least one receiver must be defined")
+	}
+	for name, template := range spec.Receivers {
+		match := receiverCreatorRuleRegex.FindStringSubmatch(template.Rule)
+		if match == nil {
+			return fmt.Errorf("the rule of the %s receiver must start with the endpoint type, e.g. type == \"pod\"", name)
+		}
+		switch match[1] {
+		case "pod", "port":
+		case "k8s.node":
+			if !spec.ObserveNodes {
+				return fmt.Errorf("the rule of the %s receiver matches nodes, which requires observeNodes", name)
+			}
+		default:
+			return fmt.Errorf("the rule of the %s receiver matches the %s endpoint type, which isn't observed", name, match[1])
+		}
+		receiverConfig := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(template.Config), &receiverConfig); err != nil {
+			return fmt.Errorf("the config of the %s receiver isn't valid: %w", name, err)
+		}
+	}
+
+	collectorConfig := struct {
+		Service struct {
+			Pipelines map[string]interface{} `yaml:"pipelines"`
+		} `yaml:"service"`
+	}{}
+	if err := yaml.Unmarshal([]byte(config), &collectorConfig); err != nil {
+		return err
+	}
+	for _, pipeline := range spec.Pipelines {
+		if _, ok := collectorConfig.Service.Pipelines[pipeline]; !ok {
+			return fmt.Errorf("the %s pipeline doesn't exist", pipeline)
+		}
+	}
+	return nil
+}
+
+// validateGuardrails checks that the memory_limiter of the guardrails preset has room for the data between its
+// soft and hard limits, and that its processors don't collide with the ones of the configuration.
+func validateGuardrails(guardrails GuardrailsSpec, config string) error {
+	limitPercentage := guardrails.EffectiveMemoryLimitPercentage()
+	spikeLimitPercentage := guardrails.EffectiveMemorySpikeLimitPercentage()
+	if spikeLimitPercentage >= limitPercentage {
+		return fmt.Errorf("the memorySpikeLimitPercentage %d must be lower than the memoryLimitPercentage %d", spikeLimitPercentage, limitPercentage)
+	}
+	if guardrails.SendBatchSize != nil && guardrails.SendBatchMaxSize != nil && *guardrails.SendBatchMaxSize < *guardrails.SendBatchSize {
+		return fmt.Errorf("the sendBatchMaxSize %d must not be lower than the sendBatchSize %d", *guardrails.SendBatchMaxSize, *guardrails.SendBatchSize)
+	}
+	if guardrails.BatchTimeout != nil && guardrails.BatchTimeout.Duration <= 0 {
+		return fmt.Errorf("the batchTimeout must be positive")
+	}
+
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return fmt.Errorf("the configuration can't be parsed: %w", err)
+	}
+	if processors, ok := cfg["processors"].(map[string]interface{}); ok {
+		for _, name := range GuardrailsProcessors {
+			if _, ok := processors[name]; ok {
+				return fmt.Errorf("the %s processor is already configured", name)
+			}
+		}
+	}
+	return nil
+}
+
+// guardrailsWarnings returns a warning when the memory_limiter of the guardrails preset has no memory limit of the
+// collector container to compute its limits from, and uses the memory of the node instead.
+func (r *OpenTelemetryCollector) guardrailsWarnings() admission.Warnings {
+	if r.Spec.Guardrails == nil {
+		return nil
+	}
+	if _, ok := r.Spec.Resources.Limits[corev1.ResourceMemory]; ok {
+		return nil
+	}
+	return admission.Warnings{
+		"the guardrails limit the memory usage to a percentage of the memory limit of the collector container, which isn't set: the memory of the node is used instead",
+	}
+}
+
+// legacyReceiversWarnings returns a warning for each deprecated receiver or receiver setting of the config, whose ports
+// the operator only infers while the operator.collector.legacyreceivers feature gate is enabled.
+func (r *OpenTelemetryCollector) legacyReceiversWarnings() admission.Warnings {
+	config, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, path := range adapters.ConfigToLegacyReceivers(config) {
+		replacement := "the otlp receiver"
+		if strings.HasSuffix(path, ".remote_sampling") {
+			replacement = "the jaegerremotesampling extension"
+		}
+		warning := fmt.Sprintf("%s is deprecated, migrate to %s", path, replacement)
+		if !featuregate.EnableLegacyReceivers.IsEnabled() {
+			warning += ": the operator doesn't infer its ports, list them in spec.ports"
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// receiverEndpointsWarnings returns a warning for each receiver endpoint of the config listening on the loopback
+// interface that the receiver endpoints policy leaves unreachable: the collectors behind services must listen on
+// other interfaces, while the application containers reach the sidecars over localhost.
+func (r *OpenTelemetryCollector) receiverEndpointsWarnings() admission.Warnings {
+	sidecar := r.Spec.Mode == ModeSidecar
+	policy := r.Spec.ReceiverEndpoints.Effective()
+	if (sidecar && policy != ReceiverEndpointsPodIP) || (!sidecar && policy != ReceiverEndpointsKeep) {
+		return nil
+	}
+	config, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	endpoints := adapters.ConfigToLoopbackEndpoints(config)
+	paths := make([]string, 0, len(endpoints))
+	for path := range endpoints {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var warnings admission.Warnings
+	for _, path := range paths {
+		if sidecar {
+			warnings = append(warnings, fmt.Sprintf("%s is rewritten to the IP of the pod, which the application containers can't reach over localhost: set spec.receiverEndpoints to keep or all-interfaces", path))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s listens on %v, which isn't reachable through the services of the collector: set spec.receiverEndpoints to all-interfaces or pod-ip", path, endpoints[path]["endpoint"]))
+		}
+	}
+	return warnings
+}
+
+// validateSelfTelemetryOTLP checks the endpoint, the signals and the metrics interval the collector pushes its own
+// telemetry with.
+func validateSelfTelemetryOTLP(otlp SelfTelemetryOTLPSpec) error {
+	endpoint, err := url.Parse(otlp.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("the endpoint %q isn't an http or https URL", otlp.Endpoint)
+	}
+	seen := map[SelfTelemetrySignal]bool{}
+	for _, signal := range otlp.Signals {
+		if seen[signal] {
+			return fmt.Errorf("the %s signal is listed more than once", signal)
+		}
+		seen[signal] = true
+	}
+	if otlp.MetricsInterval != nil && otlp.MetricsInterval.Duration <= 0 {
+		return fmt.Errorf("the metrics interval must be positive")
+	}
+	return nil
+}
+
+// validateRemoteWrite checks the endpoint and the pipelines of the remote write preset, and that its exporter and
+// volume claim don't collide with the ones of the spec.
+func validateRemoteWrite(spec OpenTelemetryCollectorSpec) error {
+	endpoint, err := url.Parse(spec.RemoteWrite.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("the endpoint %q isn't an http or https URL", spec.RemoteWrite.Endpoint)
+	}
+
+	config, err := adapters.ConfigFromString(spec.Config)
+	if err != nil {
+		return fmt.Errorf("the configuration can't be parsed: %w", err)
+	}
+	if exporters, ok := config["exporters"].(map[string]interface{}); ok {
+		if _, ok := exporters[remoteWriteExporter]; ok {
+			return fmt.Errorf("the %s exporter is already configured", remoteWriteExporter)
+		}
+	}
+	service, _ := config["service"].(map[string]interface{})
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+	for _, pipeline := range spec.RemoteWrite.Pipelines {
+		if _, ok := pipelines[pipeline]; !ok {
+			return fmt.Errorf("the %s pipeline doesn't exist", pipeline)
+		}
+	}
+
+	if spec.RemoteWrite.WAL != nil {
+		for _, claim := range spec.VolumeClaimTemplates {
+			if claim.Name == remoteWriteWALVolume {
+				return fmt.Errorf("the %s volume claim template is already defined", remoteWriteWALVolume)
+			}
+		}
+	}
+	return nil
+}
+
+// checkScalingRules checks the scaling rules of the autoscaler against the limits of the HorizontalPodAutoscaler API.
+func checkScalingRules(direction string, rules autoscalingv2.HPAScalingRules) error {
+	if rules.StabilizationWindowSeconds != nil && (*rules.StabilizationWindowSeconds < 0 || *rules.StabilizationWindowSeconds > 3600) {
+		return fmt.Errorf("%s stabilizationWindowSeconds should be between 0 and 3600", direction)
+	}
+	if rules.SelectPolicy != nil {
+		switch *rules.SelectPolicy {
+		case autoscalingv2.MaxChangePolicySelect, autoscalingv2.MinChangePolicySelect, autoscalingv2.DisabledPolicySelect:
+		default:
+			return fmt.Errorf("%s selectPolicy should be one of %s, %s or %s", direction,
+				autoscalingv2.MaxChangePolicySelect, autoscalingv2.MinChangePolicySelect, autoscalingv2.DisabledPolicySelect)
+		}
+	}
+	for _, policy := range rules.Policies {
+		if policy.Type != autoscalingv2.PodsScalingPolicy && policy.Type != autoscalingv2.PercentScalingPolicy {
+			return fmt.Errorf("%s policy type should be %s or %s", direction, autoscalingv2.PodsScalingPolicy, autoscalingv2.PercentScalingPolicy)
+		}
+		if policy.Value < 1 {
+			return fmt.Errorf("%s policy value should be one or more", direction)
+		}
+		if policy.PeriodSeconds < 1 || policy.PeriodSeconds > 1800 {
+			return fmt.Errorf("%s policy periodSeconds should be between 1 and 1800", direction)
+		}
+	}
+	return nil
+}
+
+// validateAvailability checks that the minimum available replicas can be kept with the replicas the collector can be
+// scaled down to.
+func validateAvailability(spec OpenTelemetryCollectorSpec) error {
+	minAvailable := spec.Availability.MinAvailable
+	if minAvailable < 1 {
+		return fmt.Errorf("minAvailable should be one or more")
+	}
+
+	minReplicas := int32(1)
+	if spec.Replicas != nil {
+		minReplicas = *spec.Replicas
+	}
+	if spec.Autoscaler != nil && spec.Autoscaler.MaxReplicas != nil && spec.Autoscaler.MinReplicas != nil {
+		minReplicas = *spec.Autoscaler.MinReplicas
+	}
+
+	if minAvailable > minReplicas {
+		return fmt.Errorf("minAvailable %d is greater than the %d replicas the collector can be scaled down to", minAvailable, minReplicas)
+	}
+	// the rolling updates of stateful sets replace one replica at a time, without surging new replicas
+	if spec.Mode == ModeStatefulSet && minAvailable == minReplicas {
+		return fmt.Errorf("minAvailable %d should be less than the %d replicas of the stateful set, whose rolling updates replace one replica at a time", minAvailable, minReplicas)
+	}
+	return nil
+}
+
+// validateTopologyAware checks the zones and that the collector isn't otherwise split or scaled, since each zone runs
+// its own replicas of the collector and its own TargetAllocator.
+func validateTopologyAware(spec OpenTelemetryCollectorSpec) error {
+	if !spec.TargetAllocator.Enabled {
+		return fmt.Errorf("the TargetAllocator should be enabled")
+	}
+	if spec.TargetAllocator.JobShards != nil && *spec.TargetAllocator.JobShards > 1 {
+		return fmt.Errorf("the zones can't be used with jobShards")
+	}
+	if featuregate.EnableTargetAllocatorRewrite.IsEnabled() {
+		return fmt.Errorf("the zones can't be used when the %s feature gate is enabled", featuregate.EnableTargetAllocatorRewrite.ID())
+	}
+	if spec.Autoscaler != nil && spec.Autoscaler.MaxReplicas != nil {
+		return fmt.Errorf("the zones can't be used with the autoscaler")
+	}
+	if spec.VerticalAutoscaler != nil {
+		return fmt.Errorf("the zones can't be used with the vertical autoscaler")
+	}
+
+	zones := spec.TargetAllocator.TopologyAware.Zones
+	if len(zones) == 0 {
+		return fmt.Errorf("at least one zone should be set")
+	}
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		if errs := validation.IsValidLabelValue(zone); zone == "" || len(errs) > 0 {
+			return fmt.Errorf("the zone '%s' isn't a valid label value: %s", zone, strings.Join(errs, ", "))
+		}
+		if seen[zone] {
+			return fmt.Errorf("the zone '%s' is set more than once", zone)
+		}
+		seen[zone] = true
+	}
+	return nil
+}
+
+// validateNodeProfiles checks the names, node selectors and configurations of the node profiles.
+func validateNodeProfiles(spec OpenTelemetryCollectorSpec) error {
+	if spec.VerticalAutoscaler != nil {
+		return fmt.Errorf("the node profiles can't be used with the vertical autoscaler")
+	}
+	seen := map[string]bool{}
+	for _, profile := range spec.NodeProfiles {
+		// the name is a DNS-1035 label, so that the names of its config maps don't clash with the ones of the parts of
+		// the collector's configuration
+		if errs := validation.IsDNS1035Label(profile.Name); len(errs) > 0 {
+			return fmt.Errorf("the name '%s' of the node profile is invalid: %s", profile.Name, strings.Join(errs, ", "))
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("the node profile '%s' is set more than once", profile.Name)
+		}
+		seen[profile.Name] = true
+		if len(profile.NodeSelector) == 0 {
+			return fmt.Errorf("the node profile '%s' should have a node selector", profile.Name)
+		}
+		if _, err := adapters.ConfigFromString(profile.Config); err != nil {
+			return fmt.Errorf("the configuration of the node profile '%s' is invalid: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateHostPorts checks that the host ports name ports of the collector container, inferred from the receivers
+// and the telemetry of the collector or listed in the ports, and that they don't bind the same port of the node.
+func validateHostPorts(spec OpenTelemetryCollectorSpec) error {
+	// the ports of the spec take precedence over the inferred ports, like in the collector container
+	ports := map[string]corev1.ContainerPort{}
+	if config, err := adapters.ConfigFromString(spec.Config); err == nil {
+		ports = adapters.ConfigToContainerPorts(logr.Discard(), config)
+	}
+	for _, port := range spec.Ports {
+		ports[port.Name] = corev1.ContainerPort{Name: port.Name, ContainerPort: port.Port, Protocol: port.Protocol}
+	}
+
+	bound := map[string]string{}
+	for _, hostPort := range spec.HostPorts {
+		port, ok := ports[hostPort.Name]
+		if !ok {
+			return fmt.Errorf("the port '%s' isn't a port of the collector container, list it in the ports", hostPort.Name)
+		}
+		number := port.ContainerPort
+		if hostPort.HostPort > 0 {
+			number = hostPort.HostPort
+		}
+		// the host network binds the container ports on the node, so they can't be mapped to other ports
+		if spec.HostNetwork && number != port.ContainerPort {
+			return fmt.Errorf("the port '%s' listens on the port %d of the node with the host network, it can't be bound to the port %d", hostPort.Name, port.ContainerPort, number)
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		key := fmt.Sprintf("%d/%s", number, protocol)
+		if other, ok := bound[key]; ok {
+			return fmt.Errorf("the ports '%s' and '%s' are both bound to the port %s of the node", other, hostPort.Name, key)
+		}
+		bound[key] = hostPort.Name
+	}
+	return nil
+}
+
+// validateSidecarTiers checks the names and configurations of the sidecar tiers.
+func validateSidecarTiers(tiers []SidecarTier) error {
+	seen := map[string]bool{}
+	for _, tier := range tiers {
+		if errs := validation.IsDNS1123Label(tier.Name); len(errs) > 0 {
+			return fmt.Errorf("the name '%s' of the sidecar tier is invalid: %s", tier.Name, strings.Join(errs, ", "))
+		}
+		if seen[tier.Name] {
+			return fmt.Errorf("the sidecar tier '%s' is set more than once", tier.Name)
+		}
+		seen[tier.Name] = true
+		if _, err := adapters.ConfigFromString(tier.Config); err != nil {
+			return fmt.Errorf("the configuration of the sidecar tier '%s' is invalid: %w", tier.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateCompatibilityMode checks that the platform of the compatibility mode can run the collector pods, since the
+// fields it rejects that the operator can't leave out are the mode and the volumes the configuration relies on.
+func validateCompatibilityMode(spec OpenTelemetryCollectorSpec) error {
+	mode := spec.CompatibilityMode
+	switch mode {
+	case CompatibilityModeGKEAutopilot, CompatibilityModeEKSFargate:
+	default:
+		return fmt.Errorf("the compatibilityMode should be %s or %s", CompatibilityModeGKEAutopilot, CompatibilityModeEKSFargate)
+	}
+	if mode == CompatibilityModeEKSFargate && spec.Mode == ModeDaemonSet {
+		return fmt.Errorf("%s doesn't run daemonsets, use the %s or %s mode, or the %s mode to run the collector next to the workloads", mode, ModeDeployment, ModeStatefulSet, ModeSidecar)
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		if mode == CompatibilityModeEKSFargate {
+			return fmt.Errorf("%s doesn't allow the hostPath volume '%s', read the files through the API server or replace it with an emptyDir or persistent volume", mode, volume.Name)
+		}
+		if path := strings.TrimSuffix(volume.HostPath.Path, "/"); path != "/var/log" && !strings.HasPrefix(path, "/var/log/") {
+			return fmt.Errorf("%s only allows the hostPath volumes under /var/log, the volume '%s' mounts %s", mode, volume.Name, volume.HostPath.Path)
+		}
+		for _, mount := range spec.VolumeMounts {
+			if mount.Name == volume.Name && !mount.ReadOnly {
+				return fmt.Errorf("%s only allows the hostPath volumes to be mounted read-only, set readOnly on the mount of the volume '%s' at %s", mode, volume.Name, mount.MountPath)
+			}
+		}
+	}
+
+	// the node profiles select the nodes of their daemonsets and exclude them from the daemonset of the instance, so
+	// their node selectors can't be left out like the one of the instance
+	for _, profile := range spec.NodeProfiles {
+		for key := range profile.NodeSelector {
+			if mode.ReservedNodeLabel(key) {
+				return fmt.Errorf("%s manages the node label '%s' the node profile '%s' selects, select the nodes on a label of your own", mode, key, profile.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// compatibilityWarnings returns the warnings for the fields the operator leaves out of the collector pods, since the
+// platform of the compatibility mode would reject them.
+func (r *OpenTelemetryCollector) compatibilityWarnings() admission.Warnings {
+	mode := r.Spec.CompatibilityMode
+	if mode == "" {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	if r.Spec.HostNetwork {
+		warnings = append(warnings, fmt.Sprintf("%s doesn't allow the host network, the collector pods use the pod network instead: expose the receivers through the service ports", mode))
+	}
+	if r.Spec.SecurityContext != nil && r.Spec.SecurityContext.Privileged != nil && *r.Spec.SecurityContext.Privileged {
+		warnings = append(warnings, fmt.Sprintf("%s doesn't allow privileged containers, the collector container runs unprivileged instead", mode))
+	}
+	keys := make([]string, 0, len(r.Spec.NodeSelector))
+	for key := range r.Spec.NodeSelector {
+		if mode.ReservedNodeLabel(key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		warnings = append(warnings, fmt.Sprintf("%s manages the node labels %s, the collector pods don't select the nodes on them", mode, strings.Join(keys, ", ")))
+	}
+	return warnings
+}
+
+// validateVerticalAutoscaler checks the update mode and that the lower bounds of the recommended resources aren't
+// greater than their upper bounds.
+func validateVerticalAutoscaler(vertical VerticalAutoscalerSpec) error {
+	switch vertical.UpdateMode {
+	case VerticalAutoscalerUpdateModeOff, VerticalAutoscalerUpdateModeInitial, VerticalAutoscalerUpdateModeAuto:
+	default:
+		return fmt.Errorf("the updateMode should be %s, %s or %s", VerticalAutoscalerUpdateModeOff, VerticalAutoscalerUpdateModeInitial, VerticalAutoscalerUpdateModeAuto)
+	}
+	for name, minAllowed := range vertical.MinAllowed {
+		if maxAllowed, ok := vertical.MaxAllowed[name]; ok && minAllowed.Cmp(maxAllowed) > 0 {
+			return fmt.Errorf("the minAllowed %s %s is greater than the maxAllowed %s", name, minAllowed.String(), maxAllowed.String())
+		}
+	}
+	return nil
+}
+
+// verticalAutoscalerWarnings returns a warning when the recommendations of the vertical autoscaler are applied to the
+// collector pods while the autoscaler scales the collector on their CPU or memory utilization, which is relative to
+// the requests the vertical autoscaler changes.
+func (r *OpenTelemetryCollector) verticalAutoscalerWarnings() admission.Warnings {
+	vertical := r.Spec.VerticalAutoscaler
+	if vertical == nil || vertical.UpdateMode == VerticalAutoscalerUpdateModeOff {
+		return nil
+	}
+	autoscaler := r.Spec.Autoscaler
+	if autoscaler == nil || autoscaler.MaxReplicas == nil {
+		return nil
+	}
+	if autoscaler.TargetCPUUtilization == nil && autoscaler.TargetMemoryUtilization == nil {
+		return nil
+	}
+	return admission.Warnings{
+		fmt.Sprintf("the vertical autoscaler applies its recommendations in %s mode while the autoscaler scales the collector on the CPU or memory utilization, which is relative to the requests the vertical autoscaler changes: the two may keep reacting to each other", vertical.UpdateMode),
+	}
+}
+
+// validateLoadBalancerHealthCheck checks that the configuration enables the health_check extension the load balancers
+// are pointed to.
+func validateLoadBalancerHealthCheck(healthCheck LoadBalancerHealthCheckSpec, config string) error {
+	if healthCheck.Provider == LoadBalancerProviderGCP && healthCheck.BackendConfig == "" {
+		return fmt.Errorf("the backendConfig is required with the %s provider", LoadBalancerProviderGCP)
+	}
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return err
+	}
+	if _, _, err := adapters.ConfigToHealthCheckPort(cfg); err != nil {
+		return err
+	}
+	return nil
+}
+
+// portOwners records which component each port number and name of the collector is taken by.
+type portOwners struct {
+	numbers        map[string]string
+	names          map[string]string
+	containerNames map[string]string
+}
+
+func newPortOwners() portOwners {
+	return portOwners{numbers: map[string]string{}, names: map[string]string{}, containerNames: map[string]string{}}
+}
+
+// claim records the port for the owner, returning an error naming both owners when another one has the same port
+// number and protocol, the same service port name or the same container port name.
+func (o portOwners) claim(owner string, port corev1.ServicePort, container bool) error {
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	number := fmt.Sprintf("%d/%s", port.Port, protocol)
+	if other, ok := o.numbers[number]; ok {
+		return fmt.Errorf("%s and %s both listen on the port %s", other, owner, number)
+	}
+	if other, ok := o.names[port.Name]; ok {
+		return fmt.Errorf("%s and %s both use the service port name %q", other, owner, port.Name)
+	}
+	o.numbers[number] = owner
+	o.names[port.Name] = owner
+	if !container {
+		return nil
+	}
+	name := adapters.ContainerPortName(port.Name)
+	if other, ok := o.containerNames[name]; ok {
+		return fmt.Errorf("%s and %s both use the container port name %q", other, owner, name)
+	}
+	o.containerNames[name] = owner
+	return nil
+}
+
+// validatePortConflicts checks that neither the ports of the spec nor the ports inferred from the enabled receivers
+// collide with each other. The ports of the spec replace the inferred ports they share a name or number with, so
+// they're only checked against each other.
+func validatePortConflicts(ports []corev1.ServicePort, config string) error {
+	owners := newPortOwners()
+	for _, p := range ports {
+		if err := owners.claim(fmt.Sprintf("the port %q", p.Name), p, false); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		// the configuration checks report it
+		return nil
+	}
+	byReceiver, err := adapters.ConfigToReceiverPortsByReceiver(logr.Discard(), cfg)
+	if err != nil {
+		return nil
+	}
+	receivers := make([]string, 0, len(byReceiver))
+	for receiver := range byReceiver {
+		receivers = append(receivers, receiver)
+	}
+	sort.Strings(receivers)
+
+	owners = newPortOwners()
+	for _, receiver := range receivers {
+		for _, p := range byReceiver[receiver] {
+			if err := owners.claim(fmt.Sprintf("the %q port of the %q receiver", p.Name, receiver), p, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateIngressPorts checks the hosts and paths the ports are exposed by.
+func validateIngressPorts(ingress Ingress) error {
+	if len(ingress.Ports) > 0 && ingress.Type != IngressTypeNginx {
+		return fmt.Errorf("the ports can only be set with the %s type", IngressTypeNginx)
+	}
+	for _, port := range ingress.Ports {
+		if len(port.Hostname) > 0 {
+			errs := validation.IsDNS1123Subdomain(port.Hostname)
+			if strings.HasPrefix(port.Hostname, "*.") {
+				errs = validation.IsWildcardDNS1123Subdomain(port.Hostname)
+			}
+			if len(errs) > 0 {
+				return fmt.Errorf("the hostname %q of the %s port is invalid: %s", port.Hostname, port.Name, errs[0])
+			}
+		}
+		if len(port.Path) > 0 && !strings.HasPrefix(port.Path, "/") {
+			return fmt.Errorf("the path %q of the %s port must start with /", port.Path, port.Name)
+		}
+	}
+	return nil
+}
+
+// ingressPorts returns the ports exposed by the ingress, by name, and the overrides of their hosts, paths and
+// annotations. No port is returned when the configuration can't be parsed.
+func (r *OpenTelemetryCollector) ingressPorts() (map[string]corev1.ServicePort, map[string]IngressPort) {
+	overrides := map[string]IngressPort{}
+	listed := map[string]bool{}
+	for _, override := range r.Spec.Ingress.Ports {
+		overrides[override.Name] = override
+		listed[override.Name] = true
+	}
+	config, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return nil, overrides
+	}
+	var signals []string
+	for _, signal := range r.Spec.Ingress.Signals {
+		signals = append(signals, string(signal))
+	}
+	// the ports of the spec take precedence over the ports inferred from the receivers
+	ports := map[string]corev1.ServicePort{}
+	inferred, _ := adapters.ConfigToReceiverPorts(logr.Discard(), config)
+	for _, port := range adapters.SignalPorts(logr.Discard(), config, append(inferred, r.Spec.Ports...), signals, listed) {
+		ports[port.Name] = port
+	}
+	return ports, overrides
+}
+
+// validateIngressPaths checks that the ports of the ingress are exposed by distinct paths or hosts, the gRPC ports
+// being exposed by the root path of their host by default.
+func (r *OpenTelemetryCollector) validateIngressPaths() error {
+	if r.Spec.Ingress.Type != IngressTypeNginx || r.Spec.Mode == ModeSidecar {
+		return nil
+	}
+	ports, overrides := r.ingressPorts()
+	exposed := map[string]string{}
+	for _, name := range sortedPortNames(ports) {
+		override := overrides[name]
+		host := r.Spec.Ingress.Hostname
+		if len(override.Hostname) > 0 {
+			host = override.Hostname
+		}
+		path := "/" + name
+		if adapters.IsGRPCPort(ports[name]) {
+			path = "/"
+		}
+		if len(override.Path) > 0 {
+			path = override.Path
+		}
+		key := host + path
+		if other, ok := exposed[key]; ok {
+			return fmt.Errorf("the ports %s and %s are both exposed by the path '%s' of the host '%s', set a distinct hostname or path for one of them in spec.ingress.ports", other, name, path, host)
+		}
+		exposed[key] = name
+	}
+	return nil
+}
+
+// ingressWarnings returns the warnings for the gRPC ports the ingress may not route, because the controller of the
+// ingress class isn't known to support gRPC backends, or doesn't serve HTTP/2 without TLS.
+func (r *OpenTelemetryCollector) ingressWarnings() admission.Warnings {
+	if r.Spec.Ingress.Type != IngressTypeNginx || r.Spec.Mode == ModeSidecar {
+		return nil
+	}
+	ports, overrides := r.ingressPorts()
+
+	class := adapters.IngressClass(r.Spec.Ingress.IngressClassName, r.Spec.Ingress.Annotations)
+	_, known := adapters.GRPCIngressAnnotations(class)
+	var (
+		warnings admission.Warnings
+		grpc     []string
+		unknown  []string
+	)
+	for _, name := range sortedPortNames(ports) {
+		if !adapters.IsGRPCPort(ports[name]) {
+			continue
+		}
+		grpc = append(grpc, name)
+		if !known && len(overrides[name].Annotations) == 0 {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the ingress class '%s' isn't known to support gRPC backends, the annotations enabling them must be set for the gRPC ports %s in spec.ingress.ports", class, strings.Join(unknown, ", ")))
+	}
+	if len(grpc) > 0 && strings.Contains(class, "nginx") && len(r.Spec.Ingress.TLS) == 0 {
+		warnings = append(warnings, fmt.Sprintf("ingress-nginx only serves HTTP/2, which gRPC requires, over TLS, the gRPC ports %s can't be reached without spec.ingress.tls", strings.Join(grpc, ", ")))
+	}
+	return warnings
+}
+
+func sortedPortNames(ports map[string]corev1.ServicePort) []string {
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateDNS checks that the pods have a nameserver when the DNS policy doesn't give them one.
+func validateDNS(policy corev1.DNSPolicy, config *corev1.PodDNSConfig) error {
+	if policy == corev1.DNSNone && (config == nil || len(config.Nameservers) == 0) {
+		return fmt.Errorf("the DNS policy %s requires at least one nameserver in podDnsConfig", policy)
+	}
+	return nil
+}
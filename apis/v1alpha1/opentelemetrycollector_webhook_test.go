@@ -16,13 +16,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestOTELColDefaultingWebhook(t *testing.T) {
@@ -40,14 +46,18 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 			otelcol: OpenTelemetryCollector{},
 			expected: OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						HashedNamesAnnotation: "true",
+					},
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeDeployment,
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Mode:              ModeDeployment,
+					ReceiverEndpoints: ReceiverEndpointsAllInterfaces,
+					Replicas:          &one,
+					UpgradeStrategy:   UpgradeStrategyAutomatic,
 				},
 			},
 		},
@@ -62,14 +72,18 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 			},
 			expected: OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						HashedNamesAnnotation: "true",
+					},
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeSidecar,
-					Replicas:        &five,
-					UpgradeStrategy: "adhoc",
+					Mode:              ModeSidecar,
+					ReceiverEndpoints: ReceiverEndpointsKeep,
+					Replicas:          &five,
+					UpgradeStrategy:   "adhoc",
 				},
 			},
 		},
@@ -85,14 +99,18 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 			},
 			expected: OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						HashedNamesAnnotation: "true",
+					},
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeDeployment,
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Mode:              ModeDeployment,
+					ReceiverEndpoints: ReceiverEndpointsAllInterfaces,
+					Replicas:          &one,
+					UpgradeStrategy:   UpgradeStrategyAutomatic,
 					Autoscaler: &AutoscalerSpec{
 						TargetCPUUtilization: &defaultCPUTarget,
 						MaxReplicas:          &five,
@@ -110,14 +128,18 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 			},
 			expected: OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						HashedNamesAnnotation: "true",
+					},
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeDeployment,
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Mode:              ModeDeployment,
+					ReceiverEndpoints: ReceiverEndpointsAllInterfaces,
+					Replicas:          &one,
+					UpgradeStrategy:   UpgradeStrategyAutomatic,
 					Autoscaler: &AutoscalerSpec{
 						TargetCPUUtilization: &defaultCPUTarget,
 						// webhook Default adds MaxReplicas to Autoscaler because
@@ -141,12 +163,16 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 			},
 			expected: OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						HashedNamesAnnotation: "true",
+					},
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeDeployment,
+					Mode:              ModeDeployment,
+					ReceiverEndpoints: ReceiverEndpointsAllInterfaces,
 					Ingress: Ingress{
 						Type: IngressTypeRoute,
 						Route: OpenShiftRoute{
@@ -168,16 +194,39 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColDefaultingWebhookExistingInstance(t *testing.T) {
+	// the existing instances keep the names of their objects and their receiver endpoints, only the ones being created
+	// get hashed names and the default receiver endpoints policy
+	otelcol := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Now(),
+		},
+	}
+	otelcol.Default()
+	assert.False(t, otelcol.HashedNames())
+	assert.Empty(t, otelcol.Spec.ReceiverEndpoints)
+	assert.Equal(t, ReceiverEndpointsKeep, otelcol.Spec.ReceiverEndpoints.Effective())
+
+	otelcol.CreationTimestamp = metav1.Time{}
+	otelcol.Default()
+	assert.True(t, otelcol.HashedNames())
+	assert.Equal(t, ReceiverEndpointsAllInterfaces, otelcol.Spec.ReceiverEndpoints)
+}
+
 // TODO: a lot of these tests use .Spec.MaxReplicas and .Spec.MinReplicas. These fields are
 // deprecated and moved to .Spec.Autoscaler. Fine to use these fields to test that old CRD is
 // still supported but should eventually be updated.
 func TestOTELColValidatingWebhook(t *testing.T) {
 	minusOne := int32(-1)
 	zero := int32(0)
+	moreThanHour := int32(3601)
+	invalidSelectPolicy := autoscalingv2.ScalingPolicySelect("Random")
 	zero64 := int64(0)
 	one := int32(1)
 	three := int32(3)
 	five := int32(5)
+	twenty := int32(20)
+	shareProcessNamespace := true
 
 	tests := []struct { //nolint:govet
 		name        string
@@ -207,8 +256,8 @@ func TestOTELColValidatingWebhook(t *testing.T) {
     endpoint: "0.0.0.0:12346"
   prometheus:
     config:
-      scrape_config:
-        job_name: otel-collector
+      scrape_configs:
+      - job_name: otel-collector
         scrape_interval: 10s
   jaeger/custom:
     protocols:
@@ -285,336 +334,2392 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			expectedErr: "the OpenTelemetry Spec Prometheus configuration is incorrect",
 		},
 		{
-			name: "invalid port name",
-			otelcol: OpenTelemetryCollector{
-				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							// this port name contains a non alphanumeric character, which is invalid.
-							Name:     "-test🦄port",
-							Port:     12345,
-							Protocol: v1.ProtocolTCP,
-						},
-					},
-				},
-			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
-		},
-		{
-			name: "invalid port name, too long",
+			name: "aws identity in sidecar mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccdddd", // len: 16, too long
-							Port: 5555,
-						},
-					},
+					Mode:        ModeSidecar,
+					AWSIdentity: &AWSIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/otel-collector"},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "does not support the attributes 'serviceAccountAnnotations', 'awsIdentity', 'gcpIdentity' and 'azureIdentity'",
 		},
 		{
-			name: "invalid port num",
+			name: "service account annotations with an existing service account",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccddd", // len: 15
-							// no port set means it's 0, which is invalid
-						},
-					},
+					ServiceAccount:            "existing",
+					ServiceAccountAnnotations: map[string]string{"foo": "bar"},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "can't be used with the existing service account existing",
 		},
 		{
-			name: "invalid max replicas",
+			name: "invalid aws identity role",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &zero,
+					AWSIdentity: &AWSIdentitySpec{RoleARN: "otel-collector"},
 				},
 			},
-			expectedErr: "maxReplicas should be defined and one or more",
+			expectedErr: "\"otel-collector\" isn't the ARN of an IAM role",
 		},
 		{
-			name: "invalid replicas, greater than max",
+			name: "azure identity with an existing service account",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Replicas:    &five,
+					ServiceAccount: "existing",
+					AzureIdentity:  &AzureIdentitySpec{ClientID: "00000000-0000-0000-0000-000000000000"},
 				},
 			},
-			expectedErr: "replicas must not be greater than maxReplicas",
+			expectedErr: "can't be used with the existing service account existing",
 		},
 		{
-			name: "invalid min replicas, greater than max",
+			name: "invalid gcp identity service account",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &five,
+					GCPIdentity: &GCPIdentitySpec{ServiceAccount: "otel-collector"},
 				},
 			},
-			expectedErr: "minReplicas must not be greater than maxReplicas",
+			expectedErr: "\"otel-collector\" isn't the email of a Google service account",
 		},
 		{
-			name: "invalid min replicas, lesser than 1",
+			name: "azure identity without client id",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &zero,
+					AzureIdentity: &AzureIdentitySpec{},
 				},
 			},
-			expectedErr: "minReplicas should be one or more",
+			expectedErr: "the OpenTelemetry Spec AzureIdentity configuration is incorrect, the client ID is required",
 		},
 		{
-			name: "invalid autoscaler scale down",
+			name: "valid autoscaler behavior",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
 					MaxReplicas: &three,
 					Autoscaler: &AutoscalerSpec{
 						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleDown: &autoscalingv2.HPAScalingRules{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
 								StabilizationWindowSeconds: &zero,
 							},
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+							},
 						},
 					},
 				},
 			},
-			expectedErr: "scaleDown should be one or more",
 		},
 		{
-			name: "invalid autoscaler scale up",
+			name: "valid availability",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleUp: &autoscalingv2.HPAScalingRules{
-								StabilizationWindowSeconds: &zero,
-							},
-						},
-					},
+					Mode:         ModeDeployment,
+					Replicas:     &three,
+					Availability: &AvailabilitySpec{MinAvailable: 3},
 				},
 			},
-			expectedErr: "scaleUp should be one or more",
 		},
 		{
-			name: "invalid autoscaler target cpu utilization",
+			name: "availability in daemonset mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						TargetCPUUtilization: &zero,
-					},
+					Mode:         ModeDaemonSet,
+					Availability: &AvailabilitySpec{MinAvailable: 1},
 				},
 			},
-			expectedErr: "targetCPUUtilization should be greater than 0 and less than 100",
+			expectedErr: "does not support the attribute 'availability'",
 		},
 		{
-			name: "autoscaler minReplicas is less than maxReplicas",
+			name: "availability greater than the replicas",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Autoscaler: &AutoscalerSpec{
-						MaxReplicas: &one,
-						MinReplicas: &five,
-					},
+					Mode:         ModeDeployment,
+					Replicas:     &three,
+					Availability: &AvailabilitySpec{MinAvailable: 5},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas",
+			expectedErr: "minAvailable 5 is greater than the 3 replicas the collector can be scaled down to",
 		},
 		{
-			name: "invalid autoscaler metric type",
+			name: "availability greater than the autoscaler min replicas",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
+					Mode:     ModeDeployment,
+					Replicas: &five,
 					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.ResourceMetricSourceType,
-							},
-						},
+						MinReplicas: &one,
+						MaxReplicas: &five,
 					},
+					Availability: &AvailabilitySpec{MinAvailable: 3},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod",
+			expectedErr: "minAvailable 3 is greater than the 1 replicas the collector can be scaled down to",
 		},
 		{
-			name: "invalid pod metric average value",
+			name: "availability of all the stateful set replicas",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.PodsMetricSourceType,
-								Pods: &autoscalingv2.PodsMetricSource{
-									Metric: autoscalingv2.MetricIdentifier{
-										Name: "custom1",
-									},
-									Target: autoscalingv2.MetricTarget{
-										Type:         autoscalingv2.AverageValueMetricType,
-										AverageValue: resource.NewQuantity(int64(0), resource.DecimalSI),
-									},
-								},
-							},
-						},
-					},
+					Mode:         ModeStatefulSet,
+					Replicas:     &three,
+					Availability: &AvailabilitySpec{MinAvailable: 3},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0",
+			expectedErr: "minAvailable 3 should be less than the 3 replicas of the stateful set",
 		},
 		{
-			name: "utilization target is not valid with pod metrics",
+			name: "valid vertical autoscaler",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Metrics: []MetricSpec{
-							{
-								Type: autoscalingv2.PodsMetricSourceType,
-								Pods: &autoscalingv2.PodsMetricSource{
-									Metric: autoscalingv2.MetricIdentifier{
-										Name: "custom1",
-									},
-									Target: autoscalingv2.MetricTarget{
-										Type:               autoscalingv2.UtilizationMetricType,
-										AverageUtilization: &one,
-									},
-								},
-							},
-						},
+					Mode: ModeDaemonSet,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{
+						UpdateMode: VerticalAutoscalerUpdateModeAuto,
+						MinAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+						MaxAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type",
 		},
 		{
-			name: "invalid deployment mode incompabible with ingress settings",
+			name: "vertical autoscaler in sidecar mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Ingress: Ingress{
-						Type: IngressTypeNginx,
-					},
+					Mode:               ModeSidecar,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{UpdateMode: VerticalAutoscalerUpdateModeInitial},
 				},
 			},
-			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
-				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
-			),
+			expectedErr: "does not support the attribute 'verticalAutoscaler'",
 		},
 		{
-			name: "invalid mode with priorityClassName",
+			name: "invalid vertical autoscaler update mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:              ModeSidecar,
-					PriorityClassName: "test-class",
+					Mode:               ModeDeployment,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{UpdateMode: "Recreate"},
 				},
 			},
-			expectedErr: "does not support the attribute 'priorityClassName'",
+			expectedErr: "the updateMode should be Off, Initial or Auto",
 		},
 		{
-			name: "invalid mode with affinity",
+			name: "receiver tls in sidecar mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Affinity: &v1.Affinity{
-						NodeAffinity: &v1.NodeAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
-								NodeSelectorTerms: []v1.NodeSelectorTerm{
-									{
-										MatchExpressions: []v1.NodeSelectorRequirement{
-											{
-												Key:      "node",
-												Operator: v1.NodeSelectorOpIn,
-												Values:   []string{"test-node"},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
+					Mode:        ModeSidecar,
+					ReceiverTLS: map[string]ReceiverTLSSpec{"otlp": {SecretName: "otlp-cert"}},
 				},
 			},
-			expectedErr: "does not support the attribute 'affinity'",
+			expectedErr: "does not support the attribute 'receiverTLS'",
 		},
 		{
-			name: "invalid InitialDelaySeconds",
+			name: "receiver tls of an unknown receiver",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						InitialDelaySeconds: &minusOne,
-					},
+					Mode:        ModeDeployment,
+					Config:      "receivers:\n  otlp:\n",
+					ReceiverTLS: map[string]ReceiverTLSSpec{"otlp": {SecretName: "otlp-cert"}, "zipkin": {SecretName: "zipkin-cert"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect",
+			expectedErr: "the receiver \"zipkin\" isn't configured",
 		},
 		{
-			name: "invalid PeriodSeconds",
+			name: "receiver tls without tls protocol",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						PeriodSeconds: &zero,
-					},
+					Mode:        ModeDeployment,
+					Config:      "receivers:\n  jaeger:\n    protocols:\n      thrift_compact:\n",
+					ReceiverTLS: map[string]ReceiverTLSSpec{"jaeger": {SecretName: "jaeger-cert"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe PeriodSeconds configuration is incorrect",
+			expectedErr: "the receiver \"jaeger\" has no grpc, http or thrift_http protocol to serve over TLS",
 		},
 		{
-			name: "invalid TimeoutSeconds",
+			name: "receiver tls without key",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						TimeoutSeconds: &zero,
-					},
+					Mode:        ModeDeployment,
+					Config:      "receivers:\n  otlp:\n    protocols:\n      grpc:\n",
+					ReceiverTLS: map[string]ReceiverTLSSpec{"otlp": {SecretName: "otlp-cert", CertKey: "cert.pem"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe TimeoutSeconds configuration is incorrect",
+			expectedErr: "the receiver \"otlp\" must set both certKey and keyKey for its certificate",
 		},
 		{
-			name: "invalid SuccessThreshold",
+			name: "receiver tls without certificate",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						SuccessThreshold: &zero,
-					},
+					Mode:        ModeDeployment,
+					Config:      "receivers:\n  otlp:\n    protocols:\n      grpc:\n",
+					ReceiverTLS: map[string]ReceiverTLSSpec{"otlp": {SecretName: "otlp-cert", KeyKey: "key.pem"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe SuccessThreshold configuration is incorrect",
+			expectedErr: "the receiver \"otlp\" must set both certKey and keyKey for its certificate",
 		},
 		{
-			name: "invalid FailureThreshold",
+			name: "receiver tls with the same certificate and key",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						FailureThreshold: &zero,
-					},
+					Mode:        ModeDeployment,
+					Config:      "receivers:\n  otlp:\n    protocols:\n      grpc:\n",
+					ReceiverTLS: map[string]ReceiverTLSSpec{"otlp": {SecretName: "otlp-cert", CertKey: "tls.pem", KeyKey: "tls.pem"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe FailureThreshold configuration is incorrect",
+			expectedErr: "the receiver \"otlp\" reads its certificate and its key from the same key \"tls.pem\" of the secret",
 		},
 		{
-			name: "invalid TerminationGracePeriodSeconds",
+			name: "exporter tls in sidecar mode",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					LivenessProbe: &Probe{
-						TerminationGracePeriodSeconds: &zero64,
-					},
+					Mode:        ModeSidecar,
+					ExporterTLS: map[string]ExporterTLSSpec{"otlp": {SecretName: "backend-ca", CAKey: "ca.crt"}},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec LivenessProbe TerminationGracePeriodSeconds configuration is incorrect",
+			expectedErr: "does not support the attribute 'exporterTLS'",
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := test.otelcol.validateCRDSpec()
-			if test.expectedErr == "" {
-				assert.NoError(t, err)
-				return
-			}
-			assert.ErrorContains(t, err, test.expectedErr)
+		{
+			name: "exporter tls of an unknown exporter",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					Config:      "exporters:\n  otlp:\n",
+					ExporterTLS: map[string]ExporterTLSSpec{"kafka": {SecretName: "kafka-ca", CAKey: "ca.crt"}},
+				},
+			},
+			expectedErr: "the exporter \"kafka\" isn't configured",
+		},
+		{
+			name: "exporter tls with an incomplete client certificate",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					Config:      "exporters:\n  otlp:\n",
+					ExporterTLS: map[string]ExporterTLSSpec{"otlp": {SecretName: "backend-client", CertKey: "tls.crt"}},
+				},
+			},
+			expectedErr: "the exporter \"otlp\" must set both certKey and keyKey for its client certificate",
+		},
+		{
+			name: "exporter tls without certificate",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					Config:      "exporters:\n  otlp:\n",
+					ExporterTLS: map[string]ExporterTLSSpec{"otlp": {SecretName: "backend-ca"}},
+				},
+			},
+			expectedErr: "the exporter \"otlp\" mounts neither a CA nor a client certificate",
+		},
+		{
+			name: "exporter tokens in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeSidecar,
+					ExporterTokens: map[string]ExporterTokenSpec{"otlphttp": {Audience: "gateway"}},
+				},
+			},
+			expectedErr: "does not support the attribute 'exporterTokens'",
+		},
+		{
+			name: "exporter token without audience",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeDeployment,
+					Config:         "exporters:\n  otlphttp:\n",
+					ExporterTokens: map[string]ExporterTokenSpec{"otlphttp": {}},
+				},
+			},
+			expectedErr: "the exporter \"otlphttp\" has no audience",
+		},
+		{
+			name: "exporter token of an exporter with an authenticator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeDeployment,
+					Config:         "exporters:\n  otlphttp:\n    auth:\n      authenticator: oauth2client\n",
+					ExporterTokens: map[string]ExporterTokenSpec{"otlphttp": {Audience: "gateway"}},
+				},
+			},
+			expectedErr: "the exporter \"otlphttp\" already authenticates with oauth2client",
+		},
+		{
+			name: "connector without receiving pipeline",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Config: "connectors:\n  spanmetrics:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [spanmetrics]\n",
+				},
+			},
+			expectedErr: "the connector spanmetrics is an exporter of the traces pipeline, but isn't a receiver of any pipeline",
+		},
+		{
+			name: "connector between incompatible pipelines",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Config: "connectors:\n  spanmetrics:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [spanmetrics]\n    logs:\n      receivers: [spanmetrics]\n      exporters: [otlp]\n",
+				},
+			},
+			expectedErr: "the connector spanmetrics is an exporter of the traces pipeline, but isn't a receiver of any pipeline it can connect traces to",
+		},
+		{
+			name: "undeclared authenticator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Config: "exporters:\n  otlp:\n    auth:\n      authenticator: oauth2client\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [otlp]\n",
+				},
+			},
+			expectedErr: "the exporter otlp references the oauth2client extension in auth.authenticator, which isn't declared in extensions",
+		},
+		{
+			name: "storage extension not enabled",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Config: "extensions:\n  file_storage:\nreceivers:\n  filelog:\n    storage: file_storage\nservice:\n  pipelines:\n    logs:\n      receivers: [filelog]\n      exporters: [logging]\n",
+				},
+			},
+			expectedErr: "the receiver filelog references the file_storage extension in storage, which isn't enabled in service.extensions",
+		},
+		{
+			name: "guardrails with a spike limit above the limit",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:       ModeDeployment,
+					Guardrails: &GuardrailsSpec{MemoryLimitPercentage: &twenty},
+				},
+			},
+			expectedErr: "the memorySpikeLimitPercentage 25 must be lower than the memoryLimitPercentage 20",
+		},
+		{
+			name: "guardrails with a processor of the configuration",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:       ModeDeployment,
+					Guardrails: &GuardrailsSpec{},
+					Config:     "processors:\n  batch/guardrails:\n",
+				},
+			},
+			expectedErr: "the batch/guardrails processor is already configured",
+		},
+		{
+			name: "self telemetry with an invalid endpoint",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeSidecar,
+					SelfTelemetry: SelfTelemetrySpec{OTLP: &SelfTelemetryOTLPSpec{Endpoint: "gateway:4318"}},
+				},
+			},
+			expectedErr: "the endpoint \"gateway:4318\" isn't an http or https URL",
+		},
+		{
+			name: "self telemetry with a duplicated signal",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					SelfTelemetry: SelfTelemetrySpec{OTLP: &SelfTelemetryOTLPSpec{
+						Endpoint: "http://gateway:4318",
+						Signals:  []SelfTelemetrySignal{SelfTelemetrySignalLogs, SelfTelemetrySignalLogs},
+					}},
+				},
+			},
+			expectedErr: "the logs signal is listed more than once",
+		},
+		{
+			name: "self telemetry with a negative metrics interval",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					SelfTelemetry: SelfTelemetrySpec{OTLP: &SelfTelemetryOTLPSpec{
+						Endpoint:        "http://gateway:4318",
+						MetricsInterval: &metav1.Duration{Duration: -time.Second},
+					}},
+				},
+			},
+			expectedErr: "the metrics interval must be positive",
+		},
+		{
+			name: "remote write wal in deployment mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					RemoteWrite: &RemoteWriteSpec{Endpoint: "http://prometheus:9090/api/v1/write", WAL: &RemoteWriteWALSpec{}},
+				},
+			},
+			expectedErr: "does not support the attribute 'remoteWrite.wal'",
+		},
+		{
+			name: "remote write with an invalid endpoint",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					RemoteWrite: &RemoteWriteSpec{Endpoint: "prometheus:9090"},
+				},
+			},
+			expectedErr: "the endpoint \"prometheus:9090\" isn't an http or https URL",
+		},
+		{
+			name: "remote write along with a prometheusremotewrite exporter",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					Config:      "exporters:\n  prometheusremotewrite:\n    endpoint: http://prometheus:9090/api/v1/write\n",
+					RemoteWrite: &RemoteWriteSpec{Endpoint: "http://prometheus:9090/api/v1/write"},
+				},
+			},
+			expectedErr: "the prometheusremotewrite exporter is already configured",
+		},
+		{
+			name: "remote write with an unknown pipeline",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					Config:      "service:\n  pipelines:\n    metrics:\n",
+					RemoteWrite: &RemoteWriteSpec{Endpoint: "http://prometheus:9090/api/v1/write", Pipelines: []string{"metrics/prometheus"}},
+				},
+			},
+			expectedErr: "the metrics/prometheus pipeline doesn't exist",
+		},
+		{
+			name: "remote write wal along with a volume claim template of the same name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeStatefulSet,
+					RemoteWrite:          &RemoteWriteSpec{Endpoint: "http://prometheus:9090/api/v1/write", WAL: &RemoteWriteWALSpec{}},
+					VolumeClaimTemplates: []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "remote-write-wal"}}},
+				},
+			},
+			expectedErr: "the remote-write-wal volume claim template is already defined",
+		},
+		{
+			name: "autoscaler schedules in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Autoscaler: &AutoscalerSpec{
+						Schedules: []AutoscalerSchedule{{Name: "night", Start: "0 20 * * *", Duration: metav1.Duration{Duration: time.Hour}, MaxReplicas: &one}},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'autoscaler.schedules'",
+		},
+		{
+			name: "invalid autoscaler schedule start",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Autoscaler: &AutoscalerSpec{
+						Schedules: []AutoscalerSchedule{{Name: "night", Start: "every night", Duration: metav1.Duration{Duration: time.Hour}, MaxReplicas: &one}},
+					},
+				},
+			},
+			expectedErr: "the start \"every night\" of the night schedule isn't a cron expression",
+		},
+		{
+			name: "unknown autoscaler schedule time zone",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Autoscaler: &AutoscalerSpec{
+						Schedules: []AutoscalerSchedule{{Name: "night", Start: "0 20 * * *", TimeZone: "Mars/Olympus", Duration: metav1.Duration{Duration: time.Hour}, MaxReplicas: &one}},
+					},
+				},
+			},
+			expectedErr: "the time zone \"Mars/Olympus\" of the night schedule is unknown",
+		},
+		{
+			name: "autoscaler schedule without bounds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Autoscaler: &AutoscalerSpec{
+						Schedules: []AutoscalerSchedule{{Name: "night", Start: "0 20 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+					},
+				},
+			},
+			expectedErr: "the night schedule must set minReplicas or maxReplicas",
+		},
+		{
+			name: "vertical autoscaler min allowed greater than max allowed",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{
+						UpdateMode: VerticalAutoscalerUpdateModeOff,
+						MinAllowed: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+						MaxAllowed: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+					},
+				},
+			},
+			expectedErr: "the minAllowed cpu 2 is greater than the maxAllowed 500m",
+		},
+		{
+			name: "valid config versions",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeDeployment,
+					ConfigVersions: &three,
+				},
+			},
+		},
+		{
+			name: "config versions in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeSidecar,
+					ConfigVersions: &three,
+				},
+			},
+			expectedErr: "does not support the attribute 'configVersions'",
+		},
+		{
+			name: "invalid config versions",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:           ModeDeployment,
+					ConfigVersions: &zero,
+				},
+			},
+			expectedErr: "configVersions should be at least 1",
+		},
+		{
+			name: "valid blue/green rollout strategy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDeployment,
+					RolloutStrategy: RolloutStrategyBlueGreen,
+					ConfigVersions:  &three,
+				},
+			},
+		},
+		{
+			name: "blue/green rollout strategy in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDaemonSet,
+					RolloutStrategy: RolloutStrategyBlueGreen,
+					ConfigVersions:  &three,
+				},
+			},
+			expectedErr: "does not support the rollout strategy blueGreen",
+		},
+		{
+			name: "blue/green rollout strategy without config versions",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDeployment,
+					RolloutStrategy: RolloutStrategyBlueGreen,
+				},
+			},
+			expectedErr: "requires configVersions of at least 2",
+		},
+		{
+			name: "valid istio",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeDeployment,
+					Istio: &IstioSpec{ReceiverMTLSMode: IstioMTLSModeStrict},
+				},
+			},
+		},
+		{
+			name: "istio in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeSidecar,
+					Istio: &IstioSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'istio'",
+		},
+		{
+			name: "invalid istio receiver mtls mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:  ModeDeployment,
+					Istio: &IstioSpec{ReceiverMTLSMode: "DISABLE"},
+				},
+			},
+			expectedErr: "the receiverMtlsMode should be PERMISSIVE or STRICT",
+		},
+		{
+			name: "valid load balancer health check",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LoadBalancerHealthCheck: &LoadBalancerHealthCheckSpec{Provider: LoadBalancerProviderAWS},
+					Config: `extensions:
+  health_check:
+service:
+  extensions: [health_check]
+`,
+				},
+			},
+		},
+		{
+			name: "load balancer health check in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                    ModeSidecar,
+					LoadBalancerHealthCheck: &LoadBalancerHealthCheckSpec{Provider: LoadBalancerProviderAWS},
+				},
+			},
+			expectedErr: "does not support the attribute 'loadBalancerHealthCheck'",
+		},
+		{
+			name: "load balancer health check without health_check extension",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LoadBalancerHealthCheck: &LoadBalancerHealthCheckSpec{Provider: LoadBalancerProviderAWS},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LoadBalancerHealthCheck configuration is incorrect",
+		},
+		{
+			name: "gcp load balancer health check without backend config",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LoadBalancerHealthCheck: &LoadBalancerHealthCheckSpec{Provider: LoadBalancerProviderGCP},
+				},
+			},
+			expectedErr: "the backendConfig is required with the gcp provider",
+		},
+		{
+			name: "share process namespace in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                  ModeSidecar,
+					ShareProcessNamespace: &shareProcessNamespace,
+				},
+			},
+			expectedErr: "does not support the attribute 'shareProcessNamespace'",
+		},
+		{
+			name: "dns config in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeSidecar,
+					DNSPolicy: v1.DNSDefault,
+				},
+			},
+			expectedErr: "does not support the attributes 'dnsPolicy' and 'podDnsConfig'",
+		},
+		{
+			name: "dns policy None without nameserver",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					DNSPolicy: v1.DNSNone,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec DNS configuration is incorrect, the DNS policy None requires at least one nameserver in podDnsConfig",
+		},
+		{
+			name: "target allocator dns policy None without nameserver",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						DNSPolicy:    v1.DNSNone,
+						PodDNSConfig: &v1.PodDNSConfig{Searches: []string{"example.com"}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec TargetAllocator DNS configuration is incorrect",
+		},
+		{
+			name: "invalid secret provider name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecretProviders: []SecretProvider{{Name: "Vault", SecretProviderClass: "vault-credentials"}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec SecretProviders configuration is incorrect, the name \"Vault\" is invalid",
+		},
+		{
+			name: "duplicate secret provider name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecretProviders: []SecretProvider{
+						{Name: "vault", SecretProviderClass: "vault-credentials"},
+						{Name: "vault", SecretProviderClass: "other-credentials"},
+					},
+				},
+			},
+			expectedErr: "the name \"vault\" is used by several secret providers",
+		},
+		{
+			name: "secret provider without class",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					SecretProviders: []SecretProvider{{Name: "vault"}},
+				},
+			},
+			expectedErr: "the secret provider \"vault\" has no secretProviderClass",
+		},
+		{
+			name: "secret provider in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeSidecar,
+					SecretProviders: []SecretProvider{{Name: "vault", SecretProviderClass: "vault-credentials"}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'secretProviders'",
+		},
+		{
+			name: "secret reference to another namespace",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Config: "exporters:\n  otlphttp:\n    headers:\n      api-key: ${secret:kube-system/vendor-credentials/api-key}\n",
+				},
+			},
+			expectedErr: "references the secret kube-system/vendor-credentials/api-key, which isn't in the default namespace",
+		},
+		{
+			name: "secret references sharing an env var",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Config: "exporters:\n  otlphttp:\n    headers:\n      api-key: ${secret:default/vendor.credentials/api-key}\n      token: ${secret:default/vendor-credentials/api-key}\n",
+				},
+			},
+			expectedErr: "references the secrets default/vendor-credentials/api-key and default/vendor.credentials/api-key, which can't be told apart",
+		},
+		{
+			name: "secret reference in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeSidecar,
+					Config: "exporters:\n  otlphttp:\n    headers:\n      api-key: ${secret:default/vendor-credentials/api-key}\n",
+				},
+			},
+			expectedErr: "does not support the secret references of the config",
+		},
+		{
+			name: "secret reference of a receiver creator template to another namespace",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled: true,
+						Receivers: map[string]ReceiverCreatorTemplate{
+							"redis": {
+								Rule:   `type == "pod"`,
+								Config: "password: ${secret:kube-system/redis/password}\n",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "references the secret kube-system/redis/password, which isn't in the default namespace",
+		},
+		{
+			name: "large config in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeSidecar,
+					Config: "receivers:\n  filelog:\n    include: [" + strings.Repeat("a", 1024*1024) + "]\n",
+				},
+			},
+			expectedErr: "the config of a sidecar can't be more than 1048576 bytes",
+		},
+		{
+			name: "large config with a component too large for a config map",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Config: "receivers:\n  filelog:\n    include: [" + strings.Repeat("a", 1024*1024) + "]\n",
+				},
+			},
+			expectedErr: "the receivers/filelog config is 1048618 bytes, more than the 1048576 bytes a config map can hold",
+		},
+		{
+			name: "invalid mode with receiver creator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled: true,
+					},
+				},
+			},
+			expectedErr: "does not support the receiver creator preset",
+		},
+		{
+			name: "valid receiver creator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled:   true,
+						Pipelines: []string{"metrics"},
+						Receivers: map[string]ReceiverCreatorTemplate{
+							"redis": {
+								Rule:   `type == "port" && port == 6379`,
+								Config: "collection_interval: 10s",
+							},
+						},
+					},
+					Config: `service:
+  pipelines:
+    metrics:
+`,
+				},
+			},
+		},
+		{
+			name: "invalid receiver creator rule",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled: true,
+						Receivers: map[string]ReceiverCreatorTemplate{
+							"redis": {
+								Rule: `port == 6379`,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the rule of the redis receiver must start with the endpoint type",
+		},
+		{
+			name: "receiver creator rule for nodes not observed",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled: true,
+						Receivers: map[string]ReceiverCreatorTemplate{
+							"kubeletstats": {
+								Rule: `type == "k8s.node"`,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "requires observeNodes",
+		},
+		{
+			name: "receiver creator unknown pipeline",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					ReceiverCreator: ReceiverCreatorSpec{
+						Enabled:   true,
+						Pipelines: []string{"logs"},
+						Receivers: map[string]ReceiverCreatorTemplate{
+							"redis": {
+								Rule: `type == "port"`,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the logs pipeline doesn't exist",
+		},
+		{
+			name: "invalid mode with hibernate",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeSidecar,
+					Hibernate: true,
+				},
+			},
+			expectedErr: "does not support the attribute 'hibernate'",
+		},
+		{
+			name: "invalid mode with configValidation",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:             ModeSidecar,
+					ConfigValidation: &ConfigValidationSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'configValidation'",
+		},
+		{
+			name: "invalid configValidation timeout",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ConfigValidation: &ConfigValidationSpec{
+						Timeout: &metav1.Duration{},
+					},
+				},
+			},
+			expectedErr: "the timeout must be positive",
+		},
+		{
+			name: "invalid scrapeOverrides minScrapeInterval",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						ScrapeOverrides: &TargetAllocatorScrapeOverrides{
+							MinScrapeInterval: &metav1.Duration{},
+						},
+					},
+				},
+			},
+			expectedErr: "the minScrapeInterval of the scrapeOverrides must be positive",
+		},
+		{
+			name: "allocationState persistentVolumeClaim with replicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Replicas: &three,
+						AllocationState: &TargetAllocatorAllocationState{
+							PersistentVolumeClaim: "my-allocation-state",
+						},
+					},
+				},
+			},
+			expectedErr: "allocationState can't be persisted in a PersistentVolumeClaim with more than one replica",
+		},
+		{
+			name: "allocationState persistentVolumeClaim with jobShards",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						JobShards: &three,
+						AllocationState: &TargetAllocatorAllocationState{
+							PersistentVolumeClaim: "my-allocation-state",
+						},
+					},
+				},
+			},
+			expectedErr: "allocationState can't be persisted in a PersistentVolumeClaim with jobShards",
+		},
+		{
+			name: "allocationState persistentVolumeClaim with zones",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a"}},
+						AllocationState: &TargetAllocatorAllocationState{
+							PersistentVolumeClaim: "my-allocation-state",
+						},
+					},
+				},
+			},
+			expectedErr: "allocationState can't be persisted in a PersistentVolumeClaim with topologyAware zones",
+		},
+		{
+			name: "invalid port name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							// this port name contains a non alphanumeric character, which is invalid.
+							Name:     "-test🦄port",
+							Port:     12345,
+							Protocol: v1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "invalid port name, too long",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							Name: "aaaabbbbccccdddd", // len: 16, too long
+							Port: 5555,
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "invalid port num",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							Name: "aaaabbbbccccddd", // len: 15
+							// no port set means it's 0, which is invalid
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "duplicate port",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							Name: "first",
+							Port: 5555,
+						},
+						{
+							Name:     "second",
+							Port:     5555,
+							Protocol: v1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			expectedErr: `the port "first" and the port "second" both listen on the port 5555/TCP`,
+		},
+		{
+			name: "receivers on the same port",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+  otlp/secondary:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, otlp/secondary]
+      exporters: [debug]
+`,
+				},
+			},
+			expectedErr: `the "otlp-grpc" port of the "otlp" receiver and the "otlp-secondary-grpc" port of the "otlp/secondary" receiver both listen on the port 4317/TCP`,
+		},
+		{
+			name: "receivers with the same truncated port name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  jaeger/collector-a:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:14250
+  jaeger/collector-b:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:14251
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [jaeger/collector-a, jaeger/collector-b]
+      exporters: [debug]
+`,
+				},
+			},
+			expectedErr: `both use the container port name "jaeger-collecto"`,
+		},
+		{
+			name: "invalid max replicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &zero,
+				},
+			},
+			expectedErr: "maxReplicas should be defined and one or more",
+		},
+		{
+			name: "invalid replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Replicas:    &five,
+				},
+			},
+			expectedErr: "replicas must not be greater than maxReplicas",
+		},
+		{
+			name: "invalid min replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &five,
+				},
+			},
+			expectedErr: "minReplicas must not be greater than maxReplicas",
+		},
+		{
+			name: "invalid min replicas, lesser than 1",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &zero,
+				},
+			},
+			expectedErr: "minReplicas should be one or more",
+		},
+		{
+			name: "invalid autoscaler scale down",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &minusOne,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleDown stabilizationWindowSeconds should be between 0 and 3600",
+		},
+		{
+			name: "invalid autoscaler scale up",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &moreThanHour,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleUp stabilizationWindowSeconds should be between 0 and 3600",
+		},
+		{
+			name: "invalid autoscaler scale down policy type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								Policies: []autoscalingv2.HPAScalingPolicy{{Type: "Replicas", Value: 1, PeriodSeconds: 60}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleDown policy type should be Pods or Percent",
+		},
+		{
+			name: "invalid autoscaler scale down policy value",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, PeriodSeconds: 60}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleDown policy value should be one or more",
+		},
+		{
+			name: "invalid autoscaler scale up policy period",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
+								Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 3600}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleUp policy periodSeconds should be between 1 and 1800",
+		},
+		{
+			name: "invalid autoscaler scale up select policy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
+								SelectPolicy: &invalidSelectPolicy,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleUp selectPolicy should be one of Max, Min or Disabled",
+		},
+		{
+			name: "invalid autoscaler target cpu utilization",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						TargetCPUUtilization: &zero,
+					},
+				},
+			},
+			expectedErr: "targetCPUUtilization should be greater than 0 and less than 100",
+		},
+		{
+			name: "autoscaler minReplicas is less than maxReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						MaxReplicas: &one,
+						MinReplicas: &five,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, minReplicas must not be greater than maxReplicas",
+		},
+		{
+			name: "invalid autoscaler metric type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.ResourceMetricSourceType,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, metric type unsupported. Expected metric of source type Pod",
+		},
+		{
+			name: "invalid pod metric average value",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{
+										Name: "custom1",
+									},
+									Target: autoscalingv2.MetricTarget{
+										Type:         autoscalingv2.AverageValueMetricType,
+										AverageValue: resource.NewQuantity(int64(0), resource.DecimalSI),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0",
+		},
+		{
+			name: "utilization target is not valid with pod metrics",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Metrics: []MetricSpec{
+							{
+								Type: autoscalingv2.PodsMetricSourceType,
+								Pods: &autoscalingv2.PodsMetricSource{
+									Metric: autoscalingv2.MetricIdentifier{
+										Name: "custom1",
+									},
+									Target: autoscalingv2.MetricTarget{
+										Type:               autoscalingv2.UtilizationMetricType,
+										AverageUtilization: &one,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, invalid pods target type",
+		},
+		{
+			name: "invalid deployment mode incompabible with ingress settings",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Ingress: Ingress{
+						Type: IngressTypeNginx,
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			),
+		},
+		{
+			name: "ingress ports with route type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:  IngressTypeRoute,
+						Ports: []IngressPort{{Name: "otlp-grpc", Path: "/"}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ingress configuration is incorrect, the ports can only be set with the ingress type",
+		},
+		{
+			name: "invalid ingress port hostname",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:  IngressTypeNginx,
+						Ports: []IngressPort{{Name: "otlp-grpc", Hostname: "otlp_example.com"}},
+					},
+				},
+			},
+			expectedErr: `the hostname "otlp_example.com" of the otlp-grpc port is invalid`,
+		},
+		{
+			name: "invalid ingress port path",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ingress: Ingress{
+						Type:  IngressTypeNginx,
+						Ports: []IngressPort{{Name: "otlp-grpc", Hostname: "*.example.com", Path: "otlp"}},
+					},
+				},
+			},
+			expectedErr: `the path "otlp" of the otlp-grpc port must start with /`,
+		},
+		{
+			name: "gRPC ports sharing the root path of a host",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+  otlp/internal:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4319
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, otlp/internal]
+      exporters: [logging]
+`,
+					Ingress: Ingress{
+						Type:     IngressTypeNginx,
+						Hostname: "example.com",
+					},
+				},
+			},
+			expectedErr: "the ports otlp-grpc and otlp-internal-grpc are both exposed by the path '/' of the host 'example.com', set a distinct hostname or path for one of them in spec.ingress.ports",
+		},
+		{
+			name: "ports sharing a path of a host",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+					Ingress: Ingress{
+						Type:  IngressTypeNginx,
+						Ports: []IngressPort{{Name: "otlp-http", Path: "/"}},
+					},
+				},
+			},
+			expectedErr: "the ports otlp-grpc and otlp-http are both exposed by the path '/' of the host ''",
+		},
+		{
+			name: "invalid mode with priorityClassName",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:              ModeSidecar,
+					PriorityClassName: "test-class",
+				},
+			},
+			expectedErr: "does not support the attribute 'priorityClassName'",
+		},
+		{
+			name: "invalid mode with affinity",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Affinity: &v1.Affinity{
+						NodeAffinity: &v1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+								NodeSelectorTerms: []v1.NodeSelectorTerm{
+									{
+										MatchExpressions: []v1.NodeSelectorRequirement{
+											{
+												Key:      "node",
+												Operator: v1.NodeSelectorOpIn,
+												Values:   []string{"test-node"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'affinity'",
+		},
+		{
+			name: "invalid InitialDelaySeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						InitialDelaySeconds: &minusOne,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe InitialDelaySeconds configuration is incorrect",
+		},
+		{
+			name: "invalid PeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						PeriodSeconds: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe PeriodSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid TimeoutSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						TimeoutSeconds: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe TimeoutSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid SuccessThreshold",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						SuccessThreshold: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe SuccessThreshold configuration is incorrect",
+		},
+		{
+			name: "invalid FailureThreshold",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						FailureThreshold: &zero,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe FailureThreshold configuration is incorrect",
+		},
+		{
+			name: "invalid TerminationGracePeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &Probe{
+						TerminationGracePeriodSeconds: &zero64,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec LivenessProbe TerminationGracePeriodSeconds configuration is incorrect",
+		},
+		{
+			name: "invalid mode with sidecarNamespaceSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                     ModeDeployment,
+					SidecarNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				},
+			},
+			expectedErr: "does not support the attribute 'sidecarNamespaceSelector'",
+		},
+		{
+			name: "invalid sidecarNamespaceSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					SidecarNamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Like"}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec SidecarNamespaceSelector configuration is incorrect",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.otelcol.validateCRDSpec()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookJobShards(t *testing.T) {
+	three := int32(3)
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode: ModeStatefulSet,
+			TargetAllocator: OpenTelemetryTargetAllocator{
+				Enabled:   true,
+				JobShards: &three,
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        scrape_interval: 10s
+`,
+		},
+	}
+
+	assert.NoError(t, otelcol.validateCRDSpec())
+
+	err := colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRewrite.ID(), true)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRewrite.ID(), false)
+	})
+
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "jobShards can't be used")
+}
+
+func TestOTELColValidatingWebhookJobShardsPrometheusCR(t *testing.T) {
+	three := int32(3)
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode: ModeStatefulSet,
+			TargetAllocator: OpenTelemetryTargetAllocator{
+				Enabled:   true,
+				JobShards: &three,
+				PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{
+					Enabled: true,
+				},
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        scrape_interval: 10s
+`,
+		},
+	}
+
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "jobShards can't be used with the prometheusCR")
+}
+
+func TestOTELColValidatingWebhookJobShardsWorkItems(t *testing.T) {
+	three := int32(3)
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode: ModeStatefulSet,
+			TargetAllocator: OpenTelemetryTargetAllocator{
+				Enabled:   true,
+				JobShards: &three,
+				WorkItems: []TargetAllocatorWorkItem{
+					{JobName: "sql-queries", Endpoint: "postgres:5432"},
+				},
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        scrape_interval: 10s
+`,
+		},
+	}
+
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "jobShards can't be used with the workItems")
+}
+
+func TestOTELColValidatingWebhookTopologyAware(t *testing.T) {
+	three := int32(3)
+	config := `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        scrape_interval: 10s
+`
+	for _, tt := range []struct {
+		name        string
+		spec        OpenTelemetryCollectorSpec
+		expectedErr string
+	}{
+		{
+			name: "valid zones",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a", "eu-west-1b"}},
+				},
+			},
+		},
+		{
+			name: "target allocator disabled",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a"}},
+				},
+			},
+			expectedErr: "the TargetAllocator should be enabled",
+		},
+		{
+			name: "job shards",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					JobShards:     &three,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a"}},
+				},
+			},
+			expectedErr: "the zones can't be used with jobShards",
+		},
+		{
+			name: "autoscaler",
+			spec: OpenTelemetryCollectorSpec{
+				Autoscaler: &AutoscalerSpec{MaxReplicas: &three},
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a"}},
+				},
+			},
+			expectedErr: "the zones can't be used with the autoscaler",
+		},
+		{
+			name: "vertical autoscaler",
+			spec: OpenTelemetryCollectorSpec{
+				VerticalAutoscaler: &VerticalAutoscalerSpec{UpdateMode: VerticalAutoscalerUpdateModeOff},
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a"}},
+				},
+			},
+			expectedErr: "the zones can't be used with the vertical autoscaler",
+		},
+		{
+			name: "no zones",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{},
+				},
+			},
+			expectedErr: "at least one zone should be set",
+		},
+		{
+			name: "invalid zone",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu west"}},
+				},
+			},
+			expectedErr: "the zone 'eu west' isn't a valid label value",
+		},
+		{
+			name: "duplicate zone",
+			spec: OpenTelemetryCollectorSpec{
+				TargetAllocator: OpenTelemetryTargetAllocator{
+					Enabled:       true,
+					TopologyAware: &TopologyAwareSpec{Zones: []string{"eu-west-1a", "eu-west-1a"}},
+				},
+			},
+			expectedErr: "the zone 'eu-west-1a' is set more than once",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{Spec: tt.spec}
+			otelcol.Spec.Mode = ModeStatefulSet
+			otelcol.Spec.Config = config
+			err := otelcol.validateCRDSpec()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookNodeProfiles(t *testing.T) {
+	gpu := map[string]string{"node-pool": "gpu"}
+	for _, tt := range []struct {
+		name        string
+		mode        Mode
+		vertical    *VerticalAutoscalerSpec
+		profiles    []NodeProfile
+		expectedErr string
+	}{
+		{
+			name: "valid profiles",
+			mode: ModeDaemonSet,
+			profiles: []NodeProfile{
+				{Name: "gpu", NodeSelector: gpu, Config: "receivers:\n  nvidia:\n"},
+				{Name: "spot", NodeSelector: map[string]string{"node-pool": "spot"}},
+			},
+		},
+		{
+			name:        "deployment mode",
+			mode:        ModeDeployment,
+			profiles:    []NodeProfile{{Name: "gpu", NodeSelector: gpu}},
+			expectedErr: "does not support the attribute 'nodeProfiles'",
+		},
+		{
+			name:        "vertical autoscaler",
+			mode:        ModeDaemonSet,
+			vertical:    &VerticalAutoscalerSpec{UpdateMode: VerticalAutoscalerUpdateModeOff},
+			profiles:    []NodeProfile{{Name: "gpu", NodeSelector: gpu}},
+			expectedErr: "the node profiles can't be used with the vertical autoscaler",
+		},
+		{
+			name:        "invalid name",
+			mode:        ModeDaemonSet,
+			profiles:    []NodeProfile{{Name: "1", NodeSelector: gpu}},
+			expectedErr: "the name '1' of the node profile is invalid",
+		},
+		{
+			name:        "duplicate name",
+			mode:        ModeDaemonSet,
+			profiles:    []NodeProfile{{Name: "gpu", NodeSelector: gpu}, {Name: "gpu", NodeSelector: gpu}},
+			expectedErr: "the node profile 'gpu' is set more than once",
+		},
+		{
+			name:        "no node selector",
+			mode:        ModeDaemonSet,
+			profiles:    []NodeProfile{{Name: "gpu"}},
+			expectedErr: "the node profile 'gpu' should have a node selector",
+		},
+		{
+			name:        "invalid config",
+			mode:        ModeDaemonSet,
+			profiles:    []NodeProfile{{Name: "gpu", NodeSelector: gpu, Config: "🦄"}},
+			expectedErr: "the configuration of the node profile 'gpu' is invalid",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               tt.mode,
+					VerticalAutoscaler: tt.vertical,
+					NodeProfiles:       tt.profiles,
+				},
+			}
+			err := otelcol.validateCRDSpec()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookHostPorts(t *testing.T) {
+	config := `receivers:
+  statsd:
+  syslog:
+    tcp:
+      listen_address: 0.0.0.0:54526
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [statsd]
+      exporters: [debug]
+    logs:
+      receivers: [syslog]
+      exporters: [debug]
+`
+	for _, tt := range []struct {
+		name        string
+		mode        Mode
+		hostNetwork bool
+		ports       []v1.ServicePort
+		hostPorts   []HostPortSpec
+		expectedErr string
+	}{
+		{
+			name:      "valid host ports",
+			mode:      ModeDaemonSet,
+			ports:     []v1.ServicePort{{Name: "custom", Port: 9000}},
+			hostPorts: []HostPortSpec{{Name: "statsd"}, {Name: "syslog", HostPort: 8125}, {Name: "custom"}},
+		},
+		{
+			name:      "metrics port of the collector",
+			mode:      ModeDaemonSet,
+			hostPorts: []HostPortSpec{{Name: "metrics", HostPort: 18888}},
+		},
+		{
+			name:        "metrics port bound with another port",
+			mode:        ModeDaemonSet,
+			ports:       []v1.ServicePort{{Name: "custom", Port: 9000}},
+			hostPorts:   []HostPortSpec{{Name: "metrics"}, {Name: "custom", HostPort: 8888}},
+			expectedErr: "the ports 'metrics' and 'custom' are both bound to the port 8888/TCP of the node",
+		},
+		{
+			name:        "deployment mode",
+			mode:        ModeDeployment,
+			hostPorts:   []HostPortSpec{{Name: "statsd"}},
+			expectedErr: "does not support the attribute 'hostPorts'",
+		},
+		{
+			name:        "unknown port",
+			mode:        ModeDaemonSet,
+			hostPorts:   []HostPortSpec{{Name: "otlp-grpc"}},
+			expectedErr: "the port 'otlp-grpc' isn't a port of the collector container",
+		},
+		{
+			name:        "conflicting host ports",
+			mode:        ModeDaemonSet,
+			ports:       []v1.ServicePort{{Name: "custom", Port: 9000, Protocol: v1.ProtocolUDP}},
+			hostPorts:   []HostPortSpec{{Name: "statsd"}, {Name: "custom", HostPort: 8125}},
+			expectedErr: "the ports 'statsd' and 'custom' are both bound to the port 8125/UDP of the node",
+		},
+		{
+			name:        "remapped port with the host network",
+			mode:        ModeDaemonSet,
+			hostNetwork: true,
+			hostPorts:   []HostPortSpec{{Name: "syslog", HostPort: 514}},
+			expectedErr: "the port 'syslog' listens on the port 54526 of the node with the host network",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        tt.mode,
+					HostNetwork: tt.hostNetwork,
+					Config:      config,
+					Ports:       tt.ports,
+					HostPorts:   tt.hostPorts,
+				},
+			}
+			err := otelcol.validateCRDSpec()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookSidecarTiers(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		mode        Mode
+		tiers       []SidecarTier
+		expectedErr string
+	}{
+		{
+			name: "valid tiers",
+			mode: ModeSidecar,
+			tiers: []SidecarTier{
+				{Name: "small", Config: "service:\n  pipelines:\n    traces:\n      processors: [batch]\n"},
+				{Name: "large", Resources: &v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}}},
+			},
+		},
+		{
+			name:        "deployment mode",
+			mode:        ModeDeployment,
+			tiers:       []SidecarTier{{Name: "small"}},
+			expectedErr: "does not support the attribute 'sidecarTiers'",
+		},
+		{
+			name:        "invalid name",
+			mode:        ModeSidecar,
+			tiers:       []SidecarTier{{Name: "Small"}},
+			expectedErr: "the name 'Small' of the sidecar tier is invalid",
+		},
+		{
+			name:        "duplicate name",
+			mode:        ModeSidecar,
+			tiers:       []SidecarTier{{Name: "small"}, {Name: "small"}},
+			expectedErr: "the sidecar tier 'small' is set more than once",
+		},
+		{
+			name:        "invalid config",
+			mode:        ModeSidecar,
+			tiers:       []SidecarTier{{Name: "small", Config: "🦄"}},
+			expectedErr: "the configuration of the sidecar tier 'small' is invalid",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:         tt.mode,
+					SidecarTiers: tt.tiers,
+				},
+			}
+			err := otelcol.validateCRDSpec()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookNameOverride(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			NameOverride: "otel",
+		},
+	}
+	assert.NoError(t, otelcol.validateCRDSpec())
+
+	otelcol.Spec.NameOverride = "1-otel"
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "the OpenTelemetry Spec NameOverride configuration is incorrect, '1-otel' is invalid")
+
+	otelcol.Spec.NameOverride = "otel.collector"
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "the OpenTelemetry Spec NameOverride configuration is incorrect, 'otel.collector' is invalid")
+}
+
+func TestOTELColValidatingWebhookFIPS(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode: ModeSidecar,
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          min_version: "1.2"
+exporters:
+  otlp:
+    endpoint: gateway:4317
+`,
+			SidecarTiers: []SidecarTier{
+				{Name: "legacy", Config: "exporters:\n  otlp:\n    tls:\n      min_version: \"1.0\"\n"},
+			},
+		},
+	}
+
+	assert.NoError(t, otelcol.validateCRDSpec())
+
+	err := colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), true)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), false)
+	})
+
+	assert.ErrorContains(t, otelcol.validateCRDSpec(), "the OpenTelemetry Collector config isn't FIPS compliant: exporters.otlp.tls.min_version is 1.0")
+
+	otelcol.Spec.SidecarTiers = nil
+	assert.NoError(t, otelcol.validateCRDSpec())
+}
+
+func TestOTELColValidatingWebhookCompatibilityMode(t *testing.T) {
+	logs := v1.Volume{Name: "logs", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log/pods"}}}
+	for _, tt := range []struct {
+		name          string
+		compatibility CompatibilityMode
+		mode          Mode
+		volumes       []v1.Volume
+		volumeMounts  []v1.VolumeMount
+		profiles      []NodeProfile
+		expectedErr   string
+	}{
+		{
+			name:          "autopilot daemonset reading the logs",
+			compatibility: CompatibilityModeGKEAutopilot,
+			mode:          ModeDaemonSet,
+			volumes:       []v1.Volume{logs},
+			volumeMounts:  []v1.VolumeMount{{Name: "logs", MountPath: "/var/log/pods", ReadOnly: true}},
+		},
+		{
+			name:          "fargate deployment",
+			compatibility: CompatibilityModeEKSFargate,
+			mode:          ModeDeployment,
+		},
+		{
+			name:          "unknown mode",
+			compatibility: "aks-virtual-nodes",
+			mode:          ModeDeployment,
+			expectedErr:   "the compatibilityMode should be gke-autopilot or eks-fargate",
+		},
+		{
+			name:          "fargate daemonset",
+			compatibility: CompatibilityModeEKSFargate,
+			mode:          ModeDaemonSet,
+			expectedErr:   "eks-fargate doesn't run daemonsets, use the deployment or statefulset mode",
+		},
+		{
+			name:          "fargate hostPath",
+			compatibility: CompatibilityModeEKSFargate,
+			mode:          ModeDeployment,
+			volumes:       []v1.Volume{logs},
+			expectedErr:   "eks-fargate doesn't allow the hostPath volume 'logs'",
+		},
+		{
+			name:          "autopilot hostPath outside of the logs",
+			compatibility: CompatibilityModeGKEAutopilot,
+			mode:          ModeDaemonSet,
+			volumes: []v1.Volume{
+				{Name: "proc", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/proc"}}},
+			},
+			expectedErr: "gke-autopilot only allows the hostPath volumes under /var/log, the volume 'proc' mounts /proc",
+		},
+		{
+			name:          "autopilot writable hostPath",
+			compatibility: CompatibilityModeGKEAutopilot,
+			mode:          ModeDaemonSet,
+			volumes:       []v1.Volume{logs},
+			volumeMounts:  []v1.VolumeMount{{Name: "logs", MountPath: "/var/log/pods"}},
+			expectedErr:   "set readOnly on the mount of the volume 'logs' at /var/log/pods",
+		},
+		{
+			name:          "node profile on a reserved label",
+			compatibility: CompatibilityModeGKEAutopilot,
+			mode:          ModeDaemonSet,
+			profiles:      []NodeProfile{{Name: "arm", NodeSelector: map[string]string{"cloud.google.com/gke-nodepool": "arm"}}},
+			expectedErr:   "gke-autopilot manages the node label 'cloud.google.com/gke-nodepool' the node profile 'arm' selects",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:              tt.mode,
+					CompatibilityMode: tt.compatibility,
+					Volumes:           tt.volumes,
+					VolumeMounts:      tt.volumeMounts,
+					NodeProfiles:      tt.profiles,
+				},
+			}
+			err := otelcol.validateCRDSpec()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOTELColCompatibilityWarnings(t *testing.T) {
+	privileged := true
+	for _, tt := range []struct {
+		name          string
+		compatibility CompatibilityMode
+		spec          OpenTelemetryCollectorSpec
+		expected      []string
+	}{
+		{
+			name: "without compatibility mode",
+			spec: OpenTelemetryCollectorSpec{HostNetwork: true},
+		},
+		{
+			name:          "compatible instance",
+			compatibility: CompatibilityModeGKEAutopilot,
+			spec:          OpenTelemetryCollectorSpec{NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"}},
+		},
+		{
+			name:          "host access",
+			compatibility: CompatibilityModeEKSFargate,
+			spec: OpenTelemetryCollectorSpec{
+				HostNetwork:     true,
+				SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+				NodeSelector: map[string]string{
+					"kubernetes.io/hostname":         "node-1",
+					"eks.amazonaws.com/compute-type": "fargate",
+					"eks.amazonaws.com/nodegroup":    "collectors",
+				},
+			},
+			expected: []string{
+				"eks-fargate doesn't allow the host network, the collector pods use the pod network instead: expose the receivers through the service ports",
+				"eks-fargate doesn't allow privileged containers, the collector container runs unprivileged instead",
+				"eks-fargate manages the node labels eks.amazonaws.com/nodegroup, kubernetes.io/hostname, the collector pods don't select the nodes on them",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{Spec: tt.spec}
+			otelcol.Spec.CompatibilityMode = tt.compatibility
+			assert.Equal(t, tt.expected, []string(otelcol.compatibilityWarnings()))
+		})
+	}
+}
+
+func TestOTELColVerticalAutoscalerWarnings(t *testing.T) {
+	five := int32(5)
+	ninety := int32(90)
+	for _, tt := range []struct {
+		name       string
+		mode       VerticalAutoscalerUpdateMode
+		autoscaler *AutoscalerSpec
+		expected   []string
+	}{
+		{
+			name: "without autoscaler",
+			mode: VerticalAutoscalerUpdateModeAuto,
+		},
+		{
+			name:       "recommendations only",
+			mode:       VerticalAutoscalerUpdateModeOff,
+			autoscaler: &AutoscalerSpec{MaxReplicas: &five, TargetCPUUtilization: &ninety},
+		},
+		{
+			name:       "autoscaler on custom metrics",
+			mode:       VerticalAutoscalerUpdateModeAuto,
+			autoscaler: &AutoscalerSpec{MaxReplicas: &five, Metrics: []MetricSpec{{Type: autoscalingv2.PodsMetricSourceType}}},
+		},
+		{
+			name:       "autoscaler on memory utilization",
+			mode:       VerticalAutoscalerUpdateModeInitial,
+			autoscaler: &AutoscalerSpec{MaxReplicas: &five, TargetMemoryUtilization: &ninety},
+			expected: []string{
+				"the vertical autoscaler applies its recommendations in Initial mode while the autoscaler scales the collector on the CPU or memory utilization, which is relative to the requests the vertical autoscaler changes: the two may keep reacting to each other",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeDeployment,
+					Autoscaler:         tt.autoscaler,
+					VerticalAutoscaler: &VerticalAutoscalerSpec{UpdateMode: tt.mode},
+				},
+			}
+			assert.Equal(t, tt.expected, []string(otelcol.verticalAutoscalerWarnings()))
+		})
+	}
+}
+
+func TestOTELColGuardrailsWarnings(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:       ModeDeployment,
+			Guardrails: &GuardrailsSpec{},
+		},
+	}
+	assert.Len(t, otelcol.guardrailsWarnings(), 1)
+
+	otelcol.Spec.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}
+	assert.Empty(t, otelcol.guardrailsWarnings())
+}
+
+func TestOTELColLegacyReceiversWarnings(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  opencensus:
+  jaeger:
+    protocols:
+      grpc:
+    remote_sampling:
+      host_endpoint: 0.0.0.0:5778
+`,
+		},
+	}
+
+	for _, enabled := range []bool{true, false} {
+		originalVal := featuregate.EnableLegacyReceivers.IsEnabled()
+		assert.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), enabled))
+
+		warnings := otelcol.legacyReceiversWarnings()
+
+		assert.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), originalVal))
+		if enabled {
+			assert.Equal(t, []string{
+				"receivers.jaeger.remote_sampling is deprecated, migrate to the jaegerremotesampling extension",
+				"receivers.opencensus is deprecated, migrate to the otlp receiver",
+			}, []string(warnings))
+		} else {
+			assert.Equal(t, []string{
+				"receivers.jaeger.remote_sampling is deprecated, migrate to the jaegerremotesampling extension: the operator doesn't infer its ports, list them in spec.ports",
+				"receivers.opencensus is deprecated, migrate to the otlp receiver: the operator doesn't infer its ports, list them in spec.ports",
+			}, []string(warnings))
+		}
+	}
+
+	otelcol.Spec.Config = "receivers:\n  otlp:\n"
+	assert.Empty(t, otelcol.legacyReceiversWarnings())
+}
+
+func TestOTELColIngressWarnings(t *testing.T) {
+	nginx := "nginx"
+	traefik := "traefik"
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+  jaeger:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      exporters: [logging]
+`
+	for _, tt := range []struct {
+		name     string
+		ingress  Ingress
+		expected []string
+	}{
+		{
+			name: "nginx with TLS and gRPC hosts",
+			ingress: Ingress{
+				Type:             IngressTypeNginx,
+				IngressClassName: &nginx,
+				TLS:              []networkingv1.IngressTLS{{SecretName: "collector-tls"}},
+				Ports: []IngressPort{
+					{Name: "jaeger-grpc", Hostname: "jaeger.example.com"},
+				},
+			},
+		},
+		{
+			name: "nginx without TLS",
+			ingress: Ingress{
+				Type:             IngressTypeNginx,
+				IngressClassName: &nginx,
+				Ports: []IngressPort{
+					{Name: "jaeger-grpc", Path: "/jaeger.api_v2.CollectorService"},
+				},
+			},
+			expected: []string{
+				"ingress-nginx only serves HTTP/2, which gRPC requires, over TLS, the gRPC ports jaeger-grpc, otlp-grpc can't be reached without spec.ingress.tls",
+			},
+		},
+		{
+			name: "unknown class",
+			ingress: Ingress{
+				Type:             IngressTypeNginx,
+				IngressClassName: &traefik,
+				Hostname:         "example.com",
+				Ports: []IngressPort{
+					{Name: "jaeger-grpc", Hostname: "jaeger.example.com", Annotations: map[string]string{"some.key": "some.value"}},
+				},
+			},
+			expected: []string{
+				"the ingress class 'traefik' isn't known to support gRPC backends, the annotations enabling them must be set for the gRPC ports otlp-grpc in spec.ingress.ports",
+			},
+		},
+		{
+			name: "route",
+			ingress: Ingress{
+				Type: IngressTypeRoute,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:    ModeDeployment,
+					Config:  config,
+					Ingress: tt.ingress,
+				},
+			}
+			assert.Equal(t, tt.expected, []string(otelcol.ingressWarnings()))
+		})
+	}
+}
+
+func TestOTELColReceiverEndpointsWarnings(t *testing.T) {
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+      http:
+        endpoint: 0.0.0.0:4318
+  zipkin:
+    endpoint: 127.0.0.1:9411
+`
+	for _, tt := range []struct {
+		desc     string
+		mode     Mode
+		policy   ReceiverEndpointsPolicy
+		expected []string
+	}{
+		{
+			desc:   "deployment with the default policy",
+			mode:   ModeDeployment,
+			policy: DefaultReceiverEndpoints(ModeDeployment),
+		},
+		{
+			desc: "deployment without a policy, created before it existed",
+			mode: ModeDeployment,
+			expected: []string{
+				"receivers.otlp.protocols.grpc.endpoint listens on localhost:4317, which isn't reachable through the services of the collector: set spec.receiverEndpoints to all-interfaces or pod-ip",
+				"receivers.zipkin.endpoint listens on 127.0.0.1:9411, which isn't reachable through the services of the collector: set spec.receiverEndpoints to all-interfaces or pod-ip",
+			},
+		},
+		{
+			desc:   "deployment keeping the endpoints",
+			mode:   ModeDeployment,
+			policy: ReceiverEndpointsKeep,
+			expected: []string{
+				"receivers.otlp.protocols.grpc.endpoint listens on localhost:4317, which isn't reachable through the services of the collector: set spec.receiverEndpoints to all-interfaces or pod-ip",
+				"receivers.zipkin.endpoint listens on 127.0.0.1:9411, which isn't reachable through the services of the collector: set spec.receiverEndpoints to all-interfaces or pod-ip",
+			},
+		},
+		{
+			desc: "sidecar with the default policy",
+			mode: ModeSidecar,
+		},
+		{
+			desc:   "sidecar listening on all the interfaces",
+			mode:   ModeSidecar,
+			policy: ReceiverEndpointsAllInterfaces,
+		},
+		{
+			desc:   "sidecar listening on the pod IP",
+			mode:   ModeSidecar,
+			policy: ReceiverEndpointsPodIP,
+			expected: []string{
+				"receivers.otlp.protocols.grpc.endpoint is rewritten to the IP of the pod, which the application containers can't reach over localhost: set spec.receiverEndpoints to keep or all-interfaces",
+				"receivers.zipkin.endpoint is rewritten to the IP of the pod, which the application containers can't reach over localhost: set spec.receiverEndpoints to keep or all-interfaces",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{Mode: tt.mode, ReceiverEndpoints: tt.policy, Config: config},
+			}
+			assert.Equal(t, tt.expected, []string(otelcol.receiverEndpointsWarnings()))
 		})
 	}
 }
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// ProxySpec defines the proxy the containers managed by the operator send their outgoing traffic through.
+type ProxySpec struct {
+	// HTTPProxy is the proxy for HTTP requests, set as the HTTP_PROXY environment variable.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy for HTTPS requests, set as the HTTPS_PROXY environment variable.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the comma-separated list of hosts, domains and CIDRs that aren't reached through
+	// the proxy, set as the NO_PROXY environment variable.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
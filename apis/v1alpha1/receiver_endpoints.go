@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ReceiverEndpointsPolicy represents how the receivers listening on the loopback interface are rewritten.
+	// +kubebuilder:validation:Enum=keep;all-interfaces;pod-ip
+	ReceiverEndpointsPolicy string
+)
+
+const (
+	// ReceiverEndpointsKeep specifies that the endpoints of the receivers are left as configured.
+	ReceiverEndpointsKeep ReceiverEndpointsPolicy = "keep"
+	// ReceiverEndpointsAllInterfaces specifies that the receivers listening on the loopback interface listen on
+	// 0.0.0.0 instead.
+	ReceiverEndpointsAllInterfaces ReceiverEndpointsPolicy = "all-interfaces"
+	// ReceiverEndpointsPodIP specifies that the receivers listening on the loopback interface listen on the IP of
+	// the pod instead, expanded from the POD_IP environment variable.
+	ReceiverEndpointsPodIP ReceiverEndpointsPolicy = "pod-ip"
+)
+
+// Effective returns the policy applied to the collector. The instances without a policy, created before it existed,
+// keep their endpoints, the webhook setting the default policy of the instances as they're created.
+func (p ReceiverEndpointsPolicy) Effective() ReceiverEndpointsPolicy {
+	if p == "" {
+		return ReceiverEndpointsKeep
+	}
+	return p
+}
+
+// DefaultReceiverEndpoints returns the policy of the instances created in the given mode: the sidecars keep the
+// endpoints, as the application containers send to them over the loopback interface, and the other collectors
+// listen on all the interfaces, to be reachable through their services.
+func DefaultReceiverEndpoints(mode Mode) ReceiverEndpointsPolicy {
+	if mode == ModeSidecar {
+		return ReceiverEndpointsKeep
+	}
+	return ReceiverEndpointsAllInterfaces
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// RolloutStrategy represents how the changes of the collector Deployment are rolled out.
+	// +kubebuilder:validation:Enum=rollingUpdate;blueGreen
+	RolloutStrategy string
+)
+
+const (
+	// RolloutStrategyRollingUpdate specifies that the collector pods are replaced in place by a rolling update.
+	RolloutStrategyRollingUpdate RolloutStrategy = "rollingUpdate"
+
+	// RolloutStrategyBlueGreen specifies that the changes are rolled out to a parallel Deployment, which the Services
+	// are switched to once it's ready, before the previous Deployment is deleted.
+	RolloutStrategyBlueGreen RolloutStrategy = "blueGreen"
+)
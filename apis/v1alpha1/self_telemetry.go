@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// SelfTelemetryProtocol represents the OTLP protocol the collector pushes its own telemetry with.
+	// +kubebuilder:validation:Enum=grpc;http/protobuf
+	SelfTelemetryProtocol string
+
+	// SelfTelemetrySignal represents a signal of the collector's own telemetry.
+	// +kubebuilder:validation:Enum=metrics;logs;traces
+	SelfTelemetrySignal string
+)
+
+const (
+	// SelfTelemetryProtocolGRPC specifies that the telemetry is pushed with OTLP over gRPC.
+	SelfTelemetryProtocolGRPC SelfTelemetryProtocol = "grpc"
+	// SelfTelemetryProtocolHTTPProtobuf specifies that the telemetry is pushed with OTLP over HTTP, encoded as protobuf.
+	SelfTelemetryProtocolHTTPProtobuf SelfTelemetryProtocol = "http/protobuf"
+)
+
+const (
+	// SelfTelemetrySignalMetrics specifies the collector's own metrics.
+	SelfTelemetrySignalMetrics SelfTelemetrySignal = "metrics"
+	// SelfTelemetrySignalLogs specifies the collector's own logs.
+	SelfTelemetrySignalLogs SelfTelemetrySignal = "logs"
+	// SelfTelemetrySignalTraces specifies the collector's own traces.
+	SelfTelemetrySignalTraces SelfTelemetrySignal = "traces"
+)
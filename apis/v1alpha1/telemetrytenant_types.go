@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TelemetryTenantSpec defines the namespaces of a tenant and the backend their telemetry is routed to by a shared
+// gateway collector.
+type TelemetryTenantSpec struct {
+	// Collector is the name of the gateway OpenTelemetryCollector routing the telemetry of the tenant, in the
+	// namespace of the TelemetryTenant.
+	// +required
+	Collector string `json:"collector"`
+
+	// Namespaces are the namespaces of the tenant, whose telemetry is identified by its k8s.namespace.name resource
+	// attribute.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the tenant by their labels, in addition to the listed ones. The
+	// routing table of the collector is regenerated as the selected namespaces come and go. It can't be empty, which
+	// would select all the namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Backend is where the telemetry of the tenant is exported to.
+	// +required
+	Backend TelemetryTenantBackend `json:"backend"`
+}
+
+// TelemetryTenantBackend defines the OTLP backend the telemetry of a tenant is exported to.
+type TelemetryTenantBackend struct {
+	// Endpoint is the OTLP/gRPC endpoint of the backend.
+	// +required
+	Endpoint string `json:"endpoint"`
+
+	// CredentialsSecret is the key of the secret, in the namespace of the TelemetryTenant, holding the credentials
+	// sent to the backend in the credentials header.
+	// +optional
+	CredentialsSecret *corev1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+
+	// CredentialsHeader is the header the credentials are sent in. Authorization by default.
+	// +optional
+	// +kubebuilder:default=Authorization
+	CredentialsHeader string `json:"credentialsHeader,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=oteltenant;oteltenants
+// +kubebuilder:printcolumn:name="Collector",type="string",JSONPath=".spec.collector"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.backend.endpoint"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Telemetry Tenant"
+
+// TelemetryTenant is the spec for a tenant of a shared gateway collector, whose telemetry is routed to the backend of
+// the tenant.
+type TelemetryTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TelemetryTenantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TelemetryTenantList contains a list of TelemetryTenant.
+type TelemetryTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TelemetryTenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TelemetryTenant{}, &TelemetryTenantList{})
+}
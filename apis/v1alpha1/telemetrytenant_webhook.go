@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var telemetrytenantlog = logf.Log.WithName("telemetrytenant-resource")
+
+func (r *TelemetryTenant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-telemetrytenant,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=telemetrytenants,versions=v1alpha1,name=vtelemetrytenantcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
+
+var _ webhook.Validator = &TelemetryTenant{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *TelemetryTenant) ValidateCreate() (admission.Warnings, error) {
+	telemetrytenantlog.Info("validate create", "name", r.Name)
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *TelemetryTenant) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	telemetrytenantlog.Info("validate update", "name", r.Name)
+	return nil, r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *TelemetryTenant) ValidateDelete() (admission.Warnings, error) {
+	telemetrytenantlog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
+
+func (r *TelemetryTenant) validate() error {
+	selector := r.Spec.NamespaceSelector
+	if selector == nil {
+		return nil
+	}
+	// an empty selector selects all the namespaces, which would claim the namespaces of all the other tenants
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return fmt.Errorf("the TelemetryTenant spec.namespaceSelector is empty, it would select all the namespaces")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return fmt.Errorf("the TelemetryTenant spec.namespaceSelector is invalid: %w", err)
+	}
+	return nil
+}
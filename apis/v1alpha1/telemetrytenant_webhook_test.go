@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTelemetryTenantValidatingWebhook(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		selector *metav1.LabelSelector
+		err      string
+	}{
+		{
+			name: "listed namespaces",
+		},
+		{
+			name:     "namespace selector",
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-a"}},
+		},
+		{
+			name:     "empty namespace selector",
+			selector: &metav1.LabelSelector{},
+			err:      "the TelemetryTenant spec.namespaceSelector is empty, it would select all the namespaces",
+		},
+		{
+			name:     "invalid namespace selector",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}}},
+			err:      "the TelemetryTenant spec.namespaceSelector is invalid",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := TelemetryTenant{
+				Spec: TelemetryTenantSpec{
+					Collector:         "gateway",
+					Namespaces:        []string{"shop"},
+					NamespaceSelector: tt.selector,
+				},
+			}
+			_, err := tenant.ValidateCreate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			_, err = tenant.ValidateUpdate(&tenant)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// VerticalAutoscalerUpdateMode represents how the recommendations of the VerticalPodAutoscaler are applied.
+	// +kubebuilder:validation:Enum=Off;Initial;Auto
+	VerticalAutoscalerUpdateMode string
+)
+
+const (
+	// VerticalAutoscalerUpdateModeOff specifies that the recommendations are only computed, not applied.
+	VerticalAutoscalerUpdateModeOff VerticalAutoscalerUpdateMode = "Off"
+	// VerticalAutoscalerUpdateModeInitial specifies that the recommendations are applied to the pods when they are created.
+	VerticalAutoscalerUpdateModeInitial VerticalAutoscalerUpdateMode = "Initial"
+	// VerticalAutoscalerUpdateModeAuto specifies that the recommendations are applied to the pods when they are created,
+	// and that the pods whose resources are too far from them are evicted.
+	VerticalAutoscalerUpdateModeAuto VerticalAutoscalerUpdateMode = "Auto"
+)
@@ -23,9 +23,30 @@ import (
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSIdentitySpec) DeepCopyInto(out *AWSIdentitySpec) {
+	*out = *in
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSIdentitySpec.
+func (in *AWSIdentitySpec) DeepCopy() *AWSIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApacheHttpd) DeepCopyInto(out *ApacheHttpd) {
 	*out = *in
@@ -56,6 +77,32 @@ func (in *ApacheHttpd) DeepCopy() *ApacheHttpd {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerSchedule) DeepCopyInto(out *AutoscalerSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerSchedule.
+func (in *AutoscalerSchedule) DeepCopy() *AutoscalerSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 	*out = *in
@@ -91,6 +138,13 @@ func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AutoscalerSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerSpec.
@@ -103,6 +157,56 @@ func (in *AutoscalerSpec) DeepCopy() *AutoscalerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilitySpec) DeepCopyInto(out *AvailabilitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilitySpec.
+func (in *AvailabilitySpec) DeepCopy() *AvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIdentitySpec) DeepCopyInto(out *AzureIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIdentitySpec.
+func (in *AzureIdentitySpec) DeepCopy() *AzureIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigValidationSpec) DeepCopyInto(out *ConfigValidationSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigValidationSpec.
+func (in *ConfigValidationSpec) DeepCopy() *ConfigValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DotNet) DeepCopyInto(out *DotNet) {
 	*out = *in
@@ -141,6 +245,56 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterTLSSpec) DeepCopyInto(out *ExporterTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterTLSSpec.
+func (in *ExporterTLSSpec) DeepCopy() *ExporterTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterTokenSpec) DeepCopyInto(out *ExporterTokenSpec) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterTokenSpec.
+func (in *ExporterTokenSpec) DeepCopy() *ExporterTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPIdentitySpec) DeepCopyInto(out *GCPIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPIdentitySpec.
+func (in *GCPIdentitySpec) DeepCopy() *GCPIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Go) DeepCopyInto(out *Go) {
 	*out = *in
@@ -164,6 +318,121 @@ func (in *Go) DeepCopy() *Go {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsFilterSpec) DeepCopyInto(out *GuardrailsFilterSpec) {
+	*out = *in
+	if in.Spans != nil {
+		in, out := &in.Spans, &out.Spans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogRecords != nil {
+		in, out := &in.LogRecords, &out.LogRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsFilterSpec.
+func (in *GuardrailsFilterSpec) DeepCopy() *GuardrailsFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsSpec) DeepCopyInto(out *GuardrailsSpec) {
+	*out = *in
+	if in.MemoryLimitPercentage != nil {
+		in, out := &in.MemoryLimitPercentage, &out.MemoryLimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemorySpikeLimitPercentage != nil {
+		in, out := &in.MemorySpikeLimitPercentage, &out.MemorySpikeLimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SendBatchSize != nil {
+		in, out := &in.SendBatchSize, &out.SendBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SendBatchMaxSize != nil {
+		in, out := &in.SendBatchMaxSize, &out.SendBatchMaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchTimeout != nil {
+		in, out := &in.BatchTimeout, &out.BatchTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TraceSamplingPercentage != nil {
+		in, out := &in.TraceSamplingPercentage, &out.TraceSamplingPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(GuardrailsFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsSpec.
+func (in *GuardrailsSpec) DeepCopy() *GuardrailsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPortSpec) DeepCopyInto(out *HostPortSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPortSpec.
+func (in *HostPortSpec) DeepCopy() *HostPortSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPortSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+	if in.Digests != nil {
+		in, out := &in.Digests, &out.Digests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
@@ -186,6 +455,18 @@ func (in *Ingress) DeepCopyInto(out *Ingress) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]IngressPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = make([]IngressSignal, len(*in))
+		copy(*out, *in)
+	}
 	out.Route = in.Route
 }
 
@@ -199,6 +480,28 @@ func (in *Ingress) DeepCopy() *Ingress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressPort) DeepCopyInto(out *IngressPort) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressPort.
+func (in *IngressPort) DeepCopy() *IngressPort {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instrumentation) DeepCopyInto(out *Instrumentation) {
 	*out = *in
@@ -276,6 +579,11 @@ func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
 	in.Java.DeepCopyInto(&out.Java)
 	in.NodeJS.DeepCopyInto(&out.NodeJS)
 	in.Python.DeepCopyInto(&out.Python)
@@ -309,6 +617,21 @@ func (in *InstrumentationStatus) DeepCopy() *InstrumentationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioSpec) DeepCopyInto(out *IstioSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioSpec.
+func (in *IstioSpec) DeepCopy() *IstioSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Java) DeepCopyInto(out *Java) {
 	*out = *in
@@ -333,69 +656,156 @@ func (in *Java) DeepCopy() *Java {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+func (in *LastKnownGoodStatus) DeepCopyInto(out *LastKnownGoodStatus) {
 	*out = *in
-	if in.Pods != nil {
-		in, out := &in.Pods, &out.Pods
-		*out = new(v2.PodsMetricSource)
-		(*in).DeepCopyInto(*out)
-	}
+	in.RecordedTime.DeepCopyInto(&out.RecordedTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
-func (in *MetricSpec) DeepCopy() *MetricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastKnownGoodStatus.
+func (in *LastKnownGoodStatus) DeepCopy() *LastKnownGoodStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricSpec)
+	out := new(LastKnownGoodStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeJS) DeepCopyInto(out *NodeJS) {
+func (in *LegacyAgent) DeepCopyInto(out *LegacyAgent) {
 	*out = *in
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeJS.
-func (in *NodeJS) DeepCopy() *NodeJS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegacyAgent.
+func (in *LegacyAgent) DeepCopy() *LegacyAgent {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeJS)
+	out := new(LegacyAgent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenShiftRoute) DeepCopyInto(out *OpenShiftRoute) {
+func (in *LoadBalancerHealthCheckSpec) DeepCopyInto(out *LoadBalancerHealthCheckSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenShiftRoute.
-func (in *OpenShiftRoute) DeepCopy() *OpenShiftRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheckSpec.
+func (in *LoadBalancerHealthCheckSpec) DeepCopy() *LoadBalancerHealthCheckSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenShiftRoute)
+	out := new(LoadBalancerHealthCheckSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenTelemetryCollector) DeepCopyInto(out *OpenTelemetryCollector) {
+func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(v2.PodsMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
+func (in *MetricSpec) DeepCopy() *MetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeJS) DeepCopyInto(out *NodeJS) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeJS.
+func (in *NodeJS) DeepCopy() *NodeJS {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeJS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProfile) DeepCopyInto(out *NodeProfile) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProfile.
+func (in *NodeProfile) DeepCopy() *NodeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenShiftRoute) DeepCopyInto(out *OpenShiftRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenShiftRoute.
+func (in *OpenShiftRoute) DeepCopy() *OpenShiftRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenShiftRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryCollector) DeepCopyInto(out *OpenTelemetryCollector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -482,11 +892,26 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(AvailabilitySpec)
+		**out = **in
+	}
 	if in.Autoscaler != nil {
 		in, out := &in.Autoscaler, &out.Autoscaler
 		*out = new(AutoscalerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VerticalAutoscaler != nil {
+		in, out := &in.VerticalAutoscaler, &out.VerticalAutoscaler
+		*out = new(VerticalAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Istio != nil {
+		in, out := &in.Istio, &out.Istio
+		*out = new(IstioSpec)
+		**out = **in
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(v1.SecurityContext)
@@ -505,6 +930,50 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		}
 	}
 	in.TargetAllocator.DeepCopyInto(&out.TargetAllocator)
+	in.ReceiverCreator.DeepCopyInto(&out.ReceiverCreator)
+	in.SelfTelemetry.DeepCopyInto(&out.SelfTelemetry)
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(RemoteWriteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AWSIdentity != nil {
+		in, out := &in.AWSIdentity, &out.AWSIdentity
+		*out = new(AWSIdentitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCPIdentity != nil {
+		in, out := &in.GCPIdentity, &out.GCPIdentity
+		*out = new(GCPIdentitySpec)
+		**out = **in
+	}
+	if in.AzureIdentity != nil {
+		in, out := &in.AzureIdentity, &out.AzureIdentity
+		*out = new(AzureIdentitySpec)
+		**out = **in
+	}
+	if in.ConfigVersions != nil {
+		in, out := &in.ConfigVersions, &out.ConfigVersions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConfigValidation != nil {
+		in, out := &in.ConfigValidation, &out.ConfigValidation
+		*out = new(ConfigValidationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
 		*out = make([]v1.VolumeMount, len(*in))
@@ -519,6 +988,11 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostPorts != nil {
+		in, out := &in.HostPorts, &out.HostPorts
+		*out = make([]HostPortSpec, len(*in))
+		copy(*out, *in)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -533,6 +1007,11 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
 		*out = make([]v1.PersistentVolumeClaim, len(*in))
@@ -547,6 +1026,25 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeProfiles != nil {
+		in, out := &in.NodeProfiles, &out.NodeProfiles
+		*out = make([]NodeProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarTiers != nil {
+		in, out := &in.SidecarTiers, &out.SidecarTiers
+		*out = make([]SidecarTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarNamespaceSelector != nil {
+		in, out := &in.SidecarNamespaceSelector, &out.SidecarNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]v1.Volume, len(*in))
@@ -554,7 +1052,48 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecretProviders != nil {
+		in, out := &in.SecretProviders, &out.SecretProviders
+		*out = make([]SecretProvider, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReceiverTLS != nil {
+		in, out := &in.ReceiverTLS, &out.ReceiverTLS
+		*out = make(map[string]ReceiverTLSSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExporterTLS != nil {
+		in, out := &in.ExporterTLS, &out.ExporterTLS
+		*out = make(map[string]ExporterTLSSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExporterTokens != nil {
+		in, out := &in.ExporterTokens, &out.ExporterTokens
+		*out = make(map[string]ExporterTokenSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.LoadBalancerHealthCheck != nil {
+		in, out := &in.LoadBalancerHealthCheck, &out.LoadBalancerHealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		**out = **in
+	}
+	if in.PodDNSConfig != nil {
+		in, out := &in.PodDNSConfig, &out.PodDNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShareProcessNamespace != nil {
+		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(v1.Affinity)
@@ -596,6 +1135,30 @@ func (in *OpenTelemetryCollectorStatus) DeepCopyInto(out *OpenTelemetryCollector
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastKnownGood != nil {
+		in, out := &in.LastKnownGood, &out.LastKnownGood
+		*out = new(LastKnownGoodStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorStatus.
@@ -616,8 +1179,45 @@ func (in *OpenTelemetryTargetAllocator) DeepCopyInto(out *OpenTelemetryTargetAll
 		*out = new(int32)
 		**out = **in
 	}
+	if in.JobShards != nil {
+		in, out := &in.JobShards, &out.JobShards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TopologyAware != nil {
+		in, out := &in.TopologyAware, &out.TopologyAware
+		*out = new(TopologyAwareSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	in.PrometheusCR.DeepCopyInto(&out.PrometheusCR)
+	if in.PodDNSConfig != nil {
+		in, out := &in.PodDNSConfig, &out.PodDNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScrapeOverrides != nil {
+		in, out := &in.ScrapeOverrides, &out.ScrapeOverrides
+		*out = new(TargetAllocatorScrapeOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTargetsPerCollector != nil {
+		in, out := &in.MaxTargetsPerCollector, &out.MaxTargetsPerCollector
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllocationState != nil {
+		in, out := &in.AllocationState, &out.AllocationState
+		*out = new(TargetAllocatorAllocationState)
+		**out = **in
+	}
+	if in.WorkItems != nil {
+		in, out := &in.WorkItems, &out.WorkItems
+		*out = make([]TargetAllocatorWorkItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryTargetAllocator.
@@ -704,6 +1304,21 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Python) DeepCopyInto(out *Python) {
 	*out = *in
@@ -727,6 +1342,120 @@ func (in *Python) DeepCopy() *Python {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverCreatorSpec) DeepCopyInto(out *ReceiverCreatorSpec) {
+	*out = *in
+	if in.Pipelines != nil {
+		in, out := &in.Pipelines, &out.Pipelines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make(map[string]ReceiverCreatorTemplate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverCreatorSpec.
+func (in *ReceiverCreatorSpec) DeepCopy() *ReceiverCreatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverCreatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverCreatorTemplate) DeepCopyInto(out *ReceiverCreatorTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverCreatorTemplate.
+func (in *ReceiverCreatorTemplate) DeepCopy() *ReceiverCreatorTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverCreatorTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverTLSSpec) DeepCopyInto(out *ReceiverTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverTLSSpec.
+func (in *ReceiverTLSSpec) DeepCopy() *ReceiverTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
+	*out = *in
+	if in.Pipelines != nil {
+		in, out := &in.Pipelines, &out.Pipelines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WAL != nil {
+		in, out := &in.WAL, &out.WAL
+		*out = new(RemoteWriteWALSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteSpec.
+func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteWALSpec) DeepCopyInto(out *RemoteWriteWALSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteWALSpec.
+func (in *RemoteWriteWALSpec) DeepCopy() *RemoteWriteWALSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteWALSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -749,6 +1478,21 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sampler) DeepCopyInto(out *Sampler) {
 	*out = *in
@@ -778,3 +1522,303 @@ func (in *ScaleSubresourceStatus) DeepCopy() *ScaleSubresourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProvider) DeepCopyInto(out *SecretProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProvider.
+func (in *SecretProvider) DeepCopy() *SecretProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfTelemetryOTLPSpec) DeepCopyInto(out *SelfTelemetryOTLPSpec) {
+	*out = *in
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = make([]SelfTelemetrySignal, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricsInterval != nil {
+		in, out := &in.MetricsInterval, &out.MetricsInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfTelemetryOTLPSpec.
+func (in *SelfTelemetryOTLPSpec) DeepCopy() *SelfTelemetryOTLPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfTelemetryOTLPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfTelemetrySpec) DeepCopyInto(out *SelfTelemetrySpec) {
+	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(SelfTelemetryOTLPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfTelemetrySpec.
+func (in *SelfTelemetrySpec) DeepCopy() *SelfTelemetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfTelemetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarTier) DeepCopyInto(out *SidecarTier) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarTier.
+func (in *SidecarTier) DeepCopy() *SidecarTier {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetAllocatorAllocationState) DeepCopyInto(out *TargetAllocatorAllocationState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorAllocationState.
+func (in *TargetAllocatorAllocationState) DeepCopy() *TargetAllocatorAllocationState {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetAllocatorAllocationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetAllocatorScrapeOverrides) DeepCopyInto(out *TargetAllocatorScrapeOverrides) {
+	*out = *in
+	if in.MinScrapeInterval != nil {
+		in, out := &in.MinScrapeInterval, &out.MinScrapeInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SampleLimit != nil {
+		in, out := &in.SampleLimit, &out.SampleLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LabelLimit != nil {
+		in, out := &in.LabelLimit, &out.LabelLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorScrapeOverrides.
+func (in *TargetAllocatorScrapeOverrides) DeepCopy() *TargetAllocatorScrapeOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetAllocatorScrapeOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetAllocatorWorkItem) DeepCopyInto(out *TargetAllocatorWorkItem) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorWorkItem.
+func (in *TargetAllocatorWorkItem) DeepCopy() *TargetAllocatorWorkItem {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetAllocatorWorkItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryTenant) DeepCopyInto(out *TelemetryTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryTenant.
+func (in *TelemetryTenant) DeepCopy() *TelemetryTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TelemetryTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryTenantBackend) DeepCopyInto(out *TelemetryTenantBackend) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryTenantBackend.
+func (in *TelemetryTenantBackend) DeepCopy() *TelemetryTenantBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryTenantBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryTenantList) DeepCopyInto(out *TelemetryTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TelemetryTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryTenantList.
+func (in *TelemetryTenantList) DeepCopy() *TelemetryTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TelemetryTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryTenantSpec) DeepCopyInto(out *TelemetryTenantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Backend.DeepCopyInto(&out.Backend)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryTenantSpec.
+func (in *TelemetryTenantSpec) DeepCopy() *TelemetryTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyAwareSpec) DeepCopyInto(out *TopologyAwareSpec) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAwareSpec.
+func (in *TopologyAwareSpec) DeepCopy() *TopologyAwareSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyAwareSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalAutoscalerSpec) DeepCopyInto(out *VerticalAutoscalerSpec) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalAutoscalerSpec.
+func (in *VerticalAutoscalerSpec) DeepCopy() *VerticalAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
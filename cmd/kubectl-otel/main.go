@@ -0,0 +1,367 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command kubectl-otel is a kubectl plugin rendering the objects the operator creates for OpenTelemetryCollector
+// resources, so that they can be reviewed before the resources are applied.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	oteltesting "github.com/open-telemetry/opentelemetry-operator/pkg/testing"
+)
+
+const usage = `Renders the objects the OpenTelemetry Operator creates for OpenTelemetryCollector resources.
+
+Usage:
+  kubectl otel render -f FILE [flags]   print the manifests of the collectors
+  kubectl otel config -f FILE [flags]   print the collector configurations, as rewritten by the operator
+  kubectl otel adopt -f FILE [flags]    print OpenTelemetryCollector resources taking over the collector deployments
+                                        and config maps of the file, e.g. from kubectl get deployment,configmap -o yaml
+  kubectl otel generate -f FILE --name NAME [flags]
+                                        print an OpenTelemetryCollector resource running the collector configuration
+                                        of the file, along with the permissions its components need
+  kubectl otel convert-prometheus -f FILE [--name NAME] [flags]
+                                        print OpenTelemetryCollector resources scraping the targets of the Prometheus
+                                        resources of the Prometheus Operator in the file, with a target allocator
+  kubectl otel convert-jaeger -f FILE [--name NAME] [flags]
+                                        print OpenTelemetryCollector resources receiving the spans of the Jaeger
+                                        resources of the Jaeger Operator in the file, and writing them to their storage
+
+Flags:
+`
+
+var scheme = k8sruntime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	v := version.Get()
+
+	var (
+		file                 string
+		namespace            string
+		collectorImage       string
+		targetAllocatorImage string
+		name                 string
+		mode                 string
+		strict               bool
+	)
+
+	flags := pflag.NewFlagSet("kubectl-otel", pflag.ContinueOnError)
+	flags.StringVarP(&file, "filename", "f", "-", "The file holding the OpenTelemetryCollector resources, or - for the standard input.")
+	flags.StringVarP(&namespace, "namespace", "n", "default", "The namespace of the resources without one.")
+	flags.StringVar(&collectorImage, "collector-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image of the operator.")
+	flags.StringVar(&targetAllocatorImage, "target-allocator-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image of the operator.")
+	flags.StringVar(&name, "name", "", "The name of the generated OpenTelemetryCollector resource.")
+	flags.StringVar(&mode, "mode", "", "The mode of the generated OpenTelemetryCollector resource, inferred from the receivers by default.")
+	flags.BoolVar(&strict, "strict", false, "Fail on the unknown attributes of the resources and the unknown keys of the collector configurations, instead of warning about the latter.")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	if len(os.Args) < 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+	if os.Args[1] == "generate" && len(name) == 0 {
+		fmt.Fprintln(os.Stderr, "error: --name is required")
+		os.Exit(2)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := []oteltesting.Option{
+		oteltesting.WithNamespace(namespace),
+		oteltesting.WithCollectorImage(collectorImage),
+		oteltesting.WithTargetAllocatorImage(targetAllocatorImage),
+	}
+	if strict {
+		opts = append(opts, oteltesting.WithStrict())
+		if err = colfeaturegate.GlobalRegistry().Set(featuregate.EnableStrictConfig.ID(), true); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	switch os.Args[1] {
+	case "render", "config":
+		err = run(os.Stdout, bytes.NewReader(data), os.Args[1] == "config", opts...)
+	case "adopt":
+		err = adopt(os.Stdout, data, namespace)
+	case "generate":
+		err = generate(os.Stdout, string(data), name, namespace, otelv1alpha1.Mode(mode))
+	case "convert-prometheus":
+		err = convert(os.Stdout, data, name, namespace, "Prometheus", collector.FromPrometheus)
+	case "convert-jaeger":
+		err = convert(os.Stdout, data, name, namespace, "Jaeger", func(jaeger unstructured.Unstructured, name string) (collector.Generated, error) {
+			return collector.FromJaeger(logr.Discard(), jaeger, name)
+		})
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run writes the manifests, or only the rewritten configurations, of the collectors as YAML documents.
+func run(w io.Writer, r io.Reader, configOnly bool, opts ...oteltesting.Option) error {
+	collectors, err := oteltesting.Decode(r, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, otelcol := range collectors {
+		manifests, err := oteltesting.Render(otelcol, opts...)
+		if err != nil {
+			return err
+		}
+		for _, warning := range manifests.Warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", otelcol.Name, warning)
+		}
+
+		if configOnly {
+			_, err = fmt.Fprintf(w, "---\n# %s/%s\n%s", otelcol.Namespace, otelcol.Name, manifests.Config)
+		} else {
+			var out []byte
+			if out, err = manifests.YAML(); err == nil {
+				_, err = w.Write(out)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adopt writes the OpenTelemetryCollector resources taking over the deployments of the YAML documents, along with the
+// config maps they mount.
+func adopt(w io.Writer, data []byte, namespace string) error {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		return err
+	}
+
+	var (
+		deployments []appsv1.Deployment
+		configMaps  []corev1.ConfigMap
+	)
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			deployments = append(deployments, *o)
+		case *corev1.ConfigMap:
+			configMaps = append(configMaps, *o)
+		}
+	}
+	if len(deployments) == 0 {
+		return errors.New("no deployment to adopt was found")
+	}
+
+	encoder := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	for _, deployment := range deployments {
+		otelcol, err := collector.FromDeployment(deployment, configMaps)
+		if err != nil {
+			return err
+		}
+		if len(otelcol.Namespace) == 0 {
+			otelcol.Namespace = namespace
+		}
+
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		if err := encoder.Encode(&otelcol, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeObjects returns the objects of the YAML documents, including the items of lists.
+func decodeObjects(data []byte) ([]k8sruntime.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var objects []k8sruntime.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the resources: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the resource: %w", err)
+		}
+		list, ok := obj.(*corev1.List)
+		if !ok {
+			objects = append(objects, obj)
+			continue
+		}
+		for _, item := range list.Items {
+			obj, _, err := decoder.Decode(item.Raw, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the resource: %w", err)
+			}
+			objects = append(objects, obj)
+		}
+	}
+}
+
+// generate writes an OpenTelemetryCollector resource running the collector configuration, preceded by the hints on
+// its settings, and followed by the permissions its components need.
+func generate(w io.Writer, config, name, namespace string, mode otelv1alpha1.Mode) error {
+	generated, err := collector.FromConfig(logr.Discard(), name, namespace, config, mode)
+	if err != nil {
+		return err
+	}
+
+	return writeGenerated(w, generated)
+}
+
+// convert writes the OpenTelemetryCollector resources replacing the resources of the given kind of the YAML
+// documents, each preceded by the hints on its conversion, and followed by the permissions its components need.
+func convert(w io.Writer, data []byte, name, namespace, kind string, from func(unstructured.Unstructured, string) (collector.Generated, error)) error {
+	var resources []unstructured.Unstructured
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the resources: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		jsonDoc, err := utilyaml.ToJSON(doc)
+		if err != nil {
+			return fmt.Errorf("failed to read the resources: %w", err)
+		}
+		obj, err := k8sruntime.Decode(unstructured.UnstructuredJSONScheme, jsonDoc)
+		if err != nil {
+			return fmt.Errorf("failed to decode the resource: %w", err)
+		}
+		switch o := obj.(type) {
+		case *unstructured.Unstructured:
+			resources = append(resources, *o)
+		case *unstructured.UnstructuredList:
+			resources = append(resources, o.Items...)
+		}
+	}
+	if len(resources) == 0 {
+		return fmt.Errorf("no %s resource to convert was found", kind)
+	}
+	if len(name) > 0 && len(resources) > 1 {
+		return fmt.Errorf("--name can't be used with several %s resources", kind)
+	}
+
+	for _, resource := range resources {
+		if len(resource.GetNamespace()) == 0 {
+			resource.SetNamespace(namespace)
+		}
+		generated, err := from(resource, name)
+		if err != nil {
+			return err
+		}
+		if err := writeGenerated(w, generated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGenerated writes a generated OpenTelemetryCollector resource, preceded by the hints on its settings, and
+// followed by the permissions its components need.
+func writeGenerated(w io.Writer, generated collector.Generated) error {
+	for _, hint := range generated.Hints {
+		if _, err := fmt.Fprintf(w, "# %s\n", hint); err != nil {
+			return err
+		}
+	}
+	for _, port := range generated.Ports {
+		if _, err := fmt.Fprintf(w, "# the operator opens the port %d (%s) on the collector service\n", port.Port, port.Name); err != nil {
+			return err
+		}
+	}
+
+	objects := []k8sruntime.Object{&generated.Instance}
+	if generated.ClusterRole != nil {
+		objects = append(objects, generated.ClusterRole, generated.ClusterRoleBinding)
+	}
+	encoder := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	for _, obj := range objects {
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		if err := encoder.Encode(obj, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -17,10 +17,14 @@ package agent
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/inventory"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/metrics"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
 
@@ -50,6 +54,13 @@ type Agent struct {
 	config              config.Config
 	applier             operator.ConfigApplier
 	remoteConfigEnabled bool
+
+	otherConnectionSettingsEnabled bool
+
+	// inventory is the last inventory reported to the server, nil until the first report.
+	inventory     *inventory.Inventory
+	inventoryLock sync.Mutex
+	done          chan struct{}
 }
 
 func NewAgent(logger types.Logger, applier operator.ConfigApplier, config config.Config, opampClient client.OpAMPClient) *Agent {
@@ -62,6 +73,9 @@ func NewAgent(logger types.Logger, applier operator.ConfigApplier, config config
 		agentDescription:    config.GetDescription(),
 		remoteConfigEnabled: config.RemoteConfigEnabled(),
 		opampClient:         opampClient,
+		done:                make(chan struct{}),
+
+		otherConnectionSettingsEnabled: config.OtherConnectionSettingsEnabled(),
 	}
 
 	agent.logger.Debugf("Agent created, id=%v, type=%s, version=%s.",
@@ -102,8 +116,13 @@ func (agent *Agent) saveRemoteConfigStatus(_ context.Context, status *protobufs.
 // Start sets up the callbacks for the OpAMP client and begins the client's connection to the server.
 func (agent *Agent) Start() error {
 	agent.startTime = uint64(time.Now().UnixNano())
+	tlsConfig, err := agent.config.GetTLSConfig()
+	if err != nil {
+		return err
+	}
 	settings := types.StartSettings{
 		OpAMPServerURL: agent.config.Endpoint,
+		TLSConfig:      tlsConfig,
 		InstanceUid:    agent.instanceId.String(),
 		Callbacks: types.CallbacksStruct{
 			OnConnectFunc:              agent.onConnect,
@@ -117,7 +136,7 @@ func (agent *Agent) Start() error {
 		PackagesStateProvider: nil,
 		Capabilities:          agent.config.GetCapabilities(),
 	}
-	err := agent.opampClient.SetAgentDescription(agent.agentDescription)
+	err = agent.opampClient.SetAgentDescription(agent.agentDescription)
 	if err != nil {
 		return err
 	}
@@ -135,9 +154,60 @@ func (agent *Agent) Start() error {
 
 	agent.logger.Debugf("OpAMP Client started.")
 
+	if agent.config.InventoryInterval > 0 {
+		agent.updateInventory(context.Background())
+		go agent.reportInventory(agent.config.InventoryInterval)
+	}
+
 	return nil
 }
 
+// Inventory builds the inventory of the collectors of the cluster.
+func (agent *Agent) Inventory() (inventory.Inventory, error) {
+	instances, err := agent.applier.ListAllInstances()
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	managedLabels := map[string]string{operator.ResourceIdentifierKey: operator.ResourceIdentifierValue}
+	return inventory.New(agent.config.ClusterName, instances, managedLabels, time.Now()), nil
+}
+
+// reportInventory updates the inventory reported to the server at the given interval, until the agent shuts down.
+func (agent *Agent) reportInventory(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-agent.done:
+			return
+		case <-ticker.C:
+			agent.updateInventory(context.Background())
+		}
+	}
+}
+
+// updateInventory builds the inventory of the collectors and, when they changed since the last report, reports it to
+// the server as part of the effective configuration.
+func (agent *Agent) updateInventory(ctx context.Context) {
+	inv, err := agent.Inventory()
+	if err != nil {
+		agent.logger.Errorf("couldn't build the inventory: %v", err)
+		return
+	}
+	agent.inventoryLock.Lock()
+	changed := agent.inventory == nil || !reflect.DeepEqual(agent.inventory.Collectors, inv.Collectors)
+	if changed {
+		agent.inventory = &inv
+	}
+	agent.inventoryLock.Unlock()
+	if !changed {
+		return
+	}
+	if err := agent.opampClient.UpdateEffectiveConfig(ctx); err != nil {
+		agent.logger.Errorf("couldn't report the inventory: %v", err)
+	}
+}
+
 // updateAgentIdentity receives a new instanced Id from the remote server and updates the agent's instanceID field.
 // The meter will be reinitialized by the onMessage function.
 func (agent *Agent) updateAgentIdentity(instanceId ulid.ULID) {
@@ -148,7 +218,7 @@ func (agent *Agent) updateAgentIdentity(instanceId ulid.ULID) {
 }
 
 // getEffectiveConfig is called when a remote server needs to learn of the current effective configuration of each
-// collector the agent is managing.
+// collector the agent is managing. The last inventory of the collectors of the cluster is reported along with them.
 func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.EffectiveConfig, error) {
 	instances, err := agent.applier.ListInstances()
 	if err != nil {
@@ -168,6 +238,20 @@ func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.Effectiv
 			ContentType: "yaml",
 		}
 	}
+	agent.inventoryLock.Lock()
+	inv := agent.inventory
+	agent.inventoryLock.Unlock()
+	if inv != nil {
+		marshaled, err := json.Marshal(inv)
+		if err != nil {
+			agent.logger.Errorf("couldn't marshal the inventory: %v", err)
+			return nil, err
+		}
+		instanceMap[inventory.ConfigKey] = &protobufs.AgentConfigFile{
+			Body:        marshaled,
+			ContentType: inventory.ContentType,
+		}
+	}
 	return &protobufs.EffectiveConfig{
 		ConfigMap: &protobufs.AgentConfigMap{
 			ConfigMap: instanceMap,
@@ -245,6 +329,7 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 // Shutdown will stop the OpAMP client gracefully.
 func (agent *Agent) Shutdown() {
 	agent.logger.Debugf("Agent shutting down...")
+	close(agent.done)
 	if agent.opampClient != nil {
 		err := agent.opampClient.Stop(context.Background())
 		if err != nil {
@@ -292,4 +377,11 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 	if msg.OwnMetricsConnSettings != nil {
 		agent.initMeter(msg.OwnMetricsConnSettings)
 	}
+
+	// Connection settings for the destinations of the managed collectors, e.g. rotated backend credentials.
+	if agent.otherConnectionSettingsEnabled && msg.OtherConnSettings != nil {
+		if err := agent.applier.ApplyConnectionSettings(msg.OtherConnSettings); err != nil {
+			agent.logger.Errorf("couldn't apply connection settings: %v", err)
+		}
+	}
 }
@@ -17,6 +17,7 @@ package agent
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -36,6 +37,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/inventory"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
 )
@@ -517,6 +519,36 @@ func Test_CanUpdateIdentity(t *testing.T) {
 	assert.Equal(t, agent.instanceId, newId)
 }
 
+func TestAgent_reportsInventory(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	conf, err := config.Load("testdata/agentinventory.yaml")
+	require.NoError(t, err, "should be able to load config")
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(clientLogger, applier, conf, mockClient)
+	err = agent.Start()
+	defer agent.Shutdown()
+	require.NoError(t, err, "should be able to start agent")
+
+	// the inventory is reported when the agent starts
+	require.NotNil(t, mockClient.lastEffectiveConfig)
+	inv := inventory.Inventory{}
+	require.NoError(t, json.Unmarshal(mockClient.lastEffectiveConfig.ConfigMap.GetConfigMap()[inventory.ConfigKey].GetBody(), &inv))
+	assert.Equal(t, "eu-west-1", inv.Cluster)
+	assert.Empty(t, inv.Collectors)
+
+	data, err := getMessageDataFromConfigFile(map[string]string{"good/testnamespace": "basic.yaml"})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	agent.updateInventory(context.Background())
+
+	require.NoError(t, json.Unmarshal(mockClient.lastEffectiveConfig.ConfigMap.GetConfigMap()[inventory.ConfigKey].GetBody(), &inv))
+	require.Len(t, inv.Collectors, 1)
+	assert.Equal(t, "good", inv.Collectors[0].Name)
+	assert.Equal(t, "testnamespace", inv.Collectors[0].Namespace)
+	assert.True(t, inv.Collectors[0].ManagedByBridge)
+	assert.Equal(t, map[string]int{inventory.Unknown: 1}, inv.Versions)
+}
+
 func getMessageDataFromConfigFile(filemap map[string]string) (*types.MessageData, error) {
 	toReturn := &types.MessageData{}
 	if filemap == nil {
@@ -17,16 +17,21 @@ package config
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/util/homedir"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +44,9 @@ var (
 	schemeBuilder = runtime.NewSchemeBuilder(registerKnownTypes)
 )
 
+// inClusterNamespacePath is the file holding the namespace of the bridge's pod.
+const inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 func registerKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
 	metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
@@ -48,6 +56,13 @@ func registerKnownTypes(s *runtime.Scheme) error {
 type CLIConfig struct {
 	ListenAddr     *string
 	ConfigFilePath *string
+	// InventoryAddr is the address the inventory of the collectors is served on, which isn't authenticated.
+	InventoryAddr *string
+	// EnableLeaderElection makes the replicas of the bridge elect the only one connecting to the OpAMP server and
+	// applying its configurations, through the LeaderElectionID lease of the LeaderElectionNamespace.
+	EnableLeaderElection    *bool
+	LeaderElectionID        *string
+	LeaderElectionNamespace *string
 
 	ClusterConfig *rest.Config
 	// KubeConfigFilePath empty if in cluster configuration is in use
@@ -67,6 +82,11 @@ func ParseCLI(logger logr.Logger) (CLIConfig, error) {
 		RootLogger:     logger,
 		ListenAddr:     pflag.String("listen-addr", ":8080", "The address where this service serves."),
 		ConfigFilePath: pflag.String("config-file", defaultConfigFilePath, "The path to the config file."),
+		InventoryAddr:  pflag.String("inventory-addr", "localhost:8081", "The address where the inventory of the collectors is served, without authentication."),
+		EnableLeaderElection: pflag.Bool("enable-leader-election", false,
+			"Enable leader election, so that a single replica of the bridge connects to the OpAMP server. Required to run more than one replica."),
+		LeaderElectionID:        pflag.String("leader-election-id", "opamp-bridge.opentelemetry.io", "The name of the lease the replicas elect the leader with."),
+		LeaderElectionNamespace: pflag.String("leader-election-namespace", "", "The namespace of the leader election lease, the one of the bridge's pod by default."),
 	}
 	kubeconfigPath := pflag.String("kubeconfig-path", filepath.Join(homedir.HomeDir(), ".kube", "config"), "absolute path to the KubeconfigPath file")
 	pflag.Parse()
@@ -99,3 +119,34 @@ func (cli CLIConfig) GetKubernetesClient() (client.Client, error) {
 		Scheme: scheme.Scheme,
 	})
 }
+
+// GetLeaderElectionLock returns the lease the replicas of the bridge elect their leader with.
+func (cli CLIConfig) GetLeaderElectionLock() (resourcelock.Interface, error) {
+	namespace := *cli.LeaderElectionNamespace
+	if len(namespace) == 0 {
+		content, err := os.ReadFile(inClusterNamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("the namespace of the leader election lease must be set when running outside of the cluster: %w", err)
+		}
+		namespace = strings.TrimSpace(string(content))
+	}
+	// the pods' hostnames are their names, which are unique in the namespace
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	coordinationClient, err := coordinationv1client.NewForConfig(cli.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      *cli.LeaderElectionID,
+		},
+		Client: coordinationClient,
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func TestGetLeaderElectionLock(t *testing.T) {
+	// prepare
+	id := "opamp-bridge.opentelemetry.io"
+	namespace := "observability"
+	cli := CLIConfig{
+		ClusterConfig:           &rest.Config{Host: "https://localhost:6443"},
+		LeaderElectionID:        &id,
+		LeaderElectionNamespace: &namespace,
+	}
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	// test
+	lock, err := cli.GetLeaderElectionLock()
+
+	// verify
+	require.NoError(t, err)
+	require.IsType(t, &resourcelock.LeaseLock{}, lock)
+	lease := lock.(*resourcelock.LeaseLock)
+	assert.Equal(t, "observability", lease.LeaseMeta.Namespace)
+	assert.Equal(t, "opamp-bridge.opentelemetry.io", lease.LeaseMeta.Name)
+	assert.Equal(t, hostname, lock.Identity())
+}
@@ -16,6 +16,9 @@ package config
 
 import (
 	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -46,6 +49,36 @@ type Config struct {
 
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	ComponentsAllowed map[string][]string `yaml:"components_allowed,omitempty"`
+
+	// CollectorSelector restricts the collectors reported and managed by the bridge to the ones carrying these labels.
+	CollectorSelector map[string]string `yaml:"collector_selector,omitempty"`
+
+	// PipelinesDenied is a list of collector pipelines (e.g. traces/internal) the bridge may not add, change or remove.
+	PipelinesDenied []string `yaml:"pipelines_denied,omitempty"`
+
+	// ClusterName identifies the cluster of the bridge to the OpAMP server, e.g. to aggregate the inventories of
+	// several clusters.
+	ClusterName string `yaml:"cluster_name,omitempty"`
+
+	// InventoryInterval is how often the bridge reports the inventory of the collectors of the cluster to the OpAMP
+	// server. The inventory isn't reported when zero.
+	InventoryInterval time.Duration `yaml:"inventory_interval,omitempty"`
+
+	// TLS configures the client side of the TLS connection to the OpAMP server. The files are
+	// usually mounted from a secret.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+}
+
+// TLSConfig holds the paths of the certificates used to connect to the OpAMP server.
+type TLSConfig struct {
+	// CAFile is the CA bundle used to verify the server certificate. The system roots are used when empty.
+	CAFile string `yaml:"ca_file,omitempty"`
+	// CertFile and KeyFile hold the client certificate presented to the server for mTLS.
+	CertFile string `yaml:"cert_file,omitempty"`
+	KeyFile  string `yaml:"key_file,omitempty"`
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName         string `yaml:"server_name,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
 }
 
 func (c *Config) CreateClient(logger *logger.Logger) client.OpAMPClient {
@@ -55,6 +88,40 @@ func (c *Config) CreateClient(logger *logger.Logger) client.OpAMPClient {
 	return client.NewWebSocket(logger)
 }
 
+// GetTLSConfig builds the TLS configuration used to connect to the OpAMP server, or nil when TLS is not configured.
+func (c *Config) GetTLSConfig() (*tls.Config, error) {
+	if c.TLS == nil {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.TLS.ServerName,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify, // #nosec G402 -- explicitly requested by the user
+	}
+	if c.TLS.CAFile != "" {
+		ca, err := os.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return nil, errors.New("both cert_file and key_file must be set for client authentication")
+	}
+	if c.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
 func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
 	m := make(map[string]map[string]bool)
 	for component, componentSet := range c.ComponentsAllowed {
@@ -89,7 +156,7 @@ func (c *Config) GetAgentVersion() string {
 }
 
 func (c *Config) GetDescription() *protobufs.AgentDescription {
-	return &protobufs.AgentDescription{
+	description := &protobufs.AgentDescription{
 		IdentifyingAttributes: []*protobufs.KeyValue{
 			keyValuePair("service.name", c.GetAgentType()),
 			keyValuePair("service.version", c.GetAgentVersion()),
@@ -99,6 +166,10 @@ func (c *Config) GetDescription() *protobufs.AgentDescription {
 			keyValuePair("host.name", hostname),
 		},
 	}
+	if c.ClusterName != "" {
+		description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes, keyValuePair("k8s.cluster.name", c.ClusterName))
+	}
+	return description
 }
 
 func keyValuePair(key string, value string) *protobufs.KeyValue {
@@ -122,6 +193,11 @@ func (c *Config) RemoteConfigEnabled() bool {
 	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig != 0
 }
 
+func (c *Config) OtherConnectionSettingsEnabled() bool {
+	capabilities := c.GetCapabilities()
+	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsOtherConnectionSettings != 0
+}
+
 func Load(file string) (Config, error) {
 	var cfg Config
 	if err := unmarshal(&cfg, file); err != nil {
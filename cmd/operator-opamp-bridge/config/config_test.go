@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0600))
+	missingFile := filepath.Join(dir, "missing.pem")
+
+	for _, tt := range []struct {
+		desc         string
+		tls          *TLSConfig
+		expectedErr  string
+		rootCAs      bool
+		certificates int
+	}{
+		{
+			desc: "no TLS",
+		},
+		{
+			desc: "server name",
+			tls:  &TLSConfig{ServerName: "opamp.example.com"},
+		},
+		{
+			desc:    "CA",
+			tls:     &TLSConfig{CAFile: certFile},
+			rootCAs: true,
+		},
+		{
+			desc:         "CA and client certificate",
+			tls:          &TLSConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile},
+			rootCAs:      true,
+			certificates: 1,
+		},
+		{
+			desc:         "client certificate",
+			tls:          &TLSConfig{CertFile: certFile, KeyFile: keyFile},
+			certificates: 1,
+		},
+		{
+			desc:        "missing CA file",
+			tls:         &TLSConfig{CAFile: missingFile},
+			expectedErr: "failed to read CA file",
+		},
+		{
+			desc:        "invalid CA file",
+			tls:         &TLSConfig{CAFile: invalidFile},
+			expectedErr: "no certificates found in CA file",
+		},
+		{
+			desc:        "certificate without key",
+			tls:         &TLSConfig{CertFile: certFile},
+			expectedErr: "both cert_file and key_file must be set",
+		},
+		{
+			desc:        "key without certificate",
+			tls:         &TLSConfig{KeyFile: keyFile},
+			expectedErr: "both cert_file and key_file must be set",
+		},
+		{
+			desc:        "invalid certificate file",
+			tls:         &TLSConfig{CertFile: invalidFile, KeyFile: keyFile},
+			expectedErr: "failed to load client certificate",
+		},
+		{
+			desc:        "missing key file",
+			tls:         &TLSConfig{CertFile: certFile, KeyFile: missingFile},
+			expectedErr: "failed to load client certificate",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{TLS: tt.tls}
+
+			tlsConfig, err := c.GetTLSConfig()
+			if len(tt.expectedErr) > 0 {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, tlsConfig)
+				return
+			}
+			require.NoError(t, err)
+			if tt.tls == nil {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+			require.NotNil(t, tlsConfig)
+			assert.Equal(t, tt.tls.ServerName, tlsConfig.ServerName)
+			assert.Equal(t, tt.rootCAs, tlsConfig.RootCAs != nil)
+			assert.Len(t, tlsConfig.Certificates, tt.certificates)
+		})
+	}
+}
+
+// writeCertificate writes a self-signed certificate and its key to the given directory, and returns their paths.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "opamp.example.com"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
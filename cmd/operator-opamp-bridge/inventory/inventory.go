@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inventory describes the OpenTelemetryCollectors of a cluster, so that an OpAMP server managing several
+// clusters can aggregate them into a fleet view.
+package inventory
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// ConfigKey is the key of the inventory in the effective configuration reported to the OpAMP server. It can't be
+	// mistaken for a collector, whose keys are of the form name/namespace.
+	ConfigKey = "inventory"
+	// ContentType is the content type of the inventory document.
+	ContentType = "application/json"
+
+	// conditionTypeHealthy is the type of the status condition the operator aggregates the failures of the collector
+	// pods into.
+	conditionTypeHealthy = "Healthy"
+)
+
+// Health of a collector, as reported by its Healthy status condition.
+const (
+	Healthy   = "healthy"
+	Unhealthy = "unhealthy"
+	Unknown   = "unknown"
+)
+
+// Inventory is the document describing the OpenTelemetryCollectors of a cluster.
+type Inventory struct {
+	// Cluster is the name of the cluster, as configured in the bridge.
+	Cluster string `json:"cluster,omitempty"`
+	// GeneratedAt is when the inventory was built.
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Versions counts the collectors by version.
+	Versions map[string]int `json:"versions"`
+	// Collectors are sorted by namespace and name.
+	Collectors []Collector `json:"collectors"`
+}
+
+// Collector describes an OpenTelemetryCollector.
+type Collector struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Mode      string `json:"mode"`
+	// Version is the version of the collector the operator reported, empty until it reconciled the collector.
+	Version         string `json:"version,omitempty"`
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+	Image           string `json:"image,omitempty"`
+	// Replicas is the desired number of replicas, Pods the number of pods running.
+	Replicas int32 `json:"replicas,omitempty"`
+	Pods     int32 `json:"pods"`
+	// Health is one of healthy, unhealthy or unknown, with the message of the Healthy condition when unhealthy.
+	Health  string `json:"health"`
+	Message string `json:"message,omitempty"`
+	// ManagedByBridge is true for the collectors created by the OpAMP server through the bridge.
+	ManagedByBridge bool `json:"managedByBridge"`
+}
+
+// New builds the inventory of the given collectors. The collectors carrying the managedLabels were created through the
+// bridge.
+func New(cluster string, instances []v1alpha1.OpenTelemetryCollector, managedLabels map[string]string, now time.Time) Inventory {
+	inv := Inventory{
+		Cluster:     cluster,
+		GeneratedAt: now.UTC(),
+		Versions:    map[string]int{},
+		Collectors:  []Collector{},
+	}
+	for _, instance := range instances {
+		col := newCollector(instance)
+		col.ManagedByBridge = hasLabels(instance.Labels, managedLabels)
+		inv.Collectors = append(inv.Collectors, col)
+
+		version := col.Version
+		if version == "" {
+			version = Unknown
+		}
+		inv.Versions[version]++
+	}
+	sort.Slice(inv.Collectors, func(i, j int) bool {
+		if inv.Collectors[i].Namespace != inv.Collectors[j].Namespace {
+			return inv.Collectors[i].Namespace < inv.Collectors[j].Namespace
+		}
+		return inv.Collectors[i].Name < inv.Collectors[j].Name
+	})
+	return inv
+}
+
+func newCollector(instance v1alpha1.OpenTelemetryCollector) Collector {
+	col := Collector{
+		Name:            instance.Name,
+		Namespace:       instance.Namespace,
+		Mode:            string(instance.Spec.Mode),
+		Version:         instance.Status.Version,
+		OperatorVersion: instance.Status.OperatorVersion,
+		Image:           instance.Spec.Image,
+		Pods:            instance.Status.Scale.Replicas,
+		Health:          Unknown,
+	}
+	if instance.Spec.Replicas != nil {
+		col.Replicas = *instance.Spec.Replicas
+	}
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, conditionTypeHealthy); condition != nil {
+		switch condition.Status {
+		case metav1.ConditionTrue:
+			col.Health = Healthy
+		case metav1.ConditionFalse:
+			col.Health = Unhealthy
+			col.Message = condition.Message
+		}
+	}
+	return col
+}
+
+func hasLabels(labels map[string]string, expected map[string]string) bool {
+	if len(expected) == 0 {
+		return false
+	}
+	for k, v := range expected {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Unhealthy returns the collectors reported unhealthy.
+func (i Inventory) Unhealthy() []Collector {
+	var unhealthy []Collector
+	for _, col := range i.Collectors {
+		if col.Health == Unhealthy {
+			unhealthy = append(unhealthy, col)
+		}
+	}
+	return unhealthy
+}
+
+// Handler serves the inventory built by the given function as JSON, for the queries made from within the cluster.
+func Handler(build func() (Inventory, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		inv, err := build()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		_ = json.NewEncoder(w).Encode(inv)
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+var managedLabels = map[string]string{"created-by": "operator-opamp-bridge"}
+
+func collectors() []v1alpha1.OpenTelemetryCollector {
+	replicas := int32(2)
+	return []v1alpha1.OpenTelemetryCollector{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability", Labels: managedLabels},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:     v1alpha1.ModeDeployment,
+				Image:    "otel/opentelemetry-collector:0.78.0",
+				Replicas: &replicas,
+			},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{
+				Version: "0.78.0",
+				Scale:   v1alpha1.ScaleSubresourceStatus{Replicas: 2},
+				Conditions: []metav1.Condition{{
+					Type:    "Healthy",
+					Status:  metav1.ConditionFalse,
+					Message: "1 pod is crash looping",
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "observability"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDaemonSet},
+			Status: v1alpha1.OpenTelemetryCollectorStatus{
+				Version: "0.78.0",
+				Conditions: []metav1.Condition{{
+					Type:   "Healthy",
+					Status: metav1.ConditionTrue,
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeSidecar},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	inv := New("eu-west-1", collectors(), managedLabels, now)
+
+	assert.Equal(t, "eu-west-1", inv.Cluster)
+	assert.Equal(t, now, inv.GeneratedAt)
+	assert.Equal(t, map[string]int{"0.78.0": 2, Unknown: 1}, inv.Versions)
+	assert.Equal(t, []Collector{
+		{Name: "new", Namespace: "default", Mode: "sidecar", Health: Unknown},
+		{Name: "agent", Namespace: "observability", Mode: "daemonset", Version: "0.78.0", Health: Healthy},
+		{
+			Name:            "gateway",
+			Namespace:       "observability",
+			Mode:            "deployment",
+			Version:         "0.78.0",
+			Image:           "otel/opentelemetry-collector:0.78.0",
+			Replicas:        2,
+			Pods:            2,
+			Health:          Unhealthy,
+			Message:         "1 pod is crash looping",
+			ManagedByBridge: true,
+		},
+	}, inv.Collectors)
+
+	unhealthy := inv.Unhealthy()
+	require.Len(t, unhealthy, 1)
+	assert.Equal(t, "gateway", unhealthy[0].Name)
+}
+
+func TestHandler(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		method   string
+		err      error
+		expected int
+	}{
+		{
+			desc:     "get",
+			method:   http.MethodGet,
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "post",
+			method:   http.MethodPost,
+			expected: http.StatusMethodNotAllowed,
+		},
+		{
+			desc:     "failed listing",
+			method:   http.MethodGet,
+			err:      errors.New("forbidden"),
+			expected: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			handler := Handler(func() (Inventory, error) {
+				return New("eu-west-1", collectors(), managedLabels, time.Now()), tt.err
+			})
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/inventory", nil))
+
+			assert.Equal(t, tt.expected, rec.Code)
+			if tt.expected == http.StatusOK {
+				assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+				inv := Inventory{}
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&inv))
+				assert.Len(t, inv.Collectors, 3)
+			}
+		})
+	}
+}
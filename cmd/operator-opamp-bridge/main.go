@@ -15,11 +15,19 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/tools/leaderelection"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/agent"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/inventory"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/logger"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/operator"
 )
@@ -45,18 +53,77 @@ func main() {
 		l.Error(kubeErr, "Couldn't create kubernetes client")
 		os.Exit(1)
 	}
-	operatorClient := operator.NewClient(l.WithName("operator-client"), kubeClient, cfg.GetComponentsAllowed())
+	operatorClient := operator.NewClient(l.WithName("operator-client"), kubeClient, cfg.GetComponentsAllowed(),
+		operator.WithCollectorSelector(cfg.CollectorSelector),
+		operator.WithPipelinesDenied(cfg.PipelinesDenied),
+	)
 
 	opampClient := cfg.CreateClient(agentLogger)
 	opampAgent := agent.NewAgent(agentLogger, operatorClient, cfg, opampClient)
 
-	if err := opampAgent.Start(); err != nil {
-		l.Error(err, "Cannot start OpAMP client")
-		os.Exit(1)
+	// The inventory of the collectors can also be queried locally, e.g. with a port-forward. It isn't authenticated, so
+	// it's served on its own address, bound to localhost by default.
+	mux := http.NewServeMux()
+	mux.Handle("/inventory", inventory.Handler(opampAgent.Inventory))
+	server := &http.Server{Addr: *cliConf.InventoryAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error(err, "Cannot serve the inventory")
+		}
+	}()
+	defer func() { _ = server.Close() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// run connects the agent to the OpAMP server until the given context is done
+	stopped := make(chan struct{})
+	run := func(ctx context.Context) {
+		defer close(stopped)
+		if err := opampAgent.Start(); err != nil {
+			l.Error(err, "Cannot start OpAMP client")
+			os.Exit(1)
+		}
+		<-ctx.Done()
+		opampAgent.Shutdown()
+	}
+	if !*cliConf.EnableLeaderElection {
+		run(ctx)
+		return
 	}
 
-	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt)
-	<-interrupt
-	opampAgent.Shutdown()
+	// the replicas would otherwise all connect to the OpAMP server and apply its configurations concurrently
+	lock, err := cliConf.GetLeaderElectionLock()
+	if err != nil {
+		l.Error(err, "Cannot create the leader election lock")
+		os.Exit(1)
+	}
+	var leading atomic.Bool
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		ReleaseOnCancel: true,
+		Name:            *cliConf.LeaderElectionID,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				l.Info("Acquired the leadership, starting the OpAMP client")
+				leading.Store(true)
+				run(ctx)
+			},
+			OnStoppedLeading: func() {
+				l.Info("Stopped leading")
+			},
+		},
+	})
+	if leading.Load() {
+		<-stopped
+	}
+	// the leadership is only lost without an interrupt when the lease couldn't be renewed, the replica restarts to
+	// stand for the election again
+	if ctx.Err() == nil {
+		_ = server.Close()
+		os.Exit(1)
+	}
 }
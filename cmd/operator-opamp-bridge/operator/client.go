@@ -17,6 +17,8 @@ package operator
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -43,8 +45,16 @@ type ConfigApplier interface {
 	// ListInstances retrieves all OpenTelemetryCollector CRDs created by the operator-opamp-bridge agent.
 	ListInstances() ([]v1alpha1.OpenTelemetryCollector, error)
 
+	// ListAllInstances retrieves all OpenTelemetryCollector CRDs matching the collector selector, including the ones
+	// not created by the operator-opamp-bridge agent.
+	ListAllInstances() ([]v1alpha1.OpenTelemetryCollector, error)
+
 	// Delete attempts to delete an OpenTelemetryCollector object given a name and namespace.
 	Delete(name string, namespace string) error
+
+	// ApplyConnectionSettings writes the connection settings offered by the server into secrets referenced by the
+	// OpenTelemetryCollector CRDs created by the operator-opamp-bridge agent.
+	ApplyConnectionSettings(settings map[string]*protobufs.OtherConnectionSettings) error
 }
 
 type Client struct {
@@ -52,17 +62,49 @@ type Client struct {
 	componentsAllowed map[string]map[string]bool
 	k8sClient         client.Client
 	close             chan bool
+	collectorSelector map[string]string
+	pipelinesDenied   map[string]bool
+
+	// connectionSettings holds the latest connection settings offered by the server, keyed by offer name.
+	connectionSettings map[string]*protobufs.OtherConnectionSettings
 }
 
 var _ ConfigApplier = &Client{}
 
-func NewClient(log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool) *Client {
-	return &Client{
+// Option configures the Client.
+type Option func(*Client)
+
+// WithCollectorSelector limits the collectors the client lists, updates and deletes to the ones carrying the given
+// labels. Collectors created by the client get the labels added.
+func WithCollectorSelector(selector map[string]string) Option {
+	return func(c *Client) {
+		c.collectorSelector = selector
+	}
+}
+
+// WithPipelinesDenied prevents the client from adding, changing or removing the given collector pipelines.
+func WithPipelinesDenied(pipelines []string) Option {
+	return func(c *Client) {
+		c.pipelinesDenied = make(map[string]bool, len(pipelines))
+		for _, p := range pipelines {
+			c.pipelinesDenied[p] = true
+		}
+	}
+}
+
+func NewClient(log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool, opts ...Option) *Client {
+	cl := &Client{
 		log:               log,
 		componentsAllowed: componentsAllowed,
 		k8sClient:         c,
 		close:             make(chan bool, 1),
+
+		connectionSettings: map[string]*protobufs.OtherConnectionSettings{},
+	}
+	for _, opt := range opts {
+		opt(cl)
 	}
+	return cl
 }
 
 func (c Client) create(ctx context.Context, name string, namespace string, collector *v1alpha1.OpenTelemetryCollector) error {
@@ -76,8 +118,16 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 	if collector.ObjectMeta.Labels == nil {
 		collector.ObjectMeta.Labels = map[string]string{}
 	}
+	for k, v := range c.collectorSelector {
+		collector.ObjectMeta.Labels[k] = v
+	}
 	collector.ObjectMeta.Labels[ResourceIdentifierKey] = ResourceIdentifierValue
-	err := collector.ValidateCreate()
+	if len(c.connectionSettings) > 0 {
+		if err := c.withConnectionSettings(ctx, collector); err != nil {
+			return err
+		}
+	}
+	_, err := collector.ValidateCreate()
 	if err != nil {
 		return err
 	}
@@ -88,7 +138,12 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 func (c Client) update(ctx context.Context, old *v1alpha1.OpenTelemetryCollector, new *v1alpha1.OpenTelemetryCollector) error {
 	new.ObjectMeta = old.ObjectMeta
 	new.TypeMeta = old.TypeMeta
-	err := new.ValidateUpdate(old)
+	if len(c.connectionSettings) > 0 {
+		if err := c.withConnectionSettings(ctx, new); err != nil {
+			return err
+		}
+	}
+	_, err := new.ValidateUpdate(old)
 	if err != nil {
 		return err
 	}
@@ -119,6 +174,20 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 	if err != nil {
 		return err
 	}
+	var oldSpec v1alpha1.OpenTelemetryCollectorSpec
+	if instance != nil {
+		if !c.manages(instance) {
+			return errors.NewBadRequest(fmt.Sprintf("Collector %s/%s does not match the collector selector", namespace, name))
+		}
+		oldSpec = instance.Spec
+	}
+	denied, err := c.deniedPipelineChanges(oldSpec, collectorSpec)
+	if err != nil {
+		return err
+	}
+	if len(denied) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Pipelines in config are not allowed to be modified: %v", denied))
+	}
 	if instance != nil {
 		return c.update(ctx, instance, collector)
 	}
@@ -138,15 +207,38 @@ func (c Client) Delete(name string, namespace string) error {
 		}
 		return err
 	}
+	if !c.manages(&result) {
+		return errors.NewBadRequest(fmt.Sprintf("Collector %s/%s does not match the collector selector", namespace, name))
+	}
+	denied, err := c.deniedPipelineChanges(result.Spec, v1alpha1.OpenTelemetryCollectorSpec{})
+	if err != nil {
+		return err
+	}
+	if len(denied) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Pipelines in config are not allowed to be removed: %v", denied))
+	}
 	return c.k8sClient.Delete(ctx, &result)
 }
 
 func (c Client) ListInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
 	ctx := context.Background()
 	result := v1alpha1.OpenTelemetryCollectorList{}
-	err := c.k8sClient.List(ctx, &result, client.MatchingLabels{
-		ResourceIdentifierKey: ResourceIdentifierValue,
-	})
+	labels := client.MatchingLabels{}
+	for k, v := range c.collectorSelector {
+		labels[k] = v
+	}
+	labels[ResourceIdentifierKey] = ResourceIdentifierValue
+	err := c.k8sClient.List(ctx, &result, labels)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func (c Client) ListAllInstances() ([]v1alpha1.OpenTelemetryCollector, error) {
+	ctx := context.Background()
+	result := v1alpha1.OpenTelemetryCollectorList{}
+	err := c.k8sClient.List(ctx, &result, client.MatchingLabels(c.collectorSelector))
 	if err != nil {
 		return nil, err
 	}
@@ -198,3 +290,83 @@ func (c Client) validate(spec v1alpha1.OpenTelemetryCollectorSpec) ([]string, er
 	}
 	return invalidComponents, nil
 }
+
+// manages returns whether the collector matches the collector selector of the client.
+func (c Client) manages(collector *v1alpha1.OpenTelemetryCollector) bool {
+	for k, v := range c.collectorSelector {
+		if collector.GetLabels()[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// deniedPipelineChanges returns the denied pipelines that differ between the old and the new spec, in the components
+// they list or in the configuration of these components.
+func (c Client) deniedPipelineChanges(old v1alpha1.OpenTelemetryCollectorSpec, new v1alpha1.OpenTelemetryCollectorSpec) ([]string, error) {
+	if len(c.pipelinesDenied) == 0 {
+		return nil, nil
+	}
+	oldPipelines, err := pipelines(old)
+	if err != nil {
+		return nil, err
+	}
+	newPipelines, err := pipelines(new)
+	if err != nil {
+		return nil, err
+	}
+	var denied []string
+	for pipeline := range c.pipelinesDenied {
+		if !reflect.DeepEqual(oldPipelines[pipeline], newPipelines[pipeline]) {
+			denied = append(denied, pipeline)
+		}
+	}
+	sort.Strings(denied)
+	return denied, nil
+}
+
+// pipelineComponent is a component listed by a pipeline, with its configuration.
+type pipelineComponent struct {
+	name   string
+	config interface{}
+}
+
+// pipelines returns the receivers, processors and exporters of the pipelines of the given spec, in the order they
+// are listed, by pipeline and by kind of component.
+func pipelines(spec v1alpha1.OpenTelemetryCollectorSpec) (map[string]map[string][]pipelineComponent, error) {
+	collectorConfig := struct {
+		Receivers  map[string]interface{} `yaml:"receivers"`
+		Processors map[string]interface{} `yaml:"processors"`
+		Exporters  map[string]interface{} `yaml:"exporters"`
+		Connectors map[string]interface{} `yaml:"connectors"`
+		Service    struct {
+			Pipelines map[string]map[string][]string `yaml:"pipelines"`
+		} `yaml:"service"`
+	}{}
+	if err := yaml.Unmarshal([]byte(spec.Config), &collectorConfig); err != nil {
+		return nil, err
+	}
+	// the connectors are both the exporter of a pipeline and the receiver of another one
+	sections := map[string][]map[string]interface{}{
+		"receivers":  {collectorConfig.Receivers, collectorConfig.Connectors},
+		"processors": {collectorConfig.Processors},
+		"exporters":  {collectorConfig.Exporters, collectorConfig.Connectors},
+	}
+	result := map[string]map[string][]pipelineComponent{}
+	for name, pipeline := range collectorConfig.Service.Pipelines {
+		result[name] = map[string][]pipelineComponent{}
+		for kind, configs := range sections {
+			for _, component := range pipeline[kind] {
+				var config interface{}
+				for _, section := range configs {
+					if c, ok := section[component]; ok {
+						config = c
+						break
+					}
+				}
+				result[name][kind] = append(result[name][kind], pipelineComponent{name: component, config: config})
+			}
+		}
+	}
+	return result, nil
+}
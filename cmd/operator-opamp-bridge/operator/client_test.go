@@ -15,6 +15,8 @@
 package operator
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"testing"
 
@@ -22,6 +24,8 @@ import (
 
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +48,7 @@ func getFakeClient(t *testing.T) client.WithWatch {
 	scheme := runtime.NewScheme()
 	err := schemeBuilder.AddToScheme(scheme)
 	require.NoError(t, err, "Should be able to add custom types")
+	require.NoError(t, corev1.AddToScheme(scheme), "Should be able to add core types")
 	c := fake.NewClientBuilder().WithScheme(scheme)
 	return c.Build()
 }
@@ -187,6 +192,163 @@ func Test_collectorDelete(t *testing.T) {
 	assert.Len(t, allInstances, 0)
 }
 
+func Test_collectorSelector(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(clientLogger, fakeClient, nil, WithCollectorSelector(map[string]string{"team": "a"}))
+	other := NewClient(clientLogger, fakeClient, nil, WithCollectorSelector(map[string]string{"team": "b"}))
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the newly created instance")
+	assert.Equal(t, "a", instance.GetLabels()["team"])
+
+	allInstances, err := c.ListInstances()
+	require.NoError(t, err, "Should be able to list all collectors")
+	assert.Len(t, allInstances, 1)
+	allInstances, err = other.ListInstances()
+	require.NoError(t, err, "Should be able to list all collectors")
+	assert.Len(t, allInstances, 0)
+
+	err = other.Apply(name, namespace, configmap)
+	assert.Error(t, err, "Should not update a collector that doesn't match the selector")
+	err = other.Delete(name, namespace)
+	assert.Error(t, err, "Should not delete a collector that doesn't match the selector")
+}
+
+func Test_pipelinesDenied(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	newColConfig, err := loadConfig("testdata/updated-collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	newConfigMap := &protobufs.AgentConfigFile{
+		Body:        newColConfig,
+		ContentType: "yaml",
+	}
+
+	denied := NewClient(clientLogger, fakeClient, nil, WithPipelinesDenied([]string{"traces"}))
+	err = denied.Apply(name, namespace, configmap)
+	assert.Error(t, err, "Should not add a denied pipeline")
+
+	c := NewClient(clientLogger, fakeClient, nil, WithPipelinesDenied([]string{"metrics"}))
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	err = denied.Apply(name, namespace, newConfigMap)
+	assert.Error(t, err, "Should not change a denied pipeline")
+	err = denied.Apply(name, namespace, configmap)
+	assert.NoError(t, err, "Should apply a config leaving the denied pipeline unchanged")
+	err = denied.Delete(name, namespace)
+	assert.Error(t, err, "Should not remove a denied pipeline")
+	exporterConfigMap := &protobufs.AgentConfigFile{
+		Body:        bytes.Replace(colConfig, []byte("    logging:\n"), []byte("    logging:\n      verbosity: detailed\n"), 1),
+		ContentType: "yaml",
+	}
+	err = denied.Apply(name, namespace, exporterConfigMap)
+	assert.Error(t, err, "Should not change the configuration of the exporter of a denied pipeline")
+	err = c.Apply(name, namespace, exporterConfigMap)
+	require.NoError(t, err, "Should change the configuration of the exporter of pipelines that aren't denied")
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	err = c.Apply(name, namespace, newConfigMap)
+	require.NoError(t, err, "Should change pipelines that aren't denied")
+}
+
+func Test_connectionSettings(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(clientLogger, fakeClient, nil)
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	settings := map[string]*protobufs.OtherConnectionSettings{
+		"Backend": {
+			DestinationEndpoint: "https://backend.example.com:4318",
+			Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+				{Key: "Authorization", Value: "Bearer first"},
+			}},
+			Certificate: &protobufs.TLSCertificate{
+				PublicKey:   []byte("cert"),
+				PrivateKey:  []byte("key"),
+				CaPublicKey: []byte("ca"),
+			},
+		},
+	}
+	err = c.ApplyConnectionSettings(settings)
+	require.NoError(t, err, "Should apply connection settings")
+
+	secret := &corev1.Secret{}
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: "opamp-connection-backend", Namespace: namespace}, secret)
+	require.NoError(t, err, "Should create the connection settings secret")
+	assert.Equal(t, map[string][]byte{
+		"endpoint":             []byte("https://backend.example.com:4318"),
+		"header.Authorization": []byte("Bearer first"),
+		"ca.crt":               []byte("ca"),
+		"tls.crt":              []byte("cert"),
+		"tls.key":              []byte("key"),
+	}, secret.Data)
+
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the instance")
+	require.Len(t, instance.Spec.VolumeMounts, 1)
+	assert.Equal(t, "/etc/opamp/connections/backend", instance.Spec.VolumeMounts[0].MountPath)
+	require.Len(t, instance.Spec.Env, 2)
+	assert.Equal(t, "OPAMP_CONNECTION_BACKEND_ENDPOINT", instance.Spec.Env[0].Name)
+	assert.Equal(t, "OPAMP_CONNECTION_BACKEND_HEADER_AUTHORIZATION", instance.Spec.Env[1].Name)
+	firstHash := instance.Spec.PodAnnotations[ConnectionSettingsAnnotation]
+	assert.NotEmpty(t, firstHash)
+
+	// Rotating the credentials updates the secret and rolls the collector
+	settings["Backend"].Headers.Headers[0].Value = "Bearer second"
+	err = c.ApplyConnectionSettings(settings)
+	require.NoError(t, err, "Should apply rotated connection settings")
+	instance, err = c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the instance")
+	assert.Len(t, instance.Spec.VolumeMounts, 1)
+	assert.NotEqual(t, firstHash, instance.Spec.PodAnnotations[ConnectionSettingsAnnotation])
+
+	// Remote configuration updates keep the connection settings
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply config")
+	instance, err = c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the instance")
+	assert.Len(t, instance.Spec.Volumes, 1)
+
+	// Withdrawn offers are removed
+	err = c.ApplyConnectionSettings(map[string]*protobufs.OtherConnectionSettings{})
+	require.NoError(t, err, "Should remove connection settings")
+	instance, err = c.GetInstance(name, namespace)
+	require.NoError(t, err, "Should be able to get the instance")
+	assert.Empty(t, instance.Spec.Volumes)
+	assert.Empty(t, instance.Spec.Env)
+	assert.NotContains(t, instance.Spec.PodAnnotations, ConnectionSettingsAnnotation)
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: "opamp-connection-backend", Namespace: namespace}, secret)
+	assert.True(t, errors.IsNotFound(err), "Should delete the connection settings secret")
+}
+
 func loadConfig(file string) ([]byte, error) {
 	yamlFile, err := os.ReadFile(file)
 	if err != nil {
@@ -194,3 +356,32 @@ func loadConfig(file string) ([]byte, error) {
 	}
 	return yamlFile, nil
 }
+
+func Test_listAllInstances(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(clientLogger, fakeClient, nil)
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{
+		Body:        colConfig,
+		ContentType: "yaml",
+	}
+	err = c.Apply(name, namespace, configmap)
+	require.NoError(t, err, "Should apply base config")
+
+	// a collector created outside the bridge
+	err = fakeClient.Create(context.Background(), &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace},
+	})
+	require.NoError(t, err, "Should create a collector")
+
+	instances, err := c.ListInstances()
+	require.NoError(t, err, "Should be able to list the collectors created by the bridge")
+	assert.Len(t, instances, 1)
+
+	allInstances, err := c.ListAllInstances()
+	require.NoError(t, err, "Should be able to list all collectors")
+	assert.Len(t, allInstances, 2)
+}
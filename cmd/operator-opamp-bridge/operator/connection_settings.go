@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// ConnectionSettingsComponentKey labels the secrets holding the connection settings offered by the OpAMP server.
+	ConnectionSettingsComponentKey   = "app.kubernetes.io/component"
+	ConnectionSettingsComponentValue = "opamp-connection-settings"
+
+	// ConnectionSettingsAnnotation holds the hash of the connection settings mounted into the collector pods, so that
+	// the pods are rolled when the credentials are rotated.
+	ConnectionSettingsAnnotation = "opamp.opentelemetry.io/connection-settings"
+
+	// ConnectionSettingsMountPath is the directory the connection settings are mounted in, one directory per offer.
+	// Collector configurations reference the certificates as <mount path>/<offer name>/tls.crt, tls.key and ca.crt,
+	// with the offer name lowercased.
+	ConnectionSettingsMountPath = "/etc/opamp/connections"
+
+	connectionSettingsPrefix = "opamp-connection-"
+	connectionSettingsEnv    = "OPAMP_CONNECTION_"
+
+	endpointKey = "endpoint"
+	caCertKey   = "ca.crt"
+	certKey     = "tls.crt"
+	keyKey      = "tls.key"
+	headerKey   = "header."
+	settingKey  = "setting."
+)
+
+// ConnectionSettingsSecretName returns the name of the secret holding the connection settings offered under the given name.
+func ConnectionSettingsSecretName(name string) string {
+	return connectionSettingsPrefix + strings.ToLower(sanitize(name, '-'))
+}
+
+// ConnectionSettingsEnvName returns the name of the environment variable exposing the given secret key of an offer, e.g.
+// OPAMP_CONNECTION_BACKEND_ENDPOINT or OPAMP_CONNECTION_BACKEND_HEADER_AUTHORIZATION.
+func ConnectionSettingsEnvName(name string, key string) string {
+	return strings.ToUpper(connectionSettingsEnv + sanitize(name, '_') + "_" + sanitize(key, '_'))
+}
+
+// ApplyConnectionSettings stores the connection settings offered by the OpAMP server. The settings are written into a
+// secret in the namespace of every managed collector and the collectors are updated to reference it. Offers that are
+// no longer present are removed from the collectors and their secrets are deleted.
+func (c Client) ApplyConnectionSettings(settings map[string]*protobufs.OtherConnectionSettings) error {
+	for name := range c.connectionSettings {
+		delete(c.connectionSettings, name)
+	}
+	for name, s := range settings {
+		if s != nil {
+			c.connectionSettings[name] = s
+		}
+	}
+	c.log.Info("Received connection settings", "offers", len(c.connectionSettings))
+
+	instances, err := c.ListInstances()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	var multiErr error
+	for i := range instances {
+		instance := &instances[i]
+		if err := c.withConnectionSettings(ctx, instance); err != nil {
+			multiErr = multierr.Append(multiErr, err)
+			continue
+		}
+		if err := c.k8sClient.Update(ctx, instance); err != nil {
+			multiErr = multierr.Append(multiErr, err)
+		}
+	}
+	return multiErr
+}
+
+// withConnectionSettings writes the stored connection settings into secrets in the namespace of the collector and
+// replaces the volumes, volume mounts and environment variables referencing them.
+func (c Client) withConnectionSettings(ctx context.Context, collector *v1alpha1.OpenTelemetryCollector) error {
+	removeConnectionSettings(collector)
+
+	names := make([]string, 0, len(c.connectionSettings))
+	for name := range c.connectionSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	current := map[string]bool{}
+	for _, name := range names {
+		data := connectionSettingsData(c.connectionSettings[name])
+		secretName := ConnectionSettingsSecretName(name)
+		if err := c.applySecret(ctx, secretName, collector.Namespace, data); err != nil {
+			return err
+		}
+		current[secretName] = true
+		addConnectionSettings(collector, name, secretName, data)
+
+		keys := sortedKeys(data)
+		_, _ = hash.Write([]byte(secretName))
+		for _, k := range keys {
+			_, _ = hash.Write([]byte(k))
+			_, _ = hash.Write(data[k])
+		}
+	}
+	if len(names) > 0 {
+		if collector.Spec.PodAnnotations == nil {
+			collector.Spec.PodAnnotations = map[string]string{}
+		}
+		collector.Spec.PodAnnotations[ConnectionSettingsAnnotation] = fmt.Sprintf("%x", hash.Sum(nil))
+	}
+	return c.deleteStaleSecrets(ctx, collector.Namespace, current)
+}
+
+func (c Client) applySecret(ctx context.Context, name string, namespace string, data map[string][]byte) error {
+	existing := &corev1.Secret{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if errors.IsNotFound(err) {
+		c.log.Info("Creating connection settings secret", "name", name, "namespace", namespace)
+		return c.k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					ResourceIdentifierKey:          ResourceIdentifierValue,
+					ConnectionSettingsComponentKey: ConnectionSettingsComponentValue,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		})
+	}
+	existing.Data = data
+	return c.k8sClient.Update(ctx, existing)
+}
+
+func (c Client) deleteStaleSecrets(ctx context.Context, namespace string, current map[string]bool) error {
+	secrets := &corev1.SecretList{}
+	err := c.k8sClient.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{
+		ResourceIdentifierKey:          ResourceIdentifierValue,
+		ConnectionSettingsComponentKey: ConnectionSettingsComponentValue,
+	})
+	if err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if current[secrets.Items[i].Name] {
+			continue
+		}
+		c.log.Info("Deleting connection settings secret", "name", secrets.Items[i].Name, "namespace", namespace)
+		if err := c.k8sClient.Delete(ctx, &secrets.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectionSettingsData flattens the offered settings into secret data.
+func connectionSettingsData(settings *protobufs.OtherConnectionSettings) map[string][]byte {
+	data := map[string][]byte{}
+	if len(settings.GetDestinationEndpoint()) > 0 {
+		data[endpointKey] = []byte(settings.GetDestinationEndpoint())
+	}
+	if cert := settings.GetCertificate(); cert != nil {
+		if len(cert.GetCaPublicKey()) > 0 {
+			data[caCertKey] = cert.GetCaPublicKey()
+		}
+		if len(cert.GetPublicKey()) > 0 {
+			data[certKey] = cert.GetPublicKey()
+		}
+		if len(cert.GetPrivateKey()) > 0 {
+			data[keyKey] = cert.GetPrivateKey()
+		}
+	}
+	for _, header := range settings.GetHeaders().GetHeaders() {
+		data[headerKey+sanitize(header.GetKey(), '-')] = []byte(header.GetValue())
+	}
+	for k, v := range settings.GetOtherSettings() {
+		data[settingKey+sanitize(k, '-')] = []byte(v)
+	}
+	return data
+}
+
+// addConnectionSettings mounts the certificates of an offer and exposes its endpoint, headers and other settings as
+// environment variables, so that the collector configuration can reference them with ${env:...}.
+func addConnectionSettings(collector *v1alpha1.OpenTelemetryCollector, name string, secretName string, data map[string][]byte) {
+	collector.Spec.Volumes = append(collector.Spec.Volumes, corev1.Volume{
+		Name: secretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+		},
+	})
+	collector.Spec.VolumeMounts = append(collector.Spec.VolumeMounts, corev1.VolumeMount{
+		Name:      secretName,
+		MountPath: path.Join(ConnectionSettingsMountPath, strings.ToLower(sanitize(name, '-'))),
+		ReadOnly:  true,
+	})
+	for _, k := range sortedKeys(data) {
+		if k == caCertKey || k == certKey || k == keyKey {
+			continue
+		}
+		collector.Spec.Env = append(collector.Spec.Env, corev1.EnvVar{
+			Name: ConnectionSettingsEnvName(name, k),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  k,
+				},
+			},
+		})
+	}
+}
+
+// removeConnectionSettings drops everything addConnectionSettings added to the collector.
+func removeConnectionSettings(collector *v1alpha1.OpenTelemetryCollector) {
+	var volumes []corev1.Volume
+	for _, v := range collector.Spec.Volumes {
+		if !strings.HasPrefix(v.Name, connectionSettingsPrefix) {
+			volumes = append(volumes, v)
+		}
+	}
+	collector.Spec.Volumes = volumes
+
+	var mounts []corev1.VolumeMount
+	for _, m := range collector.Spec.VolumeMounts {
+		if !strings.HasPrefix(m.Name, connectionSettingsPrefix) {
+			mounts = append(mounts, m)
+		}
+	}
+	collector.Spec.VolumeMounts = mounts
+
+	var env []corev1.EnvVar
+	for _, e := range collector.Spec.Env {
+		if !strings.HasPrefix(e.Name, connectionSettingsEnv) {
+			env = append(env, e)
+		}
+	}
+	collector.Spec.Env = env
+
+	delete(collector.Spec.PodAnnotations, ConnectionSettingsAnnotation)
+}
+
+// sanitize replaces every character that isn't allowed in secret keys and environment variable names.
+func sanitize(s string, replacement rune) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return replacement
+	}, s)
+}
+
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
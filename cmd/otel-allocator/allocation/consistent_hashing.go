@@ -50,26 +50,40 @@ type consistentHashingAllocator struct {
 	// targetItem hash -> target item pointer
 	targetItems map[string]*target.Item
 
+	// unassignedTargets is a map from a target item's hash to the target items left unassigned because all the
+	// collectors are at their maximum number of targets
+	unassignedTargets map[string]*target.Item
+
+	// handoffs holds the targets which moved between collectors, still served to the collectors they moved from
+	handoffs *handoffs
+
 	// collectorKey -> job -> target item hash -> true
 	targetItemsPerJobPerCollector map[string]map[string]map[string]bool
 
 	log logr.Logger
 
 	filter Filter
+
+	maxTargetsPerCollector int
 }
 
-func newConsistentHashingAllocator(log logr.Logger, opts ...AllocationOption) Allocator {
+func newConsistentHasher(members []consistent.Member) *consistent.Consistent {
 	config := consistent.Config{
 		PartitionCount:    1061,
 		ReplicationFactor: 5,
 		Load:              1.1,
 		Hasher:            hasher{},
 	}
-	consistentHasher := consistent.New(nil, config)
+	return consistent.New(members, config)
+}
+
+func newConsistentHashingAllocator(log logr.Logger, opts ...AllocationOption) Allocator {
 	chAllocator := &consistentHashingAllocator{
-		consistentHasher:              consistentHasher,
+		consistentHasher:              newConsistentHasher(nil),
 		collectors:                    make(map[string]*Collector),
 		targetItems:                   make(map[string]*target.Item),
+		unassignedTargets:             make(map[string]*target.Item),
+		handoffs:                      newHandoffs(),
 		targetItemsPerJobPerCollector: make(map[string]map[string]map[string]bool),
 		log:                           log,
 	}
@@ -85,6 +99,25 @@ func (c *consistentHashingAllocator) SetFilter(filter Filter) {
 	c.filter = filter
 }
 
+// SetTargetHandoff sets how long the targets which move between collectors are still served to the collectors they
+// moved from, from the next moves.
+func (c *consistentHashingAllocator) SetTargetHandoff(duration HandoffDuration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.handoffs.duration = duration
+}
+
+// SetMaxTargetsPerCollector sets the maximum number of targets per collector, and assigns the unassigned targets
+// the collectors can take under the new maximum.
+func (c *consistentHashingAllocator) SetMaxTargetsPerCollector(maxTargets int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.maxTargetsPerCollector = maxTargets
+	if len(c.collectors) > 0 {
+		c.assignUnassignedTargets()
+	}
+}
+
 // addCollectorTargetItemMapping keeps track of which collector has which jobs and targets
 // this allows the allocator to respond without any extra allocations to http calls. The caller of this method
 // has to acquire a lock.
@@ -99,6 +132,9 @@ func (c *consistentHashingAllocator) addCollectorTargetItemMapping(tg *target.It
 }
 
 // addTargetToTargetItems assigns a target to the collector based on its hash and adds it to the allocator's targetItems
+// When the target moves from another collector, it's handed off from that collector.
+// When the collector is at its maximum number of targets, the target is assigned to the collector with the fewest
+// targets instead, and when all the collectors are at their maximum, it's moved to the unassigned targets.
 // This method is called from within SetTargets and SetCollectors, which acquire the needed lock.
 // This is only called after the collectors are cleared or when a new target has been found in the tempTargetMap.
 // INVARIANT: c.collectors must have at least 1 collector set.
@@ -106,17 +142,42 @@ func (c *consistentHashingAllocator) addCollectorTargetItemMapping(tg *target.It
 // item while it's being encoded by the server JSON handler.
 func (c *consistentHashingAllocator) addTargetToTargetItems(tg *target.Item) {
 	// Check if this is a reassignment, if so, decrement the previous collector's NumTargets
+	var previousCollector string
 	if previousColName, ok := c.collectors[tg.CollectorName]; ok {
+		previousCollector = previousColName.String()
 		previousColName.NumTargets--
 		delete(c.targetItemsPerJobPerCollector[tg.CollectorName][tg.JobName], tg.Hash())
 		TargetsPerCollector.WithLabelValues(previousColName.String(), consistentHashingStrategyName).Set(float64(c.collectors[previousColName.String()].NumTargets))
 	}
-	colOwner := c.consistentHasher.LocateKey([]byte(tg.Hash()))
-	tg.CollectorName = colOwner.String()
+	colOwner := c.collectors[c.consistentHasher.LocateKey([]byte(tg.Hash())).String()]
+	if atCapacity(colOwner, c.maxTargetsPerCollector) {
+		// the least loaded collector is at capacity only when all the collectors are
+		colOwner = leastLoadedCollector(c.collectors)
+		if atCapacity(colOwner, c.maxTargetsPerCollector) {
+			tg.CollectorName = ""
+			delete(c.targetItems, tg.Hash())
+			c.unassignedTargets[tg.Hash()] = tg
+			return
+		}
+	}
+	tg.CollectorName = colOwner.Name
+	c.handoffs.moved(tg, previousCollector)
 	c.targetItems[tg.Hash()] = tg
 	c.addCollectorTargetItemMapping(tg)
-	c.collectors[colOwner.String()].NumTargets++
-	TargetsPerCollector.WithLabelValues(colOwner.String(), consistentHashingStrategyName).Set(float64(c.collectors[colOwner.String()].NumTargets))
+	colOwner.NumTargets++
+	TargetsPerCollector.WithLabelValues(colOwner.Name, consistentHashingStrategyName).Set(float64(colOwner.NumTargets))
+}
+
+// assignUnassignedTargets tries to assign the unassigned targets again, once collectors were added or the maximum
+// number of targets per collector was raised. The caller of this method has to acquire a lock.
+// INVARIANT: c.collectors must have at least 1 collector set.
+func (c *consistentHashingAllocator) assignUnassignedTargets() {
+	unassigned := c.unassignedTargets
+	c.unassignedTargets = make(map[string]*target.Item)
+	for _, item := range unassigned {
+		c.addTargetToTargetItems(item)
+	}
+	TargetsUnassigned.WithLabelValues(consistentHashingStrategyName).Set(float64(len(c.unassignedTargets)))
 }
 
 // handleTargets receives the new and removed targets and reconciles the current state.
@@ -131,6 +192,7 @@ func (c *consistentHashingAllocator) handleTargets(diff diff.Changes[*target.Ite
 			col.NumTargets--
 			delete(c.targetItems, k)
 			delete(c.targetItemsPerJobPerCollector[item.CollectorName][item.JobName], item.Hash())
+			c.handoffs.removeTarget(k)
 			TargetsPerCollector.WithLabelValues(item.CollectorName, consistentHashingStrategyName).Set(float64(col.NumTargets))
 		}
 	}
@@ -149,13 +211,15 @@ func (c *consistentHashingAllocator) handleTargets(diff diff.Changes[*target.Ite
 
 // handleCollectors receives the new and removed collectors and reconciles the current state.
 // Any removals are removed from the allocator's collectors. New collectors are added to the allocator's collector map.
-// Finally, update all targets' collectors to match the consistent hashing.
+// Finally, update all targets' collectors to match the consistent hashing, and assign the unassigned targets the
+// collectors have room for.
 func (c *consistentHashingAllocator) handleCollectors(diff diff.Changes[*Collector]) {
 	// Clear removed collectors
 	for _, k := range diff.Removals() {
 		delete(c.collectors, k.Name)
 		delete(c.targetItemsPerJobPerCollector, k.Name)
 		c.consistentHasher.Remove(k.Name)
+		c.handoffs.removeCollector(k.Name)
 		TargetsPerCollector.WithLabelValues(k.Name, consistentHashingStrategyName).Set(0)
 	}
 	// Insert the new collectors
@@ -168,6 +232,7 @@ func (c *consistentHashingAllocator) handleCollectors(diff diff.Changes[*Collect
 	for _, item := range c.targetItems {
 		c.addTargetToTargetItems(item)
 	}
+	c.assignUnassignedTargets()
 }
 
 // SetTargets accepts a list of targets that will be used to make
@@ -189,12 +254,47 @@ func (c *consistentHashingAllocator) SetTargets(targets map[string]*target.Item)
 		c.log.Info("No collector instances present, cannot set targets")
 		return
 	}
+	c.handoffs.prune()
+	// The unassigned targets which are still discovered are additions of the diff, to assign again
+	c.unassignedTargets = make(map[string]*target.Item)
 	// Check for target changes
 	targetsDiff := diff.Maps(c.targetItems, targets)
 	// If there are any additions or removals
 	if len(targetsDiff.Additions()) != 0 || len(targetsDiff.Removals()) != 0 {
 		c.handleTargets(targetsDiff)
 	}
+	TargetsUnassigned.WithLabelValues(consistentHashingStrategyName).Set(float64(len(c.unassignedTargets)))
+}
+
+// RestoreTargets sets the given targets, keeping them on the collectors they were assigned to when these collectors are
+// set and have room for them.
+func (c *consistentHashingAllocator) RestoreTargets(targets map[string]*target.Item) {
+	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("RestoreTargets", consistentHashingStrategyName))
+	defer timer.ObserveDuration()
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if len(c.collectors) == 0 {
+		c.log.Info("No collector instances present, cannot restore targets")
+		return
+	}
+	for k, item := range targets {
+		if _, ok := c.targetItems[k]; ok {
+			continue
+		}
+		col, ok := c.collectors[item.CollectorName]
+		if !ok || atCapacity(col, c.maxTargetsPerCollector) {
+			item.CollectorName = ""
+			c.addTargetToTargetItems(item)
+			continue
+		}
+		c.targetItems[k] = item
+		c.addCollectorTargetItemMapping(item)
+		col.NumTargets++
+		TargetsPerCollector.WithLabelValues(col.Name, consistentHashingStrategyName).Set(float64(col.NumTargets))
+	}
+	TargetsUnassigned.WithLabelValues(consistentHashingStrategyName).Set(float64(len(c.unassignedTargets)))
 }
 
 // SetCollectors sets the set of collectors with key=collectorName, value=Collector object.
@@ -212,6 +312,7 @@ func (c *consistentHashingAllocator) SetCollectors(collectors map[string]*Collec
 	c.m.Lock()
 	defer c.m.Unlock()
 
+	c.handoffs.prune()
 	// Check for collector changes
 	collectorsDiff := diff.Maps(c.collectors, collectors)
 	if len(collectorsDiff.Additions()) != 0 || len(collectorsDiff.Removals()) != 0 {
@@ -219,22 +320,39 @@ func (c *consistentHashingAllocator) SetCollectors(collectors map[string]*Collec
 	}
 }
 
+// GetTargetsForCollectorAndJob returns the targets of the given job assigned to the given collector, along with the
+// ones handed off from the collector.
 func (c *consistentHashingAllocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
 	c.m.RLock()
 	defer c.m.RUnlock()
-	if _, ok := c.targetItemsPerJobPerCollector[collector]; !ok {
-		return []*target.Item{}
+	handedOff := c.handoffs.targets(collector, job)
+	targetItemsCopy := make([]*target.Item, 0, len(c.targetItemsPerJobPerCollector[collector][job])+len(handedOff))
+	for targetHash := range c.targetItemsPerJobPerCollector[collector][job] {
+		targetItemsCopy = append(targetItemsCopy, c.targetItems[targetHash])
 	}
-	if _, ok := c.targetItemsPerJobPerCollector[collector][job]; !ok {
-		return []*target.Item{}
+	return append(targetItemsCopy, handedOff...)
+}
+
+// Preview returns the given collectors with the number of targets they would be assigned if they replaced the current
+// ones. A separate consistent hasher is used, so the current allocation is left untouched.
+func (c *consistentHashingAllocator) Preview(collectors map[string]*Collector) map[string]*Collector {
+	preview := make(map[string]*Collector, len(collectors))
+	members := make([]consistent.Member, 0, len(collectors))
+	for name := range collectors {
+		preview[name] = NewCollector(name)
+		members = append(members, preview[name])
 	}
-	targetItemsCopy := make([]*target.Item, len(c.targetItemsPerJobPerCollector[collector][job]))
-	index := 0
-	for targetHash := range c.targetItemsPerJobPerCollector[collector][job] {
-		targetItemsCopy[index] = c.targetItems[targetHash]
-		index++
+	if len(members) == 0 {
+		return preview
 	}
-	return targetItemsCopy
+	consistentHasher := newConsistentHasher(members)
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+	for _, item := range c.targetItems {
+		preview[consistentHasher.LocateKey([]byte(item.Hash())).String()].NumTargets++
+	}
+	return preview
 }
 
 // TargetItems returns a shallow copy of the targetItems map.
@@ -103,3 +103,30 @@ func TestNumRemapped(t *testing.T) {
 	}
 	assert.InDelta(t, numItems/numFinalCols, countRemapped, expectedDelta)
 }
+
+func TestConsistentHashingPreview(t *testing.T) {
+	numItems := 10_000
+	c := newConsistentHashingAllocator(logger)
+	c.SetCollectors(MakeNCollectors(15, 0))
+	c.SetTargets(MakeNNewTargets(numItems, 15, 0))
+
+	newCols := MakeNCollectors(16, 0)
+	preview := c.Preview(newCols)
+	assert.Len(t, preview, 16)
+	total := 0
+	for _, col := range preview {
+		total += col.NumTargets
+	}
+	assert.Equal(t, numItems, total)
+	// the live allocation must not change
+	assert.Len(t, c.Collectors(), 15)
+
+	c.SetCollectors(newCols)
+	numTargets := map[string]int{}
+	for _, item := range c.TargetItems() {
+		numTargets[item.CollectorName]++
+	}
+	for name, col := range preview {
+		assert.Equal(t, numTargets[name], col.NumTargets)
+	}
+}
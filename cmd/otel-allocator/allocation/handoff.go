@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+// HandoffDuration returns how long the targets of the given job are still served to the collector they moved from,
+// 0 meaning they aren't.
+type HandoffDuration func(jobName string) time.Duration
+
+// handoffs holds the targets which moved from a collector to another, and are still served to the collector they
+// moved from for a while. Both collectors scrape the targets during the handoff, so that the series of the targets
+// don't go stale between the last scrape of the collector they moved from and the first scrape of the one they moved
+// to. The handoffs aren't safe for concurrent use, the allocators protect them with their lock.
+type handoffs struct {
+	duration HandoffDuration
+	// target item hash -> handoff
+	items map[string]handoff
+	// now is replaced by the tests
+	now func() time.Time
+}
+
+type handoff struct {
+	item  *target.Item
+	from  string
+	until time.Time
+}
+
+func newHandoffs() *handoffs {
+	return &handoffs{
+		items: make(map[string]handoff),
+		now:   time.Now,
+	}
+}
+
+// moved records that the given target was assigned to its collector, from the given collector. The target is handed
+// off from the given collector, unless it's the same collector. A handoff of the target from its new collector ends,
+// the collector being assigned the target again.
+func (h *handoffs) moved(item *target.Item, from string) {
+	if ho, ok := h.items[item.Hash()]; ok && ho.from == item.CollectorName {
+		delete(h.items, item.Hash())
+	}
+	if h.duration == nil || len(from) == 0 || from == item.CollectorName {
+		return
+	}
+	duration := h.duration(item.JobName)
+	if duration <= 0 {
+		return
+	}
+	h.items[item.Hash()] = handoff{item: item, from: from, until: h.now().Add(duration)}
+	targetsHandedOff.Inc()
+}
+
+// prune forgets the handoffs which ended. The allocators prune the handoffs once per change of their targets or
+// collectors, rather than on every moved target, as the handoffs may hold many targets.
+func (h *handoffs) prune() {
+	now := h.now()
+	for hash, ho := range h.items {
+		if !now.Before(ho.until) {
+			delete(h.items, hash)
+		}
+	}
+}
+
+// removeTarget ends the handoff of the target with the given hash, which is no longer discovered.
+func (h *handoffs) removeTarget(hash string) {
+	delete(h.items, hash)
+}
+
+// removeCollector ends the handoffs from the given collector, which is gone.
+func (h *handoffs) removeCollector(name string) {
+	for hash, ho := range h.items {
+		if ho.from == name {
+			delete(h.items, hash)
+		}
+	}
+}
+
+// targets returns the targets of the given job handed off from the given collector.
+func (h *handoffs) targets(collector, job string) []*target.Item {
+	var items []*target.Item
+	now := h.now()
+	for _, ho := range h.items {
+		if ho.from == collector && ho.item.JobName == job && now.Before(ho.until) {
+			items = append(items, ho.item)
+		}
+	}
+	return items
+}
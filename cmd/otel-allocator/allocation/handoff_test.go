@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestConsistentHashingTargetHandoff(t *testing.T) {
+	now := time.Now()
+	a, err := New(consistentHashingStrategyName, logger, WithTargetHandoff(func(jobName string) time.Duration {
+		return time.Minute
+	}))
+	require.NoError(t, err)
+	c := a.(*consistentHashingAllocator)
+	c.handoffs.now = func() time.Time { return now }
+
+	c.SetCollectors(MakeNCollectors(3, 0))
+	targets := MakeNNewTargets(30, 0, 0)
+	c.SetTargets(targets)
+	previous := map[string]string{}
+	for hash, item := range c.TargetItems() {
+		previous[hash] = item.CollectorName
+	}
+
+	c.SetCollectors(MakeNCollectors(4, 0))
+	moved := 0
+	for hash, item := range c.TargetItems() {
+		from := previous[hash]
+		if item.CollectorName == from {
+			continue
+		}
+		moved++
+		assert.Contains(t, c.GetTargetsForCollectorAndJob(from, item.JobName), item, "the target is served to the collector it moved from")
+		assert.Contains(t, c.GetTargetsForCollectorAndJob(item.CollectorName, item.JobName), item)
+	}
+	require.NotZero(t, moved)
+
+	// a removed target is no longer handed off
+	var removed *target.Item
+	for hash, item := range c.TargetItems() {
+		if item.CollectorName != previous[hash] {
+			removed = item
+			delete(targets, hash)
+			break
+		}
+	}
+	c.SetTargets(targets)
+	assert.Empty(t, c.GetTargetsForCollectorAndJob(previous[removed.Hash()], removed.JobName))
+
+	// the handoffs end after their duration
+	now = now.Add(time.Minute)
+	for hash, item := range c.TargetItems() {
+		if from := previous[hash]; from != item.CollectorName {
+			assert.Empty(t, c.GetTargetsForCollectorAndJob(from, item.JobName))
+		}
+	}
+}
+
+func TestHandoffs(t *testing.T) {
+	now := time.Now()
+	h := newHandoffs()
+	h.now = func() time.Time { return now }
+	item := target.NewItem("test-job", "test-url", nil, "collector-1")
+
+	// without a duration, the targets aren't handed off
+	h.moved(item, "collector-0")
+	assert.Empty(t, h.targets("collector-0", "test-job"))
+
+	h.duration = func(jobName string) time.Duration {
+		return 30 * time.Second
+	}
+	h.moved(item, "collector-0")
+	assert.Equal(t, []*target.Item{item}, h.targets("collector-0", "test-job"))
+	assert.Empty(t, h.targets("collector-0", "other-job"))
+	assert.Empty(t, h.targets("collector-1", "test-job"))
+
+	// the target moves back to the collector it was handed off from
+	item.CollectorName = "collector-0"
+	h.moved(item, "collector-1")
+	assert.Empty(t, h.targets("collector-0", "test-job"))
+	assert.Equal(t, []*target.Item{item}, h.targets("collector-1", "test-job"))
+
+	// the handoffs which ended are pruned
+	h.moved(target.NewItem("test-job", "other-url", nil, "collector-2"), "collector-0")
+	now = now.Add(30 * time.Second)
+	assert.Empty(t, h.targets("collector-0", "test-job"))
+	assert.Len(t, h.items, 2)
+	h.prune()
+	assert.Empty(t, h.items)
+
+	h.moved(item, "collector-1")
+	h.removeCollector("collector-1")
+	assert.Empty(t, h.targets("collector-1", "test-job"))
+}
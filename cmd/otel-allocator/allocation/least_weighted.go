@@ -46,6 +46,9 @@ type leastWeightedAllocator struct {
 	collectors map[string]*Collector
 	// targetItems is a map from a target item's hash to the target items allocated state
 	targetItems map[string]*target.Item
+	// unassignedTargets is a map from a target item's hash to the target items left unassigned because all the
+	// collectors are at their maximum number of targets
+	unassignedTargets map[string]*target.Item
 
 	// collectorKey -> job -> target item hash -> true
 	targetItemsPerJobPerCollector map[string]map[string]map[string]bool
@@ -53,6 +56,8 @@ type leastWeightedAllocator struct {
 	log logr.Logger
 
 	filter Filter
+
+	maxTargetsPerCollector int
 }
 
 // SetFilter sets the filtering hook to use.
@@ -60,6 +65,21 @@ func (allocator *leastWeightedAllocator) SetFilter(filter Filter) {
 	allocator.filter = filter
 }
 
+// SetTargetHandoff is a no-op: the least-weighted strategy only moves the targets of the collectors which are removed,
+// which can't be handed the targets off.
+func (allocator *leastWeightedAllocator) SetTargetHandoff(_ HandoffDuration) {}
+
+// SetMaxTargetsPerCollector sets the maximum number of targets per collector, and assigns the unassigned targets
+// the collectors can take under the new maximum.
+func (allocator *leastWeightedAllocator) SetMaxTargetsPerCollector(maxTargets int) {
+	allocator.m.Lock()
+	defer allocator.m.Unlock()
+	allocator.maxTargetsPerCollector = maxTargets
+	if len(allocator.collectors) > 0 {
+		allocator.assignUnassignedTargets()
+	}
+}
+
 func (allocator *leastWeightedAllocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
 	allocator.m.RLock()
 	defer allocator.m.RUnlock()
@@ -105,16 +125,7 @@ func (allocator *leastWeightedAllocator) Collectors() map[string]*Collector {
 // acquires the needed lock. This method assumes there are is at least 1 collector set.
 // INVARIANT: allocator.collectors must have at least 1 collector set.
 func (allocator *leastWeightedAllocator) findNextCollector() *Collector {
-	var col *Collector
-	for _, v := range allocator.collectors {
-		// If the initial collector is empty, set the initial collector to the first element of map
-		if col == nil {
-			col = v
-		} else if v.NumTargets < col.NumTargets {
-			col = v
-		}
-	}
-	return col
+	return leastLoadedCollector(allocator.collectors)
 }
 
 // addCollectorTargetItemMapping keeps track of which collector has which jobs and targets
@@ -131,6 +142,7 @@ func (allocator *leastWeightedAllocator) addCollectorTargetItemMapping(tg *targe
 }
 
 // addTargetToTargetItems assigns a target to the next available collector and adds it to the allocator's targetItems
+// When all the collectors are at their maximum number of targets, the target is moved to the unassigned targets instead.
 // This method is called from within SetTargets and SetCollectors, which acquire the needed lock.
 // This is only called after the collectors are cleared or when a new target has been found in the tempTargetMap.
 // INVARIANT: allocator.collectors must have at least 1 collector set.
@@ -138,6 +150,13 @@ func (allocator *leastWeightedAllocator) addCollectorTargetItemMapping(tg *targe
 // item while it's being encoded by the server JSON handler.
 func (allocator *leastWeightedAllocator) addTargetToTargetItems(tg *target.Item) {
 	chosenCollector := allocator.findNextCollector()
+	// the chosen collector has the fewest targets, so when it's at capacity, all the collectors are
+	if atCapacity(chosenCollector, allocator.maxTargetsPerCollector) {
+		tg.CollectorName = ""
+		delete(allocator.targetItems, tg.Hash())
+		allocator.unassignedTargets[tg.Hash()] = tg
+		return
+	}
 	tg.CollectorName = chosenCollector.Name
 	allocator.targetItems[tg.Hash()] = tg
 	allocator.addCollectorTargetItemMapping(tg)
@@ -145,6 +164,18 @@ func (allocator *leastWeightedAllocator) addTargetToTargetItems(tg *target.Item)
 	TargetsPerCollector.WithLabelValues(chosenCollector.Name, leastWeightedStrategyName).Set(float64(chosenCollector.NumTargets))
 }
 
+// assignUnassignedTargets tries to assign the unassigned targets again, once collectors were added or the maximum
+// number of targets per collector was raised. The caller of this method has to acquire a lock.
+// INVARIANT: allocator.collectors must have at least 1 collector set.
+func (allocator *leastWeightedAllocator) assignUnassignedTargets() {
+	unassigned := allocator.unassignedTargets
+	allocator.unassignedTargets = make(map[string]*target.Item)
+	for _, item := range unassigned {
+		allocator.addTargetToTargetItems(item)
+	}
+	TargetsUnassigned.WithLabelValues(leastWeightedStrategyName).Set(float64(len(allocator.unassignedTargets)))
+}
+
 // handleTargets receives the new and removed targets and reconciles the current state.
 // Any removals are removed from the allocator's targetItems and unassigned from the corresponding collector.
 // Any net-new additions are assigned to the next available collector.
@@ -175,7 +206,8 @@ func (allocator *leastWeightedAllocator) handleTargets(diff diff.Changes[*target
 
 // handleCollectors receives the new and removed collectors and reconciles the current state.
 // Any removals are removed from the allocator's collectors. New collectors are added to the allocator's collector map.
-// Finally, any targets of removed collectors are reallocated to the next available collector.
+// Finally, any targets of removed collectors are reallocated to the next available collector, and the unassigned targets
+// are assigned to the new collectors.
 func (allocator *leastWeightedAllocator) handleCollectors(diff diff.Changes[*Collector]) {
 	// Clear removed collectors
 	for _, k := range diff.Removals() {
@@ -194,6 +226,7 @@ func (allocator *leastWeightedAllocator) handleCollectors(diff diff.Changes[*Col
 			allocator.addTargetToTargetItems(item)
 		}
 	}
+	allocator.assignUnassignedTargets()
 }
 
 // SetTargets accepts a list of targets that will be used to make
@@ -215,12 +248,45 @@ func (allocator *leastWeightedAllocator) SetTargets(targets map[string]*target.I
 		allocator.log.Info("No collector instances present, cannot set targets")
 		return
 	}
+	// The unassigned targets which are still discovered are additions of the diff, to assign again
+	allocator.unassignedTargets = make(map[string]*target.Item)
 	// Check for target changes
 	targetsDiff := diff.Maps(allocator.targetItems, targets)
 	// If there are any additions or removals
 	if len(targetsDiff.Additions()) != 0 || len(targetsDiff.Removals()) != 0 {
 		allocator.handleTargets(targetsDiff)
 	}
+	TargetsUnassigned.WithLabelValues(leastWeightedStrategyName).Set(float64(len(allocator.unassignedTargets)))
+}
+
+// RestoreTargets sets the given targets, keeping them on the collectors they were assigned to when these collectors are
+// set and have room for them.
+func (allocator *leastWeightedAllocator) RestoreTargets(targets map[string]*target.Item) {
+	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("RestoreTargets", leastWeightedStrategyName))
+	defer timer.ObserveDuration()
+
+	allocator.m.Lock()
+	defer allocator.m.Unlock()
+
+	if len(allocator.collectors) == 0 {
+		allocator.log.Info("No collector instances present, cannot restore targets")
+		return
+	}
+	for k, item := range targets {
+		if _, ok := allocator.targetItems[k]; ok {
+			continue
+		}
+		col, ok := allocator.collectors[item.CollectorName]
+		if !ok || atCapacity(col, allocator.maxTargetsPerCollector) {
+			allocator.addTargetToTargetItems(item)
+			continue
+		}
+		allocator.targetItems[k] = item
+		allocator.addCollectorTargetItemMapping(item)
+		col.NumTargets++
+		TargetsPerCollector.WithLabelValues(col.Name, leastWeightedStrategyName).Set(float64(col.NumTargets))
+	}
+	TargetsUnassigned.WithLabelValues(leastWeightedStrategyName).Set(float64(len(allocator.unassignedTargets)))
 }
 
 // SetCollectors sets the set of collectors with key=collectorName, value=Collector object.
@@ -245,11 +311,46 @@ func (allocator *leastWeightedAllocator) SetCollectors(collectors map[string]*Co
 	}
 }
 
+// Preview returns the given collectors with the number of targets they would be assigned if they replaced the current
+// ones. Just like SetCollectors, targets stay on their collector when it is kept, and targets of removed collectors go
+// to the collector with the fewest targets. The current allocation is left untouched.
+func (allocator *leastWeightedAllocator) Preview(collectors map[string]*Collector) map[string]*Collector {
+	preview := make(map[string]*Collector, len(collectors))
+	for name := range collectors {
+		preview[name] = NewCollector(name)
+	}
+	if len(preview) == 0 {
+		return preview
+	}
+
+	allocator.m.RLock()
+	defer allocator.m.RUnlock()
+	reallocated := 0
+	for _, item := range allocator.targetItems {
+		if col, ok := preview[item.CollectorName]; ok {
+			col.NumTargets++
+		} else {
+			reallocated++
+		}
+	}
+	for i := 0; i < reallocated; i++ {
+		var col *Collector
+		for _, v := range preview {
+			if col == nil || v.NumTargets < col.NumTargets {
+				col = v
+			}
+		}
+		col.NumTargets++
+	}
+	return preview
+}
+
 func newLeastWeightedAllocator(log logr.Logger, opts ...AllocationOption) Allocator {
 	lwAllocator := &leastWeightedAllocator{
 		log:                           log,
 		collectors:                    make(map[string]*Collector),
 		targetItems:                   make(map[string]*target.Item),
+		unassignedTargets:             make(map[string]*target.Item),
 		targetItemsPerJobPerCollector: make(map[string]map[string]map[string]bool),
 	}
 
@@ -256,3 +256,27 @@ func TestCollectorBalanceWhenAddingAndRemovingAtRandom(t *testing.T) {
 		assert.InDelta(t, i.NumTargets, count, math.Round(percent))
 	}
 }
+
+func TestLeastWeightedPreview(t *testing.T) {
+	s, _ := New("least-weighted", logger)
+	s.SetCollectors(MakeNCollectors(3, 0))
+	s.SetTargets(MakeNNewTargets(60, 3, 0))
+
+	// targets of the removed collector go to the new one, which has the fewest targets
+	preview := s.Preview(MakeNCollectors(3, 1))
+	assert.Len(t, preview, 3)
+	assert.Equal(t, 20, preview["collector-1"].NumTargets)
+	assert.Equal(t, 20, preview["collector-2"].NumTargets)
+	assert.Equal(t, 20, preview["collector-3"].NumTargets)
+
+	// adding collectors leaves them empty, as targets are not moved to them
+	preview = s.Preview(MakeNCollectors(4, 0))
+	assert.Len(t, preview, 4)
+	assert.Equal(t, 20, preview["collector-0"].NumTargets)
+	assert.Equal(t, 0, preview["collector-3"].NumTargets)
+
+	// the live allocation must not change
+	for _, col := range s.Collectors() {
+		assert.Equal(t, 20, col.NumTargets)
+	}
+}
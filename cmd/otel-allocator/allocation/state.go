@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+// State holds the collectors and targets of an allocator, so that they can be restored when the target allocator
+// restarts, instead of waiting for the collectors and targets to be discovered again.
+type State struct {
+	Collectors []string      `json:"collectors"`
+	Targets    []StateTarget `json:"targets"`
+}
+
+// StateTarget is a target of the allocation State.
+type StateTarget struct {
+	JobName   string         `json:"job_name"`
+	TargetURL string         `json:"target_url"`
+	Labels    model.LabelSet `json:"labels"`
+	Collector string         `json:"collector"`
+}
+
+// NewState returns the current State of the given allocator.
+func NewState(allocator Allocator) State {
+	state := State{}
+	for name := range allocator.Collectors() {
+		state.Collectors = append(state.Collectors, name)
+	}
+	for _, item := range allocator.TargetItems() {
+		var targetURL string
+		if len(item.TargetURL) > 0 {
+			targetURL = item.TargetURL[0]
+		}
+		state.Targets = append(state.Targets, StateTarget{
+			JobName:   item.JobName,
+			TargetURL: targetURL,
+			Labels:    item.Labels,
+			Collector: item.CollectorName,
+		})
+	}
+	sort.Strings(state.Collectors)
+	sort.Slice(state.Targets, func(i, j int) bool {
+		if state.Targets[i].JobName != state.Targets[j].JobName {
+			return state.Targets[i].JobName < state.Targets[j].JobName
+		}
+		return state.Targets[i].TargetURL < state.Targets[j].TargetURL
+	})
+	return state
+}
+
+// LoadState reads the State persisted in the given file.
+func LoadState(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("error unmarshaling allocation state: %w", err)
+	}
+	return state, nil
+}
+
+// Save persists the State in the given file. The file is replaced at once, so that a partially written State is never
+// loaded.
+func (s State) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Restore sets the collectors and targets of the State on the given allocator. Targets are kept on the collectors they
+// were assigned to, see Allocator.RestoreTargets.
+func (s State) Restore(allocator Allocator) {
+	collectors := make(map[string]*Collector, len(s.Collectors))
+	for _, name := range s.Collectors {
+		collectors[name] = NewCollector(name)
+	}
+	allocator.SetCollectors(collectors)
+
+	targets := make(map[string]*target.Item, len(s.Targets))
+	for _, tg := range s.Targets {
+		item := target.NewItem(tg.JobName, tg.TargetURL, tg.Labels, tg.Collector)
+		targets[item.Hash()] = item
+	}
+	allocator.RestoreTargets(targets)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestStateSaveAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	targets := map[string]*target.Item{}
+	for i := 0; i < 100; i++ {
+		item := target.NewItem("test-job", "test-url", model.LabelSet{"i": model.LabelValue(strconv.Itoa(i))}, "")
+		targets[item.Hash()] = item
+	}
+	allocator, _ := New(consistentHashingStrategyName, logger)
+	allocator.SetCollectors(MakeNCollectors(3, 0))
+	allocator.SetTargets(targets)
+
+	require.NoError(t, NewState(allocator).Save(path))
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Len(t, state.Collectors, 3)
+	assert.Len(t, state.Targets, 100)
+
+	restored, _ := New(consistentHashingStrategyName, logger)
+	state.Restore(restored)
+	assert.Len(t, restored.Collectors(), 3)
+	restoredItems := restored.TargetItems()
+	assert.Len(t, restoredItems, 100)
+	for hash, item := range allocator.TargetItems() {
+		require.Contains(t, restoredItems, hash)
+		assert.Equal(t, item.CollectorName, restoredItems[hash].CollectorName)
+		assert.Equal(t, item.Labels, restoredItems[hash].Labels)
+	}
+}
+
+func TestStateRestoreKeepsAssignments(t *testing.T) {
+	state := State{
+		Collectors: []string{"collector-0", "collector-1", "collector-2"},
+		Targets: []StateTarget{
+			{JobName: "test-job", TargetURL: "test-url-0", Collector: "collector-2"},
+			{JobName: "test-job", TargetURL: "test-url-1", Collector: "collector-2"},
+			{JobName: "test-job", TargetURL: "test-url-2", Collector: "collector-2"},
+			{JobName: "test-job", TargetURL: "test-url-3", Collector: "collector-gone"},
+		},
+	}
+	for _, strategy := range GetRegisteredAllocatorNames() {
+		t.Run(strategy, func(t *testing.T) {
+			restored, err := New(strategy, logger)
+			require.NoError(t, err)
+			state.Restore(restored)
+			items := restored.TargetItems()
+			require.Len(t, items, 4)
+			onCollector2 := 0
+			for _, item := range items {
+				if item.CollectorName == "collector-2" {
+					onCollector2++
+				}
+				if item.TargetURL[0] == "test-url-3" {
+					assert.Contains(t, state.Collectors, item.CollectorName, "the target of a collector which is gone is allocated again")
+					continue
+				}
+				assert.Equal(t, "collector-2", item.CollectorName)
+			}
+			assert.Len(t, restored.GetTargetsForCollectorAndJob("collector-2", "test-job"), onCollector2)
+			assert.Equal(t, onCollector2, restored.Collectors()["collector-2"].NumTargets)
+		})
+	}
+}
+
+func TestLoadMissingState(t *testing.T) {
+	_, err := LoadState(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
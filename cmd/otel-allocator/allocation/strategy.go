@@ -49,6 +49,16 @@ var (
 		Name: "opentelemetry_allocator_targets_remaining",
 		Help: "Number of targets kept after filtering.",
 	})
+	// TargetsUnassigned records how many targets are left unassigned because all the collectors have reached the
+	// maximum number of targets per collector.
+	TargetsUnassigned = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_targets_unassigned",
+		Help: "The number of targets left unassigned because the collectors are at their maximum number of targets.",
+	}, []string{"strategy"})
+	targetsHandedOff = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_target_handoffs",
+		Help: "Number of targets handed off from a collector to another.",
+	})
 )
 
 type AllocationOption func(Allocator)
@@ -63,6 +73,21 @@ func WithFilter(filter Filter) AllocationOption {
 	}
 }
 
+// WithMaxTargetsPerCollector limits the number of targets assigned to each collector, see
+// Allocator.SetMaxTargetsPerCollector.
+func WithMaxTargetsPerCollector(maxTargets int) AllocationOption {
+	return func(allocator Allocator) {
+		allocator.SetMaxTargetsPerCollector(maxTargets)
+	}
+}
+
+// WithTargetHandoff hands the targets moving between collectors off, see Allocator.SetTargetHandoff.
+func WithTargetHandoff(duration HandoffDuration) AllocationOption {
+	return func(allocator Allocator) {
+		allocator.SetTargetHandoff(duration)
+	}
+}
+
 func RecordTargetsKept(targets map[string]*target.Item) {
 	targetsRemaining.Add(float64(len(targets)))
 }
@@ -93,10 +118,24 @@ func GetRegisteredAllocatorNames() []string {
 type Allocator interface {
 	SetCollectors(collectors map[string]*Collector)
 	SetTargets(targets map[string]*target.Item)
+	// RestoreTargets sets the given targets like SetTargets, keeping them on the collectors named by their
+	// CollectorName when these collectors are set and have room for them, so that the targets restored after a restart
+	// don't move between collectors. The other targets are allocated by the strategy.
+	RestoreTargets(targets map[string]*target.Item)
 	TargetItems() map[string]*target.Item
 	Collectors() map[string]*Collector
 	GetTargetsForCollectorAndJob(collector string, job string) []*target.Item
 	SetFilter(filter Filter)
+	// SetMaxTargetsPerCollector limits the number of targets assigned to each collector, 0 meaning no limit. The
+	// targets that would be assigned to a collector at the limit are assigned to the collector with the fewest targets
+	// instead, and left unassigned when all the collectors are at the limit, until collectors are added or targets
+	// removed, instead of overloading the collectors. Lowering the limit doesn't unassign targets.
+	SetMaxTargetsPerCollector(maxTargets int)
+	// SetTargetHandoff keeps serving the targets which move from a collector to another to the collector they moved
+	// from, for the duration returned for their job, so that the targets are scraped while the collector they moved
+	// to picks them up. A nil duration disables the handoff.
+	SetTargetHandoff(duration HandoffDuration)
+	Preview(collectors map[string]*Collector) map[string]*Collector
 }
 
 var _ consistent.Member = Collector{}
@@ -121,6 +160,26 @@ func NewCollector(name string) *Collector {
 	return &Collector{Name: name}
 }
 
+// atCapacity returns whether the given collector can't be assigned more targets with the given maximum number of
+// targets per collector.
+func atCapacity(col *Collector, maxTargets int) bool {
+	return maxTargets > 0 && col.NumTargets >= maxTargets
+}
+
+// leastLoadedCollector returns the collector with the fewest targets, or nil without collectors.
+func leastLoadedCollector(collectors map[string]*Collector) *Collector {
+	var col *Collector
+	for _, v := range collectors {
+		// If the initial collector is empty, set the initial collector to the first element of map
+		if col == nil {
+			col = v
+		} else if v.NumTargets < col.NumTargets {
+			col = v
+		}
+	}
+	return col
+}
+
 func init() {
 	err := Register(leastWeightedStrategyName, newLeastWeightedAllocator)
 	if err != nil {
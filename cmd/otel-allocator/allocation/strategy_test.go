@@ -19,6 +19,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
 )
 
@@ -134,3 +137,40 @@ func TestCollectorDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxTargetsPerCollector(t *testing.T) {
+	assertMaxTargets := func(t *testing.T, a Allocator, maxTargets int) {
+		for _, col := range a.Collectors() {
+			assert.LessOrEqual(t, col.NumTargets, maxTargets)
+		}
+	}
+	for _, s := range GetRegisteredAllocatorNames() {
+		t.Run(s, func(t *testing.T) {
+			a, err := New(s, logger, WithMaxTargetsPerCollector(2))
+			require.NoError(t, err)
+			a.SetCollectors(MakeNCollectors(3, 0))
+			targets := MakeNNewTargets(10, 0, 0)
+			a.SetTargets(targets)
+			assertMaxTargets(t, a, 2)
+			assert.Len(t, a.TargetItems(), 6)
+
+			// the unassigned targets are assigned to the new collectors
+			a.SetCollectors(MakeNCollectors(5, 0))
+			assertMaxTargets(t, a, 2)
+			assert.Len(t, a.TargetItems(), 10)
+
+			// the unassigned targets are kept across the discoveries of targets
+			a.SetCollectors(MakeNCollectors(3, 0))
+			a.SetTargets(targets)
+			assertMaxTargets(t, a, 2)
+			assert.Len(t, a.TargetItems(), 6)
+
+			// the unassigned targets are assigned when the limit is raised
+			a.SetMaxTargetsPerCollector(0)
+			assert.Len(t, a.TargetItems(), 10)
+			for _, item := range a.TargetItems() {
+				assert.NotEmpty(t, item.CollectorName)
+			}
+		})
+	}
+}
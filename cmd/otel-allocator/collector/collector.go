@@ -26,14 +26,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
 )
 
 const (
-	watcherTimeout = 15 * time.Minute
+	// notReadyCheckInterval is how often collectors are checked for having been NotReady for longer than the grace period.
+	notReadyCheckInterval = time.Second
 )
 
 var (
@@ -48,67 +51,82 @@ type Client struct {
 	log       logr.Logger
 	k8sClient kubernetes.Interface
 	close     chan struct{}
+	// resyncPeriod is how often the informer replays the collector pods, to recover from missed updates.
+	resyncPeriod time.Duration
+	// notReadyGracePeriod is how long a collector keeps its targets after becoming NotReady.
+	notReadyGracePeriod time.Duration
+	// notReadySince holds when the collectors still having targets became NotReady.
+	notReadySince map[string]time.Time
 }
 
-func NewClient(logger logr.Logger, kubeConfig *rest.Config) (*Client, error) {
+func NewClient(logger logr.Logger, kubeConfig *rest.Config, resyncPeriod time.Duration, notReadyGracePeriod time.Duration) (*Client, error) {
 	clientset, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		return &Client{}, err
 	}
 
 	return &Client{
-		log:       logger.WithValues("component", "opentelemetry-targetallocator"),
-		k8sClient: clientset,
-		close:     make(chan struct{}),
+		log:                 logger.WithValues("component", "opentelemetry-targetallocator"),
+		k8sClient:           clientset,
+		close:               make(chan struct{}),
+		resyncPeriod:        resyncPeriod,
+		notReadyGracePeriod: notReadyGracePeriod,
+		notReadySince:       make(map[string]time.Time),
 	}, nil
 }
 
+// Watch keeps track of the collector pods matching the given labels, using an informer so that the pods are only
+// listed once and then kept up to date from the events, and calls fn whenever the collectors or their readiness change.
+// Events which change neither, like the periodic resyncs of the informer, don't call fn.
 func (k *Client) Watch(ctx context.Context, labelMap map[string]string, fn func(collectors map[string]*allocation.Collector)) error {
-	collectorMap := map[string]*allocation.Collector{}
-
-	opts := metav1.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labelMap).String(),
-	}
-	pods, err := k.k8sClient.CoreV1().Pods(ns).List(ctx, opts)
-	if err != nil {
-		k.log.Error(err, "Pod failure")
-		os.Exit(1)
-	}
-	for i := range pods.Items {
-		pod := pods.Items[i]
-		if pod.GetObjectMeta().GetDeletionTimestamp() == nil {
-			collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+	factory := informers.NewSharedInformerFactoryWithOptions(k.k8sClient, k.resyncPeriod,
+		informers.WithNamespace(ns),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labels.SelectorFromSet(labelMap).String()
+		}))
+	stopCh := make(chan struct{})
+	events := make(chan watch.Event)
+	send := func(eventType watch.EventType, obj interface{}) {
+		pod, ok := obj.(*v1.Pod)
+		if !ok {
+			return
 		}
-	}
-
-	fn(collectorMap)
-
-	for {
-		if !k.restartWatch(ctx, opts, collectorMap, fn) {
-			return nil
+		select {
+		case events <- watch.Event{Type: eventType, Object: pod}:
+		case <-stopCh:
 		}
 	}
-}
-
-func (k *Client) restartWatch(ctx context.Context, opts metav1.ListOptions, collectorMap map[string]*allocation.Collector, fn func(collectors map[string]*allocation.Collector)) bool {
-	// add timeout to the context before calling Watch
-	ctx, cancel := context.WithTimeout(ctx, watcherTimeout)
-	defer cancel()
-	watcher, err := k.k8sClient.CoreV1().Pods(ns).Watch(ctx, opts)
+	_, err := factory.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			send(watch.Added, obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			send(watch.Modified, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			send(watch.Deleted, obj)
+		},
+	})
 	if err != nil {
-		k.log.Error(err, "unable to create collector pod watcher")
-		return false
+		return err
 	}
-	k.log.Info("Successfully started a collector pod watcher")
-	if msg := runWatch(ctx, k, watcher.ResultChan(), collectorMap, fn); msg != "" {
+
+	defer factory.Shutdown()
+	defer close(stopCh)
+	factory.Start(stopCh)
+	k.log.Info("Successfully started a collector pod informer")
+	if msg := runWatch(ctx, k, events, map[string]*allocation.Collector{}, fn); msg != "" {
 		k.log.Info("Collector pod watch event stopped " + msg)
-		return false
 	}
-
-	return true
+	return nil
 }
 
 func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap map[string]*allocation.Collector, fn func(collectors map[string]*allocation.Collector)) string {
+	ticker := time.NewTicker(notReadyCheckInterval)
+	defer ticker.Stop()
 	for {
 		collectorsDiscovered.Set(float64(len(collectorMap)))
 		select {
@@ -116,6 +134,10 @@ func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap
 			return "kubernetes client closed"
 		case <-ctx.Done():
 			return ""
+		case <-ticker.C:
+			if k.removeNotReadyCollectors(collectorMap) {
+				fn(collectorMap)
+			}
 		case event, ok := <-c:
 			if !ok {
 				k.log.Info("No event found. Restarting watch routine")
@@ -128,17 +150,80 @@ func runWatch(ctx context.Context, k *Client, c <-chan watch.Event, collectorMap
 				return ""
 			}
 
+			changed := false
 			switch event.Type { //nolint:exhaustive
-			case watch.Added:
-				collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+			case watch.Added, watch.Modified:
+				changed = k.updateCollector(collectorMap, pod)
 			case watch.Deleted:
-				delete(collectorMap, pod.Name)
+				changed = k.removeCollector(collectorMap, pod.Name)
+			}
+			if changed {
+				fn(collectorMap)
 			}
-			fn(collectorMap)
 		}
 	}
 }
 
+// updateCollector adds the collector of the given pod once it's Ready. A collector becoming NotReady keeps its targets
+// for the grace period, in case it becomes Ready again, before removeNotReadyCollectors removes it. Terminating
+// collectors are removed right away. It returns whether the collectors or their readiness changed.
+func (k *Client) updateCollector(collectorMap map[string]*allocation.Collector, pod *v1.Pod) bool {
+	if pod.GetObjectMeta().GetDeletionTimestamp() != nil {
+		return k.removeCollector(collectorMap, pod.Name)
+	}
+	_, notReady := k.notReadySince[pod.Name]
+	if isPodReady(pod) {
+		delete(k.notReadySince, pod.Name)
+		if _, ok := collectorMap[pod.Name]; !ok {
+			collectorMap[pod.Name] = allocation.NewCollector(pod.Name)
+			return true
+		}
+		return notReady
+	}
+	if _, ok := collectorMap[pod.Name]; !ok {
+		return false
+	}
+	if !notReady {
+		k.log.Info("Collector is not ready", "collector", pod.Name, "gracePeriod", k.notReadyGracePeriod)
+		k.notReadySince[pod.Name] = time.Now()
+		return true
+	}
+	return false
+}
+
+// removeCollector removes the collector of the pod with the given name, and returns whether it was a collector.
+func (k *Client) removeCollector(collectorMap map[string]*allocation.Collector, name string) bool {
+	_, ok := collectorMap[name]
+	delete(collectorMap, name)
+	delete(k.notReadySince, name)
+	return ok
+}
+
+// removeNotReadyCollectors removes the collectors that have been NotReady for longer than the grace period, and
+// returns whether any collector was removed.
+func (k *Client) removeNotReadyCollectors(collectorMap map[string]*allocation.Collector) bool {
+	removed := false
+	for name, since := range k.notReadySince {
+		if time.Since(since) < k.notReadyGracePeriod {
+			continue
+		}
+		k.log.Info("Collector has not been ready for longer than the grace period, reallocating its targets", "collector", name)
+		delete(collectorMap, name)
+		delete(k.notReadySince, name)
+		removed = true
+	}
+	return removed
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func (k *Client) Close() {
 	close(k.close)
 }
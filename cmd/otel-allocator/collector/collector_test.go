@@ -38,9 +38,10 @@ var logger = logf.Log.WithName("collector-unit-tests")
 
 func getTestClient() (Client, watch.Interface) {
 	kubeClient := Client{
-		k8sClient: fake.NewSimpleClientset(),
-		close:     make(chan struct{}),
-		log:       logger,
+		k8sClient:     fake.NewSimpleClientset(),
+		close:         make(chan struct{}),
+		log:           logger,
+		notReadySince: make(map[string]time.Time),
 	}
 
 	labelMap := map[string]string{
@@ -70,9 +71,23 @@ func pod(name string) *v1.Pod {
 			Namespace: "test-ns",
 			Labels:    labelSet,
 		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:   v1.PodReady,
+					Status: v1.ConditionTrue,
+				},
+			},
+		},
 	}
 }
 
+func notReadyPod(name string) *v1.Pod {
+	p := pod(name)
+	p.Status.Conditions[0].Status = v1.ConditionFalse
+	return p
+}
+
 func Test_runWatch(t *testing.T) {
 	type args struct {
 		kubeFn       func(t *testing.T, client Client, group *sync.WaitGroup)
@@ -166,6 +181,88 @@ func Test_runWatch(t *testing.T) {
 	}
 }
 
+func Test_runWatchReadiness(t *testing.T) {
+	kubeClient, watcher := getTestClient()
+	kubeClient.notReadyGracePeriod = 2 * time.Second
+	defer func() {
+		close(kubeClient.close)
+		watcher.Stop()
+	}()
+	updates := make(chan map[string]*allocation.Collector)
+	go runWatch(context.Background(), &kubeClient, watcher.ResultChan(), map[string]*allocation.Collector{}, func(colMap map[string]*allocation.Collector) {
+		collectors := make(map[string]*allocation.Collector, len(colMap))
+		for k, v := range colMap {
+			collectors[k] = v
+		}
+		updates <- collectors
+	})
+
+	// a collector that isn't ready yet doesn't get targets
+	_, err := kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), notReadyPod("test-pod1"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), pod("test-pod1"), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, <-updates, "test-pod1")
+
+	// updates changing neither the collectors nor their readiness are ignored
+	unchanged := pod("test-pod1")
+	unchanged.Annotations = map[string]string{"test": "unchanged"}
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), unchanged, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	select {
+	case collectors := <-updates:
+		t.Fatalf("unexpected update of the collectors %v", collectors)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// a collector becoming not ready keeps its targets for the grace period
+	notReadySince := time.Now()
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), notReadyPod("test-pod1"), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, <-updates, "test-pod1")
+
+	assert.Empty(t, <-updates)
+	assert.GreaterOrEqual(t, time.Since(notReadySince), kubeClient.notReadyGracePeriod)
+}
+
+func TestWatch(t *testing.T) {
+	kubeClient, watcher := getTestClient()
+	watcher.Stop()
+	_, err := kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), pod("test-pod1"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	other := pod("other-pod")
+	other.Labels = map[string]string{"app.kubernetes.io/instance": "default.other"}
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), other, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	updates := make(chan []string, 10)
+	done := make(chan error)
+	go func() {
+		done <- kubeClient.Watch(context.Background(), map[string]string{"app.kubernetes.io/instance": "default.test"}, func(colMap map[string]*allocation.Collector) {
+			var names []string
+			for name := range colMap {
+				names = append(names, name)
+			}
+			updates <- names
+		})
+	}()
+
+	// pods existing before the watch are listed, only the ones matching the labels are collectors
+	assert.ElementsMatch(t, []string{"test-pod1"}, <-updates)
+
+	_, err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), pod("test-pod2"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test-pod1", "test-pod2"}, <-updates)
+
+	err = kubeClient.k8sClient.CoreV1().Pods("test-ns").Delete(context.Background(), "test-pod1", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test-pod2"}, <-updates)
+
+	close(kubeClient.close)
+	assert.NoError(t, <-done)
+}
+
 // this tests runWatch in the case of watcher channel closing and watcher timing out.
 func Test_closeChannel(t *testing.T) {
 	tests := []struct {
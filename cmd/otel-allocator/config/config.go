@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
 	promconfig "github.com/prometheus/prometheus/config"
 	_ "github.com/prometheus/prometheus/discovery/install"
 	"github.com/spf13/pflag"
@@ -38,6 +39,7 @@ import (
 
 const DefaultResyncTime = 5 * time.Minute
 const DefaultConfigFilePath string = "/conf/targetallocator.yaml"
+const DefaultCollectorNotReadyGracePeriod = 30 * time.Second
 
 type Config struct {
 	LabelSelector          map[string]string  `yaml:"label_selector,omitempty"`
@@ -46,6 +48,67 @@ type Config struct {
 	FilterStrategy         *string            `yaml:"filter_strategy,omitempty"`
 	PodMonitorSelector     map[string]string  `yaml:"pod_monitor_selector,omitempty"`
 	ServiceMonitorSelector map[string]string  `yaml:"service_monitor_selector,omitempty"`
+	WorkItems              []WorkItem         `yaml:"work_items,omitempty"`
+	ScrapeOverrides        *ScrapeOverrides   `yaml:"scrape_overrides,omitempty"`
+	// MaxTargetsPerCollector is the maximum number of targets assigned to each collector, 0 meaning no limit. The
+	// targets over the limit are left unassigned until collectors are added.
+	MaxTargetsPerCollector int            `yaml:"max_targets_per_collector,omitempty"`
+	TargetHandoff          *TargetHandoff `yaml:"target_handoff,omitempty"`
+}
+
+// TargetHandoff keeps serving the targets which move from a collector to another to the collector they moved from,
+// until the collector they moved to has scraped them, so that their series don't go stale in between.
+type TargetHandoff struct {
+	// RefreshInterval is the interval the collectors get their targets from the target allocator at.
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+}
+
+// Duration returns how long the targets of a job scraped at the given interval are handed off for: the collector they
+// moved to gets them within a refresh interval, and scrapes them within a scrape interval.
+func (h *TargetHandoff) Duration(scrapeInterval time.Duration) time.Duration {
+	return time.Duration(h.RefreshInterval) + scrapeInterval
+}
+
+// ScrapeOverrides are enforced on all the scrape configs served to the collectors, whatever the scrape configs,
+// ServiceMonitors or PodMonitors they come from request, so that a single job can't overload the collectors.
+type ScrapeOverrides struct {
+	// MinScrapeInterval is the shortest scrape interval, the shorter ones are raised to it.
+	MinScrapeInterval model.Duration `yaml:"min_scrape_interval,omitempty"`
+	// SampleLimit is the highest sample limit, set on the scrape configs without a limit or with a higher one.
+	SampleLimit uint `yaml:"sample_limit,omitempty"`
+	// LabelLimit is the highest label limit, set on the scrape configs without a limit or with a higher one.
+	LabelLimit uint `yaml:"label_limit,omitempty"`
+}
+
+// Apply returns the given scrape config with the overrides enforced, or the given scrape config itself when it
+// complies with them already. The given scrape config is never modified, since it's shared with the discovery.
+func (o *ScrapeOverrides) Apply(scrapeConfig *promconfig.ScrapeConfig) *promconfig.ScrapeConfig {
+	if o == nil {
+		return scrapeConfig
+	}
+	overridden := *scrapeConfig
+	if overridden.ScrapeInterval < o.MinScrapeInterval {
+		overridden.ScrapeInterval = o.MinScrapeInterval
+	}
+	if o.SampleLimit > 0 && (overridden.SampleLimit == 0 || overridden.SampleLimit > o.SampleLimit) {
+		overridden.SampleLimit = o.SampleLimit
+	}
+	if o.LabelLimit > 0 && (overridden.LabelLimit == 0 || overridden.LabelLimit > o.LabelLimit) {
+		overridden.LabelLimit = o.LabelLimit
+	}
+	if overridden.ScrapeInterval == scrapeConfig.ScrapeInterval && overridden.SampleLimit == scrapeConfig.SampleLimit && overridden.LabelLimit == scrapeConfig.LabelLimit {
+		return scrapeConfig
+	}
+	return &overridden
+}
+
+// WorkItem is an item of work to allocate among the collectors, besides the Prometheus targets. Work items are served
+// by job, the same way as targets, so that receivers other than the Prometheus one can share their work across
+// collectors, like SQL queries or SNMP devices to poll.
+type WorkItem struct {
+	JobName  string            `yaml:"job_name"`
+	Endpoint string            `yaml:"endpoint"`
+	Labels   map[string]string `yaml:"labels,omitempty"`
 }
 
 func (c Config) GetAllocationStrategy() string {
@@ -74,6 +137,16 @@ type CLIConfig struct {
 	KubeConfigFilePath string
 	RootLogger         logr.Logger
 	PromCRWatcherConf  PrometheusCRWatcherConfig
+	// AllocationStateFile empty if the allocation state isn't persisted
+	AllocationStateFile          *string
+	CollectorResyncPeriod        *time.Duration
+	CollectorNotReadyGracePeriod *time.Duration
+	// JobShard is the shard of this instance when the scrape jobs are sharded across JobShards instances
+	JobShard  *int
+	JobShards *int
+	// Zone is the availability zone of this instance when there's one instance per zone in Zones, empty otherwise
+	Zone  *string
+	Zones *[]string
 }
 
 func Load(file string) (Config, error) {
@@ -105,6 +178,13 @@ func ParseCLI() (CLIConfig, error) {
 		PromCRWatcherConf: PrometheusCRWatcherConfig{
 			Enabled: pflag.Bool("enable-prometheus-cr-watcher", false, "Enable Prometheus CRs as target sources"),
 		},
+		AllocationStateFile:          pflag.String("allocation-state-file", "", "The path to the file where the allocation state is persisted, to be restored on restart."),
+		CollectorResyncPeriod:        pflag.Duration("collector-resync-period", DefaultResyncTime, "How often the collector pods are resynced from the informer cache."),
+		CollectorNotReadyGracePeriod: pflag.Duration("collector-not-ready-grace-period", DefaultCollectorNotReadyGracePeriod, "How long a collector keeps its targets after becoming not ready."),
+		JobShard:                     pflag.Int("job-shard", 0, "The shard of the scrape jobs this instance is responsible for."),
+		JobShards:                    pflag.Int("job-shards", 1, "The number of shards the scrape jobs are partitioned across."),
+		Zone:                         pflag.String("zone", "", "The availability zone whose targets this instance is responsible for."),
+		Zones:                        pflag.StringSlice("zones", nil, "The availability zones there's an instance for. The targets in other or unknown zones are served by the instance of the first zone."),
 	}
 	kubeconfigPath := pflag.String("kubeconfig-path", filepath.Join(homedir.HomeDir(), ".kube", "config"), "absolute path to the KubeconfigPath file")
 	pflag.Parse()
@@ -133,8 +213,32 @@ func ParseCLI() (CLIConfig, error) {
 // ValidateConfig validates the cli and file configs together.
 func ValidateConfig(config *Config, cliConfig *CLIConfig) error {
 	scrapeConfigsPresent := (config.Config != nil && len(config.Config.ScrapeConfigs) > 0)
-	if !(*cliConfig.PromCRWatcherConf.Enabled || scrapeConfigsPresent) {
-		return fmt.Errorf("at least one scrape config must be defined, or Prometheus CR watching must be enabled")
+	if !(*cliConfig.PromCRWatcherConf.Enabled || scrapeConfigsPresent || len(config.WorkItems) > 0) {
+		return fmt.Errorf("at least one scrape config or work item must be defined, or Prometheus CR watching must be enabled")
+	}
+	for i, item := range config.WorkItems {
+		if item.JobName == "" || item.Endpoint == "" {
+			return fmt.Errorf("work item %d must have a job_name and an endpoint", i)
+		}
+	}
+	if cliConfig.JobShard != nil && cliConfig.JobShards != nil &&
+		(*cliConfig.JobShards < 1 || *cliConfig.JobShard < 0 || *cliConfig.JobShard >= *cliConfig.JobShards) {
+		return fmt.Errorf("job shard %d is out of range for %d job shards", *cliConfig.JobShard, *cliConfig.JobShards)
+	}
+	if cliConfig.Zone != nil && *cliConfig.Zone != "" && !hasZone(cliConfig.Zones, *cliConfig.Zone) {
+		return fmt.Errorf("zone %s isn't one of the zones", *cliConfig.Zone)
 	}
 	return nil
 }
+
+func hasZone(zones *[]string, zone string) bool {
+	if zones == nil {
+		return false
+	}
+	for _, z := range *zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
@@ -154,6 +154,80 @@ func TestLoad(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "work items",
+			args: args{
+				file: "./testdata/work_items_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				WorkItems: []WorkItem{
+					{
+						JobName:  "sql-queries",
+						Endpoint: "postgres:5432",
+						Labels: map[string]string{
+							"database": "orders",
+						},
+					},
+					{
+						JobName:  "snmp",
+						Endpoint: "switch-1:161",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "scrape overrides",
+			args: args{
+				file: "./testdata/scrape_overrides_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				ScrapeOverrides: &ScrapeOverrides{
+					MinScrapeInterval: model.Duration(30 * time.Second),
+					SampleLimit:       10000,
+					LabelLimit:        50,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "max targets per collector",
+			args: args{
+				file: "./testdata/max_targets_per_collector_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				MaxTargetsPerCollector: 500,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "target handoff",
+			args: args{
+				file: "./testdata/target_handoff_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				TargetHandoff: &TargetHandoff{
+					RefreshInterval: model.Duration(30 * time.Second),
+				},
+			},
+			wantErr: assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -166,9 +240,55 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestTargetHandoffDuration(t *testing.T) {
+	handoff := &TargetHandoff{RefreshInterval: model.Duration(30 * time.Second)}
+	assert.Equal(t, 45*time.Second, handoff.Duration(15*time.Second))
+	assert.Equal(t, 30*time.Second, handoff.Duration(0))
+}
+
+func TestScrapeOverridesApply(t *testing.T) {
+	overrides := &ScrapeOverrides{
+		MinScrapeInterval: model.Duration(30 * time.Second),
+		SampleLimit:       10000,
+		LabelLimit:        50,
+	}
+
+	compliant := &promconfig.ScrapeConfig{
+		JobName:        "compliant",
+		ScrapeInterval: model.Duration(time.Minute),
+		SampleLimit:    5000,
+		LabelLimit:     30,
+	}
+	assert.Same(t, compliant, overrides.Apply(compliant))
+
+	greedy := &promconfig.ScrapeConfig{
+		JobName:        "greedy",
+		ScrapeInterval: model.Duration(time.Second),
+		ScrapeTimeout:  model.Duration(time.Second),
+		LabelLimit:     100,
+	}
+	overridden := overrides.Apply(greedy)
+	assert.Equal(t, &promconfig.ScrapeConfig{
+		JobName:        "greedy",
+		ScrapeInterval: model.Duration(30 * time.Second),
+		ScrapeTimeout:  model.Duration(time.Second),
+		SampleLimit:    10000,
+		LabelLimit:     50,
+	}, overridden)
+	// the given scrape config is left as it is
+	assert.Equal(t, model.Duration(time.Second), greedy.ScrapeInterval)
+	assert.Equal(t, uint(0), greedy.SampleLimit)
+
+	var none *ScrapeOverrides
+	assert.Same(t, greedy, none.Apply(greedy))
+}
+
 func TestValidateConfig(t *testing.T) {
 	enabled := true
 	disabled := false
+	two, three := 2, 3
+	zoneA, zoneC := "eu-west-1a", "eu-west-1c"
+	zones := []string{"eu-west-1a", "eu-west-1b"}
 	testCases := []struct {
 		name        string
 		cliConfig   CLIConfig
@@ -185,13 +305,13 @@ func TestValidateConfig(t *testing.T) {
 			name:        "promCR disabled, no Prometheus config",
 			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &disabled}},
 			fileConfig:  Config{Config: nil},
-			expectedErr: fmt.Errorf("at least one scrape config must be defined, or Prometheus CR watching must be enabled"),
+			expectedErr: fmt.Errorf("at least one scrape config or work item must be defined, or Prometheus CR watching must be enabled"),
 		},
 		{
 			name:        "promCR disabled, Prometheus config present, no scrapeConfigs",
 			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &disabled}},
 			fileConfig:  Config{Config: &promconfig.Config{}},
-			expectedErr: fmt.Errorf("at least one scrape config must be defined, or Prometheus CR watching must be enabled"),
+			expectedErr: fmt.Errorf("at least one scrape config or work item must be defined, or Prometheus CR watching must be enabled"),
 		},
 		{
 			name:      "promCR disabled, Prometheus config present, scrapeConfigs present",
@@ -201,6 +321,42 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:        "promCR disabled, no Prometheus config, work items present",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &disabled}},
+			fileConfig:  Config{WorkItems: []WorkItem{{JobName: "sql", Endpoint: "postgres:5432"}}},
+			expectedErr: nil,
+		},
+		{
+			name:        "work item without endpoint",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &disabled}},
+			fileConfig:  Config{WorkItems: []WorkItem{{JobName: "sql"}}},
+			expectedErr: fmt.Errorf("work item 0 must have a job_name and an endpoint"),
+		},
+		{
+			name:        "job shard out of range",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &enabled}, JobShard: &three, JobShards: &three},
+			fileConfig:  Config{Config: nil},
+			expectedErr: fmt.Errorf("job shard 3 is out of range for 3 job shards"),
+		},
+		{
+			name:        "job shard in range",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &enabled}, JobShard: &two, JobShards: &three},
+			fileConfig:  Config{Config: nil},
+			expectedErr: nil,
+		},
+		{
+			name:        "zone not in zones",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &enabled}, Zone: &zoneC, Zones: &zones},
+			fileConfig:  Config{Config: nil},
+			expectedErr: fmt.Errorf("zone eu-west-1c isn't one of the zones"),
+		},
+		{
+			name:        "zone in zones",
+			cliConfig:   CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &enabled}, Zone: &zoneA, Zones: &zones},
+			fileConfig:  Config{Config: nil},
+			expectedErr: nil,
+		},
 		{
 			name:      "promCR enabled, Prometheus config present, scrapeConfigs present",
 			cliConfig: CLIConfig{PromCRWatcherConf: PrometheusCRWatcherConfig{Enabled: &enabled}},
@@ -16,14 +16,18 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	gokitlog "github.com/go-kit/log"
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/discovery"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,6 +41,13 @@ import (
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/watcher"
 )
 
+const (
+	// allocationStateSaveInterval is how often the allocation state is persisted, when enabled.
+	allocationStateSaveInterval = 30 * time.Second
+	// zoneLabel is the label the operator sets on the collector pods of each availability zone.
+	zoneLabel = "topology.kubernetes.io/zone"
+)
+
 var (
 	setupLog     = ctrl.Log.WithName("setup")
 	eventsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -56,6 +67,8 @@ func main() {
 		fileWatcher      allocatorWatcher.Watcher
 		promWatcher      allocatorWatcher.Watcher
 		targetDiscoverer *target.Discoverer
+		workItemSource   *target.StaticSource
+		targetSource     target.Source
 
 		discoveryCancel context.CancelFunc
 		runGroup        run.Group
@@ -83,17 +96,42 @@ func main() {
 	log := ctrl.Log.WithName("allocator")
 
 	allocatorPrehook = prehook.New(cfg.GetTargetsFilterStrategy(), log)
-	allocator, err = allocation.New(cfg.GetAllocationStrategy(), log, allocation.WithFilter(allocatorPrehook))
+	allocator, err = allocation.New(cfg.GetAllocationStrategy(), log, allocation.WithFilter(allocatorPrehook), allocation.WithMaxTargetsPerCollector(cfg.MaxTargetsPerCollector))
 	if err != nil {
 		setupLog.Error(err, "Unable to initialize allocation strategy")
 		os.Exit(1)
 	}
+	if *cliConf.AllocationStateFile != "" {
+		state, loadErr := allocation.LoadState(*cliConf.AllocationStateFile)
+		switch {
+		case errors.Is(loadErr, fs.ErrNotExist):
+			setupLog.Info("No allocation state to restore")
+		case loadErr != nil:
+			setupLog.Error(loadErr, "Unable to load the allocation state")
+		default:
+			setupLog.Info("Restoring allocation state", "collectors", len(state.Collectors), "targets", len(state.Targets))
+			state.Restore(allocator)
+		}
+	}
 	srv := server.NewServer(log, allocator, cliConf.ListenAddr)
+	allocator.SetTargetHandoff(targetHandoff(cfg, srv))
 
 	discoveryCtx, discoveryCancel := context.WithCancel(ctx)
 	discoveryManager = discovery.NewManager(discoveryCtx, gokitlog.NewNopLogger())
 	targetDiscoverer = target.NewDiscoverer(log, discoveryManager, allocatorPrehook, srv)
-	collectorWatcher, collectorWatcherErr := collector.NewClient(log, cliConf.ClusterConfig)
+	targetDiscoverer.SetJobShard(*cliConf.JobShard, *cliConf.JobShards)
+	targetDiscoverer.SetZone(*cliConf.Zone, *cliConf.Zones)
+	targetDiscoverer.SetScrapeOverrides(cfg.ScrapeOverrides)
+	if *cliConf.Zone != "" {
+		// only the collectors of the same zone are assigned the targets
+		if cfg.LabelSelector == nil {
+			cfg.LabelSelector = map[string]string{}
+		}
+		cfg.LabelSelector[zoneLabel] = *cliConf.Zone
+	}
+	workItemSource = target.NewStaticSource(workItems(cfg, *cliConf.Zone, *cliConf.Zones))
+	targetSource = target.Combine(targetDiscoverer, workItemSource)
+	collectorWatcher, collectorWatcherErr := collector.NewClient(log, cliConf.ClusterConfig, *cliConf.CollectorResyncPeriod, *cliConf.CollectorNotReadyGracePeriod)
 	if collectorWatcherErr != nil {
 		setupLog.Error(collectorWatcherErr, "Unable to initialize collector watcher")
 		os.Exit(1)
@@ -157,13 +195,13 @@ func main() {
 				setupLog.Error(err, "Unable to apply initial configuration")
 				return err
 			}
-			err := targetDiscoverer.Watch(allocator.SetTargets)
+			err := targetSource.Watch(allocator.SetTargets)
 			setupLog.Info("Target discoverer exited")
 			return err
 		},
 		func(_ error) {
 			setupLog.Info("Closing target discoverer")
-			targetDiscoverer.Close()
+			targetSource.Close()
 		})
 	runGroup.Add(
 		func() error {
@@ -198,6 +236,17 @@ func main() {
 						setupLog.Error(err, "Unable to load configuration")
 						continue
 					}
+					if event.Source == allocatorWatcher.EventSourceConfigMap {
+						reloadedCfg, reloadErr := config.Load(*cliConf.ConfigFilePath)
+						if reloadErr != nil {
+							setupLog.Error(reloadErr, "Unable to reload work items, scrape overrides, max targets per collector and target handoff")
+						} else {
+							workItemSource.SetItems(workItems(reloadedCfg, *cliConf.Zone, *cliConf.Zones))
+							targetDiscoverer.SetScrapeOverrides(reloadedCfg.ScrapeOverrides)
+							allocator.SetMaxTargetsPerCollector(reloadedCfg.MaxTargetsPerCollector)
+							allocator.SetTargetHandoff(targetHandoff(reloadedCfg, srv))
+						}
+					}
 					err = targetDiscoverer.ApplyConfig(event.Source, loadConfig)
 					if err != nil {
 						setupLog.Error(err, "Unable to apply configuration")
@@ -214,6 +263,32 @@ func main() {
 			setupLog.Info("Closing watcher loop")
 			close(eventCloser)
 		})
+	if *cliConf.AllocationStateFile != "" {
+		stateSaverCloser := make(chan struct{})
+		saveState := func() {
+			if saveErr := allocation.NewState(allocator).Save(*cliConf.AllocationStateFile); saveErr != nil {
+				setupLog.Error(saveErr, "Unable to save the allocation state")
+			}
+		}
+		runGroup.Add(
+			func() error {
+				ticker := time.NewTicker(allocationStateSaveInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						saveState()
+					case <-stateSaverCloser:
+						saveState()
+						return nil
+					}
+				}
+			},
+			func(_ error) {
+				setupLog.Info("Closing allocation state saver")
+				close(stateSaverCloser)
+			})
+	}
 	runGroup.Add(
 		func() error {
 			for {
@@ -234,3 +309,34 @@ func main() {
 	}
 	setupLog.Info("Target allocator exited.")
 }
+
+// workItems returns the work items of the configuration as target items, so that they are allocated along with the
+// Prometheus targets. When there's one instance for each of the given zones, only the instance of the first zone gets
+// them, as their zone isn't known.
+func workItems(cfg config.Config, zone string, zones []string) map[string]*target.Item {
+	items := make(map[string]*target.Item, len(cfg.WorkItems))
+	for _, workItem := range cfg.WorkItems {
+		itemLabels := model.LabelSet{model.AddressLabel: model.LabelValue(workItem.Endpoint)}
+		for name, value := range workItem.Labels {
+			itemLabels[model.LabelName(name)] = model.LabelValue(value)
+		}
+		if !target.InZone(itemLabels, zone, zones) {
+			continue
+		}
+		item := target.NewItem(workItem.JobName, workItem.Endpoint, itemLabels, "")
+		items[item.Hash()] = item
+	}
+	return items
+}
+
+// targetHandoff returns how long the targets moving between collectors are handed off for, from the scrape intervals
+// of their jobs served by the given server, or nil when the targets aren't handed off.
+func targetHandoff(cfg config.Config, srv *server.Server) allocation.HandoffDuration {
+	if cfg.TargetHandoff == nil {
+		return nil
+	}
+	handoff := *cfg.TargetHandoff
+	return func(jobName string) time.Duration {
+		return handoff.Duration(srv.ScrapeInterval(jobName))
+	}
+}
@@ -29,9 +29,15 @@ type mockAllocator struct {
 
 func (m *mockAllocator) SetCollectors(_ map[string]*allocation.Collector)               {}
 func (m *mockAllocator) SetTargets(_ map[string]*target.Item)                           {}
+func (m *mockAllocator) RestoreTargets(_ map[string]*target.Item)                       {}
 func (m *mockAllocator) Collectors() map[string]*allocation.Collector                   { return nil }
 func (m *mockAllocator) GetTargetsForCollectorAndJob(_ string, _ string) []*target.Item { return nil }
 func (m *mockAllocator) SetFilter(_ allocation.Filter)                                  {}
+func (m *mockAllocator) SetMaxTargetsPerCollector(_ int)                                {}
+func (m *mockAllocator) SetTargetHandoff(_ allocation.HandoffDuration)                  {}
+func (m *mockAllocator) Preview(_ map[string]*allocation.Collector) map[string]*allocation.Collector {
+	return nil
+}
 
 func (m *mockAllocator) TargetItems() map[string]*target.Item {
 	return m.targetItems
@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -31,7 +33,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/model"
 	promconfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
@@ -45,6 +50,16 @@ var (
 	}, []string{"path"})
 )
 
+const (
+	// maxPreviewCollectors is the highest number of collectors an allocation preview can be asked for.
+	maxPreviewCollectors = 1000
+)
+
+var (
+	// collectorOrdinalRegex matches the names of the collector pods created by a StatefulSet.
+	collectorOrdinalRegex = regexp.MustCompile(`^(.+)-\d+$`)
+)
+
 var (
 	jsonConfig = jsoniter.Config{
 		EscapeHTML:                    false,
@@ -58,6 +73,20 @@ type collectorJSON struct {
 	Jobs []*target.Item `json:"targets"`
 }
 
+// relabelResultJSON is the result of evaluating a target against the relabel configs of a job.
+type relabelResultJSON struct {
+	Keep   bool              `json:"keep"`
+	Labels map[string]string `json:"labels"`
+	Steps  []relabelStepJSON `json:"steps"`
+}
+
+// relabelStepJSON holds the labels of a target after applying one of the relabel configs of a job.
+type relabelStepJSON struct {
+	Action relabel.Action    `json:"action"`
+	Keep   bool              `json:"keep"`
+	Labels map[string]string `json:"labels"`
+}
+
 type Server struct {
 	logger         logr.Logger
 	allocator      allocation.Allocator
@@ -69,6 +98,11 @@ type Server struct {
 	// is applied.
 	mtx                  sync.RWMutex
 	scrapeConfigResponse []byte
+	// relabelConfigs holds the relabel configs of every job, used to serve targets with their labels
+	// already relabeled. It is protected by mtx as well.
+	relabelConfigs map[string][]*relabel.Config
+	// scrapeIntervals holds the scrape interval of every job. It is protected by mtx as well.
+	scrapeIntervals map[string]time.Duration
 }
 
 func NewServer(log logr.Logger, allocator allocation.Allocator, listenAddr *string) *Server {
@@ -87,6 +121,8 @@ func NewServer(log logr.Logger, allocator allocation.Allocator, listenAddr *stri
 	router.GET("/scrape_configs", s.ScrapeConfigsHandler)
 	router.GET("/jobs", s.JobHandler)
 	router.GET("/jobs/:job_id/targets", s.TargetsHandler)
+	router.POST("/jobs/:job_id/relabel", s.RelabelHandler)
+	router.GET("/allocation/preview", s.PreviewHandler)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	registerPprof(router.Group("/debug/pprof/"))
 
@@ -118,12 +154,27 @@ func (s *Server) UpdateScrapeConfigResponse(configs map[string]*promconfig.Scrap
 	if err != nil {
 		return err
 	}
+	relabelConfigs := make(map[string][]*relabel.Config, len(configs))
+	scrapeIntervals := make(map[string]time.Duration, len(configs))
+	for job, cfg := range configs {
+		relabelConfigs[job] = replaceShardRelabelConfig(cfg.RelabelConfigs)
+		scrapeIntervals[job] = time.Duration(cfg.ScrapeInterval)
+	}
 	s.mtx.Lock()
 	s.scrapeConfigResponse = jsonConfig
+	s.relabelConfigs = relabelConfigs
+	s.scrapeIntervals = scrapeIntervals
 	s.mtx.Unlock()
 	return nil
 }
 
+// ScrapeInterval returns the scrape interval of the given job, or 0 when the job is unknown.
+func (s *Server) ScrapeInterval(job string) time.Duration {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.scrapeIntervals[job]
+}
+
 // ScrapeConfigsHandler returns the available scrape configuration discovered by the target allocator.
 func (s *Server) ScrapeConfigsHandler(c *gin.Context) {
 	s.mtx.RLock()
@@ -153,8 +204,12 @@ func (s *Server) PrometheusMiddleware(c *gin.Context) {
 	timer.ObserveDuration()
 }
 
+// TargetsHandler returns the targets of a job, either for all collectors or for the one given by the collector_id
+// query parameter. When include=labels is requested along with a collector_id, the job's relabel configs are
+// applied by the target allocator, so that the collector doesn't have to run them on its own.
 func (s *Server) TargetsHandler(c *gin.Context) {
 	q := c.Request.URL.Query()["collector_id"]
+	includeLabels := c.Query("include") == "labels"
 
 	jobIdParam := c.Params.ByName("job_id")
 	jobId, err := url.QueryUnescape(jobIdParam)
@@ -169,6 +224,9 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 
 	} else {
 		tgs := s.allocator.GetTargetsForCollectorAndJob(q[0], jobId)
+		if includeLabels {
+			tgs = s.relabelTargets(jobId, tgs)
+		}
 		// Displays empty list if nothing matches
 		if len(tgs) == 0 {
 			s.jsonHandler(c.Writer, []interface{}{})
@@ -178,6 +236,136 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 	}
 }
 
+// relabelTargets returns copies of the given targets with the job's relabel configs applied. Targets dropped by
+// the relabel configs are left out, and the __meta_ labels are removed as they are not used after relabeling.
+func (s *Server) relabelTargets(job string, tgs []*target.Item) []*target.Item {
+	s.mtx.RLock()
+	cfgs := s.relabelConfigs[job]
+	s.mtx.RUnlock()
+
+	relabeled := make([]*target.Item, 0, len(tgs))
+	for _, tg := range tgs {
+		lbls := make(map[string]string, len(tg.Labels))
+		for k, v := range tg.Labels {
+			lbls[string(k)] = string(v)
+		}
+		lset, keep := relabel.Process(labels.FromMap(lbls), cfgs...)
+		if !keep {
+			continue
+		}
+		newLabels := model.LabelSet{}
+		for _, l := range lset {
+			if strings.HasPrefix(l.Name, model.MetaLabelPrefix) {
+				continue
+			}
+			newLabels[model.LabelName(l.Name)] = model.LabelValue(l.Value)
+		}
+		relabeled = append(relabeled, target.NewItem(tg.JobName, lset.Get(model.AddressLabel), newLabels, tg.CollectorName))
+	}
+	return relabeled
+}
+
+// RelabelHandler evaluates the target labels given in the request body against the relabel configs of a job, and
+// returns whether the target is kept along with its resulting labels and the labels after each relabel config. This is
+// meant to debug why a target isn't scraped.
+func (s *Server) RelabelHandler(c *gin.Context) {
+	jobId, err := url.QueryUnescape(c.Params.ByName("job_id"))
+	if err != nil {
+		s.errorHandler(c.Writer, err)
+		return
+	}
+
+	s.mtx.RLock()
+	cfgs, ok := s.relabelConfigs[jobId]
+	s.mtx.RUnlock()
+	if !ok {
+		c.Writer.WriteHeader(http.StatusNotFound)
+		s.jsonHandler(c.Writer, fmt.Sprintf("job %s not found", jobId))
+		return
+	}
+
+	var lbls map[string]string
+	if err := s.jsonMarshaller.NewDecoder(c.Request.Body).Decode(&lbls); err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		s.jsonHandler(c.Writer, fmt.Sprintf("the request body must be a JSON object of target labels: %s", err))
+		return
+	}
+
+	s.jsonHandler(c.Writer, evaluateRelabelConfigs(labels.FromMap(lbls), cfgs))
+}
+
+// evaluateRelabelConfigs applies the given relabel configs one at a time, stopping at the first one dropping the
+// target.
+func evaluateRelabelConfigs(lset labels.Labels, cfgs []*relabel.Config) relabelResultJSON {
+	result := relabelResultJSON{Keep: true, Steps: []relabelStepJSON{}}
+	for _, cfg := range cfgs {
+		var keep bool
+		lset, keep = relabel.Process(lset, cfg)
+		if !keep {
+			result.Keep = false
+			result.Steps = append(result.Steps, relabelStepJSON{Action: cfg.Action, Keep: false, Labels: map[string]string{}})
+			break
+		}
+		result.Steps = append(result.Steps, relabelStepJSON{Action: cfg.Action, Keep: true, Labels: lset.Map()})
+	}
+	result.Labels = map[string]string{}
+	if result.Keep {
+		result.Labels = lset.Map()
+	}
+	return result
+}
+
+// replaceShardRelabelConfig returns a copy of the given relabel configs where $(SHARD) is replaced by 0, the same way
+// the relabel-config prehook does it, as the target allocator is the only shard.
+func replaceShardRelabelConfig(cfgs []*relabel.Config) []*relabel.Config {
+	replaced := make([]*relabel.Config, len(cfgs))
+	for i, cfg := range cfgs {
+		if cfg.Regex.String() == "$(SHARD)" {
+			cfgCopy := *cfg
+			cfgCopy.Regex = relabel.MustNewRegexp("0")
+			cfg = &cfgCopy
+		}
+		replaced[i] = cfg
+	}
+	return replaced
+}
+
+// PreviewHandler returns the number of targets each collector would be assigned if the number of collectors given by
+// the collectors query parameter were running. The live allocation is left untouched.
+func (s *Server) PreviewHandler(c *gin.Context) {
+	count, err := strconv.Atoi(c.Query("collectors"))
+	if err != nil || count < 1 || count > maxPreviewCollectors {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		s.jsonHandler(c.Writer, fmt.Sprintf("collectors must be a number between 1 and %d", maxPreviewCollectors))
+		return
+	}
+
+	displayData := make(map[string]int, count)
+	for name, col := range s.allocator.Preview(previewCollectors(s.allocator.Collectors(), count)) {
+		displayData[name] = col.NumTargets
+	}
+	s.jsonHandler(c.Writer, displayData)
+}
+
+// previewCollectors returns the given number of collectors. When the current collectors are the pods of a StatefulSet,
+// they are named the way the StatefulSet would name its pods, as some allocation strategies depend on the names.
+func previewCollectors(current map[string]*allocation.Collector, count int) map[string]*allocation.Collector {
+	prefix := "collector"
+	for name := range current {
+		if match := collectorOrdinalRegex.FindStringSubmatch(name); match != nil {
+			prefix = match[1]
+			break
+		}
+	}
+
+	collectors := make(map[string]*allocation.Collector, count)
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("%s-%d", prefix, i)
+		collectors[name] = allocation.NewCollector(name)
+	}
+	return collectors
+}
+
 func (s *Server) errorHandler(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusInternalServerError)
 	s.jsonHandler(w, err)
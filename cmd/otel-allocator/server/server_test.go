@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -179,6 +180,209 @@ func TestServer_TargetsHandler(t *testing.T) {
 	}
 }
 
+func TestServer_TargetsHandlerIncludeLabels(t *testing.T) {
+	consistentHashing, _ := allocation.New("consistent-hashing", logger)
+	keptItem := target.NewItem("test-job", "kept:8080", model.LabelSet{
+		model.AddressLabel:                   "kept:8080",
+		"__meta_kubernetes_pod_label_app":    "my-app",
+		"__meta_kubernetes_pod_annotation_a": "b",
+	}, "")
+	droppedItem := target.NewItem("test-job", "dropped:8080", model.LabelSet{
+		model.AddressLabel:                "dropped:8080",
+		"__meta_kubernetes_pod_label_app": "other-app",
+	}, "")
+
+	listenAddr := ":8080"
+	s := NewServer(logger, consistentHashing, &listenAddr)
+	err := s.UpdateScrapeConfigResponse(map[string]*promconfig.ScrapeConfig{
+		"test-job": {
+			JobName: "test-job",
+			RelabelConfigs: []*relabel.Config{
+				{
+					SourceLabels: model.LabelNames{"__meta_kubernetes_pod_label_app"},
+					Regex:        relabel.MustNewRegexp("my-app"),
+					Action:       relabel.Keep,
+				},
+				{
+					SourceLabels: model.LabelNames{"__meta_kubernetes_pod_label_app"},
+					Separator:    ";",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					TargetLabel:  "app",
+					Action:       relabel.Replace,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	consistentHashing.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+	consistentHashing.SetTargets(map[string]*target.Item{
+		keptItem.Hash():    keptItem,
+		droppedItem.Hash(): droppedItem,
+	})
+
+	request := httptest.NewRequest("GET", "/jobs/test-job/targets?collector_id=test-collector&include=labels", nil)
+	w := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	result := w.Result()
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+
+	bodyBytes, err := io.ReadAll(result.Body)
+	require.NoError(t, err)
+	var itemResponse []*target.Item
+	require.NoError(t, json.Unmarshal(bodyBytes, &itemResponse))
+	assert.Equal(t, []*target.Item{
+		{
+			TargetURL: []string{"kept:8080"},
+			Labels: model.LabelSet{
+				model.AddressLabel: "kept:8080",
+				"app":              "my-app",
+			},
+		},
+	}, itemResponse)
+}
+
+func TestServer_ScrapeInterval(t *testing.T) {
+	listenAddr := ":8080"
+	s := NewServer(logger, &mockAllocator{}, &listenAddr)
+	assert.Zero(t, s.ScrapeInterval("test-job"))
+
+	require.NoError(t, s.UpdateScrapeConfigResponse(map[string]*promconfig.ScrapeConfig{
+		"test-job": {
+			JobName:        "test-job",
+			ScrapeInterval: model.Duration(15 * time.Second),
+		},
+	}))
+	assert.Equal(t, 15*time.Second, s.ScrapeInterval("test-job"))
+	assert.Zero(t, s.ScrapeInterval("other-job"))
+}
+
+func TestServer_RelabelHandler(t *testing.T) {
+	listenAddr := ":8080"
+	s := NewServer(logger, nil, &listenAddr)
+	err := s.UpdateScrapeConfigResponse(map[string]*promconfig.ScrapeConfig{
+		"test-job": {
+			JobName: "test-job",
+			RelabelConfigs: []*relabel.Config{
+				{
+					SourceLabels: model.LabelNames{"__meta_kubernetes_pod_label_app"},
+					Separator:    ";",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					TargetLabel:  "app",
+					Action:       relabel.Replace,
+				},
+				{
+					SourceLabels: model.LabelNames{"app"},
+					Separator:    ";",
+					Regex:        relabel.MustNewRegexp("my-app"),
+					Action:       relabel.Keep,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		description    string
+		job            string
+		body           string
+		expectedCode   int
+		expectedResult relabelResultJSON
+	}{
+		{
+			description:  "kept target",
+			job:          "test-job",
+			body:         `{"__address__": "kept:8080", "__meta_kubernetes_pod_label_app": "my-app"}`,
+			expectedCode: http.StatusOK,
+			expectedResult: relabelResultJSON{
+				Keep: true,
+				Labels: map[string]string{
+					"__address__":                     "kept:8080",
+					"__meta_kubernetes_pod_label_app": "my-app",
+					"app":                             "my-app",
+				},
+				Steps: []relabelStepJSON{
+					{
+						Action: relabel.Replace,
+						Keep:   true,
+						Labels: map[string]string{
+							"__address__":                     "kept:8080",
+							"__meta_kubernetes_pod_label_app": "my-app",
+							"app":                             "my-app",
+						},
+					},
+					{
+						Action: relabel.Keep,
+						Keep:   true,
+						Labels: map[string]string{
+							"__address__":                     "kept:8080",
+							"__meta_kubernetes_pod_label_app": "my-app",
+							"app":                             "my-app",
+						},
+					},
+				},
+			},
+		},
+		{
+			description:  "dropped target",
+			job:          "test-job",
+			body:         `{"__address__": "dropped:8080", "__meta_kubernetes_pod_label_app": "other-app"}`,
+			expectedCode: http.StatusOK,
+			expectedResult: relabelResultJSON{
+				Keep:   false,
+				Labels: map[string]string{},
+				Steps: []relabelStepJSON{
+					{
+						Action: relabel.Replace,
+						Keep:   true,
+						Labels: map[string]string{
+							"__address__":                     "dropped:8080",
+							"__meta_kubernetes_pod_label_app": "other-app",
+							"app":                             "other-app",
+						},
+					},
+					{
+						Action: relabel.Keep,
+						Keep:   false,
+						Labels: map[string]string{},
+					},
+				},
+			},
+		},
+		{
+			description:  "unknown job",
+			job:          "unknown-job",
+			body:         `{"__address__": "kept:8080"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description:  "invalid labels",
+			job:          "test-job",
+			body:         `["kept:8080"]`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			request := httptest.NewRequest("POST", fmt.Sprintf("/jobs/%s/relabel", tc.job), strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+			s.server.Handler.ServeHTTP(w, request)
+			result := w.Result()
+			assert.Equal(t, tc.expectedCode, result.StatusCode)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+			var relabelResult relabelResultJSON
+			require.NoError(t, json.Unmarshal(bodyBytes, &relabelResult))
+			assert.Equal(t, tc.expectedResult, relabelResult)
+		})
+	}
+}
+
 func TestServer_ScrapeConfigsHandler(t *testing.T) {
 	tests := []struct {
 		description   string
@@ -536,6 +740,76 @@ func TestServer_JobHandler(t *testing.T) {
 	}
 }
 
+func TestServer_PreviewHandler(t *testing.T) {
+	tests := []struct {
+		description        string
+		collectors         string
+		expectedCode       int
+		expectedCollectors []string
+	}{
+		{
+			description:        "scale up",
+			collectors:         "4",
+			expectedCode:       http.StatusOK,
+			expectedCollectors: []string{"test-collector-0", "test-collector-1", "test-collector-2", "test-collector-3"},
+		},
+		{
+			description:        "scale down",
+			collectors:         "1",
+			expectedCode:       http.StatusOK,
+			expectedCollectors: []string{"test-collector-0"},
+		},
+		{
+			description:  "missing count",
+			collectors:   "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "invalid count",
+			collectors:   "0",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			listenAddr := ":8080"
+			consistentHashing, _ := allocation.New("consistent-hashing", logger)
+			consistentHashing.SetCollectors(map[string]*allocation.Collector{
+				"test-collector-0": {Name: "test-collector-0"},
+				"test-collector-1": {Name: "test-collector-1"},
+			})
+			consistentHashing.SetTargets(map[string]*target.Item{
+				baseTargetItem.Hash():       baseTargetItem,
+				testJobTargetItemTwo.Hash(): testJobTargetItemTwo,
+			})
+			s := NewServer(logger, consistentHashing, &listenAddr)
+			request := httptest.NewRequest("GET", fmt.Sprintf("/allocation/preview?collectors=%s", tc.collectors), nil)
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+			result := w.Result()
+
+			assert.Equal(t, tc.expectedCode, result.StatusCode)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			bodyBytes, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+			preview := map[string]int{}
+			require.NoError(t, json.Unmarshal(bodyBytes, &preview))
+			total := 0
+			var names []string
+			for name, numTargets := range preview {
+				names = append(names, name)
+				total += numTargets
+			}
+			assert.ElementsMatch(t, tc.expectedCollectors, names)
+			assert.Equal(t, 2, total)
+			assert.Len(t, consistentHashing.Collectors(), 2)
+		})
+	}
+}
+
 func newLink(jobName string) target.LinkJSON {
 	return target.LinkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(jobName))}
 }
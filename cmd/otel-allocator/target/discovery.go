@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/model/relabel"
 
+	allocatorconfig "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/watcher"
 )
 
@@ -32,8 +33,14 @@ var (
 		Name: "opentelemetry_allocator_targets",
 		Help: "Number of targets discovered.",
 	}, []string{"job_name"})
+	scrapeConfigsOverridden = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_scrape_configs_overridden",
+		Help: "Number of scrape configs changed by the scrape overrides.",
+	})
 )
 
+var _ Source = &Discoverer{}
+
 type Discoverer struct {
 	log                  logr.Logger
 	manager              *discovery.Manager
@@ -42,6 +49,13 @@ type Discoverer struct {
 	hook                 discoveryHook
 	scrapeConfigsHash    uint64
 	scrapeConfigsUpdater scrapeConfigsUpdater
+	jobShard             int
+	jobShards            int
+	zone                 string
+	zones                []string
+	scrapeOverrides      *allocatorconfig.ScrapeOverrides
+	// noJobs is notified when the config has no jobs, as the discovery manager doesn't send anything in that case.
+	noJobs chan struct{}
 }
 
 type discoveryHook interface {
@@ -57,15 +71,43 @@ func NewDiscoverer(log logr.Logger, manager *discovery.Manager, hook discoveryHo
 		log:                  log,
 		manager:              manager,
 		close:                make(chan struct{}),
+		noJobs:               make(chan struct{}, 1),
 		configsMap:           make(map[allocatorWatcher.EventSource]*config.Config),
 		hook:                 hook,
 		scrapeConfigsUpdater: scrapeConfigsUpdater,
 	}
 }
 
+// SetJobShard restricts the Discoverer to the scrape jobs owned by the given shard, when the scrape jobs are
+// partitioned across the given number of shards.
+func (m *Discoverer) SetJobShard(shard, shards int) {
+	m.jobShard = shard
+	m.jobShards = shards
+}
+
+// SetZone restricts the Discoverer to the targets of the given availability zone, when there's one instance for each
+// of the given zones.
+func (m *Discoverer) SetZone(zone string, zones []string) {
+	m.zone = zone
+	m.zones = zones
+}
+
+// SetScrapeOverrides sets the overrides enforced on the scrape configs served to the collectors, from the next
+// configuration applied.
+func (m *Discoverer) SetScrapeOverrides(overrides *allocatorconfig.ScrapeOverrides) {
+	m.scrapeOverrides = overrides
+}
+
+func (m *Discoverer) ownsJob(jobName string) bool {
+	return m.jobShards <= 1 || JobShard(jobName, m.jobShards) == m.jobShard
+}
+
 func (m *Discoverer) ApplyConfig(source allocatorWatcher.EventSource, cfg *config.Config) error {
 	if cfg == nil {
 		m.log.Info("Service Discovery got empty Prometheus config", "source", source.String())
+		if len(m.configsMap) == 0 {
+			m.notifyNoJobs()
+		}
 		return nil
 	}
 	m.configsMap[source] = cfg
@@ -73,15 +115,25 @@ func (m *Discoverer) ApplyConfig(source allocatorWatcher.EventSource, cfg *confi
 
 	discoveryCfg := make(map[string]discovery.Configs)
 	relabelCfg := make(map[string][]*relabel.Config)
+	overriddenCount := 0
 
 	for _, value := range m.configsMap {
 		for _, scrapeConfig := range value.ScrapeConfigs {
+			if !m.ownsJob(scrapeConfig.JobName) {
+				continue
+			}
+			if overridden := m.scrapeOverrides.Apply(scrapeConfig); overridden != scrapeConfig {
+				overriddenCount++
+				scrapeConfig = overridden
+			}
 			jobToScrapeConfig[scrapeConfig.JobName] = scrapeConfig
 			discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
 			relabelCfg[scrapeConfig.JobName] = scrapeConfig.RelabelConfigs
 		}
 	}
 
+	scrapeConfigsOverridden.Set(float64(overriddenCount))
+
 	hash, err := hashstructure.Hash(jobToScrapeConfig, nil)
 	if err != nil {
 		return err
@@ -100,7 +152,21 @@ func (m *Discoverer) ApplyConfig(source allocatorWatcher.EventSource, cfg *confi
 	if m.hook != nil {
 		m.hook.SetConfig(relabelCfg)
 	}
-	return m.manager.ApplyConfig(discoveryCfg)
+	if err := m.manager.ApplyConfig(discoveryCfg); err != nil {
+		return err
+	}
+	if len(discoveryCfg) == 0 {
+		m.notifyNoJobs()
+	}
+	return nil
+}
+
+func (m *Discoverer) notifyNoJobs() {
+	select {
+	case m.noJobs <- struct{}{}:
+	default:
+		// a notification is already pending
+	}
 }
 
 func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
@@ -109,6 +175,8 @@ func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
 		case <-m.close:
 			m.log.Info("Service Discovery watch event stopped: discovery manager closed")
 			return nil
+		case <-m.noJobs:
+			fn(map[string]*Item{})
 		case tsets := <-m.manager.SyncCh():
 			targets := map[string]*Item{}
 
@@ -116,8 +184,12 @@ func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
 				var count float64 = 0
 				for _, tg := range tgs {
 					for _, t := range tg.Targets {
+						labels := t.Merge(tg.Labels)
+						if !InZone(labels, m.zone, m.zones) {
+							continue
+						}
 						count++
-						item := NewItem(jobName, string(t[model.AddressLabel]), t.Merge(tg.Labels), "")
+						item := NewItem(jobName, string(t[model.AddressLabel]), labels, "")
 						targets[item.Hash()] = item
 					}
 				}
@@ -351,6 +351,80 @@ func TestDiscovery_NoConfig(t *testing.T) {
 	assert.Equal(t, expectedScrapeConfigs, scu.mockCfg)
 }
 
+func TestDiscovery_NoJobs(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	d := discovery.NewManager(ctx, gokitlog.NewNopLogger())
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), d, nil, nil)
+	defer manager.Close()
+
+	results := make(chan map[string]*Item)
+	go func() {
+		err := manager.Watch(func(targets map[string]*Item) {
+			results <- targets
+		})
+		assert.NoError(t, err)
+	}()
+
+	// the discovery manager doesn't send anything without jobs, the discoverer has to report the lack of targets
+	err := manager.ApplyConfig(allocatorWatcher.EventSourceConfigMap, &promconfig.Config{})
+	assert.NoError(t, err)
+	select {
+	case targets := <-results:
+		assert.Empty(t, targets)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no targets reported")
+	}
+}
+
+func TestDiscovery_JobShard(t *testing.T) {
+	scu := &mockScrapeConfigUpdater{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	d := discovery.NewManager(ctx, gokitlog.NewNopLogger())
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), d, nil, scu)
+	manager.SetJobShard(1, 3)
+
+	cfg := &promconfig.Config{}
+	for _, jobName := range []string{"prometheus", "kubernetes-pods", "kubernetes-nodes", "node-exporter"} {
+		cfg.ScrapeConfigs = append(cfg.ScrapeConfigs, &promconfig.ScrapeConfig{JobName: jobName})
+	}
+	err := manager.ApplyConfig(allocatorWatcher.EventSourceConfigMap, cfg)
+	assert.NoError(t, err)
+
+	// only the jobs owned by the shard are kept
+	assert.Len(t, scu.mockCfg, 1)
+	assert.Contains(t, scu.mockCfg, "kubernetes-pods")
+}
+
+func TestDiscovery_ScrapeOverrides(t *testing.T) {
+	scu := &mockScrapeConfigUpdater{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	d := discovery.NewManager(ctx, gokitlog.NewNopLogger())
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), d, nil, scu)
+	manager.SetScrapeOverrides(&config.ScrapeOverrides{
+		MinScrapeInterval: model.Duration(30 * time.Second),
+		SampleLimit:       1000,
+	})
+
+	tenant := &promconfig.ScrapeConfig{JobName: "serviceMonitor/tenant/app/0", ScrapeInterval: model.Duration(time.Second)}
+	cfg := &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{
+		tenant,
+		{JobName: "prometheus", ScrapeInterval: model.Duration(time.Minute), SampleLimit: 500},
+	}}
+	err := manager.ApplyConfig(allocatorWatcher.EventSourcePrometheusCR, cfg)
+	assert.NoError(t, err)
+
+	require.Contains(t, scu.mockCfg, "serviceMonitor/tenant/app/0")
+	assert.Equal(t, model.Duration(30*time.Second), scu.mockCfg["serviceMonitor/tenant/app/0"].ScrapeInterval)
+	assert.Equal(t, uint(1000), scu.mockCfg["serviceMonitor/tenant/app/0"].SampleLimit)
+	assert.Equal(t, model.Duration(time.Minute), scu.mockCfg["prometheus"].ScrapeInterval)
+	assert.Equal(t, uint(500), scu.mockCfg["prometheus"].SampleLimit)
+	// the scrape configs of the sources are left as they are
+	assert.Equal(t, model.Duration(time.Second), tenant.ScrapeInterval)
+}
+
 func BenchmarkApplyScrapeConfig(b *testing.B) {
 	numConfigs := 1000
 	scrapeConfig := promconfig.ScrapeConfig{
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import "hash/fnv"
+
+// JobShard returns the shard owning the scrape job with the given name, when the scrape jobs are partitioned across
+// the given number of shards. The operator uses the same function to point the collectors' http_sd_configs at the
+// target allocator shard owning each job, so both have to be kept in sync.
+func JobShard(jobName string, shards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(jobName))
+	return int(h.Sum32() % uint32(shards))
+}
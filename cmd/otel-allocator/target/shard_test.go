@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobShard(t *testing.T) {
+	// the expected shards must match the ones of the operator's targetallocator.JobShard tests
+	for job, expected := range map[string]int{
+		"prometheus":       2,
+		"kubernetes-pods":  1,
+		"kubernetes-nodes": 0,
+		"node-exporter":    0,
+	} {
+		assert.Equal(t, expected, JobShard(job, 3), job)
+		assert.Equal(t, 0, JobShard(job, 1), job)
+	}
+}
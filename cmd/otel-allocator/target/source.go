@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import (
+	"errors"
+	"sync"
+)
+
+// Source provides the work items the allocator distributes among the collectors. The Prometheus service discovery is
+// the main one, but any list of work items, like the endpoints polled by SQL query or SNMP receivers, can be allocated
+// the same way by implementing this interface.
+type Source interface {
+	// Watch calls fn with all the current items of the Source every time they change, until the Source is closed.
+	Watch(fn func(items map[string]*Item)) error
+	Close()
+}
+
+var _ Source = &StaticSource{}
+
+// StaticSource is a Source of work items that are set explicitly, like the ones listed in the configuration file.
+type StaticSource struct {
+	mtx    sync.Mutex
+	items  map[string]*Item
+	update chan struct{}
+	close  chan struct{}
+}
+
+func NewStaticSource(items map[string]*Item) *StaticSource {
+	return &StaticSource{
+		items:  items,
+		update: make(chan struct{}, 1),
+		close:  make(chan struct{}),
+	}
+}
+
+// SetItems replaces the items of the StaticSource.
+func (s *StaticSource) SetItems(items map[string]*Item) {
+	s.mtx.Lock()
+	s.items = items
+	s.mtx.Unlock()
+	select {
+	case s.update <- struct{}{}:
+	default:
+		// an update is already pending, and will pick up the new items
+	}
+}
+
+func (s *StaticSource) Watch(fn func(items map[string]*Item)) error {
+	for {
+		s.mtx.Lock()
+		items := s.items
+		s.mtx.Unlock()
+		fn(items)
+
+		select {
+		case <-s.close:
+			return nil
+		case <-s.update:
+		}
+	}
+}
+
+func (s *StaticSource) Close() {
+	close(s.close)
+}
+
+var _ Source = &combinedSource{}
+
+// combinedSource is a Source providing the items of several sources.
+type combinedSource struct {
+	sources []Source
+
+	// mtx protects items, which holds the latest items of each source, nil until the source has provided its items.
+	mtx   sync.Mutex
+	items []map[string]*Item
+}
+
+// Combine returns a Source providing the items of all the given sources. Items are only provided once every source
+// has provided its own, so that a fast source doesn't make the allocator drop the items of a slower one.
+func Combine(sources ...Source) Source {
+	return &combinedSource{
+		sources: sources,
+		items:   make([]map[string]*Item, len(sources)),
+	}
+}
+
+func (c *combinedSource) Watch(fn func(items map[string]*Item)) error {
+	errs := make(chan error, len(c.sources))
+	for i, source := range c.sources {
+		go func(i int, source Source) {
+			errs <- source.Watch(func(items map[string]*Item) {
+				c.mtx.Lock()
+				defer c.mtx.Unlock()
+				if items == nil {
+					items = map[string]*Item{}
+				}
+				c.items[i] = items
+				if merged, ok := c.merge(); ok {
+					fn(merged)
+				}
+			})
+		}(i, source)
+	}
+
+	var err error
+	for range c.sources {
+		err = errors.Join(err, <-errs)
+	}
+	return err
+}
+
+// merge returns the items of all the sources, or false if some sources haven't provided their items yet. The caller
+// of this method has to acquire the lock.
+func (c *combinedSource) merge() (map[string]*Item, bool) {
+	merged := make(map[string]*Item)
+	for _, items := range c.items {
+		if items == nil {
+			return nil, false
+		}
+		for hash, item := range items {
+			merged[hash] = item
+		}
+	}
+	return merged, true
+}
+
+func (c *combinedSource) Close() {
+	for _, source := range c.sources {
+		source.Close()
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestItems(job string, endpoints ...string) map[string]*Item {
+	items := map[string]*Item{}
+	for _, endpoint := range endpoints {
+		item := NewItem(job, endpoint, model.LabelSet{model.AddressLabel: model.LabelValue(endpoint)}, "")
+		items[item.Hash()] = item
+	}
+	return items
+}
+
+func TestStaticSource(t *testing.T) {
+	source := NewStaticSource(newTestItems("sql", "postgres:5432"))
+	updates := make(chan map[string]*Item)
+	done := make(chan error)
+	go func() {
+		done <- source.Watch(func(items map[string]*Item) {
+			updates <- items
+		})
+	}()
+
+	assert.Equal(t, newTestItems("sql", "postgres:5432"), <-updates)
+
+	source.SetItems(newTestItems("sql", "postgres:5432", "mysql:3306"))
+	assert.Equal(t, newTestItems("sql", "postgres:5432", "mysql:3306"), <-updates)
+
+	source.Close()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "static source not closed")
+	}
+}
+
+func TestCombine(t *testing.T) {
+	sqlSource := NewStaticSource(newTestItems("sql", "postgres:5432"))
+	snmpSource := NewStaticSource(nil)
+	source := Combine(sqlSource, snmpSource)
+	updates := make(chan map[string]*Item, 10)
+	done := make(chan error)
+	go func() {
+		done <- source.Watch(func(items map[string]*Item) {
+			updates <- items
+		})
+	}()
+
+	// the items are only provided once both sources have provided theirs
+	assert.Equal(t, newTestItems("sql", "postgres:5432"), <-updates)
+
+	snmpSource.SetItems(newTestItems("snmp", "switch-1:161"))
+	expected := newTestItems("sql", "postgres:5432")
+	for hash, item := range newTestItems("snmp", "switch-1:161") {
+		expected[hash] = item
+	}
+	assert.Equal(t, expected, <-updates)
+
+	source.Close()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "combined source not closed")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import "github.com/prometheus/common/model"
+
+// zoneLabels are the discovered labels holding the availability zone of a target, by order of preference. The operator
+// sets attach_metadata.node in the kubernetes_sd_configs of the topology-aware target allocators, so that the targets
+// discovered from pods and endpoints carry the labels of their node.
+var zoneLabels = []model.LabelName{
+	"__meta_kubernetes_node_label_topology_kubernetes_io_zone",
+	"__meta_kubernetes_endpointslice_endpoint_topology_topology_kubernetes_io_zone",
+}
+
+// Zone returns the availability zone of the target with the given discovered labels, or an empty string when it isn't
+// known, like for the targets of static configurations.
+func Zone(labels model.LabelSet) string {
+	for _, name := range zoneLabels {
+		if zone, ok := labels[name]; ok && zone != "" {
+			return string(zone)
+		}
+	}
+	return ""
+}
+
+// InZone returns whether the target with the given discovered labels is served by the instance of the given zone,
+// when there's one instance for each of the given zones. The targets whose zone isn't one of them are served by the
+// instance of the first zone.
+func InZone(labels model.LabelSet, zone string, zones []string) bool {
+	if zone == "" || len(zones) == 0 {
+		return true
+	}
+	targetZone := Zone(labels)
+	for _, z := range zones {
+		if z == targetZone {
+			return z == zone
+		}
+	}
+	return zone == zones[0]
+}
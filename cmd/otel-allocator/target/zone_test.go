@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package target
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInZone(t *testing.T) {
+	zones := []string{"eu-west-1a", "eu-west-1b"}
+	for _, tt := range []struct {
+		name     string
+		labels   model.LabelSet
+		expected map[string]bool
+	}{
+		{
+			name:     "pod in the second zone",
+			labels:   model.LabelSet{"__meta_kubernetes_node_label_topology_kubernetes_io_zone": "eu-west-1b"},
+			expected: map[string]bool{"eu-west-1a": false, "eu-west-1b": true},
+		},
+		{
+			name:     "endpoint in the first zone",
+			labels:   model.LabelSet{"__meta_kubernetes_endpointslice_endpoint_topology_topology_kubernetes_io_zone": "eu-west-1a"},
+			expected: map[string]bool{"eu-west-1a": true, "eu-west-1b": false},
+		},
+		{
+			name:     "unknown zone",
+			labels:   model.LabelSet{model.AddressLabel: "localhost:9090"},
+			expected: map[string]bool{"eu-west-1a": true, "eu-west-1b": false},
+		},
+		{
+			name:     "other zone",
+			labels:   model.LabelSet{"__meta_kubernetes_node_label_topology_kubernetes_io_zone": "eu-west-1c"},
+			expected: map[string]bool{"eu-west-1a": true, "eu-west-1b": false},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for zone, expected := range tt.expected {
+				assert.Equal(t, expected, InZone(tt.labels, zone, zones), zone)
+			}
+			assert.True(t, InZone(tt.labels, "", nil))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CacheByObject restricts the cache of the manager to the objects managed by the operator for the kinds it would
+// otherwise cache for the whole cluster, like Deployments or Pods. The objects of these kinds the operator doesn't
+// manage have to be read with the API reader of the manager.
+func CacheByObject() map[client.Object]cache.ByObject {
+	managed := cache.ByObject{
+		Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "opentelemetry-operator"}),
+	}
+	return map[client.Object]cache.ByObject{
+		&appsv1.Deployment{}:  managed,
+		&appsv1.DaemonSet{}:   managed,
+		&appsv1.StatefulSet{}: managed,
+		&corev1.ConfigMap{}:   managed,
+		&corev1.Service{}:     managed,
+		&corev1.Pod{}:         managed,
+		&batchv1.Job{}:        managed,
+
+		&rbacv1.ClusterRole{}:        managed,
+		&rbacv1.ClusterRoleBinding{}: managed,
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+func TestCacheByObject(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	otelcol.Name = "my-instance"
+	otelcol.Namespace = "default"
+
+	// test
+	byObject := controllers.CacheByObject()
+
+	// verify
+	assert.Len(t, byObject, 9)
+	for obj, opts := range byObject {
+		assert.True(t, opts.Label.Matches(labels.Set(collector.Labels(otelcol, "my-instance-collector", nil))), "%T", obj)
+		assert.True(t, opts.Label.Matches(labels.Set(targetallocator.Labels(otelcol, "my-instance-targetallocator"))), "%T", obj)
+		assert.False(t, opts.Label.Matches(labels.Set{"app": "my-app"}), "%T", obj)
+	}
+}
@@ -19,31 +19,79 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
+// clusterResourcesFinalizer is set on the instances owning cluster-scoped objects, which have to be deleted together
+// with the instance.
+const clusterResourcesFinalizer = "opentelemetry.io/cluster-resources"
+
+// unhealthyRequeueDelay is the delay after which unhealthy instances are reconciled again: the pods aren't watched,
+// and crashing or failing to pull their image doesn't always change the workloads owned by the instance.
+const unhealthyRequeueDelay = time.Minute
+
+// pendingValidationRequeueDelay is the delay after which the instances whose configuration is being validated are
+// reconciled again: the pods of the validation jobs aren't watched, and their timeout doesn't change the jobs.
+const pendingValidationRequeueDelay = 30 * time.Second
+
+// unassignedTargetsRequeueDelay is the delay after which the instances whose TargetAllocator caps the number of targets
+// per collector are reconciled again: the targets it leaves unassigned change without changing the watched objects.
+const unassignedTargetsRequeueDelay = time.Minute
+
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
 type OpenTelemetryCollectorReconciler struct {
 	client.Client
-	recorder record.EventRecorder
-	scheme   *runtime.Scheme
-	log      logr.Logger
-	config   config.Config
+	apiReader client.Reader
+	recorder  record.EventRecorder
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	config    config.Config
+
+	rateLimiting            RateLimiting
+	maxConcurrentReconciles int
+	// priority is nil unless some instances are reconciled before the others.
+	priority *priorityQueue
+
+	// the controller, its cache and the handler of the owned objects start the watches of the APIs installed after the
+	// operator, see watch.go
+	controller          controller.Controller
+	cache               cache.Cache
+	ownerHandler        handler.EventHandler
+	watchingPodMonitors bool
+	muWatches           sync.Mutex
 
 	tasks   []Task
 	muTasks sync.RWMutex
@@ -59,11 +107,19 @@ type Task struct {
 // Params is the set of options to build a new openTelemetryCollectorReconciler.
 type Params struct {
 	client.Client
-	Recorder record.EventRecorder
-	Scheme   *runtime.Scheme
-	Log      logr.Logger
-	Tasks    []Task
-	Config   config.Config
+	// APIReader reads the objects missing from the cache of the client, see CacheByObject.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Tasks     []Task
+	Config    config.Config
+	// RateLimiting configures how often the instances failing to reconcile are retried.
+	RateLimiting RateLimiting
+	// MaxConcurrentReconciles is the number of instances reconciled concurrently, 1 by default.
+	MaxConcurrentReconciles int
+	// PrioritySelector selects the instances reconciled before the others when many are waiting, e.g. after a restart.
+	PrioritySelector labels.Selector
 }
 
 func (r *OpenTelemetryCollectorReconciler) onOpenShiftRoutesChange() error {
@@ -114,12 +170,20 @@ func (r *OpenTelemetryCollectorReconciler) removeRouteTask(ora autodetect.OpenSh
 // NewReconciler creates a new reconciler for OpenTelemetryCollector objects.
 func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 	r := &OpenTelemetryCollectorReconciler{
-		Client:   p.Client,
-		log:      p.Log,
-		scheme:   p.Scheme,
-		config:   p.Config,
-		tasks:    p.Tasks,
-		recorder: p.Recorder,
+		Client:    p.Client,
+		apiReader: p.APIReader,
+		log:       p.Log,
+		scheme:    p.Scheme,
+		config:    p.Config,
+		tasks:     p.Tasks,
+		recorder:  p.Recorder,
+
+		rateLimiting:            p.RateLimiting,
+		maxConcurrentReconciles: p.MaxConcurrentReconciles,
+	}
+
+	if p.PrioritySelector != nil && !p.PrioritySelector.Empty() {
+		r.priority = newPriorityQueue(p.Client, p.PrioritySelector, p.MaxConcurrentReconciles)
 	}
 
 	if len(r.tasks) == 0 {
@@ -134,6 +198,11 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 				"service accounts",
 				true,
 			},
+			{
+				reconcile.ClusterRoles,
+				"cluster roles",
+				true,
+			},
 			{
 				reconcile.Services,
 				"services",
@@ -159,11 +228,41 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 				"stateful sets",
 				true,
 			},
+			{
+				reconcile.PodDisruptionBudgets,
+				"pod disruption budgets",
+				true,
+			},
+			{
+				reconcile.VerticalPodAutoscalers,
+				"vertical pod autoscalers",
+				true,
+			},
+			{
+				reconcile.PodMonitors,
+				"pod monitors",
+				true,
+			},
+			{
+				reconcile.IstioObjects,
+				"istio objects",
+				true,
+			},
 			{
 				reconcile.Ingresses,
 				"ingresses",
 				true,
 			},
+			{
+				reconcile.Adopt,
+				"adoption",
+				false,
+			},
+			{
+				reconcile.DebugContainers,
+				"debug containers",
+				false,
+			},
 			{
 				reconcile.Self,
 				"opentelemetry",
@@ -180,12 +279,17 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=telemetrytenants,verbs=get;list;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile the current state of an OpenTelemetry collector resource with the desired state.
 func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+	if r.priority != nil {
+		r.priority.started()
+		defer r.priority.done()
+	}
 
 	var instance v1alpha1.OpenTelemetryCollector
 	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
@@ -200,19 +304,185 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 	}
 
 	params := reconcile.Params{
-		Config:   r.config,
-		Client:   r.Client,
-		Instance: instance,
-		Log:      log,
-		Scheme:   r.scheme,
-		Recorder: r.recorder,
+		Config:    r.config,
+		Client:    r.Client,
+		APIReader: r.apiReader,
+		Instance:  instance,
+		Log:       log,
+		Scheme:    r.scheme,
+		Recorder:  r.recorder,
+	}
+
+	// cluster-scoped objects can't be owned by the instance, so they are deleted before the instance is
+	if instance.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(&instance, clusterResourcesFinalizer) {
+			if err := reconcile.DeleteClusterRoles(ctx, params); err != nil {
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(&instance, clusterResourcesFinalizer)
+			if err := r.Update(ctx, &instance); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if collector.Paused(instance) {
+		log.V(2).Info("reconciliation is paused, only updating the status")
+		return ctrl.Result{}, reconcile.Self(ctx, params)
+	}
+
+	if instance.Spec.ReceiverCreator.Enabled && r.config.ClusterRolesAllowed() && !controllerutil.ContainsFinalizer(&instance, clusterResourcesFinalizer) {
+		controllerutil.AddFinalizer(&instance, clusterResourcesFinalizer)
+		if err := r.Update(ctx, &instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		params.Instance = instance
+	}
+
+	// the pipelines of the tenants of the instance are generated in its configuration, leaving out the tenants which
+	// can't be routed, e.g. claiming the namespace of another tenant. When the pipelines can't be generated at all, the
+	// configuration isn't rolled out, and the collector keeps running the last one generated.
+	tenants, leftOut, err := r.tenantsOf(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	tenanted, dropped, tenantsErr := collector.TenantInstance(instance, tenants)
+	for name, reason := range dropped {
+		leftOut[name] = reason
+	}
+	if err := reconcile.TenantsStatus(ctx, params, &instance, collector.TenantsCondition(instance, tenants, leftOut, tenantsErr)); err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if tenantsErr != nil {
+		log.V(1).Info("the configuration isn't rolled out, the pipelines of the tenants can't be generated", "reason", tenantsErr.Error())
+		return ctrl.Result{}, nil
+	}
+	instance.Spec = tenanted.Spec
+	params.Instance = instance
+
+	// the configurations are validated by the collector image before they're rolled out, the collector runs its last
+	// known good configuration and image meanwhile
+	validated, err := reconcile.ValidateConfig(ctx, params, &instance)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
 	}
+	params.Instance = instance
+	if !validated && instance.Status.LastKnownGood != nil {
+		log.V(1).Info("running the last known good state until the configuration is validated", "generation", instance.Status.LastKnownGood.ObservedGeneration)
+		params.Instance = collector.LastKnownGoodInstance(instance)
+	}
+
+	// the rolled back instances run their last known good configuration and image, while their spec is kept as is
+	if collector.RolledBack(instance) {
+		log.V(1).Info("rolled back to the last known good state", "generation", instance.Status.LastKnownGood.ObservedGeneration)
+		params.Instance = collector.LastKnownGoodInstance(instance)
+	}
+
+	// the autoscaling bounds of the active schedule replace the ones of the spec until its window ends
+	now := time.Now()
+	params.Instance = collector.ScheduledInstance(params.Instance, now)
 
 	if err := r.RunTasks(ctx, params); err != nil {
+		// conflicts are expected when the instances or their objects are updated concurrently, e.g. in bulk, so they
+		// are retried with the backoff of the rate limiter without being reported as errors
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	result := ctrl.Result{}
+	if meta.IsStatusConditionFalse(instance.Status.Conditions, collector.ConditionTypeHealthy) {
+		result.RequeueAfter = unhealthyRequeueDelay
+	}
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeConfigValidated); condition != nil && condition.Status == metav1.ConditionUnknown && collector.ConfigValidationEnabled(r.config, instance) {
+		result.RequeueAfter = pendingValidationRequeueDelay
+	}
+	if targetallocator.TargetsLimited(instance) && (result.RequeueAfter == 0 || unassignedTargetsRequeueDelay < result.RequeueAfter) {
+		result.RequeueAfter = unassignedTargetsRequeueDelay
+	}
+	// the instances are reconciled again when a window of their schedules starts or ends
+	if next, ok := collector.NextScheduleChange(instance, now); ok && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
+	return result, nil
+}
+
+// tenantsOf returns the tenants of the given instance, in its namespace, with the namespaces selected by their
+// namespace selectors, and the tenants left out with the reason, e.g. an invalid selector. The sidecars have no
+// tenants, their configurations are injected as they are.
+func (r *OpenTelemetryCollectorReconciler) tenantsOf(ctx context.Context, instance v1alpha1.OpenTelemetryCollector) ([]v1alpha1.TelemetryTenant, map[string]string, error) {
+	leftOut := map[string]string{}
+	if instance.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil, leftOut, nil
+	}
+	list := &v1alpha1.TelemetryTenantList{}
+	if err := r.List(ctx, list, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the tenants: %w", err)
+	}
+	var namespaces []corev1.Namespace
+	for _, tenant := range list.Items {
+		if tenant.Spec.Collector == instance.Name && tenant.Spec.NamespaceSelector != nil {
+			namespaceList := &corev1.NamespaceList{}
+			if err := r.List(ctx, namespaceList); err != nil {
+				return nil, nil, fmt.Errorf("failed to list the namespaces: %w", err)
+			}
+			namespaces = namespaceList.Items
+			break
+		}
+	}
+	var tenants []v1alpha1.TelemetryTenant
+	for _, tenant := range list.Items {
+		if tenant.Spec.Collector != instance.Name {
+			continue
+		}
+		selected, err := collector.SelectedTenant(tenant, namespaces)
+		if err != nil {
+			leftOut[tenant.Name] = err.Error()
+			continue
+		}
+		tenants = append(tenants, selected)
+	}
+	return tenants, leftOut, nil
+}
+
+// tenantCollectorsOf returns the requests reconciling the gateway collectors of the tenants whose namespace selector
+// selects the given namespace. Both the previous and the new labels of the namespaces are mapped on updates, so that
+// the namespaces are also removed from the tenants they aren't selected by anymore.
+func (r *OpenTelemetryCollectorReconciler) tenantCollectorsOf(ctx context.Context, obj client.Object) []ctrl.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+	list := &v1alpha1.TelemetryTenantList{}
+	if err := r.List(ctx, list); err != nil {
+		r.log.Error(err, "failed to list the tenants")
+		return nil
+	}
+	var requests []ctrl.Request
+	seen := map[types.NamespacedName]bool{}
+	for _, tenant := range list.Items {
+		if selected, err := collector.TenantSelects(tenant, *namespace); err != nil || !selected {
+			continue
+		}
+		name := types.NamespacedName{Namespace: tenant.Namespace, Name: tenant.Spec.Collector}
+		if !seen[name] {
+			seen[name] = true
+			requests = append(requests, ctrl.Request{NamespacedName: name})
+		}
+	}
+	return requests
 }
 
 // RunTasks runs all the tasks associated with this reconciler.
@@ -226,7 +496,11 @@ func (r *OpenTelemetryCollectorReconciler) RunTasks(ctx context.Context, params
 				r.log.V(2).Info("Exiting reconcile loop because namespace is being terminated", "namespace", params.Instance.Namespace)
 				return nil
 			}
-			r.log.Error(err, fmt.Sprintf("failed to reconcile %s", task.Name))
+			if apierrors.IsConflict(err) {
+				r.log.V(2).Info(fmt.Sprintf("conflict while reconciling %s", task.Name), "error", err.Error())
+			} else {
+				r.log.Error(err, fmt.Sprintf("failed to reconcile %s", task.Name))
+			}
 			if task.BailOnError {
 				return err
 			}
@@ -243,20 +517,96 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		return err
 	}
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.OpenTelemetryCollector{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.ServiceAccount{}).
-		Owns(&corev1.Service{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&appsv1.DaemonSet{}).
-		Owns(&appsv1.StatefulSet{})
-
-	autoscalingVersion := r.config.AutoscalingVersion()
-	if autoscalingVersion == autodetect.AutoscalingVersionV2 {
-		builder = builder.Owns(&autoscalingv2.HorizontalPodAutoscaler{})
-	} else {
-		builder = builder.Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{})
-	}
-
-	return builder.Complete(r)
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.maxConcurrentReconciles,
+			RateLimiter:             r.rateLimiting.rateLimiter(),
+		}).
+		// the events are all enqueued through r.enqueue, so the name of the controller isn't derived from For
+		Named("opentelemetrycollector").
+		Watches(&v1alpha1.OpenTelemetryCollector{}, r.enqueue(&handler.EnqueueRequestForObject{})).
+		Watches(&v1alpha1.TelemetryTenant{}, r.enqueue(handler.EnqueueRequestsFromMapFunc(collectorOf))).
+		Watches(&corev1.Namespace{}, r.enqueue(handler.EnqueueRequestsFromMapFunc(r.tenantCollectorsOf)))
+	r.cache = mgr.GetCache()
+	r.ownerHandler = r.enqueue(handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &v1alpha1.OpenTelemetryCollector{}, handler.OnlyControllerOwner()))
+	owns := func(obj client.Object) {
+		builder = builder.Watches(obj, r.ownerHandler)
+	}
+	for _, obj := range []client.Object{
+		&corev1.ConfigMap{},
+		&corev1.ServiceAccount{},
+		&corev1.Service{},
+		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
+		&appsv1.StatefulSet{},
+		&batchv1.Job{},
+		&policyv1.PodDisruptionBudget{},
+		&networkingv1.Ingress{},
+	} {
+		owns(obj)
+	}
+	// the cluster-scoped objects can't be owned by the instances, they are mapped to them by their labels, and are only
+	// watched when the operator is allowed to manage them
+	if r.config.ClusterRolesAllowed() {
+		builder = builder.
+			Watches(&rbacv1.ClusterRole{}, r.enqueue(handler.EnqueueRequestsFromMapFunc(instanceOf))).
+			Watches(&rbacv1.ClusterRoleBinding{}, r.enqueue(handler.EnqueueRequestsFromMapFunc(instanceOf)))
+	}
+
+	// the Routes are only watched on OpenShift
+	if r.config.OpenShiftRoutes() == autodetect.OpenShiftRoutesAvailable {
+		owns(&routev1.Route{})
+	}
+
+	// the HorizontalPodAutoscalers are generated with autoscaling/v2 unless the cluster only serves autoscaling/v2beta2,
+	// like Kubernetes 1.22 and older, and aren't generated when the cluster serves neither
+	switch r.config.AutoscalingVersion() {
+	case autodetect.AutoscalingVersionV2Beta2:
+		owns(&autoscalingv2beta2.HorizontalPodAutoscaler{})
+	case autodetect.AutoscalingVersionV2:
+		owns(&autoscalingv2.HorizontalPodAutoscaler{})
+	case autodetect.AutoscalingVersionUnknown:
+		// the horizontal pod autoscalers are disabled
+	}
+
+	// the VerticalPodAutoscalers are only watched when the VerticalPodAutoscaler is installed in the cluster
+	if r.config.VerticalPodAutoscalers() == autodetect.VerticalPodAutoscalersAvailable {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(collector.VerticalPodAutoscalerGVK)
+		owns(vpa)
+	}
+
+	// the pod monitors are only watched when the Prometheus operator is installed in the cluster, or once it's installed,
+	// see onPodMonitorsChange
+	r.muWatches.Lock()
+	defer r.muWatches.Unlock()
+	if r.config.PodMonitors() == autodetect.PodMonitorsAvailable {
+		podMonitor := &unstructured.Unstructured{}
+		podMonitor.SetGroupVersionKind(collector.PodMonitorGVK)
+		owns(podMonitor)
+		r.watchingPodMonitors = true
+	}
+
+	// the Istio objects are only watched when Istio is installed in the cluster
+	if r.config.Istio() == autodetect.IstioAvailable {
+		for _, gvk := range []schema.GroupVersionKind{collector.ServiceEntryGVK, collector.IstioSidecarGVK, collector.PeerAuthenticationGVK} {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			owns(obj)
+		}
+	}
+
+	r.controller, err = builder.Build(r)
+	if err != nil {
+		return err
+	}
+	r.config.RegisterPodMonitorsChangeCallback(r.onPodMonitorsChange)
+	return nil
+}
+
+// enqueue returns the given event handler, enqueuing the requests through the priority queue when it's enabled.
+func (r *OpenTelemetryCollectorReconciler) enqueue(h handler.EventHandler) handler.EventHandler {
+	if r.priority == nil {
+		return h
+	}
+	return r.priority.handler(h)
 }
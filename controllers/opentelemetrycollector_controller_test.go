@@ -359,6 +359,48 @@ func TestSkipWhenInstanceDoesNotExist(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSkipTasksWhenPaused(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	nsn := types.NamespacedName{Name: "my-paused-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "should-not-be-called",
+				Do: func(context.Context, reconcile.Params) error {
+					assert.Fail(t, "should not have been called")
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        nsn.Name,
+			Namespace:   nsn.Namespace,
+			Annotations: map[string]string{"opentelemetry.io/pause-reconciliation": "true"},
+		},
+	}
+	err := k8sClient.Create(context.Background(), created)
+	require.NoError(t, err)
+
+	// test
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
 func TestRegisterWithManager(t *testing.T) {
 	t.Skip("this test requires a real cluster, otherwise the GetConfigOrDie will die")
 
@@ -378,8 +420,12 @@ func TestRegisterWithManager(t *testing.T) {
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
-	HPAVersionFunc                  func() (autodetect.AutoscalingVersion, error)
+	OpenShiftRoutesAvailabilityFunc        func() (autodetect.OpenShiftRoutesAvailability, error)
+	HPAVersionFunc                         func() (autodetect.AutoscalingVersion, error)
+	VerticalPodAutoscalersAvailabilityFunc func() (autodetect.VerticalPodAutoscalersAvailability, error)
+	IstioAvailabilityFunc                  func() (autodetect.IstioAvailability, error)
+	PodMonitorsAvailabilityFunc            func() (autodetect.PodMonitorsAvailability, error)
+	PermissionsFunc                        func() (autodetect.Permissions, error)
 }
 
 func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
@@ -392,3 +438,31 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) VerticalPodAutoscalersAvailability() (autodetect.VerticalPodAutoscalersAvailability, error) {
+	if m.VerticalPodAutoscalersAvailabilityFunc != nil {
+		return m.VerticalPodAutoscalersAvailabilityFunc()
+	}
+	return autodetect.VerticalPodAutoscalersNotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (autodetect.IstioAvailability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return autodetect.IstioNotAvailable, nil
+}
+
+func (m *mockAutoDetect) PodMonitorsAvailability() (autodetect.PodMonitorsAvailability, error) {
+	if m.PodMonitorsAvailabilityFunc != nil {
+		return m.PodMonitorsAvailabilityFunc()
+	}
+	return autodetect.PodMonitorsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) Permissions() (autodetect.Permissions, error) {
+	if m.PermissionsFunc != nil {
+		return m.PermissionsFunc()
+	}
+	return autodetect.Permissions{}, nil
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// priorityQueue holds the requests enqueued by the event handlers until the workers of the controller are about to be
+// free, and then hands the requests of the instances matching the selector to the controller before the others.
+// The queue of the controller is FIFO, so without it, a production instance created or updated after a restart, or
+// during a resync, waits behind all the instances listed before it.
+type priorityQueue struct {
+	client   client.Reader
+	selector labels.Selector
+	// capacity is the number of requests the controller may have queued or in flight, its MaxConcurrentReconciles.
+	capacity int
+
+	mu       sync.Mutex
+	queue    workqueue.RateLimitingInterface
+	high     []reconcile.Request
+	low      []reconcile.Request
+	pending  map[reconcile.Request]struct{}
+	inFlight int
+}
+
+func newPriorityQueue(c client.Reader, selector labels.Selector, capacity int) *priorityQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &priorityQueue{
+		client:   c,
+		selector: selector,
+		capacity: capacity,
+		pending:  map[reconcile.Request]struct{}{},
+	}
+}
+
+// handler returns an event handler holding the requests enqueued by the given one in the priority queue.
+func (p *priorityQueue) handler(h handler.EventHandler) handler.EventHandler {
+	return &priorityHandler{EventHandler: h, priority: p}
+}
+
+// add holds the request until the controller can take it, or hands it to the controller right away when it's idle.
+func (p *priorityQueue) add(ctx context.Context, q workqueue.RateLimitingInterface, req reconcile.Request) {
+	high := p.isPriority(ctx, req)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queue = q
+	if _, ok := p.pending[req]; !ok {
+		p.pending[req] = struct{}{}
+		if high {
+			p.high = append(p.high, req)
+		} else {
+			p.low = append(p.low, req)
+		}
+	}
+	p.flush()
+}
+
+// isPriority returns whether the instance of the request matches the selector, the instances that can't be read are
+// reconciled with the others.
+func (p *priorityQueue) isPriority(ctx context.Context, req reconcile.Request) bool {
+	var instance v1alpha1.OpenTelemetryCollector
+	if err := p.client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		return false
+	}
+	return p.selector.Matches(labels.Set(instance.Labels))
+}
+
+// started records a reconcile taken by a worker of the controller.
+func (p *priorityQueue) started() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight++
+}
+
+// done records the end of a reconcile and hands the next requests to the controller.
+func (p *priorityQueue) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	p.flush()
+}
+
+// flush hands the held requests to the controller, the priority ones first, until its workers are all busy.
+// The caller must hold the lock.
+func (p *priorityQueue) flush() {
+	if p.queue == nil {
+		return
+	}
+	for p.queue.Len()+p.inFlight < p.capacity {
+		var req reconcile.Request
+		switch {
+		case len(p.high) > 0:
+			req, p.high = p.high[0], p.high[1:]
+		case len(p.low) > 0:
+			req, p.low = p.low[0], p.low[1:]
+		default:
+			return
+		}
+		delete(p.pending, req)
+		p.queue.Add(req)
+	}
+}
+
+// priorityHandler passes the events to the wrapped handler with a queue adding the requests to the priority queue.
+type priorityHandler struct {
+	handler.EventHandler
+	priority *priorityQueue
+}
+
+func (h *priorityHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Create(ctx, evt, h.queue(ctx, q))
+}
+
+func (h *priorityHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Update(ctx, evt, h.queue(ctx, q))
+}
+
+func (h *priorityHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Delete(ctx, evt, h.queue(ctx, q))
+}
+
+func (h *priorityHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Generic(ctx, evt, h.queue(ctx, q))
+}
+
+func (h *priorityHandler) queue(ctx context.Context, q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	return &priorityAdder{RateLimitingInterface: q, ctx: ctx, priority: h.priority}
+}
+
+// priorityAdder is the queue given to the wrapped event handlers, the requests they add go to the priority queue
+// while the rate limited retries go straight to the controller.
+type priorityAdder struct {
+	workqueue.RateLimitingInterface
+	ctx      context.Context
+	priority *priorityQueue
+}
+
+func (a *priorityAdder) Add(item interface{}) {
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		a.RateLimitingInterface.Add(item)
+		return
+	}
+	a.priority.add(a.ctx, a.RateLimitingInterface, req)
+}
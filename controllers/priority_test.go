@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	var objs []*v1alpha1.OpenTelemetryCollector
+	for _, name := range []string{"dev-1", "dev-2", "prod", "dev-3"} {
+		otelcol := &v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if name == "prod" {
+			otelcol.Labels = map[string]string{"environment": "production"}
+		}
+		objs = append(objs, otelcol)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs[0], objs[1], objs[2], objs[3]).Build()
+	selector, err := labels.Parse("environment=production")
+	require.NoError(t, err)
+	priority := newPriorityQueue(cl, selector, 1)
+	h := priority.handler(&handler.EnqueueRequestForObject{})
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	// test
+	for _, obj := range objs {
+		h.Create(context.Background(), event.CreateEvent{Object: obj}, q)
+	}
+	var reconciled []string
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		priority.started()
+		reconciled = append(reconciled, item.(reconcile.Request).Name)
+		q.Done(item)
+		priority.done()
+	}
+
+	// verify
+	assert.Equal(t, []string{"dev-1", "prod", "dev-2", "dev-3"}, reconciled)
+}
+
+func TestPriorityQueueDeduplicates(t *testing.T) {
+	// prepare
+	priority := newPriorityQueue(fake.NewClientBuilder().Build(), labels.Everything(), 1)
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	ctx := context.Background()
+	busy := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "busy"}}
+	waiting := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "waiting"}}
+
+	// test
+	priority.add(ctx, q, busy)
+	priority.add(ctx, q, waiting)
+	priority.add(ctx, q, waiting)
+
+	// verify
+	assert.Equal(t, 1, q.Len())
+	assert.Len(t, priority.low, 1)
+
+	item, _ := q.Get()
+	priority.started()
+	q.Done(item)
+	priority.done()
+	assert.Equal(t, 1, q.Len())
+	assert.Empty(t, priority.low)
+}
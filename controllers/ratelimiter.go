@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// jitterFactor is the maximum fraction of the backoff delay added to it, so that the instances failing together, e.g.
+// on conflicts after a bulk update, aren't retried together.
+const jitterFactor = 0.5
+
+// RateLimiting configures how often the reconciler retries the instances it failed to reconcile. The zero values
+// default to the settings of controller-runtime.
+type RateLimiting struct {
+	// BaseDelay is the delay before the first retry of an instance, doubled on each failure.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay between the retries of an instance.
+	MaxDelay time.Duration
+	// QPS is the overall number of retries per second.
+	QPS float64
+	// Burst is the overall number of retries allowed above the QPS.
+	Burst int
+}
+
+func (r RateLimiting) rateLimiter() workqueue.RateLimiter {
+	baseDelay, maxDelay, qps, burst := r.BaseDelay, r.MaxDelay, r.QPS, r.Burst
+	if baseDelay <= 0 {
+		baseDelay = 5 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 1000 * time.Second
+	}
+	if qps <= 0 {
+		qps = 10
+	}
+	if burst <= 0 {
+		burst = 100
+	}
+
+	return workqueue.NewMaxOfRateLimiter(
+		&jitterRateLimiter{RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay)},
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
+
+// jitterRateLimiter adds a random jitter to the delays of the wrapped rate limiter.
+type jitterRateLimiter struct {
+	workqueue.RateLimiter
+}
+
+func (j *jitterRateLimiter) When(item interface{}) time.Duration {
+	return wait.Jitter(j.RateLimiter.When(item), jitterFactor)
+}
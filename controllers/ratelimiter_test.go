@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBackoff(t *testing.T) {
+	// prepare
+	limiter := RateLimiting{BaseDelay: time.Second, MaxDelay: time.Minute}.rateLimiter()
+
+	// test and verify
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		delay := limiter.When("my-instance")
+		assert.GreaterOrEqual(t, delay, expected)
+		assert.LessOrEqual(t, delay, expected+expected/2)
+	}
+	assert.Equal(t, 3, limiter.NumRequeues("my-instance"))
+
+	limiter.Forget("my-instance")
+	assert.Equal(t, 0, limiter.NumRequeues("my-instance"))
+}
+
+func TestRateLimiterMaxDelay(t *testing.T) {
+	// prepare
+	limiter := RateLimiting{BaseDelay: time.Second, MaxDelay: 10 * time.Second}.rateLimiter()
+
+	// test
+	var delay time.Duration
+	for i := 0; i < 10; i++ {
+		delay = limiter.When("my-instance")
+	}
+
+	// verify
+	assert.GreaterOrEqual(t, delay, 10*time.Second)
+	assert.LessOrEqual(t, delay, 15*time.Second)
+}
+
+func TestRateLimiterDefaults(t *testing.T) {
+	// prepare
+	limiter := RateLimiting{}.rateLimiter()
+
+	// test
+	delay := limiter.When("my-instance")
+
+	// verify
+	assert.GreaterOrEqual(t, delay, 5*time.Millisecond)
+	assert.LessOrEqual(t, delay, 10*time.Millisecond)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// instanceOf returns the request reconciling the instance the given object was generated for, found with the instance
+// label of the object, i.e. <namespace>.<name>.
+func instanceOf(_ context.Context, obj client.Object) []ctrl.Request {
+	labels := obj.GetLabels()
+	if labels["app.kubernetes.io/managed-by"] != "opentelemetry-operator" || labels["app.kubernetes.io/component"] != "opentelemetry-collector" {
+		return nil
+	}
+	// the namespaces can't contain dots
+	namespace, name, found := strings.Cut(labels["app.kubernetes.io/instance"], ".")
+	if !found || len(namespace) == 0 || len(name) == 0 {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// collectorOf returns the request reconciling the gateway collector of the given tenant.
+func collectorOf(_ context.Context, obj client.Object) []ctrl.Request {
+	tenant, ok := obj.(*v1alpha1.TelemetryTenant)
+	if !ok || len(tenant.Spec.Collector) == 0 {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: tenant.Namespace, Name: tenant.Spec.Collector}}}
+}
+
+// onPodMonitorsChange starts watching the pod monitors when the Prometheus operator is installed after the operator
+// started, and reconciles all the instances again so that the pod monitors of their sidecars are created right away.
+// The informers can't be stopped, so the pod monitors are still watched if the Prometheus operator is uninstalled,
+// only their reconciliation stops.
+func (r *OpenTelemetryCollectorReconciler) onPodMonitorsChange() error {
+	if r.config.PodMonitors() != autodetect.PodMonitorsAvailable {
+		return nil
+	}
+	r.muWatches.Lock()
+	defer r.muWatches.Unlock()
+	if r.controller == nil || r.watchingPodMonitors {
+		return nil
+	}
+
+	r.log.Info("the pod monitors are available, starting to watch them")
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(collector.PodMonitorGVK)
+	if err := r.controller.Watch(source.Kind(r.cache, podMonitor), r.ownerHandler); err != nil {
+		return err
+	}
+	r.watchingPodMonitors = true
+	return r.controller.Watch(source.Func(r.enqueueInstances), r.enqueue(&handler.EnqueueRequestForObject{}))
+}
+
+// enqueueInstances is a source enqueuing all the instances once, through the given handler.
+func (r *OpenTelemetryCollectorReconciler) enqueueInstances(ctx context.Context, h handler.EventHandler, q workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	var instances v1alpha1.OpenTelemetryCollectorList
+	if err := r.List(ctx, &instances); err != nil {
+		return err
+	}
+	for i := range instances.Items {
+		h.Generic(ctx, event.GenericEvent{Object: &instances.Items[i]}, q)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestInstanceOf(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	otelcol.Name = "my-instance"
+	otelcol.Namespace = "observability"
+
+	clusterRole := collector.ClusterRole(otelcol)
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "my-instance"}}}, instanceOf(context.Background(), &clusterRole))
+
+	other := &rbacv1.ClusterRole{}
+	other.Labels = map[string]string{"app.kubernetes.io/instance": "observability.my-instance"}
+	assert.Empty(t, instanceOf(context.Background(), other))
+}
+
+func TestCollectorOf(t *testing.T) {
+	tenant := &v1alpha1.TelemetryTenant{}
+	tenant.Name = "team-a"
+	tenant.Namespace = "observability"
+	tenant.Spec.Collector = "gateway"
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "gateway"}}}, collectorOf(context.Background(), tenant))
+
+	assert.Empty(t, collectorOf(context.Background(), &rbacv1.ClusterRole{}))
+}
+
+func TestTenantsOf(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	teamA := &v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "observability"},
+		Spec: v1alpha1.TelemetryTenantSpec{
+			Collector:         "gateway",
+			Namespaces:        []string{"shop"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-a"}},
+		},
+	}
+	invalid := &v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "observability"},
+		Spec: v1alpha1.TelemetryTenantSpec{
+			Collector:         "gateway",
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}}},
+		},
+	}
+	otherCollector := &v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-c", Namespace: "observability"},
+		Spec:       v1alpha1.TelemetryTenantSpec{Collector: "other", Namespaces: []string{"search"}},
+	}
+	otherNamespace := &v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-d", Namespace: "default"},
+		Spec:       v1alpha1.TelemetryTenantSpec{Collector: "gateway", Namespaces: []string{"search"}},
+	}
+	payments := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"tenant": "team-a"}}}
+	r := &OpenTelemetryCollectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(teamA, invalid, otherCollector, otherNamespace, payments).Build(),
+		log:    logr.Discard(),
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"}}
+
+	// test
+	tenants, leftOut, err := r.tenantsOf(context.Background(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, "team-a", tenants[0].Name)
+	assert.Equal(t, []string{"shop", "payments"}, tenants[0].Spec.Namespaces)
+	require.Contains(t, leftOut, "team-b")
+	assert.Contains(t, leftOut["team-b"], "invalid namespace selector of the team-b tenant")
+
+	// test
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	tenants, leftOut, err = r.tenantsOf(context.Background(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, tenants, "the sidecars have no tenants")
+	assert.Empty(t, leftOut)
+}
+
+func TestTenantCollectorsOf(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	var objs []client.Object
+	for _, tenant := range []struct {
+		name, namespace, collector string
+	}{
+		{"team-a", "observability", "gateway"},
+		{"team-b", "observability", "gateway"},
+		{"team-c", "edge", "edge-gateway"},
+	} {
+		objs = append(objs, &v1alpha1.TelemetryTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: tenant.name, Namespace: tenant.namespace},
+			Spec: v1alpha1.TelemetryTenantSpec{
+				Collector:         tenant.collector,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": tenant.name}},
+			},
+		})
+	}
+	objs = append(objs, &v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-d", Namespace: "observability"},
+		Spec:       v1alpha1.TelemetryTenantSpec{Collector: "listed", Namespaces: []string{"shop"}},
+	})
+	r := &OpenTelemetryCollectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		log:    logr.Discard(),
+	}
+	namespace := func(tenant string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"tenant": tenant}}}
+	}
+
+	// test and verify
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "gateway"}}}, r.tenantCollectorsOf(context.Background(), namespace("team-a")))
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "edge", Name: "edge-gateway"}}}, r.tenantCollectorsOf(context.Background(), namespace("team-c")))
+	assert.Empty(t, r.tenantCollectorsOf(context.Background(), namespace("team-e")), "the listed namespaces of the tenants aren't watched")
+	assert.Empty(t, r.tenantCollectorsOf(context.Background(), &rbacv1.ClusterRole{}))
+}
+
+func TestOnPodMonitorsChange(t *testing.T) {
+	// prepare
+	watches := &watchRecorder{}
+	r := &OpenTelemetryCollectorReconciler{
+		log:        logr.Discard(),
+		config:     config.New(),
+		controller: watches,
+	}
+
+	// test
+	require.NoError(t, r.onPodMonitorsChange())
+
+	// verify
+	assert.Empty(t, watches.sources, "the pod monitors aren't available")
+
+	// test
+	r.config = config.New(config.WithPodMonitors(autodetect.PodMonitorsAvailable))
+	require.NoError(t, r.onPodMonitorsChange())
+	require.NoError(t, r.onPodMonitorsChange())
+
+	// verify
+	require.Len(t, watches.sources, 2, "the pod monitors are only watched once")
+	assert.Equal(t, "kind source: *unstructured.Unstructured", fmt.Sprint(watches.sources[0]))
+}
+
+// watchRecorder is a controller recording the sources it's asked to watch.
+type watchRecorder struct {
+	controller.Controller
+	sources []source.Source
+}
+
+func (w *watchRecorder) Watch(src source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+	w.sources = append(w.sources, src)
+	return nil
+}
+
+func (w *watchRecorder) GetLogger() logr.Logger {
+	return logr.Discard()
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// InjectionExclusions holds the pods the pod webhook never injects sidecars or instrumentation into, whatever the
+// annotations of the pods and of their namespaces, e.g. the system components and the vendor images.
+type InjectionExclusions struct {
+	images     []*regexp.Regexp
+	namespaces []*regexp.Regexp
+	selectors  []labels.Selector
+}
+
+// NewInjectionExclusions excludes the pods running one of the given images, in one of the given namespaces, or matching
+// one of the given label selectors. The images and namespaces are patterns where * matches any sequence of characters,
+// e.g. registry.k8s.io/*.
+func NewInjectionExclusions(images, namespaces, selectors []string) (InjectionExclusions, error) {
+	exclusions := InjectionExclusions{}
+	for _, image := range images {
+		exclusions.images = append(exclusions.images, regexp.MustCompile("^"+globToRegexp(image)+"$"))
+	}
+	for _, namespace := range namespaces {
+		exclusions.namespaces = append(exclusions.namespaces, regexp.MustCompile("^"+globToRegexp(namespace)+"$"))
+	}
+	for _, s := range selectors {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return InjectionExclusions{}, fmt.Errorf("the label selector %q is invalid: %w", s, err)
+		}
+		if selector.Empty() {
+			return InjectionExclusions{}, fmt.Errorf("the label selector %q would exclude all the pods", s)
+		}
+		exclusions.selectors = append(exclusions.selectors, selector)
+	}
+	return exclusions, nil
+}
+
+// Excludes returns why the given pod of the namespace is excluded from injection, or an empty string when it isn't.
+func (e InjectionExclusions) Excludes(namespace string, pod corev1.Pod) string {
+	for _, pattern := range e.namespaces {
+		if pattern.MatchString(namespace) {
+			return fmt.Sprintf("the namespace %s is excluded", namespace)
+		}
+	}
+	for _, selector := range e.selectors {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return fmt.Sprintf("the labels match the excluded selector %s", selector)
+		}
+	}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, pattern := range e.images {
+				if pattern.MatchString(container.Image) {
+					return fmt.Sprintf("the image %s of the container %s is excluded", container.Image, container.Name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// globToRegexp converts a pattern where * matches any sequence of characters to a regular expression.
+func globToRegexp(pattern string) string {
+	var result strings.Builder
+	for i, literal := range strings.Split(pattern, "*") {
+		// Replace * with .*
+		if i > 0 {
+			result.WriteString(".*")
+		}
+
+		// Quote any regular expression meta characters in the
+		// literal text.
+		result.WriteString(regexp.QuoteMeta(literal))
+	}
+	return result.String()
+}
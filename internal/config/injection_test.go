@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestInjectionExclusions(t *testing.T) {
+	// prepare
+	exclusions, err := config.NewInjectionExclusions(
+		[]string{"registry.k8s.io/*", "*/vendor/agent:*"},
+		[]string{"kube-system", "vendor-*"},
+		[]string{"app.kubernetes.io/part-of=vendor"},
+	)
+	require.NoError(t, err)
+	pod := func(labels map[string]string, images ...string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: "app", Image: image})
+		}
+		return p
+	}
+
+	for _, tt := range []struct {
+		desc      string
+		namespace string
+		pod       corev1.Pod
+		excluded  bool
+	}{
+		{
+			desc:      "not excluded",
+			namespace: "default",
+			pod:       pod(map[string]string{"app.kubernetes.io/part-of": "shop"}, "docker.io/library/nginx:1.25"),
+		},
+		{
+			desc:      "excluded namespace",
+			namespace: "kube-system",
+			pod:       pod(nil, "docker.io/library/nginx:1.25"),
+			excluded:  true,
+		},
+		{
+			desc:      "excluded namespace pattern",
+			namespace: "vendor-monitoring",
+			pod:       pod(nil, "docker.io/library/nginx:1.25"),
+			excluded:  true,
+		},
+		{
+			desc:      "namespace pattern matching the whole name only",
+			namespace: "my-kube-system",
+			pod:       pod(nil, "docker.io/library/nginx:1.25"),
+		},
+		{
+			desc:      "excluded labels",
+			namespace: "default",
+			pod:       pod(map[string]string{"app.kubernetes.io/part-of": "vendor"}, "docker.io/library/nginx:1.25"),
+			excluded:  true,
+		},
+		{
+			desc:      "excluded image",
+			namespace: "default",
+			pod:       pod(nil, "docker.io/library/nginx:1.25", "quay.io/vendor/agent:v1"),
+			excluded:  true,
+		},
+		{
+			desc:      "excluded image pattern",
+			namespace: "default",
+			pod:       pod(nil, "registry.k8s.io/coredns/coredns:v1.10.1"),
+			excluded:  true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			reason := exclusions.Excludes(tt.namespace, tt.pod)
+
+			// verify
+			assert.Equal(t, tt.excluded, reason != "", reason)
+		})
+	}
+}
+
+func TestInjectionExclusionsInvalidSelector(t *testing.T) {
+	_, err := config.NewInjectionExclusions(nil, nil, []string{"app in (a"})
+	assert.Error(t, err)
+
+	_, err = config.NewInjectionExclusions(nil, nil, []string{""})
+	assert.Error(t, err)
+}
+
+func TestNoInjectionExclusions(t *testing.T) {
+	cfg := config.New()
+	assert.Empty(t, cfg.InjectionExclusions().Excludes("kube-system", corev1.Pod{}))
+}
@@ -36,10 +36,12 @@ const (
 type Config struct {
 	autoDetect                          autodetect.AutoDetect
 	logger                              logr.Logger
+	operatorVersion                     string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
 	autoInstrumentationPythonImage      string
 	collectorImage                      string
+	debugImage                          string
 	collectorConfigMapEntry             string
 	autoInstrumentationDotNetImage      string
 	autoInstrumentationGoImage          string
@@ -48,10 +50,19 @@ type Config struct {
 	autoInstrumentationNodeJSImage      string
 	autoInstrumentationJavaImage        string
 	onOpenShiftRoutesChange             changeHandler
+	onPodMonitorsChange                 changeHandler
 	labelsFilter                        []string
+	injectionExclusions                 InjectionExclusions
+	httpProxy                           string
+	httpsProxy                          string
+	noProxy                             string
 	openshiftRoutes                     openshiftRoutesStore
 	autoDetectFrequency                 time.Duration
 	hpaVersion                          hpaVersionStore
+	verticalPodAutoscalers              verticalPodAutoscalersStore
+	istio                               istioStore
+	podMonitors                         podMonitorsStore
+	permissions                         permissionsStore
 }
 
 // New constructs a new configuration based on the given options.
@@ -64,8 +75,13 @@ func New(opts ...Option) Config {
 		logger:                        logf.Log.WithName("config"),
 		openshiftRoutes:               newOpenShiftRoutesWrapper(),
 		hpaVersion:                    newHPAVersionWrapper(),
+		verticalPodAutoscalers:        newVerticalPodAutoscalersWrapper(),
+		istio:                         newIstioWrapper(),
+		podMonitors:                   newPodMonitorsWrapper(),
+		permissions:                   newPermissionsWrapper(),
 		version:                       version.Get(),
 		onOpenShiftRoutesChange:       newOnChange(),
+		onPodMonitorsChange:           newOnChange(),
 	}
 	for _, opt := range opts {
 		opt(&o)
@@ -75,20 +91,31 @@ func New(opts ...Option) Config {
 		autoDetect:                          o.autoDetect,
 		autoDetectFrequency:                 o.autoDetectFrequency,
 		collectorImage:                      o.collectorImage,
+		debugImage:                          o.debugImage,
 		collectorConfigMapEntry:             o.collectorConfigMapEntry,
 		targetAllocatorImage:                o.targetAllocatorImage,
 		operatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
 		targetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
 		logger:                              o.logger,
+		operatorVersion:                     o.version.Operator,
 		openshiftRoutes:                     o.openshiftRoutes,
 		hpaVersion:                          o.hpaVersion,
+		verticalPodAutoscalers:              o.verticalPodAutoscalers,
+		istio:                               o.istio,
+		podMonitors:                         o.podMonitors,
+		permissions:                         o.permissions,
 		onOpenShiftRoutesChange:             o.onOpenShiftRoutesChange,
+		onPodMonitorsChange:                 o.onPodMonitorsChange,
 		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
 		autoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
 		autoInstrumentationPythonImage:      o.autoInstrumentationPythonImage,
 		autoInstrumentationDotNetImage:      o.autoInstrumentationDotNetImage,
 		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
 		labelsFilter:                        o.labelsFilter,
+		injectionExclusions:                 o.injectionExclusions,
+		httpProxy:                           o.httpProxy,
+		httpsProxy:                          o.httpsProxy,
+		noProxy:                             o.noProxy,
 	}
 }
 
@@ -111,7 +138,8 @@ func (c *Config) periodicAutoDetect() {
 	}
 }
 
-// AutoDetect attempts to automatically detect relevant information for this operator.
+// AutoDetect attempts to automatically detect relevant information for this operator. The optional APIs the operator
+// isn't allowed to manage are deemed not available, so that their objects are neither watched nor reconciled.
 func (c *Config) AutoDetect() error {
 	c.logger.V(2).Info("auto-detecting the configuration based on the environment")
 
@@ -120,32 +148,112 @@ func (c *Config) AutoDetect() error {
 		return err
 	}
 
+	permissions, err := c.autoDetect.Permissions()
+	if err != nil {
+		c.logger.V(1).Info("failed to review the permissions of the operator, assuming it has them all", "error", err)
+		permissions = autodetect.Permissions{}
+	}
+	if c.ClusterRolesAllowed() != clusterRolesAllowed(permissions) {
+		c.logger.V(1).Info("cluster roles detected", "allowed", clusterRolesAllowed(permissions))
+	}
+	c.permissions.Set(permissions)
+	capabilityAvailable.WithLabelValues("cluster_roles").Set(gaugeValue(clusterRolesAllowed(permissions)))
+
+	allowed := permissions.Allowed("route.openshift.io", "routes")
+	if !allowed {
+		ora = autodetect.OpenShiftRoutesNotAvailable
+	}
 	if c.openshiftRoutes.Get() != ora {
-		c.logger.V(1).Info("openshift routes detected", "available", ora)
+		c.logger.V(1).Info("openshift routes detected", "available", ora, "allowed", allowed)
 		c.openshiftRoutes.Set(ora)
 		if err = c.onOpenShiftRoutesChange.Do(); err != nil {
 			// Don't fail if the callback failed, as auto-detection itself worked.
 			c.logger.Error(err, "configuration change notification failed for callback")
 		}
 	}
+	capabilityAvailable.WithLabelValues("openshift_routes").Set(gaugeValue(ora == autodetect.OpenShiftRoutesAvailable))
 
+	// the discovery succeeded for the routes, so the errors are about the autoscaling API itself, e.g. when the
+	// cluster serves neither autoscaling/v2 nor autoscaling/v2beta2, and only disable the horizontal pod autoscalers
 	hpaV, err := c.autoDetect.HPAVersion()
 	if err != nil {
-		return err
+		c.logger.V(2).Info("horizontal pod autoscalers not available", "error", err)
+		hpaV = autodetect.AutoscalingVersionUnknown
+	}
+	allowed = permissions.Allowed("autoscaling", "horizontalpodautoscalers")
+	if !allowed {
+		hpaV = autodetect.AutoscalingVersionUnknown
 	}
 	if c.hpaVersion.Get() != hpaV {
-		c.logger.V(1).Info("HPA version detected", "version", hpaV)
+		c.logger.V(1).Info("HPA version detected", "version", hpaV, "allowed", allowed)
 		c.hpaVersion.Set(hpaV)
 	}
+	capabilityAvailable.WithLabelValues("horizontal_pod_autoscalers").Set(gaugeValue(hpaV != autodetect.AutoscalingVersionUnknown))
+
+	vpa, err := c.autoDetect.VerticalPodAutoscalersAvailability()
+	if err != nil {
+		return err
+	}
+	allowed = permissions.Allowed("autoscaling.k8s.io", "verticalpodautoscalers")
+	if !allowed {
+		vpa = autodetect.VerticalPodAutoscalersNotAvailable
+	}
+	if c.verticalPodAutoscalers.Get() != vpa {
+		c.logger.V(1).Info("vertical pod autoscalers detected", "available", vpa, "allowed", allowed)
+		c.verticalPodAutoscalers.Set(vpa)
+	}
+	capabilityAvailable.WithLabelValues("vertical_pod_autoscalers").Set(gaugeValue(vpa == autodetect.VerticalPodAutoscalersAvailable))
+
+	istio, err := c.autoDetect.IstioAvailability()
+	if err != nil {
+		return err
+	}
+	allowed = permissions.Allowed("networking.istio.io", "serviceentries") && permissions.Allowed("networking.istio.io", "sidecars") &&
+		permissions.Allowed("security.istio.io", "peerauthentications")
+	if !allowed {
+		istio = autodetect.IstioNotAvailable
+	}
+	if c.istio.Get() != istio {
+		c.logger.V(1).Info("istio detected", "available", istio, "allowed", allowed)
+		c.istio.Set(istio)
+	}
+	capabilityAvailable.WithLabelValues("istio").Set(gaugeValue(istio == autodetect.IstioAvailable))
+
+	podMonitors, err := c.autoDetect.PodMonitorsAvailability()
+	if err != nil {
+		return err
+	}
+	allowed = permissions.Allowed("monitoring.coreos.com", "podmonitors")
+	if !allowed {
+		podMonitors = autodetect.PodMonitorsNotAvailable
+	}
+	if c.podMonitors.Get() != podMonitors {
+		c.logger.V(1).Info("pod monitors detected", "available", podMonitors, "allowed", allowed)
+		c.podMonitors.Set(podMonitors)
+		if err = c.onPodMonitorsChange.Do(); err != nil {
+			c.logger.Error(err, "configuration change notification failed for callback")
+		}
+	}
+	capabilityAvailable.WithLabelValues("pod_monitors").Set(gaugeValue(podMonitors == autodetect.PodMonitorsAvailable))
 
 	return nil
 }
 
+// OperatorVersion is the version of the operator, recorded on the objects it generates.
+func (c *Config) OperatorVersion() string {
+	return c.operatorVersion
+}
+
 // CollectorImage represents the flag to override the OpenTelemetry Collector container image.
 func (c *Config) CollectorImage() string {
 	return c.collectorImage
 }
 
+// DebugImage represents the flag to override the image of the ephemeral debug container attached to collector pods.
+func (c *Config) DebugImage() string {
+	return c.debugImage
+}
+
 // CollectorConfigMapEntry represents the configuration file name for the collector. Immutable.
 func (c *Config) CollectorConfigMapEntry() string {
 	return c.collectorConfigMapEntry
@@ -171,6 +279,31 @@ func (c *Config) AutoscalingVersion() autodetect.AutoscalingVersion {
 	return c.hpaVersion.Get()
 }
 
+// VerticalPodAutoscalers represents the availability of the VerticalPodAutoscaler API.
+func (c *Config) VerticalPodAutoscalers() autodetect.VerticalPodAutoscalersAvailability {
+	return c.verticalPodAutoscalers.Get()
+}
+
+// Istio represents the availability of the Istio networking API.
+func (c *Config) Istio() autodetect.IstioAvailability {
+	return c.istio.Get()
+}
+
+// PodMonitors represents the availability of the Prometheus operator's PodMonitor API.
+func (c *Config) PodMonitors() autodetect.PodMonitorsAvailability {
+	return c.podMonitors.Get()
+}
+
+// ClusterRolesAllowed represents whether the operator may manage the cluster roles and cluster role bindings, which it
+// can't when installed with the permissions of some namespaces only.
+func (c *Config) ClusterRolesAllowed() bool {
+	return clusterRolesAllowed(c.permissions.Get())
+}
+
+func clusterRolesAllowed(permissions autodetect.Permissions) bool {
+	return permissions.Allowed("rbac.authorization.k8s.io", "clusterroles") && permissions.Allowed("rbac.authorization.k8s.io", "clusterrolebindings")
+}
+
 // AutoInstrumentationJavaImage returns OpenTelemetry Java auto-instrumentation container image.
 func (c *Config) AutoInstrumentationJavaImage() string {
 	return c.autoInstrumentationJavaImage
@@ -206,12 +339,38 @@ func (c *Config) LabelsFilter() []string {
 	return c.labelsFilter
 }
 
+// InjectionExclusions returns the pods the pod webhook never injects sidecars or instrumentation into.
+func (c *Config) InjectionExclusions() InjectionExclusions {
+	return c.injectionExclusions
+}
+
+// HTTPProxy returns the proxy for the HTTP requests of the containers managed by the operator.
+func (c *Config) HTTPProxy() string {
+	return c.httpProxy
+}
+
+// HTTPSProxy returns the proxy for the HTTPS requests of the containers managed by the operator.
+func (c *Config) HTTPSProxy() string {
+	return c.httpsProxy
+}
+
+// NoProxy returns the hosts, domains and CIDRs the containers managed by the operator reach without the proxy.
+func (c *Config) NoProxy() string {
+	return c.noProxy
+}
+
 // RegisterOpenShiftRoutesChangeCallback registers the given function as a callback that
 // is called when the OpenShift Routes detection detects a change.
 func (c *Config) RegisterOpenShiftRoutesChangeCallback(f func() error) {
 	c.onOpenShiftRoutesChange.Register(f)
 }
 
+// RegisterPodMonitorsChangeCallback registers the given function as a callback that
+// is called when the PodMonitors detection detects a change.
+func (c *Config) RegisterPodMonitorsChangeCallback(f func() error) {
+	c.onPodMonitorsChange.Register(f)
+}
+
 type hpaVersionStore interface {
 	Set(hpaV autodetect.AutoscalingVersion)
 	Get() autodetect.AutoscalingVersion
@@ -269,3 +428,117 @@ func (p *openshiftRoutesWrapper) Get() autodetect.OpenShiftRoutesAvailability {
 	p.mu.Unlock()
 	return ora
 }
+
+type verticalPodAutoscalersStore interface {
+	Set(vpa autodetect.VerticalPodAutoscalersAvailability)
+	Get() autodetect.VerticalPodAutoscalersAvailability
+}
+
+func newVerticalPodAutoscalersWrapper() verticalPodAutoscalersStore {
+	return &verticalPodAutoscalersWrapper{
+		current: autodetect.VerticalPodAutoscalersNotAvailable,
+	}
+}
+
+type verticalPodAutoscalersWrapper struct {
+	mu      sync.Mutex
+	current autodetect.VerticalPodAutoscalersAvailability
+}
+
+func (p *verticalPodAutoscalersWrapper) Set(vpa autodetect.VerticalPodAutoscalersAvailability) {
+	p.mu.Lock()
+	p.current = vpa
+	p.mu.Unlock()
+}
+
+func (p *verticalPodAutoscalersWrapper) Get() autodetect.VerticalPodAutoscalersAvailability {
+	p.mu.Lock()
+	vpa := p.current
+	p.mu.Unlock()
+	return vpa
+}
+
+type istioStore interface {
+	Set(istio autodetect.IstioAvailability)
+	Get() autodetect.IstioAvailability
+}
+
+func newIstioWrapper() istioStore {
+	return &istioWrapper{
+		current: autodetect.IstioNotAvailable,
+	}
+}
+
+type istioWrapper struct {
+	mu      sync.Mutex
+	current autodetect.IstioAvailability
+}
+
+func (p *istioWrapper) Set(istio autodetect.IstioAvailability) {
+	p.mu.Lock()
+	p.current = istio
+	p.mu.Unlock()
+}
+
+func (p *istioWrapper) Get() autodetect.IstioAvailability {
+	p.mu.Lock()
+	istio := p.current
+	p.mu.Unlock()
+	return istio
+}
+
+type podMonitorsStore interface {
+	Set(podMonitors autodetect.PodMonitorsAvailability)
+	Get() autodetect.PodMonitorsAvailability
+}
+
+func newPodMonitorsWrapper() podMonitorsStore {
+	return &podMonitorsWrapper{
+		current: autodetect.PodMonitorsNotAvailable,
+	}
+}
+
+type podMonitorsWrapper struct {
+	mu      sync.Mutex
+	current autodetect.PodMonitorsAvailability
+}
+
+func (p *podMonitorsWrapper) Set(podMonitors autodetect.PodMonitorsAvailability) {
+	p.mu.Lock()
+	p.current = podMonitors
+	p.mu.Unlock()
+}
+
+func (p *podMonitorsWrapper) Get() autodetect.PodMonitorsAvailability {
+	p.mu.Lock()
+	podMonitors := p.current
+	p.mu.Unlock()
+	return podMonitors
+}
+
+type permissionsStore interface {
+	Set(permissions autodetect.Permissions)
+	Get() autodetect.Permissions
+}
+
+func newPermissionsWrapper() permissionsStore {
+	return &permissionsWrapper{}
+}
+
+type permissionsWrapper struct {
+	mu      sync.Mutex
+	current autodetect.Permissions
+}
+
+func (p *permissionsWrapper) Set(permissions autodetect.Permissions) {
+	p.mu.Lock()
+	p.current = permissions
+	p.mu.Unlock()
+}
+
+func (p *permissionsWrapper) Get() autodetect.Permissions {
+	p.mu.Lock()
+	permissions := p.current
+	p.mu.Unlock()
+	return permissions
+}
@@ -15,12 +15,14 @@
 package config_test
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
@@ -39,6 +41,9 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, "some-config.yaml", cfg.CollectorConfigMapEntry())
 	assert.Equal(t, autodetect.OpenShiftRoutesNotAvailable, cfg.OpenShiftRoutes())
 	assert.Equal(t, autodetect.AutoscalingVersionUnknown, cfg.AutoscalingVersion())
+	assert.Equal(t, autodetect.VerticalPodAutoscalersNotAvailable, cfg.VerticalPodAutoscalers())
+	assert.Equal(t, autodetect.IstioNotAvailable, cfg.Istio())
+	assert.Equal(t, autodetect.PodMonitorsNotAvailable, cfg.PodMonitors())
 }
 
 func TestOnPlatformChangeCallback(t *testing.T) {
@@ -69,6 +74,165 @@ func TestOnPlatformChangeCallback(t *testing.T) {
 	assert.True(t, calledBack)
 }
 
+func TestVerticalPodAutoscalersDetected(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		VerticalPodAutoscalersAvailabilityFunc: func() (autodetect.VerticalPodAutoscalersAvailability, error) {
+			return autodetect.VerticalPodAutoscalersAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.VerticalPodAutoscalersNotAvailable, cfg.VerticalPodAutoscalers())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.VerticalPodAutoscalersAvailable, cfg.VerticalPodAutoscalers())
+}
+
+func TestIstioDetected(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		IstioAvailabilityFunc: func() (autodetect.IstioAvailability, error) {
+			return autodetect.IstioAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.IstioNotAvailable, cfg.Istio())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.IstioAvailable, cfg.Istio())
+}
+
+func TestPodMonitorsDetected(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		PodMonitorsAvailabilityFunc: func() (autodetect.PodMonitorsAvailability, error) {
+			return autodetect.PodMonitorsAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.Equal(t, autodetect.PodMonitorsNotAvailable, cfg.PodMonitors())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.PodMonitorsAvailable, cfg.PodMonitors())
+}
+
+func TestMissingPermissionsDisableAPIs(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		VerticalPodAutoscalersAvailabilityFunc: func() (autodetect.VerticalPodAutoscalersAvailability, error) {
+			return autodetect.VerticalPodAutoscalersAvailable, nil
+		},
+		PodMonitorsAvailabilityFunc: func() (autodetect.PodMonitorsAvailability, error) {
+			return autodetect.PodMonitorsAvailable, nil
+		},
+		PermissionsFunc: func() (autodetect.Permissions, error) {
+			return autodetect.NewPermissions([]authorizationv1.ResourceRule{
+				{APIGroups: []string{"autoscaling", "autoscaling.k8s.io"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			}), nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// sanity check
+	require.True(t, cfg.ClusterRolesAllowed())
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.AutoscalingVersionV2, cfg.AutoscalingVersion())
+	assert.Equal(t, autodetect.VerticalPodAutoscalersAvailable, cfg.VerticalPodAutoscalers())
+	assert.Equal(t, autodetect.PodMonitorsNotAvailable, cfg.PodMonitors())
+	assert.False(t, cfg.ClusterRolesAllowed())
+}
+
+func TestPermissionsReviewFailure(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		PodMonitorsAvailabilityFunc: func() (autodetect.PodMonitorsAvailability, error) {
+			return autodetect.PodMonitorsAvailable, nil
+		},
+		PermissionsFunc: func() (autodetect.Permissions, error) {
+			return autodetect.Permissions{}, errors.New("forbidden")
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.PodMonitorsAvailable, cfg.PodMonitors())
+	assert.True(t, cfg.ClusterRolesAllowed())
+}
+
+func TestAutoscalingNotServed(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+			return autodetect.AutoscalingVersionUnknown, errors.New("Failed to find apiGroup autoscaling")
+		},
+		PodMonitorsAvailabilityFunc: func() (autodetect.PodMonitorsAvailability, error) {
+			return autodetect.PodMonitorsAvailable, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+
+	// test
+	err := cfg.AutoDetect()
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, autodetect.AutoscalingVersionUnknown, cfg.AutoscalingVersion())
+	assert.Equal(t, autodetect.PodMonitorsAvailable, cfg.PodMonitors())
+}
+
+func TestOnPodMonitorsChangeCallback(t *testing.T) {
+	// prepare
+	calledBack := 0
+	podMonitors := autodetect.PodMonitorsNotAvailable
+	mock := &mockAutoDetect{
+		PodMonitorsAvailabilityFunc: func() (autodetect.PodMonitorsAvailability, error) {
+			return podMonitors, nil
+		},
+	}
+	cfg := config.New(config.WithAutoDetect(mock))
+	cfg.RegisterPodMonitorsChangeCallback(func() error {
+		calledBack++
+		return nil
+	})
+
+	// test
+	require.NoError(t, cfg.AutoDetect())
+	podMonitors = autodetect.PodMonitorsAvailable
+	require.NoError(t, cfg.AutoDetect())
+	require.NoError(t, cfg.AutoDetect())
+
+	// verify
+	assert.Equal(t, autodetect.PodMonitorsAvailable, cfg.PodMonitors())
+	assert.Equal(t, 1, calledBack)
+}
+
 func TestAutoDetectInBackground(t *testing.T) {
 	// prepare
 	wg := &sync.WaitGroup{}
@@ -103,8 +267,12 @@ func TestAutoDetectInBackground(t *testing.T) {
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
-	HPAVersionFunc                  func() (autodetect.AutoscalingVersion, error)
+	OpenShiftRoutesAvailabilityFunc        func() (autodetect.OpenShiftRoutesAvailability, error)
+	HPAVersionFunc                         func() (autodetect.AutoscalingVersion, error)
+	VerticalPodAutoscalersAvailabilityFunc func() (autodetect.VerticalPodAutoscalersAvailability, error)
+	IstioAvailabilityFunc                  func() (autodetect.IstioAvailability, error)
+	PodMonitorsAvailabilityFunc            func() (autodetect.PodMonitorsAvailability, error)
+	PermissionsFunc                        func() (autodetect.Permissions, error)
 }
 
 func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
@@ -120,3 +288,31 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) VerticalPodAutoscalersAvailability() (autodetect.VerticalPodAutoscalersAvailability, error) {
+	if m.VerticalPodAutoscalersAvailabilityFunc != nil {
+		return m.VerticalPodAutoscalersAvailabilityFunc()
+	}
+	return autodetect.VerticalPodAutoscalersNotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (autodetect.IstioAvailability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return autodetect.IstioNotAvailable, nil
+}
+
+func (m *mockAutoDetect) PodMonitorsAvailability() (autodetect.PodMonitorsAvailability, error) {
+	if m.PodMonitorsAvailabilityFunc != nil {
+		return m.PodMonitorsAvailabilityFunc()
+	}
+	return autodetect.PodMonitorsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) Permissions() (autodetect.Permissions, error) {
+	if m.PermissionsFunc != nil {
+		return m.PermissionsFunc()
+	}
+	return autodetect.Permissions{}, nil
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// capabilityAvailable exposes the optional APIs the operator manages the objects of, i.e. the ones served by the
+// cluster that it's allowed to manage, as found by the latest auto-detection.
+var capabilityAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "opentelemetry_operator_capability_available",
+	Help: "Whether the optional API of the capability is served by the cluster and managed by the operator.",
+}, []string{"capability"})
+
+func init() {
+	metrics.Registry.MustRegister(capabilityAvailable)
+}
+
+func gaugeValue(available bool) float64 {
+	if available {
+		return 1
+	}
+	return 0
+}
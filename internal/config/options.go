@@ -15,8 +15,6 @@
 package config
 
 import (
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -39,14 +37,24 @@ type options struct {
 	autoInstrumentationPythonImage      string
 	autoInstrumentationApacheHttpdImage string
 	collectorImage                      string
+	debugImage                          string
 	collectorConfigMapEntry             string
 	targetAllocatorConfigMapEntry       string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
 	onOpenShiftRoutesChange             changeHandler
+	onPodMonitorsChange                 changeHandler
 	labelsFilter                        []string
+	injectionExclusions                 InjectionExclusions
+	httpProxy                           string
+	httpsProxy                          string
+	noProxy                             string
 	openshiftRoutes                     openshiftRoutesStore
 	hpaVersion                          hpaVersionStore
+	verticalPodAutoscalers              verticalPodAutoscalersStore
+	istio                               istioStore
+	podMonitors                         podMonitorsStore
+	permissions                         permissionsStore
 	autoDetectFrequency                 time.Duration
 }
 
@@ -75,6 +83,12 @@ func WithCollectorImage(s string) Option {
 		o.collectorImage = s
 	}
 }
+func WithDebugImage(s string) Option {
+	return func(o *options) {
+		o.debugImage = s
+	}
+}
+
 func WithCollectorConfigMapEntry(s string) Option {
 	return func(o *options) {
 		o.collectorConfigMapEntry = s
@@ -104,6 +118,26 @@ func WithPlatform(ora autodetect.OpenShiftRoutesAvailability) Option {
 		o.openshiftRoutes.Set(ora)
 	}
 }
+func WithVerticalPodAutoscalers(vpa autodetect.VerticalPodAutoscalersAvailability) Option {
+	return func(o *options) {
+		o.verticalPodAutoscalers.Set(vpa)
+	}
+}
+func WithIstio(istio autodetect.IstioAvailability) Option {
+	return func(o *options) {
+		o.istio.Set(istio)
+	}
+}
+func WithPodMonitors(podMonitors autodetect.PodMonitorsAvailability) Option {
+	return func(o *options) {
+		o.podMonitors.Set(podMonitors)
+	}
+}
+func WithPermissions(permissions autodetect.Permissions) Option {
+	return func(o *options) {
+		o.permissions.Set(permissions)
+	}
+}
 func WithVersion(v version.Version) Option {
 	return func(o *options) {
 		o.version = v
@@ -146,27 +180,36 @@ func WithAutoInstrumentationApacheHttpdImage(s string) Option {
 	}
 }
 
-func WithLabelFilters(labelFilters []string) Option {
+func WithHTTPProxy(s string) Option {
 	return func(o *options) {
+		o.httpProxy = s
+	}
+}
 
-		filters := []string{}
-		for _, pattern := range labelFilters {
-			var result strings.Builder
-
-			for i, literal := range strings.Split(pattern, "*") {
+func WithHTTPSProxy(s string) Option {
+	return func(o *options) {
+		o.httpsProxy = s
+	}
+}
 
-				// Replace * with .*
-				if i > 0 {
-					result.WriteString(".*")
-				}
+func WithNoProxy(s string) Option {
+	return func(o *options) {
+		o.noProxy = s
+	}
+}
 
-				// Quote any regular expression meta characters in the
-				// literal text.
-				result.WriteString(regexp.QuoteMeta(literal))
-			}
-			filters = append(filters, result.String())
+func WithLabelFilters(labelFilters []string) Option {
+	return func(o *options) {
+		filters := []string{}
+		for _, pattern := range labelFilters {
+			filters = append(filters, globToRegexp(pattern))
 		}
 
 		o.labelsFilter = filters
 	}
 }
+func WithInjectionExclusions(exclusions InjectionExclusions) Option {
+	return func(o *options) {
+		o.injectionExclusions = exclusions
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;patch
+
+const (
+	// PodWebhookName is the name of the pod webhook in the operator's MutatingWebhookConfiguration.
+	PodWebhookName = "mpod.kb.io"
+
+	// PodWebhookPath is the path the operator serves the pod webhook on.
+	PodWebhookPath = "/mutate-v1-pod"
+)
+
+// PodWebhookSettings are the settings of the pod webhook the operator keeps in its MutatingWebhookConfiguration,
+// overriding the ones it was deployed with. The settings that aren't set are left as deployed.
+type PodWebhookSettings struct {
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType
+	ObjectSelector     *metav1.LabelSelector
+	NamespaceSelector  *metav1.LabelSelector
+}
+
+// NewPodWebhookSettings parses the given reinvocation policy, Never or IfNeeded, and the given label selectors of
+// the pods and namespaces sent to the pod webhook. Empty values leave the settings as deployed.
+func NewPodWebhookSettings(reinvocationPolicy, objectSelector, namespaceSelector string) (PodWebhookSettings, error) {
+	settings := PodWebhookSettings{}
+	switch policy := admissionregistrationv1.ReinvocationPolicyType(reinvocationPolicy); policy {
+	case "":
+	case admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy:
+		settings.ReinvocationPolicy = &policy
+	default:
+		return PodWebhookSettings{}, fmt.Errorf("invalid reinvocation policy %q, expected %s or %s", reinvocationPolicy,
+			admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy)
+	}
+
+	var err error
+	if len(objectSelector) > 0 {
+		if settings.ObjectSelector, err = parseLabelSelector(objectSelector); err != nil {
+			return PodWebhookSettings{}, fmt.Errorf("invalid object selector %q: %w", objectSelector, err)
+		}
+	}
+	if len(namespaceSelector) > 0 {
+		if settings.NamespaceSelector, err = parseLabelSelector(namespaceSelector); err != nil {
+			return PodWebhookSettings{}, fmt.Errorf("invalid namespace selector %q: %w", namespaceSelector, err)
+		}
+	}
+	return settings, nil
+}
+
+// parseLabelSelector turns the given label selector, e.g. environment!=dev, into the selector of a webhook.
+func parseLabelSelector(value string) (*metav1.LabelSelector, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	requirements, _ := selector.Requirements()
+
+	labelSelector := &metav1.LabelSelector{}
+	for _, requirement := range requirements {
+		var operator metav1.LabelSelectorOperator
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			operator = metav1.LabelSelectorOpIn
+		case selection.NotEquals, selection.NotIn:
+			operator = metav1.LabelSelectorOpNotIn
+		case selection.Exists:
+			operator = metav1.LabelSelectorOpExists
+		case selection.DoesNotExist:
+			operator = metav1.LabelSelectorOpDoesNotExist
+		default:
+			return nil, fmt.Errorf("the %s operator isn't supported by webhook selectors", requirement.Operator())
+		}
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      requirement.Key(),
+			Operator: operator,
+			Values:   requirement.Values().List(),
+		})
+	}
+	return labelSelector, nil
+}
+
+// IsEmpty checks whether the settings leave the pod webhook as deployed.
+func (s PodWebhookSettings) IsEmpty() bool {
+	return s.ReinvocationPolicy == nil && s.ObjectSelector == nil && s.NamespaceSelector == nil
+}
+
+// applyTo sets the settings on the given webhook, and returns whether it changed.
+func (s PodWebhookSettings) applyTo(webhook *admissionregistrationv1.MutatingWebhook) bool {
+	changed := false
+	if s.ReinvocationPolicy != nil && !reflect.DeepEqual(webhook.ReinvocationPolicy, s.ReinvocationPolicy) {
+		policy := *s.ReinvocationPolicy
+		webhook.ReinvocationPolicy = &policy
+		changed = true
+	}
+	if s.ObjectSelector != nil && !reflect.DeepEqual(webhook.ObjectSelector, s.ObjectSelector) {
+		webhook.ObjectSelector = s.ObjectSelector.DeepCopy()
+		changed = true
+	}
+	if s.NamespaceSelector != nil && !reflect.DeepEqual(webhook.NamespaceSelector, s.NamespaceSelector) {
+		webhook.NamespaceSelector = s.NamespaceSelector.DeepCopy()
+		changed = true
+	}
+	return changed
+}
+
+// PodWebhookConfigurator keeps the settings of the pod webhook in the operator's MutatingWebhookConfiguration,
+// applying them again when the configuration is redeployed. The configuration is read with the reader, which
+// doesn't need to cache it. The configurations of the other operators, like the ones OLM generates and reconciles,
+// are left untouched.
+type PodWebhookConfigurator struct {
+	Client   client.Client
+	Reader   client.Reader
+	Logger   logr.Logger
+	Settings PodWebhookSettings
+	Interval time.Duration
+	// ConfigurationName is the name of the operator's MutatingWebhookConfiguration.
+	ConfigurationName string
+	// Service is the operator's webhook service, which the pod webhook calls.
+	Service types.NamespacedName
+}
+
+// Start applies the settings until the given context is done.
+func (c *PodWebhookConfigurator) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.Apply(ctx); err != nil {
+			c.Logger.Error(err, "failed to apply the settings of the pod webhook")
+		}
+	}, c.Interval)
+	return nil
+}
+
+// Apply sets the settings on the pod webhook of the operator's MutatingWebhookConfiguration, which is only patched
+// when they differ. The patch only holds the pod webhook, leaving the CA bundle injected by other controllers
+// untouched.
+func (c *PodWebhookConfigurator) Apply(ctx context.Context) error {
+	existing := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Reader.Get(ctx, types.NamespacedName{Name: c.ConfigurationName}, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.Logger.V(1).Info("the MutatingWebhookConfiguration of the operator doesn't exist, e.g. it's managed by OLM", "configuration", c.ConfigurationName)
+			return nil
+		}
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	changed := false
+	for j := range updated.Webhooks {
+		if c.isPodWebhook(updated.Webhooks[j]) && c.Settings.applyTo(&updated.Webhooks[j]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := c.Client.Patch(ctx, updated, client.StrategicMergeFrom(existing)); err != nil {
+		return fmt.Errorf("failed to patch the MutatingWebhookConfiguration %s: %w", existing.Name, err)
+	}
+	c.Logger.Info("applied the settings of the pod webhook", "configuration", existing.Name)
+	return nil
+}
+
+// isPodWebhook checks whether the given webhook is the operator's pod webhook, served by its webhook service.
+func (c *PodWebhookConfigurator) isPodWebhook(webhook admissionregistrationv1.MutatingWebhook) bool {
+	service := webhook.ClientConfig.Service
+	return webhook.Name == PodWebhookName && service != nil && service.Path != nil && *service.Path == PodWebhookPath &&
+		service.Name == c.Service.Name && service.Namespace == c.Service.Namespace
+}
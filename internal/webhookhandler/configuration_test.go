@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+)
+
+func TestNewPodWebhookSettings(t *testing.T) {
+	settings, err := NewPodWebhookSettings("IfNeeded", "app.kubernetes.io/part-of!=vendor", "environment in (dev,prod)")
+	require.NoError(t, err)
+	require.NotNil(t, settings.ReinvocationPolicy)
+	assert.Equal(t, admissionregistrationv1.IfNeededReinvocationPolicy, *settings.ReinvocationPolicy)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{
+		{Key: "app.kubernetes.io/part-of", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"vendor"}},
+	}, settings.ObjectSelector.MatchExpressions)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{
+		{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{"dev", "prod"}},
+	}, settings.NamespaceSelector.MatchExpressions)
+
+	settings, err = NewPodWebhookSettings("", "", "")
+	require.NoError(t, err)
+	assert.True(t, settings.IsEmpty())
+
+	_, err = NewPodWebhookSettings("Always", "", "")
+	assert.ErrorContains(t, err, "invalid reinvocation policy")
+
+	_, err = NewPodWebhookSettings("", "app in (", "")
+	assert.ErrorContains(t, err, "invalid object selector")
+
+	_, err = NewPodWebhookSettings("", "", "tier>1")
+	assert.ErrorContains(t, err, "invalid namespace selector")
+}
+
+func TestPodWebhookConfigurator(t *testing.T) {
+	podPath := PodWebhookPath
+	otherPath := "/mutate-opentelemetry-io-v1alpha1-instrumentation"
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	existing := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "opentelemetry-operator-mutating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name: "minstrumentation.kb.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Namespace: "opentelemetry-operator-system", Name: "opentelemetry-operator-webhook-service", Path: &otherPath},
+				},
+				ReinvocationPolicy: &never,
+			},
+			{
+				Name: PodWebhookName,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service:  &admissionregistrationv1.ServiceReference{Namespace: "opentelemetry-operator-system", Name: "opentelemetry-operator-webhook-service", Path: &podPath},
+					CABundle: []byte("ca"),
+				},
+				ReinvocationPolicy: &never,
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithObjects(existing).Build()
+	settings, err := NewPodWebhookSettings("IfNeeded", "", "kubernetes.io/metadata.name!=kube-system")
+	require.NoError(t, err)
+	configurator := podWebhookConfigurator(cl, settings)
+
+	require.NoError(t, configurator.Apply(context.Background()))
+
+	actual := admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: existing.Name}, &actual))
+	require.Len(t, actual.Webhooks, 2)
+	assert.Equal(t, never, *actual.Webhooks[0].ReinvocationPolicy, "only the pod webhook is changed")
+	assert.Nil(t, actual.Webhooks[0].NamespaceSelector)
+	assert.Equal(t, admissionregistrationv1.IfNeededReinvocationPolicy, *actual.Webhooks[1].ReinvocationPolicy)
+	assert.Equal(t, settings.NamespaceSelector, actual.Webhooks[1].NamespaceSelector)
+	assert.Nil(t, actual.Webhooks[1].ObjectSelector, "the settings that aren't set are left as deployed")
+	assert.Equal(t, []byte("ca"), actual.Webhooks[1].ClientConfig.CABundle)
+
+	// the configuration is only patched when it differs
+	resourceVersion := actual.ResourceVersion
+	require.NoError(t, configurator.Apply(context.Background()))
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: existing.Name}, &actual))
+	assert.Equal(t, resourceVersion, actual.ResourceVersion)
+}
+
+func TestPodWebhookConfiguratorOtherConfigurations(t *testing.T) {
+	podPath := PodWebhookPath
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	podWebhook := func(namespace, service string) admissionregistrationv1.MutatingWebhook {
+		return admissionregistrationv1.MutatingWebhook{
+			Name: PodWebhookName,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{Namespace: namespace, Name: service, Path: &podPath},
+			},
+			ReinvocationPolicy: &never,
+		}
+	}
+	// the configuration OLM generates for the operator, and the one of another operator built with kubebuilder
+	olm := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mpod.kb.io-abcde", Labels: map[string]string{"olm.owner": "opentelemetry-operator.v0.77.0"}},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{podWebhook("opentelemetry-operator-system", "opentelemetry-operator-controller-manager-service")},
+	}
+	// the operator's configuration, with the pod webhook of another operator listed in it
+	own := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "opentelemetry-operator-mutating-webhook-configuration"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{podWebhook("other-operator-system", "opentelemetry-operator-webhook-service")},
+	}
+	settings, err := NewPodWebhookSettings("IfNeeded", "", "")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name           string
+		configurations []*admissionregistrationv1.MutatingWebhookConfiguration
+	}{
+		{
+			name:           "configuration managed by OLM",
+			configurations: []*admissionregistrationv1.MutatingWebhookConfiguration{olm},
+		},
+		{
+			name:           "pod webhook of another service",
+			configurations: []*admissionregistrationv1.MutatingWebhookConfiguration{olm, own},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			for _, configuration := range tt.configurations {
+				builder = builder.WithObjects(configuration.DeepCopy())
+			}
+			cl := builder.Build()
+			configurator := podWebhookConfigurator(cl, settings)
+
+			require.NoError(t, configurator.Apply(context.Background()))
+
+			for _, configuration := range tt.configurations {
+				actual := admissionregistrationv1.MutatingWebhookConfiguration{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: configuration.Name}, &actual))
+				assert.Equal(t, never, *actual.Webhooks[0].ReinvocationPolicy)
+			}
+		})
+	}
+}
+
+func podWebhookConfigurator(cl client.Client, settings PodWebhookSettings) *PodWebhookConfigurator {
+	return &PodWebhookConfigurator{
+		Client:            cl,
+		Reader:            cl,
+		Logger:            logr.Discard(),
+		Settings:          settings,
+		ConfigurationName: "opentelemetry-operator-mutating-webhook-configuration",
+		Service:           types.NamespacedName{Namespace: "opentelemetry-operator-system", Name: "opentelemetry-operator-webhook-service"},
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +28,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 // +kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=ignore,groups="",resources=pods,verbs=create;update,versions=v1,name=mpod.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -37,6 +40,15 @@ import (
 
 var _ WebhookHandler = (*podSidecarInjector)(nil)
 
+// NativeSidecarsAnnotation contains the comma-separated names of the init containers that should be turned into
+// native sidecar containers, by setting their restart policy to Always.
+// The restart policy of containers isn't part of the Kubernetes API version the operator is built with, which is
+// why the pod mutators request it via this annotation and the webhook sets it on the marshaled pod.
+// The webhook only marks the collector sidecar injected by the operator, and only when the
+// operator.sidecarcontainers.native feature gate tells that the cluster supports native sidecars: the annotation can't
+// be used to turn other containers into native sidecars.
+const NativeSidecarsAnnotation = "sidecar.opentelemetry.io/native-sidecars"
+
 // WebhookHandler is a webhook handler that analyzes new pods and injects appropriate sidecars into it.
 type WebhookHandler interface {
 	admission.Handler
@@ -74,6 +86,12 @@ func (p *podSidecarInjector) Handle(ctx context.Context, req admission.Request)
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// the excluded pods are left as they are, whatever their annotations and the ones of their namespace
+	if reason := p.config.InjectionExclusions().Excludes(req.Namespace, pod); reason != "" {
+		p.logger.V(1).Info("skipping the pod excluded from injection", "namespace", req.Namespace, "name", pod.Name, "reason", reason)
+		return admission.Allowed(reason)
+	}
+
 	// we use the req.Namespace here because the pod might have not been created yet
 	ns := corev1.Namespace{}
 	err = p.client.Get(ctx, types.NamespacedName{Name: req.Namespace, Namespace: ""}, &ns)
@@ -103,5 +121,50 @@ func (p *podSidecarInjector) Handle(ctx context.Context, req admission.Request)
 		res.Allowed = true
 		return res
 	}
+
+	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
+		marshaledPod, err = markNativeSidecars(marshaledPod, pod.Annotations[NativeSidecarsAnnotation])
+		if err != nil {
+			res := admission.Errored(http.StatusInternalServerError, err)
+			res.Allowed = true
+			return res
+		}
+	}
 	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
 }
+
+// markNativeSidecars sets the restart policy of the given init containers to Always in the marshaled pod, when they're
+// the collector sidecar.
+func markNativeSidecars(marshaledPod []byte, names string) ([]byte, error) {
+	if len(names) == 0 {
+		return marshaledPod, nil
+	}
+
+	sidecars := map[string]bool{}
+	for _, name := range strings.Split(names, ",") {
+		sidecars[strings.TrimSpace(name)] = true
+	}
+
+	pod := map[string]interface{}{}
+	if err := json.Unmarshal(marshaledPod, &pod); err != nil {
+		return nil, err
+	}
+	spec, ok := pod["spec"].(map[string]interface{})
+	if !ok {
+		return marshaledPod, nil
+	}
+	initContainers, ok := spec["initContainers"].([]interface{})
+	if !ok {
+		return marshaledPod, nil
+	}
+	for _, c := range initContainers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := container["name"].(string); ok && sidecars[name] && name == naming.Container() {
+			container["restartPolicy"] = string(corev1.RestartPolicyAlways)
+		}
+	}
+	return json.Marshal(pod)
+}
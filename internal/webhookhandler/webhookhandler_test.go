@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	admv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	. "github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
 )
@@ -388,6 +390,62 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 	}
 }
 
+func TestExcludedPodShouldNotBeChanged(t *testing.T) {
+	// prepare
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-namespace-excluded-pod",
+			Annotations: map[string]string{sidecar.Annotation: "my-instance"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &ns))
+	defer func() {
+		_ = k8sClient.Delete(context.Background(), &ns)
+	}()
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: ns.Name,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &otelcol))
+	defer func() {
+		_ = k8sClient.Delete(context.Background(), &otelcol)
+	}()
+
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "agent", Image: "quay.io/vendor/agent:v1"}},
+		},
+	}
+	encoded, err := json.Marshal(pod)
+	require.NoError(t, err)
+	req := admission.Request{
+		AdmissionRequest: admv1.AdmissionRequest{
+			Namespace: ns.Name,
+			Object: runtime.RawExtension{
+				Raw: encoded,
+			},
+		},
+	}
+
+	exclusions, err := config.NewInjectionExclusions([]string{"quay.io/vendor/*"}, nil, nil)
+	require.NoError(t, err)
+	cfg := config.New(config.WithInjectionExclusions(exclusions))
+	decoder := admission.NewDecoder(scheme.Scheme)
+	injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+
+	// test
+	res := injector.Handle(context.Background(), req)
+
+	// verify
+	assert.True(t, res.Allowed)
+	assert.Len(t, res.Patches, 0)
+}
+
 func TestFailOnInvalidRequest(t *testing.T) {
 	// we use a typical Go table-test instad of Ginkgo's DescribeTable because we need to
 	// do an assertion during the declaration of the table params, which isn't supported (yet?)
@@ -439,3 +497,101 @@ func TestFailOnInvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestNativeSidecarsAreMarked(t *testing.T) {
+	// prepare
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-namespace-native-sidecars",
+		},
+	}
+	err := k8sClient.Create(context.Background(), &ns)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), &ns))
+	}()
+
+	for _, tt := range []struct {
+		name          string
+		container     string
+		gateEnabled   bool
+		restartPolicy interface{}
+	}{
+		{
+			name:          "collector sidecar",
+			container:     naming.Container(),
+			gateEnabled:   true,
+			restartPolicy: "Always",
+		},
+		{
+			name:        "feature gate disabled",
+			container:   naming.Container(),
+			gateEnabled: false,
+		},
+		{
+			name:        "other container",
+			container:   "my-init",
+			gateEnabled: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), tt.gateEnabled))
+			defer func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), false))
+			}()
+
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:  "my-app",
+						Image: "my-app-image",
+					}},
+				},
+			}
+			encoded, err := json.Marshal(pod)
+			require.NoError(t, err)
+
+			req := admission.Request{
+				AdmissionRequest: admv1.AdmissionRequest{
+					Namespace: ns.Name,
+					Object: runtime.RawExtension{
+						Raw: encoded,
+					},
+				},
+			}
+
+			cfg := config.New()
+			decoder := admission.NewDecoder(scheme.Scheme)
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{nativeSidecarMutator{name: tt.container}})
+
+			// test
+			res := injector.Handle(context.Background(), req)
+
+			// verify
+			assert.True(t, res.Allowed)
+			var initContainers []interface{}
+			for _, patch := range res.Patches {
+				if patch.Path == "/spec/initContainers" {
+					initContainers, _ = patch.Value.([]interface{})
+				}
+			}
+			require.Len(t, initContainers, 1)
+			assert.Equal(t, tt.restartPolicy, initContainers[0].(map[string]interface{})["restartPolicy"])
+		})
+	}
+}
+
+// nativeSidecarMutator adds an init container requested to be a native sidecar.
+type nativeSidecarMutator struct {
+	name string
+}
+
+func (m nativeSidecarMutator) Mutate(_ context.Context, _ corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+		Name:  m.name,
+		Image: "my-sidecar-image",
+	})
+	pod.Annotations = map[string]string{NativeSidecarsAnnotation: m.name}
+	return pod, nil
+}
@@ -17,18 +17,25 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/spf13/pflag"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -48,6 +55,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/lint"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
@@ -89,7 +98,11 @@ func main() {
 		metricsAddr                    string
 		probeAddr                      string
 		enableLeaderElection           bool
+		leaseDuration                  time.Duration
+		renewDeadline                  time.Duration
+		retryPeriod                    time.Duration
 		collectorImage                 string
+		debugImage                     string
 		targetAllocatorImage           string
 		operatorOpAMPBridgeImage       string
 		autoInstrumentationJava        string
@@ -99,16 +112,44 @@ func main() {
 		autoInstrumentationApacheHttpd string
 		autoInstrumentationGo          string
 		labelsFilter                   []string
+		excludedImages                 []string
+		excludedNamespaces             []string
+		excludedLabels                 []string
+		httpProxy                      string
+		httpsProxy                     string
+		noProxy                        string
 		webhookPort                    int
 		tlsOpt                         tlsConfig
+		syncPeriod                     time.Duration
+		maxConcurrentReconciles        int
+		rateLimiting                   controllers.RateLimiting
+		reconcilePriority              string
+		renderFiles                    []string
+		renderDir                      string
+		quotaMaxInstances              int
+		quotaMaxReplicas               int
+		quotaMaxCPURequests            string
+		quotaMaxMemoryRequests         string
+		webhookReinvocationPolicy      string
+		webhookObjectSelector          string
+		webhookNamespaceSelector       string
+		webhookConfigurationName       string
+		webhookServiceName             string
+		webhookServiceNamespace        string
+		fipsImageSuffix                string
 	)
 
 	pflag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-addr", ":8081", "The address the probe endpoint binds to.")
 	pflag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
+			"Enabling this will ensure there is only one active controller manager. All the replicas serve the webhooks.")
+	// see https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
+	pflag.DurationVar(&leaseDuration, "leader-election-lease-duration", 137*time.Second, "The duration the non-leader replicas wait before taking over the leadership from an unresponsive leader.")
+	pflag.DurationVar(&renewDeadline, "leader-election-renew-deadline", 107*time.Second, "The duration the leader retries refreshing the leadership before giving it up.")
+	pflag.DurationVar(&retryPeriod, "leader-election-retry-period", 26*time.Second, "The duration the replicas wait between attempts to acquire or renew the leadership.")
 	pflag.StringVar(&collectorImage, "collector-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&debugImage, "debug-image", "busybox:stable", "The image of the ephemeral debug container attached to the collector pods of instances with the opentelemetry.io/debug annotation.")
 	pflag.StringVar(&targetAllocatorImage, "target-allocator-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&operatorOpAMPBridgeImage, "operator-opamp-bridge-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge), "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&autoInstrumentationJava, "auto-instrumentation-java-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
@@ -118,14 +159,62 @@ func main() {
 	pflag.StringVar(&autoInstrumentationGo, "auto-instrumentation-go-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringVar(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringArrayVar(&labelsFilter, "labels", []string{}, "Labels to filter away from propagating onto deploys")
+	pflag.StringSliceVar(&excludedImages, "injection-exclude-images", nil, "Comma-separated patterns of the container images, e.g. registry.k8s.io/*, of the pods never injected with sidecars or instrumentation.")
+	pflag.StringSliceVar(&excludedNamespaces, "injection-exclude-namespaces", []string{"kube-system"}, "Comma-separated patterns of the namespaces, e.g. kube-*, whose pods are never injected with sidecars or instrumentation.")
+	pflag.StringArrayVar(&excludedLabels, "injection-exclude-labels", nil, "The label selector of the pods never injected with sidecars or instrumentation, e.g. app.kubernetes.io/part-of=vendor. Can be repeated.")
+	pflag.StringVar(&httpProxy, "http-proxy", os.Getenv("HTTP_PROXY"), "The proxy for the HTTP requests of the collectors, target allocators and instrumented containers. Defaults to the HTTP_PROXY of the operator.")
+	pflag.StringVar(&httpsProxy, "https-proxy", os.Getenv("HTTPS_PROXY"), "The proxy for the HTTPS requests of the collectors, target allocators and instrumented containers. Defaults to the HTTPS_PROXY of the operator.")
+	pflag.StringVar(&noProxy, "no-proxy", os.Getenv("NO_PROXY"), "The hosts, domains and CIDRs the collectors, target allocators and instrumented containers reach without the proxy. Defaults to the NO_PROXY of the operator.")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour, "The period after which all the watched objects are reconciled again.")
+	pflag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1, "The number of OpenTelemetryCollector instances reconciled concurrently.")
+	pflag.DurationVar(&rateLimiting.BaseDelay, "reconcile-base-delay", 5*time.Millisecond, "The delay before retrying an OpenTelemetryCollector instance that failed to reconcile, doubled on each failure, with a random jitter.")
+	pflag.DurationVar(&rateLimiting.MaxDelay, "reconcile-max-delay", 1000*time.Second, "The maximum delay between the retries of an OpenTelemetryCollector instance that fails to reconcile.")
+	pflag.Float64Var(&rateLimiting.QPS, "reconcile-qps", 10, "The overall number of retries of OpenTelemetryCollector instances per second.")
+	pflag.IntVar(&rateLimiting.Burst, "reconcile-burst", 100, "The overall number of retries of OpenTelemetryCollector instances allowed above the QPS.")
+	pflag.StringVar(&reconcilePriority, "reconcile-priority-selector", "", "The label selector of the OpenTelemetryCollector instances reconciled before the others when many are waiting, e.g. after a restart or during a resync, e.g. environment=production. Disabled when empty.")
 	pflag.StringVar(&tlsOpt.minVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.cipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
+	pflag.StringArrayVar(&renderFiles, "render", nil, "Render the objects created for the OpenTelemetryCollector resources of the file, or - for the standard input, and exit without reaching the cluster. Can be repeated.")
+	pflag.StringVar(&renderDir, "render-dir", "", "The directory the rendered objects are written to, in a <namespace>/<name>.yaml file per collector, instead of the standard output.")
+	pflag.IntVar(&quotaMaxInstances, "collector-quota-max-instances", 0, "The number of OpenTelemetryCollector instances each namespace may have. Unlimited when 0.")
+	pflag.IntVar(&quotaMaxReplicas, "collector-quota-max-replicas", 0, "The total of the replicas, or of the maximum replicas when autoscaled, of the deployment and statefulset OpenTelemetryCollector instances each namespace may have. Unlimited when 0.")
+	pflag.StringVar(&quotaMaxCPURequests, "collector-quota-max-cpu-requests", "", "The total of the CPU requests of the collector pods of the deployment and statefulset OpenTelemetryCollector instances each namespace may have, e.g. 8. Unlimited when empty.")
+	pflag.StringVar(&quotaMaxMemoryRequests, "collector-quota-max-memory-requests", "", "The total of the memory requests of the collector pods of the deployment and statefulset OpenTelemetryCollector instances each namespace may have, e.g. 16Gi. Unlimited when empty.")
+	pflag.StringVar(&webhookReinvocationPolicy, "webhook-reinvocation-policy", "", "The reinvocation policy of the pod webhook, Never or IfNeeded to call it again once the webhooks invoked after it, e.g. the one of a service mesh, changed the pod. Left as deployed when empty.")
+	pflag.StringVar(&webhookObjectSelector, "webhook-object-selector", "", "The label selector of the pods sent to the pod webhook, e.g. app.kubernetes.io/part-of!=vendor. Left as deployed when empty.")
+	pflag.StringVar(&webhookNamespaceSelector, "webhook-namespace-selector", "", "The label selector of the namespaces whose pods are sent to the pod webhook, e.g. kubernetes.io/metadata.name!=kube-system. Left as deployed when empty.")
+	pflag.StringVar(&webhookConfigurationName, "webhook-configuration-name", "opentelemetry-operator-mutating-webhook-configuration", "The name of the operator's MutatingWebhookConfiguration, whose pod webhook the webhook settings apply to.")
+	pflag.StringVar(&webhookServiceName, "webhook-service-name", "opentelemetry-operator-webhook-service", "The name of the operator's webhook service, which the pod webhook the webhook settings apply to calls.")
+	pflag.StringVar(&webhookServiceNamespace, "webhook-service-namespace", "", "The namespace of the operator's webhook service. The namespace of the operator when empty.")
+	pflag.StringVar(&fipsImageSuffix, "fips-image-suffix", "", "The suffix added in FIPS mode to the tags of the default images that aren't set by their flag, e.g. -fips, to deploy the FIPS validated builds published with this suffix. The default images are used as is when empty.")
 	pflag.Parse()
 
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	if featuregate.EnableFIPS.IsEnabled() {
+		for flagName, image := range map[string]*string{
+			"collector-image":                         &collectorImage,
+			"target-allocator-image":                  &targetAllocatorImage,
+			"operator-opamp-bridge-image":             &operatorOpAMPBridgeImage,
+			"auto-instrumentation-java-image":         &autoInstrumentationJava,
+			"auto-instrumentation-nodejs-image":       &autoInstrumentationNodeJS,
+			"auto-instrumentation-python-image":       &autoInstrumentationPython,
+			"auto-instrumentation-dotnet-image":       &autoInstrumentationDotNet,
+			"auto-instrumentation-go-image":           &autoInstrumentationGo,
+			"auto-instrumentation-apache-httpd-image": &autoInstrumentationApacheHttpd,
+		} {
+			if len(fipsImageSuffix) > 0 && !pflag.CommandLine.Changed(flagName) {
+				*image = fipsImage(*image, fipsImageSuffix)
+			}
+		}
+		if err := fipsTLSConfig(&tlsOpt); err != nil {
+			setupLog.Error(err, "the TLS settings of the webhook server aren't FIPS compliant")
+			os.Exit(1)
+		}
+	}
+
 	logger.Info("Starting the OpenTelemetry Operator",
 		"opentelemetry-operator", v.Operator,
 		"opentelemetry-collector", collectorImage,
@@ -143,21 +232,26 @@ func main() {
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
 		"labels-filter", labelsFilter,
+		"injection-exclude-images", excludedImages,
+		"injection-exclude-namespaces", excludedNamespaces,
+		"injection-exclude-labels", excludedLabels,
+		"sync-period", syncPeriod,
+		"max-concurrent-reconciles", maxConcurrentReconciles,
+		"reconcile-priority-selector", reconcilePriority,
+		"webhook-reinvocation-policy", webhookReinvocationPolicy,
+		"webhook-object-selector", webhookObjectSelector,
+		"webhook-namespace-selector", webhookNamespaceSelector,
+		"webhook-configuration-name", webhookConfigurationName,
+		"webhook-service-name", webhookServiceName,
+		"webhook-service-namespace", webhookServiceNamespace,
 	)
 
-	restConfig := ctrl.GetConfigOrDie()
-
 	// builds the operator's configuration
-	ad, err := autodetect.New(restConfig)
-	if err != nil {
-		setupLog.Error(err, "failed to setup auto-detect routine")
-		os.Exit(1)
-	}
-
-	cfg := config.New(
+	cfgOpts := []config.Option{
 		config.WithLogger(ctrl.Log.WithName("config")),
 		config.WithVersion(v),
 		config.WithCollectorImage(collectorImage),
+		config.WithDebugImage(debugImage),
 		config.WithTargetAllocatorImage(targetAllocatorImage),
 		config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
 		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
@@ -166,9 +260,28 @@ func main() {
 		config.WithAutoInstrumentationDotNetImage(autoInstrumentationDotNet),
 		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
 		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
-		config.WithAutoDetect(ad),
 		config.WithLabelFilters(labelsFilter),
-	)
+		config.WithHTTPProxy(httpProxy),
+		config.WithHTTPSProxy(httpsProxy),
+		config.WithNoProxy(noProxy),
+	}
+
+	injectionExclusions, err := config.NewInjectionExclusions(excludedImages, excludedNamespaces, excludedLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid injection exclusions")
+		os.Exit(1)
+	}
+	cfgOpts = append(cfgOpts, config.WithInjectionExclusions(injectionExclusions))
+
+	if len(renderFiles) > 0 {
+		if err := render(os.Stdout, renderFiles, renderDir, config.New(cfgOpts...)); err != nil {
+			setupLog.Error(err, "failed to render the collectors")
+			os.Exit(1)
+		}
+		return
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
 
 	watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE")
 	if found {
@@ -177,11 +290,6 @@ func main() {
 		setupLog.Info("the env var WATCH_NAMESPACE isn't set, watching all namespaces")
 	}
 
-	// see https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
-	leaseDuration := time.Second * 137
-	renewDeadline := time.Second * 107
-	retryPeriod := time.Second * 26
-
 	optionsTlSOptsFuncs := []func(*tls.Config){
 		func(config *tls.Config) { tlsConfigSetting(config, tlsOpt) },
 	}
@@ -192,6 +300,15 @@ func main() {
 		namespaces = []string{watchNamespace}
 	}
 
+	// the permissions of the operator are reviewed in the first namespace it watches, when it doesn't watch them all
+	ad, err := autodetect.New(restConfig, namespaces[0])
+	if err != nil {
+		setupLog.Error(err, "failed to setup auto-detect routine")
+		os.Exit(1)
+	}
+	cfg := config.New(append(cfgOpts, config.WithAutoDetect(ad))...)
+	otelv1alpha1.SetDefaultCollectorImage(cfg.CollectorImage())
+
 	mgrOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -201,12 +318,16 @@ func main() {
 		LeaseDuration:          &leaseDuration,
 		RenewDeadline:          &renewDeadline,
 		RetryPeriod:            &retryPeriod,
+		// hands the leadership over to another replica as soon as the leader shuts down, e.g. during rollouts
+		LeaderElectionReleaseOnCancel: true,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    webhookPort,
 			TLSOpts: optionsTlSOptsFuncs,
 		}),
 		Cache: cache.Options{
 			Namespaces: namespaces,
+			SyncPeriod: &syncPeriod,
+			ByObject:   controllers.CacheByObject(),
 		},
 	}
 
@@ -217,25 +338,43 @@ func main() {
 	}
 
 	ctx := ctrl.SetupSignalHandler()
-	err = addDependencies(ctx, mgr, cfg, v)
+	autoDetected := &atomic.Bool{}
+	err = addDependencies(ctx, mgr, cfg, v, autoDetected)
 	if err != nil {
 		setupLog.Error(err, "failed to add/run bootstrap dependencies to the controller manager")
 		os.Exit(1)
 	}
 
+	prioritySelector, err := labels.Parse(reconcilePriority)
+	if err != nil {
+		setupLog.Error(err, "invalid reconcile priority selector")
+		os.Exit(1)
+	}
+
 	if err = controllers.NewReconciler(controllers.Params{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
-		Scheme:   mgr.GetScheme(),
-		Config:   cfg,
-		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
+		Scheme:    mgr.GetScheme(),
+		Config:    cfg,
+		Recorder:  mgr.GetEventRecorderFor("opentelemetry-operator"),
+
+		RateLimiting:            rateLimiting,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		PrioritySelector:        prioritySelector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 		os.Exit(1)
 	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = (&otelv1alpha1.OpenTelemetryCollector{}).SetupWebhookWithManager(mgr); err != nil {
+		var quota otelv1alpha1.CollectorQuota
+		quota, err = collectorQuota(quotaMaxInstances, quotaMaxReplicas, quotaMaxCPURequests, quotaMaxMemoryRequests)
+		if err != nil {
+			setupLog.Error(err, "invalid collector quota")
+			os.Exit(1)
+		}
+		if err = (&otelv1alpha1.OpenTelemetryCollector{}).SetupWebhookWithQuota(mgr, quota); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpenTelemetryCollector")
 			os.Exit(1)
 		}
@@ -254,14 +393,45 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Instrumentation")
 			os.Exit(1)
 		}
+		if err = (&otelv1alpha1.TelemetryTenant{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TelemetryTenant")
+			os.Exit(1)
+		}
+		var podWebhookSettings webhookhandler.PodWebhookSettings
+		podWebhookSettings, err = webhookhandler.NewPodWebhookSettings(webhookReinvocationPolicy, webhookObjectSelector, webhookNamespaceSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid pod webhook settings")
+			os.Exit(1)
+		}
+		if !podWebhookSettings.IsEmpty() {
+			if webhookServiceNamespace == "" {
+				if webhookServiceNamespace, err = operatorNamespace(); err != nil {
+					setupLog.Error(err, "unable to find the namespace of the webhook service, set --webhook-service-namespace")
+					os.Exit(1)
+				}
+			}
+			if err = mgr.Add(&webhookhandler.PodWebhookConfigurator{
+				Client:            mgr.GetClient(),
+				Reader:            mgr.GetAPIReader(),
+				Logger:            ctrl.Log.WithName("pod-webhook-configurator"),
+				Settings:          podWebhookSettings,
+				Interval:          time.Minute,
+				ConfigurationName: webhookConfigurationName,
+				Service:           types.NamespacedName{Namespace: webhookServiceNamespace, Name: webhookServiceName},
+			}); err != nil {
+				setupLog.Error(err, "unable to set up the pod webhook configurator")
+				os.Exit(1)
+			}
+		}
 		decoder := admission.NewDecoder(mgr.GetScheme())
-		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
+		mgr.GetWebhookServer().Register(webhookhandler.PodWebhookPath, &webhook.Admission{
 			Handler: webhookhandler.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
 				[]webhookhandler.PodMutator{
 					sidecar.NewMutator(logger, cfg, mgr.GetClient()),
-					instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator")),
+					instrumentation.NewMutator(logger, cfg, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator")),
 				}),
 		})
+		mgr.GetWebhookServer().Register("/lint-collector-config", lint.NewHandler())
 	} else {
 		ctrl.Log.Info("Webhooks are disabled, operator is running an unsupported mode", "ENABLE_WEBHOOKS", "false")
 	}
@@ -275,6 +445,17 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// the replicas only receive webhook requests once they know the platform and serve the webhooks
+	if err := mgr.AddReadyzCheck("auto-detect", autoDetectChecker(autoDetected)); err != nil {
+		setupLog.Error(err, "unable to set up auto-detect ready check")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -283,11 +464,39 @@ func main() {
 	}
 }
 
-func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v version.Version) error {
+// allReplicas is a runnable every replica runs, regardless of the leader election, as the webhooks depend on it.
+type allReplicas struct {
+	manager.RunnableFunc
+}
+
+func (allReplicas) NeedLeaderElection() bool {
+	return false
+}
+
+// autoDetect runs the auto-detect mechanism for the configuration on all the replicas, and records its completion.
+func autoDetect(cfg config.Config, autoDetected *atomic.Bool) allReplicas {
+	return allReplicas{manager.RunnableFunc(func(_ context.Context) error {
+		if err := cfg.StartAutoDetect(); err != nil {
+			return err
+		}
+		autoDetected.Store(true)
+		return nil
+	})}
+}
+
+// autoDetectChecker fails until the auto-detection completed.
+func autoDetectChecker(autoDetected *atomic.Bool) healthz.Checker {
+	return func(_ *http.Request) error {
+		if !autoDetected.Load() {
+			return errors.New("the auto-detection didn't complete yet")
+		}
+		return nil
+	}
+}
+
+func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v version.Version, autoDetected *atomic.Bool) error {
 	// run the auto-detect mechanism for the configuration
-	err := mgr.Add(manager.RunnableFunc(func(_ context.Context) error {
-		return cfg.StartAutoDetect()
-	}))
+	err := mgr.Add(autoDetect(cfg, autoDetected))
 	if err != nil {
 		return fmt.Errorf("failed to start the auto-detect mechanism: %w", err)
 	}
@@ -326,6 +535,15 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 	return nil
 }
 
+// operatorNamespace returns the namespace the operator runs in, which is the one of its service account.
+func operatorNamespace() (string, error) {
+	namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
+
 // This function get the option from command argument (tlsConfig), check the validity through k8sapiflag
 // and set the config for webhook server.
 // refer to https://pkg.go.dev/k8s.io/component-base/cli/flag
@@ -344,3 +562,57 @@ func tlsConfigSetting(cfg *tls.Config, tlsOpt tlsConfig) {
 	}
 	cfg.CipherSuites = cipherSuiteIDs
 }
+
+// fipsImage returns the given image with the suffix added to its tag, the image of its FIPS validated build. The images
+// without a tag or referenced by digest are returned as is.
+func fipsImage(image, suffix string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	if strings.LastIndex(image, ":") > strings.LastIndex(image, "/") {
+		return image + suffix
+	}
+	return image
+}
+
+// fipsTLSConfig restricts the TLS settings of the webhook server to the versions and cipher suites approved by FIPS
+// 140, defaulting to the approved cipher suites when none is set.
+func fipsTLSConfig(tlsOpt *tlsConfig) error {
+	version, err := k8sapiflag.TLSVersion(tlsOpt.minVersion)
+	if err != nil {
+		return err
+	}
+	if version < tls.VersionTLS12 {
+		return fmt.Errorf("the minimum TLS version %s is lower than VersionTLS12", tlsOpt.minVersion)
+	}
+	if len(tlsOpt.cipherSuites) == 0 {
+		tlsOpt.cipherSuites = adapters.FIPSCipherSuites
+		return nil
+	}
+	for _, suite := range tlsOpt.cipherSuites {
+		if !adapters.IsFIPSCipherSuite(suite) {
+			return fmt.Errorf("the cipher suite %s isn't FIPS approved", suite)
+		}
+	}
+	return nil
+}
+
+// collectorQuota builds the quota of the collectors of each namespace out of the values of the flags.
+func collectorQuota(maxInstances, maxReplicas int, maxCPURequests, maxMemoryRequests string) (otelv1alpha1.CollectorQuota, error) {
+	quota := otelv1alpha1.CollectorQuota{
+		MaxInstances: maxInstances,
+		MaxReplicas:  int32(maxReplicas),
+		MaxRequests:  corev1.ResourceList{},
+	}
+	for name, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: maxCPURequests, corev1.ResourceMemory: maxMemoryRequests} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return quota, fmt.Errorf("the maximum %s requests %q isn't a quantity: %w", name, value, err)
+		}
+		quota.MaxRequests[name] = quantity
+	}
+	return quota, nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+func TestAutoDetectRunsOnAllReplicas(t *testing.T) {
+	var runnable manager.LeaderElectionRunnable = autoDetect(config.New(config.WithAutoDetect(&mockAutoDetect{})), &atomic.Bool{})
+	assert.False(t, runnable.NeedLeaderElection())
+}
+
+func TestAutoDetectReadiness(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		err   error
+		ready bool
+	}{
+		{
+			desc:  "auto-detection completed",
+			ready: true,
+		},
+		{
+			desc: "auto-detection failed",
+			err:  errors.New("the API server is unavailable"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			autoDetected := &atomic.Bool{}
+			cfg := config.New(config.WithAutoDetect(&mockAutoDetect{err: tt.err}))
+			check := autoDetectChecker(autoDetected)
+			req := httptest.NewRequest("GET", "/readyz", nil)
+
+			// the replica isn't ready before the auto-detection runs
+			assert.Error(t, check(req))
+
+			err := autoDetect(cfg, autoDetected).Start(context.Background())
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.ready {
+				require.NoError(t, check(req))
+			} else {
+				assert.Error(t, check(req))
+			}
+		})
+	}
+}
+
+var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
+
+type mockAutoDetect struct {
+	err error
+}
+
+func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
+	return autodetect.DefaultAutoscalingVersion, m.err
+}
+
+func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRoutesAvailability, error) {
+	return autodetect.OpenShiftRoutesNotAvailable, m.err
+}
+
+func (m *mockAutoDetect) VerticalPodAutoscalersAvailability() (autodetect.VerticalPodAutoscalersAvailability, error) {
+	return autodetect.VerticalPodAutoscalersNotAvailable, m.err
+}
+
+func (m *mockAutoDetect) IstioAvailability() (autodetect.IstioAvailability, error) {
+	return autodetect.IstioNotAvailable, m.err
+}
+
+func (m *mockAutoDetect) PodMonitorsAvailability() (autodetect.PodMonitorsAvailability, error) {
+	return autodetect.PodMonitorsNotAvailable, m.err
+}
+
+func (m *mockAutoDetect) Permissions() (autodetect.Permissions, error) {
+	return autodetect.Permissions{}, m.err
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// IstioAvailability holds the auto-detected availability of the Istio networking API.
+type IstioAvailability int
+
+const (
+	// IstioAvailable represents the networking.istio.io API is available.
+	IstioAvailable IstioAvailability = iota
+
+	// IstioNotAvailable represents the networking.istio.io API is not available.
+	IstioNotAvailable
+)
+
+func (p IstioAvailability) String() string {
+	return [...]string{"Available", "NotAvailable"}[p]
+}
@@ -16,10 +16,15 @@
 package autodetect
 
 import (
+	"context"
 	"errors"
 	"sort"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
 )
 
@@ -29,10 +34,17 @@ var _ AutoDetect = (*autoDetect)(nil)
 type AutoDetect interface {
 	OpenShiftRoutesAvailability() (OpenShiftRoutesAvailability, error)
 	HPAVersion() (AutoscalingVersion, error)
+	VerticalPodAutoscalersAvailability() (VerticalPodAutoscalersAvailability, error)
+	IstioAvailability() (IstioAvailability, error)
+	PodMonitorsAvailability() (PodMonitorsAvailability, error)
+	Permissions() (Permissions, error)
 }
 
 type autoDetect struct {
 	dcl discovery.DiscoveryInterface
+	acl authorizationv1client.AuthorizationV1Interface
+	// namespace is the namespace the rules of the operator are reviewed in.
+	namespace string
 }
 
 type AutoscalingVersion int
@@ -45,8 +57,9 @@ const (
 
 const DefaultAutoscalingVersion = AutoscalingVersionV2
 
-// New creates a new auto-detection worker, using the given client when talking to the current cluster.
-func New(restConfig *rest.Config) (AutoDetect, error) {
+// New creates a new auto-detection worker, using the given client when talking to the current cluster. The permissions
+// of the operator are reviewed in the given namespace, the default one when empty, which includes the cluster-wide ones.
+func New(restConfig *rest.Config, namespace string) (AutoDetect, error) {
 	dcl, err := discovery.NewDiscoveryClientForConfig(restConfig)
 	if err != nil {
 		// it's pretty much impossible to get into this problem, as most of the
@@ -54,9 +67,18 @@ func New(restConfig *rest.Config) (AutoDetect, error) {
 		// but let's handle this error anyway...
 		return nil, err
 	}
+	acl, err := authorizationv1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
 
 	return &autoDetect{
-		dcl: dcl,
+		dcl:       dcl,
+		acl:       acl,
+		namespace: namespace,
 	}, nil
 }
 
@@ -77,6 +99,81 @@ func (a *autoDetect) OpenShiftRoutesAvailability() (OpenShiftRoutesAvailability,
 	return OpenShiftRoutesNotAvailable, nil
 }
 
+// VerticalPodAutoscalersAvailability checks if the VerticalPodAutoscaler API is available.
+func (a *autoDetect) VerticalPodAutoscalersAvailability() (VerticalPodAutoscalersAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return VerticalPodAutoscalersNotAvailable, err
+	}
+
+	for _, apiGroup := range apiList.Groups {
+		if apiGroup.Name == "autoscaling.k8s.io" {
+			return VerticalPodAutoscalersAvailable, nil
+		}
+	}
+
+	return VerticalPodAutoscalersNotAvailable, nil
+}
+
+// IstioAvailability checks if the Istio networking API is available.
+func (a *autoDetect) IstioAvailability() (IstioAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return IstioNotAvailable, err
+	}
+
+	for _, apiGroup := range apiList.Groups {
+		if apiGroup.Name == "networking.istio.io" {
+			return IstioAvailable, nil
+		}
+	}
+
+	return IstioNotAvailable, nil
+}
+
+// PodMonitorsAvailability checks if the Prometheus operator's PodMonitor API is available. The group is also served
+// when only some of the Prometheus operator's CRDs are installed, e.g. the ServiceMonitors, so its resources are checked.
+func (a *autoDetect) PodMonitorsAvailability() (PodMonitorsAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return PodMonitorsNotAvailable, err
+	}
+
+	for _, apiGroup := range apiList.Groups {
+		if apiGroup.Name != "monitoring.coreos.com" {
+			continue
+		}
+		resources, err := a.dcl.ServerResourcesForGroupVersion("monitoring.coreos.com/v1")
+		if apierrors.IsNotFound(err) {
+			return PodMonitorsNotAvailable, nil
+		}
+		if err != nil {
+			return PodMonitorsNotAvailable, err
+		}
+		for _, resource := range resources.APIResources {
+			if resource.Name == "podmonitors" {
+				return PodMonitorsAvailable, nil
+			}
+		}
+	}
+
+	return PodMonitorsNotAvailable, nil
+}
+
+// Permissions reviews the rules granted to the operator.
+func (a *autoDetect) Permissions() (Permissions, error) {
+	review, err := a.acl.SelfSubjectRulesReviews().Create(context.Background(), &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: a.namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return Permissions{}, err
+	}
+	if review.Status.Incomplete {
+		return Permissions{}, nil
+	}
+	return NewPermissions(review.Status.ResourceRules), nil
+}
+
 func (a *autoDetect) HPAVersion() (AutoscalingVersion, error) {
 	apiList, err := a.dcl.ServerGroups()
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
@@ -59,7 +60,7 @@ func TestDetectPlatformBasedOnAvailableAPIGroups(t *testing.T) {
 		}))
 		defer server.Close()
 
-		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
 		require.NoError(t, err)
 
 		// test
@@ -71,6 +72,326 @@ func TestDetectPlatformBasedOnAvailableAPIGroups(t *testing.T) {
 	}
 }
 
+func TestDetectVerticalPodAutoscalersBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		expected     autodetect.VerticalPodAutoscalersAvailability
+	}{
+		{
+			&metav1.APIGroupList{},
+			autodetect.VerticalPodAutoscalersNotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "autoscaling",
+					},
+				},
+			},
+			autodetect.VerticalPodAutoscalersNotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "autoscaling.k8s.io",
+					},
+				},
+			},
+			autodetect.VerticalPodAutoscalersAvailable,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(tt.apiGroupList)
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
+		require.NoError(t, err)
+
+		// test
+		vpa, err := autoDetect.VerticalPodAutoscalersAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, vpa)
+	}
+}
+
+func TestDetectIstioBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		expected     autodetect.IstioAvailability
+	}{
+		{
+			&metav1.APIGroupList{},
+			autodetect.IstioNotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "security.istio.io",
+					},
+				},
+			},
+			autodetect.IstioNotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "networking.istio.io",
+					},
+				},
+			},
+			autodetect.IstioAvailable,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(tt.apiGroupList)
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
+		require.NoError(t, err)
+
+		// test
+		istio, err := autoDetect.IstioAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, istio)
+	}
+}
+
+func TestDetectPodMonitorsBasedOnAvailableAPIGroups(t *testing.T) {
+	monitoring := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "monitoring.coreos.com",
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    []metav1.APIResource
+		expected     autodetect.PodMonitorsAvailability
+	}{
+		{
+			desc:         "no monitoring group",
+			apiGroupList: &metav1.APIGroupList{},
+			expected:     autodetect.PodMonitorsNotAvailable,
+		},
+		{
+			desc:         "pod monitors",
+			apiGroupList: monitoring,
+			resources:    []metav1.APIResource{{Name: "servicemonitors"}, {Name: "podmonitors"}},
+			expected:     autodetect.PodMonitorsAvailable,
+		},
+		{
+			desc:         "service monitors only",
+			apiGroupList: monitoring,
+			resources:    []metav1.APIResource{{Name: "servicemonitors"}},
+			expected:     autodetect.PodMonitorsNotAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis/monitoring.coreos.com/v1" {
+					output, err = json.Marshal(&metav1.APIResourceList{GroupVersion: "monitoring.coreos.com/v1", APIResources: tt.resources})
+				} else {
+					output, err = json.Marshal(tt.apiGroupList)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
+			require.NoError(t, err)
+
+			// test
+			podMonitors, err := autoDetect.PodMonitorsAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, podMonitors)
+		})
+	}
+}
+
+func TestDetectPermissions(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		status   authorizationv1.SubjectRulesReviewStatus
+		expected bool
+	}{
+		{
+			desc: "allowed",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{APIGroups: []string{"monitoring.coreos.com"}, Resources: []string{"podmonitors"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+				},
+			},
+			expected: true,
+		},
+		{
+			desc: "wildcards",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+				},
+			},
+			expected: true,
+		},
+		{
+			desc: "missing verb",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{APIGroups: []string{"monitoring.coreos.com"}, Resources: []string{"podmonitors"}, Verbs: []string{"get", "list", "watch"}},
+				},
+			},
+			expected: false,
+		},
+		{
+			desc: "restricted to some objects",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{APIGroups: []string{"monitoring.coreos.com"}, Resources: []string{"podmonitors"}, Verbs: []string{"*"}, ResourceNames: []string{"my-podmonitor"}},
+				},
+			},
+			expected: false,
+		},
+		{
+			desc:     "incomplete",
+			status:   authorizationv1.SubjectRulesReviewStatus{Incomplete: true},
+			expected: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var namespace string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				review := &authorizationv1.SelfSubjectRulesReview{}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(review))
+				namespace = review.Spec.Namespace
+				review.Status = tt.status
+				output, err := json.Marshal(review)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
+			require.NoError(t, err)
+
+			// test
+			permissions, err := autoDetect.Permissions()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, "default", namespace)
+			assert.Equal(t, tt.expected, permissions.Allowed("monitoring.coreos.com", "podmonitors"))
+		})
+	}
+}
+
+func TestDetectHPAVersionBasedOnAvailableAPIGroups(t *testing.T) {
+	autoscaling := func(versions ...string) *metav1.APIGroupList {
+		group := metav1.APIGroup{Name: "autoscaling"}
+		for _, version := range versions {
+			group.Versions = append(group.Versions, metav1.GroupVersionForDiscovery{GroupVersion: "autoscaling/" + version, Version: version})
+		}
+		return &metav1.APIGroupList{Groups: []metav1.APIGroup{group}}
+	}
+
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		expected     autodetect.AutoscalingVersion
+		expectedErr  bool
+	}{
+		{
+			desc:         "kubernetes 1.21",
+			apiGroupList: autoscaling("v1", "v2beta1", "v2beta2"),
+			expected:     autodetect.AutoscalingVersionV2Beta2,
+		},
+		{
+			desc:         "kubernetes 1.23",
+			apiGroupList: autoscaling("v1", "v2", "v2beta1", "v2beta2"),
+			expected:     autodetect.AutoscalingVersionV2,
+		},
+		{
+			desc:         "kubernetes 1.26",
+			apiGroupList: autoscaling("v2", "v1"),
+			expected:     autodetect.AutoscalingVersionV2,
+		},
+		{
+			desc:         "no supported version",
+			apiGroupList: autoscaling("v1"),
+			expected:     autodetect.AutoscalingVersionUnknown,
+			expectedErr:  true,
+		},
+		{
+			desc:         "no autoscaling group",
+			apiGroupList: &metav1.APIGroupList{},
+			expected:     autodetect.AutoscalingVersionUnknown,
+			expectedErr:  true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				output, err := json.Marshal(tt.apiGroupList)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, "")
+			require.NoError(t, err)
+
+			// test
+			hpaVersion, err := autoDetect.HPAVersion()
+
+			// verify
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, hpaVersion)
+		})
+	}
+}
+
 func TestAutoscalingVersionToString(t *testing.T) {
 	assert.Equal(t, "v2", autodetect.AutoscalingVersionV2.String())
 	assert.Equal(t, "v2beta2", autodetect.AutoscalingVersionV2Beta2.String())
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// managedVerbs are the verbs the operator needs on the kinds of objects it generates.
+var managedVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// Permissions holds the auto-detected rules granted to the operator, to disable the features it isn't allowed to
+// manage instead of failing to reconcile them. The zero value allows everything, as when the rules are unknown.
+type Permissions struct {
+	rules []authorizationv1.ResourceRule
+	// reviewed is false when the rules couldn't be listed exhaustively, e.g. with a webhook authorizer.
+	reviewed bool
+}
+
+// NewPermissions returns the permissions granted by the given rules.
+func NewPermissions(rules []authorizationv1.ResourceRule) Permissions {
+	return Permissions{rules: rules, reviewed: true}
+}
+
+// Allowed returns whether the operator may get, list, watch, create, update, patch and delete the given resource.
+func (p Permissions) Allowed(group, resource string) bool {
+	if !p.reviewed {
+		return true
+	}
+	for _, verb := range managedVerbs {
+		if !p.allowed(group, resource, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Permissions) allowed(group, resource, verb string) bool {
+	for _, rule := range p.rules {
+		// the rules restricted to some objects don't allow to list or create the others
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if matches(rule.APIGroups, group) && matches(rule.Resources, resource) && matches(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
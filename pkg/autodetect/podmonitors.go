@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// PodMonitorsAvailability holds the auto-detected availability of the Prometheus operator's PodMonitor API.
+type PodMonitorsAvailability int
+
+const (
+	// PodMonitorsAvailable represents the monitoring.coreos.com API is available.
+	PodMonitorsAvailable PodMonitorsAvailability = iota
+
+	// PodMonitorsNotAvailable represents the monitoring.coreos.com API is not available.
+	PodMonitorsNotAvailable
+)
+
+func (p PodMonitorsAvailability) String() string {
+	return [...]string{"Available", "NotAvailable"}[p]
+}
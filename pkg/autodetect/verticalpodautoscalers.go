@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+// VerticalPodAutoscalersAvailability holds the auto-detected availability of the VerticalPodAutoscaler API.
+type VerticalPodAutoscalersAvailability int
+
+const (
+	// VerticalPodAutoscalersAvailable represents the autoscaling.k8s.io API is available.
+	VerticalPodAutoscalersAvailable VerticalPodAutoscalersAvailability = iota
+
+	// VerticalPodAutoscalersNotAvailable represents the autoscaling.k8s.io API is not available.
+	VerticalPodAutoscalersNotAvailable
+)
+
+func (p VerticalPodAutoscalersAvailability) String() string {
+	return [...]string{"Available", "NotAvailable"}[p]
+}
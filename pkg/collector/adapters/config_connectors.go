@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// connectorSignals are the pairs of signals the known connectors connect, from the signal of the pipelines they
+// export from to the signal of the pipelines they receive in, by connector type.
+var connectorSignals = map[string]map[string][]string{
+	"forward": {
+		"traces":   {"traces"},
+		"metrics":  {"metrics"},
+		"logs":     {"logs"},
+		"profiles": {"profiles"},
+	},
+	"count": {
+		"traces":  {"metrics"},
+		"metrics": {"metrics"},
+		"logs":    {"metrics"},
+	},
+	"spanmetrics": {
+		"traces": {"metrics"},
+	},
+	"servicegraph": {
+		"traces": {"metrics"},
+	},
+}
+
+// connectorPipelines are the pipelines a connector is an exporter and a receiver of.
+type connectorPipelines struct {
+	exporterOf []string
+	receiverOf []string
+}
+
+// ConfigToConnectorErrors checks the wiring of the connectors of the configuration as the collector does on start: each
+// connector of a pipeline must be an exporter of at least one pipeline and a receiver of at least one other, and each
+// pipeline it's an exporter of must have a pipeline it's a receiver of whose signal it can connect to, and conversely.
+// The signals are only checked for the known connectors. It returns the errors found, in order.
+func ConfigToConnectorErrors(config map[string]interface{}) []string {
+	connectors, _ := config["connectors"].(map[string]interface{})
+	if len(connectors) == 0 {
+		return nil
+	}
+	service, _ := config["service"].(map[string]interface{})
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+
+	var errs []string
+	usages := map[string]*connectorPipelines{}
+	for _, id := range sortedKeys(pipelines) {
+		pipeline, _ := pipelines[id].(map[string]interface{})
+		exporters := componentNames(pipeline["exporters"])
+		exported := map[string]bool{}
+		for _, name := range exporters {
+			exported[name] = true
+		}
+		for _, name := range componentNames(pipeline["receivers"]) {
+			if _, ok := connectors[name]; !ok {
+				continue
+			}
+			if exported[name] {
+				errs = append(errs, fmt.Sprintf("the connector %s is both a receiver and an exporter of the %s pipeline", name, id))
+			}
+			connector := usage(usages, name)
+			connector.receiverOf = append(connector.receiverOf, id)
+		}
+		for _, name := range exporters {
+			if _, ok := connectors[name]; ok {
+				connector := usage(usages, name)
+				connector.exporterOf = append(connector.exporterOf, id)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(usages) {
+		pipelines := usages[name]
+		switch {
+		case len(pipelines.receiverOf) == 0:
+			errs = append(errs, fmt.Sprintf("the connector %s is an exporter of %s, but isn't a receiver of any pipeline", name, pipelineList(pipelines.exporterOf)))
+			continue
+		case len(pipelines.exporterOf) == 0:
+			errs = append(errs, fmt.Sprintf("the connector %s is a receiver of %s, but isn't an exporter of any pipeline", name, pipelineList(pipelines.receiverOf)))
+			continue
+		}
+
+		componentType, _, _ := strings.Cut(name, "/")
+		signals, known := connectorSignals[componentType]
+		if !known {
+			continue
+		}
+		for _, exporterOf := range pipelines.exporterOf {
+			if !connects(signals, pipelineSignal(exporterOf), pipelines.receiverOf, false) {
+				errs = append(errs, fmt.Sprintf("the connector %s is an exporter of the %s pipeline, but isn't a receiver of any pipeline it can connect %s to", name, exporterOf, pipelineSignal(exporterOf)))
+			}
+		}
+		for _, receiverOf := range pipelines.receiverOf {
+			if !connects(signals, pipelineSignal(receiverOf), pipelines.exporterOf, true) {
+				errs = append(errs, fmt.Sprintf("the connector %s is a receiver of the %s pipeline, but isn't an exporter of any pipeline it can connect to %s", name, receiverOf, pipelineSignal(receiverOf)))
+			}
+		}
+	}
+	return errs
+}
+
+// connects tells whether a connector connects the given signal to the signal of one of the given pipelines, or the
+// signal of one of the pipelines to the given signal when reversed.
+func connects(signals map[string][]string, signal string, pipelines []string, reversed bool) bool {
+	for _, pipeline := range pipelines {
+		from, to := signal, pipelineSignal(pipeline)
+		if reversed {
+			from, to = to, from
+		}
+		for _, supported := range signals[from] {
+			if supported == to {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pipelineList returns the given pipelines as a phrase, e.g. "the traces and traces/backend pipelines".
+func pipelineList(ids []string) string {
+	if len(ids) == 1 {
+		return fmt.Sprintf("the %s pipeline", ids[0])
+	}
+	return fmt.Sprintf("the %s and %s pipelines", strings.Join(ids[:len(ids)-1], ", "), ids[len(ids)-1])
+}
+
+// pipelineSignal returns the signal of a pipeline, which is its type, e.g. traces for the traces/backend pipeline.
+func pipelineSignal(id string) string {
+	signal, _, _ := strings.Cut(id, "/")
+	return signal
+}
+
+func usage(usages map[string]*connectorPipelines, name string) *connectorPipelines {
+	if usages[name] == nil {
+		usages[name] = &connectorPipelines{}
+	}
+	return usages[name]
+}
+
+// componentNames returns the names of a list of components of a pipeline.
+func componentNames(list interface{}) []string {
+	items, _ := list.([]interface{})
+	var names []string
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToConnectorErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "no connectors",
+			config: `service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+		},
+		{
+			desc: "connected pipelines",
+			config: `connectors:
+  spanmetrics:
+  count:
+  forward/logs:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics, count]
+    logs:
+      receivers: [otlp]
+      exporters: [forward/logs, count]
+    logs/backend:
+      receivers: [forward/logs]
+      exporters: [otlp]
+    metrics:
+      receivers: [spanmetrics, count]
+      exporters: [prometheusremotewrite]
+`,
+		},
+		{
+			desc: "forwarded profiles",
+			config: `connectors:
+  forward/profiles:
+service:
+  pipelines:
+    profiles:
+      receivers: [otlp]
+      exporters: [forward/profiles]
+    profiles/backend:
+      receivers: [forward/profiles]
+      exporters: [otlp]
+`,
+		},
+		{
+			desc: "unused connector",
+			config: `connectors:
+  forward:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+		},
+		{
+			desc: "connector only exported to",
+			config: `connectors:
+  spanmetrics:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+    traces/backend:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+`,
+			expected: []string{"the connector spanmetrics is an exporter of the traces and traces/backend pipelines, but isn't a receiver of any pipeline"},
+		},
+		{
+			desc: "connector only received from",
+			config: `connectors:
+  forward:
+service:
+  pipelines:
+    logs:
+      receivers: [forward]
+      exporters: [otlp]
+`,
+			expected: []string{"the connector forward is a receiver of the logs pipeline, but isn't an exporter of any pipeline"},
+		},
+		{
+			desc: "connector of a single pipeline",
+			config: `connectors:
+  forward:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, forward]
+      exporters: [forward]
+`,
+			expected: []string{"the connector forward is both a receiver and an exporter of the traces pipeline"},
+		},
+		{
+			desc: "incompatible signals",
+			config: `connectors:
+  spanmetrics:
+  forward:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics, forward]
+    metrics:
+      receivers: [forward]
+      exporters: [otlp]
+    logs:
+      receivers: [spanmetrics]
+      exporters: [otlp]
+`,
+			expected: []string{
+				"the connector forward is an exporter of the traces pipeline, but isn't a receiver of any pipeline it can connect traces to",
+				"the connector forward is a receiver of the metrics pipeline, but isn't an exporter of any pipeline it can connect to metrics",
+				"the connector spanmetrics is an exporter of the traces pipeline, but isn't a receiver of any pipeline it can connect traces to",
+				"the connector spanmetrics is a receiver of the logs pipeline, but isn't an exporter of any pipeline it can connect to logs",
+			},
+		},
+		{
+			desc: "unknown connector type",
+			config: `connectors:
+  routing:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing]
+    logs:
+      receivers: [routing]
+      exporters: [otlp]
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, adapters.ConfigToConnectorErrors(config))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"net"
+)
+
+// ConfigToLoopbackEndpoints returns the settings of the receivers, or of their protocols, whose endpoint listens on
+// localhost or a loopback address, by path of the endpoint, e.g. receivers.otlp.protocols.grpc.endpoint. The
+// returned settings are the ones of the configuration, so that their endpoint can be rewritten.
+func ConfigToLoopbackEndpoints(config map[string]interface{}) map[string]map[string]interface{} {
+	receivers, _ := config["receivers"].(map[string]interface{})
+
+	endpoints := map[string]map[string]interface{}{}
+	for name, r := range receivers {
+		receiver, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if LoopbackEndpoint(receiver["endpoint"]) {
+			endpoints[fmt.Sprintf("receivers.%s.endpoint", name)] = receiver
+		}
+		protocols, _ := receiver["protocols"].(map[string]interface{})
+		for protocol, p := range protocols {
+			settings, ok := p.(map[string]interface{})
+			if ok && LoopbackEndpoint(settings["endpoint"]) {
+				endpoints[fmt.Sprintf("receivers.%s.protocols.%s.endpoint", name, protocol)] = settings
+			}
+		}
+	}
+	return endpoints
+}
+
+// LoopbackEndpoint returns whether the given endpoint is a host and port whose host is localhost or a loopback
+// address, e.g. localhost:4317 or [::1]:4317.
+func LoopbackEndpoint(endpoint interface{}) bool {
+	s, ok := endpoint.(string)
+	if !ok {
+		return false
+	}
+	host, _, err := net.SplitHostPort(s)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
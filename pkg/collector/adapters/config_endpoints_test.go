@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToLoopbackEndpoints(t *testing.T) {
+	config, err := adapters.ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+      http:
+        endpoint: 0.0.0.0:4318
+  zipkin:
+    endpoint: "[::1]:9411"
+  jaeger:
+    protocols:
+      thrift_compact:
+        endpoint: 127.0.0.1:6831
+      grpc:
+  prometheus:
+    config:
+      scrape_configs: []
+`)
+	require.NoError(t, err)
+
+	endpoints := adapters.ConfigToLoopbackEndpoints(config)
+	assert.Len(t, endpoints, 3)
+	assert.Equal(t, "localhost:4317", endpoints["receivers.otlp.protocols.grpc.endpoint"]["endpoint"])
+	assert.Equal(t, "127.0.0.1:6831", endpoints["receivers.jaeger.protocols.thrift_compact.endpoint"]["endpoint"])
+	assert.Equal(t, "[::1]:9411", endpoints["receivers.zipkin.endpoint"]["endpoint"])
+}
+
+func TestLoopbackEndpoint(t *testing.T) {
+	for endpoint, expected := range map[interface{}]bool{
+		"localhost:4317":   true,
+		"127.0.0.1:4317":   true,
+		"127.0.1.1:4317":   true,
+		"[::1]:4317":       true,
+		"0.0.0.0:4317":     false,
+		"[::]:4317":        false,
+		"10.0.0.1:4317":    false,
+		"${POD_IP}:4317":   false,
+		"localhost":        false,
+		"otel.example.com": false,
+		nil:                false,
+		4317:               false,
+	} {
+		assert.Equal(t, expected, adapters.LoopbackEndpoint(endpoint), "%v", endpoint)
+	}
+}
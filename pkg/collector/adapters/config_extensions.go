@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// extensionReference is a setting of a component naming an extension.
+type extensionReference struct {
+	setting   string
+	extension string
+}
+
+// ConfigToExtensionErrors checks that the extensions the enabled receivers and exporters of the configuration
+// authenticate with, in their auth.authenticator settings, or store their state in, in their storage settings, are
+// declared in the extensions and enabled in service.extensions, as the collector refuses to start otherwise. It returns
+// the errors found, in order.
+func ConfigToExtensionErrors(config map[string]interface{}) []string {
+	extensions, _ := config["extensions"].(map[string]interface{})
+	service, _ := config["service"].(map[string]interface{})
+	enabled := map[string]bool{}
+	for _, name := range componentNames(service["extensions"]) {
+		enabled[name] = true
+	}
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+
+	var errs []string
+	for _, kind := range []string{"receivers", "exporters"} {
+		components, _ := config[kind].(map[string]interface{})
+		used := map[string]bool{}
+		for _, pipeline := range pipelines {
+			pipelineCfg, _ := pipeline.(map[string]interface{})
+			for _, name := range componentNames(pipelineCfg[kind]) {
+				used[name] = true
+			}
+		}
+		for _, name := range sortedKeys(used) {
+			component, ok := components[name]
+			if !ok {
+				continue
+			}
+			for _, reference := range extensionReferences("", component) {
+				if _, ok := extensions[reference.extension]; !ok {
+					errs = append(errs, fmt.Sprintf("the %s %s references the %s extension in %s, which isn't declared in extensions", strings.TrimSuffix(kind, "s"), name, reference.extension, reference.setting))
+				} else if !enabled[reference.extension] {
+					errs = append(errs, fmt.Sprintf("the %s %s references the %s extension in %s, which isn't enabled in service.extensions", strings.TrimSuffix(kind, "s"), name, reference.extension, reference.setting))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// extensionReferences returns the auth.authenticator and storage settings of the given component settings, in order.
+// The settings set from environment variables are skipped.
+func extensionReferences(path string, value interface{}) []extensionReference {
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var references []extensionReference
+	for _, key := range sortedKeys(settings) {
+		setting := key
+		if len(path) > 0 {
+			setting = path + "." + key
+		}
+		if name, ok := settings[key].(string); ok {
+			if (key == "storage" || setting == "auth.authenticator" || strings.HasSuffix(setting, ".auth.authenticator")) && !strings.Contains(name, "${") && len(name) > 0 {
+				references = append(references, extensionReference{setting: setting, extension: name})
+			}
+			continue
+		}
+		references = append(references, extensionReferences(setting, settings[key])...)
+	}
+	return references
+}
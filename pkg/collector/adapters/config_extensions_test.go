@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToExtensionErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "declared and enabled extensions",
+			config: `extensions:
+  basicauth/server:
+  oauth2client:
+  file_storage:
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        auth:
+          authenticator: basicauth/server
+  filelog:
+    storage: file_storage
+exporters:
+  otlp:
+    auth:
+      authenticator: oauth2client
+    sending_queue:
+      storage: file_storage
+service:
+  extensions: [basicauth/server, oauth2client, file_storage]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+    logs:
+      receivers: [filelog]
+      exporters: [otlp]
+`,
+		},
+		{
+			desc: "undeclared extensions",
+			config: `receivers:
+  otlp:
+    protocols:
+      http:
+        auth:
+          authenticator: basicauth/server
+exporters:
+  otlp:
+    sending_queue:
+      storage: file_storage
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+			expected: []string{
+				"the receiver otlp references the basicauth/server extension in protocols.http.auth.authenticator, which isn't declared in extensions",
+				"the exporter otlp references the file_storage extension in sending_queue.storage, which isn't declared in extensions",
+			},
+		},
+		{
+			desc: "extensions not enabled",
+			config: `extensions:
+  health_check:
+  oauth2client:
+exporters:
+  otlphttp:
+    auth:
+      authenticator: oauth2client
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlphttp]
+`,
+			expected: []string{
+				"the exporter otlphttp references the oauth2client extension in auth.authenticator, which isn't enabled in service.extensions",
+			},
+		},
+		{
+			desc: "components outside of the pipelines and environment variables",
+			config: `exporters:
+  otlp:
+    auth:
+      authenticator: oauth2client
+  otlphttp:
+    auth:
+      authenticator: ${env:AUTHENTICATOR}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlphttp]
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, adapters.ConfigToExtensionErrors(config))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FIPSMinTLSVersion is the lowest TLS version approved by FIPS 140, which the collectors default to in FIPS mode.
+const FIPSMinTLSVersion = "1.2"
+
+// FIPSCipherSuites are the FIPS 140 approved TLS 1.2 cipher suites with forward secrecy, which the collectors default
+// to in FIPS mode.
+var FIPSCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// fipsApprovedCipherSuites are the cipher suites the configurations may set in FIPS mode, the TLS 1.3 ones included.
+var fipsApprovedCipherSuites = map[string]bool{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": true,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": true,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   true,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   true,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         true,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         true,
+	"TLS_AES_128_GCM_SHA256":                  true,
+	"TLS_AES_256_GCM_SHA384":                  true,
+}
+
+// fipsRejectedTLSVersions are the TLS versions FIPS 140 doesn't approve.
+var fipsRejectedTLSVersions = map[string]bool{
+	"1.0": true,
+	"1.1": true,
+}
+
+// fipsTLSSections are the sections of the configuration whose components have tls settings.
+var fipsTLSSections = []string{"receivers", "exporters", "extensions"}
+
+// ApplyFIPSTLSDefaults sets the FIPS minimum TLS version and, when the collector supports them, cipher suites in the
+// tls settings of the receivers, exporters and extensions of the given configuration that don't set them.
+func ApplyFIPSTLSDefaults(config map[string]interface{}, cipherSuites bool) {
+	walkTLSSettings(config, func(_ string, tls map[string]interface{}) {
+		if _, ok := tls["min_version"]; !ok {
+			tls["min_version"] = FIPSMinTLSVersion
+		}
+		if _, ok := tls["cipher_suites"]; !ok && cipherSuites {
+			suites := make([]interface{}, len(FIPSCipherSuites))
+			for i, suite := range FIPSCipherSuites {
+				suites[i] = suite
+			}
+			tls["cipher_suites"] = suites
+		}
+	})
+}
+
+// ConfigToFIPSErrors checks the tls settings of the receivers, exporters and extensions of the given configuration,
+// which must not allow the TLS versions or set the cipher suites FIPS 140 doesn't approve. It returns the errors
+// found, in order.
+func ConfigToFIPSErrors(config map[string]interface{}) []string {
+	var errs []string
+	walkTLSSettings(config, func(path string, tls map[string]interface{}) {
+		for _, key := range []string{"min_version", "max_version"} {
+			if version := fmt.Sprint(tls[key]); fipsRejectedTLSVersions[version] {
+				errs = append(errs, fmt.Sprintf("%s.%s is %s, FIPS requires TLS %s or later", path, key, version, FIPSMinTLSVersion))
+			}
+		}
+		suites, _ := tls["cipher_suites"].([]interface{})
+		for _, suite := range suites {
+			if !IsFIPSCipherSuite(fmt.Sprint(suite)) {
+				errs = append(errs, fmt.Sprintf("%s.cipher_suites has %v, which isn't FIPS approved", path, suite))
+			}
+		}
+	})
+	return errs
+}
+
+// IsFIPSCipherSuite tells whether the given cipher suite is approved by FIPS 140.
+func IsFIPSCipherSuite(suite string) bool {
+	return fipsApprovedCipherSuites[suite]
+}
+
+// walkTLSSettings calls the given function with the tls settings found in the components of the sections of the
+// configuration that have them, and their path, in order.
+func walkTLSSettings(config map[string]interface{}, fn func(path string, tls map[string]interface{})) {
+	for _, name := range fipsTLSSections {
+		if section, ok := config[name].(map[string]interface{}); ok {
+			walkTLSMap(name, section, fn)
+		}
+	}
+}
+
+func walkTLSMap(path string, m map[string]interface{}, fn func(path string, tls map[string]interface{})) {
+	for _, key := range sortedKeys(m) {
+		child, ok := m[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		childPath := strings.Join([]string{path, key}, ".")
+		if key == "tls" {
+			fn(childPath, child)
+			continue
+		}
+		walkTLSMap(childPath, child, fn)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestApplyFIPSTLSDefaults(t *testing.T) {
+	config, err := adapters.ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+          key_file: /certs/tls.key
+      http:
+        tls:
+          min_version: "1.3"
+          cipher_suites: [TLS_AES_128_GCM_SHA256]
+exporters:
+  otlp:
+    endpoint: collector:4317
+  otlphttp:
+    tls:
+      insecure: true
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`)
+	require.NoError(t, err)
+
+	adapters.ApplyFIPSTLSDefaults(config, true)
+
+	grpc := config["receivers"].(map[string]interface{})["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["tls"]
+	assert.Equal(t, map[string]interface{}{
+		"cert_file":     "/certs/tls.crt",
+		"key_file":      "/certs/tls.key",
+		"min_version":   adapters.FIPSMinTLSVersion,
+		"cipher_suites": []interface{}{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}, grpc)
+
+	http := config["receivers"].(map[string]interface{})["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["http"].(map[string]interface{})["tls"]
+	assert.Equal(t, map[string]interface{}{
+		"min_version":   "1.3",
+		"cipher_suites": []interface{}{"TLS_AES_128_GCM_SHA256"},
+	}, http)
+
+	otlphttp := config["exporters"].(map[string]interface{})["otlphttp"].(map[string]interface{})["tls"].(map[string]interface{})
+	assert.Equal(t, adapters.FIPSMinTLSVersion, otlphttp["min_version"])
+	assert.NotContains(t, config["exporters"].(map[string]interface{})["otlp"], "tls")
+}
+
+func TestConfigToFIPSErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "compliant",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          min_version: "1.2"
+          cipher_suites: [TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_AES_256_GCM_SHA384]
+exporters:
+  otlp:
+    tls:
+      min_version: "1.3"
+`,
+		},
+		{
+			desc: "rejected versions and cipher suites",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          min_version: "1.0"
+          cipher_suites: [TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256]
+exporters:
+  otlp:
+    tls:
+      max_version: "1.1"
+extensions:
+  oauth2client:
+    tls:
+      cipher_suites: [TLS_RSA_WITH_3DES_EDE_CBC_SHA]
+`,
+			expected: []string{
+				"receivers.otlp.protocols.grpc.tls.min_version is 1.0, FIPS requires TLS 1.2 or later",
+				"receivers.otlp.protocols.grpc.tls.cipher_suites has TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256, which isn't FIPS approved",
+				"exporters.otlp.tls.max_version is 1.1, FIPS requires TLS 1.2 or later",
+				"extensions.oauth2client.tls.cipher_suites has TLS_RSA_WITH_3DES_EDE_CBC_SHA, which isn't FIPS approved",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, adapters.ConfigToFIPSErrors(config))
+		})
+	}
+}
+
+func TestApplyFIPSTLSDefaultsWithoutCipherSuites(t *testing.T) {
+	config, err := adapters.ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+          key_file: /certs/tls.key
+`)
+	require.NoError(t, err)
+
+	adapters.ApplyFIPSTLSDefaults(config, false)
+
+	grpc := config["receivers"].(map[string]interface{})["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["tls"]
+	assert.Equal(t, map[string]interface{}{
+		"cert_file":   "/certs/tls.crt",
+		"key_file":    "/certs/tls.key",
+		"min_version": adapters.FIPSMinTLSVersion,
+	}, grpc)
+}
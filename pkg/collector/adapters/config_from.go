@@ -17,6 +17,7 @@ package adapters
 
 import (
 	"errors"
+	"fmt"
 
 	"gopkg.in/yaml.v2"
 )
@@ -28,11 +29,31 @@ var (
 
 // ConfigFromString extracts a configuration map from the given string.
 // If the given string isn't a valid YAML, ErrInvalidYAML is returned.
-func ConfigFromString(configStr string) (map[interface{}]interface{}, error) {
+func ConfigFromString(configStr string) (map[string]interface{}, error) {
 	config := make(map[interface{}]interface{})
 	if err := yaml.Unmarshal([]byte(configStr), &config); err != nil {
 		return nil, ErrInvalidYAML
 	}
 
-	return config, nil
+	return normalize(config).(map[string]interface{}), nil
+}
+
+// normalize converts the maps decoded from YAML, whose keys can be of any type, into maps with string keys, so that
+// the configuration can be navigated without asserting the type of its keys.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = normalize(item)
+		}
+		return normalized
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return v
+	}
 }
@@ -37,3 +37,44 @@ func TestEmptyString(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, res, 0)
 }
+
+func TestConfigKeysAreStrings(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+processors:
+  attributes:
+    actions:
+      - key: 1
+        action: delete
+  filter:
+    metrics:
+      include:
+        2: metric
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`
+
+	// test
+	config, err := adapters.ConfigFromString(configStr)
+
+	// verify
+	assert.NoError(t, err)
+	receivers, ok := config["receivers"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Contains(t, receivers, "otlp")
+
+	processors := config["processors"].(map[string]interface{})
+	actions := processors["attributes"].(map[string]interface{})["actions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"key": 1, "action": "delete"}, actions[0])
+
+	include := processors["filter"].(map[string]interface{})["metrics"].(map[string]interface{})["include"]
+	assert.Equal(t, map[string]interface{}{"2": "metric"}, include)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigToLegacyReceivers returns the paths of the deprecated receivers and receiver settings of the configuration, in
+// order: the opencensus receivers and the remote_sampling of the jaeger receivers, e.g. receivers.opencensus/legacy.
+func ConfigToLegacyReceivers(config map[string]interface{}) []string {
+	receivers, _ := config["receivers"].(map[string]interface{})
+
+	var legacy []string
+	for _, name := range sortedKeys(receivers) {
+		receiverType, _, _ := strings.Cut(name, "/")
+		switch receiverType {
+		case "opencensus":
+			legacy = append(legacy, fmt.Sprintf("receivers.%s", name))
+		case "jaeger":
+			receiver, _ := receivers[name].(map[string]interface{})
+			if _, ok := receiver["remote_sampling"]; ok {
+				legacy = append(legacy, fmt.Sprintf("receivers.%s.remote_sampling", name))
+			}
+		}
+	}
+	return legacy
+}
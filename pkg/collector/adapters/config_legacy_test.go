@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToLegacyReceivers(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "legacy receivers",
+			config: `receivers:
+  otlp:
+  opencensus:
+  opencensus/legacy:
+    endpoint: 0.0.0.0:55679
+  jaeger:
+    protocols:
+      grpc:
+    remote_sampling:
+      host_endpoint: 0.0.0.0:5778
+  jaeger/thrift:
+    protocols:
+      thrift_http:
+`,
+			expected: []string{"receivers.jaeger.remote_sampling", "receivers.opencensus", "receivers.opencensus/legacy"},
+		},
+		{
+			desc: "no legacy receivers",
+			config: `receivers:
+  otlp:
+  jaeger:
+    protocols:
+      grpc:
+`,
+		},
+		{
+			desc:   "no receivers",
+			config: `exporters:`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, adapters.ConfigToLegacyReceivers(config))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+// MergeConfigs returns the base configuration with the given one merged into it. The maps are merged recursively,
+// while the other values of the given configuration, including the lists, replace the base ones. Neither
+// configuration is modified.
+func MergeConfigs(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overlayMap, overlayIsMap := value.(map[string]interface{})
+		if baseIsMap && overlayIsMap {
+			merged[key] = MergeConfigs(baseMap, overlayMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
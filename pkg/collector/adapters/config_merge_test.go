@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	// prepare
+	base, err := adapters.ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  memory_limiter:
+    limit_mib: 400
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`)
+	require.NoError(t, err)
+	overlay, err := adapters.ConfigFromString(`receivers:
+  prometheus/dcgm:
+    config:
+      scrape_configs:
+      - job_name: dcgm
+processors:
+  memory_limiter:
+    limit_mib: 200
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp, prometheus/dcgm]
+`)
+	require.NoError(t, err)
+	expected, err := adapters.ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+  prometheus/dcgm:
+    config:
+      scrape_configs:
+      - job_name: dcgm
+processors:
+  memory_limiter:
+    limit_mib: 200
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp, prometheus/dcgm]
+      exporters: [logging]
+`)
+	require.NoError(t, err)
+
+	// test
+	merged := adapters.MergeConfigs(base, overlay)
+
+	// verify
+	assert.Equal(t, expected, merged)
+	assert.NotContains(t, base["receivers"], "prometheus/dcgm")
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+// ProfilesFeatureGate is the collector feature gate the pipelines of the profiles signal require.
+const ProfilesFeatureGate = "service.profilesSupport"
+
+// ConfigHasProfiles returns whether the configuration has pipelines of the profiles signal, e.g. profiles/pyroscope.
+func ConfigHasProfiles(config map[string]interface{}) bool {
+	service, _ := config["service"].(map[string]interface{})
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+	for id := range pipelines {
+		if pipelineSignal(id) == "profiles" {
+			return true
+		}
+	}
+	return false
+}
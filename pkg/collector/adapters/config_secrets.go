@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// secretReferenceRegex matches the references to the keys of secrets in a configuration,
+	// e.g. ${secret:namespace/name/key}.
+	secretReferenceRegex = regexp.MustCompile(`\$\{secret:([^/}]+)/([^/}]+)/([^/}]+)\}`)
+
+	envVarInvalidCharsRegex = regexp.MustCompile(`[^A-Za-z0-9]`)
+)
+
+// SecretReference references the key of a secret from a configuration.
+type SecretReference struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// String returns the reference as written in the configuration, without the enclosing ${secret:}.
+func (r SecretReference) String() string {
+	return fmt.Sprintf("%s/%s/%s", r.Namespace, r.Name, r.Key)
+}
+
+// EnvVar returns the name of the environment variable the referenced value is given to the collector with.
+func (r SecretReference) EnvVar() string {
+	return strings.ToUpper(fmt.Sprintf("OTEL_SECRET_%s_%s",
+		envVarInvalidCharsRegex.ReplaceAllString(r.Name, "_"),
+		envVarInvalidCharsRegex.ReplaceAllString(r.Key, "_"),
+	))
+}
+
+// ConfigToSecretReferences returns the secret references of the given configuration, sorted and without duplicates.
+func ConfigToSecretReferences(config string) []SecretReference {
+	seen := map[SecretReference]bool{}
+	var references []SecretReference
+	for _, match := range secretReferenceRegex.FindAllStringSubmatch(config, -1) {
+		reference := SecretReference{Namespace: match[1], Name: match[2], Key: match[3]}
+		if seen[reference] {
+			continue
+		}
+		seen[reference] = true
+		references = append(references, reference)
+	}
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].String() < references[j].String()
+	})
+	return references
+}
+
+// ReplaceSecretReferences replaces the secret references of the given configuration with the environment variables
+// holding the referenced values, so that the values are never written in the configuration.
+func ReplaceSecretReferences(config string) string {
+	return secretReferenceRegex.ReplaceAllStringFunc(config, func(match string) string {
+		groups := secretReferenceRegex.FindStringSubmatch(match)
+		reference := SecretReference{Namespace: groups[1], Name: groups[2], Key: groups[3]}
+		return fmt.Sprintf("${%s}", reference.EnvVar())
+	})
+}
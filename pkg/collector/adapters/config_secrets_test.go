@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const secretsConfig = `exporters:
+  otlphttp:
+    headers:
+      api-key: ${secret:default/vendor-credentials/api-key}
+  otlphttp/backup:
+    headers:
+      api-key: ${secret:default/vendor-credentials/api-key}
+      user: ${secret:default/backup.credentials/user-name}
+`
+
+func TestConfigToSecretReferences(t *testing.T) {
+	// test
+	references := adapters.ConfigToSecretReferences(secretsConfig)
+
+	// verify
+	assert.Equal(t, []adapters.SecretReference{
+		{Namespace: "default", Name: "backup.credentials", Key: "user-name"},
+		{Namespace: "default", Name: "vendor-credentials", Key: "api-key"},
+	}, references)
+	assert.Equal(t, "OTEL_SECRET_BACKUP_CREDENTIALS_USER_NAME", references[0].EnvVar())
+	assert.Equal(t, "OTEL_SECRET_VENDOR_CREDENTIALS_API_KEY", references[1].EnvVar())
+}
+
+func TestReplaceSecretReferences(t *testing.T) {
+	// test
+	config := adapters.ReplaceSecretReferences(secretsConfig)
+
+	// verify
+	assert.Equal(t, `exporters:
+  otlphttp:
+    headers:
+      api-key: ${OTEL_SECRET_VENDOR_CREDENTIALS_API_KEY}
+  otlphttp/backup:
+    headers:
+      api-key: ${OTEL_SECRET_VENDOR_CREDENTIALS_API_KEY}
+      user: ${OTEL_SECRET_BACKUP_CREDENTIALS_USER_NAME}
+`, config)
+	assert.Empty(t, adapters.ConfigToSecretReferences(config))
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// MaxConfigSize is the maximum size of a configuration stored in a single ConfigMap, which can't hold more than 1MiB.
+const MaxConfigSize = 1024 * 1024
+
+// ConfigUnit is a part of the configuration which can be stored in its own configuration file: the collector merges
+// the configuration files it's given, so each component of a section can be defined in a separate file.
+type ConfigUnit struct {
+	// Section is the top-level section of the unit, e.g. receivers.
+	Section string
+	// Component is the name of the component held by the unit, or empty when the unit holds the whole section.
+	Component string
+	// Config is the configuration of the unit, starting from its top-level section.
+	Config map[string]interface{}
+}
+
+// String returns the section and component of the unit.
+func (u ConfigUnit) String() string {
+	if u.Component == "" {
+		return u.Section
+	}
+	return fmt.Sprintf("%s/%s", u.Section, u.Component)
+}
+
+// Size returns the size of the unit once marshaled.
+func (u ConfigUnit) Size() (int, error) {
+	out, err := yaml.Marshal(u.Config)
+	if err != nil {
+		return 0, err
+	}
+	return len(out), nil
+}
+
+// ConfigToUnits splits the given configuration into units, sorted by section and component. The service section is
+// kept in a single unit, as the collector doesn't merge the lists of its pipelines, and so are the sections which
+// aren't maps of components.
+func ConfigToUnits(config map[string]interface{}) []ConfigUnit {
+	var units []ConfigUnit
+	for key, value := range config {
+		section := fmt.Sprint(key)
+		components, ok := value.(map[string]interface{})
+		if !ok || section == "service" {
+			units = append(units, ConfigUnit{
+				Section: section,
+				Config:  map[string]interface{}{key: value},
+			})
+			continue
+		}
+		for name, component := range components {
+			units = append(units, ConfigUnit{
+				Section:   section,
+				Component: fmt.Sprint(name),
+				Config:    map[string]interface{}{key: map[string]interface{}{name: component}},
+			})
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].Section != units[j].Section {
+			return units[i].Section < units[j].Section
+		}
+		return units[i].Component < units[j].Component
+	})
+	return units
+}
+
+// ConfigFromUnits merges the given units back into a configuration.
+func ConfigFromUnits(units []ConfigUnit) (string, error) {
+	config := map[string]interface{}{}
+	for _, unit := range units {
+		for key, value := range unit.Config {
+			components, ok := value.(map[string]interface{})
+			if unit.Component == "" || !ok {
+				config[key] = value
+				continue
+			}
+			section, ok := config[key].(map[string]interface{})
+			if !ok {
+				section = map[string]interface{}{}
+				config[key] = section
+			}
+			for name, component := range components {
+				section[name] = component
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToUnits(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+  jaeger:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      exporters: [logging]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	units := adapters.ConfigToUnits(config)
+
+	// verify
+	var names []string
+	for _, unit := range units {
+		names = append(names, unit.String())
+	}
+	assert.Equal(t, []string{"exporters/logging", "receivers/jaeger", "receivers/otlp", "service"}, names)
+
+	merged, err := adapters.ConfigFromUnits(units)
+	require.NoError(t, err)
+	actual, err := adapters.ConfigFromString(merged)
+	require.NoError(t, err)
+	assert.Equal(t, config, actual)
+}
+
+func TestConfigFromUnitsKeepsUnits(t *testing.T) {
+	// prepare
+	config, err := adapters.ConfigFromString("receivers:\n  otlp:\n  jaeger:\n")
+	require.NoError(t, err)
+	units := adapters.ConfigToUnits(config)
+
+	// test
+	_, err = adapters.ConfigFromUnits(units)
+	require.NoError(t, err)
+
+	// verify
+	for _, unit := range units {
+		assert.Len(t, unit.Config["receivers"], 1)
+	}
+}
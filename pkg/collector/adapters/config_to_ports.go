@@ -16,14 +16,23 @@ package adapters
 
 import (
 	"errors"
+	"net"
 	"sort"
+	"strconv"
 
 	"github.com/go-logr/logr"
+	"github.com/mitchellh/mapstructure"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/parser"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming/trim"
 )
 
+// MaxContainerPortNameLength allows us to truncate a port name according to what is considered valid port syntax:
+// https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
+const MaxContainerPortNameLength = 15
+
 var (
 	// ErrNoReceivers indicates that there are no receivers in the configuration.
 	ErrNoReceivers = errors.New("no receivers available as part of the configuration")
@@ -33,7 +42,7 @@ var (
 )
 
 // ConfigToReceiverPorts converts the incoming configuration object into a set of service ports required by the receivers.
-func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+func ConfigToReceiverPorts(logger logr.Logger, config map[string]interface{}) ([]corev1.ServicePort, error) {
 	// now, we gather which ports we might need to open
 	// for that, we get all the receivers and check their `endpoint` properties,
 	// extracting the port from it. The port name has to be a "DNS_LABEL", so, we try to make it follow the pattern:
@@ -48,6 +57,26 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 	//   examplereceiver/settings:
 	//     endpoint: 0.0.0.0:12346
 	// in this case, we have two ports, named: "examplereceiver" and "examplereceiver-settings"
+	byReceiver, err := ConfigToReceiverPortsByReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := []corev1.ServicePort{}
+	for _, rcvrPorts := range byReceiver {
+		ports = append(ports, rcvrPorts...)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports, nil
+}
+
+// ConfigToReceiverPortsByReceiver converts the incoming configuration object into the service ports required by each
+// of the enabled receivers, keyed by the name of the receiver.
+func ConfigToReceiverPortsByReceiver(logger logr.Logger, config map[string]interface{}) (map[string][]corev1.ServicePort, error) {
 	receiversProperty, ok := config["receivers"]
 	if !ok {
 		return nil, ErrNoReceivers
@@ -56,25 +85,25 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 	if recEnabled == nil {
 		return nil, ErrReceiversNotAMap
 	}
-	receivers, ok := receiversProperty.(map[interface{}]interface{})
+	receivers, ok := receiversProperty.(map[string]interface{})
 	if !ok {
 		return nil, ErrReceiversNotAMap
 	}
 
-	ports := []corev1.ServicePort{}
+	ports := map[string][]corev1.ServicePort{}
 	for key, val := range receivers {
 		// This check will pass only the enabled receivers,
 		// then only the related ports will be opened.
 		if !recEnabled[key] {
 			continue
 		}
-		receiver, ok := val.(map[interface{}]interface{})
+		receiver, ok := val.(map[string]interface{})
 		if !ok {
 			logger.Info("receiver doesn't seem to be a map of properties", "receiver", key)
-			receiver = map[interface{}]interface{}{}
+			receiver = map[string]interface{}{}
 		}
 
-		rcvrName := key.(string)
+		rcvrName := key
 		rcvrParser := parser.For(logger, rcvrName, receiver)
 
 		rcvrPorts, err := rcvrParser.Ports()
@@ -87,13 +116,92 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 		}
 
 		if len(rcvrPorts) > 0 {
-			ports = append(ports, rcvrPorts...)
+			ports[rcvrName] = rcvrPorts
 		}
 	}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
-
 	return ports, nil
 }
+
+// ContainerPortName returns the name of the container port of the port with the given name, truncated to the length of
+// a container port name.
+func ContainerPortName(name string) string {
+	return trim.Truncate(name, MaxContainerPortNameLength)
+}
+
+// ConfigToContainerPorts converts the incoming configuration object into the ports of the collector container, by
+// name: the ports of the receivers, with their names truncated, and the metrics port of the collector's telemetry.
+// The invalid ports are dropped.
+func ConfigToContainerPorts(logger logr.Logger, config map[string]interface{}) map[string]corev1.ContainerPort {
+	ports := map[string]corev1.ContainerPort{}
+	ps, err := ConfigToReceiverPorts(logger, config)
+	if err != nil {
+		logger.Error(err, "couldn't build container ports from configuration")
+	} else {
+		for _, p := range ps {
+			truncName := ContainerPortName(p.Name)
+			if p.Name != truncName {
+				logger.Info("truncating container port name",
+					"port.name.prev", p.Name, "port.name.new", truncName)
+			}
+			nameErrs := validation.IsValidPortName(truncName)
+			numErrs := validation.IsValidPortNum(int(p.Port))
+			if len(nameErrs) > 0 || len(numErrs) > 0 {
+				logger.Info("dropping invalid container port", "port.name", truncName, "port.num", p.Port,
+					"port.name.errs", nameErrs, "num.errs", numErrs)
+				continue
+			}
+			ports[truncName] = corev1.ContainerPort{
+				Name:          truncName,
+				ContainerPort: p.Port,
+				Protocol:      p.Protocol,
+			}
+		}
+	}
+
+	metricsPort, err := configToMetricsPort(config)
+	if err != nil {
+		logger.Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
+		metricsPort = 8888
+	}
+	ports["metrics"] = corev1.ContainerPort{
+		Name:          "metrics",
+		ContainerPort: metricsPort,
+		Protocol:      corev1.ProtocolTCP,
+	}
+	return ports
+}
+
+// configToMetricsPort gets the port number for the metrics endpoint from the collector config if it has been set.
+func configToMetricsPort(c map[string]interface{}) (int32, error) {
+	// we don't need to unmarshal the whole config, just follow the keys down to
+	// the metrics address.
+	type metricsCfg struct {
+		Address string
+	}
+	type telemetryCfg struct {
+		Metrics metricsCfg
+	}
+	type serviceCfg struct {
+		Telemetry telemetryCfg
+	}
+	type cfg struct {
+		Service serviceCfg
+	}
+	var cOut cfg
+	err := mapstructure.Decode(c, &cOut)
+	if err != nil {
+		return 0, err
+	}
+
+	_, port, err := net.SplitHostPort(cOut.Service.Telemetry.Metrics.Address)
+	if err != nil {
+		return 0, err
+	}
+	i64, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(i64), nil
+}
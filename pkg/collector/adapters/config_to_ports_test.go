@@ -110,6 +110,29 @@ func TestExtractPortsFromConfig(t *testing.T) {
 	assert.ElementsMatch(t, expectedPorts, ports)
 }
 
+func TestExtractPortsByReceiverFromConfig(t *testing.T) {
+	// prepare
+	config, err := adapters.ConfigFromString(portConfigStr)
+	require.NoError(t, err)
+
+	// test
+	ports, err := adapters.ConfigToReceiverPortsByReceiver(logger, config)
+	assert.NoError(t, err)
+
+	// verify
+	names := map[string][]string{}
+	for receiver, receiverPorts := range ports {
+		for _, port := range receiverPorts {
+			names[receiver] = append(names[receiver], port.Name)
+		}
+	}
+	assert.Len(t, names, 7)
+	assert.Equal(t, []string{"examplereceiver-settings"}, names["examplereceiver/settings"])
+	assert.ElementsMatch(t, []string{"jaeger-grpc", "jaeger-thrift-binary", "jaeger-thrift-compact"}, names["jaeger"])
+	assert.ElementsMatch(t, []string{"otlp-grpc", "otlp-http", "otlp-http-legacy"}, names["otlp"])
+	assert.Equal(t, []string{"otlp-2-grpc"}, names["otlp/2"])
+}
+
 func TestNoPortsParsed(t *testing.T) {
 	for _, tt := range []struct {
 		expected  error
@@ -180,17 +203,17 @@ func TestParserFailed(t *testing.T) {
 			return nil, errors.New("mocked error")
 		},
 	}
-	parser.Register("mock", func(logger logr.Logger, name string, config map[interface{}]interface{}) parser.ReceiverParser {
+	parser.Register("mock", func(logger logr.Logger, name string, config map[string]interface{}) parser.ReceiverParser {
 		return mockParser
 	})
 
-	config := map[interface{}]interface{}{
-		"receivers": map[interface{}]interface{}{
+	config := map[string]interface{}{
+		"receivers": map[string]interface{}{
 			"mock": map[string]interface{}{},
 		},
-		"service": map[interface{}]interface{}{
-			"pipelines": map[interface{}]interface{}{
-				"metrics": map[interface{}]interface{}{
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"metrics": map[string]interface{}{
 					"receivers": []interface{}{"mock"},
 				},
 			},
@@ -221,3 +244,37 @@ func (m *mockParser) Ports() ([]corev1.ServicePort, error) {
 func (m *mockParser) ParserName() string {
 	return "__mock-adapters"
 }
+
+func TestConfigToContainerPorts(t *testing.T) {
+	// prepare
+	configStr := `receivers:
+  otlp:
+    protocols:
+      grpc:
+  jaeger/with-a-long-name:
+    protocols:
+      thrift_compact:
+service:
+  telemetry:
+    metrics:
+      address: 0.0.0.0:9999
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger/with-a-long-name]
+`
+	config, err := adapters.ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	ports := adapters.ConfigToContainerPorts(logger, config)
+
+	// verify
+	assert.Equal(t, corev1.ContainerPort{Name: "metrics", ContainerPort: 9999, Protocol: corev1.ProtocolTCP}, ports["metrics"])
+	assert.Contains(t, ports, "otlp-grpc")
+	for name, port := range ports {
+		assert.Equal(t, name, port.Name)
+		assert.LessOrEqual(t, len(name), adapters.MaxContainerPortNameLength)
+	}
+	assert.Equal(t, "metrics", adapters.ContainerPortName("metrics"))
+	assert.Equal(t, "port-with-a-lon", adapters.ContainerPortName("port-with-a-long-name"))
+}
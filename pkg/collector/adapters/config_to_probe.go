@@ -16,6 +16,7 @@ package adapters
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -45,15 +46,18 @@ type probeConfiguration struct {
 const (
 	defaultHealthCheckPath = "/"
 	defaultHealthCheckPort = 13133
+
+	// HealthCheckPortName is the name of the service port exposing the health_check extension.
+	HealthCheckPortName = "health-check"
 )
 
 // ConfigToContainerProbe converts the incoming configuration object into a container probe or returns an error.
-func ConfigToContainerProbe(config map[interface{}]interface{}) (*corev1.Probe, error) {
+func ConfigToContainerProbe(config map[string]interface{}) (*corev1.Probe, error) {
 	serviceProperty, withService := config["service"]
 	if !withService {
 		return nil, errNoService
 	}
-	service, withSvcProperty := serviceProperty.(map[interface{}]interface{})
+	service, withSvcProperty := serviceProperty.(map[string]interface{})
 	if !withSvcProperty {
 		return nil, errServiceNotAMap
 	}
@@ -83,7 +87,7 @@ func ConfigToContainerProbe(config map[interface{}]interface{}) (*corev1.Probe,
 	if !ok {
 		return nil, errNoExtensions
 	}
-	extensions, ok := extensionsProperty.(map[interface{}]interface{})
+	extensions, ok := extensionsProperty.(map[string]interface{})
 	if !ok {
 		return nil, errExtensionsNotAMap
 	}
@@ -98,6 +102,25 @@ func ConfigToContainerProbe(config map[interface{}]interface{}) (*corev1.Probe,
 	return nil, errNoExtensionHealthCheck
 }
 
+// ConfigToHealthCheckPort returns the service port of the health_check extension enabled by the incoming
+// configuration object, along with the path the extension serves the health status on.
+func ConfigToHealthCheckPort(config map[string]interface{}) (corev1.ServicePort, string, error) {
+	probe, err := ConfigToContainerProbe(config)
+	if err != nil {
+		return corev1.ServicePort{}, "", err
+	}
+
+	port := probe.HTTPGet.Port
+	if port.Type != intstr.Int || port.IntVal <= 0 || port.IntVal > 65535 {
+		return corev1.ServicePort{}, "", fmt.Errorf("the health_check extension's port %q isn't a valid port number", port.String())
+	}
+	return corev1.ServicePort{
+		Name:       HealthCheckPortName,
+		Port:       port.IntVal,
+		TargetPort: port,
+	}, probe.HTTPGet.Path, nil
+}
+
 func createProbeFromExtension(extension interface{}) (*corev1.Probe, error) {
 	probeCfg := extractProbeConfigurationFromExtension(extension)
 	return &corev1.Probe{
@@ -111,7 +134,7 @@ func createProbeFromExtension(extension interface{}) (*corev1.Probe, error) {
 }
 
 func extractProbeConfigurationFromExtension(ext interface{}) probeConfiguration {
-	extensionCfg, ok := ext.(map[interface{}]interface{})
+	extensionCfg, ok := ext.(map[string]interface{})
 	if !ok {
 		return defaultProbeConfiguration()
 	}
@@ -128,7 +151,7 @@ func defaultProbeConfiguration() probeConfiguration {
 	}
 }
 
-func extractPathFromExtensionConfig(cfg map[interface{}]interface{}) string {
+func extractPathFromExtensionConfig(cfg map[string]interface{}) string {
 	if path, ok := cfg["path"]; ok {
 		if parsedPath, ok := path.(string); ok {
 			return parsedPath
@@ -137,7 +160,7 @@ func extractPathFromExtensionConfig(cfg map[interface{}]interface{}) string {
 	return defaultHealthCheckPath
 }
 
-func extractPortFromExtensionConfig(cfg map[interface{}]interface{}) intstr.IntOrString {
+func extractPortFromExtensionConfig(cfg map[string]interface{}) intstr.IntOrString {
 	endpoint, ok := cfg["endpoint"]
 	if !ok {
 		return defaultHealthCheckEndpoint()
@@ -185,3 +185,59 @@ service: [hi]`,
 		assert.Equal(t, test.expectedErr, err, test.desc)
 	}
 }
+
+func TestConfigToHealthCheckPort(t *testing.T) {
+	// prepare
+	config, err := ConfigFromString(`extensions:
+  health_check:
+    endpoint: 0.0.0.0:1234
+    path: /health
+service:
+  extensions: [health_check]`)
+	require.NoError(t, err)
+
+	// test
+	port, path, err := ConfigToHealthCheckPort(config)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, HealthCheckPortName, port.Name)
+	assert.EqualValues(t, 1234, port.Port)
+	assert.EqualValues(t, 1234, port.TargetPort.IntVal)
+	assert.Equal(t, "/health", path)
+}
+
+func TestConfigToHealthCheckPortShouldErrorIf(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config string
+	}{
+		{
+			desc: "NoHealthCheckInServiceExtensions",
+			config: `extensions:
+  health_check:
+service:
+  extensions: [pprof]`,
+		},
+		{
+			desc: "PortFromEnvironment",
+			config: `extensions:
+  health_check:
+    endpoint: 0.0.0.0:${HEALTH_CHECK_PORT}
+service:
+  extensions: [health_check]`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			_, _, err = ConfigToHealthCheckPort(config)
+
+			// verify
+			assert.Error(t, err)
+		})
+	}
+}
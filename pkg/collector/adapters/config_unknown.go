@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"sort"
+)
+
+// knownConfigKeys are the top-level sections of the collector configuration.
+var knownConfigKeys = map[string]bool{
+	"receivers":  true,
+	"processors": true,
+	"exporters":  true,
+	"connectors": true,
+	"extensions": true,
+	"service":    true,
+}
+
+// knownServiceKeys are the sections of the service of the collector configuration.
+var knownServiceKeys = map[string]bool{
+	"pipelines":  true,
+	"extensions": true,
+	"telemetry":  true,
+}
+
+// ConfigToUnknownKeys returns the paths of the keys of the top-level sections and of the service of the configuration
+// that the collector doesn't know about, e.g. "service.pipeline" for a misspelled "service.pipelines". The collector
+// refuses to start with such keys, which are usually typos. The paths are sorted.
+func ConfigToUnknownKeys(config map[string]interface{}) []string {
+	var unknown []string
+	for key, value := range config {
+		if !knownConfigKeys[key] {
+			unknown = append(unknown, key)
+			continue
+		}
+		if key != "service" {
+			continue
+		}
+		service, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for serviceKey := range service {
+			if !knownServiceKeys[serviceKey] {
+				unknown = append(unknown, "service."+serviceKey)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
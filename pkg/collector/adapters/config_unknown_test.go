@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfigToUnknownKeys(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   map[string]interface{}
+		expected []string
+	}{
+		{
+			desc: "known keys",
+			config: map[string]interface{}{
+				"receivers":  map[string]interface{}{"otlp": nil},
+				"connectors": map[string]interface{}{"forward": nil},
+				"service": map[string]interface{}{
+					"pipelines": map[string]interface{}{},
+					"telemetry": map[string]interface{}{},
+				},
+			},
+		},
+		{
+			desc: "unknown top-level keys",
+			config: map[string]interface{}{
+				"receiver":   map[string]interface{}{"otlp": nil},
+				"exporter":   map[string]interface{}{"otlp": nil},
+				"processors": map[string]interface{}{},
+			},
+			expected: []string{"exporter", "receiver"},
+		},
+		{
+			desc: "unknown service keys",
+			config: map[string]interface{}{
+				"service": map[string]interface{}{
+					"pipeline":   map[string]interface{}{},
+					"extensions": []interface{}{"health_check"},
+				},
+			},
+			expected: []string{"service.pipeline"},
+		},
+		{
+			desc: "service isn't a map",
+			config: map[string]interface{}{
+				"service": "pipelines",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			unknown := adapters.ConfigToUnknownKeys(tt.config)
+
+			// verify
+			assert.Equal(t, tt.expected, unknown)
+		})
+	}
+}
@@ -20,64 +20,43 @@ import (
 
 // Following Otel Doc: Configuring a receiver does not enable it. The receivers are enabled via pipelines within the service section.
 // GetEnabledReceivers returns all enabled receivers as a true flag set. If it can't find any receiver, it will return a nil interface.
-func GetEnabledReceivers(_ logr.Logger, config map[interface{}]interface{}) map[interface{}]bool {
+func GetEnabledReceivers(_ logr.Logger, config map[string]interface{}) map[string]bool {
 	cfgReceivers, ok := config["receivers"]
 	if !ok {
 		return nil
 	}
-	receivers, ok := cfgReceivers.(map[interface{}]interface{})
+	receivers, ok := cfgReceivers.(map[string]interface{})
 	if !ok {
 		return nil
 	}
-	availableReceivers := map[interface{}]bool{}
+	connectors, _ := config["connectors"].(map[string]interface{})
+	availableReceivers := map[string]bool{}
 
-	for recvID := range receivers {
-
-		//Safe Cast
-		receiverID, withReceiver := recvID.(string)
-		if !withReceiver {
-			return nil
-		}
+	for receiverID := range receivers {
 		//Getting all receivers present in the receivers section and setting them to false.
 		availableReceivers[receiverID] = false
 	}
 
-	cfgService, withService := config["service"].(map[interface{}]interface{})
+	cfgService, withService := config["service"].(map[string]interface{})
 	if !withService {
 		return nil
 	}
 
-	pipeline, withPipeline := cfgService["pipelines"].(map[interface{}]interface{})
+	pipeline, withPipeline := cfgService["pipelines"].(map[string]interface{})
 	if !withPipeline {
 		return nil
 	}
-	availablePipelines := map[string]bool{}
-
-	for pipID := range pipeline {
-		//Safe Cast
-		pipelineID, existsPipeline := pipID.(string)
-		if !existsPipeline {
-			return nil
-		}
-		//Getting all the available pipelines.
-		availablePipelines[pipelineID] = true
-	}
 
 	if len(pipeline) > 0 {
 		for pipelineID, pipelineCfg := range pipeline {
-			//Safe Cast
-			pipelineV, withPipelineCfg := pipelineID.(string)
-			if !withPipelineCfg {
-				continue
-			}
 			//Condition will get information if there are multiple configured pipelines.
-			if len(pipelineV) > 0 {
-				pipelineDesc, ok := pipelineCfg.(map[interface{}]interface{})
+			if len(pipelineID) > 0 {
+				pipelineDesc, ok := pipelineCfg.(map[string]interface{})
 				if !ok {
 					return nil
 				}
 				for pipSpecID, pipSpecCfg := range pipelineDesc {
-					if pipSpecID.(string) == "receivers" {
+					if pipSpecID == "receivers" {
 						receiversList, ok := pipSpecCfg.([]interface{})
 						if !ok {
 							continue
@@ -93,6 +72,10 @@ func GetEnabledReceivers(_ logr.Logger, config map[interface{}]interface{}) map[
 								if !ok {
 									return nil
 								}
+								// Connectors are receivers of the pipelines too, but they don't listen on any port.
+								if _, isConnector := connectors[receiverKey]; isConnector {
+									continue
+								}
 								availableReceivers[receiverKey] = true
 							}
 						}
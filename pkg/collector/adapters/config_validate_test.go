@@ -105,3 +105,34 @@ service:
 	check := GetEnabledReceivers(logger, config)
 	require.Empty(t, check)
 }
+
+func TestEnabledReceiversWithoutConnectors(t *testing.T) {
+	// prepare
+	configStr := `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+
+connectors:
+  spanmetrics:
+
+exporters:
+  logging:
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [logging]
+`
+	config, err := ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	check := GetEnabledReceivers(logger, config)
+	require.Equal(t, map[string]bool{"otlp": true}, check)
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// IngressClassAnnotation is the deprecated annotation setting the class of an ingress.
+	IngressClassAnnotation = "kubernetes.io/ingress.class"
+
+	// GRPCAppProtocol is the application protocol of the gRPC ports.
+	GRPCAppProtocol = "grpc"
+)
+
+// grpcIngressAnnotations are the annotations making the ingress controllers route the requests to gRPC backends, by
+// the name their ingress classes usually contain.
+var grpcIngressAnnotations = []struct {
+	class       string
+	annotations map[string]string
+}{
+	{
+		class:       "nginx",
+		annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+	},
+	{
+		class:       "alb",
+		annotations: map[string]string{"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC"},
+	},
+}
+
+// IngressClass returns the class of an ingress, given by its class name or by the deprecated annotation.
+func IngressClass(className *string, annotations map[string]string) string {
+	if className != nil {
+		return *className
+	}
+	return annotations[IngressClassAnnotation]
+}
+
+// GRPCIngressAnnotations returns the annotations making the controller of the ingress class route the requests to
+// gRPC backends, and whether the controller of the class is known, i.e. ingress-nginx or the AWS Load Balancer
+// Controller.
+func GRPCIngressAnnotations(class string) (map[string]string, bool) {
+	for _, known := range grpcIngressAnnotations {
+		if strings.Contains(class, known.class) {
+			annotations := make(map[string]string, len(known.annotations))
+			for k, v := range known.annotations {
+				annotations[k] = v
+			}
+			return annotations, true
+		}
+	}
+	return nil, false
+}
+
+// IsGRPCPort returns whether the port serves gRPC.
+func IsGRPCPort(port corev1.ServicePort) bool {
+	return port.AppProtocol != nil && *port.AppProtocol == GRPCAppProtocol
+}
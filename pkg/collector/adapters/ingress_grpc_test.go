@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestIngressClass(t *testing.T) {
+	nginx := "nginx"
+	assert.Equal(t, "nginx", adapters.IngressClass(&nginx, map[string]string{"kubernetes.io/ingress.class": "alb"}))
+	assert.Equal(t, "alb", adapters.IngressClass(nil, map[string]string{"kubernetes.io/ingress.class": "alb"}))
+	assert.Empty(t, adapters.IngressClass(nil, nil))
+}
+
+func TestGRPCIngressAnnotations(t *testing.T) {
+	for _, tt := range []struct {
+		class       string
+		annotations map[string]string
+		known       bool
+	}{
+		{
+			class:       "nginx",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+			known:       true,
+		},
+		{
+			class:       "internal-nginx",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+			known:       true,
+		},
+		{
+			class:       "alb",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC"},
+			known:       true,
+		},
+		{
+			class: "traefik",
+		},
+		{
+			class: "",
+		},
+	} {
+		t.Run(tt.class, func(t *testing.T) {
+			// test
+			annotations, known := adapters.GRPCIngressAnnotations(tt.class)
+
+			// verify
+			assert.Equal(t, tt.known, known)
+			assert.Equal(t, tt.annotations, annotations)
+		})
+	}
+}
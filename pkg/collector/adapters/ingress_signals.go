@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConfigToSignalReceivers returns the receivers of the pipelines of the given signals, the signal of a pipeline being
+// its type, e.g. traces for the traces/backend pipeline.
+func ConfigToSignalReceivers(config map[string]interface{}, signals []string) map[string]bool {
+	service, _ := config["service"].(map[string]interface{})
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+
+	wanted := map[string]bool{}
+	for _, signal := range signals {
+		wanted[signal] = true
+	}
+	receivers := map[string]bool{}
+	for id, pipeline := range pipelines {
+		signal, _, _ := strings.Cut(id, "/")
+		if !wanted[signal] {
+			continue
+		}
+		pipelineCfg, _ := pipeline.(map[string]interface{})
+		names, _ := pipelineCfg["receivers"].([]interface{})
+		for _, name := range names {
+			if receiver, ok := name.(string); ok {
+				receivers[receiver] = true
+			}
+		}
+	}
+	return receivers
+}
+
+// SignalPorts returns the given ports limited to the ports of the receivers of the pipelines of the given signals,
+// matched by number so that the ports of the spec replacing them are kept, along with the ports of the given names.
+// All the ports are returned when no signal is given.
+func SignalPorts(logger logr.Logger, config map[string]interface{}, ports []corev1.ServicePort, signals []string, names map[string]bool) []corev1.ServicePort {
+	if len(signals) == 0 {
+		return ports
+	}
+
+	byReceiver, err := ConfigToReceiverPortsByReceiver(logger, config)
+	if err != nil {
+		logger.Error(err, "couldn't determine the ports of the receivers")
+	}
+	numbers := map[int32]bool{}
+	for receiver := range ConfigToSignalReceivers(config, signals) {
+		for _, port := range byReceiver[receiver] {
+			numbers[port.Port] = true
+		}
+	}
+
+	var exposed []corev1.ServicePort
+	for _, port := range ports {
+		if numbers[port.Port] || names[port.Name] {
+			exposed = append(exposed, port)
+		}
+	}
+	return exposed
+}
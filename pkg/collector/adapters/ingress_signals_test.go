@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const signalConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+  prometheus:
+    endpoint: 0.0.0.0:9090
+service:
+  pipelines:
+    traces/backend:
+      receivers: [otlp]
+    metrics:
+      receivers: [otlp, prometheus]
+`
+
+func TestConfigToSignalReceivers(t *testing.T) {
+	config, err := adapters.ConfigFromString(signalConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{"otlp": true}, adapters.ConfigToSignalReceivers(config, []string{"traces"}))
+	assert.Equal(t, map[string]bool{"otlp": true, "prometheus": true}, adapters.ConfigToSignalReceivers(config, []string{"metrics"}))
+	assert.Empty(t, adapters.ConfigToSignalReceivers(config, []string{"logs"}))
+}
+
+func TestSignalPorts(t *testing.T) {
+	config, err := adapters.ConfigFromString(signalConfig)
+	require.NoError(t, err)
+	ports := []corev1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317},
+		{Name: "prometheus", Port: 9090},
+		{Name: "custom", Port: 8080},
+	}
+
+	assert.Equal(t, ports, adapters.SignalPorts(logger, config, ports, nil, nil))
+	assert.Equal(t, ports[:1], adapters.SignalPorts(logger, config, ports, []string{"traces"}, nil))
+	assert.Equal(t, []corev1.ServicePort{ports[0], ports[2]}, adapters.SignalPorts(logger, config, ports, []string{"traces"}, map[string]bool{"custom": true}))
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// AdoptAnnotation is the annotation of the instances taking over a collector deployment the operator didn't create,
+// set to the name of the deployment. The deployment is deleted once the collector of the instance is ready.
+const AdoptAnnotation = "opentelemetry.io/adopt"
+
+// AdoptableLabel is the label allowing the deployment it's set on to be adopted, set to "true", even though it's
+// controlled by another object, managed by another tool, or its pods don't carry the labels of the instance.
+const AdoptableLabel = "opentelemetry.io/adoptable"
+
+// FromDeployment returns an instance running the collector of a deployment the operator didn't create, with the
+// configuration read from the given config maps. The instance takes over the deployment, and its pods keep the labels
+// of the deployment's pods so that the services selecting them keep working during the migration.
+func FromDeployment(deployment appsv1.Deployment, configMaps []corev1.ConfigMap) (v1alpha1.OpenTelemetryCollector, error) {
+	podSpec := deployment.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("the deployment %s has no containers", deployment.Name)
+	}
+	container := podSpec.Containers[0]
+
+	configPath, args := splitConfigArg(append(container.Command, container.Args...))
+	if len(configPath) == 0 {
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("the deployment %s doesn't pass a --config argument to the collector", deployment.Name)
+	}
+	configVolume, config, err := configFromVolumes(configPath, container.VolumeMounts, podSpec.Volumes, configMaps)
+	if err != nil {
+		return v1alpha1.OpenTelemetryCollector{}, fmt.Errorf("failed to read the configuration of the deployment %s: %w", deployment.Name, err)
+	}
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "OpenTelemetryCollector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deployment.Name,
+			Namespace:   deployment.Namespace,
+			Labels:      deployment.Spec.Template.Labels,
+			Annotations: map[string]string{AdoptAnnotation: deployment.Name},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:                          v1alpha1.ModeDeployment,
+			Config:                        config,
+			Args:                          args,
+			Replicas:                      deployment.Spec.Replicas,
+			Image:                         container.Image,
+			ImagePullPolicy:               container.ImagePullPolicy,
+			Resources:                     container.Resources,
+			Env:                           container.Env,
+			EnvFrom:                       container.EnvFrom,
+			SecurityContext:               container.SecurityContext,
+			Lifecycle:                     container.Lifecycle,
+			PodAnnotations:                deployment.Spec.Template.Annotations,
+			PodSecurityContext:            podSpec.SecurityContext,
+			ServiceAccount:                podSpec.ServiceAccountName,
+			NodeSelector:                  podSpec.NodeSelector,
+			Tolerations:                   podSpec.Tolerations,
+			Affinity:                      podSpec.Affinity,
+			PriorityClassName:             podSpec.PriorityClassName,
+			HostNetwork:                   podSpec.HostNetwork,
+			TerminationGracePeriodSeconds: podSpec.TerminationGracePeriodSeconds,
+		},
+	}
+
+	for _, mount := range container.VolumeMounts {
+		if mount.Name != configVolume {
+			otelcol.Spec.VolumeMounts = append(otelcol.Spec.VolumeMounts, mount)
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Name != configVolume {
+			otelcol.Spec.Volumes = append(otelcol.Spec.Volumes, volume)
+		}
+	}
+
+	return otelcol, nil
+}
+
+// splitConfigArg returns the path of the --config argument and the other flags of the collector command line.
+func splitConfigArg(cmdline []string) (string, map[string]string) {
+	var configPath string
+	args := map[string]string{}
+	for i := 0; i < len(cmdline); i++ {
+		if !strings.HasPrefix(cmdline[i], "-") {
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimLeft(cmdline[i], "-"), "=")
+		if !found && i+1 < len(cmdline) && !strings.HasPrefix(cmdline[i+1], "-") {
+			i++
+			value = cmdline[i]
+		}
+
+		if name == "config" {
+			configPath = strings.TrimPrefix(value, "file:")
+		} else {
+			args[name] = value
+		}
+	}
+	if len(args) == 0 {
+		args = nil
+	}
+	return configPath, args
+}
+
+// configFromVolumes returns the name of the volume holding the configuration file, and its content.
+func configFromVolumes(configPath string, mounts []corev1.VolumeMount, volumes []corev1.Volume, configMaps []corev1.ConfigMap) (string, string, error) {
+	var mount *corev1.VolumeMount
+	for i := range mounts {
+		if strings.HasPrefix(configPath, strings.TrimSuffix(mounts[i].MountPath, "/")+"/") &&
+			(mount == nil || len(mounts[i].MountPath) > len(mount.MountPath)) {
+			mount = &mounts[i]
+		}
+	}
+	if mount == nil {
+		return "", "", fmt.Errorf("no volume is mounted for %s", configPath)
+	}
+	file := strings.TrimPrefix(configPath, strings.TrimSuffix(mount.MountPath, "/")+"/")
+	if len(mount.SubPath) > 0 {
+		file = path.Join(mount.SubPath, file)
+	}
+
+	var source *corev1.ConfigMapVolumeSource
+	for _, volume := range volumes {
+		if volume.Name == mount.Name {
+			source = volume.ConfigMap
+		}
+	}
+	if source == nil {
+		return "", "", fmt.Errorf("the volume %s isn't a config map", mount.Name)
+	}
+
+	key := file
+	for _, item := range source.Items {
+		if item.Path == file {
+			key = item.Key
+		}
+	}
+	for _, cm := range configMaps {
+		if cm.Name != source.Name {
+			continue
+		}
+		if config, ok := cm.Data[key]; ok {
+			return mount.Name, config, nil
+		}
+		return "", "", fmt.Errorf("the config map %s has no key %s", cm.Name, key)
+	}
+	return "", "", fmt.Errorf("the config map %s wasn't found", source.Name)
+}
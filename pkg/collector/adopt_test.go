@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestFromDeployment(t *testing.T) {
+	replicas := int32(3)
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otel-gateway",
+			Namespace: "observability",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "otel-gateway"},
+					Annotations: map[string]string{"sidecar.istio.io/inject": "false"},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "otel",
+					NodeSelector:       map[string]string{"pool": "observability"},
+					Containers: []corev1.Container{{
+						Name:  "otelcol",
+						Image: "otel/opentelemetry-collector-contrib:0.88.0",
+						Args:  []string{"--config", "/etc/otelcol/otel.yaml", "--feature-gates=+exporter.datadogexporter.metricexportnativeclient"},
+						Env:   []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "400MiB"}},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "config", MountPath: "/etc/otelcol"},
+							{Name: "certs", MountPath: "/certs"},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "otel-gateway-config"},
+								Items:                []corev1.KeyToPath{{Key: "collector.yaml", Path: "otel.yaml"}},
+							}},
+						},
+						{
+							Name:         "certs",
+							VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	configMaps := []corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "otel-gateway-config", Namespace: "observability"},
+		Data:       map[string]string{"collector.yaml": "receivers:\n  otlp:\n"},
+	}}
+
+	t.Run("should convert the deployment", func(t *testing.T) {
+		otelcol, err := FromDeployment(deployment, configMaps)
+		require.NoError(t, err)
+
+		assert.Equal(t, "otel-gateway", otelcol.Name)
+		assert.Equal(t, "observability", otelcol.Namespace)
+		assert.Equal(t, map[string]string{"app": "otel-gateway"}, otelcol.Labels)
+		assert.Equal(t, "otel-gateway", otelcol.Annotations[AdoptAnnotation])
+		assert.Equal(t, v1alpha1.ModeDeployment, otelcol.Spec.Mode)
+		assert.Equal(t, "receivers:\n  otlp:\n", otelcol.Spec.Config)
+		assert.Equal(t, map[string]string{"feature-gates": "+exporter.datadogexporter.metricexportnativeclient"}, otelcol.Spec.Args)
+		assert.Equal(t, &replicas, otelcol.Spec.Replicas)
+		assert.Equal(t, "otel/opentelemetry-collector-contrib:0.88.0", otelcol.Spec.Image)
+		assert.Equal(t, "otel", otelcol.Spec.ServiceAccount)
+		assert.Equal(t, map[string]string{"pool": "observability"}, otelcol.Spec.NodeSelector)
+		assert.Equal(t, map[string]string{"sidecar.istio.io/inject": "false"}, otelcol.Spec.PodAnnotations)
+		assert.Equal(t, []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "400MiB"}}, otelcol.Spec.Env)
+
+		// the config volume is replaced by the operator's own
+		require.Len(t, otelcol.Spec.Volumes, 1)
+		assert.Equal(t, "certs", otelcol.Spec.Volumes[0].Name)
+		require.Len(t, otelcol.Spec.VolumeMounts, 1)
+		assert.Equal(t, "certs", otelcol.Spec.VolumeMounts[0].Name)
+	})
+
+	t.Run("should fail without a config argument", func(t *testing.T) {
+		noConfig := *deployment.DeepCopy()
+		noConfig.Spec.Template.Spec.Containers[0].Args = nil
+
+		_, err := FromDeployment(noConfig, configMaps)
+		assert.ErrorContains(t, err, "doesn't pass a --config argument")
+	})
+
+	t.Run("should fail without the config map", func(t *testing.T) {
+		_, err := FromDeployment(deployment, nil)
+		assert.ErrorContains(t, err, "the config map otel-gateway-config wasn't found")
+	})
+
+	t.Run("should fail when the config isn't in a config map", func(t *testing.T) {
+		fromSecret := *deployment.DeepCopy()
+		fromSecret.Spec.Template.Spec.Containers[0].Args = []string{"--config=file:/certs/otel.yaml"}
+
+		_, err := FromDeployment(fromSecret, configMaps)
+		assert.ErrorContains(t, err, "the volume certs isn't a config map")
+	})
+}
+
+func TestAdoptAnnotationNotPropagated(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AdoptAnnotation: "otel-gateway"},
+		},
+	}
+
+	assert.NotContains(t, Annotations(config.New(), otelcol), AdoptAnnotation)
+	assert.NotContains(t, PodAnnotations(config.New(), otelcol), AdoptAnnotation)
+}
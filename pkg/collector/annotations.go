@@ -19,10 +19,22 @@ import (
 	"fmt"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
+// RestartAnnotation is the annotation of the instances whose collector pods are restarted whenever its value, e.g. the
+// current time, changes.
+const RestartAnnotation = "opentelemetry.io/restart-at"
+
+// PauseAnnotation is the annotation of the instances whose generated objects aren't reconciled while it's set to
+// "true", e.g. to edit them by hand during an incident. Their status is still updated.
+const PauseAnnotation = "opentelemetry.io/pause-reconciliation"
+
+// restartedAtAnnotation is the pod template annotation set by "kubectl rollout restart".
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 // Annotations return the annotations for OpenTelemetryCollector pod.
-func Annotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+func Annotations(cfg config.Config, instance v1alpha1.OpenTelemetryCollector) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	annotations := map[string]string{}
 
@@ -37,14 +49,24 @@ func Annotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
 			annotations[k] = v
 		}
 	}
+	// the debug container is attached to the running pods, so asking for it must not roll them out
+	delete(annotations, DebugAnnotation)
+	// neither must removing the adopted deployment from the instance once it's gone
+	delete(annotations, AdoptAnnotation)
+	// the restart is only requested from the pod template, see PodAnnotations
+	delete(annotations, RestartAnnotation)
+	// resuming the reconciliation must not roll out the pods by itself
+	delete(annotations, PauseAnnotation)
+	// nor must requesting the rollback, which changes the configuration and image of the pods by itself
+	delete(annotations, RollbackAnnotation)
 	// make sure sha256 for configMap is always calculated
-	annotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	annotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(collectorConfig(cfg, instance))
 
 	return annotations
 }
 
 // PodAnnotations return the spec annotations for OpenTelemetryCollector pod.
-func PodAnnotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+func PodAnnotations(cfg config.Config, instance v1alpha1.OpenTelemetryCollector) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	podAnnotations := map[string]string{}
 
@@ -54,18 +76,38 @@ func PodAnnotations(instance v1alpha1.OpenTelemetryCollector) map[string]string
 	}
 
 	// propagating annotations from metadata.annotations
-	for kMeta, vMeta := range Annotations(instance) {
+	for kMeta, vMeta := range Annotations(cfg, instance) {
 		if _, found := podAnnotations[kMeta]; !found {
 			podAnnotations[kMeta] = vMeta
 		}
 	}
 
 	// make sure sha256 for configMap is always calculated
-	podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(collectorConfig(cfg, instance))
+
+	// restart the pods the same way "kubectl rollout restart" does
+	if restartAt, ok := instance.Annotations[RestartAnnotation]; ok {
+		podAnnotations[restartedAtAnnotation] = restartAt
+	}
 
 	return podAnnotations
 }
 
+// Paused returns whether the reconciliation of the generated objects of the given instance is paused.
+func Paused(instance v1alpha1.OpenTelemetryCollector) bool {
+	return instance.Annotations[PauseAnnotation] == "true"
+}
+
+// collectorConfig returns the configuration of the given instance along with the one added by the presets, so that
+// changing a preset changes the sha256 of the configuration as well.
+func collectorConfig(cfg config.Config, instance v1alpha1.OpenTelemetryCollector) string {
+	config, err := PresetConfig(cfg, instance)
+	if err != nil {
+		return instance.Spec.Config
+	}
+	return config
+}
+
 func getConfigMapSHA(config string) string {
 	h := sha256.Sum256([]byte(config))
 	return fmt.Sprintf("%x", h)
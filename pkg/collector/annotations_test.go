@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
 func TestDefaultAnnotations(t *testing.T) {
@@ -36,8 +37,8 @@ func TestDefaultAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(config.New(), otelcol)
+	podAnnotations := PodAnnotations(config.New(), otelcol)
 
 	//verify
 	assert.Equal(t, "true", annotations["prometheus.io/scrape"])
@@ -69,8 +70,8 @@ func TestUserAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(config.New(), otelcol)
+	podAnnotations := PodAnnotations(config.New(), otelcol)
 
 	//verify
 	assert.Equal(t, "false", annotations["prometheus.io/scrape"])
@@ -92,8 +93,8 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(config.New(), otelcol)
+	podAnnotations := PodAnnotations(config.New(), otelcol)
 
 	// verify
 	assert.Len(t, annotations, 5)
@@ -101,3 +102,49 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", podAnnotations["myapp"])
 	assert.Equal(t, "pod_annotation_value", podAnnotations["pod_annotation"])
 }
+
+func TestRestartAnnotation(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"opentelemetry.io/restart-at": "2023-03-01T10:00:00Z"},
+		},
+	}
+
+	// test
+	annotations := Annotations(config.New(), otelcol)
+	podAnnotations := PodAnnotations(config.New(), otelcol)
+
+	// verify
+	assert.NotContains(t, annotations, "opentelemetry.io/restart-at")
+	assert.NotContains(t, podAnnotations, "opentelemetry.io/restart-at")
+	assert.Equal(t, "2023-03-01T10:00:00Z", podAnnotations["kubectl.kubernetes.io/restartedAt"])
+
+	// a new value changes the pod template
+	otelcol.Annotations["opentelemetry.io/restart-at"] = "2023-03-02T10:00:00Z"
+	assert.NotEqual(t, podAnnotations, PodAnnotations(config.New(), otelcol))
+}
+
+func TestPauseAnnotation(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		value    string
+		expected bool
+	}{
+		{desc: "paused", value: "true", expected: true},
+		{desc: "resumed", value: "false"},
+		{desc: "not set"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{}
+			if tt.value != "" {
+				otelcol.Annotations = map[string]string{"opentelemetry.io/pause-reconciliation": tt.value}
+			}
+
+			// test and verify
+			assert.Equal(t, tt.expected, Paused(otelcol))
+			assert.NotContains(t, PodAnnotations(config.New(), otelcol), "opentelemetry.io/pause-reconciliation")
+		})
+	}
+}
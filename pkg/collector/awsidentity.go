@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// awsRoleARNAnnotation is the annotation of the service account the EKS pod identity webhook injects the web
+	// identity token of the IAM role for.
+	awsRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	// defaultFSGroup is the group owning the volumes of the collector pods with an AWS identity or a write-ahead log,
+	// so that the web identity token is readable and the write-ahead log writable by the collector when it doesn't run
+	// as root.
+	defaultFSGroup int64 = 65534
+)
+
+// podSecurityContext returns the pod security context of the given instance, with the group owning the volumes set
+// when the instance has an AWS identity or the write-ahead log of the remote write preset.
+func podSecurityContext(otelcol v1alpha1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	identity := otelcol.Spec.AWSIdentity
+	if identity == nil && !remoteWriteWALEnabled(otelcol) {
+		return otelcol.Spec.PodSecurityContext
+	}
+	if otelcol.Spec.PodSecurityContext != nil && otelcol.Spec.PodSecurityContext.FSGroup != nil && (identity == nil || identity.FSGroup == nil) {
+		return otelcol.Spec.PodSecurityContext
+	}
+
+	securityContext := &corev1.PodSecurityContext{}
+	if otelcol.Spec.PodSecurityContext != nil {
+		securityContext = otelcol.Spec.PodSecurityContext.DeepCopy()
+	}
+	fsGroup := defaultFSGroup
+	if identity != nil && identity.FSGroup != nil {
+		fsGroup = *identity.FSGroup
+	}
+	securityContext.FSGroup = &fsGroup
+	return securityContext
+}
+
+// awsIdentityEnvVars returns the environment variables the AWS SDKs of the collector require for the AWS identity of
+// the given instance. The credentials themselves are injected by the EKS pod identity webhook.
+func awsIdentityEnvVars(otelcol v1alpha1.OpenTelemetryCollector) []corev1.EnvVar {
+	identity := otelcol.Spec.AWSIdentity
+	if identity == nil || identity.Region == "" {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name:  "AWS_REGION",
+		Value: identity.Region,
+	}}
+}
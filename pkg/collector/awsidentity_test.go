@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestAWSIdentity(t *testing.T) {
+	runAsUser := int64(1337)
+	fsGroup := int64(2000)
+
+	for _, tt := range []struct {
+		desc               string
+		identity           *v1alpha1.AWSIdentitySpec
+		podSecurityContext *corev1.PodSecurityContext
+		expectedFSGroup    *int64
+		expectedEnv        []corev1.EnvVar
+	}{
+		{
+			desc: "no identity",
+		},
+		{
+			desc:            "default fsGroup",
+			identity:        &v1alpha1.AWSIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/otel-collector"},
+			expectedFSGroup: int64Ptr(65534),
+		},
+		{
+			desc:               "fsGroup of the pod security context",
+			identity:           &v1alpha1.AWSIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/otel-collector", Region: "eu-west-1"},
+			podSecurityContext: &corev1.PodSecurityContext{RunAsUser: &runAsUser, FSGroup: &fsGroup},
+			expectedFSGroup:    &fsGroup,
+			expectedEnv:        []corev1.EnvVar{{Name: "AWS_REGION", Value: "eu-west-1"}},
+		},
+		{
+			desc:               "fsGroup of the identity",
+			identity:           &v1alpha1.AWSIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/otel-collector", FSGroup: &fsGroup},
+			podSecurityContext: &corev1.PodSecurityContext{RunAsUser: &runAsUser},
+			expectedFSGroup:    &fsGroup,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-instance",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					AWSIdentity:        tt.identity,
+					PodSecurityContext: tt.podSecurityContext,
+				},
+			}
+
+			d := Deployment(config.New(), logger, otelcol)
+
+			securityContext := d.Spec.Template.Spec.SecurityContext
+			if tt.expectedFSGroup == nil {
+				assert.Nil(t, securityContext)
+			} else {
+				assert.Equal(t, tt.expectedFSGroup, securityContext.FSGroup)
+			}
+			if tt.podSecurityContext != nil {
+				assert.Equal(t, tt.podSecurityContext.RunAsUser, securityContext.RunAsUser)
+			}
+			// the first env var is always POD_NAME
+			assert.Equal(t, append([]corev1.EnvVar{}, tt.expectedEnv...), d.Spec.Template.Spec.Containers[0].Env[1:])
+		})
+	}
+}
+
+func TestAWSIdentityServiceAccountAnnotations(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-instance",
+			Annotations: map[string]string{"instance": "annotation"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ServiceAccountAnnotations: map[string]string{"service-account": "annotation"},
+			AWSIdentity:               &v1alpha1.AWSIdentitySpec{RoleARN: "arn:aws:iam::123456789012:role/otel-collector"},
+		},
+	}
+
+	sa := ServiceAccount(otelcol)
+
+	assert.Equal(t, map[string]string{
+		"instance":                   "annotation",
+		"service-account":            "annotation",
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/otel-collector",
+	}, sa.Annotations)
+	assert.Equal(t, map[string]string{"instance": "annotation"}, otelcol.Annotations)
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// RolloutColorLabel is the label of the collector pods holding the color of the blue/green deployment they belong to,
+// which the Services select.
+const RolloutColorLabel = "opentelemetry.io/rollout-color"
+
+// RolloutRevisionAnnotation is the annotation of the blue/green deployments holding the revision of their pod template.
+const RolloutRevisionAnnotation = "opentelemetry.io/rollout-revision"
+
+// The colors of the blue/green deployments.
+const (
+	RolloutColorBlue  = "blue"
+	RolloutColorGreen = "green"
+)
+
+// BlueGreen returns whether the changes of the collector deployment of the given instance are rolled out to a parallel
+// deployment.
+func BlueGreen(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.RolloutStrategy == v1alpha1.RolloutStrategyBlueGreen && otelcol.Spec.Mode == v1alpha1.ModeDeployment
+}
+
+// ActiveColor returns the color of the blue/green deployment of the given instance the Services select, blue until a
+// rollout is recorded.
+func ActiveColor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if rollout := otelcol.Status.Rollout; rollout != nil && rollout.Active == RolloutColorGreen {
+		return RolloutColorGreen
+	}
+	return RolloutColorBlue
+}
+
+// OtherColor returns the color of the blue/green deployment the changes are rolled out to when the given one is active.
+func OtherColor(color string) string {
+	if color == RolloutColorGreen {
+		return RolloutColorBlue
+	}
+	return RolloutColorGreen
+}
+
+// BlueGreenDeploymentName returns the name of the blue/green deployment of the given color. The blue deployment keeps
+// the name of the collector deployment, so that it's adopted when the rollout strategy changes.
+func BlueGreenDeploymentName(otelcol v1alpha1.OpenTelemetryCollector, color string) string {
+	if color == RolloutColorGreen {
+		return naming.CollectorColor(otelcol, color)
+	}
+	return naming.Collector(otelcol)
+}
+
+// ActiveDeploymentName returns the name of the collector deployment the Services of the given instance select.
+func ActiveDeploymentName(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if BlueGreen(otelcol) {
+		return BlueGreenDeploymentName(otelcol, ActiveColor(otelcol))
+	}
+	return naming.Collector(otelcol)
+}
+
+// ActiveSelectorLabels returns the labels selecting the collector pods the Services of the given instance route to,
+// which are the ones of the active deployment of the blue/green rollouts.
+func ActiveSelectorLabels(otelcol v1alpha1.OpenTelemetryCollector) map[string]string {
+	labels := SelectorLabels(otelcol)
+	if BlueGreen(otelcol) {
+		labels[RolloutColorLabel] = ActiveColor(otelcol)
+	}
+	return labels
+}
+
+// PodTemplateRevision returns the revision of the given pod template, which changes with anything in it.
+func PodTemplateRevision(template corev1.PodTemplateSpec) string {
+	// the pod templates are made of serializable types only
+	data, _ := json.Marshal(template)
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:configVersionLength]
+}
+
+// BlueGreenDeployment returns the given collector deployment of the given instance as the blue/green deployment of the
+// given color, whose pods and selector have the color label.
+func BlueGreenDeployment(otelcol v1alpha1.OpenTelemetryCollector, deployment appsv1.Deployment, color string) appsv1.Deployment {
+	revision := PodTemplateRevision(deployment.Spec.Template)
+	out := *deployment.DeepCopy()
+	out.Name = BlueGreenDeploymentName(otelcol, color)
+
+	// new maps, so that we don't touch the labels and annotations shared with other objects
+	annotations := map[string]string{}
+	for k, v := range out.Annotations {
+		annotations[k] = v
+	}
+	annotations[RolloutRevisionAnnotation] = revision
+	out.Annotations = annotations
+
+	selector := map[string]string{}
+	if out.Spec.Selector != nil {
+		for k, v := range out.Spec.Selector.MatchLabels {
+			selector[k] = v
+		}
+	}
+	selector[RolloutColorLabel] = color
+	out.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+
+	labels := map[string]string{}
+	for k, v := range out.Spec.Template.Labels {
+		labels[k] = v
+	}
+	labels[RolloutColorLabel] = color
+	out.Spec.Template.Labels = labels
+
+	return out
+}
+
+// BlueGreenReady returns whether all the replicas of the given blue/green deployment run the pod template with the
+// given revision and are available, so that the Services can be switched to it.
+func BlueGreenReady(deployment appsv1.Deployment, revision string) bool {
+	if deployment.Annotations[RolloutRevisionAnnotation] != revision || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.Replicas == replicas && status.UpdatedReplicas == replicas && status.AvailableReplicas == replicas
+}
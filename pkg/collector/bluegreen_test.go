@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func blueGreenInstance() v1alpha1.OpenTelemetryCollector {
+	versions := int32(3)
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:            v1alpha1.ModeDeployment,
+			RolloutStrategy: v1alpha1.RolloutStrategyBlueGreen,
+			ConfigVersions:  &versions,
+			Config:          "receivers:\n  otlp:\n",
+		},
+	}
+}
+
+func TestBlueGreen(t *testing.T) {
+	otelcol := blueGreenInstance()
+	assert.True(t, BlueGreen(otelcol))
+
+	otelcol.Spec.Mode = v1alpha1.ModeStatefulSet
+	assert.False(t, BlueGreen(otelcol))
+
+	otelcol = blueGreenInstance()
+	otelcol.Spec.RolloutStrategy = v1alpha1.RolloutStrategyRollingUpdate
+	assert.False(t, BlueGreen(otelcol))
+}
+
+func TestActiveColor(t *testing.T) {
+	otelcol := blueGreenInstance()
+	assert.Equal(t, RolloutColorBlue, ActiveColor(otelcol))
+	assert.Equal(t, "my-instance-collector", ActiveDeploymentName(otelcol))
+	assert.Equal(t, RolloutColorBlue, ActiveSelectorLabels(otelcol)[RolloutColorLabel])
+
+	otelcol.Status.Rollout = &v1alpha1.RolloutStatus{Active: RolloutColorGreen, Revision: "0123456789"}
+	assert.Equal(t, RolloutColorGreen, ActiveColor(otelcol))
+	assert.Equal(t, RolloutColorBlue, OtherColor(ActiveColor(otelcol)))
+	assert.Equal(t, "my-instance-collector-green", ActiveDeploymentName(otelcol))
+	assert.Equal(t, RolloutColorGreen, ActiveSelectorLabels(otelcol)[RolloutColorLabel])
+
+	otelcol.Spec.RolloutStrategy = ""
+	assert.Equal(t, "my-instance-collector", ActiveDeploymentName(otelcol))
+	assert.NotContains(t, ActiveSelectorLabels(otelcol), RolloutColorLabel)
+}
+
+func TestBlueGreenDeployment(t *testing.T) {
+	otelcol := blueGreenInstance()
+	deployment := Deployment(config.New(), logf.Log, otelcol)
+	revision := PodTemplateRevision(deployment.Spec.Template)
+
+	green := BlueGreenDeployment(otelcol, deployment, RolloutColorGreen)
+
+	assert.Equal(t, "my-instance-collector-green", green.Name)
+	assert.Equal(t, revision, green.Annotations[RolloutRevisionAnnotation])
+	assert.Equal(t, RolloutColorGreen, green.Spec.Selector.MatchLabels[RolloutColorLabel])
+	assert.Equal(t, RolloutColorGreen, green.Spec.Template.Labels[RolloutColorLabel])
+	// the given deployment is left as it is
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, RolloutColorLabel)
+	assert.NotContains(t, deployment.Spec.Template.Labels, RolloutColorLabel)
+	assert.NotContains(t, deployment.Annotations, RolloutRevisionAnnotation)
+
+	blue := BlueGreenDeployment(otelcol, deployment, RolloutColorBlue)
+	assert.Equal(t, "my-instance-collector", blue.Name)
+	assert.Equal(t, revision, blue.Annotations[RolloutRevisionAnnotation])
+
+	otelcol.Spec.Config = "receivers:\n  jaeger:\n"
+	assert.NotEqual(t, revision, PodTemplateRevision(Deployment(config.New(), logf.Log, otelcol).Spec.Template))
+}
+
+func TestBlueGreenReady(t *testing.T) {
+	replicas := int32(2)
+	ready := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation:  3,
+			Annotations: map[string]string{RolloutRevisionAnnotation: "0123456789"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  2,
+		},
+	}
+	assert.True(t, BlueGreenReady(ready, "0123456789"))
+	assert.False(t, BlueGreenReady(ready, "9876543210"))
+
+	stale := *ready.DeepCopy()
+	stale.Status.ObservedGeneration = 2
+	assert.False(t, BlueGreenReady(stale, "0123456789"))
+
+	rolling := *ready.DeepCopy()
+	rolling.Status.Replicas = 3
+	assert.False(t, BlueGreenReady(rolling, "0123456789"))
+
+	unavailable := *ready.DeepCopy()
+	unavailable.Status.AvailableReplicas = 1
+	assert.False(t, BlueGreenReady(unavailable, "0123456789"))
+}
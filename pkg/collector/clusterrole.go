@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ClusterRole returns the cluster role the k8s_observer of the receiver creator preset requires for the given instance.
+func ClusterRole(otelcol v1alpha1.OpenTelemetryCollector) rbacv1.ClusterRole {
+	name := naming.ClusterRole(otelcol)
+	labels := Labels(otelcol, name, []string{})
+
+	resources := []string{"pods"}
+	if otelcol.Spec.ReceiverCreator.ObserveNodes {
+		resources = append(resources, "nodes")
+	}
+
+	return rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: otelcol.Annotations,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: resources,
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+}
+
+// ClusterRoleBinding returns the binding of the cluster role to the service account of the given instance.
+func ClusterRoleBinding(otelcol v1alpha1.OpenTelemetryCollector) rbacv1.ClusterRoleBinding {
+	name := naming.ClusterRole(otelcol)
+	labels := Labels(otelcol, name, []string{})
+
+	return rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: otelcol.Annotations,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ServiceAccountName(otelcol),
+			Namespace: otelcol.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+	}
+}
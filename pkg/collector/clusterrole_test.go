@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestClusterRole(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ReceiverCreator: v1alpha1.ReceiverCreatorSpec{
+				Enabled:      true,
+				ObserveNodes: true,
+			},
+		},
+	}
+
+	// test
+	clusterRole := ClusterRole(otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-my-namespace-collector-64e3ce28", clusterRole.Name)
+	assert.Equal(t, "my-namespace.my-instance", clusterRole.Labels["app.kubernetes.io/instance"])
+	assert.Equal(t, []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods", "nodes"},
+		Verbs:     []string{"get", "list", "watch"},
+	}}, clusterRole.Rules)
+}
+
+func TestClusterRoleBinding(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+	}
+
+	// test
+	binding := ClusterRoleBinding(otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-my-namespace-collector-64e3ce28", binding.Name)
+	assert.Equal(t, "my-instance-my-namespace-collector-64e3ce28", binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{
+		Kind:      "ServiceAccount",
+		Name:      "my-instance-collector",
+		Namespace: "my-namespace",
+	}}, binding.Subjects)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// compatibleInstance returns the given instance without the host network, the privileged security context and the
+// node selectors on the labels reserved by the platform of its compatibility mode. The instance is returned as is when
+// it has no compatibility mode.
+func compatibleInstance(otelcol v1alpha1.OpenTelemetryCollector) v1alpha1.OpenTelemetryCollector {
+	mode := otelcol.Spec.CompatibilityMode
+	if mode == "" {
+		return otelcol
+	}
+
+	compatible := *otelcol.DeepCopy()
+	compatible.Spec.HostNetwork = false
+	if compatible.Spec.SecurityContext != nil {
+		compatible.Spec.SecurityContext.Privileged = nil
+	}
+	for key := range compatible.Spec.NodeSelector {
+		if mode.ReservedNodeLabel(key) {
+			delete(compatible.Spec.NodeSelector, key)
+		}
+	}
+	return compatible
+}
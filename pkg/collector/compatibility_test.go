@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestCompatibilityMode(t *testing.T) {
+	privileged := true
+	runAsNonRoot := true
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			HostNetwork: true,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged:   &privileged,
+				RunAsNonRoot: &runAsNonRoot,
+			},
+			NodeSelector: map[string]string{
+				"kubernetes.io/hostname":        "node-1",
+				"cloud.google.com/gke-nodepool": "default-pool",
+				"cloud.google.com/gke-spot":     "true",
+			},
+		},
+	}
+	cfg := config.New()
+
+	t.Run("without compatibility mode", func(t *testing.T) {
+		d := Deployment(cfg, logger, otelcol)
+
+		assert.True(t, d.Spec.Template.Spec.HostNetwork)
+		assert.Equal(t, corev1.DNSClusterFirstWithHostNet, d.Spec.Template.Spec.DNSPolicy)
+		assert.Equal(t, &privileged, d.Spec.Template.Spec.Containers[0].SecurityContext.Privileged)
+		assert.Len(t, d.Spec.Template.Spec.NodeSelector, 3)
+	})
+
+	t.Run("gke-autopilot", func(t *testing.T) {
+		instance := *otelcol.DeepCopy()
+		instance.Spec.CompatibilityMode = v1alpha1.CompatibilityModeGKEAutopilot
+		d := Deployment(cfg, logger, instance)
+
+		assert.False(t, d.Spec.Template.Spec.HostNetwork)
+		assert.Equal(t, corev1.DNSClusterFirst, d.Spec.Template.Spec.DNSPolicy)
+		assert.Equal(t, &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot}, d.Spec.Template.Spec.Containers[0].SecurityContext)
+		assert.Equal(t, map[string]string{"cloud.google.com/gke-spot": "true"}, d.Spec.Template.Spec.NodeSelector)
+
+		// the instance itself is left as is
+		assert.True(t, instance.Spec.HostNetwork)
+		assert.Len(t, instance.Spec.NodeSelector, 3)
+	})
+
+	t.Run("eks-fargate", func(t *testing.T) {
+		instance := *otelcol.DeepCopy()
+		instance.Spec.CompatibilityMode = v1alpha1.CompatibilityModeEKSFargate
+		instance.Spec.NodeSelector = map[string]string{
+			"eks.amazonaws.com/compute-type": "fargate",
+			"eks.amazonaws.com/nodegroup":    "collectors",
+		}
+		ss := StatefulSet(cfg, logger, instance)
+
+		assert.False(t, ss.Spec.Template.Spec.HostNetwork)
+		assert.Nil(t, ss.Spec.Template.Spec.Containers[0].SecurityContext.Privileged)
+		assert.Equal(t, map[string]string{"eks.amazonaws.com/compute-type": "fargate"}, ss.Spec.Template.Spec.NodeSelector)
+	})
+
+	t.Run("gke-autopilot daemonset", func(t *testing.T) {
+		instance := *otelcol.DeepCopy()
+		instance.Spec.Mode = v1alpha1.ModeDaemonSet
+		instance.Spec.CompatibilityMode = v1alpha1.CompatibilityModeGKEAutopilot
+		ds := DaemonSet(cfg, logger, instance)
+
+		assert.False(t, ds.Spec.Template.Spec.HostNetwork)
+		assert.Nil(t, ds.Spec.Template.Spec.Containers[0].SecurityContext.Privileged)
+		assert.Equal(t, map[string]string{"cloud.google.com/gke-spot": "true"}, ds.Spec.Template.Spec.NodeSelector)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// configPartSize is the size the parts of a configuration are filled up to. It leaves room for the changes made to
+// the configuration when it's rendered, like the target allocator rewrite of the prometheus receiver.
+const configPartSize = adapters.MaxConfigSize * 9 / 10
+
+// configLayout assigns the units of a configuration to the parts it's split into.
+type configLayout struct {
+	parts int
+	units map[string]int
+}
+
+// ConfigPartCount returns the number of parts the configuration of the given instance is split into, each of them
+// being held by its own config map.
+func ConfigPartCount(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) int {
+	layout, err := configLayoutOf(cfg, otelcol)
+	if err != nil {
+		return 1
+	}
+	return layout.parts
+}
+
+// ConfigParts splits the given rendered configuration of the instance into ConfigPartCount parts. The layout is
+// computed from the instance's configuration rather than the rendered one, so that the number of parts is known when
+// building the pods of the instance.
+func ConfigParts(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, config string) ([]string, error) {
+	layout, err := configLayoutOf(cfg, otelcol)
+	if err != nil {
+		return nil, err
+	}
+	if layout.parts == 1 {
+		if len(config) > adapters.MaxConfigSize {
+			return nil, fmt.Errorf("the configuration is %d bytes, more than the %d bytes a config map can hold", len(config), adapters.MaxConfigSize)
+		}
+		return []string{config}, nil
+	}
+
+	c, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return nil, err
+	}
+	units := make([][]adapters.ConfigUnit, layout.parts)
+	for _, unit := range adapters.ConfigToUnits(c) {
+		// the units only found in the rendered configuration are added to the first part
+		part := layout.units[unit.String()]
+		units[part] = append(units[part], unit)
+	}
+
+	parts := make([]string, layout.parts)
+	for i := range units {
+		part, err := adapters.ConfigFromUnits(units[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(part) > adapters.MaxConfigSize {
+			return nil, fmt.Errorf("the part %d of the configuration is %d bytes, more than the %d bytes a config map can hold", i, len(part), adapters.MaxConfigSize)
+		}
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+func configLayoutOf(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) (configLayout, error) {
+	layout := configLayout{parts: 1, units: map[string]int{}}
+	config := collectorConfig(cfg, otelcol)
+	if len(config) <= configPartSize {
+		return layout, nil
+	}
+
+	c, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return layout, err
+	}
+	size := 0
+	for _, unit := range adapters.ConfigToUnits(c) {
+		unitSize, err := unit.Size()
+		if err != nil {
+			return layout, err
+		}
+		if size > 0 && size+unitSize > configPartSize {
+			layout.parts++
+			size = 0
+		}
+		layout.units[unit.String()] = layout.parts - 1
+		size += unitSize
+	}
+	return layout, nil
+}
+
+// configPartEntry returns the name of the file holding the given part of the configuration.
+func configPartEntry(entry string, part int) string {
+	if part == 0 {
+		return entry
+	}
+	ext := path.Ext(entry)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(entry, ext), part, ext)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// largeConfig returns a configuration with the given number of receivers, each of them about size bytes.
+func largeConfig(receivers int, size int) string {
+	var config strings.Builder
+	config.WriteString("receivers:\n")
+	var names []string
+	for i := 0; i < receivers; i++ {
+		name := fmt.Sprintf("filelog/%d", i)
+		names = append(names, name)
+		fmt.Fprintf(&config, "  %s:\n    include: [%s]\n", name, strings.Repeat("a", size))
+	}
+	config.WriteString("exporters:\n  logging:\n")
+	fmt.Fprintf(&config, "service:\n  pipelines:\n    logs:\n      receivers: [%s]\n      exporters: [logging]\n", strings.Join(names, ", "))
+	return config.String()
+}
+
+func TestConfigPartsSingle(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: largeConfig(2, 10),
+		},
+	}
+
+	parts, err := ConfigParts(config.New(), otelcol, otelcol.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ConfigPartCount(config.New(), otelcol))
+	assert.Equal(t, []string{otelcol.Spec.Config}, parts)
+}
+
+func TestConfigPartsSplit(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: largeConfig(30, 100*1024),
+		},
+	}
+
+	parts, err := ConfigParts(config.New(), otelcol, otelcol.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, 4, ConfigPartCount(config.New(), otelcol))
+	require.Len(t, parts, 4)
+
+	// the collector merges the parts back into the original configuration
+	merged := map[string]interface{}{}
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(part), adapters.MaxConfigSize)
+		config, err := adapters.ConfigFromString(part)
+		require.NoError(t, err)
+		for section, components := range config {
+			if _, ok := merged[section]; !ok {
+				merged[section] = map[string]interface{}{}
+			}
+			for name, component := range components.(map[string]interface{}) {
+				merged[section].(map[string]interface{})[name] = component
+			}
+		}
+	}
+	expected, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, expected, merged)
+}
+
+func TestConfigPartsComponentTooLarge(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: largeConfig(2, 1100*1024),
+		},
+	}
+
+	_, err := ConfigParts(config.New(), otelcol, otelcol.Spec.Config)
+	assert.ErrorContains(t, err, "more than the 1048576 bytes a config map can hold")
+}
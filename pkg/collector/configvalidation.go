@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ConditionTypeConfigValidated is the type of the status condition reporting whether the validate command of the
+// collector succeeded with the configuration of the instance.
+const ConditionTypeConfigValidated = "ConfigValidated"
+
+// Reasons of the ConfigValidated condition.
+const (
+	ReasonConfigValid       = "ConfigValid"
+	ReasonConfigInvalid     = "ConfigInvalid"
+	ReasonValidationPending = "ValidationPending"
+)
+
+// ConfigValidationComponent is the component label of the jobs validating the configuration, which keeps their pods
+// out of the selectors of the collector pods.
+const ConfigValidationComponent = "opentelemetry-collector-validation"
+
+const (
+	defaultConfigValidationTimeout = 2 * time.Minute
+	// invalidConfigExitCode is the exit code of the validate command when the configuration is invalid, the other
+	// failures of the collector container, like being OOM killed, don't tell anything about the configuration.
+	invalidConfigExitCode = 1
+)
+
+// configValidationVersion is the first collector version with the validate command.
+var configValidationVersion = semver.MustParse("0.86.0")
+
+// ConfigValidationEnabled returns whether the configurations of the given instance are validated before they're rolled
+// out. The sidecars are injected from the spec, so their configuration isn't validated, and neither are the
+// configurations of the collectors whose image tag is a version without the validate command, which the webhook warns
+// about.
+func ConfigValidationEnabled(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.ConfigValidation == nil || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return false
+	}
+	version := Version(cfg, otelcol)
+	return version == nil || !version.LessThan(configValidationVersion)
+}
+
+// ConfigValidationDigest returns the digest of the given rendered configuration and image of the given instance,
+// along with the feature gates the configuration is validated with.
+func ConfigValidationDigest(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, config string) string {
+	image := Image(cfg, otelcol)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s", image, otelcol.Spec.Args[featureGatesArg], config)
+	return fmt.Sprintf("%x", h.Sum(nil))[:configVersionLength]
+}
+
+// ConfigValidationLabels returns the labels selecting the configuration validation jobs of the given instance.
+func ConfigValidationLabels(otelcol v1alpha1.OpenTelemetryCollector) map[string]string {
+	labels := SelectorLabels(otelcol)
+	labels["app.kubernetes.io/component"] = ConfigValidationComponent
+	return labels
+}
+
+// configValidationObjectLabels returns the labels of the job and config maps validating the configuration with the
+// given digest.
+func configValidationObjectLabels(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, digest string) map[string]string {
+	labels := Labels(otelcol, naming.ConfigValidationJob(otelcol, digest), cfg.LabelsFilter())
+	for k, v := range ConfigValidationLabels(otelcol) {
+		labels[k] = v
+	}
+	return labels
+}
+
+// ConfigValidationConfigMaps returns the config maps holding the parts of the given rendered configuration validated
+// by the job with the given digest, split like the configuration of the collector.
+func ConfigValidationConfigMaps(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, config, digest string) ([]corev1.ConfigMap, error) {
+	otelcol = compatibleInstance(otelcol)
+	parts, err := ConfigParts(cfg, otelcol, config)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := make([]corev1.ConfigMap, len(parts))
+	for i, part := range parts {
+		configMaps[i] = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.ConfigValidationConfigMap(otelcol, digest, i),
+				Namespace: otelcol.Namespace,
+				Labels:    configValidationObjectLabels(cfg, otelcol, digest),
+			},
+			Data: map[string]string{
+				cfg.CollectorConfigMapEntry(): part,
+			},
+		}
+	}
+	return configMaps, nil
+}
+
+// ConfigValidationJob returns the job running the validate command of the collector image of the given instance on
+// the configuration held by the config maps with the given digest, in a pod like the collector pods without their
+// ports, probes and volumes other than the configuration. The pods failing for other reasons than an invalid
+// configuration, e.g. evicted ones, are retried with the backoff of the job controller.
+func ConfigValidationJob(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, digest string) batchv1.Job {
+	otelcol = compatibleInstance(otelcol)
+	labels := configValidationObjectLabels(cfg, otelcol, digest)
+
+	container := Container(cfg, logger, otelcol, true)
+	args := []string{"validate"}
+	for _, arg := range container.Args {
+		if strings.HasPrefix(arg, "--config=") || strings.HasPrefix(arg, "--"+featureGatesArg+"=") {
+			args = append(args, arg)
+		}
+	}
+	container.Args = args
+	container.Ports = nil
+	container.VolumeMounts = []corev1.VolumeMount{{
+		Name:      naming.ConfigMapVolume(),
+		MountPath: "/conf",
+	}}
+	container.LivenessProbe = nil
+	container.Lifecycle = nil
+
+	// the configuration volume comes first
+	volume := volumes(cfg, otelcol, func(part int) string { return naming.ConfigValidationConfigMap(otelcol, digest, part) })[0]
+	backoffLimit := int32(3)
+
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ConfigValidationJob(otelcol, digest),
+			Namespace: otelcol.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			// an invalid configuration isn't retried, while the disruptions of the pods don't count against the
+			// backoff limit
+			PodFailurePolicy: &batchv1.PodFailurePolicy{
+				Rules: []batchv1.PodFailurePolicyRule{
+					{
+						Action: batchv1.PodFailurePolicyActionFailJob,
+						OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+							ContainerName: &container.Name,
+							Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+							Values:        []int32{invalidConfigExitCode},
+						},
+					},
+					{
+						Action: batchv1.PodFailurePolicyActionIgnore,
+						OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+							Type:   corev1.DisruptionTarget,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: ServiceAccountName(otelcol),
+					Containers:         []corev1.Container{container},
+					Volumes:            []corev1.Volume{volume},
+					RestartPolicy:      corev1.RestartPolicyNever,
+					SecurityContext:    otelcol.Spec.PodSecurityContext,
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidationCondition returns the ConfigValidated condition of the given instance from the given validation
+// job, which is nil until it's created, and its pods at the given time. The errors of an invalid configuration come
+// from the termination message of the collector container, which falls back to the end of its logs. It also returns
+// whether the job is to be re-created, having failed for other reasons than an invalid configuration, or its collector
+// having run longer than the timeout: the time the image is pulled isn't counted.
+func ConfigValidationCondition(otelcol v1alpha1.OpenTelemetryCollector, job *batchv1.Job, pods []corev1.Pod, now time.Time) (metav1.Condition, bool) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeConfigValidated,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             ReasonValidationPending,
+		Message:            "the configuration is being validated",
+	}
+	if job == nil {
+		return condition, false
+	}
+	condition.Message = fmt.Sprintf("the configuration is being validated by the job %s", job.Name)
+
+	// the clusters without pod failure policies retry the invalid configurations until the backoff limit, they're
+	// reported on the first failure
+	if excerpt, ok := validationErrors(pods); ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonConfigInvalid
+		condition.Message = fmt.Sprintf("the job %s failed to validate the configuration: %s", job.Name, excerpt)
+		return condition, false
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type { // nolint:exhaustive
+		case batchv1.JobComplete:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = ReasonConfigValid
+			condition.Message = fmt.Sprintf("the configuration was validated by the job %s", job.Name)
+			return condition, false
+		case batchv1.JobFailed:
+			condition.Message = fmt.Sprintf("the job %s failed without validating the configuration, it's re-created: %s", job.Name, c.Message)
+			return condition, true
+		}
+	}
+
+	timeout := defaultConfigValidationTimeout
+	if validation := otelcol.Spec.ConfigValidation; validation != nil && validation.Timeout != nil {
+		timeout = validation.Timeout.Duration
+	}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != naming.Container() {
+				continue
+			}
+			if running := status.State.Running; running != nil && now.Sub(running.StartedAt.Time) > timeout {
+				condition.Message = fmt.Sprintf("the job %s didn't validate the configuration within %s, it's re-created", job.Name, timeout)
+				return condition, true
+			}
+			// e.g. the image can't be pulled
+			if waiting := status.State.Waiting; waiting != nil && len(waiting.Message) > 0 {
+				condition.Message = fmt.Sprintf("the configuration is being validated by the job %s, whose pod is waiting: %s", job.Name, waiting.Message)
+			}
+		}
+	}
+	return condition, false
+}
+
+// validationErrors returns the end of the termination message of the collector container of the given pods which
+// exited because of an invalid configuration, and whether there's one.
+func validationErrors(pods []corev1.Pod) (string, bool) {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == naming.Container() && status.State.Terminated != nil && status.State.Terminated.ExitCode == invalidConfigExitCode {
+				return tail(status.State.Terminated.Message, maxLogExcerpt), true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func validationInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-instance",
+			Namespace:  "my-ns",
+			Generation: 2,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:             v1alpha1.ModeDeployment,
+			Image:            "otel/opentelemetry-collector:0.86.0",
+			Args:             map[string]string{"feature-gates": "+service.profilesSupport", "log-level": "debug"},
+			ConfigValidation: &v1alpha1.ConfigValidationSpec{},
+		},
+	}
+}
+
+func TestConfigValidationEnabled(t *testing.T) {
+	otelcol := validationInstance()
+	assert.True(t, ConfigValidationEnabled(config.New(), otelcol))
+
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	assert.False(t, ConfigValidationEnabled(config.New(), otelcol))
+
+	otelcol = validationInstance()
+	otelcol.Spec.ConfigValidation = nil
+	assert.False(t, ConfigValidationEnabled(config.New(), otelcol))
+
+	// the collector has no validate command
+	otelcol = validationInstance()
+	otelcol.Spec.Image = "otel/opentelemetry-collector:0.85.0"
+	assert.False(t, ConfigValidationEnabled(config.New(), otelcol))
+
+	otelcol.Spec.Image = "otel/opentelemetry-collector:latest"
+	assert.True(t, ConfigValidationEnabled(config.New(), otelcol))
+
+	// neither has the image of the operator's configuration, which the instances without an image run
+	otelcol.Spec.Image = ""
+	assert.False(t, ConfigValidationEnabled(config.New(config.WithCollectorImage("otel/opentelemetry-collector:0.85.0")), otelcol))
+}
+
+func TestConfigValidationDigest(t *testing.T) {
+	cfg := config.New()
+	otelcol := validationInstance()
+	digest := ConfigValidationDigest(cfg, otelcol, "receivers:\n  otlp:\n")
+	assert.Len(t, digest, 10)
+	assert.Equal(t, digest, ConfigValidationDigest(cfg, otelcol, "receivers:\n  otlp:\n"))
+
+	assert.NotEqual(t, digest, ConfigValidationDigest(cfg, otelcol, "receivers:\n  jaeger:\n"))
+
+	otelcol.Spec.Image = "otel/opentelemetry-collector:0.87.0"
+	assert.NotEqual(t, digest, ConfigValidationDigest(cfg, otelcol, "receivers:\n  otlp:\n"))
+
+	otelcol = validationInstance()
+	otelcol.Spec.Args["feature-gates"] = ""
+	assert.NotEqual(t, digest, ConfigValidationDigest(cfg, otelcol, "receivers:\n  otlp:\n"))
+}
+
+func TestConfigValidationConfigMaps(t *testing.T) {
+	configMaps, err := ConfigValidationConfigMaps(config.New(), validationInstance(), "exporters:\n  otlp:\n    headers:\n      key: ${env:KEY}\n", "0123456789")
+	require.NoError(t, err)
+
+	require.Len(t, configMaps, 1)
+	assert.Equal(t, "my-instance-validate-0123456789", configMaps[0].Name)
+	assert.Equal(t, "my-ns", configMaps[0].Namespace)
+	assert.Equal(t, ConfigValidationComponent, configMaps[0].Labels["app.kubernetes.io/component"])
+	assert.Equal(t, map[string]string{"collector.yaml": "exporters:\n  otlp:\n    headers:\n      key: ${env:KEY}\n"}, configMaps[0].Data)
+}
+
+func TestConfigValidationJob(t *testing.T) {
+	job := ConfigValidationJob(config.New(), logf.Log, validationInstance(), "0123456789")
+
+	assert.Equal(t, "my-instance-validate-0123456789", job.Name)
+	assert.Equal(t, "my-ns", job.Namespace)
+	assert.Equal(t, ConfigValidationComponent, job.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, ConfigValidationComponent, job.Spec.Template.Labels["app.kubernetes.io/component"])
+	assert.Nil(t, job.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, int32(3), *job.Spec.BackoffLimit)
+	require.NotNil(t, job.Spec.PodFailurePolicy)
+	require.Len(t, job.Spec.PodFailurePolicy.Rules, 2)
+	assert.Equal(t, batchv1.PodFailurePolicyActionFailJob, job.Spec.PodFailurePolicy.Rules[0].Action)
+	assert.Equal(t, []int32{1}, job.Spec.PodFailurePolicy.Rules[0].OnExitCodes.Values)
+	assert.Equal(t, "otc-container", *job.Spec.PodFailurePolicy.Rules[0].OnExitCodes.ContainerName)
+	assert.Equal(t, batchv1.PodFailurePolicyActionIgnore, job.Spec.PodFailurePolicy.Rules[1].Action)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, "my-instance-collector", job.Spec.Template.Spec.ServiceAccountName)
+
+	require.Len(t, job.Spec.Template.Spec.Volumes, 1)
+	volume := job.Spec.Template.Spec.Volumes[0]
+	assert.Equal(t, "otc-internal", volume.Name)
+	require.NotNil(t, volume.ConfigMap)
+	assert.Equal(t, "my-instance-validate-0123456789", volume.ConfigMap.Name)
+
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "otel/opentelemetry-collector:0.86.0", container.Image)
+	assert.Equal(t, []string{"validate", "--config=/conf/collector.yaml", "--feature-gates=+service.profilesSupport"}, container.Args)
+	assert.Empty(t, container.Ports)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "otc-internal", MountPath: "/conf"}}, container.VolumeMounts)
+	assert.Nil(t, container.LivenessProbe)
+}
+
+func TestConfigValidationCondition(t *testing.T) {
+	otelcol := validationInstance()
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-validate-0123456789"}}
+	now := time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC)
+	podWith := func(state corev1.ContainerState) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "otc-container", State: state}},
+			},
+		}
+	}
+	invalidPod := podWith(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Message:  "Error: invalid configuration: exporters::otlp: requires a non-empty \"endpoint\"",
+	}})
+	killedPod := podWith(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}})
+
+	for _, tt := range []struct {
+		desc            string
+		job             *batchv1.Job
+		conditions      []batchv1.JobCondition
+		pods            []corev1.Pod
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+		expectedRetry   bool
+	}{
+		{
+			desc:            "job not created",
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonValidationPending,
+			expectedMessage: "the configuration is being validated",
+		},
+		{
+			desc:            "job running",
+			job:             job,
+			pods:            []corev1.Pod{podWith(corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-time.Minute))}})},
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonValidationPending,
+			expectedMessage: "the configuration is being validated by the job my-instance-validate-0123456789",
+		},
+		{
+			desc:            "job complete",
+			job:             job,
+			conditions:      []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  ReasonConfigValid,
+			expectedMessage: "the configuration was validated by the job my-instance-validate-0123456789",
+		},
+		{
+			desc:            "invalid configuration",
+			job:             job,
+			conditions:      []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "PodFailurePolicy"}},
+			pods:            []corev1.Pod{invalidPod},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonConfigInvalid,
+			expectedMessage: "the job my-instance-validate-0123456789 failed to validate the configuration: Error: invalid configuration: exporters::otlp: requires a non-empty \"endpoint\"",
+		},
+		{
+			desc:            "invalid configuration retried",
+			job:             job,
+			pods:            []corev1.Pod{invalidPod, podWith(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}})},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonConfigInvalid,
+			expectedMessage: "the job my-instance-validate-0123456789 failed to validate the configuration: Error: invalid configuration: exporters::otlp: requires a non-empty \"endpoint\"",
+		},
+		{
+			desc:            "job failed without validating",
+			job:             job,
+			conditions:      []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}},
+			pods:            []corev1.Pod{killedPod},
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonValidationPending,
+			expectedMessage: "the job my-instance-validate-0123456789 failed without validating the configuration, it's re-created: Job has reached the specified backoff limit",
+			expectedRetry:   true,
+		},
+		{
+			desc:            "job timed out",
+			job:             job,
+			pods:            []corev1.Pod{podWith(corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-3 * time.Minute))}})},
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonValidationPending,
+			expectedMessage: "the job my-instance-validate-0123456789 didn't validate the configuration within 2m0s, it's re-created",
+			expectedRetry:   true,
+		},
+		{
+			desc: "image not pulled",
+			job:  job,
+			pods: []corev1.Pod{podWith(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: "Back-off pulling image \"otel/opentelemetry-collector:0.86.0\"",
+			}})},
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonValidationPending,
+			expectedMessage: "the configuration is being validated by the job my-instance-validate-0123456789, whose pod is waiting: Back-off pulling image \"otel/opentelemetry-collector:0.86.0\"",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var j *batchv1.Job
+			if tt.job != nil {
+				j = tt.job.DeepCopy()
+				j.Status.Conditions = tt.conditions
+			}
+
+			condition, retry := ConfigValidationCondition(otelcol, j, tt.pods, now)
+
+			assert.Equal(t, ConditionTypeConfigValidated, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			assert.Equal(t, tt.expectedRetry, retry)
+		})
+	}
+}
+
+func TestConfigValidationConditionTimeout(t *testing.T) {
+	otelcol := validationInstance()
+	otelcol.Spec.ConfigValidation.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "my-instance-validate-0123456789"}}
+	now := time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC)
+	pods := []corev1.Pod{{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "otc-container",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-3 * time.Minute))}},
+			}},
+		},
+	}}
+
+	_, retry := ConfigValidationCondition(otelcol, job, pods, now)
+	assert.False(t, retry)
+
+	_, retry = ConfigValidationCondition(otelcol, job, pods, now.Add(3*time.Minute))
+	assert.True(t, retry)
+}
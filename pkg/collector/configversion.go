@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// ConfigVersionLabel is the label of the versioned config maps holding the version of the configuration they hold.
+const ConfigVersionLabel = "opentelemetry.io/config-version"
+
+// configVersionLength is the number of hexadecimal digits of the hash naming the versions of the configuration.
+const configVersionLength = 10
+
+// ConfigVersion returns the version of the configuration of the given instance, or an empty string when its config maps
+// aren't versioned. The version is the hash of everything the rendered configuration is built from, so that it's known
+// when building the pod template referencing the config maps.
+func ConfigVersion(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	if otelcol.Spec.ConfigVersions == nil {
+		return ""
+	}
+	h := sha256.New()
+	h.Write([]byte(collectorConfig(cfg, otelcol)))
+	// the target allocator settings change the prometheus receiver of the rendered configuration
+	fmt.Fprintf(h, "\n%t %v %d", otelcol.Spec.TargetAllocator.Enabled, targetallocator.Zones(otelcol), targetallocator.JobShards(otelcol))
+	return fmt.Sprintf("%x", h.Sum(nil))[:configVersionLength]
+}
+
+// VersionedConfigMap returns the name of the config map with the given name holding the current version of the
+// configuration of the given instance, which is the given name itself when the config maps aren't versioned.
+func VersionedConfigMap(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, name string) string {
+	if version := ConfigVersion(cfg, otelcol); version != "" {
+		return naming.ConfigMapVersion(otelcol, name, version)
+	}
+	return name
+}
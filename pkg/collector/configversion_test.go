@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestConfigVersion(t *testing.T) {
+	three := int32(3)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:         "receivers:\n  otlp:\n",
+			ConfigVersions: &three,
+		},
+	}
+
+	t.Run("should be empty without versions", func(t *testing.T) {
+		unversioned := *otelcol.DeepCopy()
+		unversioned.Spec.ConfigVersions = nil
+
+		assert.Empty(t, ConfigVersion(config.New(), unversioned))
+		assert.Equal(t, "my-instance-collector", VersionedConfigMap(config.New(), unversioned, "my-instance-collector"))
+	})
+
+	t.Run("should follow the configuration", func(t *testing.T) {
+		version := ConfigVersion(config.New(), otelcol)
+		assert.Len(t, version, 10)
+		assert.Equal(t, version, ConfigVersion(config.New(), *otelcol.DeepCopy()))
+
+		changed := *otelcol.DeepCopy()
+		changed.Spec.Config = "receivers:\n  jaeger:\n"
+		assert.NotEqual(t, version, ConfigVersion(config.New(), changed))
+
+		withTargetAllocator := *otelcol.DeepCopy()
+		withTargetAllocator.Spec.TargetAllocator.Enabled = true
+		assert.NotEqual(t, version, ConfigVersion(config.New(), withTargetAllocator))
+
+		// the other settings don't change the configuration
+		scaled := *otelcol.DeepCopy()
+		scaled.Spec.Replicas = &three
+		assert.Equal(t, version, ConfigVersion(config.New(), scaled))
+	})
+
+	t.Run("should reference the versioned config map from the volume", func(t *testing.T) {
+		volumes := Volumes(config.New(), otelcol)
+
+		assert.Equal(t, "my-instance-collector-"+ConfigVersion(config.New(), otelcol), volumes[0].ConfigMap.Name)
+	})
+}
@@ -16,31 +16,37 @@ package collector
 
 import (
 	"fmt"
-	"net"
 	"sort"
-	"strconv"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
-	"github.com/mitchellh/mapstructure"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/proxy"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
-// maxPortLen allows us to truncate a port name according to what is considered valid port syntax:
-// https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
-const maxPortLen = 15
+// Image returns the collector image of the given instance, defaulting to the one of the operator's configuration.
+func Image(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	if len(otelcol.Spec.Image) > 0 {
+		return otelcol.Spec.Image
+	}
+	return cfg.CollectorImage()
+}
+
+// Version returns the version of the collector image of the given instance, or nil when its tag isn't a version.
+func Version(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *semver.Version {
+	return v1alpha1.ImageVersion(Image(cfg, otelcol))
+}
 
 // Container builds a container for the given collector.
 func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, addConfig bool) corev1.Container {
-	image := otelcol.Spec.Image
-	if len(image) == 0 {
-		image = cfg.CollectorImage()
-	}
+	otelcol = compatibleInstance(otelcol)
+	image := Image(cfg, otelcol)
 
 	// build container ports from service ports
 	ports := getConfigContainerPorts(logger, otelcol.Spec.Config)
@@ -51,9 +57,10 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			Protocol:      p.Protocol,
 		}
 	}
+	bindHostPorts(otelcol, ports)
 
 	var volumeMounts []corev1.VolumeMount
-	argsMap := otelcol.Spec.Args
+	argsMap := profilesArgs(otelcol.Spec.Args, otelcol.Spec.Config)
 	if argsMap == nil {
 		argsMap = map[string]string{}
 	}
@@ -72,6 +79,10 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			delete(argsMap, "config")
 		}
 		args = append(args, fmt.Sprintf("--config=/conf/%s", cfg.CollectorConfigMapEntry()))
+		// a configuration too large for a single config map is split in parts, which the collector merges back
+		for part := 1; part < ConfigPartCount(cfg, otelcol); part++ {
+			args = append(args, fmt.Sprintf("--config=/conf/%s", configPartEntry(cfg.CollectorConfigMapEntry(), part)))
+		}
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
 				Name:      naming.ConfigMapVolume(),
@@ -89,6 +100,12 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	sort.Strings(sortedArgs)
 	args = append(args, sortedArgs...)
 
+	volumeMounts = append(volumeMounts, secretProviderVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, receiverTLSVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, exporterTLSVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, exporterTokenVolumeMounts(otelcol)...)
+	volumeMounts = append(volumeMounts, remoteWriteVolumeMounts(otelcol)...)
+
 	if len(otelcol.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
@@ -119,6 +136,44 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		})
 	}
 
+	if otelcol.Spec.ReceiverCreator.Enabled && otelcol.Spec.Mode == v1alpha1.ModeDaemonSet {
+		// The k8s_observer of the receiver creator preset only observes the node of the collector.
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "K8S_NODE_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "spec.nodeName",
+				},
+			},
+		})
+	}
+
+	envVars = append(envVars, selfTelemetryEnvVars(otelcol, envVars)...)
+	envVars = append(envVars, awsIdentityEnvVars(otelcol)...)
+	envVars = append(envVars, receiverEndpointsEnvVars(otelcol, envVars)...)
+	envVars = append(envVars, hostPortsEnvVars(otelcol, envVars)...)
+
+	// The values of the secrets referenced by the config, including the parts the presets add, are only given to the
+	// collector through environment variables, the config refers to these variables instead.
+	presetConfig, err := PresetConfig(cfg, otelcol)
+	if err != nil {
+		presetConfig = otelcol.Spec.Config
+	}
+	for _, reference := range adapters.ConfigToSecretReferences(presetConfig) {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: reference.EnvVar(),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: reference.Name},
+					Key:                  reference.Key,
+				},
+			},
+		})
+	}
+
+	// The variables set by the user take precedence over the proxy.
+	envVars = proxy.MergeEnvVars(envVars, proxyEnvVars(cfg, otelcol))
+
 	var livenessProbe *corev1.Probe
 	if config, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
 		if probe, err := getLivenessProbe(config, otelcol.Spec.LivenessProbe); err == nil {
@@ -141,86 +196,18 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		SecurityContext: otelcol.Spec.SecurityContext,
 		LivenessProbe:   livenessProbe,
 		Lifecycle:       otelcol.Spec.Lifecycle,
+		// the end of the logs of crashing collectors is reported in the Healthy condition of the instance
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 }
 
 func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.ContainerPort {
-	ports := map[string]corev1.ContainerPort{}
 	c, err := adapters.ConfigFromString(cfg)
 	if err != nil {
 		logger.Error(err, "couldn't extract the configuration")
-		return ports
-	}
-	ps, err := adapters.ConfigToReceiverPorts(logger, c)
-	if err != nil {
-		logger.Error(err, "couldn't build container ports from configuration")
-	} else {
-		for _, p := range ps {
-			truncName := naming.Truncate(p.Name, maxPortLen)
-			if p.Name != truncName {
-				logger.Info("truncating container port name",
-					"port.name.prev", p.Name, "port.name.new", truncName)
-			}
-			nameErrs := validation.IsValidPortName(truncName)
-			numErrs := validation.IsValidPortNum(int(p.Port))
-			if len(nameErrs) > 0 || len(numErrs) > 0 {
-				logger.Info("dropping invalid container port", "port.name", truncName, "port.num", p.Port,
-					"port.name.errs", nameErrs, "num.errs", numErrs)
-				continue
-			}
-			ports[truncName] = corev1.ContainerPort{
-				Name:          truncName,
-				ContainerPort: p.Port,
-				Protocol:      p.Protocol,
-			}
-		}
+		return map[string]corev1.ContainerPort{}
 	}
-
-	metricsPort, err := getMetricsPort(c)
-	if err != nil {
-		logger.Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
-		metricsPort = 8888
-	}
-	ports["metrics"] = corev1.ContainerPort{
-		Name:          "metrics",
-		ContainerPort: metricsPort,
-		Protocol:      corev1.ProtocolTCP,
-	}
-	return ports
-}
-
-// getMetricsPort gets the port number for the metrics endpoint from the collector config if it has been set.
-func getMetricsPort(c map[interface{}]interface{}) (int32, error) {
-	// we don't need to unmarshal the whole config, just follow the keys down to
-	// the metrics address.
-	type metricsCfg struct {
-		Address string
-	}
-	type telemetryCfg struct {
-		Metrics metricsCfg
-	}
-	type serviceCfg struct {
-		Telemetry telemetryCfg
-	}
-	type cfg struct {
-		Service serviceCfg
-	}
-	var cOut cfg
-	err := mapstructure.Decode(c, &cOut)
-	if err != nil {
-		return 0, err
-	}
-
-	_, port, err := net.SplitHostPort(cOut.Service.Telemetry.Metrics.Address)
-	if err != nil {
-		return 0, err
-	}
-	i64, err := strconv.ParseInt(port, 10, 32)
-	if err != nil {
-		return 0, err
-	}
-
-	return int32(i64), nil
+	return adapters.ConfigToContainerPorts(logger, c)
 }
 
 func portMapToList(portMap map[string]corev1.ContainerPort) []corev1.ContainerPort {
@@ -234,7 +221,7 @@ func portMapToList(portMap map[string]corev1.ContainerPort) []corev1.ContainerPo
 	return ports
 }
 
-func getLivenessProbe(config map[interface{}]interface{}, probeConfig *v1alpha1.Probe) (*corev1.Probe, error) {
+func getLivenessProbe(config map[string]interface{}, probeConfig *v1alpha1.Probe) (*corev1.Probe, error) {
 	probe, err := adapters.ConfigToContainerProbe(config)
 	if err != nil {
 		return nil, err
@@ -259,3 +246,13 @@ func getLivenessProbe(config map[interface{}]interface{}, probeConfig *v1alpha1.
 	}
 	return probe, nil
 }
+
+// proxyEnvVars returns the proxy environment variables of the collector, which reaches the Kubernetes API and the
+// TargetAllocator services without the proxy.
+func proxyEnvVars(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.EnvVar {
+	noProxyHosts := []string{proxy.KubernetesServiceHost}
+	if otelcol.Spec.TargetAllocator.Enabled {
+		noProxyHosts = append(noProxyHosts, targetallocator.Services(otelcol)...)
+	}
+	return proxy.EnvVars(cfg, otelcol.Spec.Proxy, noProxyHosts...)
+}
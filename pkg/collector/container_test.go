@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -239,6 +240,30 @@ func TestContainerCustomVolumes(t *testing.T) {
 	assert.Equal(t, "custom-volume-mount", c.VolumeMounts[1].Name)
 }
 
+func TestContainerSecretProviderVolumes(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			SecretProviders: []v1alpha1.SecretProvider{{
+				Name:                "vault",
+				SecretProviderClass: "vault-credentials",
+			}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Len(t, c.VolumeMounts, 2)
+	assert.Equal(t, corev1.VolumeMount{
+		Name:      "secret-provider-vault",
+		MountPath: "/etc/otelcol/secrets/vault",
+		ReadOnly:  true,
+	}, c.VolumeMounts[1])
+}
+
 func TestContainerCustomSecurityContext(t *testing.T) {
 	// default config without security context
 	c1 := Container(config.New(), logger, v1alpha1.OpenTelemetryCollector{Spec: v1alpha1.OpenTelemetryCollectorSpec{}}, true)
@@ -304,6 +329,78 @@ func TestContainerDefaultEnvVars(t *testing.T) {
 	assert.Equal(t, c.Env[0].Name, "POD_NAME")
 }
 
+func TestContainerSecretEnvVars(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `exporters:
+  otlphttp:
+    headers:
+      api-key: ${secret:default/vendor-credentials/api-key}`,
+		},
+	}
+
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Len(t, c.Env, 2)
+	assert.Equal(t, corev1.EnvVar{
+		Name: "OTEL_SECRET_VENDOR_CREDENTIALS_API_KEY",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "vendor-credentials"},
+				Key:                  "api-key",
+			},
+		},
+	}, c.Env[1])
+}
+
+func TestContainerReceiverCreatorSecretEnvVars(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]`,
+			ReceiverCreator: v1alpha1.ReceiverCreatorSpec{
+				Enabled:   true,
+				Pipelines: []string{"metrics"},
+				Receivers: map[string]v1alpha1.ReceiverCreatorTemplate{
+					"redis": {
+						Rule:   `type == "pod"`,
+						Config: "password: ${secret:default/redis/password}",
+					},
+				},
+			},
+		},
+	}
+
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Contains(t, c.Env, corev1.EnvVar{
+		Name: "OTEL_SECRET_REDIS_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "redis"},
+				Key:                  "password",
+			},
+		},
+	})
+}
+
 func TestContainerResourceRequirements(t *testing.T) {
 	otelcol := v1alpha1.OpenTelemetryCollector{
 		Spec: v1alpha1.OpenTelemetryCollectorSpec{
@@ -388,6 +485,31 @@ func TestContainerOrderedArgs(t *testing.T) {
 	assert.Equal(t, "--log-level=debug", c.Args[2])
 }
 
+func TestContainerSplitConfigArgs(t *testing.T) {
+	// prepare a configuration too large for a single config map
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: largeConfig(30, 100*1024),
+			Args: map[string]string{
+				"log-level": "debug",
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify that each part of the config is given to the collector, before the other args
+	assert.Equal(t, []string{
+		"--config=/conf/collector.yaml",
+		"--config=/conf/collector-1.yaml",
+		"--config=/conf/collector-2.yaml",
+		"--config=/conf/collector-3.yaml",
+		"--log-level=debug",
+	}, c.Args)
+}
+
 func TestContainerImagePullPolicy(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -556,3 +678,34 @@ func TestContainerLifecycle(t *testing.T) {
 	// verify
 	assert.Equal(t, expectedLifecycleHooks, *c.Lifecycle)
 }
+
+func TestContainerProxy(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Env: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://user-proxy:3128"},
+			},
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+			},
+		},
+	}
+	cfg := config.New(
+		config.WithHTTPProxy("http://proxy:3128"),
+		config.WithHTTPSProxy("http://proxy:3128"),
+		config.WithNoProxy(".svc"),
+	)
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://user-proxy:3128"})
+	assert.NotContains(t, c.Env, corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy:3128"})
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy:3128"})
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,$(KUBERNETES_SERVICE_HOST),my-instance-targetallocator"})
+}
@@ -25,16 +25,47 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
+// DaemonSets builds the daemonsets for the given instance, the one of the instance and one for each node profile. The
+// daemonset of the instance doesn't run on the nodes of the profiles.
+func DaemonSets(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []appsv1.DaemonSet {
+	instanceDaemonSet := DaemonSet(cfg, logger, otelcol)
+	if len(otelcol.Spec.NodeProfiles) == 0 {
+		return []appsv1.DaemonSet{instanceDaemonSet}
+	}
+	instanceDaemonSet.Spec.Template.Spec.Affinity = excludeNodeProfiles(otelcol.Spec.Affinity, otelcol.Spec.NodeProfiles)
+
+	daemonSets := []appsv1.DaemonSet{instanceDaemonSet}
+	for _, profile := range otelcol.Spec.NodeProfiles {
+		instance, err := NodeProfileInstance(otelcol, profile)
+		if err != nil {
+			logger.Error(err, "failed to merge the configuration of the node profile, using the collector's one", "profile", profile.Name)
+		}
+		profileVolumes := volumes(cfg, instance, func(part int) string {
+			return VersionedConfigMap(cfg, instance, naming.ConfigMapNodeProfilePart(otelcol, profile.Name, part))
+		})
+		profileDaemonSet := daemonSet(cfg, logger, instance, naming.CollectorNodeProfile(otelcol, profile.Name), profileVolumes)
+		profileDaemonSet.Labels[NodeProfileLabel] = profile.Name
+		profileDaemonSet.Spec.Selector.MatchLabels[NodeProfileLabel] = profile.Name
+		profileDaemonSet.Spec.Template.Labels[NodeProfileLabel] = profile.Name
+		daemonSets = append(daemonSets, profileDaemonSet)
+	}
+	return daemonSets
+}
+
 // DaemonSet builds the deployment for the given instance.
 func DaemonSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) appsv1.DaemonSet {
-	name := naming.Collector(otelcol)
+	return daemonSet(cfg, logger, otelcol, naming.Collector(otelcol), Volumes(cfg, otelcol))
+}
+
+func daemonSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, name string, volumes []corev1.Volume) appsv1.DaemonSet {
+	otelcol = compatibleInstance(otelcol)
 	labels := Labels(otelcol, name, cfg.LabelsFilter())
 
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(cfg, otelcol)
+	podAnnotations := PodAnnotations(cfg, otelcol)
 	return appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Collector(otelcol),
+			Name:        name,
 			Namespace:   otelcol.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
@@ -45,20 +76,23 @@ func DaemonSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels(otelcol, labels),
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol, true)},
-					Volumes:            Volumes(cfg, otelcol),
-					Tolerations:        otelcol.Spec.Tolerations,
-					NodeSelector:       otelcol.Spec.NodeSelector,
-					HostNetwork:        otelcol.Spec.HostNetwork,
-					DNSPolicy:          getDNSPolicy(otelcol),
-					SecurityContext:    otelcol.Spec.PodSecurityContext,
-					PriorityClassName:  otelcol.Spec.PriorityClassName,
-					Affinity:           otelcol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(otelcol),
+					Containers:                    []corev1.Container{Container(cfg, logger, otelcol, true)},
+					Volumes:                       volumes,
+					Tolerations:                   otelcol.Spec.Tolerations,
+					NodeSelector:                  otelcol.Spec.NodeSelector,
+					HostNetwork:                   otelcol.Spec.HostNetwork,
+					ShareProcessNamespace:         otelcol.Spec.ShareProcessNamespace,
+					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.PodDNSConfig,
+					SecurityContext:               podSecurityContext(otelcol),
+					PriorityClassName:             otelcol.Spec.PriorityClassName,
+					Affinity:                      otelcol.Spec.Affinity,
+					TerminationGracePeriodSeconds: otelcol.Spec.TerminationGracePeriodSeconds,
 				},
 			},
 		},
@@ -276,3 +276,103 @@ func TestDaemonSetAffinity(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.Affinity)
 	assert.Equal(t, *testAffinityValue, *d2.Spec.Template.Spec.Affinity)
 }
+
+func TestDaemonSetTerminationGracePeriodSeconds(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := DaemonSet(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	gracePeriodSec := int64(60)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-terminationGracePeriodSeconds",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TerminationGracePeriodSeconds: &gracePeriodSec,
+		},
+	}
+
+	d2 := DaemonSet(cfg, logger, otelcol2)
+	assert.NotNil(t, d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, gracePeriodSec, *d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestDaemonSetsNodeProfiles(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDaemonSet,
+			Affinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{
+							MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.io/os", Operator: v1.NodeSelectorOpIn, Values: []string{"linux"}}},
+						}},
+					},
+				},
+			},
+			NodeProfiles: []v1alpha1.NodeProfile{
+				{Name: "gpu", NodeSelector: map[string]string{"node-pool": "gpu"}},
+				{Name: "spot", NodeSelector: map[string]string{"lifecycle": "spot", "zone": "a"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	daemonSets := DaemonSets(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, daemonSets, 3)
+
+	// the daemonset of the instance doesn't run on the nodes of the profiles
+	assert.Equal(t, "my-instance-collector", daemonSets[0].Name)
+	notIn := func(key, value string) v1.NodeSelectorRequirement {
+		return v1.NodeSelectorRequirement{Key: key, Operator: v1.NodeSelectorOpNotIn, Values: []string{value}}
+	}
+	linux := v1.NodeSelectorRequirement{Key: "kubernetes.io/os", Operator: v1.NodeSelectorOpIn, Values: []string{"linux"}}
+	assert.Equal(t, []v1.NodeSelectorTerm{
+		{MatchExpressions: []v1.NodeSelectorRequirement{linux, notIn("node-pool", "gpu"), notIn("lifecycle", "spot")}},
+		{MatchExpressions: []v1.NodeSelectorRequirement{linux, notIn("node-pool", "gpu"), notIn("zone", "a")}},
+	}, daemonSets[0].Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	assert.Len(t, otelcol.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+
+	// each profile runs on its nodes with its own configuration
+	for i, profile := range []string{"gpu", "spot"} {
+		ds := daemonSets[i+1]
+		assert.Equal(t, "my-instance-collector-"+profile, ds.Name)
+		assert.Equal(t, profile, ds.Labels[NodeProfileLabel])
+		assert.Equal(t, profile, ds.Spec.Selector.MatchLabels[NodeProfileLabel])
+		assert.Equal(t, profile, ds.Spec.Template.Labels[NodeProfileLabel])
+		assert.Equal(t, otelcol.Spec.NodeProfiles[i].NodeSelector, ds.Spec.Template.Spec.NodeSelector)
+		assert.Equal(t, "my-instance-collector-"+profile, ds.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	}
+}
+
+func TestDaemonSetsNoNodeProfiles(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+	cfg := config.New()
+
+	// test
+	daemonSets := DaemonSets(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, daemonSets, 1)
+	assert.Equal(t, DaemonSet(cfg, logger, otelcol), daemonSets[0])
+}
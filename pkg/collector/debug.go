@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// DebugAnnotation is the annotation of the instances whose collector pods get an ephemeral debug container, set to
+// "true". The image of the container is the debug image of the operator, so that the annotation can't be used to run
+// arbitrary images next to the collectors.
+const DebugAnnotation = "opentelemetry.io/debug"
+
+// DebugContainer returns the ephemeral debug container to attach to the collector pods of the given instance, and
+// whether the instance asks for one. The container shares the process namespace of the collector container and mounts
+// its configuration.
+func DebugContainer(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) (corev1.EphemeralContainer, bool) {
+	if debug, err := strconv.ParseBool(otelcol.Annotations[DebugAnnotation]); err != nil || !debug || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return corev1.EphemeralContainer{}, false
+	}
+
+	return corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:  naming.DebugContainer(),
+			Image: cfg.DebugImage(),
+			Env: []corev1.EnvVar{{
+				Name:  "OTELCOL_CONFIG",
+				Value: fmt.Sprintf("/conf/%s", cfg.CollectorConfigMapEntry()),
+			}},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      naming.ConfigMapVolume(),
+				MountPath: "/conf",
+			}},
+			Stdin: true,
+			TTY:   true,
+		},
+		TargetContainerName: naming.Container(),
+	}, true
+}
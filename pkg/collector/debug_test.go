@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestDebugContainer(t *testing.T) {
+	cfg := config.New(config.WithDebugImage("default-debug-image"))
+
+	for _, tt := range []struct {
+		desc          string
+		annotations   map[string]string
+		mode          v1alpha1.Mode
+		expectedImage string
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc:        "disabled",
+			annotations: map[string]string{"opentelemetry.io/debug": "false"},
+		},
+		{
+			desc:          "default image",
+			annotations:   map[string]string{"opentelemetry.io/debug": "true"},
+			expectedImage: "default-debug-image",
+		},
+		{
+			desc:        "not a boolean",
+			annotations: map[string]string{"opentelemetry.io/debug": "my-debug-image"},
+		},
+		{
+			desc:        "sidecar mode",
+			annotations: map[string]string{"opentelemetry.io/debug": "true"},
+			mode:        v1alpha1.ModeSidecar,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-instance",
+					Annotations: tt.annotations,
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: tt.mode,
+				},
+			}
+
+			container, ok := DebugContainer(cfg, otelcol)
+
+			assert.Equal(t, tt.expectedImage != "", ok)
+			if ok {
+				assert.Equal(t, tt.expectedImage, container.Image)
+				assert.Equal(t, "otc-debug", container.Name)
+				assert.Equal(t, "otc-container", container.TargetContainerName)
+				assert.Equal(t, "/conf", container.VolumeMounts[0].MountPath)
+			}
+		})
+	}
+}
+
+func TestDebugAnnotationNotPropagated(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-instance",
+			Annotations: map[string]string{"opentelemetry.io/debug": "true"},
+		},
+	}
+
+	d := Deployment(config.New(), logger, otelcol)
+
+	assert.NotContains(t, d.Annotations, "opentelemetry.io/debug")
+	assert.NotContains(t, d.Spec.Template.Annotations, "opentelemetry.io/debug")
+}
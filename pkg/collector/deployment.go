@@ -19,6 +19,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -27,11 +28,12 @@ import (
 
 // Deployment builds the deployment for the given instance.
 func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) appsv1.Deployment {
+	otelcol = compatibleInstance(otelcol)
 	name := naming.Collector(otelcol)
 	labels := Labels(otelcol, name, cfg.LabelsFilter())
 
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(cfg, otelcol)
+	podAnnotations := PodAnnotations(cfg, otelcol)
 
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -41,13 +43,14 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: otelcol.Spec.Replicas,
+			Replicas: replicas(otelcol),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabels(otelcol),
 			},
+			Strategy: deploymentStrategy(otelcol),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels(otelcol, labels),
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
@@ -55,10 +58,12 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 					Containers:                    []corev1.Container{Container(cfg, logger, otelcol, true)},
 					Volumes:                       Volumes(cfg, otelcol),
 					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.PodDNSConfig,
 					HostNetwork:                   otelcol.Spec.HostNetwork,
+					ShareProcessNamespace:         otelcol.Spec.ShareProcessNamespace,
 					Tolerations:                   otelcol.Spec.Tolerations,
 					NodeSelector:                  otelcol.Spec.NodeSelector,
-					SecurityContext:               otelcol.Spec.PodSecurityContext,
+					SecurityContext:               podSecurityContext(otelcol),
 					PriorityClassName:             otelcol.Spec.PriorityClassName,
 					Affinity:                      otelcol.Spec.Affinity,
 					TerminationGracePeriodSeconds: otelcol.Spec.TerminationGracePeriodSeconds,
@@ -67,3 +72,26 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 		},
 	}
 }
+
+// deploymentStrategy returns the rolling update strategy keeping the minimum available replicas of the given
+// instance, or the default strategy when the instance doesn't set its availability. New replicas are surged before
+// the old ones are removed when none of the old ones can be unavailable.
+func deploymentStrategy(otelcol v1alpha1.OpenTelemetryCollector) appsv1.DeploymentStrategy {
+	if otelcol.Spec.Availability == nil {
+		return appsv1.DeploymentStrategy{}
+	}
+
+	maxUnavailable := MinReplicas(otelcol) - otelcol.Spec.Availability.MinAvailable
+	if maxUnavailable < 0 {
+		maxUnavailable = 0
+	}
+	unavailable := intstr.FromInt(int(maxUnavailable))
+	surge := intstr.FromString("25%")
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &unavailable,
+			MaxSurge:       &surge,
+		},
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -203,6 +205,29 @@ func TestDeploymentHostNetwork(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.DNSPolicy, v1.DNSClusterFirstWithHostNet)
 }
 
+func TestDeploymentDNS(t *testing.T) {
+	ndots := "2"
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			HostNetwork: true,
+			DNSPolicy:   v1.DNSNone,
+			PodDNSConfig: &v1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Options:     []v1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+
+	assert.Equal(t, v1.DNSNone, d.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, otelcol.Spec.PodDNSConfig, d.Spec.Template.Spec.DNSConfig)
+}
+
 func TestDeploymentFilterLabels(t *testing.T) {
 	excludedLabels := map[string]string{
 		"foo":         "1",
@@ -346,3 +371,87 @@ func TestDeploymentTerminationGracePeriodSeconds(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
 	assert.Equal(t, gracePeriodSec, *d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
 }
+
+func TestDeploymentShareProcessNamespace(t *testing.T) {
+	shareProcessNamespace := true
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ShareProcessNamespace: &shareProcessNamespace,
+		},
+	}
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+
+	assert.Equal(t, &shareProcessNamespace, d.Spec.Template.Spec.ShareProcessNamespace)
+}
+
+func TestDeploymentStrategy(t *testing.T) {
+	three := int32(3)
+	five := int32(5)
+
+	for _, tt := range []struct {
+		desc                   string
+		spec                   v1alpha1.OpenTelemetryCollectorSpec
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			desc: "default",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{Replicas: &three},
+		},
+		{
+			desc: "some replicas can be unavailable",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Replicas:     &three,
+				Availability: &v1alpha1.AvailabilitySpec{MinAvailable: 2},
+			},
+			expectedMaxUnavailable: &intstr.IntOrString{IntVal: 1},
+		},
+		{
+			desc: "no replica can be unavailable",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Replicas:     &three,
+				Availability: &v1alpha1.AvailabilitySpec{MinAvailable: 3},
+			},
+			expectedMaxUnavailable: &intstr.IntOrString{IntVal: 0},
+		},
+		{
+			desc: "autoscaler min replicas",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Replicas: &five,
+				Autoscaler: &v1alpha1.AutoscalerSpec{
+					MinReplicas: &three,
+					MaxReplicas: &five,
+				},
+				Availability: &v1alpha1.AvailabilitySpec{MinAvailable: 3},
+			},
+			expectedMaxUnavailable: &intstr.IntOrString{IntVal: 0},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-instance",
+				},
+				Spec: tt.spec,
+			}
+			cfg := config.New()
+
+			// test
+			d := Deployment(cfg, logger, otelcol)
+
+			// verify
+			if tt.expectedMaxUnavailable == nil {
+				assert.Equal(t, appsv1.DeploymentStrategy{}, d.Spec.Strategy)
+				return
+			}
+			assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, d.Spec.Strategy.Type)
+			assert.Equal(t, tt.expectedMaxUnavailable, d.Spec.Strategy.RollingUpdate.MaxUnavailable)
+			assert.Equal(t, "25%", d.Spec.Strategy.RollingUpdate.MaxSurge.String())
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// exporterTLSVolumes returns the volumes mounting the certificates of the exporters of the given instance. Only the
+// keys of the certificates are mounted, so that the kubelet doesn't start pods whose secrets miss one of them.
+func exporterTLSVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumes []corev1.Volume
+	for _, exporter := range sortedNames(otelcol.Spec.ExporterTLS) {
+		tls := otelcol.Spec.ExporterTLS[exporter]
+		var items []corev1.KeyToPath
+		if len(tls.CAKey) > 0 {
+			items = append(items, corev1.KeyToPath{Key: tls.CAKey, Path: tlsCAFile})
+		}
+		if len(tls.CertKey) > 0 && len(tls.KeyKey) > 0 {
+			items = append(items,
+				corev1.KeyToPath{Key: tls.CertKey, Path: tlsCertFile},
+				corev1.KeyToPath{Key: tls.KeyKey, Path: tlsKeyFile},
+			)
+		}
+		volumes = append(volumes, tlsVolume(naming.ExporterTLSVolume(exporter), tls.SecretName, items))
+	}
+	return volumes
+}
+
+// exporterTLSVolumeMounts returns the mounts of the certificate volumes of the given instance.
+func exporterTLSVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, exporter := range sortedNames(otelcol.Spec.ExporterTLS) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.ExporterTLSVolume(exporter),
+			MountPath: exporterTLSDir(exporter),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+// exporterTLSConfig sets the paths of the mounted certificates in the tls settings of the exporters of the given
+// configuration. The other tls settings are kept.
+func exporterTLSConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	if len(otelcol.Spec.ExporterTLS) == 0 || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+	exporters, err := configSection(config, "exporters")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range sortedNames(otelcol.Spec.ExporterTLS) {
+		if _, ok := exporters[name]; !ok {
+			return "", fmt.Errorf("the exporter %s of exporterTLS isn't configured", name)
+		}
+		exporter, err := configSection(exporters, name)
+		if err != nil {
+			return "", err
+		}
+		tls, err := configSection(exporter, "tls")
+		if err != nil {
+			return "", err
+		}
+
+		spec := otelcol.Spec.ExporterTLS[name]
+		dir := exporterTLSDir(name)
+		if len(spec.CAKey) > 0 {
+			tls["ca_file"] = path.Join(dir, tlsCAFile)
+		}
+		if len(spec.CertKey) > 0 && len(spec.KeyKey) > 0 {
+			tls["cert_file"] = path.Join(dir, tlsCertFile)
+			tls["key_file"] = path.Join(dir, tlsKeyFile)
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// exporterTLSDir returns the directory the certificates of the given exporter are mounted in.
+func exporterTLSDir(exporter string) string {
+	return path.Join(tlsPath, "exporters", naming.DNSName(exporter))
+}
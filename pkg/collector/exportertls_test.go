@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func exporterTLSInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			ExporterTLS: map[string]v1alpha1.ExporterTLSSpec{
+				"otlp":          {SecretName: "backend-ca", CAKey: "ca.crt"},
+				"otlphttp/mtls": {SecretName: "backend-client", CAKey: "ca.crt", CertKey: "tls.crt", KeyKey: "tls.key"},
+			},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    tls:
+      insecure_skip_verify: true
+  otlphttp/mtls:
+    endpoint: https://backend:4318
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp/mtls]
+`,
+		},
+	}
+}
+
+func TestExporterTLSVolumes(t *testing.T) {
+	otelcol := exporterTLSInstance()
+
+	volumes := Volumes(config.New(), otelcol)
+	require.Len(t, volumes, 3)
+	assert.Equal(t, corev1.Volume{
+		Name: "exporter-tls-otlp",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "backend-ca",
+				Items: []corev1.KeyToPath{
+					{Key: "ca.crt", Path: "ca.crt"},
+				},
+			},
+		},
+	}, volumes[1])
+	assert.Equal(t, "exporter-tls-otlphttp-mtls", volumes[2].Name)
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: "ca.crt", Path: "ca.crt"},
+		{Key: "tls.crt", Path: "tls.crt"},
+		{Key: "tls.key", Path: "tls.key"},
+	}, volumes[2].Secret.Items)
+
+	container := Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "exporter-tls-otlphttp-mtls", MountPath: "/etc/otelcol/tls/exporters/otlphttp-mtls", ReadOnly: true})
+
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	assert.Len(t, Volumes(config.New(), otelcol), 1)
+}
+
+func TestExporterTLSConfig(t *testing.T) {
+	otelcol := exporterTLSInstance()
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	exporters := cfg["exporters"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"ca_file":              "/etc/otelcol/tls/exporters/otlp/ca.crt",
+		"insecure_skip_verify": true,
+	}, exporters["otlp"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{
+		"ca_file":   "/etc/otelcol/tls/exporters/otlphttp-mtls/ca.crt",
+		"cert_file": "/etc/otelcol/tls/exporters/otlphttp-mtls/tls.crt",
+		"key_file":  "/etc/otelcol/tls/exporters/otlphttp-mtls/tls.key",
+	}, exporters["otlphttp/mtls"].(map[string]interface{})["tls"])
+}
+
+func TestExporterTLSConfigWithoutExporter(t *testing.T) {
+	otelcol := exporterTLSInstance()
+	otelcol.Spec.ExporterTLS = map[string]v1alpha1.ExporterTLSSpec{"kafka": {SecretName: "kafka-ca", CAKey: "ca.crt"}}
+
+	_, err := PresetConfig(config.New(), otelcol)
+	assert.ErrorContains(t, err, "the exporter kafka of exporterTLS isn't configured")
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// exporterTokenPath is the directory the ServiceAccount tokens of the exporters are projected in.
+	exporterTokenPath = "/var/run/secrets/otelcol/tokens"
+	exporterTokenFile = "token"
+)
+
+// exporterTokenVolumes returns the volumes projecting the ServiceAccount tokens of the exporters of the given instance.
+func exporterTokenVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumes []corev1.Volume
+	for _, exporter := range sortedNames(otelcol.Spec.ExporterTokens) {
+		token := otelcol.Spec.ExporterTokens[exporter]
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.ExporterTokenVolume(exporter),
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          token.Audience,
+							ExpirationSeconds: token.ExpirationSeconds,
+							Path:              exporterTokenFile,
+						},
+					}},
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// exporterTokenVolumeMounts returns the mounts of the token volumes of the given instance.
+func exporterTokenVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, exporter := range sortedNames(otelcol.Spec.ExporterTokens) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.ExporterTokenVolume(exporter),
+			MountPath: path.Join(exporterTokenPath, naming.DNSName(exporter)),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+// exporterTokenConfig adds a bearertokenauth extension reading the projected token of each exporter of the given
+// instance, enables it in the service and sets it as the authenticator of the exporter.
+func exporterTokenConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	if len(otelcol.Spec.ExporterTokens) == 0 || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+	exporters, err := configSection(config, "exporters")
+	if err != nil {
+		return "", err
+	}
+	extensions, err := configSection(config, "extensions")
+	if err != nil {
+		return "", err
+	}
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range sortedNames(otelcol.Spec.ExporterTokens) {
+		if _, ok := exporters[name]; !ok {
+			return "", fmt.Errorf("the exporter %s of exporterTokens isn't configured", name)
+		}
+		exporter, err := configSection(exporters, name)
+		if err != nil {
+			return "", err
+		}
+		auth, err := configSection(exporter, "auth")
+		if err != nil {
+			return "", err
+		}
+
+		extension := exporterTokenExtension(name)
+		if authenticator, ok := auth["authenticator"]; ok && authenticator != extension {
+			return "", fmt.Errorf("the exporter %s of exporterTokens already authenticates with %v", name, authenticator)
+		}
+		if _, ok := extensions[extension]; ok {
+			return "", fmt.Errorf("the %s extension is already configured, it can't be used along with exporterTokens", extension)
+		}
+		extensions[extension] = map[string]interface{}{
+			"filename": path.Join(exporterTokenPath, naming.DNSName(name), exporterTokenFile),
+		}
+		auth["authenticator"] = extension
+		service["extensions"] = appendComponentName(service["extensions"], extension)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// exporterTokenExtension returns the name of the bearertokenauth extension authenticating the given exporter.
+func exporterTokenExtension(exporter string) string {
+	return fmt.Sprintf("bearertokenauth/%s", naming.DNSName(exporter))
+}
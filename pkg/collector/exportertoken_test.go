@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func exporterTokenInstance() v1alpha1.OpenTelemetryCollector {
+	expiration := int64(7200)
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			ExporterTokens: map[string]v1alpha1.ExporterTokenSpec{
+				"otlphttp/gateway": {Audience: "gateway", ExpirationSeconds: &expiration},
+			},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlphttp/gateway:
+    endpoint: https://gateway.observability.svc:8443
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlphttp/gateway]
+`,
+		},
+	}
+}
+
+func TestExporterTokenVolumes(t *testing.T) {
+	otelcol := exporterTokenInstance()
+
+	volumes := Volumes(config.New(), otelcol)
+	require.Len(t, volumes, 2)
+	expiration := int64(7200)
+	assert.Equal(t, corev1.Volume{
+		Name: "exporter-token-otlphttp-gateway",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "gateway",
+						ExpirationSeconds: &expiration,
+						Path:              "token",
+					},
+				}},
+			},
+		},
+	}, volumes[1])
+
+	container := Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "exporter-token-otlphttp-gateway", MountPath: "/var/run/secrets/otelcol/tokens/otlphttp-gateway", ReadOnly: true})
+
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	assert.Len(t, Volumes(config.New(), otelcol), 1)
+}
+
+func TestExporterTokenConfig(t *testing.T) {
+	otelcol := exporterTokenInstance()
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"filename": "/var/run/secrets/otelcol/tokens/otlphttp-gateway/token",
+	}, cfg["extensions"].(map[string]interface{})["bearertokenauth/otlphttp-gateway"])
+	assert.Equal(t, []interface{}{"health_check", "bearertokenauth/otlphttp-gateway"}, cfg["service"].(map[string]interface{})["extensions"])
+	exporter := cfg["exporters"].(map[string]interface{})["otlphttp/gateway"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"authenticator": "bearertokenauth/otlphttp-gateway"}, exporter["auth"])
+	assert.Equal(t, "https://gateway.observability.svc:8443", exporter["endpoint"])
+}
+
+func TestExporterTokenConfigWithAuthenticator(t *testing.T) {
+	otelcol := exporterTokenInstance()
+	otelcol.Spec.Config = `exporters:
+  otlphttp/gateway:
+    auth:
+      authenticator: oauth2client
+`
+
+	_, err := PresetConfig(config.New(), otelcol)
+	assert.ErrorContains(t, err, "the exporter otlphttp/gateway of exporterTokens already authenticates with oauth2client")
+}
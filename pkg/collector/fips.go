@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// fipsCipherSuitesVersion is the first collector version whose tls settings have the cipher_suites.
+var fipsCipherSuitesVersion = semver.MustParse("0.83.0")
+
+// fipsConfig returns the given configuration with the FIPS minimum TLS version and cipher suites set in the tls
+// settings of its components that don't set them, when the operator runs in FIPS mode. The cipher suites are only set
+// for the collectors supporting them, or whose version isn't known.
+func fipsConfig(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, presetConfig string) (string, error) {
+	if !featuregate.EnableFIPS.IsEnabled() {
+		return presetConfig, nil
+	}
+
+	config, err := adapters.ConfigFromString(presetConfig)
+	if err != nil {
+		return "", err
+	}
+	version := Version(cfg, otelcol)
+	adapters.ApplyFIPSTLSDefaults(config, version == nil || !version.LessThan(fipsCipherSuitesVersion))
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
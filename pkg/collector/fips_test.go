@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestPresetConfigFIPS(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+          key_file: /certs/tls.key
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+		},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		originalVal := featuregate.EnableFIPS.IsEnabled()
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), enabled))
+
+		cfg, err := PresetConfig(config.New(), otelcol)
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), originalVal))
+		require.NoError(t, err)
+
+		config, err := adapters.ConfigFromString(cfg)
+		require.NoError(t, err)
+		tls := config["receivers"].(map[string]interface{})["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["tls"].(map[string]interface{})
+		if enabled {
+			assert.Equal(t, adapters.FIPSMinTLSVersion, tls["min_version"])
+			assert.Len(t, tls["cipher_suites"], len(adapters.FIPSCipherSuites))
+		} else {
+			assert.NotContains(t, tls, "min_version")
+			assert.NotContains(t, tls, "cipher_suites")
+		}
+	}
+}
+
+func TestPresetConfigFIPSCipherSuites(t *testing.T) {
+	originalVal := featuregate.EnableFIPS.IsEnabled()
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableFIPS.ID(), originalVal))
+	})
+
+	for _, tt := range []struct {
+		image        string
+		defaultImage string
+		cipherSuites bool
+	}{
+		{image: "otel/opentelemetry-collector-contrib:0.77.0"},
+		{image: "otel/opentelemetry-collector-contrib:0.83.0", cipherSuites: true},
+		{image: "otel/opentelemetry-collector-contrib:latest", cipherSuites: true},
+		// the instances without an image run the one of the operator's configuration
+		{defaultImage: "otel/opentelemetry-collector-contrib:0.77.0"},
+		{defaultImage: "otel/opentelemetry-collector-contrib:0.83.0", cipherSuites: true},
+	} {
+		t.Run(tt.image+tt.defaultImage, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Image: tt.image,
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+          key_file: /certs/tls.key
+`,
+				},
+			}
+
+			cfg, err := PresetConfig(config.New(config.WithCollectorImage(tt.defaultImage)), otelcol)
+			require.NoError(t, err)
+
+			config, err := adapters.ConfigFromString(cfg)
+			require.NoError(t, err)
+			tls := config["receivers"].(map[string]interface{})["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["tls"].(map[string]interface{})
+			assert.Equal(t, adapters.FIPSMinTLSVersion, tls["min_version"])
+			if tt.cipherSuites {
+				assert.Len(t, tls["cipher_suites"], len(adapters.FIPSCipherSuites))
+			} else {
+				assert.NotContains(t, tls, "cipher_suites")
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// nodeReceivers are the receivers collecting data of the node they run on, which run in daemonset mode.
+var nodeReceivers = map[string]bool{
+	"filelog":      true,
+	"hostmetrics":  true,
+	"journald":     true,
+	"kubeletstats": true,
+}
+
+// componentRules are the permissions the Kubernetes API components need, by component type.
+var componentRules = map[string][]rbacv1.PolicyRule{
+	"k8s_cluster": {
+		{
+			APIGroups: []string{""},
+			Resources: []string{"events", "namespaces", "namespaces/status", "nodes", "nodes/spec", "pods", "pods/status", "replicationcontrollers", "replicationcontrollers/status", "resourcequotas", "services"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"batch"},
+			Resources: []string{"cronjobs", "jobs"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"autoscaling"},
+			Resources: []string{"horizontalpodautoscalers"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	},
+	"k8s_events": {{
+		APIGroups: []string{""},
+		Resources: []string{"events", "namespaces"},
+		Verbs:     []string{"get", "list", "watch"},
+	}},
+	"k8sattributes": {
+		{
+			APIGroups: []string{""},
+			Resources: []string{"namespaces", "pods"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"replicasets"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	},
+	"kubeletstats": {{
+		APIGroups: []string{""},
+		Resources: []string{"nodes/stats", "nodes/proxy"},
+		Verbs:     []string{"get"},
+	}},
+}
+
+// Generated is an instance generated from a collector configuration, along with what the operator infers from it.
+type Generated struct {
+	// Instance is the generated instance.
+	Instance v1alpha1.OpenTelemetryCollector
+
+	// Ports are the ports the operator opens for the receivers of the configuration.
+	Ports []corev1.ServicePort
+
+	// ClusterRole holds the permissions the receivers and processors of the configuration need, if any, and
+	// ClusterRoleBinding grants them to the instance's service account.
+	ClusterRole        *rbacv1.ClusterRole
+	ClusterRoleBinding *rbacv1.ClusterRoleBinding
+
+	// Hints are notes on the settings the instance may need.
+	Hints []string
+}
+
+// FromConfig generates an instance running the given collector configuration. When the mode is empty, it's inferred
+// from the receivers: daemonset for the receivers collecting node data, statefulset with a target allocator for the
+// prometheus receiver, and deployment otherwise.
+func FromConfig(logger logr.Logger, name, namespace, config string, mode v1alpha1.Mode) (Generated, error) {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return Generated{}, fmt.Errorf("failed to parse the configuration: %w", err)
+	}
+
+	receivers := componentTypes(enabledReceivers(logger, cfg))
+	processors := componentTypes(configuredComponents(cfg, "processors"))
+	extensions := componentTypes(configuredComponents(cfg, "extensions"))
+
+	if len(mode) == 0 {
+		mode = inferMode(receivers)
+	}
+
+	generated := Generated{
+		Instance: v1alpha1.OpenTelemetryCollector{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "OpenTelemetryCollector",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:   mode,
+				Config: config,
+			},
+		},
+	}
+	otelcol := &generated.Instance
+
+	if receivers["prometheus"] {
+		if mode == v1alpha1.ModeStatefulSet {
+			otelcol.Spec.TargetAllocator.Enabled = true
+			generated.Hints = append(generated.Hints, "the scrape jobs of the prometheus receiver are distributed over the collectors by the target allocator, set targetAllocator.prometheusCR.enabled to also scrape the targets of ServiceMonitors and PodMonitors")
+		} else {
+			generated.Hints = append(generated.Hints, fmt.Sprintf("the prometheus receiver scrapes all the targets from every collector in %s mode, use the statefulset mode to distribute them with the target allocator", mode))
+		}
+	}
+	if receivers["receiver_creator"] && extensions["k8s_observer"] && mode != v1alpha1.ModeSidecar {
+		generated.Hints = append(generated.Hints, "the k8s_observer extension of the receiver creator can be configured by the operator with receiverCreator.enabled, which also grants it the permissions it needs")
+	}
+	if mode != v1alpha1.ModeDaemonSet {
+		for _, receiver := range sortedKeys(receivers) {
+			if nodeReceivers[receiver] {
+				generated.Hints = append(generated.Hints, fmt.Sprintf("the %s receiver collects the data of the node the collector runs on, use the daemonset mode to collect the data of every node", receiver))
+			}
+		}
+	}
+	if receivers["k8sobjects"] {
+		generated.Hints = append(generated.Hints, "the k8sobjects receiver needs the permissions to list and watch the objects it collects, which depend on its configuration")
+	}
+	for _, ref := range adapters.ConfigToSecretReferences(config) {
+		generated.Hints = append(generated.Hints, fmt.Sprintf("the configuration refers to the secret key %s, which the operator injects as the %s environment variable", ref, ref.EnvVar()))
+	}
+
+	if mode != v1alpha1.ModeSidecar {
+		ports, err := adapters.ConfigToReceiverPorts(logger, cfg)
+		if err != nil {
+			logger.V(2).Info("couldn't infer the ports of the receivers", "reason", err)
+		}
+		generated.Ports = ports
+	}
+
+	var rules []rbacv1.PolicyRule
+	for _, component := range sortedKeys(mergeSets(receivers, processors)) {
+		rules = append(rules, componentRules[component]...)
+	}
+	if len(rules) > 0 {
+		roleName := naming.ClusterObject(namespace, name, "collector-components")
+		generated.ClusterRole = &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRole",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: roleName},
+			Rules:      rules,
+		}
+		generated.ClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: roleName},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      ServiceAccountName(*otelcol),
+				Namespace: namespace,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleName,
+			},
+		}
+	}
+
+	return generated, nil
+}
+
+func inferMode(receivers map[string]bool) v1alpha1.Mode {
+	for receiver := range receivers {
+		if nodeReceivers[receiver] {
+			return v1alpha1.ModeDaemonSet
+		}
+	}
+	if receivers["prometheus"] {
+		return v1alpha1.ModeStatefulSet
+	}
+	return v1alpha1.ModeDeployment
+}
+
+func enabledReceivers(logger logr.Logger, cfg map[string]interface{}) []string {
+	var names []string
+	for name, enabled := range adapters.GetEnabledReceivers(logger, cfg) {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func configuredComponents(cfg map[string]interface{}, kind string) []string {
+	components, ok := cfg[kind].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var names []string
+	for name := range components {
+		names = append(names, name)
+	}
+	return names
+}
+
+// componentTypes returns the types of the named components, e.g. otlp for otlp/internal.
+func componentTypes(names []string) map[string]bool {
+	types := map[string]bool{}
+	for _, name := range names {
+		componentType, _, _ := strings.Cut(name, "/")
+		types[componentType] = true
+	}
+	return types
+}
+
+func mergeSets(sets ...map[string]bool) map[string]bool {
+	merged := map[string]bool{}
+	for _, set := range sets {
+		for k, v := range set {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestFromConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		config       string
+		mode         v1alpha1.Mode
+		expectedMode v1alpha1.Mode
+		expectedTA   bool
+		expectedRole bool
+		expectedHint string
+	}{
+		{
+			desc: "deployment by default",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			expectedMode: v1alpha1.ModeDeployment,
+		},
+		{
+			desc: "daemonset for node receivers",
+			config: `receivers:
+  hostmetrics:
+  kubeletstats:
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [hostmetrics, kubeletstats]
+      exporters: [debug]
+`,
+			expectedMode: v1alpha1.ModeDaemonSet,
+			expectedRole: true,
+		},
+		{
+			desc: "statefulset with target allocator for prometheus",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+        static_configs:
+        - targets: [localhost:8888]
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`,
+			expectedMode: v1alpha1.ModeStatefulSet,
+			expectedTA:   true,
+			expectedHint: "target allocator",
+		},
+		{
+			desc: "prometheus in the given mode",
+			config: `receivers:
+  prometheus/self:
+    config:
+      scrape_configs:
+      - job_name: self
+        static_configs:
+        - targets: [localhost:8888]
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus/self]
+      exporters: [debug]
+`,
+			mode:         v1alpha1.ModeDeployment,
+			expectedMode: v1alpha1.ModeDeployment,
+			expectedHint: "use the statefulset mode",
+		},
+		{
+			desc: "permissions of the k8sattributes processor",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  k8sattributes/pods:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes/pods]
+      exporters: [debug]
+`,
+			expectedMode: v1alpha1.ModeDeployment,
+			expectedRole: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			generated, err := FromConfig(logger, "my-collector", "observability", tt.config, tt.mode)
+			require.NoError(t, err)
+
+			otelcol := generated.Instance
+			assert.Equal(t, "my-collector", otelcol.Name)
+			assert.Equal(t, "observability", otelcol.Namespace)
+			assert.Equal(t, tt.config, otelcol.Spec.Config)
+			assert.Equal(t, tt.expectedMode, otelcol.Spec.Mode)
+			assert.Equal(t, tt.expectedTA, otelcol.Spec.TargetAllocator.Enabled)
+
+			if tt.expectedRole {
+				require.NotNil(t, generated.ClusterRole)
+				assert.Equal(t, "my-collector-observability-collector-components-648e11eb", generated.ClusterRole.Name)
+				assert.NotEmpty(t, generated.ClusterRole.Rules)
+				require.NotNil(t, generated.ClusterRoleBinding)
+				assert.Equal(t, "my-collector-collector", generated.ClusterRoleBinding.Subjects[0].Name)
+				assert.Equal(t, generated.ClusterRole.Name, generated.ClusterRoleBinding.RoleRef.Name)
+			} else {
+				assert.Nil(t, generated.ClusterRole)
+			}
+			if tt.expectedHint != "" {
+				assert.Condition(t, func() bool {
+					for _, hint := range generated.Hints {
+						if strings.Contains(hint, tt.expectedHint) {
+							return true
+						}
+					}
+					return false
+				}, "no hint contains %q: %v", tt.expectedHint, generated.Hints)
+			}
+		})
+	}
+}
+
+func TestFromConfigPorts(t *testing.T) {
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+	generated, err := FromConfig(logger, "my-collector", "observability", config, "")
+	require.NoError(t, err)
+
+	require.Len(t, generated.Ports, 1)
+	assert.Equal(t, int32(4317), generated.Ports[0].Port)
+}
+
+func TestFromConfigInvalid(t *testing.T) {
+	_, err := FromConfig(logger, "my-collector", "observability", "}", "")
+	assert.ErrorContains(t, err, "failed to parse the configuration")
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// guardrailsConfig adds the processors of the guardrails preset to the given configuration, and to all of its
+// pipelines: the memory_limiter first, then the filter and the probabilistic sampler of the signal of the pipeline,
+// the processors of the pipeline, and the batch processor last, except in the pipelines already batching and in the
+// profiles pipelines it doesn't support.
+func guardrailsConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	spec := otelcol.Spec.Guardrails
+	if spec == nil {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	processors, err := configSection(config, "processors")
+	if err != nil {
+		return "", err
+	}
+	for name, processor := range guardrailsProcessors(*spec) {
+		if _, ok := processors[name]; ok {
+			return "", fmt.Errorf("the %s processor is already configured, it can't be used along with the guardrails preset", name)
+		}
+		processors[name] = processor
+	}
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+	pipelines, err := configSection(service, "pipelines")
+	if err != nil {
+		return "", err
+	}
+	for name, p := range pipelines {
+		pipeline, ok := p.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("the %s pipeline isn't a map", name)
+		}
+		signal := strings.SplitN(name, "/", 2)[0]
+
+		names := []interface{}{v1alpha1.GuardrailsMemoryLimiter}
+		if guardrailsFilters(spec.Filter, signal) {
+			names = append(names, v1alpha1.GuardrailsFilter)
+		}
+		if signal == "traces" && spec.TraceSamplingPercentage != nil {
+			names = append(names, v1alpha1.GuardrailsSampler)
+		}
+		existing, _ := pipeline["processors"].([]interface{})
+		names = append(names, existing...)
+		if signal != "profiles" && !hasBatchProcessor(existing) {
+			names = append(names, v1alpha1.GuardrailsBatch)
+		}
+		pipeline["processors"] = names
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// guardrailsProcessors returns the processors of the guardrails preset, by name.
+func guardrailsProcessors(spec v1alpha1.GuardrailsSpec) map[string]interface{} {
+	// the batch processor keeps its own defaults for the settings the preset doesn't set
+	batch := map[string]interface{}{}
+	if spec.SendBatchSize != nil {
+		batch["send_batch_size"] = *spec.SendBatchSize
+	}
+	if spec.SendBatchMaxSize != nil {
+		batch["send_batch_max_size"] = *spec.SendBatchMaxSize
+	}
+	if spec.BatchTimeout != nil {
+		batch["timeout"] = spec.BatchTimeout.Duration.String()
+	}
+
+	processors := map[string]interface{}{
+		v1alpha1.GuardrailsMemoryLimiter: map[string]interface{}{
+			"check_interval":         "1s",
+			"limit_percentage":       spec.EffectiveMemoryLimitPercentage(),
+			"spike_limit_percentage": spec.EffectiveMemorySpikeLimitPercentage(),
+		},
+		v1alpha1.GuardrailsBatch: batch,
+	}
+
+	if spec.TraceSamplingPercentage != nil {
+		processors[v1alpha1.GuardrailsSampler] = map[string]interface{}{
+			"sampling_percentage": *spec.TraceSamplingPercentage,
+		}
+	}
+
+	if filter := spec.Filter; filter != nil {
+		conditions := map[string]interface{}{
+			"error_mode": "ignore",
+		}
+		if len(filter.Spans) > 0 {
+			conditions["traces"] = map[string]interface{}{"span": stringsToInterfaces(filter.Spans)}
+		}
+		if len(filter.Metrics) > 0 {
+			conditions["metrics"] = map[string]interface{}{"metric": stringsToInterfaces(filter.Metrics)}
+		}
+		if len(filter.LogRecords) > 0 {
+			conditions["logs"] = map[string]interface{}{"log_record": stringsToInterfaces(filter.LogRecords)}
+		}
+		if len(conditions) > 1 {
+			processors[v1alpha1.GuardrailsFilter] = conditions
+		}
+	}
+
+	return processors
+}
+
+// hasBatchProcessor returns whether the given processors of a pipeline include a batch processor.
+func hasBatchProcessor(processors []interface{}) bool {
+	for _, processor := range processors {
+		name, _ := processor.(string)
+		if name == "batch" || strings.HasPrefix(name, "batch/") {
+			return true
+		}
+	}
+	return false
+}
+
+// guardrailsFilters returns whether the filter of the guardrails preset has conditions for the given signal.
+func guardrailsFilters(filter *v1alpha1.GuardrailsFilterSpec, signal string) bool {
+	if filter == nil {
+		return false
+	}
+	switch signal {
+	case "traces":
+		return len(filter.Spans) > 0
+	case "metrics":
+		return len(filter.Metrics) > 0
+	case "logs":
+		return len(filter.LogRecords) > 0
+	}
+	return false
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	out := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+	return out
+}
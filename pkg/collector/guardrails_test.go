@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func guardrailsInstance(guardrails v1alpha1.GuardrailsSpec) v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:       v1alpha1.ModeDeployment,
+			Guardrails: &guardrails,
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  attributes:
+    actions: []
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [attributes]
+      exporters: [logging]
+    metrics/otlp:
+      receivers: [otlp]
+      exporters: [logging]
+    logs:
+      receivers: [otlp]
+      exporters: [logging]
+    profiles:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+		},
+	}
+}
+
+func TestGuardrailsConfig(t *testing.T) {
+	// prepare
+	limit := int32(75)
+	sendBatchSize := int32(1000)
+	sampling := int32(10)
+	otelcol := guardrailsInstance(v1alpha1.GuardrailsSpec{
+		MemoryLimitPercentage:   &limit,
+		SendBatchSize:           &sendBatchSize,
+		BatchTimeout:            &metav1.Duration{Duration: 5 * time.Second},
+		TraceSamplingPercentage: &sampling,
+		Filter: &v1alpha1.GuardrailsFilterSpec{
+			Spans: []string{`attributes["http.route"] == "/healthz"`},
+		},
+	})
+
+	// test
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	processors := cfg["processors"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"check_interval":         "1s",
+		"limit_percentage":       75,
+		"spike_limit_percentage": 25,
+	}, processors["memory_limiter/guardrails"])
+	assert.Equal(t, map[string]interface{}{
+		"send_batch_size": 1000,
+		"timeout":         "5s",
+	}, processors["batch/guardrails"])
+	assert.Equal(t, map[string]interface{}{"sampling_percentage": 10}, processors["probabilistic_sampler/guardrails"])
+	assert.Equal(t, map[string]interface{}{
+		"error_mode": "ignore",
+		"traces": map[string]interface{}{
+			"span": []interface{}{`attributes["http.route"] == "/healthz"`},
+		},
+	}, processors["filter/guardrails"])
+
+	pipelines := cfg["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails", "filter/guardrails", "probabilistic_sampler/guardrails", "attributes", "batch/guardrails"},
+		pipelines["traces"].(map[string]interface{})["processors"])
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails", "batch/guardrails"}, pipelines["metrics/otlp"].(map[string]interface{})["processors"])
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails", "batch/guardrails"}, pipelines["logs"].(map[string]interface{})["processors"])
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails"}, pipelines["profiles"].(map[string]interface{})["processors"])
+}
+
+func TestGuardrailsConfigDefaults(t *testing.T) {
+	// prepare
+	otelcol := guardrailsInstance(v1alpha1.GuardrailsSpec{})
+
+	// test
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+	processors := cfg["processors"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"check_interval":         "1s",
+		"limit_percentage":       80,
+		"spike_limit_percentage": 25,
+	}, processors["memory_limiter/guardrails"])
+	assert.Equal(t, map[string]interface{}{}, processors["batch/guardrails"])
+	assert.NotContains(t, processors, "filter/guardrails")
+	assert.NotContains(t, processors, "probabilistic_sampler/guardrails")
+}
+
+func TestGuardrailsConfigBatchingPipeline(t *testing.T) {
+	// prepare
+	otelcol := guardrailsInstance(v1alpha1.GuardrailsSpec{})
+	otelcol.Spec.Config = strings.Replace(otelcol.Spec.Config, "processors: [attributes]", "processors: [attributes, batch/traces]", 1)
+
+	// test
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+	pipelines := cfg["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails", "attributes", "batch/traces"}, pipelines["traces"].(map[string]interface{})["processors"])
+	assert.Equal(t, []interface{}{"memory_limiter/guardrails", "batch/guardrails"}, pipelines["logs"].(map[string]interface{})["processors"])
+}
+
+func TestGuardrailsConfigConflict(t *testing.T) {
+	// prepare
+	otelcol := guardrailsInstance(v1alpha1.GuardrailsSpec{})
+	otelcol.Spec.Config = `processors:
+  batch/guardrails:
+service:
+  pipelines: {}
+`
+
+	// test
+	_, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	assert.ErrorContains(t, err, "the batch/guardrails processor is already configured, it can't be used along with the guardrails preset")
+}
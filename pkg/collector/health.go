@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// ConditionTypeHealthy is the type of the status condition aggregating the failures of the collector pods.
+const ConditionTypeHealthy = "Healthy"
+
+// Reasons of the Healthy condition, from the most to the least severe.
+const (
+	ReasonImagePullBackOff = "ImagePullBackOff"
+	ReasonCrashLoopBackOff = "CrashLoopBackOff"
+	ReasonNoPods           = "NoPods"
+	ReasonOOMKilled        = "OOMKilled"
+	ReasonPodsNotReady     = "PodsNotReady"
+	ReasonPodsHealthy      = "PodsHealthy"
+)
+
+// maxLogExcerpt is the number of bytes kept from the end of the termination message of a crashing container.
+const maxLogExcerpt = 512
+
+// Health aggregates the state of the given collector pods into the Healthy condition of the instance. The log excerpt
+// of crashing containers comes from their termination message, which falls back to the end of their logs.
+func Health(otelcol v1alpha1.OpenTelemetryCollector, pods []corev1.Pod) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ConditionTypeHealthy,
+		ObservedGeneration: otelcol.Generation,
+	}
+
+	// sorting the pods keeps the message stable between reconciliations
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var (
+		imagePull   []string
+		crashLoop   []string
+		excerpt     string
+		oomKilled   int
+		notReady    int
+		restarts    int32
+		running     int
+		imageReason string
+	)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		running++
+		if !podReady(pod) {
+			notReady++
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			restarts += status.RestartCount
+			if terminatedReason(status) == ReasonOOMKilled {
+				oomKilled++
+			}
+			if status.State.Waiting == nil {
+				continue
+			}
+			switch status.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				imagePull = append(imagePull, pod.Name)
+				if imageReason == "" {
+					imageReason = status.State.Waiting.Message
+				}
+			case "CrashLoopBackOff":
+				crashLoop = append(crashLoop, pod.Name)
+				if excerpt == "" && status.LastTerminationState.Terminated != nil {
+					excerpt = tail(status.LastTerminationState.Terminated.Message, maxLogExcerpt)
+				}
+			}
+		}
+	}
+
+	var messages []string
+	if len(imagePull) > 0 {
+		messages = append(messages, fmt.Sprintf("%d of %d pods can't pull their image (%s): %s", len(imagePull), running, strings.Join(imagePull, ", "), imageReason))
+	}
+	if len(crashLoop) > 0 {
+		message := fmt.Sprintf("%d of %d pods are crash looping (%s)", len(crashLoop), running, strings.Join(crashLoop, ", "))
+		if excerpt != "" {
+			message = fmt.Sprintf("%s, last logs of %s: %s", message, crashLoop[0], excerpt)
+		}
+		messages = append(messages, message)
+	}
+	if oomKilled > 0 {
+		messages = append(messages, fmt.Sprintf("OOMKilled containers: %d, restarts: %d", oomKilled, restarts))
+	}
+
+	switch {
+	case len(imagePull) > 0:
+		condition.Reason = ReasonImagePullBackOff
+	case len(crashLoop) > 0:
+		condition.Reason = ReasonCrashLoopBackOff
+	case running == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = ReasonNoPods
+		condition.Message = "no collector pods are running"
+		return condition
+	case notReady > 0 && oomKilled > 0:
+		condition.Reason = ReasonOOMKilled
+	case notReady > 0:
+		condition.Reason = ReasonPodsNotReady
+	default:
+		// containers OOMKilled in the past are reported, but don't make ready pods unhealthy
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonPodsHealthy
+		condition.Message = strings.Join(append([]string{fmt.Sprintf("%d pods are ready", running)}, messages...), "; ")
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	if notReady > 0 {
+		messages = append(messages, fmt.Sprintf("%d of %d pods are not ready", notReady, running))
+	}
+	condition.Message = strings.Join(messages, "; ")
+	return condition
+}
+
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func terminatedReason(status corev1.ContainerStatus) string {
+	if status.State.Terminated != nil {
+		return status.State.Terminated.Reason
+	}
+	if status.LastTerminationState.Terminated != nil {
+		return status.LastTerminationState.Terminated.Reason
+	}
+	return ""
+}
+
+func tail(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return "..." + s[start:]
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestHealth(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-instance",
+			Generation: 3,
+		},
+	}
+
+	for _, tt := range []struct {
+		desc            string
+		pods            []corev1.Pod
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			desc:            "no pods",
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  ReasonNoPods,
+			expectedMessage: "no collector pods are running",
+		},
+		{
+			desc:            "ready pods",
+			pods:            []corev1.Pod{pod("pod-b", true), pod("pod-a", true)},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  ReasonPodsHealthy,
+			expectedMessage: "2 pods are ready",
+		},
+		{
+			desc:            "not ready pods",
+			pods:            []corev1.Pod{pod("pod-a", true), pod("pod-b", false)},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonPodsNotReady,
+			expectedMessage: "1 of 2 pods are not ready",
+		},
+		{
+			desc: "image pull failures",
+			pods: []corev1.Pod{
+				pod("pod-a", false, corev1.ContainerStatus{
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "otel/opentelemetry-collector:0.0.0"`,
+					}},
+				}),
+				pod("pod-b", true),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonImagePullBackOff,
+			expectedMessage: `1 of 2 pods can't pull their image (pod-a): Back-off pulling image "otel/opentelemetry-collector:0.0.0"; 1 of 2 pods are not ready`,
+		},
+		{
+			desc: "crash loop",
+			pods: []corev1.Pod{
+				pod("pod-a", false, corev1.ContainerStatus{
+					RestartCount: 4,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason: "CrashLoopBackOff",
+					}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason:   "Error",
+						ExitCode: 1,
+						Message:  "Error: failed to get config: invalid configuration\n",
+					}},
+				}),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonCrashLoopBackOff,
+			expectedMessage: "1 of 1 pods are crash looping (pod-a), last logs of pod-a: Error: failed to get config: invalid configuration; 1 of 1 pods are not ready",
+		},
+		{
+			desc: "OOMKilled",
+			pods: []corev1.Pod{
+				pod("pod-a", false, corev1.ContainerStatus{
+					RestartCount: 2,
+					State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason:   "OOMKilled",
+						ExitCode: 137,
+					}},
+				}),
+				pod("pod-b", true, corev1.ContainerStatus{RestartCount: 1}),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  ReasonOOMKilled,
+			expectedMessage: "OOMKilled containers: 1, restarts: 3; 1 of 2 pods are not ready",
+		},
+		{
+			desc: "OOMKilled in the past",
+			pods: []corev1.Pod{
+				pod("pod-a", true, corev1.ContainerStatus{
+					RestartCount: 1,
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason: "OOMKilled",
+					}},
+				}),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  ReasonPodsHealthy,
+			expectedMessage: "1 pods are ready; OOMKilled containers: 1, restarts: 1",
+		},
+		{
+			desc: "terminated pods",
+			pods: []corev1.Pod{
+				pod("pod-a", true),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod-b"},
+					Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+				},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  ReasonPodsHealthy,
+			expectedMessage: "1 pods are ready",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			condition := Health(otelcol, tt.pods)
+
+			// verify
+			assert.Equal(t, ConditionTypeHealthy, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+		})
+	}
+}
+
+func TestHealthTruncatesLogs(t *testing.T) {
+	// prepare
+	logs := strings.Repeat("a", 1000) + strings.Repeat("b", 500)
+	pods := []corev1.Pod{
+		pod("pod-a", false, corev1.ContainerStatus{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: logs,
+			}},
+		}),
+	}
+
+	// test
+	condition := Health(v1alpha1.OpenTelemetryCollector{}, pods)
+
+	// verify
+	assert.Contains(t, condition.Message, "last logs of pod-a: ...aaaaaaaaaaaa"+strings.Repeat("b", 500)+";")
+	assert.Less(t, len(condition.Message), 700)
+}
+
+func pod(name string, ready bool, statuses ...corev1.ContainerStatus) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: statuses,
+		},
+	}
+}
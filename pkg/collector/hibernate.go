@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// ConditionTypePaused is the type of the status condition reporting the instances whose collectors are hibernated or
+// whose reconciliation is paused.
+const ConditionTypePaused = "Paused"
+
+// Reasons of the Paused condition.
+const (
+	ReasonHibernated           = "Hibernated"
+	ReasonReconciliationPaused = "ReconciliationPaused"
+)
+
+// Hibernated returns whether the workloads of the given instance are scaled to zero.
+func Hibernated(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Hibernate && otelcol.Spec.Mode != v1alpha1.ModeSidecar
+}
+
+// replicas returns the number of replicas of the workloads of the given instance, zero while it's hibernated.
+func replicas(otelcol v1alpha1.OpenTelemetryCollector) *int32 {
+	if Hibernated(otelcol) {
+		zero := int32(0)
+		return &zero
+	}
+	return otelcol.Spec.Replicas
+}
+
+// PausedCondition returns the Paused condition of the given instance, or nil when it's neither hibernated nor paused.
+// A paused reconciliation takes precedence, as the hibernation only takes effect once it resumes.
+func PausedCondition(otelcol v1alpha1.OpenTelemetryCollector) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: otelcol.Generation,
+	}
+	switch {
+	case Paused(otelcol):
+		condition.Reason = ReasonReconciliationPaused
+		condition.Message = fmt.Sprintf("the operator doesn't reconcile the objects of the collector, remove the %s annotation to resume", PauseAnnotation)
+	case Hibernated(otelcol):
+		condition.Reason = ReasonHibernated
+		condition.Message = "the workloads of the collector are scaled to zero, unset spec.hibernate to resume"
+	default:
+		return nil
+	}
+	return condition
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestHibernate(t *testing.T) {
+	three := int32(3)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-instance",
+			Generation: 2,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      v1alpha1.ModeStatefulSet,
+			Replicas:  &three,
+			Hibernate: true,
+		},
+	}
+	cfg := config.New()
+
+	assert.True(t, Hibernated(otelcol))
+	assert.Equal(t, int32(0), *Deployment(cfg, logger, otelcol).Spec.Replicas)
+	assert.Equal(t, int32(0), *StatefulSet(cfg, logger, otelcol).Spec.Replicas)
+
+	condition := PausedCondition(otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, ConditionTypePaused, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonHibernated, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	otelcol.Spec.Hibernate = false
+	assert.False(t, Hibernated(otelcol))
+	assert.Equal(t, int32(3), *Deployment(cfg, logger, otelcol).Spec.Replicas)
+	assert.Nil(t, PausedCondition(otelcol))
+}
+
+func TestPausedConditionWithAnnotation(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{PauseAnnotation: "true"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Hibernate: true,
+		},
+	}
+
+	condition := PausedCondition(otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonReconciliationPaused, condition.Reason)
+}
+
+func TestHibernateSidecar(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      v1alpha1.ModeSidecar,
+			Hibernate: true,
+		},
+	}
+
+	assert.False(t, Hibernated(otelcol))
+	assert.Nil(t, PausedCondition(otelcol))
+}
@@ -33,7 +33,7 @@ func HorizontalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1al
 
 	name := naming.Collector(otelcol)
 	labels := Labels(otelcol, name, cfg.LabelsFilter())
-	annotations := Annotations(otelcol)
+	annotations := Annotations(cfg, otelcol)
 	var result client.Object
 
 	objectMeta := metav1.ObjectMeta{
@@ -66,17 +66,19 @@ func HorizontalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1al
 			metrics = append(metrics, utilizationTarget)
 		}
 
-		targetCPUUtilization := autoscalingv2beta2.MetricSpec{
-			Type: autoscalingv2beta2.ResourceMetricSourceType,
-			Resource: &autoscalingv2beta2.ResourceMetricSource{
-				Name: corev1.ResourceCPU,
-				Target: autoscalingv2beta2.MetricTarget{
-					Type:               autoscalingv2beta2.UtilizationMetricType,
-					AverageUtilization: otelcol.Spec.Autoscaler.TargetCPUUtilization,
+		if otelcol.Spec.Autoscaler.TargetCPUUtilization != nil {
+			targetCPUUtilization := autoscalingv2beta2.MetricSpec{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:               autoscalingv2beta2.UtilizationMetricType,
+						AverageUtilization: otelcol.Spec.Autoscaler.TargetCPUUtilization,
+					},
 				},
-			},
+			}
+			metrics = append(metrics, targetCPUUtilization)
 		}
-		metrics = append(metrics, targetCPUUtilization)
 
 		autoscaler := autoscalingv2beta2.HorizontalPodAutoscaler{
 			ObjectMeta: objectMeta,
@@ -195,48 +197,38 @@ func ConvertToV2beta2Behavior(v2behavior autoscalingv2.HorizontalPodAutoscalerBe
 	behavior := &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{}
 
 	if v2behavior.ScaleUp != nil {
-		scaleUpRules := &autoscalingv2beta2.HPAScalingRules{}
-		scaleUpTime := *v2behavior.ScaleUp.StabilizationWindowSeconds
-		scaleUpRules.StabilizationWindowSeconds = &scaleUpTime
-
-		if v2behavior.ScaleUp.SelectPolicy != nil {
-			scaleUpSelectPolicy := ConvertToV2Beta2SelectPolicy(*v2behavior.ScaleUp.SelectPolicy)
-			scaleUpRules.SelectPolicy = &scaleUpSelectPolicy
-		}
-		if v2behavior.ScaleUp.Policies != nil {
-			scaleUpPolicies := []autoscalingv2beta2.HPAScalingPolicy{}
-			for _, policy := range v2behavior.ScaleUp.Policies {
-				v2beta2policy := ConvertToV2Beta2HPAScalingPolicy(policy)
-				scaleUpPolicies = append(scaleUpPolicies, v2beta2policy)
-			}
-			scaleUpRules.Policies = scaleUpPolicies
-		}
-
-		behavior.ScaleUp = scaleUpRules
+		behavior.ScaleUp = convertToV2Beta2ScalingRules(*v2behavior.ScaleUp)
 	}
 
 	if v2behavior.ScaleDown != nil {
-		scaleDownRules := &autoscalingv2beta2.HPAScalingRules{}
-		scaleDownTime := *v2behavior.ScaleDown.StabilizationWindowSeconds
-		scaleDownRules.StabilizationWindowSeconds = &scaleDownTime
+		behavior.ScaleDown = convertToV2Beta2ScalingRules(*v2behavior.ScaleDown)
+	}
 
-		if v2behavior.ScaleDown.SelectPolicy != nil {
-			scaleDownSelectPolicy := ConvertToV2Beta2SelectPolicy(*v2behavior.ScaleDown.SelectPolicy)
-			scaleDownRules.SelectPolicy = &scaleDownSelectPolicy
-		}
-		if v2behavior.ScaleDown.Policies != nil {
-			ScaleDownPolicies := []autoscalingv2beta2.HPAScalingPolicy{}
-			for _, policy := range v2behavior.ScaleDown.Policies {
-				v2beta2policy := ConvertToV2Beta2HPAScalingPolicy(policy)
-				ScaleDownPolicies = append(ScaleDownPolicies, v2beta2policy)
-			}
-			scaleDownRules.Policies = ScaleDownPolicies
-		}
+	return *behavior
+}
 
-		behavior.ScaleDown = scaleDownRules
+// convertToV2Beta2ScalingRules creates v2beta2 HPAScalingRules from a v2 instance. The unset stabilization window
+// stays unset, for the HPA to use its default one.
+func convertToV2Beta2ScalingRules(v2rules autoscalingv2.HPAScalingRules) *autoscalingv2beta2.HPAScalingRules {
+	rules := &autoscalingv2beta2.HPAScalingRules{}
+
+	if v2rules.StabilizationWindowSeconds != nil {
+		stabilizationWindow := *v2rules.StabilizationWindowSeconds
+		rules.StabilizationWindowSeconds = &stabilizationWindow
+	}
+	if v2rules.SelectPolicy != nil {
+		selectPolicy := ConvertToV2Beta2SelectPolicy(*v2rules.SelectPolicy)
+		rules.SelectPolicy = &selectPolicy
+	}
+	if v2rules.Policies != nil {
+		policies := []autoscalingv2beta2.HPAScalingPolicy{}
+		for _, policy := range v2rules.Policies {
+			policies = append(policies, ConvertToV2Beta2HPAScalingPolicy(policy))
+		}
+		rules.Policies = policies
 	}
 
-	return *behavior
+	return rules
 }
 
 func ConvertToV2Beta2HPAScalingPolicy(v2policy autoscalingv2.HPAScalingPolicy) autoscalingv2beta2.HPAScalingPolicy {
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -107,6 +108,49 @@ func TestHPA(t *testing.T) {
 	}
 }
 
+func TestHPAWithMemoryUtilizationOnly(t *testing.T) {
+	var maxReplicas int32 = 5
+	var memoryUtilization int32 = 77
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				MaxReplicas:             &maxReplicas,
+				TargetMemoryUtilization: &memoryUtilization,
+			},
+		},
+	}
+
+	for _, autoscalingVersion := range []autodetect.AutoscalingVersion{autodetect.AutoscalingVersionV2, autodetect.AutoscalingVersionV2Beta2} {
+		t.Run(autoscalingVersion.String(), func(t *testing.T) {
+			// prepare
+			configuration := config.New(config.WithAutoDetect(&mockAutoDetect{
+				HPAVersionFunc: func() (autodetect.AutoscalingVersion, error) {
+					return autoscalingVersion, nil
+				},
+			}))
+			require.NoError(t, configuration.AutoDetect())
+
+			// test
+			raw := HorizontalPodAutoscaler(configuration, logger, otelcol)
+
+			// verify
+			if autoscalingVersion == autodetect.AutoscalingVersionV2Beta2 {
+				hpa := raw.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+				require.Len(t, hpa.Spec.Metrics, 1)
+				assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+			} else {
+				hpa := raw.(*autoscalingv2.HorizontalPodAutoscaler)
+				require.Len(t, hpa.Spec.Metrics, 1)
+				assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+			}
+		})
+	}
+}
+
 func TestConvertToV2Beta2PodMetrics(t *testing.T) {
 	expectedValues := []int64{int64(10), int64(20)}
 	expectedNames := []string{"custom1", "custom2"}
@@ -178,6 +222,28 @@ func TestConvertToV2beta2Behavior(t *testing.T) {
 	assert.EqualValues(t, autoscalingv2beta2.MinPolicySelect, *v2Beta2Behavior.ScaleDown.SelectPolicy)
 }
 
+func TestConvertToV2beta2BehaviorWithoutStabilizationWindow(t *testing.T) {
+	v2Behavior := autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			Policies: []autoscalingv2.HPAScalingPolicy{{
+				Type:          autoscalingv2.PodsScalingPolicy,
+				Value:         1,
+				PeriodSeconds: 60,
+			}},
+		},
+	}
+
+	v2Beta2Behavior := ConvertToV2beta2Behavior(v2Behavior)
+
+	assert.Nil(t, v2Beta2Behavior.ScaleUp)
+	assert.Nil(t, v2Beta2Behavior.ScaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, []autoscalingv2beta2.HPAScalingPolicy{{
+		Type:          autoscalingv2beta2.PodsScalingPolicy,
+		Value:         1,
+		PeriodSeconds: 60,
+	}}, v2Beta2Behavior.ScaleDown.Policies)
+}
+
 func TestConvertToV2Beta2HPAScalingPolicy(t *testing.T) {
 	v2Policy := autoscalingv2.HPAScalingPolicy{
 		Type:          autoscalingv2.PodsScalingPolicy,
@@ -205,8 +271,12 @@ func TestConvertToV2Beta2SelectPolicy(t *testing.T) {
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
-	HPAVersionFunc                  func() (autodetect.AutoscalingVersion, error)
+	OpenShiftRoutesAvailabilityFunc        func() (autodetect.OpenShiftRoutesAvailability, error)
+	HPAVersionFunc                         func() (autodetect.AutoscalingVersion, error)
+	VerticalPodAutoscalersAvailabilityFunc func() (autodetect.VerticalPodAutoscalersAvailability, error)
+	IstioAvailabilityFunc                  func() (autodetect.IstioAvailability, error)
+	PodMonitorsAvailabilityFunc            func() (autodetect.PodMonitorsAvailability, error)
+	PermissionsFunc                        func() (autodetect.Permissions, error)
 }
 
 func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
@@ -219,3 +289,31 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) VerticalPodAutoscalersAvailability() (autodetect.VerticalPodAutoscalersAvailability, error) {
+	if m.VerticalPodAutoscalersAvailabilityFunc != nil {
+		return m.VerticalPodAutoscalersAvailabilityFunc()
+	}
+	return autodetect.VerticalPodAutoscalersNotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (autodetect.IstioAvailability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return autodetect.IstioNotAvailable, nil
+}
+
+func (m *mockAutoDetect) PodMonitorsAvailability() (autodetect.PodMonitorsAvailability, error) {
+	if m.PodMonitorsAvailabilityFunc != nil {
+		return m.PodMonitorsAvailabilityFunc()
+	}
+	return autodetect.PodMonitorsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) Permissions() (autodetect.Permissions, error) {
+	if m.PermissionsFunc != nil {
+		return m.PermissionsFunc()
+	}
+	return autodetect.Permissions{}, nil
+}
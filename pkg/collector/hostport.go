@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const hostIPEnvVar = "HOST_IP"
+
+// bindHostPorts sets the ports of the node the given container ports are bound to, following the host ports of the
+// given instance. The host ports naming no container port are skipped.
+func bindHostPorts(otelcol v1alpha1.OpenTelemetryCollector, ports map[string]corev1.ContainerPort) {
+	if otelcol.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return
+	}
+	for _, hostPort := range otelcol.Spec.HostPorts {
+		port, ok := ports[hostPort.Name]
+		if !ok {
+			continue
+		}
+		port.HostPort = port.ContainerPort
+		if hostPort.HostPort > 0 {
+			port.HostPort = hostPort.HostPort
+		}
+		ports[hostPort.Name] = port
+	}
+}
+
+// hostPortsEnvVars returns the variable holding the IP of the node when ports of the given instance are bound to
+// ports of the node, unless it's already defined.
+func hostPortsEnvVars(otelcol v1alpha1.OpenTelemetryCollector, existing []corev1.EnvVar) []corev1.EnvVar {
+	if otelcol.Spec.Mode != v1alpha1.ModeDaemonSet || len(otelcol.Spec.HostPorts) == 0 {
+		return nil
+	}
+	for _, envVar := range existing {
+		if envVar.Name == hostIPEnvVar {
+			return nil
+		}
+	}
+	return []corev1.EnvVar{fieldRefEnvVar(hostIPEnvVar, "status.hostIP")}
+}
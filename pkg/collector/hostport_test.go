@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func hostPortsInstance(mode v1alpha1.Mode) v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      mode,
+			HostPorts: []v1alpha1.HostPortSpec{{Name: "statsd"}, {Name: "syslog", HostPort: 514}},
+			Config: `receivers:
+  statsd:
+  syslog:
+    udp:
+      listen_address: 0.0.0.0:54526
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [statsd]
+      exporters: [debug]
+    logs:
+      receivers: [syslog]
+      exporters: [debug]
+`,
+		},
+	}
+}
+
+func TestHostPorts(t *testing.T) {
+	container := Container(config.New(), logr.Discard(), hostPortsInstance(v1alpha1.ModeDaemonSet), true)
+
+	ports := map[string]corev1.ContainerPort{}
+	for _, port := range container.Ports {
+		ports[port.Name] = port
+	}
+	assert.Equal(t, int32(8125), ports["statsd"].HostPort)
+	assert.Equal(t, int32(514), ports["syslog"].HostPort)
+	assert.Equal(t, int32(54526), ports["syslog"].ContainerPort)
+	assert.Zero(t, ports["metrics"].HostPort)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: "HOST_IP",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"},
+		},
+	})
+}
+
+func TestHostPortsNotDaemonSet(t *testing.T) {
+	container := Container(config.New(), logr.Discard(), hostPortsInstance(v1alpha1.ModeDeployment), true)
+
+	for _, port := range container.Ports {
+		assert.Zero(t, port.HostPort, port.Name)
+	}
+	for _, envVar := range container.Env {
+		assert.NotEqual(t, "HOST_IP", envVar.Name)
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// The kinds of the Istio objects, which are handled as unstructured objects as their API is only served when Istio is
+// installed in the cluster.
+var (
+	ServiceEntryGVK       = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "ServiceEntry"}
+	IstioSidecarGVK       = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "Sidecar"}
+	PeerAuthenticationGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "PeerAuthentication"}
+)
+
+// exporterEndpoint is a host and port an exporter of the collector sends its data to.
+type exporterEndpoint struct {
+	host     string
+	port     int64
+	protocol string
+}
+
+// ServiceEntry builds the service entry registering the external hosts of the exporters in the mesh, or returns nil
+// when the instance doesn't set istio or doesn't export to an external host.
+func ServiceEntry(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) *unstructured.Unstructured {
+	if otelcol.Spec.Istio == nil || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+
+	var hosts []interface{}
+	var ports []interface{}
+	seenHosts := map[string]bool{}
+	seenPorts := map[int64]bool{}
+	for _, endpoint := range exporterEndpoints(logger, otelcol.Spec.Config) {
+		if _, inCluster := inClusterNamespace(endpoint.host); inCluster {
+			continue
+		}
+		if !seenHosts[endpoint.host] {
+			seenHosts[endpoint.host] = true
+			hosts = append(hosts, endpoint.host)
+		}
+		// the ports of a service entry apply to all of its hosts
+		if !seenPorts[endpoint.port] {
+			seenPorts[endpoint.port] = true
+			ports = append(ports, map[string]interface{}{
+				"number":   endpoint.port,
+				"protocol": endpoint.protocol,
+				"name":     strings.ToLower(endpoint.protocol) + "-" + strconv.FormatInt(endpoint.port, 10),
+			})
+		}
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	name := naming.ServiceEntry(otelcol)
+	serviceEntry := &unstructured.Unstructured{}
+	serviceEntry.SetGroupVersionKind(ServiceEntryGVK)
+	serviceEntry.SetName(name)
+	serviceEntry.SetNamespace(otelcol.Namespace)
+	serviceEntry.SetLabels(Labels(otelcol, name, cfg.LabelsFilter()))
+	serviceEntry.SetAnnotations(Annotations(cfg, otelcol))
+	serviceEntry.Object["spec"] = map[string]interface{}{
+		"hosts":      hosts,
+		"ports":      ports,
+		"location":   "MESH_EXTERNAL",
+		"resolution": "DNS",
+		// only the collector's namespace sees the external hosts of its exporters
+		"exportTo": []interface{}{"."},
+	}
+
+	return serviceEntry
+}
+
+// IstioSidecar builds the sidecar scoping the egress of the collector pods to their own namespace, the Istio control
+// plane, the Kubernetes API and the namespaces of the in-cluster hosts of the exporters, or returns nil when the
+// instance doesn't scope the egress of its pods, which would keep the pull receivers from reaching their targets.
+func IstioSidecar(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) *unstructured.Unstructured {
+	if otelcol.Spec.Istio == nil || !otelcol.Spec.Istio.ScopeEgress || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+
+	// the own namespace holds the service entry and the target allocator, while the default one holds the service of
+	// the Kubernetes API the receivers and processors discovering the cluster talk to
+	namespaces := map[string]bool{otelcol.Namespace: true, "istio-system": true, "default": true}
+	for _, endpoint := range exporterEndpoints(logger, otelcol.Spec.Config) {
+		if namespace, inCluster := inClusterNamespace(endpoint.host); inCluster && namespace != "" {
+			namespaces[namespace] = true
+		}
+	}
+	delete(namespaces, otelcol.Namespace)
+	others := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		others = append(others, namespace)
+	}
+	sort.Strings(others)
+	hosts := []interface{}{"./*"}
+	for _, namespace := range others {
+		hosts = append(hosts, namespace+"/*")
+	}
+
+	name := naming.IstioSidecar(otelcol)
+	sidecar := &unstructured.Unstructured{}
+	sidecar.SetGroupVersionKind(IstioSidecarGVK)
+	sidecar.SetName(name)
+	sidecar.SetNamespace(otelcol.Namespace)
+	sidecar.SetLabels(Labels(otelcol, name, cfg.LabelsFilter()))
+	sidecar.SetAnnotations(Annotations(cfg, otelcol))
+	sidecar.Object["spec"] = map[string]interface{}{
+		"workloadSelector": map[string]interface{}{
+			"labels": labelsToUnstructured(SelectorLabels(otelcol)),
+		},
+		"egress": []interface{}{
+			map[string]interface{}{
+				"hosts": hosts,
+			},
+		},
+	}
+
+	return sidecar
+}
+
+// PeerAuthentication builds the peer authentication setting the mutual TLS mode of the receiver ports of the collector
+// pods, or returns nil when the instance doesn't set istio or, in the permissive mode, doesn't have receiver ports.
+func PeerAuthentication(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) *unstructured.Unstructured {
+	if otelcol.Spec.Istio == nil || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": labelsToUnstructured(SelectorLabels(otelcol)),
+		},
+	}
+	if otelcol.Spec.Istio.ReceiverMTLSMode == v1alpha1.IstioMTLSModeStrict {
+		spec["mtls"] = map[string]interface{}{"mode": string(v1alpha1.IstioMTLSModeStrict)}
+	} else {
+		// the other ports of the collector, like the ones of its metrics and health check, keep the mode of the
+		// namespace or of the mesh
+		portLevelMTLS := map[string]interface{}{}
+		for _, port := range receiverPorts(logger, otelcol.Spec.Config) {
+			portLevelMTLS[strconv.Itoa(int(port.Port))] = map[string]interface{}{"mode": string(v1alpha1.IstioMTLSModePermissive)}
+		}
+		for _, port := range otelcol.Spec.Ports {
+			portLevelMTLS[strconv.Itoa(int(port.Port))] = map[string]interface{}{"mode": string(v1alpha1.IstioMTLSModePermissive)}
+		}
+		if len(portLevelMTLS) == 0 {
+			return nil
+		}
+		spec["portLevelMtls"] = portLevelMTLS
+	}
+
+	name := naming.PeerAuthentication(otelcol)
+	peerAuthentication := &unstructured.Unstructured{}
+	peerAuthentication.SetGroupVersionKind(PeerAuthenticationGVK)
+	peerAuthentication.SetName(name)
+	peerAuthentication.SetNamespace(otelcol.Namespace)
+	peerAuthentication.SetLabels(Labels(otelcol, name, cfg.LabelsFilter()))
+	peerAuthentication.SetAnnotations(Annotations(cfg, otelcol))
+	peerAuthentication.Object["spec"] = spec
+
+	return peerAuthentication
+}
+
+// receiverPorts returns the ports of the receivers of the given configuration.
+func receiverPorts(logger logr.Logger, cfg string) []corev1.ServicePort {
+	c, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		logger.Error(err, "couldn't extract the configuration")
+		return nil
+	}
+	ports, err := adapters.ConfigToReceiverPorts(logger, c)
+	if err != nil {
+		logger.V(2).Info("couldn't build the receiver ports from the configuration", "error", err)
+		return nil
+	}
+	return ports
+}
+
+// exporterEndpoints returns the endpoints of the exporters of the given configuration, leaving out the ones referencing
+// environment variables, whose host isn't known until the collector starts.
+func exporterEndpoints(logger logr.Logger, cfg string) []exporterEndpoint {
+	c, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		logger.Error(err, "couldn't extract the configuration")
+		return nil
+	}
+	exporters, ok := c["exporters"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var endpoints []exporterEndpoint
+	for _, name := range names {
+		exporter, ok := exporters[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		insecure := false
+		if tls, ok := exporter["tls"].(map[string]interface{}); ok {
+			insecure, _ = tls["insecure"].(bool)
+		}
+		keys := make([]string, 0, len(exporter))
+		for key := range exporter {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// the otlphttp exporter also sets the endpoints of the signals, like traces_endpoint
+			if key != "endpoint" && !strings.HasSuffix(key, "_endpoint") {
+				continue
+			}
+			value, ok := exporter[key].(string)
+			if !ok {
+				continue
+			}
+			if endpoint, ok := parseExporterEndpoint(value, insecure); ok {
+				endpoints = append(endpoints, endpoint)
+			} else {
+				logger.V(2).Info("skipping the exporter endpoint, as its host and port can't be determined", "exporter", name, "endpoint", value)
+			}
+		}
+	}
+	return endpoints
+}
+
+// parseExporterEndpoint parses the URL of the HTTP exporters, or the host:port of the gRPC exporters, whose protocol is
+// plaintext gRPC when the TLS of the exporter is insecure.
+func parseExporterEndpoint(endpoint string, insecure bool) (exporterEndpoint, bool) {
+	if strings.Contains(endpoint, "${") {
+		return exporterEndpoint{}, false
+	}
+
+	var host, port, protocol string
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return exporterEndpoint{}, false
+		}
+		host, port = u.Hostname(), u.Port()
+		switch u.Scheme {
+		case "https":
+			protocol = "TLS"
+			if port == "" {
+				port = "443"
+			}
+		case "http":
+			protocol = "HTTP"
+			if port == "" {
+				port = "80"
+			}
+		default:
+			return exporterEndpoint{}, false
+		}
+	} else {
+		var err error
+		host, port, err = net.SplitHostPort(endpoint)
+		if err != nil {
+			return exporterEndpoint{}, false
+		}
+		protocol = "TLS"
+		if insecure {
+			protocol = "GRPC"
+		}
+	}
+
+	number, err := strconv.ParseInt(port, 10, 32)
+	// the service entries register host names, the addresses are left to the mesh
+	if err != nil || host == "" || net.ParseIP(host) != nil {
+		return exporterEndpoint{}, false
+	}
+	return exporterEndpoint{host: host, port: number, protocol: protocol}, true
+}
+
+// inClusterNamespace tells whether the host is the one of a service of the cluster, and returns its namespace when the
+// host isn't a short name resolved in the collector's namespace.
+func inClusterNamespace(host string) (string, bool) {
+	if !strings.Contains(host, ".") {
+		return "", true
+	}
+	if strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.") {
+		return strings.Split(host, ".")[1], true
+	}
+	return "", false
+}
+
+func labelsToUnstructured(labels map[string]string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+const istioConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  otlp:
+    endpoint: api.honeycomb.io:443
+  otlphttp:
+    endpoint: https://otlp.eu01.nr-data.net
+    traces_endpoint: http://traces.example.com:8080/v1/traces
+  otlp/gateway:
+    endpoint: gateway-collector.observability.svc.cluster.local:4317
+    tls:
+      insecure: true
+  otlp/local:
+    endpoint: tempo:4317
+  otlp/env:
+    endpoint: ${env:BACKEND_ENDPOINT}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp, otlp/gateway, otlp/local, otlp/env]
+`
+
+func istioInstance(mtlsMode v1alpha1.IstioMTLSMode) v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Config: istioConfig,
+			Istio:  &v1alpha1.IstioSpec{ReceiverMTLSMode: mtlsMode},
+		},
+	}
+}
+
+func TestIstioObjectsWithoutIstio(t *testing.T) {
+	otelcol := istioInstance("")
+	otelcol.Spec.Istio = nil
+
+	assert.Nil(t, ServiceEntry(config.New(), logger, otelcol))
+	assert.Nil(t, IstioSidecar(config.New(), logger, otelcol))
+	assert.Nil(t, PeerAuthentication(config.New(), logger, otelcol))
+}
+
+func TestServiceEntry(t *testing.T) {
+	// test
+	serviceEntry := ServiceEntry(config.New(), logger, istioInstance(""))
+
+	// verify
+	require.NotNil(t, serviceEntry)
+	assert.Equal(t, ServiceEntryGVK, serviceEntry.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector-egress", serviceEntry.GetName())
+	assert.Equal(t, "my-namespace", serviceEntry.GetNamespace())
+
+	hosts, _, err := unstructured.NestedStringSlice(serviceEntry.Object, "spec", "hosts")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.honeycomb.io", "otlp.eu01.nr-data.net", "traces.example.com"}, hosts)
+
+	ports, _, err := unstructured.NestedSlice(serviceEntry.Object, "spec", "ports")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"number": int64(443), "protocol": "TLS", "name": "tls-443"},
+		map[string]interface{}{"number": int64(8080), "protocol": "HTTP", "name": "http-8080"},
+	}, ports)
+
+	location, _, err := unstructured.NestedString(serviceEntry.Object, "spec", "location")
+	require.NoError(t, err)
+	assert.Equal(t, "MESH_EXTERNAL", location)
+}
+
+func TestServiceEntryWithoutExternalHosts(t *testing.T) {
+	otelcol := istioInstance("")
+	otelcol.Spec.Config = `exporters:
+  otlp:
+    endpoint: tempo:4317
+`
+
+	assert.Nil(t, ServiceEntry(config.New(), logger, otelcol))
+}
+
+func TestIstioSidecar(t *testing.T) {
+	// prepare
+	otelcol := istioInstance("")
+	otelcol.Spec.Istio.ScopeEgress = true
+
+	// test
+	sidecar := IstioSidecar(config.New(), logger, otelcol)
+
+	// verify
+	require.NotNil(t, sidecar)
+	assert.Equal(t, IstioSidecarGVK, sidecar.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector", sidecar.GetName())
+
+	selector, _, err := unstructured.NestedStringMap(sidecar.Object, "spec", "workloadSelector", "labels")
+	require.NoError(t, err)
+	assert.Equal(t, SelectorLabels(istioInstance("")), selector)
+
+	egress, _, err := unstructured.NestedSlice(sidecar.Object, "spec", "egress")
+	require.NoError(t, err)
+	require.Len(t, egress, 1)
+	assert.Equal(t, []interface{}{"./*", "default/*", "istio-system/*", "observability/*"}, egress[0].(map[string]interface{})["hosts"])
+}
+
+func TestIstioSidecarWithoutScopedEgress(t *testing.T) {
+	// the egress isn't scoped by default, so that the pull receivers reach their targets
+	assert.Nil(t, IstioSidecar(config.New(), logger, istioInstance("")))
+}
+
+func TestPeerAuthentication(t *testing.T) {
+	t.Run("permissive receiver ports", func(t *testing.T) {
+		// test
+		peerAuthentication := PeerAuthentication(config.New(), logger, istioInstance(""))
+
+		// verify
+		require.NotNil(t, peerAuthentication)
+		assert.Equal(t, PeerAuthenticationGVK, peerAuthentication.GroupVersionKind())
+		assert.Equal(t, "my-instance-collector", peerAuthentication.GetName())
+
+		portLevelMTLS, _, err := unstructured.NestedMap(peerAuthentication.Object, "spec", "portLevelMtls")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"4317": map[string]interface{}{"mode": "PERMISSIVE"},
+			"4318": map[string]interface{}{"mode": "PERMISSIVE"},
+			// the legacy port of the otlp http receiver
+			"55681": map[string]interface{}{"mode": "PERMISSIVE"},
+		}, portLevelMTLS)
+		_, found, err := unstructured.NestedMap(peerAuthentication.Object, "spec", "mtls")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		// test
+		peerAuthentication := PeerAuthentication(config.New(), logger, istioInstance(v1alpha1.IstioMTLSModeStrict))
+
+		// verify
+		require.NotNil(t, peerAuthentication)
+		mode, _, err := unstructured.NestedString(peerAuthentication.Object, "spec", "mtls", "mode")
+		require.NoError(t, err)
+		assert.Equal(t, "STRICT", mode)
+		_, found, err := unstructured.NestedMap(peerAuthentication.Object, "spec", "portLevelMtls")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("permissive without receiver ports", func(t *testing.T) {
+		otelcol := istioInstance("")
+		otelcol.Spec.Config = `exporters:
+  otlp:
+    endpoint: tempo:4317
+`
+
+		assert.Nil(t, PeerAuthentication(config.New(), logger, otelcol))
+	})
+}
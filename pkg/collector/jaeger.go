@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// jaegerSpec holds the attributes of the spec of a Jaeger resource of the Jaeger Operator that the conversion reads.
+// The resource is read as an unstructured object, so that the operator doesn't depend on the API of the Jaeger
+// Operator.
+type jaegerSpec struct {
+	Strategy    string                      `json:"strategy,omitempty"`
+	AllInOne    jaegerComponent             `json:"allInOne,omitempty"`
+	Collector   jaegerComponent             `json:"collector,omitempty"`
+	Agent       jaegerComponent             `json:"agent,omitempty"`
+	Storage     jaegerStorage               `json:"storage,omitempty"`
+	Sampling    jaegerComponent             `json:"sampling,omitempty"`
+	Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
+	Tolerations []corev1.Toleration         `json:"tolerations,omitempty"`
+	Affinity    *corev1.Affinity            `json:"affinity,omitempty"`
+}
+
+type jaegerComponent struct {
+	Strategy     string                      `json:"strategy,omitempty"`
+	Options      map[string]interface{}      `json:"options,omitempty"`
+	Replicas     *int32                      `json:"replicas,omitempty"`
+	Autoscale    *bool                       `json:"autoscale,omitempty"`
+	MinReplicas  *int32                      `json:"minReplicas,omitempty"`
+	MaxReplicas  *int32                      `json:"maxReplicas,omitempty"`
+	Resources    corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string           `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration         `json:"tolerations,omitempty"`
+	Affinity     *corev1.Affinity            `json:"affinity,omitempty"`
+}
+
+type jaegerStorage struct {
+	Type       string                 `json:"type,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+	SecretName string                 `json:"secretName,omitempty"`
+}
+
+// FromJaeger generates an instance receiving the spans the collector, or the all-in-one instance, of a Jaeger
+// resource of the Jaeger Operator receives, and writing them to its storage where the collector has an exporter for
+// it. The instance is named after the resource unless a name is given, so that its service has the name of the
+// service of the Jaeger collector. The hints list the settings of the resource that couldn't be converted.
+func FromJaeger(logger logr.Logger, jaeger unstructured.Unstructured, name string) (Generated, error) {
+	if jaeger.GetKind() != "Jaeger" {
+		return Generated{}, fmt.Errorf("unsupported resource kind %s", jaeger.GetKind())
+	}
+	if len(name) == 0 {
+		name = jaeger.GetName()
+	}
+
+	var spec jaegerSpec
+	if rawSpec, ok := jaeger.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSpec, &spec); err != nil {
+			return Generated{}, fmt.Errorf("failed to read the spec of the Jaeger %s: %w", jaeger.GetName(), err)
+		}
+	}
+
+	var hints []string
+	hint := func(format string, args ...interface{}) {
+		hints = append(hints, fmt.Sprintf(format, args...))
+	}
+
+	strategy := strings.ToLower(spec.Strategy)
+	component := spec.Collector
+	switch strategy {
+	case "", "allinone":
+		component = spec.AllInOne
+	case "production":
+	case "streaming":
+		hint("the Jaeger ingester of the streaming strategy isn't converted, keep it to write the spans of the Kafka topic to the storage")
+	default:
+		return Generated{}, fmt.Errorf("unsupported strategy %s of the Jaeger %s", spec.Strategy, jaeger.GetName())
+	}
+
+	config, envFrom, err := jaegerCollectorConfig(spec, strategy, flattenOptions(component.Options), hint)
+	if err != nil {
+		return Generated{}, err
+	}
+	generated, err := FromConfig(logger, name, jaeger.GetNamespace(), config, v1alpha1.ModeDeployment)
+	if err != nil {
+		return Generated{}, err
+	}
+	generated.Hints = append(hints, generated.Hints...)
+
+	otelcol := &generated.Instance
+	otelcol.Spec.EnvFrom = envFrom
+	otelcol.Spec.Resources = component.Resources
+	if len(otelcol.Spec.Resources.Limits) == 0 && len(otelcol.Spec.Resources.Requests) == 0 {
+		otelcol.Spec.Resources = spec.Resources
+	}
+	otelcol.Spec.NodeSelector = component.NodeSelector
+	otelcol.Spec.Tolerations = component.Tolerations
+	if len(otelcol.Spec.Tolerations) == 0 {
+		otelcol.Spec.Tolerations = spec.Tolerations
+	}
+	otelcol.Spec.Affinity = component.Affinity
+	if otelcol.Spec.Affinity == nil {
+		otelcol.Spec.Affinity = spec.Affinity
+	}
+	if strategy == "production" || strategy == "streaming" {
+		otelcol.Spec.Replicas = component.Replicas
+		if component.MaxReplicas != nil && (component.Autoscale == nil || *component.Autoscale) {
+			otelcol.Spec.Autoscaler = &v1alpha1.AutoscalerSpec{
+				MinReplicas: component.MinReplicas,
+				MaxReplicas: component.MaxReplicas,
+			}
+		}
+	}
+
+	if strings.EqualFold(spec.Agent.Strategy, "daemonset") {
+		generated.Hints = append(generated.Hints, "the Jaeger agents of the DaemonSet strategy aren't converted, add an OpenTelemetryCollector in daemonset mode with the jaeger receiver forwarding the spans to this one")
+	}
+	if len(spec.Sampling.Options) > 0 {
+		generated.Hints = append(generated.Hints, "the sampling strategies of the Jaeger aren't converted, configure them in the jaegerremotesampling extension")
+	}
+	generated.Hints = append(generated.Hints, "the Jaeger Query UI isn't part of the collectors, keep it reading the storage of the Jaeger")
+	if name == jaeger.GetName() {
+		generated.Hints = append(generated.Hints, fmt.Sprintf("the collector service has the name of the service of the Jaeger collector, %s, delete the Jaeger before applying this resource so that its clients keep reaching it, or choose another name", naming.Service(*otelcol)))
+	}
+
+	return generated, nil
+}
+
+// jaegerCollectorConfig returns the configuration of the collectors replacing the Jaeger, along with the sources of
+// the environment variables holding the credentials of its storage.
+func jaegerCollectorConfig(spec jaegerSpec, strategy string, componentOptions map[string]string, hint func(string, ...interface{})) (string, []corev1.EnvFromSource, error) {
+	receivers := yaml.MapSlice{
+		{Key: "jaeger", Value: yaml.MapSlice{
+			{Key: "protocols", Value: yaml.MapSlice{
+				{Key: "grpc", Value: yaml.MapSlice{}},
+				{Key: "thrift_binary", Value: yaml.MapSlice{}},
+				{Key: "thrift_compact", Value: yaml.MapSlice{}},
+				{Key: "thrift_http", Value: yaml.MapSlice{}},
+			}},
+		}},
+		{Key: "otlp", Value: yaml.MapSlice{
+			{Key: "protocols", Value: yaml.MapSlice{
+				{Key: "grpc", Value: yaml.MapSlice{}},
+				{Key: "http", Value: yaml.MapSlice{}},
+			}},
+		}},
+	}
+	receiverNames := []string{"jaeger", "otlp"}
+	if zipkin := componentOptions["collector.zipkin.host-port"]; len(zipkin) > 0 {
+		receivers = append(receivers, yaml.MapItem{Key: "zipkin", Value: yaml.MapSlice{{Key: "endpoint", Value: zipkinEndpoint(zipkin)}}})
+		receiverNames = append(receiverNames, "zipkin")
+	}
+
+	storageOptions := flattenOptions(spec.Storage.Options)
+	storageType := strings.ToLower(spec.Storage.Type)
+	if strategy == "streaming" {
+		// the collector of the streaming strategy writes to Kafka, the storage is the one of the ingester
+		storageType, storageOptions = "kafka", componentOptions
+	}
+
+	var (
+		exporterName string
+		exporter     yaml.MapSlice
+		envFrom      []corev1.EnvFromSource
+	)
+	switch storageType {
+	case "elasticsearch", "opensearch":
+		exporterName = "elasticsearch"
+		exporter = yaml.MapSlice{
+			{Key: "endpoints", Value: splitOption(storageOptions, "es.server-urls", "http://elasticsearch:9200")},
+			{Key: "traces_index", Value: indexName(storageOptions["es.index-prefix"], "jaeger-span")},
+		}
+		if len(spec.Storage.SecretName) > 0 {
+			exporter = append(exporter,
+				yaml.MapItem{Key: "user", Value: "${ES_USERNAME}"},
+				yaml.MapItem{Key: "password", Value: "${ES_PASSWORD}"},
+			)
+		}
+		hint("the elasticsearch exporter doesn't write the spans in the format of Jaeger, keep the Jaeger collector behind an otlp exporter for Jaeger Query to read them")
+	case "cassandra":
+		exporterName = "cassandra"
+		exporter = yaml.MapSlice{{Key: "dsn", Value: strings.Join(splitOption(storageOptions, "cassandra.servers", "cassandra"), ",")}}
+		if keyspace := storageOptions["cassandra.keyspace"]; len(keyspace) > 0 {
+			exporter = append(exporter, yaml.MapItem{Key: "keyspace", Value: keyspace})
+		}
+		hint("the cassandra exporter doesn't write the spans in the format of Jaeger, keep the Jaeger collector behind an otlp exporter for Jaeger Query to read them")
+	case "kafka":
+		exporterName = "kafka"
+		exporter = yaml.MapSlice{
+			{Key: "brokers", Value: splitOption(storageOptions, "kafka.producer.brokers", "kafka:9092")},
+			{Key: "topic", Value: optionOrDefault(storageOptions, "kafka.producer.topic", "jaeger-spans")},
+			{Key: "encoding", Value: "jaeger_proto"},
+		}
+	default:
+		if len(storageType) == 0 {
+			storageType = "memory"
+		}
+		exporterName = "logging"
+		exporter = yaml.MapSlice{}
+		hint("the %s storage of the Jaeger isn't available to collectors, replace the logging exporter with the exporter of the tracing backend, e.g. an otlp exporter to a Jaeger collector", storageType)
+	}
+	if len(spec.Storage.SecretName) > 0 {
+		envFrom = []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: spec.Storage.SecretName}}}}
+		if exporterName != "elasticsearch" {
+			hint("the variables of the secret %s are set in the environment of the collectors, refer to them in the configuration of the %s exporter", spec.Storage.SecretName, exporterName)
+		}
+	}
+
+	config := yaml.MapSlice{
+		{Key: "receivers", Value: receivers},
+		{Key: "processors", Value: yaml.MapSlice{
+			{Key: "batch", Value: yaml.MapSlice{}},
+		}},
+		{Key: "exporters", Value: yaml.MapSlice{
+			{Key: exporterName, Value: exporter},
+		}},
+		{Key: "service", Value: yaml.MapSlice{
+			{Key: "pipelines", Value: yaml.MapSlice{
+				{Key: "traces", Value: yaml.MapSlice{
+					{Key: "receivers", Value: receiverNames},
+					{Key: "processors", Value: []string{"batch"}},
+					{Key: "exporters", Value: []string{exporterName}},
+				}},
+			}},
+		}},
+	}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate the configuration: %w", err)
+	}
+	return string(out), envFrom, nil
+}
+
+// flattenOptions returns the options of a Jaeger component by their dotted names, as the Jaeger Operator accepts
+// both nested and dotted options, e.g. es: {server-urls: ...} and es.server-urls: ....
+func flattenOptions(options map[string]interface{}) map[string]string {
+	flattened := map[string]string{}
+	var flatten func(prefix string, options map[string]interface{})
+	flatten = func(prefix string, options map[string]interface{}) {
+		for key, value := range options {
+			if nested, ok := value.(map[string]interface{}); ok {
+				flatten(prefix+key+".", nested)
+				continue
+			}
+			flattened[prefix+key] = fmt.Sprint(value)
+		}
+	}
+	flatten("", options)
+	return flattened
+}
+
+func optionOrDefault(options map[string]string, key, defaultValue string) string {
+	if value := options[key]; len(value) > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// splitOption returns the values of a comma-separated option.
+func splitOption(options map[string]string, key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(optionOrDefault(options, key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// indexName returns the name of an Elasticsearch index of Jaeger, which Jaeger joins to its prefix with a dash.
+func indexName(prefix, name string) string {
+	if len(prefix) == 0 {
+		return name
+	}
+	return strings.TrimSuffix(prefix, "-") + "-" + name
+}
+
+// zipkinEndpoint returns the endpoint of the zipkin receiver listening on the given host and port of Jaeger, which
+// can omit the host.
+func zipkinEndpoint(hostPort string) string {
+	if strings.HasPrefix(hostPort, ":") {
+		return "0.0.0.0" + hostPort
+	}
+	return hostPort
+}
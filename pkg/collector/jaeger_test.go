@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func jaegerResource(spec map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "jaegertracing.io/v1",
+		"kind":       "Jaeger",
+		"metadata": map[string]interface{}{
+			"name":      "tracing",
+			"namespace": "observability",
+		},
+		"spec": spec,
+	}}
+}
+
+func TestFromJaegerProduction(t *testing.T) {
+	jaeger := jaegerResource(map[string]interface{}{
+		"strategy": "production",
+		"collector": map[string]interface{}{
+			"replicas":    int64(2),
+			"maxReplicas": int64(5),
+			"options": map[string]interface{}{
+				"collector.zipkin.host-port": ":9411",
+			},
+		},
+		"storage": map[string]interface{}{
+			"type":       "elasticsearch",
+			"secretName": "es-credentials",
+			"options": map[string]interface{}{
+				"es": map[string]interface{}{
+					"server-urls":  "https://es1:9200,https://es2:9200",
+					"index-prefix": "prod",
+				},
+			},
+		},
+	})
+
+	generated, err := FromJaeger(logr.Discard(), jaeger, "otel")
+	require.NoError(t, err)
+
+	otelcol := generated.Instance
+	assert.Equal(t, "otel", otelcol.Name)
+	assert.Equal(t, "observability", otelcol.Namespace)
+	assert.Equal(t, v1alpha1.ModeDeployment, otelcol.Spec.Mode)
+	assert.Equal(t, int32(2), *otelcol.Spec.Replicas)
+	require.NotNil(t, otelcol.Spec.Autoscaler)
+	assert.Equal(t, int32(5), *otelcol.Spec.Autoscaler.MaxReplicas)
+	require.Len(t, otelcol.Spec.EnvFrom, 1)
+	assert.Equal(t, "es-credentials", otelcol.Spec.EnvFrom[0].SecretRef.Name)
+
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	require.NoError(t, err)
+	receivers := cfg["receivers"].(map[string]interface{})
+	assert.Contains(t, receivers, "jaeger")
+	assert.Contains(t, receivers, "otlp")
+	assert.Equal(t, "0.0.0.0:9411", receivers["zipkin"].(map[string]interface{})["endpoint"])
+	exporter := cfg["exporters"].(map[string]interface{})["elasticsearch"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"https://es1:9200", "https://es2:9200"}, exporter["endpoints"])
+	assert.Equal(t, "prod-jaeger-span", exporter["traces_index"])
+	assert.Equal(t, "${ES_USERNAME}", exporter["user"])
+
+	var ports []int32
+	for _, port := range generated.Ports {
+		ports = append(ports, port.Port)
+	}
+	assert.Contains(t, ports, int32(14250))
+	assert.Contains(t, ports, int32(9411))
+	assert.Contains(t, generated.Hints, "the elasticsearch exporter doesn't write the spans in the format of Jaeger, keep the Jaeger collector behind an otlp exporter for Jaeger Query to read them")
+}
+
+func TestFromJaegerAllInOne(t *testing.T) {
+	generated, err := FromJaeger(logr.Discard(), jaegerResource(map[string]interface{}{
+		"agent":    map[string]interface{}{"strategy": "DaemonSet"},
+		"sampling": map[string]interface{}{"options": map[string]interface{}{"default_strategy": map[string]interface{}{"type": "probabilistic", "param": 0.5}}},
+	}), "")
+	require.NoError(t, err)
+
+	otelcol := generated.Instance
+	assert.Equal(t, "tracing", otelcol.Name)
+	assert.Nil(t, otelcol.Spec.Replicas)
+	assert.Contains(t, otelcol.Spec.Config, "logging: {}")
+	assert.Equal(t, []string{
+		"the memory storage of the Jaeger isn't available to collectors, replace the logging exporter with the exporter of the tracing backend, e.g. an otlp exporter to a Jaeger collector",
+		"the Jaeger agents of the DaemonSet strategy aren't converted, add an OpenTelemetryCollector in daemonset mode with the jaeger receiver forwarding the spans to this one",
+		"the sampling strategies of the Jaeger aren't converted, configure them in the jaegerremotesampling extension",
+		"the Jaeger Query UI isn't part of the collectors, keep it reading the storage of the Jaeger",
+		"the collector service has the name of the service of the Jaeger collector, tracing-collector, delete the Jaeger before applying this resource so that its clients keep reaching it, or choose another name",
+	}, generated.Hints)
+}
+
+func TestFromJaegerStreaming(t *testing.T) {
+	generated, err := FromJaeger(logr.Discard(), jaegerResource(map[string]interface{}{
+		"strategy": "streaming",
+		"collector": map[string]interface{}{
+			"options": map[string]interface{}{
+				"kafka.producer.brokers": "kafka-0:9092,kafka-1:9092",
+				"kafka.producer.topic":   "spans",
+			},
+		},
+		"storage": map[string]interface{}{"type": "cassandra"},
+	}), "otel")
+	require.NoError(t, err)
+
+	cfg, err := adapters.ConfigFromString(generated.Instance.Spec.Config)
+	require.NoError(t, err)
+	exporter := cfg["exporters"].(map[string]interface{})["kafka"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"kafka-0:9092", "kafka-1:9092"}, exporter["brokers"])
+	assert.Equal(t, "spans", exporter["topic"])
+	assert.Equal(t, "jaeger_proto", exporter["encoding"])
+	assert.Contains(t, generated.Hints, "the Jaeger ingester of the streaming strategy isn't converted, keep it to write the spans of the Kafka topic to the storage")
+}
+
+func TestFromJaegerUnsupported(t *testing.T) {
+	resource := jaegerResource(map[string]interface{}{"strategy": "serverless"})
+	_, err := FromJaeger(logr.Discard(), resource, "")
+	assert.ErrorContains(t, err, "unsupported strategy serverless of the Jaeger tracing")
+
+	resource.SetKind("Prometheus")
+	_, err = FromJaeger(logr.Discard(), resource, "")
+	assert.ErrorContains(t, err, "unsupported resource kind Prometheus")
+}
@@ -68,7 +68,7 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, name string, filterLabels
 func SelectorLabels(instance v1alpha1.OpenTelemetryCollector) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/managed-by": "opentelemetry-operator",
-		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name),
+		"app.kubernetes.io/instance":   naming.Instance(instance),
 		"app.kubernetes.io/part-of":    "opentelemetry",
 		"app.kubernetes.io/component":  "opentelemetry-collector",
 	}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// ConditionTypeLegacyReceivers is the type of the status condition warning about the instances whose configuration
+// uses deprecated receivers.
+const ConditionTypeLegacyReceivers = "LegacyReceivers"
+
+// Reasons of the LegacyReceivers condition.
+const (
+	// ReasonLegacyReceiversSupported is the reason while the operator.collector.legacyreceivers feature gate is enabled.
+	ReasonLegacyReceiversSupported = "LegacyReceiversSupported"
+	// ReasonLegacyReceiversUnsupported is the reason once the feature gate is disabled.
+	ReasonLegacyReceiversUnsupported = "LegacyReceiversUnsupported"
+)
+
+// LegacyReceiversCondition returns the LegacyReceivers condition of the given instance, or nil when its configuration
+// doesn't use any deprecated receiver.
+func LegacyReceiversCondition(otelcol v1alpha1.OpenTelemetryCollector) *metav1.Condition {
+	config, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	legacy := adapters.ConfigToLegacyReceivers(config)
+	if len(legacy) == 0 {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               ConditionTypeLegacyReceivers,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             ReasonLegacyReceiversSupported,
+		Message:            fmt.Sprintf("%s deprecated, migrate to the otlp receiver and the jaegerremotesampling extension before the operator stops inferring their ports", legacyList(legacy)),
+	}
+	if !featuregate.EnableLegacyReceivers.IsEnabled() {
+		condition.Reason = ReasonLegacyReceiversUnsupported
+		condition.Message = fmt.Sprintf("%s deprecated, the operator doesn't infer their ports, list them in spec.ports or migrate to the otlp receiver and the jaegerremotesampling extension", legacyList(legacy))
+	}
+	return condition
+}
+
+// legacyList returns the given configuration paths as the subject of a sentence, e.g. "receivers.opencensus is".
+func legacyList(paths []string) string {
+	if len(paths) == 1 {
+		return paths[0] + " is"
+	}
+	return strings.Join(paths, ", ") + " are"
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestLegacyReceiversCondition(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  opencensus:
+  jaeger:
+    protocols:
+      grpc:
+    remote_sampling:
+      strategy_file: /etc/strategies.json
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [opencensus, jaeger]
+      exporters: [logging]
+`,
+		},
+	}
+
+	for _, tt := range []struct {
+		desc     string
+		enabled  bool
+		expected string
+	}{
+		{
+			desc:     "legacy receivers enabled",
+			enabled:  true,
+			expected: ReasonLegacyReceiversSupported,
+		},
+		{
+			desc:     "legacy receivers disabled",
+			expected: ReasonLegacyReceiversUnsupported,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			originalVal := featuregate.EnableLegacyReceivers.IsEnabled()
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), tt.enabled))
+			defer func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), originalVal))
+			}()
+
+			// test
+			condition := LegacyReceiversCondition(otelcol)
+
+			// verify
+			require.NotNil(t, condition)
+			assert.Equal(t, ConditionTypeLegacyReceivers, condition.Type)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, tt.expected, condition.Reason)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+			assert.Contains(t, condition.Message, "receivers.jaeger.remote_sampling, receivers.opencensus are deprecated")
+		})
+	}
+
+	t.Run("no legacy receivers", func(t *testing.T) {
+		assert.Nil(t, LegacyReceiversCondition(v1alpha1.OpenTelemetryCollector{
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{Config: "receivers:\n  otlp:\n"},
+		}))
+	})
+}
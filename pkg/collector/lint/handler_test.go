@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler()
+
+	t.Run("schemas", func(t *testing.T) {
+		// test
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// verify
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var schemas Schemas
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &schemas))
+		assert.Equal(t, "duration", schemas["processors"]["batch"]["timeout"])
+	})
+
+	t.Run("lint", func(t *testing.T) {
+		// test
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("processors:\n  batch:\n    timeout: 5s\n")))
+
+		// verify
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"warnings": []}`, rec.Body.String())
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		// test
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("🦄")))
+
+		// verify
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		// test
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+
+		// verify
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+	})
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks the settings of the collector components against their schemas, beyond the structure of the
+// configuration, e.g. that the timeout of the batch processor is a duration.
+package lint
+
+import (
+	_ "embed"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemasYAML holds the schemas of the components, see schemas.yaml for its format.
+//
+//go:embed schemas.yaml
+var schemasYAML []byte
+
+// Schemas are the types of the settings of the components, by component kind, component type and setting path, e.g.
+// Schemas["processors"]["batch"]["timeout"] is "duration".
+type Schemas map[string]map[string]map[string]string
+
+var schemas = mustLoadSchemas(schemasYAML)
+
+func mustLoadSchemas(data []byte) Schemas {
+	s := Schemas{}
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		panic(fmt.Sprintf("the component schemas are invalid: %v", err))
+	}
+	for kind, components := range s {
+		for component, settings := range components {
+			for setting, settingType := range settings {
+				if _, err := checker(settingType); err != nil {
+					panic(fmt.Sprintf("the type of the %s setting of the %s %s is invalid: %v", setting, component, kind, err))
+				}
+			}
+		}
+	}
+	return s
+}
+
+// ComponentSchemas returns the schemas of the components checked by the linter.
+func ComponentSchemas() Schemas {
+	return schemas
+}
+
+// Config returns the warnings for the settings of the components of the configuration that don't match their schemas.
+// The configuration can be a snippet, e.g. with only the processors section. The settings whose value refers to an
+// environment variable, like ${env:POD_IP}:4317, can't be checked and are skipped. The warnings are sorted.
+func Config(config map[string]interface{}) []string {
+	var warnings []string
+	for kind, components := range schemas {
+		section, ok := config[kind].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, componentConfig := range section {
+			componentType, _, _ := strings.Cut(name, "/")
+			settings, ok := components[componentType]
+			if !ok {
+				continue
+			}
+			cfg, ok := componentConfig.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for setting, settingType := range settings {
+				value, ok := lookup(cfg, setting)
+				if !ok || hasEnvVar(value) {
+					continue
+				}
+				check, _ := checker(settingType)
+				if problem := check(value); problem != "" {
+					warnings = append(warnings, fmt.Sprintf("the OpenTelemetry Collector config setting '%s.%s.%s' is set to '%v', %s", kind, name, setting, value, problem))
+				}
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// lookup returns the value of the setting of the dot-separated path.
+func lookup(config map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = config
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+func hasEnvVar(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.Contains(s, "${")
+}
+
+// checker returns the function checking the values of the setting type, which returns the problem of the value, if any.
+func checker(settingType string) (func(interface{}) string, error) {
+	switch settingType {
+	case "duration":
+		return checkDuration, nil
+	case "hostport":
+		return checkHostPort, nil
+	case "endpoint":
+		return checkEndpoint, nil
+	case "url":
+		return checkURL, nil
+	case "uint":
+		return checkUint, nil
+	case "percentage":
+		return checkPercentage, nil
+	case "bool":
+		return checkBool, nil
+	}
+	if values, ok := strings.CutPrefix(settingType, "oneof "); ok {
+		return checkOneOf(strings.Fields(values)), nil
+	}
+	return nil, fmt.Errorf("unknown setting type %q", settingType)
+}
+
+func checkDuration(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if _, err := time.ParseDuration(v); err == nil {
+			return ""
+		}
+	case int:
+		return "which is a number of nanoseconds rather than a duration like 200ms or 10s"
+	}
+	return "which isn't a duration like 200ms or 10s"
+}
+
+func checkHostPort(value interface{}) string {
+	if s, ok := value.(string); ok && isHostPort(s) {
+		return ""
+	}
+	return "which isn't a host:port address like 0.0.0.0:4317"
+}
+
+func checkEndpoint(value interface{}) string {
+	s, ok := value.(string)
+	if ok && (isHostPort(s) || isURL(s, "http", "https", "dns", "unix")) {
+		return ""
+	}
+	return "which isn't a host:port address or a URL"
+}
+
+func checkURL(value interface{}) string {
+	if s, ok := value.(string); ok && isURL(s, "http", "https") {
+		return ""
+	}
+	return "which isn't an http or https URL"
+}
+
+func checkUint(value interface{}) string {
+	if i, ok := value.(int); ok && i >= 0 {
+		return ""
+	}
+	return "which isn't a non-negative integer"
+}
+
+func checkPercentage(value interface{}) string {
+	var f float64
+	switch v := value.(type) {
+	case int:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return "which isn't a number"
+	}
+	if f < 0 || f > 100 {
+		return "which isn't between 0 and 100"
+	}
+	return ""
+}
+
+func checkBool(value interface{}) string {
+	if _, ok := value.(bool); ok {
+		return ""
+	}
+	return "which isn't true or false"
+}
+
+func checkOneOf(values []string) func(interface{}) string {
+	return func(value interface{}) string {
+		for _, v := range values {
+			if value == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("which isn't one of %s", strings.Join(values, ", "))
+	}
+}
+
+func isHostPort(s string) bool {
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return false
+	}
+	_, err = strconv.ParseUint(port, 10, 16)
+	return err == nil
+}
+
+func isURL(s string, schemes ...string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return scheme == "unix" || scheme == "dns" || len(u.Host) > 0
+		}
+	}
+	return false
+}
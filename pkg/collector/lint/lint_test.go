@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "valid settings",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: ${env:POD_IP}:4318
+processors:
+  batch:
+    timeout: 200ms
+    send_batch_size: 8192
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 75
+  probabilistic_sampler:
+    sampling_percentage: 15.3
+exporters:
+  otlp:
+    endpoint: tempo:4317
+    compression: zstd
+    tls:
+      insecure: true
+  otlp/dns:
+    endpoint: dns:///tempo:4317
+  otlphttp:
+    endpoint: https://otlp.example.com:4318
+  debug:
+    verbosity: detailed
+extensions:
+  health_check:
+    endpoint: :13133
+`,
+		},
+		{
+			desc: "invalid settings",
+			config: `receivers:
+  otlp/custom:
+    protocols:
+      grpc:
+        endpoint: http://0.0.0.0:4317
+processors:
+  batch:
+    timeout: 5
+    send_batch_size: -1
+  batch/2:
+    timeout: 5 seconds
+  memory_limiter:
+    limit_percentage: 120
+exporters:
+  otlp:
+    endpoint: "tempo:"
+    compression: brotli
+    tls:
+      insecure: "yes"
+  otlphttp:
+    endpoint: otlp.example.com:4318
+`,
+			expected: []string{
+				"the OpenTelemetry Collector config setting 'exporters.otlp.compression' is set to 'brotli', which isn't one of gzip, zstd, snappy, none",
+				"the OpenTelemetry Collector config setting 'exporters.otlp.endpoint' is set to 'tempo:', which isn't a host:port address or a URL",
+				"the OpenTelemetry Collector config setting 'exporters.otlp.tls.insecure' is set to 'yes', which isn't true or false",
+				"the OpenTelemetry Collector config setting 'exporters.otlphttp.endpoint' is set to 'otlp.example.com:4318', which isn't an http or https URL",
+				"the OpenTelemetry Collector config setting 'processors.batch.send_batch_size' is set to '-1', which isn't a non-negative integer",
+				"the OpenTelemetry Collector config setting 'processors.batch.timeout' is set to '5', which is a number of nanoseconds rather than a duration like 200ms or 10s",
+				"the OpenTelemetry Collector config setting 'processors.batch/2.timeout' is set to '5 seconds', which isn't a duration like 200ms or 10s",
+				"the OpenTelemetry Collector config setting 'processors.memory_limiter.limit_percentage' is set to '120', which isn't between 0 and 100",
+				"the OpenTelemetry Collector config setting 'receivers.otlp/custom.protocols.grpc.endpoint' is set to 'http://0.0.0.0:4317', which isn't a host:port address like 0.0.0.0:4317",
+			},
+		},
+		{
+			desc: "snippet",
+			config: `batch:
+  timeout: 5
+`,
+		},
+		{
+			desc: "unknown components and settings",
+			config: `processors:
+  custom:
+    timeout: 5
+  batch:
+    timeouts: 5
+    send_batch_size:
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := adapters.ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			warnings := Config(config)
+
+			// verify
+			assert.Equal(t, tt.expected, warnings)
+		})
+	}
+}
+
+func TestInvalidSchemas(t *testing.T) {
+	assert.PanicsWithValue(t, `the type of the timeout setting of the batch processors is invalid: unknown setting type "period"`, func() {
+		mustLoadSchemas([]byte("processors:\n  batch:\n    timeout: period\n"))
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// NodeProfileLabel is the label holding the node profile of the daemonsets of the node profiles and of their pods.
+const NodeProfileLabel = "opentelemetry.io/node-profile"
+
+// NodeProfileInstance returns the instance as it runs on the nodes of the given profile, with the configuration,
+// resources, tolerations and environment variables of the profile. When the configuration of the profile can't be
+// merged, the returned instance keeps the configuration of the given one along with the error.
+func NodeProfileInstance(otelcol v1alpha1.OpenTelemetryCollector, profile v1alpha1.NodeProfile) (v1alpha1.OpenTelemetryCollector, error) {
+	instance := *otelcol.DeepCopy()
+	instance.Spec.NodeProfiles = nil
+
+	nodeSelector := make(map[string]string, len(otelcol.Spec.NodeSelector)+len(profile.NodeSelector))
+	for k, v := range otelcol.Spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	for k, v := range profile.NodeSelector {
+		nodeSelector[k] = v
+	}
+	instance.Spec.NodeSelector = nodeSelector
+	instance.Spec.Tolerations = append(instance.Spec.Tolerations, profile.Tolerations...)
+	instance.Spec.Env = append(instance.Spec.Env, profile.Env...)
+	if profile.Resources != nil {
+		instance.Spec.Resources = *profile.Resources.DeepCopy()
+	}
+
+	if profile.Config == "" {
+		return instance, nil
+	}
+	config, err := mergeConfigs(otelcol.Spec.Config, profile.Config)
+	if err != nil {
+		return instance, fmt.Errorf("failed to merge the configuration of the node profile %s: %w", profile.Name, err)
+	}
+	instance.Spec.Config = config
+	return instance, nil
+}
+
+func mergeConfigs(base, overlay string) (string, error) {
+	baseConfig, err := adapters.ConfigFromString(base)
+	if err != nil {
+		return "", err
+	}
+	overlayConfig, err := adapters.ConfigFromString(overlay)
+	if err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(adapters.MergeConfigs(baseConfig, overlayConfig))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// excludeNodeProfiles returns a copy of the given affinity that additionally requires the nodes not to match the node
+// selector of any of the given profiles. A node doesn't match a node selector when one of its labels is different, so
+// the requirement is expanded into one node selector term for each combination of a label of each profile.
+func excludeNodeProfiles(affinity *corev1.Affinity, profiles []v1alpha1.NodeProfile) *corev1.Affinity {
+	exclusions := [][]corev1.NodeSelectorRequirement{{}}
+	for _, profile := range profiles {
+		keys := make([]string, 0, len(profile.NodeSelector))
+		for key := range profile.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		expanded := make([][]corev1.NodeSelectorRequirement, 0, len(exclusions)*len(keys))
+		for _, exclusion := range exclusions {
+			for _, key := range keys {
+				requirements := append(append([]corev1.NodeSelectorRequirement{}, exclusion...), corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{profile.NodeSelector[key]},
+				})
+				expanded = append(expanded, requirements)
+			}
+		}
+		exclusions = expanded
+	}
+
+	excluded := affinity.DeepCopy()
+	if excluded == nil {
+		excluded = &corev1.Affinity{}
+	}
+	if excluded.NodeAffinity == nil {
+		excluded.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if excluded.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		excluded.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := excluded.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	terms := required.NodeSelectorTerms
+	if len(terms) == 0 {
+		terms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	// the terms are ORed, so each of the terms of the affinity is combined with each of the exclusions
+	required.NodeSelectorTerms = make([]corev1.NodeSelectorTerm, 0, len(terms)*len(exclusions))
+	for _, term := range terms {
+		for _, exclusion := range exclusions {
+			combined := *term.DeepCopy()
+			combined.MatchExpressions = append(combined.MatchExpressions, exclusion...)
+			required.NodeSelectorTerms = append(required.NodeSelectorTerms, combined)
+		}
+	}
+	return excluded
+}
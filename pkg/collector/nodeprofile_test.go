@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestNodeProfileInstance(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:         v1alpha1.ModeDaemonSet,
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			Tolerations:  []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}},
+			Env:          []v1.EnvVar{{Name: "A", Value: "a"}},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+		},
+	}
+	resources := v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")}}
+	profile := v1alpha1.NodeProfile{
+		Name:         "gpu",
+		NodeSelector: map[string]string{"node-pool": "gpu"},
+		Resources:    &resources,
+		Tolerations:  []v1.Toleration{{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists}},
+		Env:          []v1.EnvVar{{Name: "B", Value: "b"}},
+		Config: `receivers:
+  prometheus/dcgm:
+    config:
+      scrape_configs:
+      - job_name: dcgm
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp, prometheus/dcgm]
+`,
+	}
+	otelcol.Spec.NodeProfiles = []v1alpha1.NodeProfile{profile}
+
+	// test
+	instance, err := NodeProfileInstance(otelcol, profile)
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, instance.Spec.NodeProfiles)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "node-pool": "gpu"}, instance.Spec.NodeSelector)
+	assert.Len(t, instance.Spec.Tolerations, 2)
+	assert.Equal(t, []v1.EnvVar{{Name: "A", Value: "a"}, {Name: "B", Value: "b"}}, instance.Spec.Env)
+	assert.Equal(t, resources, instance.Spec.Resources)
+
+	config, err := adapters.ConfigFromString(instance.Spec.Config)
+	require.NoError(t, err)
+	assert.Contains(t, config["receivers"], "otlp")
+	assert.Contains(t, config["receivers"], "prometheus/dcgm")
+	assert.Contains(t, config["exporters"], "logging")
+
+	// the instance is left untouched
+	assert.Len(t, otelcol.Spec.Tolerations, 1)
+	assert.Len(t, otelcol.Spec.Env, 1)
+	assert.Len(t, otelcol.Spec.NodeSelector, 1)
+}
+
+func TestNodeProfileInstanceInvalidConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers:\n  otlp:\n",
+		},
+	}
+
+	// test
+	instance, err := NodeProfileInstance(otelcol, v1alpha1.NodeProfile{Name: "gpu", Config: "🦄"})
+
+	// verify
+	assert.Error(t, err)
+	assert.Equal(t, otelcol.Spec.Config, instance.Spec.Config)
+}
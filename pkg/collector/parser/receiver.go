@@ -21,10 +21,13 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -42,15 +45,25 @@ type ReceiverParser interface {
 }
 
 // Builder specifies the signature required for parser builders.
-type Builder func(logr.Logger, string, map[interface{}]interface{}) ReceiverParser
+type Builder func(logr.Logger, string, map[string]interface{}) ReceiverParser
 
-// registry holds a record of all known parsers.
-var registry = make(map[string]Builder)
+var (
+	// registry holds a record of all known parsers.
+	registry = make(map[string]Builder)
+	// registryMu guards the registry, which is read by concurrent reconciliations.
+	registryMu sync.RWMutex
+
+	// legacyReceivers are the deprecated receivers, which are parsed as unknown receivers unless the
+	// operator.collector.legacyreceivers feature gate is enabled.
+	legacyReceivers = map[string]bool{"opencensus": true}
+)
 
 // BuilderFor returns a parser builder for the given receiver name.
 func BuilderFor(name string) Builder {
+	registryMu.RLock()
 	builder := registry[receiverType(name)]
-	if builder == nil {
+	registryMu.RUnlock()
+	if builder == nil || (legacyReceivers[receiverType(name)] && !featuregate.EnableLegacyReceivers.IsEnabled()) {
 		builder = NewGenericReceiverParser
 	}
 
@@ -58,28 +71,32 @@ func BuilderFor(name string) Builder {
 }
 
 // For returns a new parser for the given receiver name + config.
-func For(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func For(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	builder := BuilderFor(name)
 	return builder(logger, name, config)
 }
 
 // Register adds a new parser builder to the list of known builders.
 func Register(name string, builder Builder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
 	registry[name] = builder
 }
 
 // IsRegistered checks whether a parser is registered with the given name.
 func IsRegistered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 	_, ok := registry[name]
 	return ok
 }
 
-var (
+const (
 	endpointKey      = "endpoint"
 	listenAddressKey = "listen_address"
 )
 
-func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[interface{}]interface{}) *v1.ServicePort {
+func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[string]interface{}) *v1.ServicePort {
 	var endpoint interface{}
 	switch {
 	// syslog receiver contains the endpoint
@@ -87,12 +104,12 @@ func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[in
 	// i.e. either in tcp or udp section with field key
 	// as `listen_address`
 	case name == "syslog":
-		var c map[interface{}]interface{}
+		var c map[string]interface{}
 		if udp, isUDP := config["udp"]; isUDP && udp != nil {
-			c = udp.(map[interface{}]interface{})
+			c = udp.(map[string]interface{})
 			endpoint = getAddressFromConfig(logger, name, listenAddressKey, c)
 		} else if tcp, isTCP := config["tcp"]; isTCP && tcp != nil {
-			c = tcp.(map[interface{}]interface{})
+			c = tcp.(map[string]interface{})
 			endpoint = getAddressFromConfig(logger, name, listenAddressKey, c)
 		}
 
@@ -136,7 +153,7 @@ func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[in
 	return nil
 }
 
-func getAddressFromConfig(logger logr.Logger, name, key string, config map[interface{}]interface{}) interface{} {
+func getAddressFromConfig(logger logr.Logger, name, key string, config map[string]interface{}) interface{} {
 	endpoint, ok := config[key]
 	if !ok {
 		logger.V(2).Info("%s receiver doesn't have an %s", name, key)
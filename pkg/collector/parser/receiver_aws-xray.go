@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameAWSXRAY = "__awsxray"
 
 // NewAWSXrayReceiverParser builds a new parser for AWS xray receivers, from the contrib repository.
-func NewAWSXrayReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewAWSXrayReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
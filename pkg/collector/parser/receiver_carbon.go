@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameCarbon = "__carbon"
 
 // NewCarbonReceiverParser builds a new parser for Carbon receivers, from the contrib repository.
-func NewCarbonReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewCarbonReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameCollectd = "__collectd"
 
 // NewCollectdReceiverParser builds a new parser for Collectd receivers, from the contrib repository.
-func NewCollectdReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewCollectdReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
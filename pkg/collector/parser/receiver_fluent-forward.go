@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameFluentForward = "__fluentforward"
 
 // NewFluentForwardReceiverParser builds a new parser for FluentForward receivers, from the contrib repository.
-func NewFluentForwardReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewFluentForwardReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
@@ -25,7 +25,7 @@ var _ ReceiverParser = &GenericReceiver{}
 
 // GenericReceiver is a special parser for generic receivers. It doesn't self-register and should be created/used directly.
 type GenericReceiver struct {
-	config             map[interface{}]interface{}
+	config             map[string]interface{}
 	defaultAppProtocol *string
 	logger             logr.Logger
 	name               string
@@ -38,7 +38,7 @@ type GenericReceiver struct {
 // so that it can expose the required port based on the receiver's config. Receiver scrapers are ignored.
 
 // NewGenericReceiverParser builds a new parser for generic receivers.
-func NewGenericReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewGenericReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:     logger,
 		name:       name,
@@ -29,7 +29,7 @@ var logger = logf.Log.WithName("unit-tests")
 func TestParseEndpoint(t *testing.T) {
 	// prepare
 	// there's no parser registered to handle "myreceiver", so, it falls back to the generic parser
-	builder := parser.NewGenericReceiverParser(logger, "myreceiver", map[interface{}]interface{}{
+	builder := parser.NewGenericReceiverParser(logger, "myreceiver", map[string]interface{}{
 		"endpoint": "0.0.0.0:1234",
 	})
 
@@ -45,7 +45,7 @@ func TestParseEndpoint(t *testing.T) {
 func TestFailedToParseEndpoint(t *testing.T) {
 	// prepare
 	// there's no parser registered to handle "myreceiver", so, it falls back to the generic parser
-	builder := parser.NewGenericReceiverParser(logger, "myreceiver", map[interface{}]interface{}{
+	builder := parser.NewGenericReceiverParser(logger, "myreceiver", map[string]interface{}{
 		"endpoint": "0.0.0.0",
 	})
 
@@ -59,7 +59,7 @@ func TestFailedToParseEndpoint(t *testing.T) {
 
 func TestDownstreamParsers(t *testing.T) {
 	for _, tt := range []struct {
-		builder      func(logr.Logger, string, map[interface{}]interface{}) parser.ReceiverParser
+		builder      func(logr.Logger, string, map[string]interface{}) parser.ReceiverParser
 		desc         string
 		receiverName string
 		parserName   string
@@ -84,7 +84,7 @@ func TestDownstreamParsers(t *testing.T) {
 		t.Run(tt.receiverName, func(t *testing.T) {
 			t.Run("builds successfully", func(t *testing.T) {
 				// test
-				builder := tt.builder(logger, tt.receiverName, map[interface{}]interface{}{})
+				builder := tt.builder(logger, tt.receiverName, map[string]interface{}{})
 
 				// verify
 				assert.Equal(t, tt.parserName, builder.ParserName())
@@ -92,7 +92,7 @@ func TestDownstreamParsers(t *testing.T) {
 
 			t.Run("assigns the expected port", func(t *testing.T) {
 				// prepare
-				builder := tt.builder(logger, tt.receiverName, map[interface{}]interface{}{})
+				builder := tt.builder(logger, tt.receiverName, map[string]interface{}{})
 
 				// test
 				ports, err := builder.Ports()
@@ -106,7 +106,7 @@ func TestDownstreamParsers(t *testing.T) {
 
 			t.Run("allows port to be overridden", func(t *testing.T) {
 				// prepare
-				builder := tt.builder(logger, tt.receiverName, map[interface{}]interface{}{
+				builder := tt.builder(logger, tt.receiverName, map[string]interface{}{
 					"endpoint": "0.0.0.0:65535",
 				})
 
@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameInfluxdb = "__influxdb"
 
 // NewInfluxdbReceiverParser builds a new parser for Influxdb receivers, from the contrib repository.
-func NewInfluxdbReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewInfluxdbReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
@@ -19,6 +19,8 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var _ ReceiverParser = &JaegerReceiverParser{}
@@ -26,32 +28,39 @@ var _ ReceiverParser = &JaegerReceiverParser{}
 const (
 	parserNameJaeger = "__jaeger"
 
-	defaultGRPCPort          int32 = 14250
-	defaultThriftHTTPPort    int32 = 14268
-	defaultThriftCompactPort int32 = 6831
-	defaultThriftBinaryPort  int32 = 6832
+	defaultGRPCPort           int32 = 14250
+	defaultThriftHTTPPort     int32 = 14268
+	defaultThriftCompactPort  int32 = 6831
+	defaultThriftBinaryPort   int32 = 6832
+	defaultRemoteSamplingPort int32 = 5778
 )
 
 // JaegerReceiverParser parses the configuration for Jaeger-specific receivers.
 type JaegerReceiverParser struct {
-	config map[interface{}]interface{}
+	config map[string]interface{}
 	logger logr.Logger
 	name   string
+	// remoteSampling is the deprecated remote sampling configuration of the receiver, nil when not configured.
+	remoteSampling map[string]interface{}
 }
 
 // NewJaegerReceiverParser builds a new parser for Jaeger receivers.
-func NewJaegerReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
-	if protocols, ok := config["protocols"].(map[interface{}]interface{}); ok {
+func NewJaegerReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
+	remoteSampling, _ := config["remote_sampling"].(map[string]interface{})
+	if protocols, ok := config["protocols"].(map[string]interface{}); ok {
 		return &JaegerReceiverParser{
-			logger: logger,
-			name:   name,
-			config: protocols,
+			logger:         logger,
+			name:           name,
+			config:         protocols,
+			remoteSampling: remoteSampling,
 		}
 	}
 
 	return &JaegerReceiverParser{
-		name:   name,
-		config: map[interface{}]interface{}{},
+		logger:         logger,
+		name:           name,
+		config:         map[string]interface{}{},
+		remoteSampling: remoteSampling,
 	}
 }
 
@@ -95,7 +104,7 @@ func (j *JaegerReceiverParser) Ports() ([]corev1.ServicePort, error) {
 			var protocolPort *corev1.ServicePort
 
 			// do we have a configuration block for the protocol?
-			settings, ok := receiverProtocol.(map[interface{}]interface{})
+			settings, ok := receiverProtocol.(map[string]interface{})
 			if ok {
 				protocolPort = singlePortFromConfigEndpoint(j.logger, nameWithProtocol, settings)
 			}
@@ -122,6 +131,24 @@ func (j *JaegerReceiverParser) Ports() ([]corev1.ServicePort, error) {
 		}
 	}
 
+	// the deprecated remote sampling serves the sampling strategies on its host endpoint
+	if j.remoteSampling != nil && featuregate.EnableLegacyReceivers.IsEnabled() {
+		nameWithProtocol := fmt.Sprintf("%s-remote-sampling", j.name)
+		port := singlePortFromConfigEndpoint(j.logger, nameWithProtocol, map[string]interface{}{
+			endpointKey: j.remoteSampling["host_endpoint"],
+		})
+		if port == nil {
+			port = &corev1.ServicePort{
+				Name: portName(nameWithProtocol, defaultRemoteSamplingPort),
+				Port: defaultRemoteSamplingPort,
+			}
+		}
+		port.Protocol = corev1.ProtocolTCP
+		appProtocol := "http"
+		port.AppProtocol = &appProtocol
+		ports = append(ports, *port)
+	}
+
 	return ports, nil
 }
 
@@ -18,7 +18,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestJaegerSelfRegisters(t *testing.T) {
@@ -28,7 +32,7 @@ func TestJaegerSelfRegisters(t *testing.T) {
 
 func TestJaegerIsFoundByName(t *testing.T) {
 	// test
-	p := For(logger, "jaeger", map[interface{}]interface{}{})
+	p := For(logger, "jaeger", map[string]interface{}{})
 
 	// verify
 	assert.Equal(t, "__jaeger", p.ParserName())
@@ -36,9 +40,9 @@ func TestJaegerIsFoundByName(t *testing.T) {
 
 func TestJaegerMinimalConfiguration(t *testing.T) {
 	// prepare
-	builder := NewJaegerReceiverParser(logger, "jaeger", map[interface{}]interface{}{
-		"protocols": map[interface{}]interface{}{
-			"grpc": map[interface{}]interface{}{},
+	builder := NewJaegerReceiverParser(logger, "jaeger", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{},
 		},
 	})
 
@@ -54,9 +58,9 @@ func TestJaegerMinimalConfiguration(t *testing.T) {
 
 func TestJaegerPortsOverridden(t *testing.T) {
 	// prepare
-	builder := NewJaegerReceiverParser(logger, "jaeger", map[interface{}]interface{}{
-		"protocols": map[interface{}]interface{}{
-			"grpc": map[interface{}]interface{}{
+	builder := NewJaegerReceiverParser(logger, "jaeger", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{
 				"endpoint": "0.0.0.0:1234",
 			},
 		},
@@ -74,12 +78,12 @@ func TestJaegerPortsOverridden(t *testing.T) {
 
 func TestJaegerExposeDefaultPorts(t *testing.T) {
 	// prepare
-	builder := NewJaegerReceiverParser(logger, "jaeger", map[interface{}]interface{}{
-		"protocols": map[interface{}]interface{}{
-			"grpc":           map[interface{}]interface{}{},
-			"thrift_http":    map[interface{}]interface{}{},
-			"thrift_compact": map[interface{}]interface{}{},
-			"thrift_binary":  map[interface{}]interface{}{},
+	builder := NewJaegerReceiverParser(logger, "jaeger", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc":           map[string]interface{}{},
+			"thrift_http":    map[string]interface{}{},
+			"thrift_compact": map[string]interface{}{},
+			"thrift_binary":  map[string]interface{}{},
 		},
 	})
 
@@ -112,3 +116,59 @@ func TestJaegerExposeDefaultPorts(t *testing.T) {
 		assert.True(t, v.seen, "the port %s wasn't included in the service ports", k)
 	}
 }
+
+func TestJaegerRemoteSampling(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		enabled  bool
+		config   map[string]interface{}
+		expected []int32
+	}{
+		{
+			desc:     "default host endpoint",
+			enabled:  true,
+			config:   map[string]interface{}{"strategy_file": "/etc/strategies.json"},
+			expected: []int32{14250, 5778},
+		},
+		{
+			desc:     "host endpoint",
+			enabled:  true,
+			config:   map[string]interface{}{"host_endpoint": "0.0.0.0:15778"},
+			expected: []int32{14250, 15778},
+		},
+		{
+			desc:     "legacy receivers disabled",
+			config:   map[string]interface{}{"host_endpoint": "0.0.0.0:15778"},
+			expected: []int32{14250},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			originalVal := featuregate.EnableLegacyReceivers.IsEnabled()
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), tt.enabled))
+			defer func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), originalVal))
+			}()
+			builder := NewJaegerReceiverParser(logger, "jaeger", map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{},
+				},
+				"remote_sampling": tt.config,
+			})
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			var actual []int32
+			for _, port := range ports {
+				actual = append(actual, port.Port)
+			}
+			assert.Equal(t, tt.expected, actual)
+			if len(ports) > 1 {
+				assert.Equal(t, "jaeger-remote-sampling", ports[1].Name)
+				assert.Equal(t, corev1.ProtocolTCP, ports[1].Protocol)
+			}
+		})
+	}
+}
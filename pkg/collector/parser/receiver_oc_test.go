@@ -14,4 +14,46 @@
 
 package parser
 
-// all tests for the OpenCensus parser are currently part of the test TestDownstreamParsers
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// the other tests for the OpenCensus parser are part of the test TestDownstreamParsers
+
+func TestOpenCensusLegacyReceivers(t *testing.T) {
+	for _, tt := range []struct {
+		desc          string
+		enabled       bool
+		expectedPorts int
+	}{
+		{
+			desc:          "legacy receivers enabled",
+			enabled:       true,
+			expectedPorts: 1,
+		},
+		{
+			desc: "legacy receivers disabled",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			originalVal := featuregate.EnableLegacyReceivers.IsEnabled()
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), tt.enabled))
+			defer func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableLegacyReceivers.ID(), originalVal))
+			}()
+
+			// test
+			ports, err := For(logger, "opencensus", map[string]interface{}{}).Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, tt.expectedPorts)
+		})
+	}
+}
@@ -32,21 +32,21 @@ const (
 	defaultOTLPHTTPPort       int32 = 4318
 )
 
-var (
+const (
 	grpc = "grpc"
 	http = "http"
 )
 
 // OTLPReceiverParser parses the configuration for OTLP receivers.
 type OTLPReceiverParser struct {
-	config map[interface{}]interface{}
+	config map[string]interface{}
 	logger logr.Logger
 	name   string
 }
 
 // NewOTLPReceiverParser builds a new parser for OTLP receivers.
-func NewOTLPReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
-	if protocols, ok := config["protocols"].(map[interface{}]interface{}); ok {
+func NewOTLPReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
+	if protocols, ok := config["protocols"].(map[string]interface{}); ok {
 		return &OTLPReceiverParser{
 			logger: logger,
 			name:   name,
@@ -56,7 +56,7 @@ func NewOTLPReceiverParser(logger logr.Logger, name string, config map[interface
 
 	return &OTLPReceiverParser{
 		name:   name,
-		config: map[interface{}]interface{}{},
+		config: map[string]interface{}{},
 	}
 }
 
@@ -64,6 +64,9 @@ func NewOTLPReceiverParser(logger logr.Logger, name string, config map[interface
 func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 	ports := []corev1.ServicePort{}
 
+	// the ports of concurrent reconciliations must not share the app protocols
+	grpcAppProtocol, httpAppProtocol := grpc, http
+
 	for _, protocol := range []struct {
 		name         string
 		defaultPorts []corev1.ServicePort
@@ -75,7 +78,7 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 					Name:        portName(fmt.Sprintf("%s-grpc", o.name), defaultOTLPGRPCPort),
 					Port:        defaultOTLPGRPCPort,
 					TargetPort:  intstr.FromInt(int(defaultOTLPGRPCPort)),
-					AppProtocol: &grpc,
+					AppProtocol: &grpcAppProtocol,
 				},
 			},
 		},
@@ -86,13 +89,13 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 					Name:        portName(fmt.Sprintf("%s-http", o.name), defaultOTLPHTTPPort),
 					Port:        defaultOTLPHTTPPort,
 					TargetPort:  intstr.FromInt(int(defaultOTLPHTTPPort)),
-					AppProtocol: &http,
+					AppProtocol: &httpAppProtocol,
 				},
 				{
 					Name:        portName(fmt.Sprintf("%s-http-legacy", o.name), defaultOTLPHTTPLegacyPort),
 					Port:        defaultOTLPHTTPLegacyPort,
 					TargetPort:  intstr.FromInt(int(defaultOTLPHTTPPort)), // we target the official port, not the legacy
-					AppProtocol: &http,
+					AppProtocol: &httpAppProtocol,
 				},
 			},
 		},
@@ -104,7 +107,7 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 			var protocolPort *corev1.ServicePort
 
 			// do we have a configuration block for the protocol?
-			settings, ok := receiverProtocol.(map[interface{}]interface{})
+			settings, ok := receiverProtocol.(map[string]interface{})
 			if ok {
 				protocolPort = singlePortFromConfigEndpoint(o.logger, nameWithProtocol, settings)
 			}
@@ -117,10 +120,10 @@ func (o *OTLPReceiverParser) Ports() ([]corev1.ServicePort, error) {
 				// infer protocol and appProtocol from protocol.name
 				if protocol.name == grpc {
 					protocolPort.Protocol = corev1.ProtocolTCP
-					protocolPort.AppProtocol = &grpc
+					protocolPort.AppProtocol = &grpcAppProtocol
 				} else if protocol.name == http {
 					protocolPort.Protocol = corev1.ProtocolTCP
-					protocolPort.AppProtocol = &http
+					protocolPort.AppProtocol = &httpAppProtocol
 				}
 				ports = append(ports, *protocolPort)
 			}
@@ -27,7 +27,7 @@ func TestOTLPSelfRegisters(t *testing.T) {
 
 func TestOTLPIsFoundByName(t *testing.T) {
 	// test
-	p := For(logger, "otlp", map[interface{}]interface{}{})
+	p := For(logger, "otlp", map[string]interface{}{})
 
 	// verify
 	assert.Equal(t, "__otlp", p.ParserName())
@@ -35,12 +35,12 @@ func TestOTLPIsFoundByName(t *testing.T) {
 
 func TestOTLPPortsOverridden(t *testing.T) {
 	// prepare
-	builder := NewOTLPReceiverParser(logger, "otlp", map[interface{}]interface{}{
-		"protocols": map[interface{}]interface{}{
-			"grpc": map[interface{}]interface{}{
+	builder := NewOTLPReceiverParser(logger, "otlp", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{
 				"endpoint": "0.0.0.0:1234",
 			},
-			"http": map[interface{}]interface{}{
+			"http": map[string]interface{}{
 				"endpoint": "0.0.0.0:1235",
 			},
 		},
@@ -74,10 +74,10 @@ func TestOTLPPortsOverridden(t *testing.T) {
 
 func TestOTLPExposeDefaultPorts(t *testing.T) {
 	// prepare
-	builder := NewOTLPReceiverParser(logger, "otlp", map[interface{}]interface{}{
-		"protocols": map[interface{}]interface{}{
-			"grpc": map[interface{}]interface{}{},
-			"http": map[interface{}]interface{}{},
+	builder := NewOTLPReceiverParser(logger, "otlp", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{},
+			"http": map[string]interface{}{},
 		},
 	})
 
@@ -107,3 +107,22 @@ func TestOTLPExposeDefaultPorts(t *testing.T) {
 		assert.True(t, v.seen, "the port %s wasn't included in the service ports", k)
 	}
 }
+
+func TestOTLPPortsDontShareAppProtocols(t *testing.T) {
+	// prepare
+	builder := NewOTLPReceiverParser(logger, "otlp", map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{},
+		},
+	})
+	first, err := builder.Ports()
+	assert.NoError(t, err)
+
+	// test
+	*first[0].AppProtocol = "changed"
+	second, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, "grpc", *second[0].AppProtocol)
+}
@@ -15,6 +15,8 @@
 package parser
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -88,7 +90,7 @@ func TestReceiverParsePortFromEndpoint(t *testing.T) {
 
 func TestReceiverFailsWhenPortIsntString(t *testing.T) {
 	// prepare
-	config := map[interface{}]interface{}{
+	config := map[string]interface{}{
 		"endpoint": 123,
 	}
 
@@ -102,7 +104,7 @@ func TestReceiverFailsWhenPortIsntString(t *testing.T) {
 func TestIgnorekubeletstatsEndpoint(t *testing.T) {
 	// ignore "kubeletstats" receiver endpoint field, this is special case
 	// as this receiver gets parsed by generic receiver parser
-	builder := NewGenericReceiverParser(logger, "kubeletstats", map[interface{}]interface{}{
+	builder := NewGenericReceiverParser(logger, "kubeletstats", map[string]interface{}{
 		"endpoint": "0.0.0.0:9000",
 	})
 
@@ -116,7 +118,7 @@ func TestIgnorekubeletstatsEndpoint(t *testing.T) {
 
 func TestReceiverFallbackWhenNotRegistered(t *testing.T) {
 	// test
-	p := For(logger, "myreceiver", map[interface{}]interface{}{})
+	p := For(logger, "myreceiver", map[string]interface{}{})
 
 	// test
 	assert.Equal(t, "__generic", p.ParserName())
@@ -125,13 +127,13 @@ func TestReceiverFallbackWhenNotRegistered(t *testing.T) {
 func TestReceiverShouldFindRegisteredParser(t *testing.T) {
 	// prepare
 	builderCalled := false
-	Register("mock", func(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	Register("mock", func(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 		builderCalled = true
 		return &mockParser{}
 	})
 
 	// test
-	For(logger, "mock", map[interface{}]interface{}{})
+	For(logger, "mock", map[string]interface{}{})
 
 	// verify
 	assert.True(t, builderCalled)
@@ -147,3 +149,23 @@ func (m *mockParser) Ports() ([]corev1.ServicePort, error) {
 func (m *mockParser) ParserName() string {
 	return "__mock"
 }
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			Register(fmt.Sprintf("__concurrent-%d", i), NewGenericReceiverParser)
+		}(i)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "__otlp", For(logger, "otlp", map[string]interface{}{}).ParserName())
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, IsRegistered(fmt.Sprintf("__concurrent-%d", i)))
+	}
+}
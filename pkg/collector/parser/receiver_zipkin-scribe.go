@@ -19,7 +19,7 @@ import "github.com/go-logr/logr"
 const parserNameZipkinScribe = "__zipkinscribe"
 
 // NewZipkinScribeReceiverParser builds a new parser for ZipkinScribe receivers.
-func NewZipkinScribeReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewZipkinScribeReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	return &GenericReceiver{
 		logger:      logger,
 		name:        name,
@@ -22,7 +22,7 @@ import (
 const parserNameZipkin = "__zipkin"
 
 // NewZipkinReceiverParser builds a new parser for Zipkin receivers.
-func NewZipkinReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+func NewZipkinReceiverParser(logger logr.Logger, name string, config map[string]interface{}) ReceiverParser {
 	http := "http"
 	return &GenericReceiver{
 		logger:             logger,
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// PodDisruptionBudget builds the pod disruption budget for the given instance, or returns nil when the instance
+// doesn't set its availability.
+func PodDisruptionBudget(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *policyv1.PodDisruptionBudget {
+	if otelcol.Spec.Availability == nil {
+		return nil
+	}
+	if otelcol.Spec.Mode != v1alpha1.ModeDeployment && otelcol.Spec.Mode != v1alpha1.ModeStatefulSet {
+		return nil
+	}
+
+	name := naming.PodDisruptionBudget(otelcol)
+	minAvailable := intstr.FromInt(int(otelcol.Spec.Availability.MinAvailable))
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   otelcol.Namespace,
+			Labels:      Labels(otelcol, name, cfg.LabelsFilter()),
+			Annotations: Annotations(cfg, otelcol),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: SelectorLabels(otelcol),
+			},
+		},
+	}
+}
+
+// MinReplicas returns the number of replicas the collector of the given instance doesn't go below: the minimum
+// replicas of the autoscaler when autoscaling is enabled, the replicas otherwise.
+func MinReplicas(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.MaxReplicas != nil && otelcol.Spec.Autoscaler.MinReplicas != nil {
+		return *otelcol.Spec.Autoscaler.MinReplicas
+	}
+	if otelcol.Spec.Replicas != nil {
+		return *otelcol.Spec.Replicas
+	}
+	return 1
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:         v1alpha1.ModeDeployment,
+			Availability: &v1alpha1.AvailabilitySpec{MinAvailable: 2},
+		},
+	}
+
+	// test
+	pdb := PodDisruptionBudget(config.New(), otelcol)
+
+	// verify
+	require.NotNil(t, pdb)
+	assert.Equal(t, "my-instance-collector", pdb.Name)
+	assert.Equal(t, "my-namespace", pdb.Namespace)
+	assert.Equal(t, "my-instance-collector", pdb.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, 2, pdb.Spec.MinAvailable.IntValue())
+	assert.Equal(t, SelectorLabels(otelcol), pdb.Spec.Selector.MatchLabels)
+}
+
+func TestPodDisruptionBudgetWithoutAvailability(t *testing.T) {
+	for _, otelcol := range []v1alpha1.OpenTelemetryCollector{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-availability"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "daemonset"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:         v1alpha1.ModeDaemonSet,
+				Availability: &v1alpha1.AvailabilitySpec{MinAvailable: 1},
+			},
+		},
+	} {
+		t.Run(otelcol.Name, func(t *testing.T) {
+			assert.Nil(t, PodDisruptionBudget(config.New(), otelcol))
+		})
+	}
+}
+
+func TestMinReplicas(t *testing.T) {
+	two := int32(2)
+	five := int32(5)
+
+	assert.Equal(t, int32(1), MinReplicas(v1alpha1.OpenTelemetryCollector{}))
+	assert.Equal(t, int32(5), MinReplicas(v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{Replicas: &five},
+	}))
+	assert.Equal(t, int32(2), MinReplicas(v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Replicas:   &five,
+			Autoscaler: &v1alpha1.AutoscalerSpec{MinReplicas: &two, MaxReplicas: &five},
+		},
+	}))
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// SidecarInjectedLabel is set on the pods the collector is injected into as a sidecar, with the namespace and name of
+// the instance as its value.
+const SidecarInjectedLabel = "sidecar.opentelemetry.io/injected"
+
+// PodMonitorGVK is the kind of the PodMonitors, which are handled as unstructured objects as their API is only served
+// when the Prometheus operator is installed in the cluster.
+var PodMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// PodMonitor builds the pod monitor scraping the metrics port of the sidecars injected into pods, or returns nil when
+// the instance isn't a sidecar or pushes its metrics instead. The sidecars have no service of their own, so their pods
+// are selected by the label set on injection instead, and only their collector container is kept.
+func PodMonitor(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *unstructured.Unstructured {
+	if otelcol.Spec.Mode != v1alpha1.ModeSidecar || selfTelemetryPushesMetrics(otelcol) {
+		return nil
+	}
+
+	// the pods of the other namespaces may reference the instance when it sets its sidecar namespace selector
+	namespaceSelector := map[string]interface{}{
+		"matchNames": []interface{}{otelcol.Namespace},
+	}
+	if otelcol.Spec.SidecarNamespaceSelector != nil {
+		namespaceSelector = map[string]interface{}{
+			"any": true,
+		}
+	}
+
+	name := naming.PodMonitor(otelcol)
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(PodMonitorGVK)
+	podMonitor.SetName(name)
+	podMonitor.SetNamespace(otelcol.Namespace)
+	podMonitor.SetLabels(Labels(otelcol, name, cfg.LabelsFilter()))
+	podMonitor.SetAnnotations(Annotations(cfg, otelcol))
+	podMonitor.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				SidecarInjectedLabel: naming.Instance(otelcol),
+			},
+		},
+		"namespaceSelector": namespaceSelector,
+		"podMetricsEndpoints": []interface{}{
+			map[string]interface{}{
+				"port": "metrics",
+				// the containers of the workloads may name their own ports metrics as well
+				"relabelings": []interface{}{
+					map[string]interface{}{
+						"sourceLabels": []interface{}{"__meta_kubernetes_pod_container_name"},
+						"regex":        naming.Container(),
+						"action":       "keep",
+					},
+				},
+			},
+		},
+	}
+
+	return podMonitor
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPodMonitor(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+		},
+	}
+
+	// test
+	podMonitor := PodMonitor(config.New(), otelcol)
+
+	// verify
+	require.NotNil(t, podMonitor)
+	assert.Equal(t, PodMonitorGVK, podMonitor.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector", podMonitor.GetName())
+	assert.Equal(t, "my-namespace", podMonitor.GetNamespace())
+	assert.Equal(t, "my-instance-collector", podMonitor.GetLabels()["app.kubernetes.io/name"])
+
+	selector, _, err := unstructured.NestedStringMap(podMonitor.Object, "spec", "selector", "matchLabels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sidecar.opentelemetry.io/injected": "my-namespace.my-instance"}, selector)
+
+	namespaces, _, err := unstructured.NestedStringSlice(podMonitor.Object, "spec", "namespaceSelector", "matchNames")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my-namespace"}, namespaces)
+
+	endpoints, _, err := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"port": "metrics",
+		"relabelings": []interface{}{
+			map[string]interface{}{
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_container_name"},
+				"regex":        "otc-container",
+				"action":       "keep",
+			},
+		},
+	}}, endpoints)
+}
+
+func TestPodMonitorWithSidecarNamespaceSelector(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+			SidecarNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "a"},
+			},
+		},
+	}
+
+	// test
+	podMonitor := PodMonitor(config.New(), otelcol)
+
+	// verify
+	require.NotNil(t, podMonitor)
+	anyNamespace, _, err := unstructured.NestedBool(podMonitor.Object, "spec", "namespaceSelector", "any")
+	require.NoError(t, err)
+	assert.True(t, anyNamespace)
+}
+
+func TestPodMonitorNotSidecar(t *testing.T) {
+	for _, mode := range []v1alpha1.Mode{v1alpha1.ModeDeployment, v1alpha1.ModeDaemonSet, v1alpha1.ModeStatefulSet} {
+		t.Run(string(mode), func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: mode,
+				},
+			}
+
+			// test
+			podMonitor := PodMonitor(config.New(), otelcol)
+
+			// verify
+			assert.Nil(t, podMonitor)
+		})
+	}
+}
+
+func TestPodMonitorPushedMetrics(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+			SelfTelemetry: v1alpha1.SelfTelemetrySpec{
+				OTLP: &v1alpha1.SelfTelemetryOTLPSpec{
+					Endpoint: "http://gateway.observability:4318",
+				},
+			},
+		},
+	}
+
+	// test
+	podMonitor := PodMonitor(config.New(), otelcol)
+
+	// verify
+	assert.Nil(t, podMonitor)
+}
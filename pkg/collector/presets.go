@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// PresetConfig returns the configuration of the given instance, with the components and settings of the enabled
+// presets added to it. The last known good configuration is returned as is, as it was recorded once rendered.
+func PresetConfig(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) (string, error) {
+	if runsLastKnownGood(otelcol) {
+		return otelcol.Spec.Config, nil
+	}
+	config, err := ReceiverCreatorConfig(otelcol)
+	if err != nil {
+		return "", err
+	}
+	if config, err = selfTelemetryConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = remoteWriteConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = guardrailsConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = receiverTLSConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = exporterTLSConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = exporterTokenConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	if config, err = receiverEndpointsConfig(otelcol, config); err != nil {
+		return "", err
+	}
+	return fipsConfig(cfg, otelcol, config)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// featureGatesArg is the arg of the collector listing the feature gates to enable, or to disable when prefixed with -.
+const featureGatesArg = "feature-gates"
+
+// profilesArgs returns the args of the collector with the feature gate of the profiles signal enabled when its
+// configuration has profiles pipelines, which the collector rejects otherwise. The args are returned as they are when
+// they enable or disable the feature gate already.
+func profilesArgs(args map[string]string, cfg string) map[string]string {
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil || !adapters.ConfigHasProfiles(config) {
+		return args
+	}
+
+	gates := args[featureGatesArg]
+	for _, gate := range strings.Split(gates, ",") {
+		if strings.TrimLeft(strings.TrimSpace(gate), "+-") == adapters.ProfilesFeatureGate {
+			return args
+		}
+	}
+
+	// new map, so that we don't touch the instance's args
+	updated := make(map[string]string, len(args)+1)
+	for k, v := range args {
+		updated[k] = v
+	}
+	if gates == "" {
+		updated[featureGatesArg] = adapters.ProfilesFeatureGate
+	} else {
+		updated[featureGatesArg] = gates + "," + adapters.ProfilesFeatureGate
+	}
+	return updated
+}
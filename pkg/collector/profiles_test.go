@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const profilesConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: pyroscope:4317
+service:
+  pipelines:
+    profiles:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+
+func TestProfilesArgs(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		args     map[string]string
+		config   string
+		expected map[string]string
+	}{
+		{
+			desc:     "profiles pipeline",
+			config:   profilesConfig,
+			expected: map[string]string{"feature-gates": "service.profilesSupport"},
+		},
+		{
+			desc:     "profiles pipeline with other feature gates",
+			args:     map[string]string{"feature-gates": "+random-feature", "log-level": "debug"},
+			config:   profilesConfig,
+			expected: map[string]string{"feature-gates": "+random-feature,service.profilesSupport", "log-level": "debug"},
+		},
+		{
+			desc:     "feature gate disabled",
+			args:     map[string]string{"feature-gates": "-service.profilesSupport"},
+			config:   profilesConfig,
+			expected: map[string]string{"feature-gates": "-service.profilesSupport"},
+		},
+		{
+			desc:     "no profiles pipeline",
+			args:     map[string]string{"log-level": "debug"},
+			config:   "service:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [otlp]\n",
+			expected: map[string]string{"log-level": "debug"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, profilesArgs(tt.args, tt.config))
+		})
+	}
+}
+
+func TestContainerProfilesArgs(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: profilesConfig,
+			Args:   map[string]string{"log-level": "debug"},
+		},
+	}
+
+	c := Container(config.New(), logr.Discard(), otelcol, true)
+
+	assert.Equal(t, []string{"--config=/conf/collector.yaml", "--feature-gates=service.profilesSupport", "--log-level=debug"}, c.Args)
+	assert.Equal(t, map[string]string{"log-level": "debug"}, otelcol.Spec.Args)
+	// the profiles are received on the ports of the otlp receiver
+	ports := map[string]int32{}
+	for _, p := range c.Ports {
+		ports[p.Name] = p.ContainerPort
+	}
+	assert.Equal(t, int32(4317), ports["otlp-grpc"])
+}
@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// prometheusSpec holds the attributes of the spec of a Prometheus resource of the Prometheus Operator that the
+// conversion reads. The resource is read as an unstructured object, so that the operator doesn't depend on the API of
+// the Prometheus Operator.
+type prometheusSpec struct {
+	ServiceMonitorSelector          *metav1.LabelSelector     `json:"serviceMonitorSelector,omitempty"`
+	ServiceMonitorNamespaceSelector *metav1.LabelSelector     `json:"serviceMonitorNamespaceSelector,omitempty"`
+	PodMonitorSelector              *metav1.LabelSelector     `json:"podMonitorSelector,omitempty"`
+	PodMonitorNamespaceSelector     *metav1.LabelSelector     `json:"podMonitorNamespaceSelector,omitempty"`
+	ProbeSelector                   *metav1.LabelSelector     `json:"probeSelector,omitempty"`
+	RuleSelector                    *metav1.LabelSelector     `json:"ruleSelector,omitempty"`
+	AdditionalScrapeConfigs         *corev1.SecretKeySelector `json:"additionalScrapeConfigs,omitempty"`
+	ScrapeInterval                  string                    `json:"scrapeInterval,omitempty"`
+	ScrapeTimeout                   string                    `json:"scrapeTimeout,omitempty"`
+	ExternalLabels                  map[string]string         `json:"externalLabels,omitempty"`
+	RemoteWrite                     []prometheusRemoteWrite   `json:"remoteWrite,omitempty"`
+	Replicas                        *int32                    `json:"replicas,omitempty"`
+	Shards                          *int32                    `json:"shards,omitempty"`
+	ServiceAccountName              string                    `json:"serviceAccountName,omitempty"`
+	NodeSelector                    map[string]string         `json:"nodeSelector,omitempty"`
+	Tolerations                     []corev1.Toleration       `json:"tolerations,omitempty"`
+	Affinity                        *corev1.Affinity          `json:"affinity,omitempty"`
+}
+
+type prometheusRemoteWrite struct {
+	URL         string                 `json:"url"`
+	Name        string                 `json:"name,omitempty"`
+	Headers     map[string]string      `json:"headers,omitempty"`
+	BasicAuth   map[string]interface{} `json:"basicAuth,omitempty"`
+	BearerToken string                 `json:"bearerToken,omitempty"`
+	OAuth2      map[string]interface{} `json:"oauth2,omitempty"`
+	Sigv4       map[string]interface{} `json:"sigv4,omitempty"`
+	TLSConfig   map[string]interface{} `json:"tlsConfig,omitempty"`
+}
+
+// targetAllocatorRules are the permissions the target allocator needs to discover the targets of ServiceMonitors and
+// PodMonitors.
+var targetAllocatorRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"configmaps", "endpoints", "namespaces", "nodes", "nodes/metrics", "pods", "services"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"discovery.k8s.io"},
+		Resources: []string{"endpointslices"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"networking.k8s.io"},
+		Resources: []string{"ingresses"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"monitoring.coreos.com"},
+		Resources: []string{"podmonitors", "servicemonitors"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		NonResourceURLs: []string{"/metrics"},
+		Verbs:           []string{"get"},
+	},
+}
+
+// FromPrometheus generates an instance scraping the targets of the ServiceMonitors and PodMonitors selected by a
+// Prometheus resource of the Prometheus Operator, with a target allocator distributing them over the collectors, and
+// the remote write endpoints of the resource as exporters. The instance is named after the resource unless a name is
+// given. The hints list the settings of the resource that couldn't be converted.
+func FromPrometheus(prometheus unstructured.Unstructured, name string) (Generated, error) {
+	if prometheus.GetKind() != "Prometheus" {
+		return Generated{}, fmt.Errorf("unsupported resource kind %s", prometheus.GetKind())
+	}
+	if len(name) == 0 {
+		name = prometheus.GetName()
+	}
+	namespace := prometheus.GetNamespace()
+
+	var spec prometheusSpec
+	if rawSpec, ok := prometheus.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSpec, &spec); err != nil {
+			return Generated{}, fmt.Errorf("failed to read the spec of the Prometheus %s: %w", prometheus.GetName(), err)
+		}
+	}
+
+	generated := Generated{
+		Instance: v1alpha1.OpenTelemetryCollector{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "OpenTelemetryCollector",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:         v1alpha1.ModeStatefulSet,
+				NodeSelector: spec.NodeSelector,
+				Tolerations:  spec.Tolerations,
+				Affinity:     spec.Affinity,
+				TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+					Enabled:        true,
+					ServiceAccount: spec.ServiceAccountName,
+					PrometheusCR: v1alpha1.OpenTelemetryTargetAllocatorPrometheusCR{
+						Enabled: true,
+					},
+				},
+			},
+		},
+	}
+	otelcol := &generated.Instance
+	hint := func(format string, args ...interface{}) {
+		generated.Hints = append(generated.Hints, fmt.Sprintf(format, args...))
+	}
+
+	// the target allocator distributes the targets over the collectors like the shards of Prometheus do, while the
+	// replicas of Prometheus scrape the same targets
+	if spec.Shards != nil && *spec.Shards > 1 {
+		otelcol.Spec.Replicas = spec.Shards
+	}
+	if spec.Replicas != nil && *spec.Replicas > 1 {
+		hint("the %d replicas of Prometheus scrape the same targets, while the target allocator distributes the targets over the collectors, set replicas to scale them", *spec.Replicas)
+	}
+
+	otelcol.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector = prometheusSelector("serviceMonitorSelector", spec.ServiceMonitorSelector, hint)
+	otelcol.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector = prometheusSelector("podMonitorSelector", spec.PodMonitorSelector, hint)
+	// an empty namespace selector selects all the namespaces, like the target allocator does, while a missing one
+	// only selects the namespace of the Prometheus
+	if !emptySelector(spec.ServiceMonitorNamespaceSelector) || !emptySelector(spec.PodMonitorNamespaceSelector) {
+		hint("the target allocator selects the ServiceMonitors and PodMonitors in all the namespaces its service account can read, regardless of the namespace selectors of the Prometheus")
+	}
+	if spec.ProbeSelector != nil {
+		hint("the Probes of the probeSelector aren't supported by the target allocator")
+	}
+	if spec.RuleSelector != nil {
+		hint("the recording and alerting rules of the ruleSelector aren't evaluated by the collectors")
+	}
+	if spec.AdditionalScrapeConfigs != nil {
+		hint("the scrape configurations of the key %s of the secret %s aren't converted, add them to the scrape_configs of the prometheus receiver", spec.AdditionalScrapeConfigs.Key, spec.AdditionalScrapeConfigs.Name)
+	}
+
+	config, err := prometheusCollectorConfig(*otelcol, spec, hint)
+	if err != nil {
+		return Generated{}, err
+	}
+	otelcol.Spec.Config = config
+
+	// a service account set in the Prometheus resource already has the permissions to discover the targets
+	if len(spec.ServiceAccountName) == 0 {
+		roleName := naming.ClusterObject(namespace, name, "targetallocator")
+		generated.ClusterRole = &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRole",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: roleName},
+			Rules:      targetAllocatorRules,
+		}
+		generated.ClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: roleName},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      naming.ServiceAccount(*otelcol),
+				Namespace: namespace,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleName,
+			},
+		}
+	}
+
+	return generated, nil
+}
+
+// prometheusSelector returns the labels of a selector of a Prometheus resource. The target allocator selects all the
+// resources when the labels are empty, and only matches labels.
+func prometheusSelector(attribute string, selector *metav1.LabelSelector, hint func(string, ...interface{})) map[string]string {
+	if selector == nil {
+		hint("the %s of the Prometheus isn't set, which selects no resources, while the target allocator selects all of them", attribute)
+		return nil
+	}
+	if len(selector.MatchExpressions) > 0 {
+		hint("the match expressions of the %s aren't supported by the target allocator, only its match labels are kept", attribute)
+	}
+	return selector.MatchLabels
+}
+
+func emptySelector(selector *metav1.LabelSelector) bool {
+	return selector != nil && len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
+
+// prometheusCollectorConfig returns the configuration of the collectors of the instance, scraping the targets of its
+// target allocator and sending them to the remote write endpoints of the Prometheus resource.
+func prometheusCollectorConfig(otelcol v1alpha1.OpenTelemetryCollector, spec prometheusSpec, hint func(string, ...interface{})) (string, error) {
+	global := yaml.MapSlice{}
+	if len(spec.ScrapeInterval) > 0 {
+		global = append(global, yaml.MapItem{Key: "scrape_interval", Value: spec.ScrapeInterval})
+	}
+	if len(spec.ScrapeTimeout) > 0 {
+		global = append(global, yaml.MapItem{Key: "scrape_timeout", Value: spec.ScrapeTimeout})
+	}
+	if len(spec.ExternalLabels) > 0 {
+		global = append(global, yaml.MapItem{Key: "external_labels", Value: spec.ExternalLabels})
+	}
+
+	exporters := yaml.MapSlice{}
+	var exporterNames []string
+	for i, remoteWrite := range spec.RemoteWrite {
+		exporterName := "prometheusremotewrite"
+		if len(remoteWrite.Name) > 0 {
+			exporterName = fmt.Sprintf("prometheusremotewrite/%s", remoteWrite.Name)
+		} else if i > 0 {
+			exporterName = fmt.Sprintf("prometheusremotewrite/%d", i)
+		}
+		exporter := yaml.MapSlice{{Key: "endpoint", Value: remoteWrite.URL}}
+		if len(remoteWrite.Headers) > 0 {
+			exporter = append(exporter, yaml.MapItem{Key: "headers", Value: remoteWrite.Headers})
+		}
+		exporters = append(exporters, yaml.MapItem{Key: exporterName, Value: exporter})
+		exporterNames = append(exporterNames, exporterName)
+
+		unconverted := map[string]bool{
+			"basicAuth":   remoteWrite.BasicAuth != nil,
+			"bearerToken": len(remoteWrite.BearerToken) > 0,
+			"oauth2":      remoteWrite.OAuth2 != nil,
+			"sigv4":       remoteWrite.Sigv4 != nil,
+			"tlsConfig":   remoteWrite.TLSConfig != nil,
+		}
+		var auth []string
+		for _, attribute := range sortedKeys(unconverted) {
+			if unconverted[attribute] {
+				auth = append(auth, attribute)
+			}
+		}
+		if len(auth) > 0 {
+			hint("the %s settings of the remote write endpoint %s aren't converted, configure the authentication and TLS of the %s exporter", strings.Join(auth, ", "), remoteWrite.URL, exporterName)
+		}
+	}
+	if len(exporterNames) == 0 {
+		exporters = append(exporters, yaml.MapItem{Key: "logging", Value: yaml.MapSlice{}})
+		exporterNames = append(exporterNames, "logging")
+		hint("the Prometheus has no remote write endpoint, replace the logging exporter with the exporter of the metrics backend")
+	}
+
+	prometheusConfig := yaml.MapSlice{}
+	if len(global) > 0 {
+		prometheusConfig = append(prometheusConfig, yaml.MapItem{Key: "global", Value: global})
+	}
+	prometheusConfig = append(prometheusConfig, yaml.MapItem{Key: "scrape_configs", Value: []interface{}{}})
+
+	config := yaml.MapSlice{
+		{Key: "receivers", Value: yaml.MapSlice{
+			{Key: "prometheus", Value: yaml.MapSlice{
+				{Key: "config", Value: prometheusConfig},
+				{Key: "target_allocator", Value: yaml.MapSlice{
+					{Key: "endpoint", Value: fmt.Sprintf("http://%s:80", naming.TAService(otelcol))},
+					{Key: "interval", Value: "30s"},
+					{Key: "collector_id", Value: "${POD_NAME}"},
+				}},
+			}},
+		}},
+		{Key: "processors", Value: yaml.MapSlice{
+			{Key: "batch", Value: yaml.MapSlice{}},
+		}},
+		{Key: "exporters", Value: exporters},
+		{Key: "service", Value: yaml.MapSlice{
+			{Key: "pipelines", Value: yaml.MapSlice{
+				{Key: "metrics", Value: yaml.MapSlice{
+					{Key: "receivers", Value: []string{"prometheus"}},
+					{Key: "processors", Value: []string{"batch"}},
+					{Key: "exporters", Value: exporterNames},
+				}},
+			}},
+		}},
+	}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the configuration: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func prometheusResource(spec map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "Prometheus",
+		"metadata": map[string]interface{}{
+			"name":      "k8s",
+			"namespace": "monitoring",
+		},
+		"spec": spec,
+	}}
+}
+
+func TestFromPrometheus(t *testing.T) {
+	prometheus := prometheusResource(map[string]interface{}{
+		"shards":         int64(3),
+		"scrapeInterval": "30s",
+		"externalLabels": map[string]interface{}{"cluster": "prod"},
+		"serviceMonitorSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"team": "frontend"},
+		},
+		"serviceMonitorNamespaceSelector": map[string]interface{}{},
+		"podMonitorSelector":              map[string]interface{}{},
+		"podMonitorNamespaceSelector":     map[string]interface{}{},
+		"nodeSelector":                    map[string]interface{}{"pool": "monitoring"},
+		"remoteWrite": []interface{}{
+			map[string]interface{}{"url": "https://mimir.example.com/api/v1/push"},
+			map[string]interface{}{"url": "https://backup.example.com/api/v1/push"},
+		},
+	})
+
+	generated, err := FromPrometheus(prometheus, "")
+	require.NoError(t, err)
+	assert.Empty(t, generated.Hints)
+
+	otelcol := generated.Instance
+	assert.Equal(t, "k8s", otelcol.Name)
+	assert.Equal(t, "monitoring", otelcol.Namespace)
+	assert.Equal(t, v1alpha1.ModeStatefulSet, otelcol.Spec.Mode)
+	assert.Equal(t, int32(3), *otelcol.Spec.Replicas)
+	assert.Equal(t, map[string]string{"pool": "monitoring"}, otelcol.Spec.NodeSelector)
+	assert.True(t, otelcol.Spec.TargetAllocator.Enabled)
+	assert.True(t, otelcol.Spec.TargetAllocator.PrometheusCR.Enabled)
+	assert.Equal(t, map[string]string{"team": "frontend"}, otelcol.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector)
+	assert.Empty(t, otelcol.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector)
+
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	require.NoError(t, err)
+	receiver := cfg["receivers"].(map[string]interface{})["prometheus"].(map[string]interface{})
+	global := receiver["config"].(map[string]interface{})["global"].(map[string]interface{})
+	assert.Equal(t, "30s", global["scrape_interval"])
+	assert.Equal(t, map[string]interface{}{"cluster": "prod"}, global["external_labels"])
+	assert.Equal(t, "http://k8s-targetallocator:80", receiver["target_allocator"].(map[string]interface{})["endpoint"])
+	exporters := cfg["exporters"].(map[string]interface{})
+	assert.Contains(t, exporters, "prometheusremotewrite")
+	assert.Contains(t, exporters, "prometheusremotewrite/1")
+
+	require.NotNil(t, generated.ClusterRole)
+	assert.Equal(t, "k8s-monitoring-targetallocator-b04f6710", generated.ClusterRole.Name)
+	assert.Equal(t, "k8s-collector", generated.ClusterRoleBinding.Subjects[0].Name)
+}
+
+func TestFromPrometheusHints(t *testing.T) {
+	prometheus := prometheusResource(map[string]interface{}{
+		"replicas":           int64(2),
+		"serviceAccountName": "prometheus-k8s",
+		"podMonitorSelector": map[string]interface{}{
+			"matchExpressions": []interface{}{
+				map[string]interface{}{"key": "team", "operator": "Exists"},
+			},
+		},
+		"ruleSelector": map[string]interface{}{},
+		"additionalScrapeConfigs": map[string]interface{}{
+			"name": "additional-scrape-configs",
+			"key":  "prometheus-additional.yaml",
+		},
+		"remoteWrite": []interface{}{
+			map[string]interface{}{"url": "https://mimir.example.com/api/v1/push", "name": "mimir", "bearerToken": "secret"},
+		},
+	})
+
+	generated, err := FromPrometheus(prometheus, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, "metrics", generated.Instance.Name)
+	assert.Nil(t, generated.Instance.Spec.Replicas)
+	assert.Equal(t, "prometheus-k8s", generated.Instance.Spec.TargetAllocator.ServiceAccount)
+	assert.Nil(t, generated.ClusterRole)
+	assert.Contains(t, generated.Instance.Spec.Config, "prometheusremotewrite/mimir:")
+
+	assert.Equal(t, []string{
+		"the 2 replicas of Prometheus scrape the same targets, while the target allocator distributes the targets over the collectors, set replicas to scale them",
+		"the serviceMonitorSelector of the Prometheus isn't set, which selects no resources, while the target allocator selects all of them",
+		"the match expressions of the podMonitorSelector aren't supported by the target allocator, only its match labels are kept",
+		"the target allocator selects the ServiceMonitors and PodMonitors in all the namespaces its service account can read, regardless of the namespace selectors of the Prometheus",
+		"the recording and alerting rules of the ruleSelector aren't evaluated by the collectors",
+		"the scrape configurations of the key prometheus-additional.yaml of the secret additional-scrape-configs aren't converted, add them to the scrape_configs of the prometheus receiver",
+		"the bearerToken settings of the remote write endpoint https://mimir.example.com/api/v1/push aren't converted, configure the authentication and TLS of the prometheusremotewrite/mimir exporter",
+	}, generated.Hints)
+}
+
+func TestFromPrometheusUnsupportedKind(t *testing.T) {
+	resource := prometheusResource(nil)
+	resource.SetKind("Alertmanager")
+	_, err := FromPrometheus(resource, "")
+	assert.ErrorContains(t, err, "unsupported resource kind Alertmanager")
+}
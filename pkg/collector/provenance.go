@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	// OperatorVersionAnnotation is the annotation of the generated workloads with the version of the operator that
+	// generated them. It isn't set on their pod templates, so that upgrading the operator doesn't restart the pods.
+	OperatorVersionAnnotation = "opentelemetry.io/operator-version"
+
+	// ImagesAnnotation is the annotation of the generated pod templates with the images of their containers, as
+	// comma-separated <container>=<image> pairs.
+	ImagesAnnotation = "opentelemetry.io/images"
+)
+
+// WithProvenance records the version of the operator in the annotations of the given workload, and the images of the
+// containers of its pod template in the annotations of the template, so that the workloads can be audited without the
+// operator.
+func WithProvenance(cfg config.Config, workload *metav1.ObjectMeta, template *corev1.PodTemplateSpec) {
+	if version := cfg.OperatorVersion(); len(version) > 0 {
+		annotations := map[string]string{}
+		for k, v := range workload.Annotations {
+			annotations[k] = v
+		}
+		annotations[OperatorVersionAnnotation] = version
+		workload.Annotations = annotations
+	}
+
+	var images []string
+	for _, container := range append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...) {
+		images = append(images, fmt.Sprintf("%s=%s", container.Name, container.Image))
+	}
+	if len(images) > 0 {
+		annotations := map[string]string{}
+		for k, v := range template.Annotations {
+			annotations[k] = v
+		}
+		annotations[ImagesAnnotation] = strings.Join(images, ",")
+		template.Annotations = annotations
+	}
+}
+
+// Images returns the images run by the containers of the given pods, along with the digests they resolved to, ordered
+// by component, container and image. The images whose digest isn't known yet, e.g. while they're pulled, have none.
+func Images(pods []corev1.Pod) []v1alpha1.ImageStatus {
+	type key struct {
+		component, container, image string
+	}
+	digests := map[key]map[string]bool{}
+
+	for _, pod := range pods {
+		component := pod.Labels["app.kubernetes.io/component"]
+		images := map[string]string{}
+		for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			images[container.Name] = container.Image
+		}
+		for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			image, ok := images[status.Name]
+			if !ok {
+				continue
+			}
+			k := key{component: component, container: status.Name, image: image}
+			if digests[k] == nil {
+				digests[k] = map[string]bool{}
+			}
+			if digest := imageDigest(status.ImageID); len(digest) > 0 {
+				digests[k][digest] = true
+			}
+		}
+	}
+
+	statuses := make([]v1alpha1.ImageStatus, 0, len(digests))
+	for k, set := range digests {
+		status := v1alpha1.ImageStatus{Component: k.component, Container: k.container, Image: k.image}
+		for digest := range set {
+			status.Digests = append(status.Digests, digest)
+		}
+		sort.Strings(status.Digests)
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		a, b := statuses[i], statuses[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		return a.Image < b.Image
+	})
+	return statuses
+}
+
+// imageDigest returns the digest of the image ID of a container status, e.g. sha256:1a2b out of
+// docker-pullable://ghcr.io/open-telemetry/collector@sha256:1a2b. The IDs of the images without a registry digest,
+// e.g. the ones built on the node, have none.
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return ""
+}
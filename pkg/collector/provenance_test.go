@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestWithProvenance(t *testing.T) {
+	cfg := config.New(config.WithVersion(version.Version{Operator: "0.82.0"}))
+	workload := metav1.ObjectMeta{
+		Annotations: map[string]string{"opentelemetry-operator-config/sha256": "1a2b"},
+	}
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"prometheus.io/scrape": "true"},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox:stable"}},
+			Containers:     []corev1.Container{{Name: "otc-container", Image: "ghcr.io/open-telemetry/opentelemetry-collector:0.82.0"}},
+		},
+	}
+
+	WithProvenance(cfg, &workload, &template)
+
+	assert.Equal(t, map[string]string{
+		"opentelemetry-operator-config/sha256": "1a2b",
+		OperatorVersionAnnotation:              "0.82.0",
+	}, workload.Annotations)
+	// the version of the operator isn't on the pod template, so that upgrading the operator doesn't restart the pods
+	assert.Equal(t, map[string]string{
+		"prometheus.io/scrape": "true",
+		ImagesAnnotation:       "init=busybox:stable,otc-container=ghcr.io/open-telemetry/opentelemetry-collector:0.82.0",
+	}, template.Annotations)
+}
+
+func TestWithProvenanceUnknownVersion(t *testing.T) {
+	cfg := config.New(config.WithVersion(version.Version{}))
+	workload := metav1.ObjectMeta{}
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ta-container", Image: "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.82.0"}},
+		},
+	}
+
+	WithProvenance(cfg, &workload, &template)
+
+	assert.Empty(t, workload.Annotations)
+	assert.Equal(t, map[string]string{
+		ImagesAnnotation: "ta-container=ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.82.0",
+	}, template.Annotations)
+}
+
+func TestImages(t *testing.T) {
+	collectorPod := func(imageID string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/component": "opentelemetry-collector"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "otc-container", Image: "otel/opentelemetry-collector:latest"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "otc-container", Image: "docker.io/otel/opentelemetry-collector:latest", ImageID: imageID}},
+			},
+		}
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/component": "opentelemetry-targetallocator"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ta-container", Image: "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.82.0"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "ta-container", ImageID: "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator@sha256:3c4d"}},
+			},
+		},
+		collectorPod("docker-pullable://otel/opentelemetry-collector@sha256:2b3c"),
+		collectorPod("docker-pullable://otel/opentelemetry-collector@sha256:1a2b"),
+		collectorPod("docker-pullable://otel/opentelemetry-collector@sha256:1a2b"),
+		// the image is being pulled
+		collectorPod(""),
+	}
+
+	assert.Equal(t, []v1alpha1.ImageStatus{
+		{
+			Component: "opentelemetry-collector",
+			Container: "otc-container",
+			Image:     "otel/opentelemetry-collector:latest",
+			Digests:   []string{"sha256:1a2b", "sha256:2b3c"},
+		},
+		{
+			Component: "opentelemetry-targetallocator",
+			Container: "ta-container",
+			Image:     "ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:0.82.0",
+			Digests:   []string{"sha256:3c4d"},
+		},
+	}, Images(pods))
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	k8sObserverExtension    = "k8s_observer"
+	receiverCreatorReceiver = "receiver_creator"
+)
+
+// ReceiverCreatorConfig returns the configuration of the given instance, with the k8s_observer extension and the
+// receiver_creator receiver added to it when the receiver creator preset is enabled.
+func ReceiverCreatorConfig(otelcol v1alpha1.OpenTelemetryCollector) (string, error) {
+	spec := otelcol.Spec.ReceiverCreator
+	if !spec.Enabled {
+		return otelcol.Spec.Config, nil
+	}
+
+	config, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return "", err
+	}
+
+	observer := map[string]interface{}{
+		"auth_type":     "serviceAccount",
+		"observe_pods":  true,
+		"observe_nodes": spec.ObserveNodes,
+	}
+	if otelcol.Spec.Mode == v1alpha1.ModeDaemonSet {
+		// each collector of the daemonset only starts receivers for its own node
+		observer["node"] = "${K8S_NODE_NAME}"
+	}
+	if err := addComponent(config, "extensions", k8sObserverExtension, observer); err != nil {
+		return "", err
+	}
+
+	receivers := map[string]interface{}{}
+	for name, template := range spec.Receivers {
+		receiver := map[string]interface{}{
+			"rule": template.Rule,
+		}
+		if len(template.Config) > 0 {
+			receiverConfig, err := adapters.ConfigFromString(template.Config)
+			if err != nil {
+				return "", fmt.Errorf("couldn't parse the config of the %s receiver creator template: %w", name, err)
+			}
+			receiver["config"] = receiverConfig
+		}
+		receivers[name] = receiver
+	}
+	receiverCreator := map[string]interface{}{
+		"watch_observers": []interface{}{k8sObserverExtension},
+		"receivers":       receivers,
+	}
+	if err := addComponent(config, "receivers", receiverCreatorReceiver, receiverCreator); err != nil {
+		return "", err
+	}
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+	service["extensions"] = appendComponentName(service["extensions"], k8sObserverExtension)
+	pipelines, err := configSection(service, "pipelines")
+	if err != nil {
+		return "", err
+	}
+	for _, name := range spec.Pipelines {
+		pipeline, ok := pipelines[name].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("the %s pipeline of the receiver creator doesn't exist", name)
+		}
+		pipeline["receivers"] = appendComponentName(pipeline["receivers"], receiverCreatorReceiver)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// configSection returns the given section of the configuration, creating it when missing.
+func configSection(config map[string]interface{}, name string) (map[string]interface{}, error) {
+	if config[name] == nil {
+		config[name] = map[string]interface{}{}
+	}
+	section, ok := config[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the %s section of the configuration isn't a map", name)
+	}
+	return section, nil
+}
+
+// addComponent adds the given component to a section of the configuration, unless it's already configured.
+func addComponent(config map[string]interface{}, sectionName, name string, component interface{}) error {
+	section, err := configSection(config, sectionName)
+	if err != nil {
+		return err
+	}
+	if _, ok := section[name]; ok {
+		return fmt.Errorf("the %s component is already configured, it can't be used along with the receiver creator", name)
+	}
+	section[name] = component
+	return nil
+}
+
+// appendComponentName appends the given name to a list of components, unless it's already there.
+func appendComponentName(list interface{}, name string) []interface{} {
+	names, _ := list.([]interface{})
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
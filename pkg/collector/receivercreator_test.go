@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+const receiverCreatorTestConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`
+
+func TestReceiverCreatorConfigDisabled(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: receiverCreatorTestConfig,
+		},
+	}
+
+	// test
+	config, err := ReceiverCreatorConfig(otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, receiverCreatorTestConfig, config)
+}
+
+func TestReceiverCreatorConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDaemonSet,
+			Config: receiverCreatorTestConfig,
+			ReceiverCreator: v1alpha1.ReceiverCreatorSpec{
+				Enabled:   true,
+				Pipelines: []string{"metrics"},
+				Receivers: map[string]v1alpha1.ReceiverCreatorTemplate{
+					"redis": {
+						Rule:   `type == "pod" && labels["app"] == "redis"`,
+						Config: "endpoint: '`endpoint`:6379'",
+					},
+				},
+			},
+		},
+	}
+	expected := `receivers:
+  otlp:
+    protocols:
+      grpc:
+  receiver_creator:
+    watch_observers: [k8s_observer]
+    receivers:
+      redis:
+        rule: type == "pod" && labels["app"] == "redis"
+        config:
+          endpoint: '` + "`endpoint`" + `:6379'
+extensions:
+  k8s_observer:
+    auth_type: serviceAccount
+    observe_pods: true
+    observe_nodes: false
+    node: ${K8S_NODE_NAME}
+exporters:
+  logging:
+service:
+  extensions: [k8s_observer]
+  pipelines:
+    metrics:
+      receivers: [otlp, receiver_creator]
+      exporters: [logging]
+`
+
+	// test
+	config, err := ReceiverCreatorConfig(otelcol)
+
+	// verify
+	require.NoError(t, err)
+	var actualConfig, expectedConfig map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &actualConfig))
+	require.NoError(t, yaml.Unmarshal([]byte(expected), &expectedConfig))
+	assert.Equal(t, expectedConfig, actualConfig)
+}
+
+func TestReceiverCreatorConfigErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      string
+		pipelines   []string
+		expectedErr string
+	}{
+		{
+			name:        "unknown pipeline",
+			config:      receiverCreatorTestConfig,
+			pipelines:   []string{"logs"},
+			expectedErr: "the logs pipeline of the receiver creator doesn't exist",
+		},
+		{
+			name: "observer already configured",
+			config: `extensions:
+  k8s_observer:
+`,
+			expectedErr: "the k8s_observer component is already configured",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Config: tt.config,
+					ReceiverCreator: v1alpha1.ReceiverCreatorSpec{
+						Enabled:   true,
+						Pipelines: tt.pipelines,
+					},
+				},
+			}
+
+			// test
+			_, err := ReceiverCreatorConfig(otelcol)
+
+			// verify
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const podIPEnvVar = "POD_IP"
+
+// receiverEndpointsConfig rewrites the host of the receivers listening on the loopback interface of the given
+// configuration following the receiver endpoints policy of the given instance. The configuration is returned as
+// is when no endpoint is rewritten.
+func receiverEndpointsConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	var host string
+	switch otelcol.Spec.ReceiverEndpoints.Effective() {
+	case v1alpha1.ReceiverEndpointsAllInterfaces:
+		host = "0.0.0.0"
+	case v1alpha1.ReceiverEndpointsPodIP:
+		host = "${" + podIPEnvVar + "}"
+	default:
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+	endpoints := adapters.ConfigToLoopbackEndpoints(config)
+	if len(endpoints) == 0 {
+		return cfg, nil
+	}
+	for _, settings := range endpoints {
+		_, port, _ := net.SplitHostPort(settings["endpoint"].(string))
+		settings["endpoint"] = net.JoinHostPort(host, port)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// receiverEndpointsEnvVars returns the variable holding the IP of the pod when the receivers of the given instance
+// listen on it, unless it's already defined.
+func receiverEndpointsEnvVars(otelcol v1alpha1.OpenTelemetryCollector, existing []corev1.EnvVar) []corev1.EnvVar {
+	if otelcol.Spec.ReceiverEndpoints.Effective() != v1alpha1.ReceiverEndpointsPodIP {
+		return nil
+	}
+	for _, envVar := range existing {
+		if envVar.Name == podIPEnvVar {
+			return nil
+		}
+	}
+	return []corev1.EnvVar{fieldRefEnvVar(podIPEnvVar, "status.podIP")}
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const receiverEndpointsConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+      http:
+        endpoint: 10.0.0.1:4318
+  zipkin:
+    endpoint: "[::1]:9411"
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      exporters: [debug]
+`
+
+func TestReceiverEndpointsConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		mode     v1alpha1.Mode
+		policy   v1alpha1.ReceiverEndpointsPolicy
+		expected string
+	}{
+		{
+			desc:     "deployment",
+			mode:     v1alpha1.ModeDeployment,
+			policy:   v1alpha1.ReceiverEndpointsAllInterfaces,
+			expected: "0.0.0.0",
+		},
+		{
+			desc:     "daemonset listening on the pod IP",
+			mode:     v1alpha1.ModeDaemonSet,
+			policy:   v1alpha1.ReceiverEndpointsPodIP,
+			expected: "${POD_IP}",
+		},
+		{
+			desc:     "sidecar listening on all the interfaces",
+			mode:     v1alpha1.ModeSidecar,
+			policy:   v1alpha1.ReceiverEndpointsAllInterfaces,
+			expected: "0.0.0.0",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:              tt.mode,
+					ReceiverEndpoints: tt.policy,
+					Config:            receiverEndpointsConfig,
+				},
+			}
+
+			presetConfig, err := PresetConfig(config.New(), otelcol)
+			require.NoError(t, err)
+			cfg, err := adapters.ConfigFromString(presetConfig)
+			require.NoError(t, err)
+
+			receivers := cfg["receivers"].(map[string]interface{})
+			protocols := receivers["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})
+			assert.Equal(t, tt.expected+":4317", protocols["grpc"].(map[string]interface{})["endpoint"])
+			assert.Equal(t, "10.0.0.1:4318", protocols["http"].(map[string]interface{})["endpoint"])
+			assert.Equal(t, tt.expected+":9411", receivers["zipkin"].(map[string]interface{})["endpoint"])
+		})
+	}
+}
+
+func TestReceiverEndpointsConfigKept(t *testing.T) {
+	for _, otelcol := range []v1alpha1.OpenTelemetryCollector{
+		{Spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeSidecar, Config: receiverEndpointsConfig}},
+		{Spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment, ReceiverEndpoints: v1alpha1.ReceiverEndpointsKeep, Config: receiverEndpointsConfig}},
+		// the instances created before the policy existed keep their endpoints
+		{Spec: v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment, Config: receiverEndpointsConfig}},
+	} {
+		presetConfig, err := PresetConfig(config.New(), otelcol)
+		require.NoError(t, err)
+		assert.Equal(t, receiverEndpointsConfig, presetConfig)
+	}
+}
+
+func TestReceiverEndpointsEnvVars(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:              v1alpha1.ModeDeployment,
+			ReceiverEndpoints: v1alpha1.ReceiverEndpointsPodIP,
+			Config:            receiverEndpointsConfig,
+		},
+	}
+
+	container := Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: "POD_IP",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+		},
+	})
+
+	otelcol.Spec.Env = []corev1.EnvVar{{Name: "POD_IP", Value: "10.0.0.1"}}
+	container = Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Equal(t, []corev1.EnvVar{{Name: "POD_IP", Value: "10.0.0.1"}}, container.Env[:1])
+	for _, envVar := range container.Env[1:] {
+		assert.NotEqual(t, "POD_IP", envVar.Name)
+	}
+
+	otelcol.Spec.Env = nil
+	otelcol.Spec.ReceiverEndpoints = ""
+	container = Container(config.New(), logr.Discard(), otelcol, true)
+	for _, envVar := range container.Env {
+		assert.NotEqual(t, "POD_IP", envVar.Name)
+	}
+}
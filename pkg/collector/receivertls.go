@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ConditionTypeReceiverTLSReady is the type of the status condition reporting whether the secrets of the receiver
+// certificates hold the keys to mount.
+const ConditionTypeReceiverTLSReady = "ReceiverTLSReady"
+
+// Reasons of the ReceiverTLSReady condition.
+const (
+	// ReasonReceiverTLSSecretsValid is the reason when all the secrets hold the keys to mount.
+	ReasonReceiverTLSSecretsValid = "SecretsValid"
+	// ReasonReceiverTLSSecretsInvalid is the reason when a secret is missing or misses one of the keys.
+	ReasonReceiverTLSSecretsInvalid = "SecretsInvalid"
+)
+
+// receiverTLSProtocols are the protocols of the receivers that can be served over TLS.
+var receiverTLSProtocols = []string{"grpc", "http", "thrift_http"}
+
+// receiverTLSVolumes returns the volumes mounting the certificates of the receivers of the given instance. Only the
+// keys of the certificates are mounted, so that the kubelet doesn't start pods whose secrets miss one of them.
+func receiverTLSVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumes []corev1.Volume
+	for _, receiver := range sortedNames(otelcol.Spec.ReceiverTLS) {
+		tls := otelcol.Spec.ReceiverTLS[receiver]
+		items := []corev1.KeyToPath{
+			{Key: defaultString(tls.CertKey, tlsCertFile), Path: tlsCertFile},
+			{Key: defaultString(tls.KeyKey, tlsKeyFile), Path: tlsKeyFile},
+		}
+		if len(tls.ClientCAKey) > 0 {
+			items = append(items, corev1.KeyToPath{Key: tls.ClientCAKey, Path: tlsCAFile})
+		}
+		volumes = append(volumes, tlsVolume(naming.ReceiverTLSVolume(receiver), tls.SecretName, items))
+	}
+	return volumes
+}
+
+// receiverTLSVolumeMounts returns the mounts of the certificate volumes of the given instance.
+func receiverTLSVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, receiver := range sortedNames(otelcol.Spec.ReceiverTLS) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.ReceiverTLSVolume(receiver),
+			MountPath: receiverTLSDir(receiver),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+// receiverTLSConfig sets the paths of the mounted certificates in the tls settings of the receivers of the given
+// configuration, or of their protocols that can be served over TLS. The other tls settings are kept.
+func receiverTLSConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	if len(otelcol.Spec.ReceiverTLS) == 0 || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+	receivers, err := configSection(config, "receivers")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range sortedNames(otelcol.Spec.ReceiverTLS) {
+		if _, ok := receivers[name]; !ok {
+			return "", fmt.Errorf("the receiver %s of receiverTLS isn't configured", name)
+		}
+		receiver, err := configSection(receivers, name)
+		if err != nil {
+			return "", err
+		}
+
+		servers := []map[string]interface{}{receiver}
+		if protocols, ok := receiver["protocols"].(map[string]interface{}); ok {
+			servers = nil
+			for _, protocol := range receiverTLSProtocols {
+				if _, ok := protocols[protocol]; !ok {
+					continue
+				}
+				server, err := configSection(protocols, protocol)
+				if err != nil {
+					return "", err
+				}
+				servers = append(servers, server)
+			}
+			if len(servers) == 0 {
+				return "", fmt.Errorf("the receiver %s of receiverTLS has no protocol served over TLS", name)
+			}
+		}
+
+		dir := receiverTLSDir(name)
+		for _, server := range servers {
+			tls, err := configSection(server, "tls")
+			if err != nil {
+				return "", err
+			}
+			tls["cert_file"] = path.Join(dir, tlsCertFile)
+			tls["key_file"] = path.Join(dir, tlsKeyFile)
+			if len(otelcol.Spec.ReceiverTLS[name].ClientCAKey) > 0 {
+				tls["client_ca_file"] = path.Join(dir, tlsCAFile)
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// receiverTLSDir returns the directory the certificate of the given receiver is mounted in.
+func receiverTLSDir(receiver string) string {
+	return path.Join(tlsPath, "receivers", naming.ReceiverTLSDir(receiver))
+}
+
+// ReceiverTLSCondition returns the ReceiverTLSReady condition of the given instance out of the given secrets of its
+// namespace, by name, reporting the secrets of the receivers that are missing or miss one of the keys to mount. It's
+// nil for the instances without receiver certificates.
+func ReceiverTLSCondition(otelcol v1alpha1.OpenTelemetryCollector, secrets map[string]corev1.Secret) *metav1.Condition {
+	if len(otelcol.Spec.ReceiverTLS) == 0 || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+
+	var missing []string
+	for _, receiver := range sortedNames(otelcol.Spec.ReceiverTLS) {
+		tls := otelcol.Spec.ReceiverTLS[receiver]
+		secret, ok := secrets[tls.SecretName]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("the secret %s of the receiver %s doesn't exist", tls.SecretName, receiver))
+			continue
+		}
+		keys := []string{defaultString(tls.CertKey, tlsCertFile), defaultString(tls.KeyKey, tlsKeyFile)}
+		if len(tls.ClientCAKey) > 0 {
+			keys = append(keys, tls.ClientCAKey)
+		}
+		for _, key := range keys {
+			if _, ok := secret.Data[key]; !ok {
+				missing = append(missing, fmt.Sprintf("the secret %s of the receiver %s has no %s key", tls.SecretName, receiver, key))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return &metav1.Condition{
+			Type:               ConditionTypeReceiverTLSReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: otelcol.Generation,
+			Reason:             ReasonReceiverTLSSecretsInvalid,
+			Message:            strings.Join(missing, ", ") + ", the collector pods can't start until it's fixed",
+		}
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeReceiverTLSReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             ReasonReceiverTLSSecretsValid,
+		Message:            "the secrets of the receivers hold the keys to mount",
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func receiverTLSInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			ReceiverTLS: map[string]v1alpha1.ReceiverTLSSpec{
+				"otlp":   {SecretName: "otlp-cert"},
+				"zipkin": {SecretName: "zipkin-cert", CertKey: "cert.pem", KeyKey: "key.pem", ClientCAKey: "ca.crt"},
+			},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+        tls:
+          min_version: "1.3"
+  zipkin:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      exporters: [debug]
+`,
+		},
+	}
+}
+
+func TestReceiverTLSVolumes(t *testing.T) {
+	otelcol := receiverTLSInstance()
+
+	volumes := Volumes(config.New(), otelcol)
+	require.Len(t, volumes, 3)
+	assert.Equal(t, corev1.Volume{
+		Name: "receiver-tls-otlp-0afb97b2",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "otlp-cert",
+				Items: []corev1.KeyToPath{
+					{Key: "tls.crt", Path: "tls.crt"},
+					{Key: "tls.key", Path: "tls.key"},
+				},
+			},
+		},
+	}, volumes[1])
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: "cert.pem", Path: "tls.crt"},
+		{Key: "key.pem", Path: "tls.key"},
+		{Key: "ca.crt", Path: "ca.crt"},
+	}, volumes[2].Secret.Items)
+
+	container := Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "receiver-tls-zipkin-136dd226", MountPath: "/etc/otelcol/tls/receivers/zipkin-136dd226", ReadOnly: true})
+
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	assert.Len(t, Volumes(config.New(), otelcol), 1)
+}
+
+func TestReceiverTLSConfig(t *testing.T) {
+	otelcol := receiverTLSInstance()
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	receivers := cfg["receivers"].(map[string]interface{})
+	protocols := receivers["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"cert_file": "/etc/otelcol/tls/receivers/otlp-0afb97b2/tls.crt",
+		"key_file":  "/etc/otelcol/tls/receivers/otlp-0afb97b2/tls.key",
+	}, protocols["grpc"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{
+		"cert_file":   "/etc/otelcol/tls/receivers/otlp-0afb97b2/tls.crt",
+		"key_file":    "/etc/otelcol/tls/receivers/otlp-0afb97b2/tls.key",
+		"min_version": "1.3",
+	}, protocols["http"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{
+		"cert_file":      "/etc/otelcol/tls/receivers/zipkin-136dd226/tls.crt",
+		"key_file":       "/etc/otelcol/tls/receivers/zipkin-136dd226/tls.key",
+		"client_ca_file": "/etc/otelcol/tls/receivers/zipkin-136dd226/ca.crt",
+	}, receivers["zipkin"].(map[string]interface{})["tls"])
+}
+
+func TestReceiverTLSConfigWithoutTLSProtocol(t *testing.T) {
+	otelcol := receiverTLSInstance()
+	otelcol.Spec.ReceiverTLS = map[string]v1alpha1.ReceiverTLSSpec{"jaeger": {SecretName: "jaeger-cert"}}
+	otelcol.Spec.Config = `receivers:
+  jaeger:
+    protocols:
+      thrift_compact:
+`
+
+	_, err := PresetConfig(config.New(), otelcol)
+	assert.ErrorContains(t, err, "the receiver jaeger of receiverTLS has no protocol served over TLS")
+}
+
+func TestReceiverTLSDirs(t *testing.T) {
+	otelcol := receiverTLSInstance()
+	otelcol.Spec.ReceiverTLS = map[string]v1alpha1.ReceiverTLSSpec{
+		"otlp/a": {SecretName: "a-cert"},
+		"otlp-a": {SecretName: "b-cert"},
+	}
+
+	// the receivers otlp/a and otlp-a are sanitized to the same name, but don't share their volume or directory
+	mounts := Container(config.New(), logr.Discard(), otelcol, true).VolumeMounts
+	assert.Contains(t, mounts, corev1.VolumeMount{Name: "receiver-tls-otlp-a-03ac3232", MountPath: "/etc/otelcol/tls/receivers/otlp-a-03ac3232", ReadOnly: true})
+	assert.Contains(t, mounts, corev1.VolumeMount{Name: "receiver-tls-otlp-a-0fb0c244", MountPath: "/etc/otelcol/tls/receivers/otlp-a-0fb0c244", ReadOnly: true})
+}
+
+func TestReceiverTLSCondition(t *testing.T) {
+	tlsSecret := func(name string, keys ...string) corev1.Secret {
+		secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name}, Data: map[string][]byte{}}
+		for _, key := range keys {
+			secret.Data[key] = []byte("pem")
+		}
+		return secret
+	}
+
+	for _, tt := range []struct {
+		desc            string
+		secrets         []corev1.Secret
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			desc:            "secrets with all the keys",
+			secrets:         []corev1.Secret{tlsSecret("otlp-cert", "tls.crt", "tls.key"), tlsSecret("zipkin-cert", "cert.pem", "key.pem", "ca.crt")},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "the secrets of the receivers hold the keys to mount",
+		},
+		{
+			desc:            "missing secret",
+			secrets:         []corev1.Secret{tlsSecret("zipkin-cert", "cert.pem", "key.pem", "ca.crt")},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "the secret otlp-cert of the receiver otlp doesn't exist, the collector pods can't start until it's fixed",
+		},
+		{
+			desc:            "missing keys",
+			secrets:         []corev1.Secret{tlsSecret("otlp-cert", "tls.crt"), tlsSecret("zipkin-cert", "tls.crt", "tls.key", "ca.crt")},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "the secret otlp-cert of the receiver otlp has no tls.key key, the secret zipkin-cert of the receiver zipkin has no cert.pem key, the secret zipkin-cert of the receiver zipkin has no key.pem key, the collector pods can't start until it's fixed",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			secrets := map[string]corev1.Secret{}
+			for _, secret := range tt.secrets {
+				secrets[secret.Name] = secret
+			}
+
+			// test
+			condition := ReceiverTLSCondition(receiverTLSInstance(), secrets)
+
+			// verify
+			require.NotNil(t, condition)
+			assert.Equal(t, ConditionTypeReceiverTLSReady, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+		})
+	}
+
+	otelcol := receiverTLSInstance()
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+	assert.Nil(t, ReceiverTLSCondition(otelcol, nil))
+	assert.Nil(t, ReceiverTLSCondition(v1alpha1.OpenTelemetryCollector{}, nil))
+}
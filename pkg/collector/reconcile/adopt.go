@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// Adopt deletes the collector deployment the instance in the current context takes over, once the collector of the
+// instance is ready, so that the collector is migrated to the operator without downtime.
+func Adopt(ctx context.Context, params Params) error {
+	name := params.Instance.Annotations[collector.AdoptAnnotation]
+	if len(name) == 0 || params.Instance.Spec.Mode == v1alpha1.ModeSidecar || name == naming.Collector(params.Instance) {
+		return nil
+	}
+
+	adopted := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: name}
+	// the adopted deployment isn't managed by the operator yet, so it's missing from the cache
+	if err := params.apiReader().Get(ctx, nns, adopted); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get: %w", err)
+	}
+
+	if reason := notAdoptable(params.Instance, *adopted); len(reason) > 0 {
+		params.Log.Info("not adopting the deployment, set the label to adopt it anyway", "deployment.name", name, "deployment.namespace", adopted.Namespace, "reason", reason, "label", collector.AdoptableLabel)
+		return nil
+	}
+
+	ready, err := collectorReady(ctx, params)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		params.Log.V(2).Info("waiting for the collector to be ready before deleting the adopted deployment", "deployment.name", name, "deployment.namespace", adopted.Namespace)
+		return nil
+	}
+
+	if err := params.Client.Delete(ctx, adopted); err != nil {
+		return fmt.Errorf("failed to delete the adopted deployment: %w", err)
+	}
+	params.Recorder.Event(&params.Instance, "Normal", "Adopted", fmt.Sprintf("the collector is ready, deleted the adopted deployment %s", name))
+	params.Log.V(2).Info("deleted the adopted deployment", "deployment.name", name, "deployment.namespace", adopted.Namespace)
+
+	return nil
+}
+
+// notAdoptable returns why the given deployment can't be adopted by the instance, or an empty string when it can. Only
+// the deployments labeled as managed by the operator, or owned by the instance, are adopted: the annotation alone would
+// let the authors of any instance delete the deployments of their namespace. The owner of a deployment controlled by
+// another object would create it again, and a deployment whose pods don't carry the labels of the instance isn't the
+// collector the instance was generated from, so those aren't adopted either. The AdoptableLabel set on a deployment
+// lifts all these checks.
+func notAdoptable(instance v1alpha1.OpenTelemetryCollector, deployment appsv1.Deployment) string {
+	if deployment.Labels[collector.AdoptableLabel] == "true" {
+		return ""
+	}
+	if owner := metav1.GetControllerOf(&deployment); owner != nil && owner.UID != instance.UID {
+		return fmt.Sprintf("the deployment is controlled by the %s %s", owner.Kind, owner.Name)
+	}
+	manager := deployment.Labels["app.kubernetes.io/managed-by"]
+	if manager != "opentelemetry-operator" && !ownedBy(deployment, instance) {
+		if len(manager) > 0 {
+			return fmt.Sprintf("the deployment is managed by %s", manager)
+		}
+		return "the deployment is neither labeled as managed by the operator nor owned by the instance"
+	}
+	for k, v := range deployment.Spec.Template.Labels {
+		if instance.Labels[k] != v {
+			return fmt.Sprintf("the pods of the deployment have the label %s=%s, which the instance doesn't have", k, v)
+		}
+	}
+	return ""
+}
+
+// ownedBy returns whether the given deployment has an owner reference to the given instance.
+func ownedBy(deployment appsv1.Deployment, instance v1alpha1.OpenTelemetryCollector) bool {
+	if len(instance.UID) == 0 {
+		return false
+	}
+	for _, owner := range deployment.OwnerReferences {
+		if owner.UID == instance.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// collectorReady returns whether all the collector pods of the instance in the current context are up-to-date and
+// available.
+func collectorReady(ctx context.Context, params Params) (bool, error) {
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.Collector(params.Instance)}
+
+	switch params.Instance.Spec.Mode {
+	case v1alpha1.ModeDaemonSet:
+		ds := &appsv1.DaemonSet{}
+		if err := params.Client.Get(ctx, nns, ds); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return ds.Status.ObservedGeneration >= ds.Generation &&
+			ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+			ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled, nil
+	case v1alpha1.ModeStatefulSet:
+		for _, name := range collector.StatefulSetNames(params.Instance) {
+			ss := &appsv1.StatefulSet{}
+			if err := params.Client.Get(ctx, types.NamespacedName{Namespace: params.Instance.Namespace, Name: name}, ss); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+			if ss.Status.ObservedGeneration < ss.Generation ||
+				ss.Status.UpdatedReplicas < replicasOrDefault(ss.Spec.Replicas) ||
+				ss.Status.AvailableReplicas < replicasOrDefault(ss.Spec.Replicas) {
+				return false, nil
+			}
+		}
+		return true, nil
+	default:
+		deployment := &appsv1.Deployment{}
+		if err := params.Client.Get(ctx, nns, deployment); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas >= replicasOrDefault(deployment.Spec.Replicas) &&
+			deployment.Status.AvailableReplicas >= replicasOrDefault(deployment.Spec.Replicas), nil
+	}
+}
+
+func replicasOrDefault(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
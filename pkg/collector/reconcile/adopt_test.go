@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestAdopt(t *testing.T) {
+	params := params()
+	params.Instance.Name = "test-adopt"
+	params.Instance.Annotations = map[string]string{collector.AdoptAnnotation: "legacy-collector"}
+	params.Instance.Labels = map[string]string{"app": "legacy-collector"}
+
+	legacy := deploymentForAdoption("legacy-collector", map[string]string{"app": "legacy-collector"})
+	legacy.Labels = map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-operator"}
+	require.NoError(t, k8sClient.Create(context.Background(), &legacy))
+	legacyName := types.NamespacedName{Namespace: legacy.Namespace, Name: legacy.Name}
+
+	t.Run("should keep the adopted deployment until the collector is ready", func(t *testing.T) {
+		err := Adopt(context.Background(), params)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &appsv1.Deployment{}, legacyName)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should delete the adopted deployment once the collector is ready", func(t *testing.T) {
+		managed := deploymentForAdoption("test-adopt-collector", collector.SelectorLabels(params.Instance))
+		require.NoError(t, k8sClient.Create(context.Background(), &managed))
+		managed.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: managed.Generation,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+		}
+		require.NoError(t, k8sClient.Status().Update(context.Background(), &managed))
+
+		err := Adopt(context.Background(), params)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &appsv1.Deployment{}, legacyName)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestAdoptRefused(t *testing.T) {
+	params := params()
+	params.Instance.Name = "test-adopt-refused"
+	params.Instance.Annotations = map[string]string{collector.AdoptAnnotation: "helm-collector"}
+	params.Instance.Labels = map[string]string{"app": "helm-collector"}
+
+	legacy := deploymentForAdoption("helm-collector", map[string]string{"app": "helm-collector"})
+	legacy.Labels = map[string]string{"app.kubernetes.io/managed-by": "Helm"}
+	require.NoError(t, k8sClient.Create(context.Background(), &legacy))
+	legacyName := types.NamespacedName{Namespace: legacy.Namespace, Name: legacy.Name}
+
+	managed := deploymentForAdoption("test-adopt-refused-collector", collector.SelectorLabels(params.Instance))
+	require.NoError(t, k8sClient.Create(context.Background(), &managed))
+	managed.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: managed.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		ReadyReplicas:      1,
+		AvailableReplicas:  1,
+	}
+	require.NoError(t, k8sClient.Status().Update(context.Background(), &managed))
+
+	t.Run("should keep a deployment managed by another tool", func(t *testing.T) {
+		err := Adopt(context.Background(), params)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &appsv1.Deployment{}, legacyName)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should delete the deployment once it's labeled as adoptable", func(t *testing.T) {
+		legacy.Labels[collector.AdoptableLabel] = "true"
+		require.NoError(t, k8sClient.Update(context.Background(), &legacy))
+
+		err := Adopt(context.Background(), params)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &appsv1.Deployment{}, legacyName)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestNotAdoptable(t *testing.T) {
+	isController := true
+	for _, tt := range []struct {
+		name      string
+		labels    map[string]string
+		owners    []metav1.OwnerReference
+		podLabels map[string]string
+		adoptable bool
+	}{
+		{
+			name:      "deployed with kubectl",
+			podLabels: map[string]string{"app": "legacy-collector"},
+		},
+		{
+			name:      "labeled as managed by the operator",
+			labels:    map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-operator"},
+			podLabels: map[string]string{"app": "legacy-collector"},
+			adoptable: true,
+		},
+		{
+			name:      "owned by the instance",
+			owners:    []metav1.OwnerReference{{APIVersion: "opentelemetry.io/v1alpha1", Kind: "OpenTelemetryCollector", Name: "test", UID: "instance-uid"}},
+			podLabels: map[string]string{"app": "legacy-collector"},
+			adoptable: true,
+		},
+		{
+			name:      "owned by another object",
+			owners:    []metav1.OwnerReference{{APIVersion: "opentelemetry.io/v1alpha1", Kind: "OpenTelemetryCollector", Name: "other", UID: "other-uid"}},
+			podLabels: map[string]string{"app": "legacy-collector"},
+		},
+		{
+			name:      "controlled by another object",
+			owners:    []metav1.OwnerReference{{APIVersion: "example.com/v1", Kind: "Collector", Name: "legacy", UID: "1", Controller: &isController}},
+			podLabels: map[string]string{"app": "legacy-collector"},
+		},
+		{
+			name:      "managed by another tool",
+			labels:    map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			podLabels: map[string]string{"app": "legacy-collector"},
+		},
+		{
+			name:      "pods not matching the instance",
+			labels:    map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-operator"},
+			podLabels: map[string]string{"app": "other-collector"},
+		},
+		{
+			name:      "labeled as adoptable",
+			labels:    map[string]string{"app.kubernetes.io/managed-by": "Helm", collector.AdoptableLabel: "true"},
+			podLabels: map[string]string{"app": "other-collector"},
+			adoptable: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := params().Instance
+			instance.Labels = map[string]string{"app": "legacy-collector"}
+			instance.UID = "instance-uid"
+			deployment := deploymentForAdoption("legacy-collector", tt.podLabels)
+			deployment.Labels = tt.labels
+			deployment.OwnerReferences = tt.owners
+
+			reason := notAdoptable(instance, deployment)
+			assert.Equal(t, tt.adoptable, len(reason) == 0, reason)
+		})
+	}
+}
+
+func deploymentForAdoption(name string, labels map[string]string) appsv1.Deployment {
+	replicas := int32(1)
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "otc-container", Image: "otel/opentelemetry-collector"}},
+				},
+			},
+		},
+	}
+}
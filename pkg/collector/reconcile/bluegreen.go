@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// blueGreenDeployments replaces the collector deployment among the given desired deployments with the blue/green
+// deployments of the instance in the current context. While its pod template changes, the active deployment keeps
+// running the previous one, and the other deployment is brought up with the new one. Once all of its replicas are
+// available, it's recorded as the active deployment, which switches the Services to it in the next reconciliation, where
+// the previous deployment is then deleted.
+func blueGreenDeployments(ctx context.Context, params Params, desired []appsv1.Deployment) ([]appsv1.Deployment, error) {
+	for i := range desired {
+		if desired[i].Name != naming.Collector(params.Instance) {
+			continue
+		}
+
+		next := desired[i]
+		revision := collector.PodTemplateRevision(next.Spec.Template)
+		active := collector.ActiveColor(params.Instance)
+		desired[i] = collector.BlueGreenDeployment(params.Instance, next, active)
+
+		rollout := params.Instance.Status.Rollout
+		if rollout != nil && rollout.Revision == revision {
+			return desired, nil
+		}
+
+		// the first pod template is rolled out to the active deployment right away, as is the one of an active
+		// deployment that's gone, since there's nothing to keep serving
+		existing := &appsv1.Deployment{}
+		err := params.Client.Get(ctx, types.NamespacedName{Namespace: params.Instance.Namespace, Name: desired[i].Name}, existing)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get: %w", err)
+		}
+		if rollout == nil || rollout.Revision == "" || k8serrors.IsNotFound(err) {
+			return desired, setRollout(ctx, params, active, revision)
+		}
+
+		// the active deployment keeps its pod template until the other one is ready with the new one
+		desired[i].Spec.Template = existing.Spec.Template
+		desired[i].Annotations[collector.RolloutRevisionAnnotation] = existing.Annotations[collector.RolloutRevisionAnnotation]
+		other := collector.BlueGreenDeployment(params.Instance, next, collector.OtherColor(active))
+		desired = append(desired, other)
+
+		preview := &appsv1.Deployment{}
+		err = params.Client.Get(ctx, types.NamespacedName{Namespace: other.Namespace, Name: other.Name}, preview)
+		if k8serrors.IsNotFound(err) {
+			return desired, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get: %w", err)
+		}
+		if collector.BlueGreenReady(*preview, revision) {
+			params.Log.V(1).Info("switching the services to the ready deployment", "deployment.name", other.Name, "deployment.namespace", other.Namespace)
+			return desired, setRollout(ctx, params, collector.OtherColor(active), revision)
+		}
+		return desired, nil
+	}
+	return desired, nil
+}
+
+// setRollout records the given active color and revision of the blue/green deployments on the status of the instance
+// in the current context.
+func setRollout(ctx context.Context, params Params, active, revision string) error {
+	changed := params.Instance.DeepCopy()
+	changed.Status.Rollout = &v1alpha1.RolloutStatus{
+		Active:   active,
+		Revision: revision,
+	}
+	if err := params.Client.Status().Patch(ctx, changed, client.MergeFrom(&params.Instance)); err != nil {
+		return fmt.Errorf("failed to apply the rollout status to the OpenTelemetry CR: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestBlueGreenDeployments(t *testing.T) {
+	versions := int32(3)
+	params := params()
+	params.Instance.Name = "test-bluegreen"
+	params.Instance.Spec.RolloutStrategy = v1alpha1.RolloutStrategyBlueGreen
+	params.Instance.Spec.ConfigVersions = &versions
+	instance := params.Instance
+	createObjectIfNotExists(t, "test-bluegreen", &instance)
+	params.Instance = instance
+
+	refresh := func(t *testing.T) {
+		actual := v1alpha1.OpenTelemetryCollector{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-bluegreen"})
+		require.NoError(t, err)
+		require.True(t, exists)
+		params.Instance.Status = actual.Status
+		params.Instance.ResourceVersion = actual.ResourceVersion
+	}
+
+	t.Run("should roll out the first pod template to the blue deployment", func(t *testing.T) {
+		desired, err := blueGreenDeployments(context.Background(), params, desiredDeployments(params))
+		require.NoError(t, err)
+		require.Len(t, desired, 1)
+		assert.Equal(t, "test-bluegreen-collector", desired[0].Name)
+		assert.Equal(t, collector.RolloutColorBlue, desired[0].Spec.Template.Labels[collector.RolloutColorLabel])
+		require.NoError(t, expectedDeployments(context.Background(), params, desired))
+
+		refresh(t)
+		require.NotNil(t, params.Instance.Status.Rollout)
+		assert.Equal(t, collector.RolloutColorBlue, params.Instance.Status.Rollout.Active)
+		assert.Equal(t, desired[0].Annotations[collector.RolloutRevisionAnnotation], params.Instance.Status.Rollout.Revision)
+	})
+
+	t.Run("should bring up the green deployment with the new pod template", func(t *testing.T) {
+		revision := params.Instance.Status.Rollout.Revision
+		params.Instance.Spec.Config = "receivers:\n  otlp:\n    protocols:\n      grpc:\n"
+
+		desired, err := blueGreenDeployments(context.Background(), params, desiredDeployments(params))
+		require.NoError(t, err)
+		require.Len(t, desired, 2)
+		assert.Equal(t, "test-bluegreen-collector", desired[0].Name)
+		assert.Equal(t, revision, desired[0].Annotations[collector.RolloutRevisionAnnotation])
+		assert.Equal(t, "test-bluegreen-collector-green", desired[1].Name)
+		assert.NotEqual(t, revision, desired[1].Annotations[collector.RolloutRevisionAnnotation])
+		require.NoError(t, expectedDeployments(context.Background(), params, desired))
+
+		refresh(t)
+		assert.Equal(t, collector.RolloutColorBlue, params.Instance.Status.Rollout.Active)
+		assert.Equal(t, revision, params.Instance.Status.Rollout.Revision)
+	})
+
+	t.Run("should switch to the green deployment once it's ready", func(t *testing.T) {
+		green := appsv1.Deployment{}
+		exists, err := populateObjectIfExists(t, &green, types.NamespacedName{Namespace: "default", Name: "test-bluegreen-collector-green"})
+		require.NoError(t, err)
+		require.True(t, exists)
+		green.Status.ObservedGeneration = green.Generation
+		green.Status.Replicas = *green.Spec.Replicas
+		green.Status.UpdatedReplicas = *green.Spec.Replicas
+		green.Status.ReadyReplicas = *green.Spec.Replicas
+		green.Status.AvailableReplicas = *green.Spec.Replicas
+		require.NoError(t, k8sClient.Status().Update(context.Background(), &green))
+
+		_, err = blueGreenDeployments(context.Background(), params, desiredDeployments(params))
+		require.NoError(t, err)
+
+		refresh(t)
+		assert.Equal(t, collector.RolloutColorGreen, params.Instance.Status.Rollout.Active)
+		assert.Equal(t, green.Annotations[collector.RolloutRevisionAnnotation], params.Instance.Status.Rollout.Revision)
+
+		// the blue deployment is then left out, so that it's deleted
+		desired, err := blueGreenDeployments(context.Background(), params, desiredDeployments(params))
+		require.NoError(t, err)
+		require.Len(t, desired, 1)
+		assert.Equal(t, "test-bluegreen-collector-green", desired[0].Name)
+	})
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods;nodes,verbs=get;list;watch
+
+// ClusterRoles reconciles the cluster role and cluster role binding required by the receiver creator preset of the
+// instance in the current context. Cluster-scoped objects can't be owned by the instance, so they are found by their
+// labels and deleted by the controller when the instance is deleted.
+func ClusterRoles(ctx context.Context, params Params) error {
+	// the operators installed with the permissions of some namespaces only can't manage the cluster roles
+	if !params.Config.ClusterRolesAllowed() {
+		if params.Instance.Spec.ReceiverCreator.Enabled {
+			params.Recorder.Event(&params.Instance, "Warning", "ClusterRolesNotAllowed", "the operator isn't allowed to manage the cluster roles of the receiver creator")
+		}
+		return nil
+	}
+
+	desiredRoles, desiredBindings := desiredClusterRoles(params)
+
+	// first, handle the create/update parts
+	if err := expectedClusterRoles(ctx, params, desiredRoles, desiredBindings); err != nil {
+		return fmt.Errorf("failed to reconcile the expected cluster roles: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteClusterRoles(ctx, params, desiredRoles, desiredBindings); err != nil {
+		return fmt.Errorf("failed to reconcile the cluster roles to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteClusterRoles deletes the cluster roles and cluster role bindings created for the instance in the current context.
+func DeleteClusterRoles(ctx context.Context, params Params) error {
+	if !params.Config.ClusterRolesAllowed() {
+		return nil
+	}
+	return deleteClusterRoles(ctx, params, []rbacv1.ClusterRole{}, []rbacv1.ClusterRoleBinding{})
+}
+
+func desiredClusterRoles(params Params) ([]rbacv1.ClusterRole, []rbacv1.ClusterRoleBinding) {
+	if !params.Instance.Spec.ReceiverCreator.Enabled {
+		return []rbacv1.ClusterRole{}, []rbacv1.ClusterRoleBinding{}
+	}
+	return []rbacv1.ClusterRole{collector.ClusterRole(params.Instance)},
+		[]rbacv1.ClusterRoleBinding{collector.ClusterRoleBinding(params.Instance)}
+}
+
+func expectedClusterRoles(ctx context.Context, params Params, roles []rbacv1.ClusterRole, bindings []rbacv1.ClusterRoleBinding) error {
+	for _, obj := range roles {
+		desired := obj
+
+		existing := &rbacv1.ClusterRole{}
+		err := params.Client.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "clusterrole.name", desired.Name)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		updated := existing.DeepCopy()
+		mergeMetadata(&updated.ObjectMeta, desired.ObjectMeta)
+		updated.Rules = desired.Rules
+
+		patch := client.MergeFrom(existing)
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "clusterrole.name", desired.Name)
+	}
+
+	for _, obj := range bindings {
+		desired := obj
+
+		existing := &rbacv1.ClusterRoleBinding{}
+		err := params.Client.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "clusterrolebinding.name", desired.Name)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// the role of a binding is immutable, so only the subjects are updated
+		updated := existing.DeepCopy()
+		mergeMetadata(&updated.ObjectMeta, desired.ObjectMeta)
+		updated.Subjects = desired.Subjects
+
+		patch := client.MergeFrom(existing)
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "clusterrolebinding.name", desired.Name)
+	}
+
+	return nil
+}
+
+func deleteClusterRoles(ctx context.Context, params Params, roles []rbacv1.ClusterRole, bindings []rbacv1.ClusterRoleBinding) error {
+	opts := []client.ListOption{
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+
+	bindingList := &rbacv1.ClusterRoleBindingList{}
+	if err := params.Client.List(ctx, bindingList, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	for i := range bindingList.Items {
+		existing := bindingList.Items[i]
+		del := true
+		for _, keep := range bindings {
+			if keep.Name == existing.Name {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "clusterrolebinding.name", existing.Name)
+		}
+	}
+
+	roleList := &rbacv1.ClusterRoleList{}
+	if err := params.Client.List(ctx, roleList, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	for i := range roleList.Items {
+		existing := roleList.Items[i]
+		del := true
+		for _, keep := range roles {
+			if keep.Name == existing.Name {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "clusterrole.name", existing.Name)
+		}
+	}
+
+	return nil
+}
+
+func mergeMetadata(updated *metav1.ObjectMeta, desired metav1.ObjectMeta) {
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	if updated.Labels == nil {
+		updated.Labels = map[string]string{}
+	}
+	for k, v := range desired.Annotations {
+		updated.Annotations[k] = v
+	}
+	for k, v := range desired.Labels {
+		updated.Labels[k] = v
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func receiverCreatorParams() Params {
+	p := params()
+	p.Instance.Spec.ReceiverCreator = v1alpha1.ReceiverCreatorSpec{
+		Enabled: true,
+		Receivers: map[string]v1alpha1.ReceiverCreatorTemplate{
+			"redis": {Rule: `type == "port" && port == 6379`},
+		},
+	}
+	return p
+}
+
+func TestDesiredClusterRoles(t *testing.T) {
+	t.Run("should not create any cluster role", func(t *testing.T) {
+		roles, bindings := desiredClusterRoles(params())
+		assert.Len(t, roles, 0)
+		assert.Len(t, bindings, 0)
+	})
+
+	t.Run("should create the receiver creator cluster role", func(t *testing.T) {
+		params := receiverCreatorParams()
+		roles, bindings := desiredClusterRoles(params)
+		assert.Equal(t, []rbacv1.ClusterRole{collector.ClusterRole(params.Instance)}, roles)
+		assert.Equal(t, []rbacv1.ClusterRoleBinding{collector.ClusterRoleBinding(params.Instance)}, bindings)
+	})
+}
+
+func TestExpectedClusterRoles(t *testing.T) {
+	t.Run("should create the cluster role and binding", func(t *testing.T) {
+		params := receiverCreatorParams()
+		roles, bindings := desiredClusterRoles(params)
+		err := expectedClusterRoles(context.Background(), params, roles, bindings)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &rbacv1.ClusterRole{}, types.NamespacedName{Name: "test-default-collector-37121037"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = populateObjectIfExists(t, &rbacv1.ClusterRoleBinding{}, types.NamespacedName{Name: "test-default-collector-37121037"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should update the rules of the existing cluster role", func(t *testing.T) {
+		params := receiverCreatorParams()
+		params.Instance.Spec.ReceiverCreator.ObserveNodes = true
+		roles, bindings := desiredClusterRoles(params)
+		err := expectedClusterRoles(context.Background(), params, roles, bindings)
+		assert.NoError(t, err)
+
+		actual := rbacv1.ClusterRole{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Name: "test-default-collector-37121037"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, []string{"pods", "nodes"}, actual.Rules[0].Resources)
+	})
+}
+
+func TestDeleteClusterRoles(t *testing.T) {
+	t.Run("should delete the managed cluster role", func(t *testing.T) {
+		existing := rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-delete-collector",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+			},
+		}
+		createObjectIfNotExists(t, "test-delete-collector", &existing)
+
+		err := DeleteClusterRoles(context.Background(), params())
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &rbacv1.ClusterRole{}, types.NamespacedName{Name: "test-delete-collector"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should not delete unrelated cluster role", func(t *testing.T) {
+		existing := rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-unrelated-collector",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "default.testing",
+					"app.kubernetes.io/managed-by": "helm-opentelemetry",
+				},
+			},
+		}
+		createObjectIfNotExists(t, "test-unrelated-collector", &existing)
+
+		err := DeleteClusterRoles(context.Background(), params())
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &rbacv1.ClusterRole{}, types.NamespacedName{Name: "test-unrelated-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
@@ -22,9 +22,12 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -42,7 +45,15 @@ type Config struct {
 	TargetAllocConfig *targetAllocator   `yaml:"target_allocator,omitempty"`
 }
 
-func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
+func ReplaceConfig(cfg config.Config, instance v1alpha1.OpenTelemetryCollector) (string, error) {
+	// The presets are applied first, so that the rest of the replacements see the components they add
+	presetConfig, err := collector.PresetConfig(cfg, instance)
+	if err != nil {
+		return "", err
+	}
+	// the secret references are replaced with the environment variables holding their values
+	instance.Spec.Config = adapters.ReplaceSecretReferences(presetConfig)
+
 	// Check if TargetAllocator is enabled, if not, return the original config
 	if !instance.Spec.TargetAllocator.Enabled {
 		return instance.Spec.Config, nil
@@ -72,7 +83,7 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 		}
 
 		// type coercion checks are handled in the AddTAConfigToPromConfig method above
-		config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
+		config["receivers"].(map[string]interface{})["prometheus"] = updPromCfgMap
 
 		out, updCfgMarshalErr := yaml.Marshal(config)
 		if updCfgMarshalErr != nil {
@@ -84,13 +95,24 @@ func ReplaceConfig(instance v1alpha1.OpenTelemetryCollector) (string, error) {
 
 	// To avoid issues caused by Prometheus validation logic, which fails regex validation when it encounters
 	// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
-	updPromCfgMap, err := ta.AddHTTPSDConfigToPromConfig(promCfgMap, naming.TAService(instance))
+	// When the jobs are sharded, each job's targets are served by the TargetAllocator shard owning it.
+	taServiceName := func(string) string { return naming.TAService(instance) }
+	if shards := targetallocator.JobShards(instance); shards > 1 {
+		taServiceName = func(jobName string) string {
+			return naming.TAServiceShard(instance, targetallocator.JobShard(jobName, shards))
+		}
+	}
+	// When the collectors run per zone, each of them reaches the TargetAllocator of its own zone.
+	if len(targetallocator.Zones(instance)) > 0 {
+		taServiceName = func(string) string { return "$" + collector.TargetAllocatorServiceEnvVar }
+	}
+	updPromCfgMap, err := ta.AddShardedHTTPSDConfigToPromConfig(promCfgMap, taServiceName)
 	if err != nil {
 		return "", err
 	}
 
 	// type coercion checks are handled in the ConfigToPromConfig method above
-	config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
+	config["receivers"].(map[string]interface{})["prometheus"] = updPromCfgMap
 
 	out, err := yaml.Marshal(config)
 	if err != nil {
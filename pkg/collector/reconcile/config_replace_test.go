@@ -15,6 +15,7 @@
 package reconcile
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -24,7 +25,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -33,7 +36,7 @@ func TestPrometheusParser(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("should update config with http_sd_config", func(t *testing.T) {
-		actualConfig, err := ReplaceConfig(param.Instance)
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
 		assert.NoError(t, err)
 
 		// prepare
@@ -64,6 +67,65 @@ func TestPrometheusParser(t *testing.T) {
 		assert.True(t, cfg.TargetAllocConfig == nil)
 	})
 
+	t.Run("should update config with http_sd_config of the owning job shard", func(t *testing.T) {
+		shards := int32(3)
+		param.Instance.Spec.TargetAllocator.JobShards = &shards
+		defer func() {
+			param.Instance.Spec.TargetAllocator.JobShards = nil
+		}()
+
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
+		assert.NoError(t, err)
+
+		// prepare
+		var cfg Config
+		promCfgMap, err := ta.ConfigToPromConfig(actualConfig)
+		assert.NoError(t, err)
+
+		promCfg, err := yaml.Marshal(promCfgMap)
+		assert.NoError(t, err)
+
+		err = yaml.UnmarshalStrict(promCfg, &cfg)
+		assert.NoError(t, err)
+
+		// test
+		assert.Len(t, cfg.PromConfig.ScrapeConfigs, 2)
+		for _, scrapeConfig := range cfg.PromConfig.ScrapeConfigs {
+			assert.Len(t, scrapeConfig.ServiceDiscoveryConfigs, 1)
+			expectedURL := fmt.Sprintf("http://test-targetallocator-%d:80/jobs/%s/targets?collector_id=$POD_NAME", targetallocator.JobShard(scrapeConfig.JobName, shards), scrapeConfig.JobName)
+			assert.Equal(t, expectedURL, scrapeConfig.ServiceDiscoveryConfigs[0].(*http.SDConfig).URL)
+		}
+	})
+
+	t.Run("should update config with http_sd_config of the target allocator of the zone", func(t *testing.T) {
+		param.Instance.Spec.TargetAllocator.TopologyAware = &v1alpha1.TopologyAwareSpec{Zones: []string{"eu-west-1a", "eu-west-1b"}}
+		defer func() {
+			param.Instance.Spec.TargetAllocator.TopologyAware = nil
+		}()
+
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
+		assert.NoError(t, err)
+
+		// prepare
+		var cfg Config
+		promCfgMap, err := ta.ConfigToPromConfig(actualConfig)
+		assert.NoError(t, err)
+
+		promCfg, err := yaml.Marshal(promCfgMap)
+		assert.NoError(t, err)
+
+		err = yaml.UnmarshalStrict(promCfg, &cfg)
+		assert.NoError(t, err)
+
+		// test
+		assert.Len(t, cfg.PromConfig.ScrapeConfigs, 2)
+		for _, scrapeConfig := range cfg.PromConfig.ScrapeConfigs {
+			assert.Len(t, scrapeConfig.ServiceDiscoveryConfigs, 1)
+			expectedURL := fmt.Sprintf("http://$TARGET_ALLOCATOR_SERVICE:80/jobs/%s/targets?collector_id=$POD_NAME", scrapeConfig.JobName)
+			assert.Equal(t, expectedURL, scrapeConfig.ServiceDiscoveryConfigs[0].(*http.SDConfig).URL)
+		}
+	})
+
 	t.Run("should update config with targetAllocator block", func(t *testing.T) {
 		err := colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRewrite.ID(), true)
 		param.Instance.Spec.TargetAllocator.Enabled = true
@@ -71,18 +133,18 @@ func TestPrometheusParser(t *testing.T) {
 
 		// Set up the test scenario
 		param.Instance.Spec.TargetAllocator.Enabled = true
-		actualConfig, err := ReplaceConfig(param.Instance)
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
 		assert.NoError(t, err)
 
 		// Verify the expected changes in the config
 		promCfgMap, err := ta.ConfigToPromConfig(actualConfig)
 		assert.NoError(t, err)
 
-		prometheusConfig := promCfgMap["config"].(map[interface{}]interface{})
+		prometheusConfig := promCfgMap["config"].(map[string]interface{})
 
 		assert.NotContains(t, prometheusConfig, "scrape_configs")
 
-		expectedTAConfig := map[interface{}]interface{}{
+		expectedTAConfig := map[string]interface{}{
 			"endpoint":     "http://test-targetallocator:80",
 			"interval":     "30s",
 			"collector_id": "${POD_NAME}",
@@ -96,7 +158,7 @@ func TestPrometheusParser(t *testing.T) {
 
 	t.Run("should not update config with http_sd_config", func(t *testing.T) {
 		param.Instance.Spec.TargetAllocator.Enabled = false
-		actualConfig, err := ReplaceConfig(param.Instance)
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
 		assert.NoError(t, err)
 
 		// prepare
@@ -139,12 +201,23 @@ func TestReplaceConfig(t *testing.T) {
 		assert.NoError(t, err)
 		expectedConfig := string(expectedConfigBytes)
 
-		actualConfig, err := ReplaceConfig(param.Instance)
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
 		assert.NoError(t, err)
 
 		assert.Equal(t, expectedConfig, actualConfig)
 	})
 
+	t.Run("should replace secret references with env vars", func(t *testing.T) {
+		instance := param.Instance
+		instance.Spec.TargetAllocator.Enabled = false
+		instance.Spec.Config = "exporters:\n  otlphttp:\n    headers:\n      api-key: ${secret:default/vendor-credentials/api-key}\n"
+
+		actualConfig, err := ReplaceConfig(param.Config, instance)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "exporters:\n  otlphttp:\n    headers:\n      api-key: ${OTEL_SECRET_VENDOR_CREDENTIALS_API_KEY}\n", actualConfig)
+	})
+
 	t.Run("should rewrite scrape configs with SD config when TargetAllocator is enabled and feature flag is not set", func(t *testing.T) {
 		param.Instance.Spec.TargetAllocator.Enabled = true
 
@@ -152,7 +225,7 @@ func TestReplaceConfig(t *testing.T) {
 		assert.NoError(t, err)
 		expectedConfig := string(expectedConfigBytes)
 
-		actualConfig, err := ReplaceConfig(param.Instance)
+		actualConfig, err := ReplaceConfig(param.Config, param.Instance)
 		assert.NoError(t, err)
 
 		assert.Equal(t, expectedConfig, actualConfig)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
@@ -39,16 +41,9 @@ import (
 
 // ConfigMaps reconciles the config map(s) required for the instance in the current context.
 func ConfigMaps(ctx context.Context, params Params) error {
-	desired := []corev1.ConfigMap{
-		desiredConfigMap(ctx, params),
-	}
-
-	if params.Instance.Spec.TargetAllocator.Enabled {
-		cm, err := desiredTAConfigMap(params)
-		if err != nil {
-			return fmt.Errorf("failed to parse config: %w", err)
-		}
-		desired = append(desired, cm)
+	desired, err := desiredAllConfigMaps(ctx, params)
+	if err != nil {
+		return err
 	}
 
 	// first, handle the create/update parts
@@ -64,11 +59,35 @@ func ConfigMaps(ctx context.Context, params Params) error {
 	return nil
 }
 
+// desiredAllConfigMaps returns the config maps of the collector, of its node profiles and, when enabled, of the target
+// allocator.
+func desiredAllConfigMaps(ctx context.Context, params Params) ([]corev1.ConfigMap, error) {
+	desired, err := desiredConfigMaps(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split the config: %w", err)
+	}
+
+	profiles, err := desiredNodeProfileConfigMaps(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	desired = append(desired, profiles...)
+
+	if params.Instance.Spec.TargetAllocator.Enabled {
+		cm, err := desiredTAConfigMap(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		desired = append(desired, cm)
+	}
+	return desired, nil
+}
+
 func desiredConfigMap(_ context.Context, params Params) corev1.ConfigMap {
 	name := naming.ConfigMap(params.Instance)
 	labels := collector.Labels(params.Instance, name, []string{})
 
-	config, err := ReplaceConfig(params.Instance)
+	config, err := ReplaceConfig(params.Config, params.Instance)
 	if err != nil {
 		params.Log.V(2).Info("failed to update prometheus config to use sharded targets: ", "err", err)
 	}
@@ -86,6 +105,68 @@ func desiredConfigMap(_ context.Context, params Params) corev1.ConfigMap {
 	}
 }
 
+// desiredConfigMaps returns the config maps holding the parts of the collector's configuration, which is split when
+// it's too large for a single config map.
+func desiredConfigMaps(ctx context.Context, params Params) ([]corev1.ConfigMap, error) {
+	cm := desiredConfigMap(ctx, params)
+	parts, err := collector.ConfigParts(params.Config, params.Instance, cm.Data["collector.yaml"])
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make([]corev1.ConfigMap, len(parts))
+	for i, part := range parts {
+		name := collector.VersionedConfigMap(params.Config, params.Instance, naming.ConfigMapPart(params.Instance, i))
+		desired[i] = *cm.DeepCopy()
+		desired[i].Name = name
+		desired[i].Labels = configMapLabels(params.Config, params.Instance, name)
+		desired[i].Data = map[string]string{
+			"collector.yaml": part,
+		}
+	}
+	return desired, nil
+}
+
+// configMapLabels returns the labels of the config map with the given name holding the configuration of the given
+// instance, along with the version of the configuration when the config maps are versioned.
+func configMapLabels(cfg config.Config, instance v1alpha1.OpenTelemetryCollector, name string) map[string]string {
+	labels := collector.Labels(instance, name, []string{})
+	if version := collector.ConfigVersion(cfg, instance); version != "" {
+		labels[collector.ConfigVersionLabel] = version
+	}
+	return labels
+}
+
+// desiredNodeProfileConfigMaps returns the config maps holding the configuration of the collector on the nodes of each
+// node profile.
+func desiredNodeProfileConfigMaps(ctx context.Context, params Params) ([]corev1.ConfigMap, error) {
+	if params.Instance.Spec.Mode != v1alpha1.ModeDaemonSet {
+		return nil, nil
+	}
+
+	var desired []corev1.ConfigMap
+	for _, profile := range params.Instance.Spec.NodeProfiles {
+		instance, err := collector.NodeProfileInstance(params.Instance, profile)
+		if err != nil {
+			return nil, err
+		}
+		profileParams := params
+		profileParams.Instance = instance
+		cms, err := desiredConfigMaps(ctx, profileParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split the config of the node profile %s: %w", profile.Name, err)
+		}
+		for i := range cms {
+			name := collector.VersionedConfigMap(params.Config, instance, naming.ConfigMapNodeProfilePart(params.Instance, profile.Name, i))
+			cms[i].Name = name
+			cms[i].Labels = configMapLabels(params.Config, instance, name)
+			cms[i].Labels[collector.NodeProfileLabel] = profile.Name
+		}
+		desired = append(desired, cms...)
+	}
+	return desired, nil
+}
+
 func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 	name := naming.TAConfigMap(params.Instance)
 	version := strings.Split(params.Instance.Spec.Image, ":")
@@ -102,10 +183,17 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 	if err != nil {
 		return corev1.ConfigMap{}, err
 	}
+	// The TargetAllocators running per zone need the zone of the nodes to filter the targets.
+	if len(targetallocator.Zones(params.Instance)) > 0 {
+		prometheusReceiverConfig, err = ta.AttachNodeMetadataToPromConfig(prometheusReceiverConfig)
+		if err != nil {
+			return corev1.ConfigMap{}, err
+		}
+	}
 
-	taConfig := make(map[interface{}]interface{})
+	taConfig := make(map[string]interface{})
 	taConfig["label_selector"] = map[string]string{
-		"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+		"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 		"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		"app.kubernetes.io/component":  "opentelemetry-collector",
 	}
@@ -124,6 +212,31 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 		taConfig["filter_strategy"] = params.Instance.Spec.TargetAllocator.FilterStrategy
 	}
 
+	if overrides := targetallocator.ScrapeOverrides(params.Instance); overrides != nil {
+		taConfig["scrape_overrides"] = overrides
+	}
+
+	if params.Instance.Spec.TargetAllocator.MaxTargetsPerCollector != nil {
+		taConfig["max_targets_per_collector"] = *params.Instance.Spec.TargetAllocator.MaxTargetsPerCollector
+	}
+
+	if params.Instance.Spec.TargetAllocator.TargetHandoff {
+		// The targets are handed off for the interval the collectors get them at with their rendered configuration
+		collectorConfig, err := ReplaceConfig(params.Config, params.Instance)
+		if err != nil {
+			return corev1.ConfigMap{}, err
+		}
+		handoff, err := targetallocator.TargetHandoff(params.Instance, collectorConfig)
+		if err != nil {
+			return corev1.ConfigMap{}, err
+		}
+		taConfig["target_handoff"] = handoff
+	}
+
+	if workItems := targetallocator.WorkItems(params.Instance); workItems != nil {
+		taConfig["work_items"] = workItems
+	}
+
 	if params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector != nil {
 		taConfig["service_monitor_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector
 	}
@@ -220,7 +333,7 @@ func deleteConfigMaps(ctx context.Context, params Params, expected []corev1.Conf
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -229,9 +342,14 @@ func deleteConfigMaps(ctx context.Context, params Params, expected []corev1.Conf
 		return fmt.Errorf("failed to list: %w", err)
 	}
 
+	retained := retainedConfigMaps(params.Instance, expected, list.Items)
 	for i := range list.Items {
 		existing := list.Items[i]
-		del := true
+		// the config maps of the configuration validation are managed along with its jobs, see ValidateConfig
+		if existing.Labels["app.kubernetes.io/component"] == collector.ConfigValidationComponent {
+			continue
+		}
+		del := !retained[existing.Name]
 		for _, keep := range expected {
 			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
 				del = false
@@ -250,6 +368,73 @@ func deleteConfigMaps(ctx context.Context, params Params, expected []corev1.Conf
 	return nil
 }
 
+// retainedConfigMaps returns the names of the config maps holding the previous versions of the configuration the
+// instance retains: the most recently created ones of the collector and of each node profile, in addition to the
+// current ones.
+func retainedConfigMaps(instance v1alpha1.OpenTelemetryCollector, expected []corev1.ConfigMap, existing []corev1.ConfigMap) map[string]bool {
+	retained := map[string]bool{}
+	if instance.Spec.ConfigVersions == nil {
+		return retained
+	}
+
+	// the versions are tracked per node profile, the collector's ones having no profile
+	key := func(cm corev1.ConfigMap) (string, bool) {
+		version, ok := cm.Labels[collector.ConfigVersionLabel]
+		return cm.Labels[collector.NodeProfileLabel] + "/" + version, ok
+	}
+	current := map[string]bool{}
+	for _, cm := range expected {
+		if k, ok := key(cm); ok {
+			current[k] = true
+		}
+	}
+
+	// the creation time of each previous version, per node profile
+	previous := map[string]map[string]metav1.Time{}
+	for _, cm := range existing {
+		k, ok := key(cm)
+		if !ok || current[k] {
+			continue
+		}
+		profile := cm.Labels[collector.NodeProfileLabel]
+		if previous[profile] == nil {
+			previous[profile] = map[string]metav1.Time{}
+		}
+		if created, ok := previous[profile][k]; !ok || created.Before(&cm.CreationTimestamp) {
+			previous[profile][k] = cm.CreationTimestamp
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, versions := range previous {
+		ordered := make([]string, 0, len(versions))
+		for k := range versions {
+			ordered = append(ordered, k)
+		}
+		sort.Slice(ordered, func(i, j int) bool {
+			ci, cj := versions[ordered[i]], versions[ordered[j]]
+			if !ci.Equal(&cj) {
+				return cj.Before(&ci)
+			}
+			return ordered[i] < ordered[j]
+		})
+		// the current version counts as one of the retained versions
+		if n := int(*instance.Spec.ConfigVersions) - 1; len(ordered) > n {
+			ordered = ordered[:n]
+		}
+		for _, k := range ordered {
+			keep[k] = true
+		}
+	}
+
+	for _, cm := range existing {
+		if k, ok := key(cm); ok && keep[k] {
+			retained[cm.Name] = true
+		}
+	}
+	return retained
+}
+
 func configMapChanged(desired *corev1.ConfigMap, actual *corev1.ConfigMap) bool {
 	return !reflect.DeepEqual(desired.Data, actual.Data)
 
@@ -16,7 +16,10 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 
@@ -30,7 +33,10 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -281,7 +287,272 @@ service_monitor_selector:
 		assert.Equal(t, expectedData, actual.Data)
 
 	})
+	t.Run("should return expected target allocator config map with scrape overrides", func(t *testing.T) {
+		expectedLables["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLables["app.kubernetes.io/name"] = "test-targetallocator"
+
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+scrape_overrides:
+  min_scrape_interval: 30s
+  sample_limit: 10000
+`,
+		}
+		sampleLimit := int32(10000)
+		p := params()
+		p.Instance.Spec.TargetAllocator.ScrapeOverrides = &v1alpha1.TargetAllocatorScrapeOverrides{
+			MinScrapeInterval: &metav1.Duration{Duration: 30 * time.Second},
+			SampleLimit:       &sampleLimit,
+		}
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "test-targetallocator", actual.Name)
+		assert.Equal(t, expectedLables, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
+	t.Run("should return expected target allocator config map with max targets per collector", func(t *testing.T) {
+		expectedLables["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLables["app.kubernetes.io/name"] = "test-targetallocator"
+
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+max_targets_per_collector: 500
+`,
+		}
+		maxTargets := int32(500)
+		p := params()
+		p.Instance.Spec.TargetAllocator.MaxTargetsPerCollector = &maxTargets
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "test-targetallocator", actual.Name)
+		assert.Equal(t, expectedLables, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
+	t.Run("should return expected target allocator config map with target handoff", func(t *testing.T) {
+		expectedLables["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLables["app.kubernetes.io/name"] = "test-targetallocator"
 
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+target_handoff:
+  refresh_interval: 60s
+`,
+		}
+		p := params()
+		p.Instance.Spec.TargetAllocator.TargetHandoff = true
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "test-targetallocator", actual.Name)
+		assert.Equal(t, expectedLables, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
+	t.Run("should return expected target allocator config map with work items", func(t *testing.T) {
+		expectedLables["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLables["app.kubernetes.io/name"] = "test-targetallocator"
+
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+work_items:
+- endpoint: postgres:5432
+  job_name: sql-queries
+  labels:
+    database: orders
+`,
+		}
+		p := params()
+		p.Instance.Spec.TargetAllocator.WorkItems = []v1alpha1.TargetAllocatorWorkItem{
+			{JobName: "sql-queries", Endpoint: "postgres:5432", Labels: map[string]string{"database": "orders"}},
+		}
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "test-targetallocator", actual.Name)
+		assert.Equal(t, expectedLables, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
+}
+
+func TestDesiredConfigMaps(t *testing.T) {
+	t.Run("should hold the config in a single config map", func(t *testing.T) {
+		desired, err := desiredConfigMaps(context.Background(), params())
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.ConfigMap{desiredConfigMap(context.Background(), params())}, desired)
+	})
+
+	t.Run("should split a large config in multiple config maps", func(t *testing.T) {
+		param := params()
+		var receivers []string
+		configStr := "receivers:\n"
+		for i := 0; i < 30; i++ {
+			receivers = append(receivers, fmt.Sprintf("filelog/%d", i))
+			configStr += fmt.Sprintf("  filelog/%d:\n    include: [%s]\n", i, strings.Repeat("a", 100*1024))
+		}
+		configStr += fmt.Sprintf("exporters:\n  logging:\nservice:\n  pipelines:\n    logs:\n      receivers: [%s]\n      exporters: [logging]\n", strings.Join(receivers, ", "))
+		param.Instance.Spec.Config = configStr
+
+		desired, err := desiredConfigMaps(context.Background(), param)
+		assert.NoError(t, err)
+		assert.Len(t, desired, 4)
+		for i, cm := range desired {
+			assert.Equal(t, naming.ConfigMapPart(param.Instance, i), cm.Name)
+			assert.Equal(t, cm.Name, cm.Labels["app.kubernetes.io/name"])
+			assert.LessOrEqual(t, len(cm.Data["collector.yaml"]), adapters.MaxConfigSize)
+		}
+	})
+}
+
+func TestDesiredVersionedConfigMaps(t *testing.T) {
+	// prepare
+	param := params()
+	three := int32(3)
+	param.Instance.Spec.ConfigVersions = &three
+	version := collector.ConfigVersion(param.Config, param.Instance)
+
+	// test
+	desired, err := desiredConfigMaps(context.Background(), param)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, desired, 1)
+	assert.Equal(t, "test-collector-"+version, desired[0].Name)
+	assert.Equal(t, version, desired[0].Labels[collector.ConfigVersionLabel])
+	assert.Equal(t, desired[0].Name, desired[0].Labels["app.kubernetes.io/name"])
+}
+
+func TestRetainedConfigMaps(t *testing.T) {
+	versioned := func(name, profile, version string, age int) v1.ConfigMap {
+		cm := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{collector.ConfigVersionLabel: version},
+				CreationTimestamp: metav1.Unix(int64(1000-age), 0),
+			},
+		}
+		if profile != "" {
+			cm.Labels[collector.NodeProfileLabel] = profile
+		}
+		return cm
+	}
+	existing := []v1.ConfigMap{
+		versioned("test-collector-v4", "", "v4", 0),
+		versioned("test-collector-v3", "", "v3", 1),
+		versioned("test-collector-1-v3", "", "v3", 1),
+		versioned("test-collector-v2", "", "v2", 2),
+		versioned("test-collector-v1", "", "v1", 3),
+		versioned("test-collector-gpu-g2", "gpu", "g2", 0),
+		versioned("test-collector-gpu-g1", "gpu", "g1", 1),
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-collector"}},
+	}
+	expected := []v1.ConfigMap{existing[0], existing[5]}
+
+	t.Run("should retain no config map without versions", func(t *testing.T) {
+		assert.Empty(t, retainedConfigMaps(params().Instance, expected, existing))
+	})
+
+	t.Run("should retain the most recent previous versions", func(t *testing.T) {
+		instance := params().Instance
+		three := int32(3)
+		instance.Spec.ConfigVersions = &three
+
+		assert.Equal(t, map[string]bool{
+			"test-collector-v3":     true,
+			"test-collector-1-v3":   true,
+			"test-collector-v2":     true,
+			"test-collector-gpu-g1": true,
+		}, retainedConfigMaps(instance, expected, existing))
+	})
+
+	t.Run("should retain only the current version", func(t *testing.T) {
+		instance := params().Instance
+		one := int32(1)
+		instance.Spec.ConfigVersions = &one
+
+		assert.Empty(t, retainedConfigMaps(instance, expected, existing))
+	})
+}
+
+func TestDesiredNodeProfileConfigMaps(t *testing.T) {
+	t.Run("should return no config map outside of the daemonset mode", func(t *testing.T) {
+		param := params()
+		param.Instance.Spec.NodeProfiles = []v1alpha1.NodeProfile{{Name: "gpu", NodeSelector: map[string]string{"node-pool": "gpu"}}}
+
+		desired, err := desiredNodeProfileConfigMaps(context.Background(), param)
+		assert.NoError(t, err)
+		assert.Empty(t, desired)
+	})
+
+	t.Run("should return the merged config of each node profile", func(t *testing.T) {
+		param := params()
+		param.Instance.Spec.Mode = v1alpha1.ModeDaemonSet
+		param.Instance.Spec.NodeProfiles = []v1alpha1.NodeProfile{{
+			Name:         "gpu",
+			NodeSelector: map[string]string{"node-pool": "gpu"},
+			Config:       "processors:\n  memory_limiter:\n    limit_mib: 200\n",
+		}}
+
+		desired, err := desiredNodeProfileConfigMaps(context.Background(), param)
+		assert.NoError(t, err)
+		assert.Len(t, desired, 1)
+		assert.Equal(t, "test-collector-gpu", desired[0].Name)
+		assert.Equal(t, "gpu", desired[0].Labels[collector.NodeProfileLabel])
+		assert.Contains(t, desired[0].Data["collector.yaml"], "limit_mib: 200")
+		assert.Contains(t, desired[0].Data["collector.yaml"], "prometheus:")
+	})
 }
 
 func TestExpectedConfigMap(t *testing.T) {
@@ -390,7 +661,7 @@ func TestExpectedConfigMap(t *testing.T) {
 		promConfig, err := ta.ConfigToPromConfig(params().Instance.Spec.Config)
 		assert.NoError(t, err)
 
-		taConfig := make(map[interface{}]interface{})
+		taConfig := make(map[string]interface{})
 		taConfig["label_selector"] = map[string]string{
 			"app.kubernetes.io/instance":   "default.test",
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// ValidateConfig validates the rendered configuration of the given instance with the validate command of its collector
+// image, and returns whether it succeeded. The job validating the configuration is created when the configuration or
+// the image changes, along with the config maps holding the configuration, and the jobs and config maps of the
+// previous configurations are deleted. The jobs failing or timing out without validating the configuration are
+// re-created. The ConfigValidated condition and the digest of the validated configuration are patched on the status of
+// the given instance. The configurations of the instances without config validation are always considered valid.
+func ValidateConfig(ctx context.Context, params Params, instance *v1alpha1.OpenTelemetryCollector) (bool, error) {
+	if !collector.ConfigValidationEnabled(params.Config, *instance) {
+		// the jobs are only left over by the instances which validated their configurations before
+		if instance.Status.ValidatedConfig == "" && meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeConfigValidated) == nil {
+			return true, nil
+		}
+		if err := deleteConfigValidationJobs(ctx, params, *instance, ""); err != nil {
+			return true, err
+		}
+		return true, deleteConfigValidationConfigMaps(ctx, params, *instance, nil)
+	}
+
+	config, err := ReplaceConfig(params.Config, *instance)
+	if err != nil {
+		params.Log.V(2).Info("failed to update prometheus config to use sharded targets: ", "err", err)
+	}
+	digest := collector.ConfigValidationDigest(params.Config, *instance, config)
+
+	desired := collector.ConfigValidationJob(params.Config, params.Log, *instance, digest)
+	configMaps, err := collector.ConfigValidationConfigMaps(params.Config, *instance, config, digest)
+	if err != nil {
+		return false, fmt.Errorf("failed to build the config maps of the configuration validation: %w", err)
+	}
+	if err := deleteConfigValidationJobs(ctx, params, *instance, desired.Name); err != nil {
+		return false, err
+	}
+	if err := deleteConfigValidationConfigMaps(ctx, params, *instance, configMaps); err != nil {
+		return false, err
+	}
+	if instance.Status.ValidatedConfig == digest {
+		return true, nil
+	}
+
+	if err := expectedConfigValidationConfigMaps(ctx, params, instance, configMaps); err != nil {
+		return false, err
+	}
+	job, err := expectedConfigValidationJob(ctx, params, instance, desired)
+	if err != nil {
+		return false, err
+	}
+
+	var pods []corev1.Pod
+	if job != nil {
+		list := &corev1.PodList{}
+		opts := []client.ListOption{
+			client.InNamespace(job.Namespace),
+			client.MatchingLabels{"job-name": job.Name},
+		}
+		if err := params.Client.List(ctx, list, opts...); err != nil {
+			return false, fmt.Errorf("failed to list the pods of the job %s: %w", job.Name, err)
+		}
+		pods = list.Items
+	}
+
+	changed := instance.DeepCopy()
+	condition, retry := collector.ConfigValidationCondition(*instance, job, pods, time.Now())
+	if retry {
+		// the deletion of the job triggers the reconciliation re-creating it, the config maps are kept
+		if err := deleteConfigValidationJobs(ctx, params, *instance, ""); err != nil {
+			return false, err
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	validated := condition.Status == metav1.ConditionTrue
+	if validated {
+		changed.Status.ValidatedConfig = digest
+	}
+	if err := params.Client.Status().Patch(ctx, changed, client.MergeFrom(instance)); err != nil {
+		return false, fmt.Errorf("failed to apply the config validation status to the OpenTelemetry CR: %w", err)
+	}
+	// the spec of the given instance is kept, it may have been rendered from other objects than the instance
+	instance.ObjectMeta = changed.ObjectMeta
+	instance.Status = changed.Status
+	return validated, nil
+}
+
+// expectedConfigValidationConfigMaps creates the config maps holding the configuration to validate which don't exist.
+// Their content only depends on their names, which are derived from the digest of the configuration, so the existing
+// ones aren't updated.
+func expectedConfigValidationConfigMaps(ctx context.Context, params Params, instance *v1alpha1.OpenTelemetryCollector, expected []corev1.ConfigMap) error {
+	for i := range expected {
+		desired := expected[i]
+		existing := &corev1.ConfigMap{}
+		err := params.Client.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+		if err == nil {
+			continue
+		}
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		if err := controllerutil.SetControllerReference(instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		// the cache may not have caught up with a config map created by a previous reconciliation
+		if err := params.Client.Create(ctx, &desired); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "configmap.name", desired.Name, "configmap.namespace", desired.Namespace)
+	}
+	return nil
+}
+
+// expectedConfigValidationJob returns the existing job validating the configuration, and creates it when it doesn't
+// exist, in which case nil is returned.
+func expectedConfigValidationJob(ctx context.Context, params Params, instance *v1alpha1.OpenTelemetryCollector, desired batchv1.Job) (*batchv1.Job, error) {
+	existing := &batchv1.Job{}
+	err := params.Client.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if err == nil && existing.DeletionTimestamp == nil {
+		return existing, nil
+	}
+	if err == nil {
+		// a re-created job waits for the deletion of the previous one
+		return nil, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(instance, &desired, params.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	// the cache may not have caught up with a job created by a previous reconciliation
+	if err := params.Client.Create(ctx, &desired); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create: %w", err)
+	}
+	params.Log.V(2).Info("created", "job.name", desired.Name, "job.namespace", desired.Namespace)
+	return nil, nil
+}
+
+// deleteConfigValidationJobs deletes the jobs validating the configuration of the instance, except the given one.
+func deleteConfigValidationJobs(ctx context.Context, params Params, instance v1alpha1.OpenTelemetryCollector, keep string) error {
+	opts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(collector.ConfigValidationLabels(instance)),
+	}
+	list := &batchv1.JobList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		if existing.Name == keep || existing.DeletionTimestamp != nil {
+			continue
+		}
+		// the pods of the jobs are deleted along with them
+		if err := params.Client.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "job.name", existing.Name, "job.namespace", existing.Namespace)
+	}
+	return nil
+}
+
+// deleteConfigValidationConfigMaps deletes the config maps holding the configurations validated for the instance,
+// except the expected ones.
+func deleteConfigValidationConfigMaps(ctx context.Context, params Params, instance v1alpha1.OpenTelemetryCollector, expected []corev1.ConfigMap) error {
+	opts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(collector.ConfigValidationLabels(instance)),
+	}
+	list := &corev1.ConfigMapList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name {
+				del = false
+				break
+			}
+		}
+		if !del {
+			continue
+		}
+		if err := params.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "configmap.name", existing.Name, "configmap.namespace", existing.Namespace)
+	}
+	return nil
+}
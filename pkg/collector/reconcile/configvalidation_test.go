@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestValidateConfig(t *testing.T) {
+	params := params()
+	params.Instance.Name = "test-validation"
+	params.Instance.Spec.ConfigValidation = &v1alpha1.ConfigValidationSpec{}
+	instance := params.Instance
+	createObjectIfNotExists(t, "test-validation", &instance)
+	params.Instance = instance
+
+	listJobs := func(t *testing.T) []batchv1.Job {
+		list := &batchv1.JobList{}
+		require.NoError(t, k8sClient.List(context.Background(), list, client.InNamespace("default"), client.MatchingLabels(collector.ConfigValidationLabels(instance))))
+		return list.Items
+	}
+
+	t.Run("should create the validation job", func(t *testing.T) {
+		validated, err := ValidateConfig(context.Background(), params, &instance)
+		assert.NoError(t, err)
+		assert.False(t, validated)
+
+		jobs := listJobs(t)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, []string{"validate", "--config=/conf/collector.yaml"}, jobs[0].Spec.Template.Spec.Containers[0].Args)
+
+		configMap := corev1.ConfigMap{}
+		exists, err := populateObjectIfExists(t, &configMap, types.NamespacedName{Namespace: "default", Name: jobs[0].Name})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.NotEmpty(t, configMap.Data["collector.yaml"])
+
+		condition := meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeConfigValidated)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+		assert.Empty(t, instance.Status.ValidatedConfig)
+	})
+
+	t.Run("should record the validated configuration once the job completes", func(t *testing.T) {
+		job := listJobs(t)[0]
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, k8sClient.Status().Update(context.Background(), &job))
+
+		validated, err := ValidateConfig(context.Background(), params, &instance)
+		assert.NoError(t, err)
+		assert.True(t, validated)
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-validation"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.NotEmpty(t, actual.Status.ValidatedConfig)
+		assert.True(t, meta.IsStatusConditionTrue(actual.Status.Conditions, collector.ConditionTypeConfigValidated))
+	})
+
+	t.Run("should replace the job when the configuration changes", func(t *testing.T) {
+		previous := listJobs(t)[0].Name
+		instance.Spec.Config = "receivers:\n  otlp:\n    protocols:\n      grpc:\n"
+
+		validated, err := ValidateConfig(context.Background(), params, &instance)
+		assert.NoError(t, err)
+		assert.False(t, validated)
+
+		var names []string
+		for _, job := range listJobs(t) {
+			if job.DeletionTimestamp == nil {
+				names = append(names, job.Name)
+			}
+		}
+		require.Len(t, names, 1)
+		assert.NotEqual(t, previous, names[0])
+
+		exists, err := populateObjectIfExists(t, &corev1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: previous})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should re-create the job when it fails without validating the configuration", func(t *testing.T) {
+		job := listJobs(t)[0]
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}}
+		require.NoError(t, k8sClient.Status().Update(context.Background(), &job))
+
+		validated, err := ValidateConfig(context.Background(), params, &instance)
+		assert.NoError(t, err)
+		assert.False(t, validated)
+
+		condition := meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeConfigValidated)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+		for _, j := range listJobs(t) {
+			assert.NotNil(t, j.DeletionTimestamp)
+		}
+
+		// the config maps are kept for the re-created job
+		exists, err := populateObjectIfExists(t, &corev1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: job.Name})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
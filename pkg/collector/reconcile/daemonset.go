@@ -19,23 +19,20 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 // +kubebuilder:rbac:groups="apps",resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 
 // DaemonSets reconciles the daemon set(s) required for the instance in the current context.
 func DaemonSets(ctx context.Context, params Params) error {
-	desired := []appsv1.DaemonSet{}
-	if params.Instance.Spec.Mode == "daemonset" {
-		desired = append(desired, collector.DaemonSet(params.Config, params.Log, params.Instance))
-	}
+	desired := desiredDaemonSets(params)
 
 	// first, handle the create/update parts
 	if err := expectedDaemonSets(ctx, params, desired); err != nil {
@@ -50,6 +47,18 @@ func DaemonSets(ctx context.Context, params Params) error {
 	return nil
 }
 
+func desiredDaemonSets(params Params) []appsv1.DaemonSet {
+	desired := []appsv1.DaemonSet{}
+	// a DaemonSet can't be scaled to zero, so the one of a hibernated instance is removed instead
+	if params.Instance.Spec.Mode == "daemonset" && !collector.Hibernated(params.Instance) {
+		desired = append(desired, collector.DaemonSets(params.Config, params.Log, params.Instance)...)
+	}
+	for i := range desired {
+		collector.WithProvenance(params.Config, &desired[i].ObjectMeta, &desired[i].Spec.Template)
+	}
+	return desired
+}
+
 func expectedDaemonSets(ctx context.Context, params Params, expected []appsv1.DaemonSet) error {
 	for _, obj := range expected {
 		desired := obj
@@ -72,7 +81,9 @@ func expectedDaemonSets(ctx context.Context, params Params, expected []appsv1.Da
 		}
 
 		// Selector is an immutable field, if set, we cannot modify it otherwise we will face reconciliation error.
-		if !apiequality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		// The existing selector is kept when possible, so that changing the labels doesn't cause any downtime.
+		selector, adopted := adoptSelector(desired.Spec.Selector, &desired.Spec.Template, existing.Spec.Selector)
+		if !adopted {
 			params.Log.V(2).Info("Spec.Selector change detected, trying to delete, the new collector daemonset will be created in the next reconcile cycle", "daemonset.name", existing.Name, "daemonset.namespace", existing.Namespace)
 
 			if err := params.Client.Delete(ctx, existing); err != nil {
@@ -80,6 +91,10 @@ func expectedDaemonSets(ctx context.Context, params Params, expected []appsv1.Da
 			}
 			continue
 		}
+		if selector != desired.Spec.Selector {
+			params.Log.V(2).Info("Spec.Selector change detected, keeping the existing selector", "daemonset.name", existing.Name, "daemonset.namespace", existing.Namespace)
+			desired.Spec.Selector = selector
+		}
 
 		// it exists already, merge the two if the end result isn't identical to the existing one
 		updated := existing.DeepCopy()
@@ -115,7 +130,7 @@ func deleteDaemonSets(ctx context.Context, params Params, expected []appsv1.Daem
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
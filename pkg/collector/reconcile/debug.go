@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods/ephemeralcontainers,verbs=update;patch
+
+// DebugContainers attaches the ephemeral debug container to the running collector pods of the instance in the current
+// context when it has the debug annotation. Ephemeral containers can't be removed, they're gone once the pods are
+// replaced.
+func DebugContainers(ctx context.Context, params Params) error {
+	container, ok := collector.DebugContainer(params.Config, params.Instance)
+	if !ok {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(collector.SelectorLabels(params.Instance)),
+	}
+	if err := params.Client.List(ctx, pods, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || hasEphemeralContainer(*pod, container.Name) {
+			continue
+		}
+
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+		if err := params.Client.SubResource("ephemeralcontainers").Update(ctx, pod); err != nil {
+			return fmt.Errorf("failed to attach the debug container to pod %s: %w", pod.Name, err)
+		}
+		params.Log.V(2).Info("attached debug container", "pod.name", pod.Name, "pod.namespace", pod.Namespace)
+	}
+
+	return nil
+}
+
+func hasEphemeralContainer(pod corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
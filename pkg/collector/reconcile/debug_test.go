@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestDebugContainers(t *testing.T) {
+	params := params()
+	params.Instance.Annotations = map[string]string{collector.DebugAnnotation: "true"}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-debug-collector",
+			Namespace: params.Instance.Namespace,
+			Labels:    collector.SelectorLabels(params.Instance),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "otc-container", Image: "otel/opentelemetry-collector"}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &pod))
+	pod.Status.Phase = corev1.PodRunning
+	require.NoError(t, k8sClient.Status().Update(context.Background(), &pod))
+
+	t.Run("should attach the debug container", func(t *testing.T) {
+		err := DebugContainers(context.Background(), params)
+		assert.NoError(t, err)
+
+		actual := corev1.Pod{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		require.Len(t, actual.Spec.EphemeralContainers, 1)
+		assert.Equal(t, "otc-debug", actual.Spec.EphemeralContainers[0].Name)
+	})
+
+	t.Run("should not attach the debug container twice", func(t *testing.T) {
+		err := DebugContainers(context.Background(), params)
+		assert.NoError(t, err)
+
+		actual := corev1.Pod{}
+		_, err = populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+		assert.NoError(t, err)
+		assert.Len(t, actual.Spec.EphemeralContainers, 1)
+	})
+}
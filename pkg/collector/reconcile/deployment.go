@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +26,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
@@ -34,13 +34,12 @@ import (
 
 // Deployments reconciles the deployment(s) required for the instance in the current context.
 func Deployments(ctx context.Context, params Params) error {
-	desired := []appsv1.Deployment{}
-	if params.Instance.Spec.Mode == "deployment" {
-		desired = append(desired, collector.Deployment(params.Config, params.Log, params.Instance))
-	}
-
-	if params.Instance.Spec.TargetAllocator.Enabled {
-		desired = append(desired, targetallocator.Deployment(params.Config, params.Log, params.Instance))
+	desired := desiredDeployments(params)
+	if collector.BlueGreen(params.Instance) {
+		var err error
+		if desired, err = blueGreenDeployments(ctx, params, desired); err != nil {
+			return fmt.Errorf("failed to reconcile the blue/green deployments: %w", err)
+		}
 	}
 
 	// first, handle the create/update parts
@@ -56,6 +55,21 @@ func Deployments(ctx context.Context, params Params) error {
 	return nil
 }
 
+func desiredDeployments(params Params) []appsv1.Deployment {
+	desired := []appsv1.Deployment{}
+	if params.Instance.Spec.Mode == "deployment" {
+		desired = append(desired, collector.Deployment(params.Config, params.Log, params.Instance))
+	}
+
+	if params.Instance.Spec.TargetAllocator.Enabled {
+		desired = append(desired, targetallocator.Deployments(params.Config, params.Log, params.Instance)...)
+	}
+	for i := range desired {
+		collector.WithProvenance(params.Config, &desired[i].ObjectMeta, &desired[i].Spec.Template)
+	}
+	return desired
+}
+
 func expectedDeployments(ctx context.Context, params Params, expected []appsv1.Deployment) error {
 	for _, obj := range expected {
 		desired := obj
@@ -78,7 +92,9 @@ func expectedDeployments(ctx context.Context, params Params, expected []appsv1.D
 		}
 
 		// Selector is an immutable field, if set, we cannot modify it otherwise we will face reconciliation error.
-		if !apiequality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		// The existing selector is kept when possible, so that changing the labels doesn't cause any downtime.
+		selector, adopted := adoptSelector(desired.Spec.Selector, &desired.Spec.Template, existing.Spec.Selector)
+		if !adopted {
 			params.Log.V(2).Info("Spec.Selector change detected, trying to delete, the new collector deployment will be created in the next reconcile cycle ", "deployment.name", existing.Name, "deployment.namespace", existing.Namespace)
 
 			if err := params.Client.Delete(ctx, existing); err != nil {
@@ -86,6 +102,10 @@ func expectedDeployments(ctx context.Context, params Params, expected []appsv1.D
 			}
 			continue
 		}
+		if selector != desired.Spec.Selector {
+			params.Log.V(2).Info("Spec.Selector change detected, keeping the existing selector", "deployment.name", existing.Name, "deployment.namespace", existing.Namespace)
+			desired.Spec.Selector = selector
+		}
 
 		// it exists already, merge the two if the end result isn't identical to the existing one
 		updated := existing.DeepCopy()
@@ -122,7 +142,7 @@ func deleteDeployments(ctx context.Context, params Params, expected []appsv1.Dep
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -385,3 +385,17 @@ func TestCurrentReplicasWithHPA(t *testing.T) {
 	res = currentReplicasWithHPA(spec, 3)
 	assert.Equal(t, int32(3), res)
 }
+
+func TestDesiredDeploymentsProvenance(t *testing.T) {
+	param := params()
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.TargetAllocator.Enabled = true
+
+	desired := desiredDeployments(param)
+
+	assert.Len(t, desired, 2)
+	for _, deployment := range desired {
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, container.Name+"="+container.Image, deployment.Spec.Template.Annotations[collector.ImagesAnnotation])
+	}
+}
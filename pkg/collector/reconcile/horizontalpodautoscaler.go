@@ -28,21 +28,20 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
 // HorizontalPodAutoscaler reconciles HorizontalPodAutoscalers if autoscale is true and replicas is nil.
 func HorizontalPodAutoscalers(ctx context.Context, params Params) error {
-	desired := []client.Object{}
-
-	// check if autoscale mode is on, e.g MaxReplicas is not nil
-	if params.Instance.Spec.MaxReplicas != nil || (params.Instance.Spec.Autoscaler != nil && params.Instance.Spec.Autoscaler.MaxReplicas != nil) {
-		if newcol := collector.HorizontalPodAutoscaler(params.Config, params.Log, params.Instance); newcol != nil {
-			desired = append(desired, newcol)
-		}
+	// the autoscaling API isn't served in a supported version, or the operator isn't allowed to manage it
+	if params.Config.AutoscalingVersion() == autodetect.AutoscalingVersionUnknown {
+		return nil
 	}
 
+	desired := desiredHorizontalPodAutoscalers(params)
+
 	// first, handle the create/update parts
 	if err := expectedHorizontalPodAutoscalers(ctx, params, desired); err != nil {
 		return fmt.Errorf("failed to reconcile the expected horizontal pod autoscalers: %w", err)
@@ -56,6 +55,23 @@ func HorizontalPodAutoscalers(ctx context.Context, params Params) error {
 	return nil
 }
 
+func desiredHorizontalPodAutoscalers(params Params) []client.Object {
+	desired := []client.Object{}
+
+	// the autoscaler of a hibernated instance would scale its workload back up
+	if collector.Hibernated(params.Instance) {
+		return desired
+	}
+
+	// check if autoscale mode is on, e.g MaxReplicas is not nil
+	if params.Instance.Spec.MaxReplicas != nil || (params.Instance.Spec.Autoscaler != nil && params.Instance.Spec.Autoscaler.MaxReplicas != nil) {
+		if newcol := collector.HorizontalPodAutoscaler(params.Config, params.Log, params.Instance); newcol != nil {
+			desired = append(desired, newcol)
+		}
+	}
+	return desired
+}
+
 func expectedHorizontalPodAutoscalers(ctx context.Context, params Params, expected []client.Object) error {
 	autoscalingVersion := params.Config.AutoscalingVersion()
 	var existing client.Object
@@ -146,7 +162,7 @@ func deleteHorizontalPodAutoscalers(ctx context.Context, params Params, expected
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -223,8 +223,12 @@ func paramsWithHPA(autoscalingVersion autodetect.AutoscalingVersion) Params {
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (autodetect.OpenShiftRoutesAvailability, error)
-	HPAVersionFunc                  func() (autodetect.AutoscalingVersion, error)
+	OpenShiftRoutesAvailabilityFunc        func() (autodetect.OpenShiftRoutesAvailability, error)
+	HPAVersionFunc                         func() (autodetect.AutoscalingVersion, error)
+	VerticalPodAutoscalersAvailabilityFunc func() (autodetect.VerticalPodAutoscalersAvailability, error)
+	IstioAvailabilityFunc                  func() (autodetect.IstioAvailability, error)
+	PodMonitorsAvailabilityFunc            func() (autodetect.PodMonitorsAvailability, error)
+	PermissionsFunc                        func() (autodetect.Permissions, error)
 }
 
 func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
@@ -237,3 +241,31 @@ func (m *mockAutoDetect) OpenShiftRoutesAvailability() (autodetect.OpenShiftRout
 	}
 	return autodetect.OpenShiftRoutesNotAvailable, nil
 }
+
+func (m *mockAutoDetect) VerticalPodAutoscalersAvailability() (autodetect.VerticalPodAutoscalersAvailability, error) {
+	if m.VerticalPodAutoscalersAvailabilityFunc != nil {
+		return m.VerticalPodAutoscalersAvailabilityFunc()
+	}
+	return autodetect.VerticalPodAutoscalersNotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (autodetect.IstioAvailability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return autodetect.IstioNotAvailable, nil
+}
+
+func (m *mockAutoDetect) PodMonitorsAvailability() (autodetect.PodMonitorsAvailability, error) {
+	if m.PodMonitorsAvailabilityFunc != nil {
+		return m.PodMonitorsAvailabilityFunc()
+	}
+	return autodetect.PodMonitorsNotAvailable, nil
+}
+
+func (m *mockAutoDetect) Permissions() (autodetect.Permissions, error) {
+	if m.PermissionsFunc != nil {
+		return m.PermissionsFunc()
+	}
+	return autodetect.Permissions{}, nil
+}
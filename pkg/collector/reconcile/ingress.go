@@ -17,6 +17,7 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -31,7 +32,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
-func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
+func desiredIngresses(_ context.Context, params Params) []networkingv1.Ingress {
 	if params.Instance.Spec.Ingress.Type != v1alpha1.IngressTypeNginx {
 		return nil
 	}
@@ -48,11 +49,46 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 		return nil
 	}
 
-	pathType := networkingv1.PathTypePrefix
-	paths := make([]networkingv1.HTTPIngressPath, len(ports))
-	for i, p := range ports {
-		paths[i] = networkingv1.HTTPIngressPath{
-			Path:     "/" + p.Name,
+	overrides := map[string]v1alpha1.IngressPort{}
+	for _, override := range params.Instance.Spec.Ingress.Ports {
+		overrides[override.Name] = override
+	}
+
+	grpcAnnotations, _ := adapters.GRPCIngressAnnotations(adapters.IngressClass(params.Instance.Spec.Ingress.IngressClassName, params.Instance.Spec.Ingress.Annotations))
+
+	// the ports with annotations of their own are exposed by an ingress of their own, and the gRPC ports, which need the
+	// annotations routing the requests to gRPC backends, by an ingress per host, the other ones share the ingress of
+	// the instance
+	shared := &ingressRules{}
+	grpcRules := &ingressRules{}
+	var own []networkingv1.Ingress
+	exposed := map[hostPath]string{}
+	for _, p := range ports {
+		override := overrides[p.Name]
+		grpc := adapters.IsGRPCPort(p)
+		host := params.Instance.Spec.Ingress.Hostname
+		if len(override.Hostname) > 0 {
+			host = override.Hostname
+		}
+		path := "/" + p.Name
+		if grpc {
+			// the paths of the gRPC requests are the names of the methods, which can't be prefixed
+			path = "/"
+		}
+		if len(override.Path) > 0 {
+			path = override.Path
+		}
+
+		// the controllers reject or arbitrarily pick one of the ingresses routing the same path of a host
+		if other, ok := exposed[hostPath{host, path}]; ok {
+			params.Log.Info("the port is exposed by the same host and path as another port, skipping it", "port", p.Name, "other", other, "host", host, "path", path)
+			continue
+		}
+		exposed[hostPath{host, path}] = p.Name
+
+		pathType := networkingv1.PathTypePrefix
+		ingressPath := networkingv1.HTTPIngressPath{
+			Path:     path,
 			PathType: &pathType,
 			Backend: networkingv1.IngressBackend{
 				Service: &networkingv1.IngressServiceBackend{
@@ -64,36 +100,136 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 				},
 			},
 		}
+
+		switch {
+		case len(override.Annotations) > 0:
+			rules := &ingressRules{}
+			rules.add(host, ingressPath)
+			annotations := mergeAnnotations(params.Instance.Spec.Ingress.Annotations)
+			if grpc {
+				annotations = mergeAnnotations(annotations, grpcAnnotations)
+			}
+			annotations = mergeAnnotations(annotations, override.Annotations)
+			own = append(own, newIngress(params, naming.PortIngress(params.Instance, p.Name), annotations, rules.rules(), tlsForHost(params.Instance.Spec.Ingress.TLS, host)))
+		case grpc && len(grpcAnnotations) > 0:
+			grpcRules.add(host, ingressPath)
+		default:
+			shared.add(host, ingressPath)
+		}
+	}
+
+	var ingresses []networkingv1.Ingress
+	if len(shared.hosts) > 0 {
+		ingresses = append(ingresses, newIngress(params, naming.Ingress(params.Instance), params.Instance.Spec.Ingress.Annotations, shared.rules(), params.Instance.Spec.Ingress.TLS))
+	}
+	annotations := mergeAnnotations(params.Instance.Spec.Ingress.Annotations, grpcAnnotations)
+	for i, rule := range grpcRules.rules() {
+		ingresses = append(ingresses, newIngress(params, naming.GRPCIngress(params.Instance, grpcRules.hosts[i]), annotations, []networkingv1.IngressRule{rule}, tlsForHost(params.Instance.Spec.Ingress.TLS, grpcRules.hosts[i])))
+	}
+	return append(ingresses, own...)
+}
+
+// hostPath is a path of a host exposed by the ingresses.
+type hostPath struct {
+	host string
+	path string
+}
+
+// mergeAnnotations returns the given annotations merged in a new map, the ones of the last maps taking precedence.
+func mergeAnnotations(annotations ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, a := range annotations {
+		for k, v := range a {
+			merged[k] = v
+		}
 	}
+	return merged
+}
 
-	return &networkingv1.Ingress{
+func newIngress(params Params, name string, annotations map[string]string, rules []networkingv1.IngressRule, tls []networkingv1.IngressTLS) networkingv1.Ingress {
+	return networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Ingress(params.Instance),
+			Name:        name,
 			Namespace:   params.Instance.Namespace,
-			Annotations: params.Instance.Spec.Ingress.Annotations,
+			Annotations: annotations,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       naming.Ingress(params.Instance),
-				"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+				"app.kubernetes.io/name":       name,
+				"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 				"app.kubernetes.io/managed-by": "opentelemetry-operator",
 			},
 		},
 		Spec: networkingv1.IngressSpec{
-			TLS: params.Instance.Spec.Ingress.TLS,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: params.Instance.Spec.Ingress.Hostname,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: paths,
-						},
-					},
-				},
-			},
+			TLS:              tls,
+			Rules:            rules,
 			IngressClassName: params.Instance.Spec.Ingress.IngressClassName,
 		},
 	}
 }
 
+// ingressRules holds the paths of the rules of an ingress, by host, in the order of the hosts.
+type ingressRules struct {
+	hosts []string
+	paths map[string][]networkingv1.HTTPIngressPath
+}
+
+func (r *ingressRules) add(host string, path networkingv1.HTTPIngressPath) {
+	if r.paths == nil {
+		r.paths = map[string][]networkingv1.HTTPIngressPath{}
+	}
+	if _, ok := r.paths[host]; !ok {
+		r.hosts = append(r.hosts, host)
+	}
+	r.paths[host] = append(r.paths[host], path)
+}
+
+func (r *ingressRules) rules() []networkingv1.IngressRule {
+	rules := make([]networkingv1.IngressRule, len(r.hosts))
+	for i, host := range r.hosts {
+		rules[i] = networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: r.paths[host],
+				},
+			},
+		}
+	}
+	return rules
+}
+
+// tlsForHost returns the TLS configurations of the host, i.e. the ones including the host, directly or through a
+// wildcard, and the ones without hosts.
+func tlsForHost(tls []networkingv1.IngressTLS, host string) []networkingv1.IngressTLS {
+	var matching []networkingv1.IngressTLS
+	for _, t := range tls {
+		if len(t.Hosts) == 0 {
+			matching = append(matching, t)
+			continue
+		}
+		for _, h := range t.Hosts {
+			if hostMatches(h, host) {
+				matching = append(matching, t)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+// hostMatches returns whether the host is matched by the pattern, which is either a host or a wildcard matching the
+// hosts of a single label, like *.example.com.
+func hostMatches(pattern string, host string) bool {
+	if pattern == host {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(pattern, "*")
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+	label, ok := strings.CutSuffix(host, suffix)
+	return ok && len(label) > 0 && !strings.Contains(label, ".")
+}
+
 // Ingresses reconciles the ingress(s) required for the instance in the current context.
 func Ingresses(ctx context.Context, params Params) error {
 	isSupportedMode := true
@@ -108,9 +244,7 @@ func Ingresses(ctx context.Context, params Params) error {
 
 	var desired []networkingv1.Ingress
 	if isSupportedMode && serviceExists {
-		if d := desiredIngresses(ctx, params); d != nil {
-			desired = append(desired, *d)
-		}
+		desired = desiredIngresses(ctx, params)
 	}
 
 	// first, handle the create/update parts
@@ -142,7 +276,7 @@ func expectedIngresses(ctx context.Context, params Params, expected []networking
 				return fmt.Errorf("failed to create: %w", err)
 			}
 			params.Log.V(2).Info("created", "ingress.name", desired.Name, "ingress.namespace", desired.Namespace)
-			return nil
+			continue
 		} else if clientGetErr != nil {
 			return fmt.Errorf("failed to get: %w", clientGetErr)
 		}
@@ -183,7 +317,7 @@ func deleteIngresses(ctx context.Context, params Params, expected []networkingv1
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -243,5 +377,15 @@ func servicePortsFromCfg(params Params) []corev1.ServicePort {
 
 		ports = append(params.Instance.Spec.Ports, resultingInferredPorts...)
 	}
-	return ports
+
+	// the ports of the other signals stay reachable from within the cluster only
+	var signals []string
+	for _, signal := range params.Instance.Spec.Ingress.Signals {
+		signals = append(signals, string(signal))
+	}
+	listed := map[string]bool{}
+	for _, port := range params.Instance.Spec.Ingress.Ports {
+		listed[port.Name] = true
+	}
+	return adapters.SignalPorts(params.Log, config, ports, signals, listed)
 }
@@ -111,6 +111,7 @@ func TestDesiredIngresses(t *testing.T) {
 		}
 
 		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 2)
 		pathType := networkingv1.PathTypePrefix
 
 		assert.NotEqual(t, &networkingv1.Ingress{
@@ -174,9 +175,284 @@ func TestDesiredIngresses(t *testing.T) {
 					},
 				},
 			},
-		}, got)
+		}, &got[0])
 	})
 
+	t.Run("should override the hosts and paths of the ports", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:     v1alpha1.IngressTypeNginx,
+			Hostname: "example.com",
+			Ports: []v1alpha1.IngressPort{
+				{Name: "otlp-grpc", Hostname: "otlp.example.com", Path: "/"},
+				{Name: "otlp-test-grpc", Path: "/test"},
+			},
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 1)
+		assert.Equal(t, naming.Ingress(params.Instance), got[0].Name)
+
+		rules := got[0].Spec.Rules
+		assert.Len(t, rules, 2)
+		assert.Equal(t, "example.com", rules[0].Host)
+		assert.Equal(t, []string{"/web", "/test"}, ingressPaths(rules[0]))
+		assert.Equal(t, "otlp.example.com", rules[1].Host)
+		assert.Equal(t, []string{"/"}, ingressPaths(rules[1]))
+		assert.Equal(t, "otlp-grpc", rules[1].HTTP.Paths[0].Backend.Service.Port.Name)
+	})
+
+	t.Run("should create an ingress for the ports with annotations", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:        v1alpha1.IngressTypeNginx,
+			Hostname:    "example.com",
+			Annotations: map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt", "some.key": "some.value"},
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "example"},
+				{Hosts: []string{"*.example.com"}, SecretName: "wildcard"},
+			},
+			Ports: []v1alpha1.IngressPort{
+				{
+					Name:        "otlp-grpc",
+					Hostname:    "otlp.example.com",
+					Path:        "/",
+					Annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC", "some.key": "other.value"},
+				},
+			},
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 2)
+
+		assert.Equal(t, naming.Ingress(params.Instance), got[0].Name)
+		assert.Equal(t, params.Instance.Spec.Ingress.Annotations, got[0].Annotations)
+		assert.Equal(t, params.Instance.Spec.Ingress.TLS, got[0].Spec.TLS)
+		assert.Equal(t, []string{"/web", "/"}, ingressPaths(got[0].Spec.Rules[0]))
+
+		assert.Equal(t, "test-otlp-grpc-ingress", got[1].Name)
+		assert.Equal(t, "test-otlp-grpc-ingress", got[1].Labels["app.kubernetes.io/name"])
+		assert.Equal(t, map[string]string{
+			"cert-manager.io/cluster-issuer":               "letsencrypt",
+			"nginx.ingress.kubernetes.io/backend-protocol": "GRPC",
+			"some.key": "other.value",
+		}, got[1].Annotations)
+		assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"*.example.com"}, SecretName: "wildcard"}}, got[1].Spec.TLS)
+		assert.Len(t, got[1].Spec.Rules, 1)
+		assert.Equal(t, "otlp.example.com", got[1].Spec.Rules[0].Host)
+		assert.Equal(t, []string{"/"}, ingressPaths(got[1].Spec.Rules[0]))
+	})
+
+	t.Run("should create an ingress per host for the gRPC ports", func(t *testing.T) {
+		nginx := "nginx"
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:             v1alpha1.IngressTypeNginx,
+			IngressClassName: &nginx,
+			Hostname:         "example.com",
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "example"},
+				{Hosts: []string{"*.example.com"}, SecretName: "wildcard"},
+			},
+			Ports: []v1alpha1.IngressPort{
+				{Name: "otlp-test-grpc", Hostname: "test.example.com"},
+			},
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 3)
+
+		assert.Equal(t, naming.Ingress(params.Instance), got[0].Name)
+		assert.Equal(t, []string{"/web"}, ingressPaths(got[0].Spec.Rules[0]))
+
+		assert.Equal(t, "test-example-com-grpc-ingress", got[1].Name)
+		assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "example"}}, got[1].Spec.TLS)
+		assert.Len(t, got[1].Spec.Rules, 1)
+		assert.Equal(t, "example.com", got[1].Spec.Rules[0].Host)
+		assert.Equal(t, []string{"/"}, ingressPaths(got[1].Spec.Rules[0]))
+		assert.Equal(t, "otlp-grpc", got[1].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+
+		assert.Equal(t, "test-test-example-com-grpc-ingress", got[2].Name)
+		assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"*.example.com"}, SecretName: "wildcard"}}, got[2].Spec.TLS)
+		assert.Equal(t, "test.example.com", got[2].Spec.Rules[0].Host)
+		assert.Equal(t, "otlp-test-grpc", got[2].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	})
+
+	t.Run("should skip the ports exposed by the path of another port", func(t *testing.T) {
+		nginx := "nginx"
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:             v1alpha1.IngressTypeNginx,
+			IngressClassName: &nginx,
+			Hostname:         "example.com",
+			Ports: []v1alpha1.IngressPort{
+				{Name: "web", Path: "/"},
+			},
+		}
+
+		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 1)
+		assert.Equal(t, naming.Ingress(params.Instance), got[0].Name)
+		assert.Equal(t, []string{"/"}, ingressPaths(got[0].Spec.Rules[0]))
+		assert.Equal(t, "web", got[0].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	})
+
+	t.Run("should only expose the ports of the signals", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:     v1alpha1.IngressTypeNginx,
+			Hostname: "example.com",
+			Signals:  []v1alpha1.IngressSignal{v1alpha1.IngressSignalTraces},
+			Ports:    []v1alpha1.IngressPort{{Name: "otlp-test-grpc", Hostname: "test.example.com"}},
+		}
+		got := desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0].Spec.Rules, 2)
+		assert.Equal(t, []string{"/"}, ingressPaths(got[0].Spec.Rules[0]))
+		assert.Equal(t, "otlp-grpc", got[0].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+		assert.Equal(t, "otlp-test-grpc", got[0].Spec.Rules[1].HTTP.Paths[0].Backend.Service.Port.Name)
+
+		params.Instance.Spec.Ingress.Signals = []v1alpha1.IngressSignal{v1alpha1.IngressSignalMetrics}
+		params.Instance.Spec.Ingress.Ports = []v1alpha1.IngressPort{{Name: "web"}}
+		got = desiredIngresses(context.Background(), params)
+		assert.Len(t, got, 1)
+		assert.Equal(t, naming.Ingress(params.Instance), got[0].Name)
+		assert.Equal(t, []string{"/web"}, ingressPaths(got[0].Spec.Rules[0]))
+	})
+
+	t.Run("should route the gRPC ports to gRPC backends", func(t *testing.T) {
+		nginx := "nginx-internal"
+		testHost := v1alpha1.IngressPort{Name: "otlp-test-grpc", Hostname: "test.example.com"}
+		for _, tt := range []struct {
+			desc        string
+			ingress     v1alpha1.Ingress
+			annotations map[string]string
+			ingresses   int
+		}{
+			{
+				desc:        "nginx",
+				ingress:     v1alpha1.Ingress{IngressClassName: &nginx, Ports: []v1alpha1.IngressPort{testHost}},
+				annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+				ingresses:   3,
+			},
+			{
+				desc: "alb",
+				ingress: v1alpha1.Ingress{
+					Annotations: map[string]string{"kubernetes.io/ingress.class": "alb"},
+					Ports:       []v1alpha1.IngressPort{testHost},
+				},
+				annotations: map[string]string{
+					"kubernetes.io/ingress.class":                        "alb",
+					"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC",
+				},
+				ingresses: 3,
+			},
+			{
+				desc: "overridden",
+				ingress: v1alpha1.Ingress{
+					IngressClassName: &nginx,
+					Ports: []v1alpha1.IngressPort{
+						{Name: "otlp-grpc", Annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPCS"}},
+						{Name: "otlp-test-grpc", Hostname: "test.example.com", Annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPCS"}},
+					},
+				},
+				annotations: map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPCS"},
+				ingresses:   3,
+			},
+			{
+				desc:      "unknown class",
+				ingress:   v1alpha1.Ingress{Ports: []v1alpha1.IngressPort{testHost}},
+				ingresses: 1,
+			},
+		} {
+			t.Run(tt.desc, func(t *testing.T) {
+				params, err := newParams("something:tag", testFileIngress)
+				if err != nil {
+					t.Fatal(err)
+				}
+				params.Instance.Spec.Ingress = tt.ingress
+				params.Instance.Spec.Ingress.Type = v1alpha1.IngressTypeNginx
+
+				got := desiredIngresses(context.Background(), params)
+				assert.Len(t, got, tt.ingresses)
+				grpcPorts := 0
+				for _, ingress := range got {
+					for _, rule := range ingress.Spec.Rules {
+						for _, path := range rule.HTTP.Paths {
+							if path.Backend.Service.Port.Name == "web" {
+								assert.Equal(t, "/web", path.Path)
+								assert.Equal(t, tt.ingress.Annotations, ingress.Annotations)
+								continue
+							}
+							grpcPorts++
+							assert.Equal(t, "/", path.Path)
+							if tt.annotations == nil {
+								assert.Empty(t, ingress.Annotations)
+							} else {
+								assert.Equal(t, tt.annotations, ingress.Annotations)
+							}
+						}
+					}
+				}
+				assert.Equal(t, 2, grpcPorts)
+			})
+		}
+	})
+}
+
+func TestTLSForHost(t *testing.T) {
+	tls := []networkingv1.IngressTLS{
+		{SecretName: "default"},
+		{Hosts: []string{"example.com"}, SecretName: "example"},
+		{Hosts: []string{"other.com", "*.example.com"}, SecretName: "wildcard"},
+	}
+
+	for _, tt := range []struct {
+		host     string
+		expected []string
+	}{
+		{host: "example.com", expected: []string{"default", "example"}},
+		{host: "otlp.example.com", expected: []string{"default", "wildcard"}},
+		{host: "otlp.eu.example.com", expected: []string{"default"}},
+		{host: "other.com", expected: []string{"default", "wildcard"}},
+		{host: "", expected: []string{"default"}},
+	} {
+		t.Run(tt.host, func(t *testing.T) {
+			var secrets []string
+			for _, t := range tlsForHost(tls, tt.host) {
+				secrets = append(secrets, t.SecretName)
+			}
+			assert.Equal(t, tt.expected, secrets)
+		})
+	}
+}
+
+func ingressPaths(rule networkingv1.IngressRule) []string {
+	var paths []string
+	for _, path := range rule.HTTP.Paths {
+		paths = append(paths, path.Path)
+	}
+	return paths
 }
 
 func TestExpectedIngresses(t *testing.T) {
@@ -189,7 +465,7 @@ func TestExpectedIngresses(t *testing.T) {
 		}
 		params.Instance.Spec.Ingress.Type = "ingress"
 
-		err = expectedIngresses(ctx, params, []networkingv1.Ingress{*desiredIngresses(ctx, params)})
+		err = expectedIngresses(ctx, params, desiredIngresses(ctx, params))
 		assert.NoError(t, err)
 
 		nns := types.NamespacedName{Namespace: "default", Name: "test-ingress"}
@@ -202,7 +478,7 @@ func TestExpectedIngresses(t *testing.T) {
 		params.Instance.Spec.Ingress.Annotations = map[string]string{"blub": "blob"}
 		params.Instance.Spec.Ingress.Hostname = expectHostname
 
-		err = expectedIngresses(ctx, params, []networkingv1.Ingress{*desiredIngresses(ctx, params)})
+		err = expectedIngresses(ctx, params, desiredIngresses(ctx, params))
 		assert.NoError(t, err)
 
 		got := &networkingv1.Ingress{}
@@ -231,7 +507,7 @@ func TestDeleteIngresses(t *testing.T) {
 		}
 		myParams.Instance.Spec.Ingress.Type = "ingress"
 
-		err = expectedIngresses(ctx, myParams, []networkingv1.Ingress{*desiredIngresses(ctx, myParams)})
+		err = expectedIngresses(ctx, myParams, desiredIngresses(ctx, myParams))
 		assert.NoError(t, err)
 
 		nns := types.NamespacedName{Namespace: "default", Name: "test-ingress"}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestDesiredIstioObjects(t *testing.T) {
+	t.Run("should not create istio objects without istio", func(t *testing.T) {
+		assert.Empty(t, desiredIstioObjects(params()))
+	})
+
+	t.Run("should create istio objects with istio", func(t *testing.T) {
+		p := paramsWithIstio()
+		p.Instance.Spec.Config = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: api.honeycomb.io:443
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+
+		actual := desiredIstioObjects(p)
+
+		require.Len(t, actual, 2)
+		assert.Equal(t, "ServiceEntry", actual[0].GetKind())
+		assert.Equal(t, "PeerAuthentication", actual[1].GetKind())
+
+		p.Instance.Spec.Istio.ScopeEgress = true
+		actual = desiredIstioObjects(p)
+
+		require.Len(t, actual, 3)
+		assert.Equal(t, "ServiceEntry", actual[0].GetKind())
+		assert.Equal(t, "test-collector-egress", actual[0].GetName())
+		assert.Equal(t, "Sidecar", actual[1].GetKind())
+		assert.Equal(t, "test-collector", actual[1].GetName())
+		assert.Equal(t, "PeerAuthentication", actual[2].GetKind())
+		assert.Equal(t, "test-collector", actual[2].GetName())
+	})
+}
+
+func TestIstioObjectsNotAvailable(t *testing.T) {
+	// prepare
+	p := paramsWithIstio()
+	p.Client = nil
+
+	// test
+	err := IstioObjects(context.Background(), p)
+
+	// verify
+	assert.NoError(t, err)
+}
+
+func paramsWithIstio() Params {
+	p := params()
+	p.Instance.Spec.Istio = &v1alpha1.IstioSpec{}
+	return p
+}
@@ -18,22 +18,31 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
 // Self updates this instance's self data. This should be the last item in the reconciliation, as it causes changes
 // making params.Instance obsolete. Default values should be set in the Defaulter webhook, this should only be used
 // for the Status, which can't be set by the defaulter.
 func Self(ctx context.Context, params Params) error {
-	changed := params.Instance
+	changed := *params.Instance.DeepCopy()
 
 	// this field is only changed for new instances: on existing instances this
 	// field is reconciled when the operator is first started, i.e. during
@@ -43,10 +52,64 @@ func Self(ctx context.Context, params Params) error {
 		changed.Status.Version = version.OpenTelemetryCollector()
 	}
 
+	changed.Status.TargetAllocatorVersion = ""
+	if v := targetallocator.Version(params.Config, changed); v != nil {
+		changed.Status.TargetAllocatorVersion = v.String()
+	}
+	if condition := targetallocator.CompatibleCondition(params.Config, changed); condition != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, targetallocator.ConditionTypeCompatible)
+	}
+
 	if err := updateScaleSubResourceStatus(ctx, params.Client, &changed); err != nil {
 		return fmt.Errorf("failed to update the scale subresource status for the OpenTelemetry CR: %w", err)
 	}
 
+	if err := updateHealthCondition(ctx, params.Config, params.Client, &changed); err != nil {
+		return fmt.Errorf("failed to update the health condition for the OpenTelemetry CR: %w", err)
+	}
+
+	if err := updateImagesStatus(ctx, params.Client, &changed); err != nil {
+		return fmt.Errorf("failed to update the images status for the OpenTelemetry CR: %w", err)
+	}
+	changed.Status.OperatorVersion = params.Config.OperatorVersion()
+
+	if condition := collector.PausedCondition(changed); condition != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypePaused)
+	}
+
+	if condition := collector.RollbackCondition(changed); condition != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeDegraded)
+	}
+
+	// the rollouts are recorded by the Deployments
+	if !collector.BlueGreen(changed) {
+		changed.Status.Rollout = nil
+	}
+
+	// the condition of the validated configurations is set by ValidateConfig
+	if !collector.ConfigValidationEnabled(params.Config, changed) {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeConfigValidated)
+		changed.Status.ValidatedConfig = ""
+	}
+
+	if condition := collector.LegacyReceiversCondition(changed); condition != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeLegacyReceivers)
+	}
+
+	if err := updateReceiverTLSCondition(ctx, params.apiReader(), &changed); err != nil {
+		return fmt.Errorf("failed to update the receiver TLS condition for the OpenTelemetry CR: %w", err)
+	}
+
+	updateTargetsAssignedCondition(ctx, params.Log, params.httpClient(), &changed)
+
 	statusPatch := client.MergeFrom(&params.Instance)
 	if err := params.Client.Status().Patch(ctx, &changed, statusPatch); err != nil {
 		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
@@ -66,8 +129,15 @@ func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, change
 
 	name := naming.Collector(*changed)
 
-	// Set the scale selector
+	// Set the scale selector, which selects the collectors of all the availability zones of topology-aware instances,
+	// and the ones of the active deployment of the blue/green rollouts
 	labels := collector.Labels(*changed, name, []string{})
+	if len(targetallocator.Zones(*changed)) > 0 {
+		labels = collector.SelectorLabels(*changed)
+	}
+	if collector.BlueGreen(*changed) {
+		labels[collector.RolloutColorLabel] = collector.ActiveColor(*changed)
+	}
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
 	if err != nil {
 		return fmt.Errorf("failed to get selector for labelSelector: %w", err)
@@ -77,7 +147,7 @@ func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, change
 	// Set the scale replicas
 	objKey := client.ObjectKey{
 		Namespace: changed.GetNamespace(),
-		Name:      naming.Collector(*changed),
+		Name:      collector.ActiveDeploymentName(*changed),
 	}
 
 	var replicas int32
@@ -90,13 +160,127 @@ func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, change
 		replicas = obj.Status.Replicas
 
 	case v1alpha1.ModeStatefulSet:
-		obj := &appsv1.StatefulSet{}
-		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get statefulSet status.replicas: %w", err)
+		for _, name := range collector.StatefulSetNames(*changed) {
+			obj := &appsv1.StatefulSet{}
+			if err := cli.Get(ctx, client.ObjectKey{Namespace: changed.GetNamespace(), Name: name}, obj); err != nil {
+				return fmt.Errorf("failed to get statefulSet status.replicas: %w", err)
+			}
+			replicas += obj.Status.Replicas
 		}
-		replicas = obj.Status.Replicas
 	}
 	changed.Status.Scale.Replicas = replicas
 
 	return nil
 }
+
+func updateHealthCondition(ctx context.Context, cfg config.Config, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+	// the pods of sidecars belong to the applications, and hibernated instances have no pods
+	if changed.Spec.Mode == v1alpha1.ModeSidecar || collector.Hibernated(*changed) {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeHealthy)
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(changed.Namespace),
+		client.MatchingLabels(collector.SelectorLabels(*changed)),
+	}
+	if err := cli.List(ctx, pods, opts...); err != nil {
+		return fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+
+	meta.SetStatusCondition(&changed.Status.Conditions, collector.Health(*changed, pods.Items))
+	// the configuration and image the healthy pods run are the ones the collector can be rolled back to
+	changed.Status.LastKnownGood = collector.LastKnownGood(cfg, *changed, pods.Items, metav1.Now())
+	return nil
+}
+
+// updateReceiverTLSCondition sets the ReceiverTLSReady condition out of the secrets of the receiver certificates, which
+// are read from the API server, as the operator doesn't cache the secrets of the cluster.
+func updateReceiverTLSCondition(ctx context.Context, reader client.Reader, changed *v1alpha1.OpenTelemetryCollector) error {
+	secrets := map[string]corev1.Secret{}
+	if changed.Spec.Mode != v1alpha1.ModeSidecar {
+		for _, tls := range changed.Spec.ReceiverTLS {
+			if _, ok := secrets[tls.SecretName]; ok {
+				continue
+			}
+			secret := corev1.Secret{}
+			err := reader.Get(ctx, client.ObjectKey{Namespace: changed.Namespace, Name: tls.SecretName}, &secret)
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get the secret %s: %w", tls.SecretName, err)
+			}
+			secrets[tls.SecretName] = secret
+		}
+	}
+
+	if condition := collector.ReceiverTLSCondition(*changed, secrets); condition != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeReceiverTLSReady)
+	}
+	return nil
+}
+
+// updateTargetsAssignedCondition sets the TargetsAssigned condition from the metrics of the TargetAllocators of the
+// instance. The condition is left as it is while a TargetAllocator can't be reached, e.g. while it starts.
+func updateTargetsAssignedCondition(ctx context.Context, logger logr.Logger, httpClient *http.Client, changed *v1alpha1.OpenTelemetryCollector) {
+	if !targetallocator.TargetsLimited(*changed) {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, targetallocator.ConditionTypeTargetsAssigned)
+		return
+	}
+
+	unassigned := 0
+	for _, url := range targetallocator.MetricsURLs(*changed) {
+		count, err := unassignedTargets(ctx, httpClient, url)
+		if err != nil {
+			logger.V(1).Info("couldn't get the unassigned targets of the TargetAllocator", "url", url, "error", err.Error())
+			return
+		}
+		unassigned += count
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, *targetallocator.TargetsAssignedCondition(*changed, unassigned))
+}
+
+// unassignedTargets gets the number of targets the TargetAllocator leaves unassigned from its metrics at the given URL.
+func unassignedTargets(ctx context.Context, httpClient *http.Client, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return targetallocator.UnassignedTargets(resp.Body)
+}
+
+// updateImagesStatus records the images run by the collector and TargetAllocator pods of the instance, along with the
+// digests they resolved to, for supply-chain audits of the instances.
+func updateImagesStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+	// the pods of sidecars belong to the applications
+	if changed.Spec.Mode == v1alpha1.ModeSidecar {
+		changed.Status.Images = nil
+		return nil
+	}
+
+	labels := collector.SelectorLabels(*changed)
+	delete(labels, "app.kubernetes.io/component")
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(changed.Namespace),
+		client.MatchingLabels(labels),
+	}
+	if err := cli.List(ctx, pods, opts...); err != nil {
+		return fmt.Errorf("failed to list the pods: %w", err)
+	}
+
+	changed.Status.Images = collector.Images(pods.Items)
+	return nil
+}
@@ -16,12 +16,23 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
 func TestSelf(t *testing.T) {
@@ -39,4 +50,122 @@ func TestSelf(t *testing.T) {
 		assert.Equal(t, actual.Status.Version, "0.0.0")
 
 	})
+
+	t.Run("should add the health condition to the status", func(t *testing.T) {
+		instance := params().Instance
+		createObjectIfNotExists(t, "test", &instance)
+		err := Self(context.Background(), params())
+		assert.NoError(t, err)
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// the test environment doesn't run the pods of the workloads
+		condition := meta.FindStatusCondition(actual.Status.Conditions, collector.ConditionTypeHealthy)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+		assert.Equal(t, collector.ReasonNoPods, condition.Reason)
+	})
+}
+
+func TestUpdateReceiverTLSCondition(t *testing.T) {
+	// prepare
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "otlp-cert", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": []byte("pem")},
+	}
+	reader := fake.NewClientBuilder().WithObjects(secret).Build()
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			ReceiverTLS: map[string]v1alpha1.ReceiverTLSSpec{
+				"otlp":   {SecretName: "otlp-cert"},
+				"zipkin": {SecretName: "zipkin-cert"},
+			},
+		},
+	}
+
+	// test
+	err := updateReceiverTLSCondition(context.Background(), reader, &instance)
+
+	// verify
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeReceiverTLSReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, collector.ReasonReceiverTLSSecretsInvalid, condition.Reason)
+	assert.Contains(t, condition.Message, "the secret otlp-cert of the receiver otlp has no tls.key key")
+	assert.Contains(t, condition.Message, "the secret zipkin-cert of the receiver zipkin doesn't exist")
+
+	// the condition is removed along with the receiver certificates
+	instance.Spec.ReceiverTLS = nil
+	require.NoError(t, updateReceiverTLSCondition(context.Background(), reader, &instance))
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeReceiverTLSReady))
+}
+
+// roundTripperFunc sends the requests of an HTTP client with the given function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestUpdateTargetsAssignedCondition(t *testing.T) {
+	// prepare
+	unassigned := map[string]int{"my-instance-targetallocator-0.default.svc": 0, "my-instance-targetallocator-1.default.svc": 5}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "# TYPE opentelemetry_allocator_targets_unassigned gauge\nopentelemetry_allocator_targets_unassigned{strategy=\"consistent-hashing\"} %d\n", unassigned[r.Host])
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	// the requests to the services of the TargetAllocators are sent to the test server, with their original host
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = serverURL.Scheme
+		req.URL.Host = serverURL.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	limit := int32(100)
+	shards := int32(2)
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "default"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeStatefulSet,
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:                true,
+				JobShards:              &shards,
+				MaxTargetsPerCollector: &limit,
+			},
+		},
+	}
+
+	// test
+	updateTargetsAssignedCondition(context.Background(), logger, httpClient, &instance)
+
+	// verify
+	condition := meta.FindStatusCondition(instance.Status.Conditions, targetallocator.ConditionTypeTargetsAssigned)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, targetallocator.ReasonTargetsUnassigned, condition.Reason)
+	assert.Contains(t, condition.Message, "leaves 5 targets unassigned")
+
+	// the condition is kept while a TargetAllocator can't be reached
+	unreachable := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})}
+	updateTargetsAssignedCondition(context.Background(), logger, unreachable, &instance)
+	assert.Equal(t, metav1.ConditionFalse, meta.FindStatusCondition(instance.Status.Conditions, targetallocator.ConditionTypeTargetsAssigned).Status)
+
+	// the condition is true once the targets are assigned
+	unassigned["my-instance-targetallocator-1.default.svc"] = 0
+	updateTargetsAssignedCondition(context.Background(), logger, httpClient, &instance)
+	assert.Equal(t, metav1.ConditionTrue, meta.FindStatusCondition(instance.Status.Conditions, targetallocator.ConditionTypeTargetsAssigned).Status)
+
+	// the condition is removed along with the limit
+	instance.Spec.TargetAllocator.MaxTargetsPerCollector = nil
+	updateTargetsAssignedCondition(context.Background(), logger, httpClient, &instance)
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, targetallocator.ConditionTypeTargetsAssigned))
 }
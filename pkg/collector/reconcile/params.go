@@ -15,6 +15,9 @@
 package reconcile
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -26,10 +29,29 @@ import (
 
 // Params holds the reconciliation-specific parameters.
 type Params struct {
-	Client   client.Client
-	Recorder record.EventRecorder
-	Scheme   *runtime.Scheme
-	Log      logr.Logger
-	Instance v1alpha1.OpenTelemetryCollector
-	Config   config.Config
+	Client client.Client
+	// APIReader reads the objects not managed by the operator, which are missing from the cache of the client. The
+	// client is used when it's not set.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Instance  v1alpha1.OpenTelemetryCollector
+	Config    config.Config
+	// HTTPClient gets the metrics of the TargetAllocators. A client with a short timeout is used when it's not set.
+	HTTPClient *http.Client
+}
+
+func (p Params) apiReader() client.Reader {
+	if p.APIReader == nil {
+		return p.Client
+	}
+	return p.APIReader
+}
+
+func (p Params) httpClient() *http.Client {
+	if p.HTTPClient == nil {
+		return &http.Client{Timeout: 5 * time.Second}
+	}
+	return p.HTTPClient
 }
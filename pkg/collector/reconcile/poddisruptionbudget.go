@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// PodDisruptionBudgets reconciles the pod disruption budget(s) required for the instance in the current context.
+func PodDisruptionBudgets(ctx context.Context, params Params) error {
+	desired := desiredPodDisruptionBudgets(params)
+
+	// first, handle the create/update parts
+	if err := expectedPodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected pod disruption budgets: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deletePodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the pod disruption budgets to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func desiredPodDisruptionBudgets(params Params) []policyv1.PodDisruptionBudget {
+	desired := []policyv1.PodDisruptionBudget{}
+	if pdb := collector.PodDisruptionBudget(params.Config, params.Instance); pdb != nil {
+		desired = append(desired, *pdb)
+	}
+	return desired
+}
+
+func expectedPodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &policyv1.PodDisruptionBudget{}
+		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "pdb.name", desired.Name, "pdb.namespace", desired.Namespace)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one
+		updated := existing.DeepCopy()
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		if updated.Labels == nil {
+			updated.Labels = map[string]string{}
+		}
+		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
+
+		for k, v := range desired.ObjectMeta.Annotations {
+			updated.ObjectMeta.Annotations[k] = v
+		}
+		for k, v := range desired.ObjectMeta.Labels {
+			updated.ObjectMeta.Labels[k] = v
+		}
+		updated.Spec.MinAvailable = desired.Spec.MinAvailable
+		updated.Spec.Selector = desired.Spec.Selector
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "pdb.name", desired.Name, "pdb.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deletePodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &policyv1.PodDisruptionBudgetList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "pdb.name", existing.Name, "pdb.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestDesiredPodDisruptionBudgets(t *testing.T) {
+	t.Run("should not create a pod disruption budget without availability", func(t *testing.T) {
+		assert.Empty(t, desiredPodDisruptionBudgets(params()))
+	})
+
+	t.Run("should create a pod disruption budget with availability", func(t *testing.T) {
+		actual := desiredPodDisruptionBudgets(paramsWithAvailability(1))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "test-collector", actual[0].Name)
+		assert.Equal(t, 1, actual[0].Spec.MinAvailable.IntValue())
+	})
+}
+
+func TestExpectedPodDisruptionBudgets(t *testing.T) {
+	t.Run("should create the pod disruption budget", func(t *testing.T) {
+		err := expectedPodDisruptionBudgets(context.Background(), params(), desiredPodDisruptionBudgets(paramsWithAvailability(1)))
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should update the pod disruption budget", func(t *testing.T) {
+		existing := desiredPodDisruptionBudgets(paramsWithAvailability(1))[0]
+		createObjectIfNotExists(t, "test-collector", &existing)
+
+		err := expectedPodDisruptionBudgets(context.Background(), params(), desiredPodDisruptionBudgets(paramsWithAvailability(2)))
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+		assert.Equal(t, 2, actual.Spec.MinAvailable.IntValue())
+	})
+}
+
+func TestDeletePodDisruptionBudgets(t *testing.T) {
+	t.Run("should delete the pod disruption budget without availability", func(t *testing.T) {
+		existing := desiredPodDisruptionBudgets(paramsWithAvailability(1))[0]
+		createObjectIfNotExists(t, "test-collector", &existing)
+
+		err := deletePodDisruptionBudgets(context.Background(), params(), desiredPodDisruptionBudgets(params()))
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func paramsWithAvailability(minAvailable int32) Params {
+	p := params()
+	p.Instance.Spec.Availability = &v1alpha1.AvailabilitySpec{MinAvailable: minAvailable}
+	return p
+}
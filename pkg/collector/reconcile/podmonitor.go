@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
+
+// PodMonitors reconciles the pod monitor(s) required for the instance in the current context.
+func PodMonitors(ctx context.Context, params Params) error {
+	if params.Config.PodMonitors() != autodetect.PodMonitorsAvailable {
+		return nil
+	}
+
+	desired := desiredPodMonitors(params)
+
+	// first, handle the create/update parts
+	if err := expectedPodMonitors(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected pod monitors: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deletePodMonitors(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the pod monitors to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func desiredPodMonitors(params Params) []unstructured.Unstructured {
+	desired := []unstructured.Unstructured{}
+	// unlike the other optional objects, the pod monitor isn't requested by the instance: it's only generated when it
+	// can be served
+	if params.Config.PodMonitors() != autodetect.PodMonitorsAvailable {
+		return desired
+	}
+	if podMonitor := collector.PodMonitor(params.Config, params.Instance); podMonitor != nil {
+		desired = append(desired, *podMonitor)
+	}
+	return desired
+}
+
+func expectedPodMonitors(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(collector.PodMonitorGVK)
+		nns := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "podmonitor.name", desired.GetName(), "podmonitor.namespace", desired.GetNamespace())
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one
+		updated := existing.DeepCopy()
+		annotations := updated.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		labels := updated.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		updated.SetOwnerReferences(desired.GetOwnerReferences())
+
+		for k, v := range desired.GetAnnotations() {
+			annotations[k] = v
+		}
+		for k, v := range desired.GetLabels() {
+			labels[k] = v
+		}
+		updated.SetAnnotations(annotations)
+		updated.SetLabels(labels)
+		updated.Object["spec"] = desired.Object["spec"]
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "podmonitor.name", desired.GetName(), "podmonitor.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deletePodMonitors(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(collector.PodMonitorGVK.GroupVersion().WithKind(collector.PodMonitorGVK.Kind + "List"))
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "podmonitor.name", existing.GetName(), "podmonitor.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+func TestDesiredPodMonitors(t *testing.T) {
+	t.Run("should not create a pod monitor without the pod monitor API", func(t *testing.T) {
+		assert.Empty(t, desiredPodMonitors(paramsWithMode(v1alpha1.ModeSidecar)))
+	})
+
+	t.Run("should not create a pod monitor when not a sidecar", func(t *testing.T) {
+		p := params()
+		p.Config = config.New(config.WithPodMonitors(autodetect.PodMonitorsAvailable))
+		assert.Empty(t, desiredPodMonitors(p))
+	})
+
+	t.Run("should create a pod monitor for a sidecar", func(t *testing.T) {
+		p := paramsWithMode(v1alpha1.ModeSidecar)
+		p.Config = config.New(config.WithPodMonitors(autodetect.PodMonitorsAvailable))
+		actual := desiredPodMonitors(p)
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "test-collector", actual[0].GetName())
+	})
+}
+
+func TestPodMonitorsNotAvailable(t *testing.T) {
+	// prepare
+	p := paramsWithMode(v1alpha1.ModeSidecar)
+	p.Client = nil
+
+	// test
+	err := PodMonitors(context.Background(), p)
+
+	// verify
+	assert.NoError(t, err)
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// Render returns the objects the operator creates for the instance in the current context, without reaching the
+// cluster, so that they can be reviewed before the instance is applied. The objects' type metadata is left empty.
+func Render(ctx context.Context, params Params) ([]client.Object, error) {
+	var objects []client.Object
+
+	serviceAccounts := desiredServiceAccounts(params)
+	for i := range serviceAccounts {
+		objects = append(objects, &serviceAccounts[i])
+	}
+
+	roles, bindings := desiredClusterRoles(params)
+	for i := range roles {
+		objects = append(objects, &roles[i])
+	}
+	for i := range bindings {
+		objects = append(objects, &bindings[i])
+	}
+
+	configMaps, err := desiredAllConfigMaps(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range configMaps {
+		objects = append(objects, &configMaps[i])
+	}
+
+	services := desiredServices(ctx, params)
+	for i := range services {
+		objects = append(objects, &services[i])
+	}
+
+	deployments := desiredDeployments(params)
+	for i := range deployments {
+		objects = append(objects, &deployments[i])
+	}
+
+	daemonSets := desiredDaemonSets(params)
+	for i := range daemonSets {
+		objects = append(objects, &daemonSets[i])
+	}
+
+	statefulSets := desiredStatefulSets(params)
+	for i := range statefulSets {
+		objects = append(objects, &statefulSets[i])
+	}
+
+	objects = append(objects, desiredHorizontalPodAutoscalers(params)...)
+
+	podDisruptionBudgets := desiredPodDisruptionBudgets(params)
+	for i := range podDisruptionBudgets {
+		objects = append(objects, &podDisruptionBudgets[i])
+	}
+
+	verticalPodAutoscalers := desiredVerticalPodAutoscalers(params)
+	for i := range verticalPodAutoscalers {
+		objects = append(objects, &verticalPodAutoscalers[i])
+	}
+
+	podMonitors := desiredPodMonitors(params)
+	for i := range podMonitors {
+		objects = append(objects, &podMonitors[i])
+	}
+
+	istioObjects := desiredIstioObjects(params)
+	for i := range istioObjects {
+		objects = append(objects, &istioObjects[i])
+	}
+
+	if params.Instance.Spec.Mode != v1alpha1.ModeSidecar {
+		ingresses := desiredIngresses(ctx, params)
+		for i := range ingresses {
+			objects = append(objects, &ingresses[i])
+		}
+		if params.Instance.Spec.Ingress.Type == v1alpha1.IngressTypeRoute {
+			routes := desiredRoutes(ctx, params)
+			for i := range routes {
+				objects = append(objects, &routes[i])
+			}
+		}
+	}
+
+	return objects, nil
+}
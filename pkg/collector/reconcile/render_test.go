@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("should render the objects of a deployment", func(t *testing.T) {
+		objects, err := Render(context.Background(), params())
+		require.NoError(t, err)
+
+		names := objectNames(objects)
+		assert.Contains(t, names, "*v1.ServiceAccount/test-collector")
+		assert.Contains(t, names, "*v1.ConfigMap/test-collector")
+		assert.Contains(t, names, "*v1.Service/test-collector")
+		assert.Contains(t, names, "*v1.Service/test-collector-headless")
+		assert.Contains(t, names, "*v1.Deployment/test-collector")
+		for _, obj := range objects {
+			_, isDaemonSet := obj.(*appsv1.DaemonSet)
+			assert.False(t, isDaemonSet)
+		}
+	})
+
+	t.Run("should render the pod disruption budget", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Availability = &v1alpha1.AvailabilitySpec{MinAvailable: 1}
+
+		objects, err := Render(context.Background(), p)
+		require.NoError(t, err)
+
+		assert.Contains(t, objectNames(objects), "*v1.PodDisruptionBudget/test-collector")
+	})
+
+	t.Run("should render only the config map of a sidecar", func(t *testing.T) {
+		objects, err := Render(context.Background(), paramsWithMode(v1alpha1.ModeSidecar))
+		require.NoError(t, err)
+
+		require.Len(t, objects, 1)
+		cm, ok := objects[0].(*corev1.ConfigMap)
+		require.True(t, ok)
+		assert.Equal(t, "test-collector", cm.Name)
+		assert.Contains(t, cm.Data["collector.yaml"], "receivers:")
+	})
+
+	t.Run("should render the target allocator", func(t *testing.T) {
+		param, err := newParams("test/test-img", "")
+		require.NoError(t, err)
+
+		objects, err := Render(context.Background(), param)
+		require.NoError(t, err)
+
+		names := objectNames(objects)
+		assert.Contains(t, names, "*v1.StatefulSet/test-collector")
+		assert.Contains(t, names, "*v1.ConfigMap/test-targetallocator")
+		assert.Contains(t, names, "*v1.Deployment/test-targetallocator")
+		assert.Contains(t, names, "*v1.Service/test-targetallocator")
+	})
+}
+
+func objectNames(objects []client.Object) []string {
+	names := make([]string, len(objects))
+	for i, obj := range objects {
+		names[i] = fmt.Sprintf("%T/%s", obj, obj.GetName())
+	}
+	return names
+}
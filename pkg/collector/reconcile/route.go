@@ -66,7 +66,7 @@ func desiredRoutes(_ context.Context, params Params) []routev1.Route {
 				Annotations: params.Instance.Spec.Ingress.Annotations,
 				Labels: map[string]string{
 					"app.kubernetes.io/name":       naming.Route(params.Instance, p.Name),
-					"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+					"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 					"app.kubernetes.io/managed-by": "opentelemetry-operator",
 				},
 			},
@@ -178,7 +178,7 @@ func deleteRoutes(ctx context.Context, params Params, expected []routev1.Route)
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
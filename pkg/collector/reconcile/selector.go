@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// adoptSelector returns the selector to use for an existing workload. Selectors are immutable, so instead of recreating
+// the workload, the selector of an existing workload is kept when it doesn't contradict the desired one. The labels of
+// the existing selector are then added to the pod template, so that the selector keeps matching the pods. The returned
+// boolean is false when the existing selector can't be adopted and the workload has to be recreated.
+func adoptSelector(desired *metav1.LabelSelector, template *corev1.PodTemplateSpec, existing *metav1.LabelSelector) (*metav1.LabelSelector, bool) {
+	if existing == nil || apiequality.Semantic.DeepEqual(desired, existing) {
+		return desired, true
+	}
+
+	if len(existing.MatchExpressions) > 0 {
+		return nil, false
+	}
+
+	if desired != nil {
+		for k, v := range existing.MatchLabels {
+			if want, ok := desired.MatchLabels[k]; ok && want != v {
+				return nil, false
+			}
+		}
+	}
+
+	// new map, so that we don't touch the labels shared with other objects
+	labels := map[string]string{}
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+	for k, v := range existing.MatchLabels {
+		labels[k] = v
+	}
+	template.Labels = labels
+
+	return existing.DeepCopy(), true
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdoptSelector(t *testing.T) {
+	desired := &metav1.LabelSelector{MatchLabels: map[string]string{
+		"app.kubernetes.io/instance":  "default.test",
+		"app.kubernetes.io/component": "opentelemetry-collector",
+	}}
+
+	for _, tt := range []struct {
+		desc           string
+		existing       *metav1.LabelSelector
+		expected       *metav1.LabelSelector
+		expectedLabels map[string]string
+		adopted        bool
+	}{
+		{
+			desc:           "no existing selector",
+			expected:       desired,
+			expectedLabels: map[string]string{"app.kubernetes.io/instance": "default.test"},
+			adopted:        true,
+		},
+		{
+			desc:           "same selector",
+			existing:       desired.DeepCopy(),
+			expected:       desired,
+			expectedLabels: map[string]string{"app.kubernetes.io/instance": "default.test"},
+			adopted:        true,
+		},
+		{
+			desc: "selector with an older label",
+			existing: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/instance": "default.test",
+				"team":                       "payments",
+			}},
+			expected: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/instance": "default.test",
+				"team":                       "payments",
+			}},
+			expectedLabels: map[string]string{
+				"app.kubernetes.io/instance": "default.test",
+				"team":                       "payments",
+			},
+			adopted: true,
+		},
+		{
+			desc: "selector with a conflicting label",
+			existing: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/instance": "default.other",
+			}},
+			expectedLabels: map[string]string{"app.kubernetes.io/instance": "default.test"},
+		},
+		{
+			desc: "selector with expressions",
+			existing: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "app.kubernetes.io/instance",
+				Operator: metav1.LabelSelectorOpExists,
+			}}},
+			expectedLabels: map[string]string{"app.kubernetes.io/instance": "default.test"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			template := &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/instance": "default.test"},
+			}}
+
+			selector, adopted := adoptSelector(desired, template, tt.existing)
+
+			assert.Equal(t, tt.adopted, adopted)
+			assert.Equal(t, tt.expected, selector)
+			assert.Equal(t, tt.expectedLabels, template.Labels)
+		})
+	}
+}
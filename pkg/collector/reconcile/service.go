@@ -40,10 +40,37 @@ const (
 	headlessExists = "Exists"
 )
 
+const (
+	// awsHealthCheck* are the annotations of the service pointing the health checks of the AWS load balancers to the
+	// health_check extension.
+	awsHealthCheckPortAnnotation     = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-port"
+	awsHealthCheckPathAnnotation     = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-path"
+	awsHealthCheckProtocolAnnotation = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol"
+
+	// gcpBackendConfigAnnotation is the annotation of the service selecting the GKE BackendConfig of its ports.
+	gcpBackendConfigAnnotation = "cloud.google.com/backend-config"
+)
+
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
 // Services reconciles the service(s) required for the instance in the current context.
 func Services(ctx context.Context, params Params) error {
+	desired := desiredServices(ctx, params)
+
+	// first, handle the create/update parts
+	if err := expectedServices(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected services: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteServices(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the services to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func desiredServices(ctx context.Context, params Params) []corev1.Service {
 	desired := []corev1.Service{}
 	if params.Instance.Spec.Mode != v1alpha1.ModeSidecar {
 		type builder func(context.Context, Params) *corev1.Service
@@ -57,20 +84,9 @@ func Services(ctx context.Context, params Params) error {
 	}
 
 	if params.Instance.Spec.TargetAllocator.Enabled {
-		desired = append(desired, desiredTAService(params))
-	}
-
-	// first, handle the create/update parts
-	if err := expectedServices(ctx, params, desired); err != nil {
-		return fmt.Errorf("failed to reconcile the expected services: %w", err)
-	}
-
-	// then, delete the extra objects
-	if err := deleteServices(ctx, params, desired); err != nil {
-		return fmt.Errorf("failed to reconcile the services to be deleted: %w", err)
+		desired = append(desired, desiredTAServices(params)...)
 	}
-
-	return nil
+	return desired
 }
 
 func desiredService(ctx context.Context, params Params) *corev1.Service {
@@ -89,6 +105,17 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 		return nil
 	}
 
+	annotations := params.Instance.Annotations
+	if params.Instance.Spec.LoadBalancerHealthCheck != nil {
+		healthCheckPort, healthCheckPath, err := adapters.ConfigToHealthCheckPort(config)
+		if err != nil {
+			params.Log.Error(err, "couldn't expose the health_check extension to the load balancers")
+		} else {
+			ports = append(ports, healthCheckPort)
+			annotations = loadBalancerHealthCheckAnnotations(params.Instance, healthCheckPort.Port, healthCheckPath)
+		}
+	}
+
 	if len(params.Instance.Spec.Ports) > 0 {
 		// we should add all the ports from the CR
 		// there are two cases where problems might occur:
@@ -124,26 +151,77 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 			Name:        naming.Service(params.Instance),
 			Namespace:   params.Instance.Namespace,
 			Labels:      labels,
-			Annotations: params.Instance.Annotations,
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
 			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              collector.SelectorLabels(params.Instance),
+			Selector:              collector.ActiveSelectorLabels(params.Instance),
 			ClusterIP:             "",
 			Ports:                 ports,
 		},
 	}
 }
 
+// loadBalancerHealthCheckAnnotations returns the annotations of the instance with the ones pointing the health checks
+// of the cloud provider load balancers to the given port and path of the health_check extension.
+func loadBalancerHealthCheckAnnotations(otelcol v1alpha1.OpenTelemetryCollector, port int32, path string) map[string]string {
+	// copy to avoid modifying otelcol.Annotations
+	annotations := map[string]string{}
+	for k, v := range otelcol.Annotations {
+		annotations[k] = v
+	}
+
+	switch otelcol.Spec.LoadBalancerHealthCheck.Provider {
+	case v1alpha1.LoadBalancerProviderAWS:
+		annotations[awsHealthCheckPortAnnotation] = fmt.Sprintf("%d", port)
+		annotations[awsHealthCheckPathAnnotation] = path
+		annotations[awsHealthCheckProtocolAnnotation] = "HTTP"
+	case v1alpha1.LoadBalancerProviderGCP:
+		if otelcol.Spec.LoadBalancerHealthCheck.BackendConfig != "" {
+			annotations[gcpBackendConfigAnnotation] = fmt.Sprintf(`{"default":%q}`, otelcol.Spec.LoadBalancerHealthCheck.BackendConfig)
+		}
+	}
+	return annotations
+}
+
 func desiredTAService(params Params) corev1.Service {
-	name := naming.TAService(params.Instance)
+	return taService(params, naming.TAService(params.Instance))
+}
+
+// desiredTAServices returns the TargetAllocator services, one for each job shard, or one for each availability zone
+// when the instance is topology-aware.
+func desiredTAServices(params Params) []corev1.Service {
+	if zones := targetallocator.Zones(params.Instance); len(zones) > 0 {
+		services := make([]corev1.Service, 0, len(zones))
+		for _, zone := range zones {
+			service := taService(params, naming.TAServiceZone(params.Instance, zone))
+			service.Labels = targetallocator.ZoneLabels(service.Labels, zone)
+			service.Spec.Selector = targetallocator.ZoneLabels(service.Spec.Selector, zone)
+			services = append(services, service)
+		}
+		return services
+	}
+
+	shards := targetallocator.JobShards(params.Instance)
+	if shards == 1 {
+		return []corev1.Service{desiredTAService(params)}
+	}
+
+	services := make([]corev1.Service, 0, shards)
+	for shard := int32(0); shard < shards; shard++ {
+		services = append(services, taService(params, naming.TAServiceShard(params.Instance, shard)))
+	}
+	return services
+}
+
+func taService(params Params, name string) corev1.Service {
 	labels := targetallocator.Labels(params.Instance, name)
 
-	selector := targetallocator.Labels(params.Instance, name)
+	selector := targetallocator.SelectorLabels(params.Instance, name)
 
 	return corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.TAService(params.Instance),
+			Name:      name,
 			Namespace: params.Instance.Namespace,
 			Labels:    labels,
 		},
@@ -258,7 +336,7 @@ func deleteServices(ctx context.Context, params Params, expected []corev1.Servic
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -147,6 +148,79 @@ func TestDesiredService(t *testing.T) {
 		assert.Equal(t, expected, *actual)
 	})
 
+	t.Run("should expose the health_check extension to the load balancers", func(t *testing.T) {
+		healthCheckConfig := `receivers:
+  otlp:
+    protocols:
+      grpc:
+extensions:
+  health_check:
+    endpoint: 0.0.0.0:13134
+    path: /health
+exporters:
+  debug:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]`
+
+		for _, tt := range []struct {
+			desc        string
+			spec        v1alpha1.LoadBalancerHealthCheckSpec
+			annotations map[string]string
+		}{
+			{
+				desc: "aws",
+				spec: v1alpha1.LoadBalancerHealthCheckSpec{Provider: v1alpha1.LoadBalancerProviderAWS},
+				annotations: map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port":     "13134",
+					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path":     "/health",
+					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTP",
+				},
+			},
+			{
+				desc: "gcp",
+				spec: v1alpha1.LoadBalancerHealthCheckSpec{Provider: v1alpha1.LoadBalancerProviderGCP, BackendConfig: "otel-health"},
+				annotations: map[string]string{
+					"cloud.google.com/backend-config": `{"default":"otel-health"}`,
+				},
+			},
+		} {
+			t.Run(tt.desc, func(t *testing.T) {
+				// prepare
+				p := params()
+				p.Instance.Spec.Config = healthCheckConfig
+				p.Instance.Spec.Ports = nil
+				p.Instance.Spec.LoadBalancerHealthCheck = &tt.spec
+
+				// test
+				actual := desiredService(context.Background(), p)
+
+				// verify
+				require.NotNil(t, actual)
+				assert.Contains(t, actual.Spec.Ports, v1.ServicePort{
+					Name:       "health-check",
+					Port:       13134,
+					TargetPort: intstr.FromInt(13134),
+				})
+				for k, v := range tt.annotations {
+					assert.Equal(t, v, actual.Annotations[k])
+				}
+				assert.NotContains(t, p.Instance.Annotations, "service.beta.kubernetes.io/aws-load-balancer-healthcheck-port")
+			})
+		}
+	})
+
+	t.Run("should not expose the health_check extension without the load balancer health check", func(t *testing.T) {
+		actual := desiredService(context.Background(), params())
+
+		require.NotNil(t, actual)
+		for _, port := range actual.Spec.Ports {
+			assert.NotEqual(t, "health-check", port.Name)
+		}
+	})
 }
 
 func TestExpectedServices(t *testing.T) {
@@ -26,17 +26,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 // +kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 
 // StatefulSets reconciles the stateful set(s) required for the instance in the current context.
 func StatefulSets(ctx context.Context, params Params) error {
-
-	desired := []appsv1.StatefulSet{}
-	if params.Instance.Spec.Mode == "statefulset" {
-		desired = append(desired, collector.StatefulSet(params.Config, params.Log, params.Instance))
-	}
+	desired := desiredStatefulSets(params)
 
 	// first, handle the create/update parts
 	if err := expectedStatefulSets(ctx, params, desired); err != nil {
@@ -51,6 +48,17 @@ func StatefulSets(ctx context.Context, params Params) error {
 	return nil
 }
 
+func desiredStatefulSets(params Params) []appsv1.StatefulSet {
+	desired := []appsv1.StatefulSet{}
+	if params.Instance.Spec.Mode == "statefulset" {
+		desired = append(desired, collector.StatefulSets(params.Config, params.Log, params.Instance)...)
+	}
+	for i := range desired {
+		collector.WithProvenance(params.Config, &desired[i].ObjectMeta, &desired[i].Spec.Template)
+	}
+	return desired
+}
+
 func expectedStatefulSets(ctx context.Context, params Params, expected []appsv1.StatefulSet) error {
 	for _, obj := range expected {
 		desired := obj
@@ -72,6 +80,12 @@ func expectedStatefulSets(ctx context.Context, params Params, expected []appsv1.
 			return fmt.Errorf("failed to get: %w", err)
 		}
 
+		// The existing selector is kept when possible, so that changing the labels doesn't cause any downtime.
+		if selector, adopted := adoptSelector(desired.Spec.Selector, &desired.Spec.Template, existing.Spec.Selector); adopted && selector != desired.Spec.Selector {
+			params.Log.V(2).Info("Spec.Selector change detected, keeping the existing selector", "statefulset.name", existing.Name, "statefulset.namespace", existing.Namespace)
+			desired.Spec.Selector = selector
+		}
+
 		// Check for immutable fields. If set, we cannot modify the stateful set, otherwise we will face reconciliation error.
 		if needsDeletion, fieldName := hasImmutableFieldChange(&desired, existing); needsDeletion {
 			params.Log.V(2).Info("Immutable field change detected, trying to delete, the new collector statefulset will be created in the next reconcile cycle",
@@ -117,7 +131,7 @@ func deleteStatefulSets(ctx context.Context, params Params, expected []appsv1.St
 	opts := []client.ListOption{
 		client.InNamespace(params.Instance.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// TenantsStatus patches the given TenantsRouted condition on the status of the given instance, removing it when nil.
+// The tenants left out are reported with an event when the condition turns false or its message changes, rather than
+// on every reconciliation.
+func TenantsStatus(ctx context.Context, params Params, instance *v1alpha1.OpenTelemetryCollector, condition *metav1.Condition) error {
+	previous := meta.FindStatusCondition(instance.Status.Conditions, collector.ConditionTypeTenantsRouted)
+	changed := instance.DeepCopy()
+	switch {
+	case condition == nil && previous == nil:
+		return nil
+	case condition == nil:
+		meta.RemoveStatusCondition(&changed.Status.Conditions, collector.ConditionTypeTenantsRouted)
+	case previous != nil && previous.Status == condition.Status && previous.Reason == condition.Reason && previous.Message == condition.Message && previous.ObservedGeneration == condition.ObservedGeneration:
+		return nil
+	default:
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+		if condition.Status == metav1.ConditionFalse && (previous == nil || previous.Message != condition.Message) {
+			params.Recorder.Event(instance, "Warning", condition.Reason, condition.Message)
+		}
+	}
+
+	if err := params.Client.Status().Patch(ctx, changed, client.MergeFrom(instance)); err != nil {
+		return fmt.Errorf("failed to apply the tenants status to the OpenTelemetry CR: %w", err)
+	}
+	instance.ObjectMeta = changed.ObjectMeta
+	instance.Status = changed.Status
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// VerticalPodAutoscalers reconciles the vertical pod autoscaler(s) required for the instance in the current context.
+func VerticalPodAutoscalers(ctx context.Context, params Params) error {
+	if params.Config.VerticalPodAutoscalers() != autodetect.VerticalPodAutoscalersAvailable {
+		if params.Instance.Spec.VerticalAutoscaler != nil {
+			params.Log.V(1).Info("the vertical autoscaler is ignored, as the VerticalPodAutoscaler API isn't available in the cluster")
+		}
+		return nil
+	}
+
+	desired := desiredVerticalPodAutoscalers(params)
+
+	// first, handle the create/update parts
+	if err := expectedVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected vertical pod autoscalers: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the vertical pod autoscalers to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func desiredVerticalPodAutoscalers(params Params) []unstructured.Unstructured {
+	desired := []unstructured.Unstructured{}
+	if vpa := collector.VerticalPodAutoscaler(params.Config, params.Instance); vpa != nil {
+		desired = append(desired, *vpa)
+	}
+	return desired
+}
+
+func expectedVerticalPodAutoscalers(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(collector.VerticalPodAutoscalerGVK)
+		nns := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "vpa.name", desired.GetName(), "vpa.namespace", desired.GetNamespace())
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		// it exists already, merge the two if the end result isn't identical to the existing one. Only the spec is
+		// managed by the operator: the recommendations are written to the status by the VerticalPodAutoscaler.
+		updated := existing.DeepCopy()
+		annotations := updated.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		labels := updated.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		updated.SetOwnerReferences(desired.GetOwnerReferences())
+
+		for k, v := range desired.GetAnnotations() {
+			annotations[k] = v
+		}
+		for k, v := range desired.GetLabels() {
+			labels[k] = v
+		}
+		updated.SetAnnotations(annotations)
+		updated.SetLabels(labels)
+		updated.Object["spec"] = desired.Object["spec"]
+
+		patch := client.MergeFrom(existing)
+
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "vpa.name", desired.GetName(), "vpa.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deleteVerticalPodAutoscalers(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   naming.Instance(params.Instance),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(collector.VerticalPodAutoscalerGVK.GroupVersion().WithKind(collector.VerticalPodAutoscalerGVK.Kind + "List"))
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "vpa.name", existing.GetName(), "vpa.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestDesiredVerticalPodAutoscalers(t *testing.T) {
+	t.Run("should not create a vertical pod autoscaler without vertical autoscaler", func(t *testing.T) {
+		assert.Empty(t, desiredVerticalPodAutoscalers(params()))
+	})
+
+	t.Run("should create a vertical pod autoscaler with vertical autoscaler", func(t *testing.T) {
+		actual := desiredVerticalPodAutoscalers(paramsWithVerticalAutoscaler(v1alpha1.VerticalAutoscalerUpdateModeInitial))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "test-collector", actual[0].GetName())
+		updateMode, _, err := unstructured.NestedString(actual[0].Object, "spec", "updatePolicy", "updateMode")
+		require.NoError(t, err)
+		assert.Equal(t, "Initial", updateMode)
+	})
+}
+
+func TestVerticalPodAutoscalersNotAvailable(t *testing.T) {
+	// prepare
+	p := paramsWithVerticalAutoscaler(v1alpha1.VerticalAutoscalerUpdateModeAuto)
+	p.Client = nil
+
+	// test
+	err := VerticalPodAutoscalers(context.Background(), p)
+
+	// verify
+	assert.NoError(t, err)
+}
+
+func paramsWithVerticalAutoscaler(updateMode v1alpha1.VerticalAutoscalerUpdateMode) Params {
+	p := params()
+	p.Instance.Spec.VerticalAutoscaler = &v1alpha1.VerticalAutoscalerSpec{UpdateMode: updateMode}
+	return p
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	remoteWriteExporter = "prometheusremotewrite"
+
+	// remoteWriteWALPath is the directory the volume of the write-ahead log of the remote write preset is mounted in.
+	remoteWriteWALPath = "/var/lib/otelcol/remote-write-wal"
+
+	defaultRemoteWriteWALSize = "1Gi"
+)
+
+// remoteWriteWALEnabled returns whether the remote write preset of the given instance keeps a write-ahead log.
+func remoteWriteWALEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.RemoteWrite != nil && otelcol.Spec.RemoteWrite.WAL != nil && otelcol.Spec.Mode == v1alpha1.ModeStatefulSet
+}
+
+// remoteWriteVolumeClaimTemplates returns the volume claim of the write-ahead log of the remote write preset.
+func remoteWriteVolumeClaimTemplates(otelcol v1alpha1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
+	if !remoteWriteWALEnabled(otelcol) {
+		return nil
+	}
+	wal := otelcol.Spec.RemoteWrite.WAL
+	size := resource.MustParse(defaultRemoteWriteWALSize)
+	if wal.Size != nil {
+		size = *wal.Size
+	}
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: naming.RemoteWriteWALVolume(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+			StorageClassName: wal.StorageClassName,
+		},
+	}}
+}
+
+// remoteWriteVolumeMounts returns the mount of the volume of the write-ahead log of the remote write preset.
+func remoteWriteVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !remoteWriteWALEnabled(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      naming.RemoteWriteWALVolume(),
+		MountPath: remoteWriteWALPath,
+	}}
+}
+
+// remoteWriteConfig adds the prometheusremotewrite exporter of the remote write preset to the given configuration,
+// and to the pipelines of the preset, or to all the metrics pipelines.
+func remoteWriteConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	spec := otelcol.Spec.RemoteWrite
+	if spec == nil {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	externalLabels := map[string]interface{}{
+		"collector": fmt.Sprintf("%s/%s", otelcol.Namespace, otelcol.Name),
+	}
+	if len(spec.ClusterName) > 0 {
+		externalLabels["cluster"] = spec.ClusterName
+	}
+	for name, value := range spec.ExternalLabels {
+		externalLabels[name] = value
+	}
+	exporter := map[string]interface{}{
+		"endpoint": spec.Endpoint,
+		"resource_to_telemetry_conversion": map[string]interface{}{
+			"enabled": true,
+		},
+		"external_labels": externalLabels,
+	}
+	if remoteWriteWALEnabled(otelcol) {
+		exporter["wal"] = map[string]interface{}{
+			"directory": remoteWriteWALPath,
+		}
+	}
+	exporters, err := configSection(config, "exporters")
+	if err != nil {
+		return "", err
+	}
+	if _, ok := exporters[remoteWriteExporter]; ok {
+		return "", fmt.Errorf("the %s exporter is already configured, it can't be used along with the remote write preset", remoteWriteExporter)
+	}
+	exporters[remoteWriteExporter] = exporter
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+	pipelines, err := configSection(service, "pipelines")
+	if err != nil {
+		return "", err
+	}
+	names := spec.Pipelines
+	if len(names) == 0 {
+		for name := range pipelines {
+			if name == "metrics" || strings.HasPrefix(name, "metrics/") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("the configuration has no metrics pipeline to add the %s exporter to", remoteWriteExporter)
+	}
+	for _, name := range names {
+		pipeline, ok := pipelines[name].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("the %s pipeline of the remote write preset doesn't exist", name)
+		}
+		pipeline["exporters"] = appendComponentName(pipeline["exporters"], remoteWriteExporter)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
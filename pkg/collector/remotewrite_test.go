@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func remoteWriteInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "metrics",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeStatefulSet,
+			RemoteWrite: &v1alpha1.RemoteWriteSpec{
+				Endpoint:       "http://prometheus:9090/api/v1/write",
+				ClusterName:    "production",
+				ExternalLabels: map[string]string{"region": "eu-west-1"},
+			},
+			Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs: []
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [logging]
+    metrics/otlp:
+      receivers: [otlp]
+      exporters: []
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+		},
+	}
+}
+
+func TestRemoteWriteConfig(t *testing.T) {
+	otelcol := remoteWriteInstance()
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "http://prometheus:9090/api/v1/write",
+		"resource_to_telemetry_conversion": map[string]interface{}{
+			"enabled": true,
+		},
+		"external_labels": map[string]interface{}{
+			"cluster":   "production",
+			"collector": "observability/metrics",
+			"region":    "eu-west-1",
+		},
+	}, cfg["exporters"].(map[string]interface{})["prometheusremotewrite"])
+
+	pipelines := cfg["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"logging", "prometheusremotewrite"}, pipelines["metrics"].(map[string]interface{})["exporters"])
+	assert.Equal(t, []interface{}{"prometheusremotewrite"}, pipelines["metrics/otlp"].(map[string]interface{})["exporters"])
+	assert.Equal(t, []interface{}{"logging"}, pipelines["traces"].(map[string]interface{})["exporters"])
+}
+
+func TestRemoteWriteConfigWithPipelines(t *testing.T) {
+	otelcol := remoteWriteInstance()
+	otelcol.Spec.RemoteWrite.Pipelines = []string{"metrics/otlp"}
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+
+	pipelines := cfg["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"logging"}, pipelines["metrics"].(map[string]interface{})["exporters"])
+	assert.Equal(t, []interface{}{"prometheusremotewrite"}, pipelines["metrics/otlp"].(map[string]interface{})["exporters"])
+
+	otelcol.Spec.RemoteWrite.Pipelines = []string{"logs"}
+	_, err = PresetConfig(config.New(), otelcol)
+	assert.ErrorContains(t, err, "the logs pipeline of the remote write preset doesn't exist")
+}
+
+func TestRemoteWriteWAL(t *testing.T) {
+	otelcol := remoteWriteInstance()
+	storageClass := "ssd"
+	size := resource.MustParse("5Gi")
+	otelcol.Spec.RemoteWrite.WAL = &v1alpha1.RemoteWriteWALSpec{Size: &size, StorageClassName: &storageClass}
+
+	presetConfig, err := PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	cfg, err := adapters.ConfigFromString(presetConfig)
+	require.NoError(t, err)
+	exporter := cfg["exporters"].(map[string]interface{})["prometheusremotewrite"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"directory": "/var/lib/otelcol/remote-write-wal"}, exporter["wal"])
+
+	claims := VolumeClaimTemplates(otelcol)
+	require.Len(t, claims, 1)
+	assert.Equal(t, "remote-write-wal", claims[0].Name)
+	assert.Equal(t, size, claims[0].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, &storageClass, claims[0].Spec.StorageClassName)
+
+	container := Container(config.New(), logr.Discard(), otelcol, true)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "remote-write-wal", MountPath: "/var/lib/otelcol/remote-write-wal"})
+
+	statefulSet := StatefulSet(config.New(), logr.Discard(), otelcol)
+	require.NotNil(t, statefulSet.Spec.Template.Spec.SecurityContext)
+	assert.Equal(t, int64(65534), *statefulSet.Spec.Template.Spec.SecurityContext.FSGroup)
+
+	// the write-ahead log is only kept on the volume claims of a statefulset
+	otelcol.Spec.Mode = v1alpha1.ModeDeployment
+	presetConfig, err = PresetConfig(config.New(), otelcol)
+	require.NoError(t, err)
+	assert.NotContains(t, presetConfig, "wal")
+}
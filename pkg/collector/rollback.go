@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// RollbackAnnotation is the annotation rolling the collector back to the configuration and image its pods last ran
+// ready with, until the spec is fixed and the annotation removed.
+const RollbackAnnotation = "opentelemetry.io/rollback"
+
+// lastKnownGoodAnnotation marks the instances running their last known good state, whose configuration is already
+// rendered, see PresetConfig. It's only set on the instances the objects are generated from.
+const lastKnownGoodAnnotation = "opentelemetry-operator-config/last-known-good"
+
+// ConditionTypeDegraded is the type of the status condition flagging the instances whose collector doesn't run their
+// spec, as they are rolled back to their last known good state.
+const ConditionTypeDegraded = "Degraded"
+
+// Reasons of the Degraded condition.
+const (
+	ReasonRolledBack      = "RolledBack"
+	ReasonNoLastKnownGood = "NoLastKnownGood"
+)
+
+// RollbackRequested returns whether the rollback annotation is set on the given instance.
+func RollbackRequested(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Annotations[RollbackAnnotation] == "true"
+}
+
+// RolledBack returns whether the collector of the given instance runs its last known good state instead of its spec.
+// The sidecars are injected from the spec, so they aren't rolled back.
+func RolledBack(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return RollbackRequested(otelcol) && otelcol.Status.LastKnownGood != nil && otelcol.Spec.Mode != v1alpha1.ModeSidecar
+}
+
+// LastKnownGoodInstance returns the given instance with the rendered configuration and image of its last known good
+// state, which the presets aren't applied to again.
+func LastKnownGoodInstance(otelcol v1alpha1.OpenTelemetryCollector) v1alpha1.OpenTelemetryCollector {
+	instance := *otelcol.DeepCopy()
+	if lastKnownGood := otelcol.Status.LastKnownGood; lastKnownGood != nil {
+		instance.Spec.Config = lastKnownGood.Config
+		instance.Spec.Image = lastKnownGood.Image
+		// new map, so that we don't touch the annotations of the given instance
+		annotations := map[string]string{}
+		for k, v := range otelcol.Annotations {
+			annotations[k] = v
+		}
+		annotations[lastKnownGoodAnnotation] = "true"
+		instance.Annotations = annotations
+	}
+	return instance
+}
+
+// runsLastKnownGood returns whether the given instance is the one returned by LastKnownGoodInstance.
+func runsLastKnownGood(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	lastKnownGood := otelcol.Status.LastKnownGood
+	return otelcol.Annotations[lastKnownGoodAnnotation] == "true" && lastKnownGood != nil && otelcol.Spec.Config == lastKnownGood.Config
+}
+
+// LastKnownGood returns the last known good state of the given instance: its current rendered configuration and image
+// once its collector is healthy and all of its pods run them, or its previous last known good state otherwise. The
+// rendered configuration is recorded, so that rolling back doesn't depend on the presets of the current spec.
+func LastKnownGood(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, pods []corev1.Pod, now metav1.Time) *v1alpha1.LastKnownGoodStatus {
+	previous := otelcol.Status.LastKnownGood
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar || RolledBack(otelcol) || !meta.IsStatusConditionTrue(otelcol.Status.Conditions, ConditionTypeHealthy) {
+		return previous
+	}
+
+	image := Image(cfg, otelcol)
+
+	// the pods of the previous configuration or image may still be ready while the new ones are being created
+	config := collectorConfig(cfg, otelcol)
+	sha := getConfigMapSHA(config)
+	running := 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		running++
+		if pod.Annotations["opentelemetry-operator-config/sha256"] != sha {
+			return previous
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == naming.Container() && container.Image != image {
+				return previous
+			}
+		}
+	}
+	if running == 0 {
+		return previous
+	}
+
+	if previous != nil && previous.Config == config && previous.Image == image {
+		return previous
+	}
+	return &v1alpha1.LastKnownGoodStatus{
+		Config:             config,
+		Image:              image,
+		ObservedGeneration: otelcol.Generation,
+		RecordedTime:       now,
+	}
+}
+
+// RollbackCondition returns the Degraded condition of the given instance, or nil when its rollback isn't requested.
+func RollbackCondition(otelcol v1alpha1.OpenTelemetryCollector) *metav1.Condition {
+	if !RollbackRequested(otelcol) || otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               ConditionTypeDegraded,
+		ObservedGeneration: otelcol.Generation,
+	}
+	if lastKnownGood := otelcol.Status.LastKnownGood; lastKnownGood != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonRolledBack
+		condition.Message = fmt.Sprintf("the collector runs the configuration and image %s of generation %d instead of the spec, remove the %s annotation once the spec is fixed",
+			lastKnownGood.Image, lastKnownGood.ObservedGeneration, RollbackAnnotation)
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonNoLastKnownGood
+		condition.Message = "the rollback is requested, but the collector pods were never seen ready, so the collector runs the spec"
+	}
+	return condition
+}
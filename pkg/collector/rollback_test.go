@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func rollbackInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-instance",
+			Generation: 4,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Image:  "otel/opentelemetry-collector:0.2.0",
+			Config: "receivers:\n  otlp:\n",
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Conditions: []metav1.Condition{{Type: ConditionTypeHealthy, Status: metav1.ConditionTrue}},
+		},
+	}
+}
+
+func runningPod(otelcol v1alpha1.OpenTelemetryCollector, image string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: PodAnnotations(config.New(), otelcol),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "otc-container", Image: image}},
+		},
+	}
+}
+
+func TestLastKnownGood(t *testing.T) {
+	now := metav1.Now()
+	previous := &v1alpha1.LastKnownGoodStatus{
+		Config:             "receivers:\n  jaeger:\n",
+		Image:              "otel/opentelemetry-collector:0.1.0",
+		ObservedGeneration: 2,
+	}
+
+	t.Run("should record the state the healthy pods run", func(t *testing.T) {
+		otelcol := rollbackInstance()
+		otelcol.Status.LastKnownGood = previous
+
+		actual := LastKnownGood(config.New(), otelcol, []corev1.Pod{runningPod(otelcol, "otel/opentelemetry-collector:0.2.0")}, now)
+
+		require.NotNil(t, actual)
+		assert.Equal(t, "receivers:\n  otlp:\n", actual.Config)
+		assert.Equal(t, "otel/opentelemetry-collector:0.2.0", actual.Image)
+		assert.Equal(t, int64(4), actual.ObservedGeneration)
+		assert.Equal(t, now, actual.RecordedTime)
+	})
+
+	t.Run("should record the default image", func(t *testing.T) {
+		otelcol := rollbackInstance()
+		otelcol.Spec.Image = ""
+		cfg := config.New(config.WithCollectorImage("otel/opentelemetry-collector:0.3.0"))
+
+		actual := LastKnownGood(cfg, otelcol, []corev1.Pod{runningPod(otelcol, "otel/opentelemetry-collector:0.3.0")}, now)
+
+		require.NotNil(t, actual)
+		assert.Equal(t, "otel/opentelemetry-collector:0.3.0", actual.Image)
+	})
+
+	t.Run("should record the rendered configuration", func(t *testing.T) {
+		otelcol := guardrailsInstance(v1alpha1.GuardrailsSpec{})
+		otelcol.Spec.Image = "otel/opentelemetry-collector:0.2.0"
+		otelcol.Status.Conditions = []metav1.Condition{{Type: ConditionTypeHealthy, Status: metav1.ConditionTrue}}
+		rendered, err := PresetConfig(config.New(), otelcol)
+		require.NoError(t, err)
+
+		actual := LastKnownGood(config.New(), otelcol, []corev1.Pod{runningPod(otelcol, "otel/opentelemetry-collector:0.2.0")}, now)
+
+		require.NotNil(t, actual)
+		assert.Equal(t, rendered, actual.Config)
+		assert.Contains(t, actual.Config, "memory_limiter/guardrails")
+	})
+
+	t.Run("should keep the time of an unchanged state", func(t *testing.T) {
+		otelcol := rollbackInstance()
+		recorded := &v1alpha1.LastKnownGoodStatus{Config: otelcol.Spec.Config, Image: otelcol.Spec.Image, ObservedGeneration: 3}
+		otelcol.Status.LastKnownGood = recorded
+
+		assert.Same(t, recorded, LastKnownGood(config.New(), otelcol, []corev1.Pod{runningPod(otelcol, otelcol.Spec.Image)}, now))
+	})
+
+	for _, tt := range []struct {
+		desc   string
+		mutate func(*v1alpha1.OpenTelemetryCollector, *[]corev1.Pod)
+	}{
+		{
+			desc: "unhealthy",
+			mutate: func(otelcol *v1alpha1.OpenTelemetryCollector, _ *[]corev1.Pod) {
+				otelcol.Status.Conditions[0].Status = metav1.ConditionFalse
+			},
+		},
+		{
+			desc: "no pods",
+			mutate: func(_ *v1alpha1.OpenTelemetryCollector, pods *[]corev1.Pod) {
+				*pods = nil
+			},
+		},
+		{
+			desc: "pods of the previous configuration",
+			mutate: func(otelcol *v1alpha1.OpenTelemetryCollector, pods *[]corev1.Pod) {
+				old := *otelcol.DeepCopy()
+				old.Spec.Config = previous.Config
+				*pods = append(*pods, runningPod(old, otelcol.Spec.Image))
+			},
+		},
+		{
+			desc: "pods of the previous image",
+			mutate: func(otelcol *v1alpha1.OpenTelemetryCollector, pods *[]corev1.Pod) {
+				*pods = append(*pods, runningPod(*otelcol, previous.Image))
+			},
+		},
+		{
+			desc: "rolled back",
+			mutate: func(otelcol *v1alpha1.OpenTelemetryCollector, _ *[]corev1.Pod) {
+				otelcol.Annotations = map[string]string{RollbackAnnotation: "true"}
+			},
+		},
+	} {
+		t.Run("should keep the previous state when "+tt.desc, func(t *testing.T) {
+			otelcol := rollbackInstance()
+			otelcol.Status.LastKnownGood = previous
+			pods := []corev1.Pod{runningPod(otelcol, otelcol.Spec.Image)}
+			tt.mutate(&otelcol, &pods)
+
+			assert.Same(t, previous, LastKnownGood(config.New(), otelcol, pods, now))
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+	otelcol := rollbackInstance()
+	otelcol.Status.LastKnownGood = &v1alpha1.LastKnownGoodStatus{
+		Config:             "receivers:\n  jaeger:\n",
+		Image:              "otel/opentelemetry-collector:0.1.0",
+		ObservedGeneration: 2,
+	}
+
+	t.Run("should not roll back without the annotation", func(t *testing.T) {
+		assert.False(t, RolledBack(otelcol))
+		assert.Nil(t, RollbackCondition(otelcol))
+	})
+
+	t.Run("should roll back to the last known good state", func(t *testing.T) {
+		rolledBack := *otelcol.DeepCopy()
+		rolledBack.Annotations = map[string]string{RollbackAnnotation: "true"}
+
+		assert.True(t, RolledBack(rolledBack))
+		instance := LastKnownGoodInstance(rolledBack)
+		assert.Equal(t, "receivers:\n  jaeger:\n", instance.Spec.Config)
+		assert.Equal(t, "otel/opentelemetry-collector:0.1.0", instance.Spec.Image)
+		assert.Equal(t, "receivers:\n  otlp:\n", rolledBack.Spec.Config, "the instance itself isn't changed")
+		assert.NotContains(t, rolledBack.Annotations, "opentelemetry-operator-config/last-known-good")
+
+		condition := RollbackCondition(rolledBack)
+		require.NotNil(t, condition)
+		assert.Equal(t, ConditionTypeDegraded, condition.Type)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, ReasonRolledBack, condition.Reason)
+		assert.Contains(t, condition.Message, "of generation 2")
+		assert.Equal(t, int64(4), condition.ObservedGeneration)
+	})
+
+	t.Run("should not render the last known good configuration again", func(t *testing.T) {
+		rolledBack := guardrailsInstance(v1alpha1.GuardrailsSpec{})
+		rolledBack.Annotations = map[string]string{RollbackAnnotation: "true"}
+		rolledBack.Status.LastKnownGood = &v1alpha1.LastKnownGoodStatus{
+			Config: "receivers:\n  jaeger:\n",
+			Image:  "otel/opentelemetry-collector:0.1.0",
+		}
+
+		rendered, err := PresetConfig(config.New(), LastKnownGoodInstance(rolledBack))
+
+		require.NoError(t, err)
+		assert.Equal(t, "receivers:\n  jaeger:\n", rendered)
+	})
+
+	t.Run("should run the spec without a last known good state", func(t *testing.T) {
+		unknown := *otelcol.DeepCopy()
+		unknown.Annotations = map[string]string{RollbackAnnotation: "true"}
+		unknown.Status.LastKnownGood = nil
+
+		assert.False(t, RolledBack(unknown))
+		condition := RollbackCondition(unknown)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonNoLastKnownGood, condition.Reason)
+	})
+
+	t.Run("should not roll back sidecars", func(t *testing.T) {
+		sidecar := *otelcol.DeepCopy()
+		sidecar.Annotations = map[string]string{RollbackAnnotation: "true"}
+		sidecar.Spec.Mode = v1alpha1.ModeSidecar
+
+		assert.False(t, RolledBack(sidecar))
+		assert.Nil(t, RollbackCondition(sidecar))
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// ActiveSchedule returns the first schedule of the autoscaler of the given instance whose window includes the given
+// time, or nil when none does.
+func ActiveSchedule(otelcol v1alpha1.OpenTelemetryCollector, now time.Time) *v1alpha1.AutoscalerSchedule {
+	if otelcol.Spec.Autoscaler == nil {
+		return nil
+	}
+	for i, schedule := range otelcol.Spec.Autoscaler.Schedules {
+		if _, ok := windowStart(schedule, now); ok {
+			return &otelcol.Spec.Autoscaler.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// NextScheduleChange returns the time left until a window of the schedules of the given instance starts or ends,
+// or false when the instance has no schedules.
+func NextScheduleChange(otelcol v1alpha1.OpenTelemetryCollector, now time.Time) (time.Duration, bool) {
+	if otelcol.Spec.Autoscaler == nil {
+		return 0, false
+	}
+	var next time.Time
+	for _, schedule := range otelcol.Spec.Autoscaler.Schedules {
+		expr, location, err := parseSchedule(schedule)
+		if err != nil {
+			continue
+		}
+		change := expr.Next(now.In(location))
+		if start, ok := windowStart(schedule, now); ok {
+			change = start.Add(schedule.Duration.Duration)
+		}
+		if !change.IsZero() && (next.IsZero() || change.Before(next)) {
+			next = change
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	return next.Sub(now), true
+}
+
+// ScheduledInstance returns the given instance with the replicas bounds of its active schedule, if any, replacing
+// the ones of its autoscaler, and with its replicas kept within these bounds.
+func ScheduledInstance(otelcol v1alpha1.OpenTelemetryCollector, now time.Time) v1alpha1.OpenTelemetryCollector {
+	schedule := ActiveSchedule(otelcol, now)
+	if schedule == nil {
+		return otelcol
+	}
+
+	scheduled := *otelcol.DeepCopy()
+	autoscaler := scheduled.Spec.Autoscaler
+	// the bounds of an autoscaler are only overridden when it's enabled, which a schedule doesn't do by itself
+	if autoscaler.MaxReplicas != nil {
+		if schedule.MinReplicas != nil {
+			autoscaler.MinReplicas = schedule.MinReplicas
+		}
+		if schedule.MaxReplicas != nil {
+			autoscaler.MaxReplicas = schedule.MaxReplicas
+		}
+		if autoscaler.MinReplicas != nil && *autoscaler.MinReplicas > *autoscaler.MaxReplicas {
+			autoscaler.MaxReplicas = autoscaler.MinReplicas
+		}
+	}
+	if scheduled.Spec.Replicas != nil {
+		replicas := *scheduled.Spec.Replicas
+		if schedule.MaxReplicas != nil && replicas > *schedule.MaxReplicas {
+			replicas = *schedule.MaxReplicas
+		}
+		if schedule.MinReplicas != nil && replicas < *schedule.MinReplicas {
+			replicas = *schedule.MinReplicas
+		}
+		scheduled.Spec.Replicas = &replicas
+	}
+	return scheduled
+}
+
+// windowStart returns the start of the window of the schedule including the given time, if any.
+func windowStart(schedule v1alpha1.AutoscalerSchedule, now time.Time) (time.Time, bool) {
+	expr, location, err := parseSchedule(schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	// the first start after the beginning of the window that would end now, which is only in the past when the
+	// window of this start includes now
+	start := expr.Next(now.In(location).Add(-schedule.Duration.Duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+func parseSchedule(schedule v1alpha1.AutoscalerSchedule) (*cronexpr.Expression, *time.Location, error) {
+	expr, err := cronexpr.Parse(schedule.Start)
+	if err != nil {
+		return nil, nil, err
+	}
+	location := time.UTC
+	if len(schedule.TimeZone) > 0 {
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, nil, err
+		}
+	}
+	return expr, location, nil
+}
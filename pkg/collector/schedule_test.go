@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func scheduledInstance() v1alpha1.OpenTelemetryCollector {
+	one, two, three, ten := int32(1), int32(2), int32(3), int32(10)
+	return v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Replicas: &three,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				MinReplicas: &three,
+				MaxReplicas: &ten,
+				Schedules: []v1alpha1.AutoscalerSchedule{
+					{
+						Name:        "night",
+						Start:       "0 20 * * *",
+						Duration:    metav1.Duration{Duration: 10 * time.Hour},
+						TimeZone:    "Europe/Paris",
+						MinReplicas: &one,
+						MaxReplicas: &two,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestActiveSchedule(t *testing.T) {
+	otelcol := scheduledInstance()
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		desc   string
+		now    time.Time
+		active bool
+	}{
+		{desc: "before the window", now: time.Date(2023, 6, 1, 19, 59, 0, 0, paris)},
+		{desc: "start of the window", now: time.Date(2023, 6, 1, 20, 0, 0, 0, paris), active: true},
+		{desc: "after midnight", now: time.Date(2023, 6, 2, 3, 0, 0, 0, paris), active: true},
+		{desc: "end of the window", now: time.Date(2023, 6, 2, 6, 0, 0, 0, paris)},
+		{desc: "other time zone", now: time.Date(2023, 6, 1, 18, 30, 0, 0, time.UTC), active: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			schedule := ActiveSchedule(otelcol, tt.now)
+			if tt.active {
+				require.NotNil(t, schedule)
+				assert.Equal(t, "night", schedule.Name)
+			} else {
+				assert.Nil(t, schedule)
+			}
+		})
+	}
+}
+
+func TestScheduledInstance(t *testing.T) {
+	otelcol := scheduledInstance()
+	night := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	day := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	scheduled := ScheduledInstance(otelcol, night)
+	assert.Equal(t, int32(1), *scheduled.Spec.Autoscaler.MinReplicas)
+	assert.Equal(t, int32(2), *scheduled.Spec.Autoscaler.MaxReplicas)
+	assert.Equal(t, int32(2), *scheduled.Spec.Replicas)
+	// the spec of the instance isn't changed
+	assert.Equal(t, int32(3), *otelcol.Spec.Autoscaler.MinReplicas)
+
+	assert.Equal(t, otelcol, ScheduledInstance(otelcol, day))
+
+	// without autoscaling, only the replicas are bounded
+	otelcol.Spec.Autoscaler.MinReplicas = nil
+	otelcol.Spec.Autoscaler.MaxReplicas = nil
+	scheduled = ScheduledInstance(otelcol, night)
+	assert.Nil(t, scheduled.Spec.Autoscaler.MaxReplicas)
+	assert.Equal(t, int32(2), *scheduled.Spec.Replicas)
+}
+
+func TestNextScheduleChange(t *testing.T) {
+	otelcol := scheduledInstance()
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	next, ok := NextScheduleChange(otelcol, time.Date(2023, 6, 1, 19, 0, 0, 0, paris))
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, next)
+
+	next, ok = NextScheduleChange(otelcol, time.Date(2023, 6, 2, 5, 30, 0, 0, paris))
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Minute, next)
+
+	_, ok = NextScheduleChange(v1alpha1.OpenTelemetryCollector{}, time.Now())
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	secretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+	secretProvidersPath   = "/etc/otelcol/secrets"
+)
+
+// SecretProviderVolumes returns the volumes mounting the secrets of the secret providers of the given instance, which
+// are fetched by the Secrets Store CSI driver. The sidecars don't mount them, as they run in the namespaces of the
+// workloads, which don't have the SecretProviderClasses of the instance.
+func SecretProviderVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	var volumes []corev1.Volume
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return volumes
+	}
+	readOnly := true
+	for _, provider := range otelcol.Spec.SecretProviders {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.SecretProviderVolume(provider.Name),
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   secretsStoreCSIDriver,
+					ReadOnly: &readOnly,
+					VolumeAttributes: map[string]string{
+						"secretProviderClass": provider.SecretProviderClass,
+					},
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// secretProviderVolumeMounts returns the mounts of the secret provider volumes of the given instance.
+func secretProviderVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		return volumeMounts
+	}
+	for _, provider := range otelcol.Spec.SecretProviders {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.SecretProviderVolume(provider.Name),
+			MountPath: path.Join(secretProvidersPath, provider.Name),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	// selfTelemetryProcessor is the resourcedetection processor the self telemetry preset adds to the configuration.
+	selfTelemetryProcessor = "resourcedetection/collector"
+
+	resourceAttributesEnvVar = "OTEL_RESOURCE_ATTRIBUTES"
+	podUIDEnvVar             = "K8S_POD_UID"
+	namespaceNameEnvVar      = "K8S_NAMESPACE_NAME"
+	nodeNameEnvVar           = "K8S_NODE_NAME"
+)
+
+// selfTelemetryAttributes are the resource attributes of the collector's own telemetry, along with the environment
+// variables holding their values.
+var selfTelemetryAttributes = []struct {
+	name   string
+	envVar string
+}{
+	{name: "service.instance.id", envVar: podUIDEnvVar},
+	{name: "k8s.pod.name", envVar: "POD_NAME"},
+	{name: "k8s.pod.uid", envVar: podUIDEnvVar},
+	{name: "k8s.namespace.name", envVar: namespaceNameEnvVar},
+	{name: "k8s.node.name", envVar: nodeNameEnvVar},
+}
+
+// selfTelemetryEnabled returns whether the collector's own telemetry is attributed to its pod.
+func selfTelemetryEnabled(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.SelfTelemetry.ResourceAttributes || otelcol.Spec.SelfTelemetry.ResourceDetection
+}
+
+// selfTelemetryEnvVars returns the environment variables of the resource attributes of the collector's own telemetry
+// that aren't in the given ones yet, the variables set by the user taking precedence.
+func selfTelemetryEnvVars(otelcol v1alpha1.OpenTelemetryCollector, existing []corev1.EnvVar) []corev1.EnvVar {
+	if !selfTelemetryEnabled(otelcol) {
+		return nil
+	}
+
+	var attributes []string
+	for _, attribute := range selfTelemetryAttributes {
+		// the kubelet expands the references to the variables defined before this one
+		attributes = append(attributes, fmt.Sprintf("%s=$(%s)", attribute.name, attribute.envVar))
+	}
+	candidates := []corev1.EnvVar{
+		fieldRefEnvVar(podUIDEnvVar, "metadata.uid"),
+		fieldRefEnvVar(namespaceNameEnvVar, "metadata.namespace"),
+		fieldRefEnvVar(nodeNameEnvVar, "spec.nodeName"),
+		{Name: resourceAttributesEnvVar, Value: strings.Join(attributes, ",")},
+	}
+
+	names := map[string]bool{}
+	for _, envVar := range existing {
+		names[envVar.Name] = true
+	}
+	var envVars []corev1.EnvVar
+	for _, envVar := range candidates {
+		if !names[envVar.Name] {
+			envVars = append(envVars, envVar)
+		}
+	}
+	return envVars
+}
+
+func fieldRefEnvVar(name, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fieldPath,
+			},
+		},
+	}
+}
+
+// selfTelemetrySignals returns the signals of the collector's own telemetry pushed to the OTLP endpoint of the
+// instance, if any.
+func selfTelemetrySignals(otelcol v1alpha1.OpenTelemetryCollector) []v1alpha1.SelfTelemetrySignal {
+	otlp := otelcol.Spec.SelfTelemetry.OTLP
+	if otlp == nil {
+		return nil
+	}
+	if len(otlp.Signals) == 0 {
+		return []v1alpha1.SelfTelemetrySignal{v1alpha1.SelfTelemetrySignalMetrics, v1alpha1.SelfTelemetrySignalLogs, v1alpha1.SelfTelemetrySignalTraces}
+	}
+	return otlp.Signals
+}
+
+// selfTelemetryPushesMetrics returns whether the collector pushes its own metrics, which its metrics port then no
+// longer serves.
+func selfTelemetryPushesMetrics(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	for _, signal := range selfTelemetrySignals(otelcol) {
+		if signal == v1alpha1.SelfTelemetrySignalMetrics {
+			return true
+		}
+	}
+	return false
+}
+
+// selfTelemetryConfig returns the given configuration with the resource attributes of the collector's own telemetry
+// added to service.telemetry.resource, unless they're already set, with the resourcedetection/collector processor
+// when the resource detection is enabled, and with the OTLP exporters of the signals pushed to an OTLP endpoint.
+func selfTelemetryConfig(otelcol v1alpha1.OpenTelemetryCollector, cfg string) (string, error) {
+	if !selfTelemetryEnabled(otelcol) && otelcol.Spec.SelfTelemetry.OTLP == nil {
+		return cfg, nil
+	}
+
+	config, err := adapters.ConfigFromString(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	service, err := configSection(config, "service")
+	if err != nil {
+		return "", err
+	}
+	telemetry, err := configSection(service, "telemetry")
+	if err != nil {
+		return "", err
+	}
+	if selfTelemetryEnabled(otelcol) {
+		resource, err := configSection(telemetry, "resource")
+		if err != nil {
+			return "", err
+		}
+		for _, attribute := range selfTelemetryAttributes {
+			if _, ok := resource[attribute.name]; !ok {
+				resource[attribute.name] = fmt.Sprintf("${%s}", attribute.envVar)
+			}
+		}
+	}
+
+	if otelcol.Spec.SelfTelemetry.ResourceDetection {
+		processors, err := configSection(config, "processors")
+		if err != nil {
+			return "", err
+		}
+		if _, ok := processors[selfTelemetryProcessor]; !ok {
+			processors[selfTelemetryProcessor] = map[string]interface{}{
+				"detectors": []interface{}{"env"},
+				"override":  false,
+			}
+		}
+	}
+
+	if err := selfTelemetryOTLPConfig(otelcol, telemetry); err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// selfTelemetryOTLPConfig appends an OTLP exporter to the metric readers and to the log and span processors of the
+// given service.telemetry section, for the signals pushed to the OTLP endpoint of the instance. The readers and
+// processors of the configuration are kept.
+func selfTelemetryOTLPConfig(otelcol v1alpha1.OpenTelemetryCollector, telemetry map[string]interface{}) error {
+	otlp := otelcol.Spec.SelfTelemetry.OTLP
+	if otlp == nil {
+		return nil
+	}
+
+	protocol := otlp.Protocol
+	if protocol == "" {
+		protocol = v1alpha1.SelfTelemetryProtocolHTTPProtobuf
+	}
+	exporter := map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"protocol": string(protocol),
+			"endpoint": otlp.Endpoint,
+		},
+	}
+
+	for _, signal := range selfTelemetrySignals(otelcol) {
+		section, err := configSection(telemetry, string(signal))
+		if err != nil {
+			return err
+		}
+		switch signal {
+		case v1alpha1.SelfTelemetrySignalMetrics:
+			periodic := map[string]interface{}{
+				"exporter": exporter,
+			}
+			if otlp.MetricsInterval != nil {
+				periodic["interval"] = otlp.MetricsInterval.Milliseconds()
+			}
+			readers, _ := section["readers"].([]interface{})
+			section["readers"] = append(readers, map[string]interface{}{"periodic": periodic})
+		case v1alpha1.SelfTelemetrySignalLogs, v1alpha1.SelfTelemetrySignalTraces:
+			processors, _ := section["processors"].([]interface{})
+			section["processors"] = append(processors, map[string]interface{}{
+				"batch": map[string]interface{}{
+					"exporter": exporter,
+				},
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestSelfTelemetryEnvVars(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Env: []corev1.EnvVar{
+				{Name: "K8S_NODE_NAME", Value: "my-node"},
+			},
+			SelfTelemetry: v1alpha1.SelfTelemetrySpec{
+				ResourceAttributes: true,
+			},
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol, true)
+
+	// verify
+	names := map[string]int{}
+	for _, envVar := range c.Env {
+		names[envVar.Name]++
+	}
+	assert.Equal(t, 1, names["K8S_NODE_NAME"])
+	assert.Equal(t, 1, names["K8S_POD_UID"])
+	assert.Equal(t, 1, names["K8S_NAMESPACE_NAME"])
+	assert.Contains(t, c.Env, corev1.EnvVar{
+		Name:  "OTEL_RESOURCE_ATTRIBUTES",
+		Value: "service.instance.id=$(K8S_POD_UID),k8s.pod.name=$(POD_NAME),k8s.pod.uid=$(K8S_POD_UID),k8s.namespace.name=$(K8S_NAMESPACE_NAME),k8s.node.name=$(K8S_NODE_NAME)",
+	})
+}
+
+func TestSelfTelemetryEnvVarsDisabled(t *testing.T) {
+	// test
+	c := Container(config.New(), logger, v1alpha1.OpenTelemetryCollector{}, true)
+
+	// verify
+	for _, envVar := range c.Env {
+		assert.NotEqual(t, "OTEL_RESOURCE_ATTRIBUTES", envVar.Name)
+	}
+}
+
+func TestSelfTelemetryConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  telemetry:
+    resource:
+      k8s.node.name: my-node
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+			SelfTelemetry: v1alpha1.SelfTelemetrySpec{
+				ResourceDetection: true,
+			},
+		},
+	}
+
+	// test
+	out, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	config := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+	service := config["service"].(map[interface{}]interface{})
+	resource := service["telemetry"].(map[interface{}]interface{})["resource"]
+	assert.Equal(t, map[interface{}]interface{}{
+		"service.instance.id": "${K8S_POD_UID}",
+		"k8s.pod.name":        "${POD_NAME}",
+		"k8s.pod.uid":         "${K8S_POD_UID}",
+		"k8s.namespace.name":  "${K8S_NAMESPACE_NAME}",
+		"k8s.node.name":       "my-node",
+	}, resource)
+	processors := config["processors"].(map[interface{}]interface{})
+	assert.Equal(t, map[interface{}]interface{}{
+		"detectors": []interface{}{"env"},
+		"override":  false,
+	}, processors["resourcedetection/collector"])
+}
+
+func TestSelfTelemetryOTLPConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  telemetry:
+    metrics:
+      readers:
+        - pull:
+            exporter:
+              prometheus:
+                host: 0.0.0.0
+                port: 8888
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+			SelfTelemetry: v1alpha1.SelfTelemetrySpec{
+				OTLP: &v1alpha1.SelfTelemetryOTLPSpec{
+					Endpoint:        "http://gateway.observability:4317",
+					Protocol:        v1alpha1.SelfTelemetryProtocolGRPC,
+					Signals:         []v1alpha1.SelfTelemetrySignal{v1alpha1.SelfTelemetrySignalMetrics, v1alpha1.SelfTelemetrySignalTraces},
+					MetricsInterval: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+	}
+
+	// test
+	out, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	config := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+	telemetry := config["service"].(map[interface{}]interface{})["telemetry"].(map[interface{}]interface{})
+	exporter := map[interface{}]interface{}{
+		"otlp": map[interface{}]interface{}{
+			"protocol": "grpc",
+			"endpoint": "http://gateway.observability:4317",
+		},
+	}
+
+	readers := telemetry["metrics"].(map[interface{}]interface{})["readers"].([]interface{})
+	require.Len(t, readers, 2)
+	assert.Contains(t, readers[0], "pull")
+	assert.Equal(t, map[interface{}]interface{}{
+		"periodic": map[interface{}]interface{}{
+			"exporter": exporter,
+			"interval": 30000,
+		},
+	}, readers[1])
+
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{
+			"batch": map[interface{}]interface{}{
+				"exporter": exporter,
+			},
+		},
+	}, telemetry["traces"].(map[interface{}]interface{})["processors"])
+	assert.NotContains(t, telemetry, "logs")
+	assert.NotContains(t, telemetry, "resource")
+}
+
+func TestSelfTelemetryOTLPConfigDefaults(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+			SelfTelemetry: v1alpha1.SelfTelemetrySpec{
+				OTLP: &v1alpha1.SelfTelemetryOTLPSpec{
+					Endpoint: "https://gateway.example.com:4318",
+				},
+			},
+		},
+	}
+
+	// test
+	out, err := PresetConfig(config.New(), otelcol)
+
+	// verify
+	require.NoError(t, err)
+	config := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+	telemetry := config["service"].(map[interface{}]interface{})["telemetry"].(map[interface{}]interface{})
+	exporter := map[interface{}]interface{}{
+		"otlp": map[interface{}]interface{}{
+			"protocol": "http/protobuf",
+			"endpoint": "https://gateway.example.com:4318",
+		},
+	}
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{
+			"periodic": map[interface{}]interface{}{
+				"exporter": exporter,
+			},
+		},
+	}, telemetry["metrics"].(map[interface{}]interface{})["readers"])
+	for _, signal := range []string{"logs", "traces"} {
+		assert.Equal(t, []interface{}{
+			map[interface{}]interface{}{
+				"batch": map[interface{}]interface{}{
+					"exporter": exporter,
+				},
+			},
+		}, telemetry[signal].(map[interface{}]interface{})["processors"], signal)
+	}
+}
@@ -41,7 +41,29 @@ func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccou
 			Name:        name,
 			Namespace:   otelcol.Namespace,
 			Labels:      labels,
-			Annotations: otelcol.Annotations,
+			Annotations: serviceAccountAnnotations(otelcol),
 		},
 	}
 }
+
+// serviceAccountAnnotations returns the annotations of the service account for the given instance, along with the
+// annotations of its workload identities.
+func serviceAccountAnnotations(otelcol v1alpha1.OpenTelemetryCollector) map[string]string {
+	identityAnnotations := workloadIdentityAnnotations(otelcol)
+	if len(otelcol.Spec.ServiceAccountAnnotations) == 0 && len(identityAnnotations) == 0 {
+		return otelcol.Annotations
+	}
+
+	// new map every time, so that we don't touch the instance's annotations
+	annotations := map[string]string{}
+	for k, v := range otelcol.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range otelcol.Spec.ServiceAccountAnnotations {
+		annotations[k] = v
+	}
+	for k, v := range identityAnnotations {
+		annotations[k] = v
+	}
+	return annotations
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// SidecarTierInstance returns the instance as it runs as the sidecar of the pods of the given tier, with the
+// configuration and resources of the tier. When the configuration of the tier can't be merged, the returned instance
+// keeps the configuration of the given one along with the error.
+func SidecarTierInstance(otelcol v1alpha1.OpenTelemetryCollector, tier v1alpha1.SidecarTier) (v1alpha1.OpenTelemetryCollector, error) {
+	instance := *otelcol.DeepCopy()
+	instance.Spec.SidecarTiers = nil
+	if tier.Resources != nil {
+		instance.Spec.Resources = *tier.Resources.DeepCopy()
+	}
+
+	if tier.Config == "" {
+		return instance, nil
+	}
+	config, err := mergeConfigs(otelcol.Spec.Config, tier.Config)
+	if err != nil {
+		return instance, fmt.Errorf("failed to merge the configuration of the sidecar tier %s: %w", tier.Name, err)
+	}
+	instance.Spec.Config = config
+	return instance, nil
+}
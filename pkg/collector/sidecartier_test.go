@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestSidecarTierInstance(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      v1alpha1.ModeSidecar,
+			Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}},
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+  tail_sampling:
+exporters:
+  otlp:
+    endpoint: gateway:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [tail_sampling, batch]
+      exporters: [otlp]
+`,
+		},
+	}
+	resources := v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}}
+	tier := v1alpha1.SidecarTier{
+		Name:      "small",
+		Resources: &resources,
+		Config: `service:
+  pipelines:
+    traces:
+      processors: [batch]
+`,
+	}
+	otelcol.Spec.SidecarTiers = []v1alpha1.SidecarTier{tier}
+
+	// test
+	instance, err := SidecarTierInstance(otelcol, tier)
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, instance.Spec.SidecarTiers)
+	assert.Equal(t, resources, instance.Spec.Resources)
+
+	config, err := adapters.ConfigFromString(instance.Spec.Config)
+	require.NoError(t, err)
+	pipelines := config["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	traces := pipelines["traces"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"batch"}, traces["processors"])
+	assert.Equal(t, []interface{}{"otlp"}, traces["receivers"])
+
+	// the instance is left untouched
+	assert.Len(t, otelcol.Spec.SidecarTiers, 1)
+	assert.Equal(t, resource.MustParse("2"), otelcol.Spec.Resources.Limits[v1.ResourceCPU])
+}
+
+func TestSidecarTierInstanceInvalidConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers:\n  otlp:\n",
+		},
+	}
+
+	// test
+	instance, err := SidecarTierInstance(otelcol, v1alpha1.SidecarTier{Name: "small", Config: "🦄"})
+
+	// verify
+	assert.Error(t, err)
+	assert.Equal(t, otelcol.Spec.Config, instance.Spec.Config)
+}
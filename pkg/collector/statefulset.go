@@ -23,15 +23,64 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 )
 
+// TargetAllocatorServiceEnvVar is the environment variable holding the TargetAllocator service of the availability
+// zone of the collectors of topology-aware instances, which their http_sd_configs point to.
+const TargetAllocatorServiceEnvVar = "TARGET_ALLOCATOR_SERVICE"
+
+// StatefulSets builds the statefulsets for the given instance, one for each availability zone when its
+// TargetAllocator is topology-aware.
+func StatefulSets(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []appsv1.StatefulSet {
+	zones := targetallocator.Zones(otelcol)
+	if len(zones) == 0 {
+		return []appsv1.StatefulSet{StatefulSet(cfg, logger, otelcol)}
+	}
+
+	statefulSets := make([]appsv1.StatefulSet, 0, len(zones))
+	for _, zone := range zones {
+		statefulSet := StatefulSet(cfg, logger, otelcol)
+		name := naming.CollectorZone(otelcol, zone)
+		labels := targetallocator.ZoneLabels(Labels(otelcol, name, cfg.LabelsFilter()), zone)
+
+		statefulSet.Name = name
+		statefulSet.Labels = labels
+		statefulSet.Spec.Selector.MatchLabels = targetallocator.ZoneLabels(statefulSet.Spec.Selector.MatchLabels, zone)
+		statefulSet.Spec.Template.Labels = podLabels(otelcol, labels)
+		statefulSet.Spec.Template.Spec.NodeSelector = targetallocator.ZoneLabels(statefulSet.Spec.Template.Spec.NodeSelector, zone)
+		container := &statefulSet.Spec.Template.Spec.Containers[0]
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  TargetAllocatorServiceEnvVar,
+			Value: naming.TAServiceZone(otelcol, zone),
+		})
+		statefulSets = append(statefulSets, statefulSet)
+	}
+	return statefulSets
+}
+
+// StatefulSetNames returns the names of the statefulsets of the given instance.
+func StatefulSetNames(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	zones := targetallocator.Zones(otelcol)
+	if len(zones) == 0 {
+		return []string{naming.Collector(otelcol)}
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, naming.CollectorZone(otelcol, zone))
+	}
+	return names
+}
+
 // StatefulSet builds the statefulset for the given instance.
 func StatefulSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) appsv1.StatefulSet {
+	otelcol = compatibleInstance(otelcol)
 	name := naming.Collector(otelcol)
 	labels := Labels(otelcol, name, cfg.LabelsFilter())
 
-	annotations := Annotations(otelcol)
-	podAnnotations := PodAnnotations(otelcol)
+	annotations := Annotations(cfg, otelcol)
+	podAnnotations := PodAnnotations(cfg, otelcol)
 
 	return appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -47,23 +96,26 @@ func StatefulSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTel
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels(otelcol, labels),
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol, true)},
-					Volumes:            Volumes(cfg, otelcol),
-					DNSPolicy:          getDNSPolicy(otelcol),
-					HostNetwork:        otelcol.Spec.HostNetwork,
-					Tolerations:        otelcol.Spec.Tolerations,
-					NodeSelector:       otelcol.Spec.NodeSelector,
-					SecurityContext:    otelcol.Spec.PodSecurityContext,
-					PriorityClassName:  otelcol.Spec.PriorityClassName,
-					Affinity:           otelcol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(otelcol),
+					Containers:                    []corev1.Container{Container(cfg, logger, otelcol, true)},
+					Volumes:                       Volumes(cfg, otelcol),
+					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.PodDNSConfig,
+					HostNetwork:                   otelcol.Spec.HostNetwork,
+					ShareProcessNamespace:         otelcol.Spec.ShareProcessNamespace,
+					Tolerations:                   otelcol.Spec.Tolerations,
+					NodeSelector:                  otelcol.Spec.NodeSelector,
+					SecurityContext:               podSecurityContext(otelcol),
+					PriorityClassName:             otelcol.Spec.PriorityClassName,
+					Affinity:                      otelcol.Spec.Affinity,
+					TerminationGracePeriodSeconds: otelcol.Spec.TerminationGracePeriodSeconds,
 				},
 			},
-			Replicas:             otelcol.Spec.Replicas,
+			Replicas:             replicas(otelcol),
 			PodManagementPolicy:  "Parallel",
 			VolumeClaimTemplates: VolumeClaimTemplates(otelcol),
 		},
@@ -299,6 +299,58 @@ func TestStatefulSetNodeSelector(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.NodeSelector, map[string]string{"node-key": "node-value"})
 }
 
+func TestStatefulSetsZones(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeStatefulSet,
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:       true,
+				TopologyAware: &v1alpha1.TopologyAwareSpec{Zones: []string{"eu-west-1a", "eu-west-1b"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	statefulSets := StatefulSets(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, []string{"my-instance-collector-eu-west-1a", "my-instance-collector-eu-west-1b"}, StatefulSetNames(otelcol))
+	assert.Len(t, statefulSets, 2)
+	for i, zone := range []string{"eu-west-1a", "eu-west-1b"} {
+		ss := statefulSets[i]
+		assert.Equal(t, "my-instance-collector-"+zone, ss.Name)
+		assert.Equal(t, zone, ss.Spec.Selector.MatchLabels["topology.kubernetes.io/zone"])
+		assert.Equal(t, zone, ss.Spec.Template.Labels["topology.kubernetes.io/zone"])
+		assert.Equal(t, map[string]string{"topology.kubernetes.io/zone": zone}, ss.Spec.Template.Spec.NodeSelector)
+		assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  TargetAllocatorServiceEnvVar,
+			Value: "my-instance-targetallocator-" + zone,
+		})
+	}
+}
+
+func TestStatefulSetsNoZones(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+	cfg := config.New()
+
+	// test
+	statefulSets := StatefulSets(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, []appsv1.StatefulSet{StatefulSet(cfg, logger, otelcol)}, statefulSets)
+	assert.Equal(t, []string{"my-instance-collector"}, StatefulSetNames(otelcol))
+}
+
 func TestStatefulSetPriorityClassName(t *testing.T) {
 	otelcol1 := v1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
@@ -355,3 +407,31 @@ func TestStatefulSetAffinity(t *testing.T) {
 	assert.NotNil(t, sts2.Spec.Template.Spec.Affinity)
 	assert.Equal(t, *testAffinityValue, *sts2.Spec.Template.Spec.Affinity)
 }
+
+func TestStatefulSetTerminationGracePeriodSeconds(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := StatefulSet(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	gracePeriodSec := int64(60)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-terminationGracePeriodSeconds",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TerminationGracePeriodSeconds: &gracePeriodSec,
+		},
+	}
+
+	d2 := StatefulSet(cfg, logger, otelcol2)
+	assert.NotNil(t, d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, gracePeriodSec, *d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
@@ -0,0 +1,425 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	// TenantsConnector is the connector the pipelines of a gateway collector export to, for their telemetry to be
+	// routed to the backends of the tenants of the collector. It's replaced by a routing connector per signal.
+	TenantsConnector = "routing/tenants"
+
+	// tenantsRouteAttribute is the resource attribute the telemetry is routed to the tenants by.
+	tenantsRouteAttribute = "k8s.namespace.name"
+
+	defaultTenantCredentialsHeader = "Authorization"
+)
+
+// ConditionTypeTenantsRouted is the type of the status condition reporting whether the telemetry of the tenants of a
+// gateway collector is routed to their backends.
+const ConditionTypeTenantsRouted = "TenantsRouted"
+
+// Reasons of the TenantsRouted condition.
+const (
+	ReasonTenantsRouted  = "TenantsRouted"
+	ReasonInvalidTenants = "InvalidTenants"
+)
+
+// tenantSignals are the signals routed to the tenants.
+var tenantSignals = []string{"traces", "metrics", "logs"}
+
+// TenantInstance returns the given instance with the pipelines of the given tenants generated in its configuration:
+// the pipelines exporting to the routing/tenants connector export to a routing connector of their signal instead,
+// whose table routes the telemetry of the namespaces of each tenant to a pipeline of the tenant, exporting to its
+// backend. The credentials of the backends are read from their secrets through environment variables.
+//
+// The namespaces claimed by several tenants are routed to the oldest one, and the tenants whose pipelines or exporter
+// are already configured are left out. The tenants not fully routed are returned with the reason. When no tenant is left to route, the routing/tenants connector is removed from
+// the pipelines, and so are the pipelines left without exporters. An error is returned when no configuration can be
+// generated, e.g. when no pipeline would be left.
+func TenantInstance(otelcol v1alpha1.OpenTelemetryCollector, tenants []v1alpha1.TelemetryTenant) (v1alpha1.OpenTelemetryCollector, map[string]string, error) {
+	leftOut := map[string]string{}
+	// the configurations without tenants aren't parsed, they're most of them
+	if len(tenants) == 0 && !strings.Contains(otelcol.Spec.Config, TenantsConnector) {
+		return otelcol, leftOut, nil
+	}
+
+	config, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	connectors, err := configSection(config, "connectors")
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	exporters, err := configSection(config, "exporters")
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	service, err := configSection(config, "service")
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	pipelines, err := configSection(service, "pipelines")
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	for _, name := range append([]string{TenantsConnector}, tenantsSignalConnectors()...) {
+		if _, ok := connectors[name]; ok {
+			return otelcol, leftOut, fmt.Errorf("the %s connector is generated from the tenants, it can't be configured", name)
+		}
+	}
+
+	tenants = routedTenants(tenants, pipelines, exporters, leftOut)
+	if len(tenants) == 0 {
+		removed, err := removeTenantsConnector(connectors, pipelines)
+		if err != nil || !removed {
+			return otelcol, leftOut, err
+		}
+		if len(pipelines) == 0 {
+			return otelcol, leftOut, fmt.Errorf("no pipeline is left without the %s connector, which has no tenant to route to", TenantsConnector)
+		}
+		tenanted := *otelcol.DeepCopy()
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return otelcol, leftOut, err
+		}
+		tenanted.Spec.Config = string(out)
+		return tenanted, leftOut, nil
+	}
+
+	routed := false
+	for _, signal := range tenantSignals {
+		connector := tenantsSignalConnector(signal)
+		found := false
+		for _, name := range sortedNames(pipelines) {
+			if name != signal && !strings.HasPrefix(name, signal+"/") {
+				continue
+			}
+			pipeline, ok := pipelines[name].(map[string]interface{})
+			if !ok {
+				return otelcol, leftOut, fmt.Errorf("the %s pipeline isn't a map", name)
+			}
+			names, _ := pipeline["exporters"].([]interface{})
+			for i, exporter := range names {
+				if exporter == TenantsConnector {
+					names[i] = connector
+					found = true
+				}
+			}
+		}
+		if !found {
+			continue
+		}
+		routed = true
+
+		var table []interface{}
+		for _, tenant := range tenants {
+			pipeline := tenantPipeline(signal, tenant.Name)
+			pipelines[pipeline] = map[string]interface{}{
+				"receivers": []interface{}{connector},
+				"exporters": []interface{}{tenantExporter(tenant.Name)},
+			}
+			for _, namespace := range tenant.Spec.Namespaces {
+				table = append(table, map[string]interface{}{
+					"value":     namespace,
+					"pipelines": []interface{}{pipeline},
+				})
+			}
+		}
+		connectors[connector] = map[string]interface{}{
+			"attribute_source": "resource",
+			"from_attribute":   tenantsRouteAttribute,
+			"table":            table,
+		}
+	}
+	if !routed {
+		for _, tenant := range tenants {
+			leftOut[tenant.Name] = fmt.Sprintf("the configuration has no pipeline exporting to the %s connector", TenantsConnector)
+		}
+		return otelcol, leftOut, nil
+	}
+
+	tenanted := *otelcol.DeepCopy()
+	for _, tenant := range tenants {
+		exporter := map[string]interface{}{
+			"endpoint": tenant.Spec.Backend.Endpoint,
+		}
+		if secret := tenant.Spec.Backend.CredentialsSecret; secret != nil {
+			env := tenantCredentialsEnv(tenant.Name)
+			header := defaultString(tenant.Spec.Backend.CredentialsHeader, defaultTenantCredentialsHeader)
+			exporter["headers"] = map[string]interface{}{
+				header: fmt.Sprintf("${env:%s}", env),
+			}
+			tenanted.Spec.Env = append(tenanted.Spec.Env, corev1.EnvVar{
+				Name: env,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: secret.DeepCopy(),
+				},
+			})
+		}
+		exporters[tenantExporter(tenant.Name)] = exporter
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return otelcol, leftOut, err
+	}
+	tenanted.Spec.Config = string(out)
+	return tenanted, leftOut, nil
+}
+
+// TenantsCondition returns the TenantsRouted condition of the given instance from its tenants, the ones not fully
+// routed with the reason, and the error generating the pipelines of the tenants, or nil when it has no tenants.
+func TenantsCondition(otelcol v1alpha1.OpenTelemetryCollector, tenants []v1alpha1.TelemetryTenant, leftOut map[string]string, err error) *metav1.Condition {
+	if len(tenants) == 0 && len(leftOut) == 0 && err == nil {
+		return nil
+	}
+	condition := &metav1.Condition{
+		Type:               ConditionTypeTenantsRouted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             ReasonTenantsRouted,
+		Message:            "the telemetry of the tenants is routed to their backends",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonInvalidTenants
+		condition.Message = fmt.Sprintf("the pipelines of the tenants can't be generated, the configuration isn't rolled out: %s", err)
+		return condition
+	}
+	if len(leftOut) > 0 {
+		names := make([]string, 0, len(leftOut))
+		for name := range leftOut {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		reasons := make([]string, len(names))
+		for i, name := range names {
+			reasons[i] = fmt.Sprintf("%s: %s", name, leftOut[name])
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonInvalidTenants
+		condition.Message = fmt.Sprintf("some tenants aren't fully routed: %s", strings.Join(reasons, "; "))
+	}
+	return condition
+}
+
+// routedTenants returns the given tenants with namespaces to route, sorted by name. The namespaces claimed by several
+// tenants are routed to the oldest one, and left out of the others. The tenants whose pipelines or exporter are
+// already in the given configuration sections are left out. The tenants and namespaces left out are added to leftOut
+// with the reason.
+func routedTenants(tenants []v1alpha1.TelemetryTenant, pipelines, exporters map[string]interface{}, leftOut map[string]string) []v1alpha1.TelemetryTenant {
+	sorted := append([]v1alpha1.TelemetryTenant{}, tenants...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	owners := map[string]string{}
+	var routed []v1alpha1.TelemetryTenant
+	for _, tenant := range sorted {
+		// the tenants without namespaces, e.g. whose namespace selector selects none yet, have nothing to route
+		if len(tenant.Spec.Namespaces) == 0 {
+			continue
+		}
+		if reason := tenantConflict(tenant, pipelines, exporters); len(reason) > 0 {
+			leftOut[tenant.Name] = reason
+			continue
+		}
+
+		var namespaces, claimed []string
+		for _, namespace := range tenant.Spec.Namespaces {
+			if owner, ok := owners[namespace]; ok {
+				claimed = append(claimed, fmt.Sprintf("the namespace %s belongs to the %s tenant", namespace, owner))
+				continue
+			}
+			owners[namespace] = tenant.Name
+			namespaces = append(namespaces, namespace)
+		}
+		if len(claimed) > 0 {
+			leftOut[tenant.Name] = strings.Join(claimed, ", ")
+		}
+		if len(namespaces) == 0 {
+			continue
+		}
+		tenant = *tenant.DeepCopy()
+		tenant.Spec.Namespaces = namespaces
+		routed = append(routed, tenant)
+	}
+
+	sort.Slice(routed, func(i, j int) bool {
+		return routed[i].Name < routed[j].Name
+	})
+	return routed
+}
+
+// tenantConflict returns why the given tenant can't be routed given the sections of the configuration, or an empty
+// string.
+func tenantConflict(tenant v1alpha1.TelemetryTenant, pipelines, exporters map[string]interface{}) string {
+	name := tenantExporter(tenant.Name)
+	if _, ok := exporters[name]; ok {
+		return fmt.Sprintf("the %s exporter is generated for the tenant, it can't be configured", name)
+	}
+	for _, signal := range tenantSignals {
+		name := tenantPipeline(signal, tenant.Name)
+		if _, ok := pipelines[name]; ok {
+			return fmt.Sprintf("the %s pipeline is generated for the tenant, it can't be configured", name)
+		}
+	}
+	return ""
+}
+
+// removeTenantsConnector removes the routing/tenants connector from the exporters of the given pipelines, and the
+// pipelines left without exporters. The connectors these pipelines were the only ones to receive from are removed from
+// the exporters of the other pipelines in turn. It returns whether the pipelines changed.
+func removeTenantsConnector(connectors, pipelines map[string]interface{}) (bool, error) {
+	changed := false
+	removed := map[string]bool{TenantsConnector: true}
+	for len(removed) > 0 {
+		for _, name := range sortedNames(pipelines) {
+			pipeline, ok := pipelines[name].(map[string]interface{})
+			if !ok {
+				return false, fmt.Errorf("the %s pipeline isn't a map", name)
+			}
+			names, _ := pipeline["exporters"].([]interface{})
+			var kept []interface{}
+			for _, exporter := range names {
+				if exporterName, _ := exporter.(string); !removed[exporterName] {
+					kept = append(kept, exporter)
+				}
+			}
+			if len(kept) == len(names) {
+				continue
+			}
+			changed = true
+			if len(kept) == 0 {
+				delete(pipelines, name)
+				continue
+			}
+			pipeline["exporters"] = kept
+		}
+
+		// the connectors exported to are also received from
+		received := map[string]bool{}
+		exported := map[string]bool{}
+		for _, p := range pipelines {
+			pipeline, _ := p.(map[string]interface{})
+			for _, section := range []struct {
+				key   string
+				names map[string]bool
+			}{{"receivers", received}, {"exporters", exported}} {
+				names, _ := pipeline[section.key].([]interface{})
+				for _, n := range names {
+					if name, ok := n.(string); ok {
+						section.names[name] = true
+					}
+				}
+			}
+		}
+		removed = map[string]bool{}
+		for name := range connectors {
+			if exported[name] && !received[name] {
+				removed[name] = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// TenantSelects returns whether the namespace selector of the given tenant selects the given namespace.
+func TenantSelects(tenant v1alpha1.TelemetryTenant, namespace corev1.Namespace) (bool, error) {
+	if tenant.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(tenant.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector of the %s tenant: %w", tenant.Name, err)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// SelectedTenant returns the given tenant with the namespaces selected by its namespace selector among the given
+// namespaces added to its namespaces.
+func SelectedTenant(tenant v1alpha1.TelemetryTenant, namespaces []corev1.Namespace) (v1alpha1.TelemetryTenant, error) {
+	if tenant.Spec.NamespaceSelector == nil {
+		return tenant, nil
+	}
+	selected := *tenant.DeepCopy()
+	listed := map[string]bool{}
+	for _, namespace := range selected.Spec.Namespaces {
+		listed[namespace] = true
+	}
+	var names []string
+	for _, namespace := range namespaces {
+		ok, err := TenantSelects(tenant, namespace)
+		if err != nil {
+			return tenant, err
+		}
+		if ok && !listed[namespace.Name] {
+			names = append(names, namespace.Name)
+		}
+	}
+	sort.Strings(names)
+	selected.Spec.Namespaces = append(selected.Spec.Namespaces, names...)
+	return selected, nil
+}
+
+// tenantsSignalConnector returns the name of the routing connector routing the given signal to the tenants.
+func tenantsSignalConnector(signal string) string {
+	return fmt.Sprintf("%s-%s", TenantsConnector, signal)
+}
+
+// tenantPipeline returns the name of the pipeline of the given signal of a tenant.
+func tenantPipeline(signal, tenant string) string {
+	return fmt.Sprintf("%s/tenant-%s", signal, tenant)
+}
+
+// tenantExporter returns the name of the exporter to the backend of a tenant.
+func tenantExporter(tenant string) string {
+	return fmt.Sprintf("otlp/tenant-%s", tenant)
+}
+
+// tenantsSignalConnectors returns the names of the routing connectors of all the signals.
+func tenantsSignalConnectors() []string {
+	names := make([]string, len(tenantSignals))
+	for i, signal := range tenantSignals {
+		names[i] = tenantsSignalConnector(signal)
+	}
+	return names
+}
+
+// tenantCredentialsEnv returns the name of the environment variable holding the credentials of the backend of a tenant.
+// The hash of the name of the tenant tells apart the tenants whose names only differ by their dashes and dots.
+func tenantCredentialsEnv(tenant string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tenant))
+	return fmt.Sprintf("OTEL_TENANT_%s_%08X_CREDENTIALS", strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(tenant)), h.Sum32())
+}
@@ -0,0 +1,497 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func gatewayInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  k8sattributes:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes]
+      exporters: [routing/tenants]
+    logs:
+      receivers: [otlp]
+      processors: [k8sattributes]
+      exporters: [debug, routing/tenants]
+`,
+		},
+	}
+}
+
+func tenant(name string, namespaces ...string) v1alpha1.TelemetryTenant {
+	return v1alpha1.TelemetryTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TelemetryTenantSpec{
+			Collector:  "gateway",
+			Namespaces: namespaces,
+			Backend: v1alpha1.TelemetryTenantBackend{
+				Endpoint: name + ".backend.example.com:4317",
+			},
+		},
+	}
+}
+
+func TestTenantInstance(t *testing.T) {
+	teamA := tenant("team-a", "shop", "payments")
+	teamA.Spec.Backend.CredentialsSecret = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "team-a-backend"},
+		Key:                  "token",
+	}
+	teamB := tenant("team-b", "search")
+	teamB.Spec.Backend.CredentialsSecret = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "team-b-backend"},
+		Key:                  "api-key",
+	}
+	teamB.Spec.Backend.CredentialsHeader = "X-API-Key"
+	otelcol := gatewayInstance()
+
+	tenanted, leftOut, err := TenantInstance(otelcol, []v1alpha1.TelemetryTenant{teamB, teamA})
+	require.NoError(t, err)
+	assert.Empty(t, leftOut)
+
+	config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"routing/tenants-traces": map[string]interface{}{
+			"attribute_source": "resource",
+			"from_attribute":   "k8s.namespace.name",
+			"table": []interface{}{
+				map[string]interface{}{"value": "shop", "pipelines": []interface{}{"traces/tenant-team-a"}},
+				map[string]interface{}{"value": "payments", "pipelines": []interface{}{"traces/tenant-team-a"}},
+				map[string]interface{}{"value": "search", "pipelines": []interface{}{"traces/tenant-team-b"}},
+			},
+		},
+		"routing/tenants-logs": map[string]interface{}{
+			"attribute_source": "resource",
+			"from_attribute":   "k8s.namespace.name",
+			"table": []interface{}{
+				map[string]interface{}{"value": "shop", "pipelines": []interface{}{"logs/tenant-team-a"}},
+				map[string]interface{}{"value": "payments", "pipelines": []interface{}{"logs/tenant-team-a"}},
+				map[string]interface{}{"value": "search", "pipelines": []interface{}{"logs/tenant-team-b"}},
+			},
+		},
+	}, config["connectors"])
+
+	exporters := config["exporters"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "team-a.backend.example.com:4317",
+		"headers":  map[string]interface{}{"Authorization": "${env:OTEL_TENANT_TEAM_A_26DB73D6_CREDENTIALS}"},
+	}, exporters["otlp/tenant-team-a"])
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "team-b.backend.example.com:4317",
+		"headers":  map[string]interface{}{"X-API-Key": "${env:OTEL_TENANT_TEAM_B_25DB7243_CREDENTIALS}"},
+	}, exporters["otlp/tenant-team-b"])
+
+	pipelines := config["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Len(t, pipelines, 6)
+	assert.Equal(t, []interface{}{"routing/tenants-traces"}, pipelines["traces"].(map[string]interface{})["exporters"])
+	assert.Equal(t, []interface{}{"debug", "routing/tenants-logs"}, pipelines["logs"].(map[string]interface{})["exporters"])
+	assert.Equal(t, map[string]interface{}{
+		"receivers": []interface{}{"routing/tenants-traces"},
+		"exporters": []interface{}{"otlp/tenant-team-a"},
+	}, pipelines["traces/tenant-team-a"])
+	assert.Contains(t, pipelines, "logs/tenant-team-b")
+	assert.NotContains(t, pipelines, "metrics/tenant-team-a")
+
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:      "OTEL_TENANT_TEAM_A_26DB73D6_CREDENTIALS",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: teamA.Spec.Backend.CredentialsSecret},
+		},
+		{
+			Name:      "OTEL_TENANT_TEAM_B_25DB7243_CREDENTIALS",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: teamB.Spec.Backend.CredentialsSecret},
+		},
+	}, tenanted.Spec.Env)
+
+	// the given instance is left as it is
+	assert.Equal(t, gatewayInstance(), otelcol)
+}
+
+func TestTenantInstanceWithoutTenants(t *testing.T) {
+	otelcol := gatewayInstance()
+	tenanted, leftOut, err := TenantInstance(otelcol, nil)
+	require.NoError(t, err)
+	assert.Empty(t, leftOut)
+
+	// the pipelines only exporting to the tenants are removed
+	config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"logs": map[string]interface{}{
+			"receivers":  []interface{}{"otlp"},
+			"processors": []interface{}{"k8sattributes"},
+			"exporters":  []interface{}{"debug"},
+		},
+	}, config["service"].(map[string]interface{})["pipelines"])
+
+	// the configurations without the tenants connector are kept as they are
+	otelcol.Spec.Config = "receivers:\n  otlp:\n"
+	tenanted, _, err = TenantInstance(otelcol, nil)
+	require.NoError(t, err)
+	assert.Equal(t, otelcol, tenanted)
+}
+
+func TestTenantInstanceWithoutTenantsConnectors(t *testing.T) {
+	otelcol := gatewayInstance()
+	otelcol.Spec.Config = `receivers:
+  otlp:
+connectors:
+  forward:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces/in:
+      receivers: [otlp]
+      exporters: [forward, debug]
+    traces/out:
+      receivers: [forward]
+      exporters: [routing/tenants]
+`
+	tenanted, _, err := TenantInstance(otelcol, nil)
+	require.NoError(t, err)
+
+	// the forward connector has no pipeline to receive from anymore
+	config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"traces/in": map[string]interface{}{
+			"receivers": []interface{}{"otlp"},
+			"exporters": []interface{}{"debug"},
+		},
+	}, config["service"].(map[string]interface{})["pipelines"])
+}
+
+func TestTenantInstanceWithoutCredentials(t *testing.T) {
+	tenanted, _, err := TenantInstance(gatewayInstance(), []v1alpha1.TelemetryTenant{tenant("team-a", "shop")})
+	require.NoError(t, err)
+
+	config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "team-a.backend.example.com:4317",
+	}, config["exporters"].(map[string]interface{})["otlp/tenant-team-a"])
+	assert.Empty(t, tenanted.Spec.Env)
+}
+
+func TestTenantInstanceCredentialsEnv(t *testing.T) {
+	teamA := tenant("team-a", "shop")
+	teamA.Spec.Backend.CredentialsSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "team-a-backend"}, Key: "token"}
+	teamADot := tenant("team.a", "search")
+	teamADot.Spec.Backend.CredentialsSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "team.a-backend"}, Key: "token"}
+
+	tenanted, _, err := TenantInstance(gatewayInstance(), []v1alpha1.TelemetryTenant{teamA, teamADot})
+	require.NoError(t, err)
+
+	require.Len(t, tenanted.Spec.Env, 2)
+	assert.Equal(t, "OTEL_TENANT_TEAM_A_26DB73D6_CREDENTIALS", tenanted.Spec.Env[0].Name)
+	assert.Equal(t, "OTEL_TENANT_TEAM_A_0EDF1FA5_CREDENTIALS", tenanted.Spec.Env[1].Name)
+}
+
+func withNamespaces(tenant v1alpha1.TelemetryTenant, namespaces ...string) v1alpha1.TelemetryTenant {
+	tenant.Spec.Namespaces = namespaces
+	return tenant
+}
+
+func TestTenantInstanceLeftOut(t *testing.T) {
+	older := tenant("team-b", "search", "shop")
+	older.CreationTimestamp = metav1.NewTime(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+	newer := tenant("team-a", "shop")
+	newer.CreationTimestamp = metav1.NewTime(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC))
+
+	for _, tt := range []struct {
+		name            string
+		config          string
+		tenants         []v1alpha1.TelemetryTenant
+		expectedLeftOut map[string]string
+		expectedRouted  []string
+	}{
+		{
+			name:            "namespace of an older tenant",
+			tenants:         []v1alpha1.TelemetryTenant{newer, older, tenant("team-c", "checkout")},
+			expectedLeftOut: map[string]string{"team-a": "the namespace shop belongs to the team-b tenant"},
+			expectedRouted:  []string{"team-b", "team-c"},
+		},
+		{
+			name:            "namespace of an older tenant among others",
+			tenants:         []v1alpha1.TelemetryTenant{withNamespaces(newer, "shop", "payments"), older},
+			expectedLeftOut: map[string]string{"team-a": "the namespace shop belongs to the team-b tenant"},
+			expectedRouted:  []string{"team-a", "team-b"},
+		},
+		{
+			name: "no pipeline exporting to the tenants",
+			config: `receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			tenants:         []v1alpha1.TelemetryTenant{tenant("team-a", "shop")},
+			expectedLeftOut: map[string]string{"team-a": "the configuration has no pipeline exporting to the routing/tenants connector"},
+		},
+		{
+			name: "configured tenant exporter",
+			config: `receivers:
+  otlp:
+exporters:
+  otlp/tenant-team-a:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing/tenants]
+`,
+			tenants:         []v1alpha1.TelemetryTenant{tenant("team-a", "shop"), tenant("team-b", "search")},
+			expectedLeftOut: map[string]string{"team-a": "the otlp/tenant-team-a exporter is generated for the tenant, it can't be configured"},
+			expectedRouted:  []string{"team-b"},
+		},
+		{
+			name: "configured tenant pipeline",
+			config: `receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing/tenants]
+    logs/tenant-team-a:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			tenants:         []v1alpha1.TelemetryTenant{tenant("team-a", "shop")},
+			expectedLeftOut: map[string]string{"team-a": "the logs/tenant-team-a pipeline is generated for the tenant, it can't be configured"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := gatewayInstance()
+			if len(tt.config) > 0 {
+				otelcol.Spec.Config = tt.config
+			}
+			tenanted, leftOut, err := TenantInstance(otelcol, tt.tenants)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLeftOut, leftOut)
+
+			config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+			require.NoError(t, err)
+			// the tenants with a pipeline receiving from the routing connector of the traces
+			pipelines := config["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+			var routed []string
+			for _, tenant := range []string{"team-a", "team-b", "team-c"} {
+				if pipeline, ok := pipelines["traces/tenant-"+tenant].(map[string]interface{}); ok && pipeline["receivers"].([]interface{})[0] == "routing/tenants-traces" {
+					routed = append(routed, tenant)
+				}
+			}
+			assert.Equal(t, tt.expectedRouted, routed)
+
+			// the namespaces are routed to a single tenant
+			connectors, _ := config["connectors"].(map[string]interface{})
+			if connector, ok := connectors["routing/tenants-traces"].(map[string]interface{}); ok {
+				routes := map[interface{}]int{}
+				for _, route := range connector["table"].([]interface{}) {
+					routes[route.(map[string]interface{})["value"]]++
+				}
+				for namespace, count := range routes {
+					assert.Equal(t, 1, count, namespace)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantInstanceErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      string
+		tenants     []v1alpha1.TelemetryTenant
+		expectedErr string
+	}{
+		{
+			name: "configured tenants connector",
+			config: `receivers:
+  otlp:
+connectors:
+  routing/tenants:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing/tenants]
+`,
+			tenants:     []v1alpha1.TelemetryTenant{tenant("team-a", "shop")},
+			expectedErr: "the routing/tenants connector is generated from the tenants, it can't be configured",
+		},
+		{
+			name: "configured signal connector",
+			config: `receivers:
+  otlp:
+connectors:
+  routing/tenants-traces:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing/tenants]
+`,
+			tenants:     []v1alpha1.TelemetryTenant{tenant("team-a", "shop")},
+			expectedErr: "the routing/tenants-traces connector is generated from the tenants, it can't be configured",
+		},
+		{
+			name: "no pipeline left without tenants",
+			config: `receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [routing/tenants]
+`,
+			expectedErr: "no pipeline is left without the routing/tenants connector, which has no tenant to route to",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := gatewayInstance()
+			otelcol.Spec.Config = tt.config
+			tenanted, _, err := TenantInstance(otelcol, tt.tenants)
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Equal(t, otelcol, tenanted)
+		})
+	}
+}
+
+func TestTenantInstanceWithoutNamespaces(t *testing.T) {
+	teamA := tenant("team-a")
+	teamA.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-a"}}
+
+	otelcol := gatewayInstance()
+	tenanted, leftOut, err := TenantInstance(otelcol, []v1alpha1.TelemetryTenant{teamA})
+	require.NoError(t, err)
+	assert.Empty(t, leftOut)
+	withoutTenants, _, err := TenantInstance(otelcol, nil)
+	require.NoError(t, err)
+	assert.Equal(t, withoutTenants, tenanted)
+
+	// none of the selectors selects a namespace
+	teamB := tenant("team-b")
+	teamB.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-b"}}
+	tenanted, leftOut, err = TenantInstance(otelcol, []v1alpha1.TelemetryTenant{teamA, teamB})
+	require.NoError(t, err)
+	assert.Empty(t, leftOut)
+	assert.Equal(t, withoutTenants, tenanted)
+
+	tenanted, _, err = TenantInstance(otelcol, []v1alpha1.TelemetryTenant{teamA, tenant("team-b", "search")})
+	require.NoError(t, err)
+	config, err := adapters.ConfigFromString(tenanted.Spec.Config)
+	require.NoError(t, err)
+	assert.Contains(t, config["exporters"], "otlp/tenant-team-b")
+	assert.NotContains(t, config["exporters"], "otlp/tenant-team-a")
+}
+
+func TestTenantsCondition(t *testing.T) {
+	otelcol := gatewayInstance()
+	otelcol.Generation = 3
+	tenants := []v1alpha1.TelemetryTenant{tenant("team-a", "shop"), tenant("team-b", "shop")}
+
+	assert.Nil(t, TenantsCondition(otelcol, nil, map[string]string{}, nil))
+
+	condition := TenantsCondition(otelcol, tenants, map[string]string{}, nil)
+	require.NotNil(t, condition)
+	assert.Equal(t, ConditionTypeTenantsRouted, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonTenantsRouted, condition.Reason)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+
+	condition = TenantsCondition(otelcol, tenants, map[string]string{"team-b": "the namespace shop belongs to the team-a tenant"}, nil)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonInvalidTenants, condition.Reason)
+	assert.Equal(t, "some tenants aren't fully routed: team-b: the namespace shop belongs to the team-a tenant", condition.Message)
+
+	condition = TenantsCondition(otelcol, nil, map[string]string{}, errors.New("the routing/tenants connector is generated from the tenants, it can't be configured"))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "the pipelines of the tenants can't be generated, the configuration isn't rolled out: the routing/tenants connector is generated from the tenants, it can't be configured", condition.Message)
+}
+
+func TestSelectedTenant(t *testing.T) {
+	namespace := func(name string, labels map[string]string) corev1.Namespace {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	namespaces := []corev1.Namespace{
+		namespace("shop", map[string]string{"tenant": "team-a"}),
+		namespace("search", map[string]string{"tenant": "team-b"}),
+		namespace("payments", map[string]string{"tenant": "team-a"}),
+		namespace("checkout", map[string]string{"tenant": "team-a"}),
+	}
+
+	teamA := tenant("team-a", "shop")
+	selected, err := SelectedTenant(teamA, namespaces)
+	require.NoError(t, err)
+	assert.Equal(t, teamA, selected)
+
+	teamA.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-a"}}
+	selected, err = SelectedTenant(teamA, namespaces)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shop", "checkout", "payments"}, selected.Spec.Namespaces)
+	// the given tenant is left as it is
+	assert.Equal(t, []string{"shop"}, teamA.Spec.Namespaces)
+
+	ok, err := TenantSelects(teamA, namespaces[1])
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	teamA.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}}}
+	_, err = SelectedTenant(teamA, namespaces)
+	assert.ErrorContains(t, err, "invalid namespace selector of the team-a tenant")
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// tlsPath is the directory the certificates of the receivers and exporters are mounted in.
+	tlsPath = "/etc/otelcol/tls"
+
+	tlsCertFile = "tls.crt"
+	tlsKeyFile  = "tls.key"
+	tlsCAFile   = "ca.crt"
+)
+
+// tlsVolume returns a volume mounting the given keys of a secret holding certificates.
+func tlsVolume(name, secretName string, items []corev1.KeyToPath) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items:      items,
+			},
+		},
+	}
+}
+
+// sortedNames returns the names of the components of the given map, in order.
+func sortedNames[V any](components map[string]V) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func defaultString(value, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
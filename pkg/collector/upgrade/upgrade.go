@@ -129,6 +129,10 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 
 	// at the end of the process, we are up to date with the latest known version, which is what we have from versions.txt
 	otelcol.Status.Version = u.Version.OpenTelemetryCollector
+	// the TargetAllocator is upgraded in lockstep, unless the images are set on the instance
+	if otelcol.Spec.TargetAllocator.Enabled && len(otelcol.Spec.TargetAllocator.Image) == 0 && len(otelcol.Spec.Image) == 0 {
+		otelcol.Status.TargetAllocatorVersion = u.Version.TargetAllocator
+	}
 
 	u.Log.V(1).Info("final version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
 	return otelcol, nil
@@ -36,7 +36,7 @@ func upgrade0_19_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		return otelcol, fmt.Errorf("couldn't upgrade to v0.19.0, failed to parse configuration: %w", err)
 	}
 
-	processors, ok := cfg["processors"].(map[interface{}]interface{})
+	processors, ok := cfg["processors"].(map[string]interface{})
 	if !ok {
 		// no processors? no need to fail because of that
 		return otelcol, nil
@@ -46,7 +46,7 @@ func upgrade0_19_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		// from the changelog https://github.com/open-telemetry/opentelemetry-collector/releases/tag/v0.19.0
 
 		// Remove deprecated queued_retry processor
-		if strings.HasPrefix(k.(string), "queued_retry") {
+		if strings.HasPrefix(k, "queued_retry") {
 			delete(processors, k)
 			existing := &corev1.ConfigMap{}
 			updated := existing.DeepCopy()
@@ -55,9 +55,9 @@ func upgrade0_19_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		}
 
 		// Remove deprecated configs from resource processor: type (set "opencensus.type" key in "attributes.upsert" map instead) and labels (use "attributes.upsert" instead).
-		if strings.HasPrefix(k.(string), "resource") {
+		if strings.HasPrefix(k, "resource") {
 			switch processor := v.(type) {
-			case map[interface{}]interface{}:
+			case map[string]interface{}:
 				// type becomes an attribute.upsert with key opencensus.type
 				if typ, found := processor["type"]; found {
 					var attributes []map[string]string
@@ -88,10 +88,10 @@ func upgrade0_19_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 						}
 					}
 
-					if ls, ok := labels.(map[interface{}]interface{}); ok {
+					if ls, ok := labels.(map[string]interface{}); ok {
 						for labelK, labelV := range ls {
 							attr := map[string]string{}
-							attr["key"] = labelK.(string)
+							attr["key"] = labelK
 							attr["value"] = labelV.(string)
 							attr["action"] = "upsert"
 							attributes = append(attributes, attr)
@@ -149,8 +149,8 @@ func TestMigrateLabels(t *testing.T) {
 
 	actual, err := adapters.ConfigFromString(res.Spec.Config)
 	require.NoError(t, err)
-	actualProcessors := actual["processors"].(map[interface{}]interface{})
-	actualProcessor := actualProcessors["resource"].(map[interface{}]interface{})
+	actualProcessors := actual["processors"].(map[string]interface{})
+	actualProcessor := actualProcessors["resource"].(map[string]interface{})
 	actualAttrs := actualProcessor["attributes"].([]interface{})
 
 	// verify
@@ -36,16 +36,16 @@ func upgrade0_24_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		return otelcol, fmt.Errorf("couldn't upgrade to v0.24.0, failed to parse configuration: %w", err)
 	}
 
-	extensions, ok := cfg["extensions"].(map[interface{}]interface{})
+	extensions, ok := cfg["extensions"].(map[string]interface{})
 	if !ok {
 		// We do not need an upgrade if there are no extensions.
 		return otelcol, nil
 	}
 
 	for k, v := range extensions {
-		if strings.HasPrefix(k.(string), "health_check") {
+		if strings.HasPrefix(k, "health_check") {
 			switch extension := v.(type) {
-			case map[interface{}]interface{}:
+			case map[string]interface{}:
 				if port, ok := extension["port"]; ok {
 					delete(extension, "port")
 					extension["endpoint"] = fmt.Sprintf("0.0.0.0:%d", port)
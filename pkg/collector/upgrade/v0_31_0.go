@@ -36,7 +36,7 @@ func upgrade0_31_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		return otelcol, fmt.Errorf("couldn't upgrade to v0.31.0, failed to parse configuration: %w", err)
 	}
 
-	receivers, ok := cfg["receivers"].(map[interface{}]interface{})
+	receivers, ok := cfg["receivers"].(map[string]interface{})
 	if !ok {
 		// no receivers? no need to fail because of that
 		return otelcol, nil
@@ -47,14 +47,14 @@ func upgrade0_31_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		// Here is the upstream PR https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/4277
 
 		// Remove deprecated field metrics_schema from influxdb receiver
-		if strings.HasPrefix(k.(string), "influxdb") {
-			influxdbConfig, ok := v.(map[interface{}]interface{})
+		if strings.HasPrefix(k, "influxdb") {
+			influxdbConfig, ok := v.(map[string]interface{})
 			if !ok {
 				// no influxdbConfig? no need to fail because of that
 				return otelcol, nil
 			}
 			for fieldKey := range influxdbConfig {
-				if strings.HasPrefix(fieldKey.(string), "metrics_schema") {
+				if strings.HasPrefix(fieldKey, "metrics_schema") {
 					delete(influxdbConfig, fieldKey)
 					existing := &corev1.ConfigMap{}
 					updated := existing.DeepCopy()
@@ -37,7 +37,7 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 	}
 
 	// upgrading the receivers
-	receivers, ok := cfg["receivers"].(map[interface{}]interface{})
+	receivers, ok := cfg["receivers"].(map[string]interface{})
 	if !ok {
 		// no receivers? no need to fail because of that
 		return otelcol, nil
@@ -48,8 +48,8 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		// Here is the upstream PR https://github.com/open-telemetry/opentelemetry-collector/pull/4063
 
 		// Change tls config key from tls_settings to tls in otlp.protocols.grpc
-		if strings.HasPrefix(k1.(string), "otlp") {
-			otlpConfig, withOTLP := v1.(map[interface{}]interface{})
+		if strings.HasPrefix(k1, "otlp") {
+			otlpConfig, withOTLP := v1.(map[string]interface{})
 			if !withOTLP {
 				// no otlpConfig? no need to fail because of that
 				return otelcol, nil
@@ -57,7 +57,7 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 			for k2, v2 := range otlpConfig {
 				// protocols config
 				if k2 == "protocols" {
-					protocConfig, withProtocConfig := v2.(map[interface{}]interface{})
+					protocConfig, withProtocConfig := v2.(map[string]interface{})
 					if !withProtocConfig {
 						// no protocolConfig? no need to fail because of that
 						return otelcol, nil
@@ -65,14 +65,14 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 					for k3, v3 := range protocConfig {
 						// grpc config
 						if k3 == "grpc" || k3 == "http" {
-							grpcHTTPConfig, withHTTPConfig := v3.(map[interface{}]interface{})
+							grpcHTTPConfig, withHTTPConfig := v3.(map[string]interface{})
 							if !withHTTPConfig {
 								// no grpcHTTPConfig? no need to fail because of that
 								return otelcol, nil
 							}
 							for k4, v4 := range grpcHTTPConfig {
 								// change tls_settings to tls
-								if k4.(string) == "tls_settings" {
+								if k4 == "tls_settings" {
 									grpcHTTPConfig["tls"] = v4
 									delete(grpcHTTPConfig, "tls_settings")
 									existing := &corev1.ConfigMap{}
@@ -89,7 +89,7 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 	cfg["receivers"] = receivers
 
 	// upgrading the exporters
-	exporters, ok := cfg["exporters"].(map[interface{}]interface{})
+	exporters, ok := cfg["exporters"].(map[string]interface{})
 	if !ok {
 		// no exporters? no need to fail because of that
 		return otelcol, nil
@@ -100,13 +100,13 @@ func upgrade0_36_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		// Here is the upstream PR https://github.com/open-telemetry/opentelemetry-collector/pull/4063
 
 		// Move all tls config into separate field i,e, tls.*
-		if strings.HasPrefix(k1.(string), "otlp") {
-			otlpConfig, ok := v1.(map[interface{}]interface{})
+		if strings.HasPrefix(k1, "otlp") {
+			otlpConfig, ok := v1.(map[string]interface{})
 			if !ok {
 				// no otlpConfig? no need to fail because of that
 				return otelcol, nil
 			}
-			tlsConfig := make(map[interface{}]interface{}, 5)
+			tlsConfig := make(map[string]interface{}, 5)
 			for key, value := range otlpConfig {
 				if key == "ca_file" || key == "cert_file" || key == "key_file" || key == "min_version" || key == "max_version" ||
 					key == "insecure" || key == "insecure_skip_verify" || key == "server_name_override" {
@@ -50,25 +50,25 @@ func upgrade0_38_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 			return otelcol, fmt.Errorf("couldn't upgrade to v0.38.0, failed to parse configuration: %w", err)
 		}
 
-		serviceConfig, ok := cfg["service"].(map[interface{}]interface{})
+		serviceConfig, ok := cfg["service"].(map[string]interface{})
 		if !ok {
 			// no serviceConfig? create one as we need to configure logging parameters
-			cfg["service"] = make(map[interface{}]interface{})
-			serviceConfig, _ = cfg["service"].(map[interface{}]interface{})
+			cfg["service"] = make(map[string]interface{})
+			serviceConfig, _ = cfg["service"].(map[string]interface{})
 		}
 
-		telemetryConfig, ok := serviceConfig["telemetry"].(map[interface{}]interface{})
+		telemetryConfig, ok := serviceConfig["telemetry"].(map[string]interface{})
 		if !ok {
 			// no telemetryConfig? create one as we need to configure logging parameters
-			serviceConfig["telemetry"] = make(map[interface{}]interface{})
-			telemetryConfig, _ = serviceConfig["telemetry"].(map[interface{}]interface{})
+			serviceConfig["telemetry"] = make(map[string]interface{})
+			telemetryConfig, _ = serviceConfig["telemetry"].(map[string]interface{})
 		}
 
-		logsConfig, ok := telemetryConfig["logs"].(map[interface{}]interface{})
+		logsConfig, ok := telemetryConfig["logs"].(map[string]interface{})
 		if !ok {
 			// no logsConfig? create one as we need to configure logging parameters
-			telemetryConfig["logs"] = make(map[interface{}]interface{})
-			logsConfig, _ = telemetryConfig["logs"].(map[interface{}]interface{})
+			telemetryConfig["logs"] = make(map[string]interface{})
+			logsConfig, _ = telemetryConfig["logs"].(map[string]interface{})
 		}
 
 		// if there is already loggingConfig
@@ -34,12 +34,12 @@ func upgrade0_39_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 
 	// Remove processors.memory_limiter.ballast_size_mib
 	// as it is deprecated in reference to https://github.com/open-telemetry/opentelemetry-collector/pull/4365
-	processors, _ := cfg["processors"].(map[interface{}]interface{})
+	processors, _ := cfg["processors"].(map[string]interface{})
 
 	for k1, v1 := range processors {
 		// Drop the deprecated field ballast_size_mib from memory_limiter
-		if strings.HasPrefix(k1.(string), "memory_limiter") {
-			memoryLimiter, _ := v1.(map[interface{}]interface{})
+		if strings.HasPrefix(k1, "memory_limiter") {
+			memoryLimiter, _ := v1.(map[string]interface{})
 			for k2 := range memoryLimiter {
 				if k2 == "ballast_size_mib" {
 					delete(memoryLimiter, k2)
@@ -58,37 +58,37 @@ func upgrade0_39_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 
 	// Rename httpd receiver to apache receiver
 	// in reference to https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/6207
-	receivers, _ := cfg["receivers"].(map[interface{}]interface{})
+	receivers, _ := cfg["receivers"].(map[string]interface{})
 
 	for k1, v1 := range receivers {
-		if strings.HasPrefix(k1.(string), "httpd") {
+		if strings.HasPrefix(k1, "httpd") {
 			// Rename httpd with apache
-			apacheKey := strings.Replace(k1.(string), "httpd", "apache", 1)
+			apacheKey := strings.Replace(k1, "httpd", "apache", 1)
 			receivers[apacheKey] = v1
 			delete(receivers, k1)
 
 			// rename receiver name in service pipelines config
-			serviceConfig, ok := cfg["service"].(map[interface{}]interface{})
+			serviceConfig, ok := cfg["service"].(map[string]interface{})
 			if !ok {
 				// no serviceConfig?
 				return otelcol, nil
 			}
 
-			pipelinesConfig, ok := serviceConfig["pipelines"].(map[interface{}]interface{})
+			pipelinesConfig, ok := serviceConfig["pipelines"].(map[string]interface{})
 			if !ok {
 				// no pipelinesConfig?
 				return otelcol, nil
 			}
 
 			for k2, v2 := range pipelinesConfig {
-				if k2.(string) == "metrics" {
-					metricsConfig, ok := v2.(map[interface{}]interface{})
+				if k2 == "metrics" {
+					metricsConfig, ok := v2.(map[string]interface{})
 					if !ok {
 						// no metricsConfig in service pipelines?
 						return otelcol, nil
 					}
 					for k3, v3 := range metricsConfig {
-						if k3.(string) == "receivers" {
+						if k3 == "receivers" {
 							receiversList, ok := v3.([]interface{})
 							if !ok {
 								// no receivers list in service pipeline?
@@ -112,7 +112,7 @@ func upgrade0_39_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 	return updateConfig(otelcol, cfg)
 }
 
-func updateConfig(otelcol *v1alpha1.OpenTelemetryCollector, cfg map[interface{}]interface{}) (*v1alpha1.OpenTelemetryCollector, error) {
+func updateConfig(otelcol *v1alpha1.OpenTelemetryCollector, cfg map[string]interface{}) (*v1alpha1.OpenTelemetryCollector, error) {
 	res, err := yaml.Marshal(cfg)
 	if err != nil {
 		return otelcol, fmt.Errorf("couldn't upgrade to v0.39.0, failed to marshall back configuration: %w", err)
@@ -49,20 +49,20 @@ func upgrade0_43_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 		if err != nil {
 			return otelcol, fmt.Errorf("couldn't upgrade to v0.43.0, failed to parse configuration: %w", err)
 		}
-		serviceConfig, ok := cfg["service"].(map[interface{}]interface{})
+		serviceConfig, ok := cfg["service"].(map[string]interface{})
 		if !ok {
-			cfg["service"] = make(map[interface{}]interface{})
-			serviceConfig, _ = cfg["service"].(map[interface{}]interface{})
+			cfg["service"] = make(map[string]interface{})
+			serviceConfig, _ = cfg["service"].(map[string]interface{})
 		}
-		telemetryConfig, ok := serviceConfig["telemetry"].(map[interface{}]interface{})
+		telemetryConfig, ok := serviceConfig["telemetry"].(map[string]interface{})
 		if !ok {
-			serviceConfig["telemetry"] = make(map[interface{}]interface{})
-			telemetryConfig, _ = serviceConfig["telemetry"].(map[interface{}]interface{})
+			serviceConfig["telemetry"] = make(map[string]interface{})
+			telemetryConfig, _ = serviceConfig["telemetry"].(map[string]interface{})
 		}
-		metricsConfig, ok := telemetryConfig["metrics"].(map[interface{}]interface{})
+		metricsConfig, ok := telemetryConfig["metrics"].(map[string]interface{})
 		if !ok {
-			telemetryConfig["metrics"] = make(map[interface{}]interface{})
-			metricsConfig, _ = telemetryConfig["metrics"].(map[interface{}]interface{})
+			telemetryConfig["metrics"] = make(map[string]interface{})
+			metricsConfig, _ = telemetryConfig["metrics"].(map[string]interface{})
 		}
 
 		// if there are already those Args under Spec.Config
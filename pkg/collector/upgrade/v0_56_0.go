@@ -39,7 +39,7 @@ func upgrade0_56_0(u VersionUpgrade, otelcol *v1alpha1.OpenTelemetryCollector) (
 	listOptions := []client.ListOption{
 		client.InNamespace(otelcol.Namespace),
 		client.MatchingLabels(map[string]string{
-			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name),
+			"app.kubernetes.io/instance":   naming.Instance(*otelcol),
 			"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		}),
 	}
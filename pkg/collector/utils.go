@@ -21,6 +21,9 @@ import (
 )
 
 func getDNSPolicy(otelcol v1alpha1.OpenTelemetryCollector) corev1.DNSPolicy {
+	if otelcol.Spec.DNSPolicy != "" {
+		return otelcol.Spec.DNSPolicy
+	}
 	dnsPolicy := corev1.DNSClusterFirst
 	if otelcol.Spec.HostNetwork {
 		dnsPolicy = corev1.DNSClusterFirstWithHostNet
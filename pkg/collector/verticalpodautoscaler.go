@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscalers, which are handled as unstructured objects as
+// their API is only served when the VerticalPodAutoscaler is installed in the cluster.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// VerticalPodAutoscaler builds the vertical pod autoscaler for the given instance, or returns nil when the instance
+// doesn't set its vertical autoscaler.
+func VerticalPodAutoscaler(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *unstructured.Unstructured {
+	if otelcol.Spec.VerticalAutoscaler == nil {
+		return nil
+	}
+
+	var kind string
+	switch otelcol.Spec.Mode {
+	case v1alpha1.ModeDeployment:
+		kind = "Deployment"
+	case v1alpha1.ModeDaemonSet:
+		kind = "DaemonSet"
+	case v1alpha1.ModeStatefulSet:
+		kind = "StatefulSet"
+	default:
+		return nil
+	}
+
+	// only the collector container is right-sized, the resources of the other containers are left as they are set
+	collectorPolicy := map[string]interface{}{
+		"containerName": naming.Container(),
+	}
+	if minAllowed := otelcol.Spec.VerticalAutoscaler.MinAllowed; len(minAllowed) > 0 {
+		collectorPolicy["minAllowed"] = resourceListToUnstructured(minAllowed)
+	}
+	if maxAllowed := otelcol.Spec.VerticalAutoscaler.MaxAllowed; len(maxAllowed) > 0 {
+		collectorPolicy["maxAllowed"] = resourceListToUnstructured(maxAllowed)
+	}
+
+	name := naming.VerticalPodAutoscaler(otelcol)
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetName(name)
+	vpa.SetNamespace(otelcol.Namespace)
+	vpa.SetLabels(Labels(otelcol, name, cfg.LabelsFilter()))
+	vpa.SetAnnotations(Annotations(cfg, otelcol))
+	vpa.Object["spec"] = map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"name":       naming.Collector(otelcol),
+		},
+		"updatePolicy": map[string]interface{}{
+			"updateMode": string(otelcol.Spec.VerticalAutoscaler.UpdateMode),
+		},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{
+				collectorPolicy,
+				map[string]interface{}{
+					"containerName": "*",
+					"mode":          "Off",
+				},
+			},
+		},
+	}
+
+	return vpa
+}
+
+func resourceListToUnstructured(resources corev1.ResourceList) map[string]interface{} {
+	out := map[string]interface{}{}
+	for name, quantity := range resources {
+		out[string(name)] = quantity.String()
+	}
+	return out
+}
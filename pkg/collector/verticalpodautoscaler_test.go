@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestVerticalPodAutoscaler(t *testing.T) {
+	for _, tt := range []struct {
+		mode v1alpha1.Mode
+		kind string
+	}{
+		{v1alpha1.ModeDeployment, "Deployment"},
+		{v1alpha1.ModeDaemonSet, "DaemonSet"},
+		{v1alpha1.ModeStatefulSet, "StatefulSet"},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: tt.mode,
+					VerticalAutoscaler: &v1alpha1.VerticalAutoscalerSpec{
+						UpdateMode: v1alpha1.VerticalAutoscalerUpdateModeAuto,
+						MinAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+						MaxAllowed: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			}
+
+			// test
+			vpa := VerticalPodAutoscaler(config.New(), otelcol)
+
+			// verify
+			require.NotNil(t, vpa)
+			assert.Equal(t, VerticalPodAutoscalerGVK, vpa.GroupVersionKind())
+			assert.Equal(t, "my-instance-collector", vpa.GetName())
+			assert.Equal(t, "my-namespace", vpa.GetNamespace())
+			assert.Equal(t, "my-instance-collector", vpa.GetLabels()["app.kubernetes.io/name"])
+
+			targetRef, _, err := unstructured.NestedStringMap(vpa.Object, "spec", "targetRef")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"apiVersion": "apps/v1", "kind": tt.kind, "name": "my-instance-collector"}, targetRef)
+
+			updateMode, _, err := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+			require.NoError(t, err)
+			assert.Equal(t, "Auto", updateMode)
+
+			policies, _, err := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{
+					"containerName": "otc-container",
+					"minAllowed":    map[string]interface{}{"memory": "64Mi"},
+					"maxAllowed":    map[string]interface{}{"cpu": "2", "memory": "2Gi"},
+				},
+				map[string]interface{}{
+					"containerName": "*",
+					"mode":          "Off",
+				},
+			}, policies)
+		})
+	}
+}
+
+func TestVerticalPodAutoscalerWithoutVerticalAutoscaler(t *testing.T) {
+	for _, otelcol := range []v1alpha1.OpenTelemetryCollector{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-vertical-autoscaler"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidecar"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:               v1alpha1.ModeSidecar,
+				VerticalAutoscaler: &v1alpha1.VerticalAutoscalerSpec{UpdateMode: v1alpha1.VerticalAutoscalerUpdateModeOff},
+			},
+		},
+	} {
+		t.Run(otelcol.Name, func(t *testing.T) {
+			assert.Nil(t, VerticalPodAutoscaler(config.New(), otelcol))
+		})
+	}
+}
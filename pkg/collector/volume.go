@@ -25,11 +25,17 @@ import (
 
 // Volumes builds the volumes for the given instance, including the config map volume.
 func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	return volumes(cfg, otelcol, func(part int) string { return VersionedConfigMap(cfg, otelcol, naming.ConfigMapPart(otelcol, part)) })
+}
+
+// volumes builds the volumes for the given instance, whose configuration is held by the config maps with the names
+// returned by configMapName for each part.
+func volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, configMapName func(part int) string) []corev1.Volume {
 	volumes := []corev1.Volume{{
 		Name: naming.ConfigMapVolume(),
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: naming.ConfigMap(otelcol)},
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName(0)},
 				Items: []corev1.KeyToPath{{
 					Key:  cfg.CollectorConfigMapEntry(),
 					Path: cfg.CollectorConfigMapEntry(),
@@ -38,6 +44,30 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		},
 	}}
 
+	if parts := ConfigPartCount(cfg, otelcol); parts > 1 {
+		// each part of the configuration is held by its own config map, all of them are mounted in the same directory
+		sources := make([]corev1.VolumeProjection, parts)
+		for part := range sources {
+			sources[part] = corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMapName(part)},
+					Items: []corev1.KeyToPath{{
+						Key:  cfg.CollectorConfigMapEntry(),
+						Path: configPartEntry(cfg.CollectorConfigMapEntry(), part),
+					}},
+				},
+			}
+		}
+		volumes[0].VolumeSource = corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		}
+	}
+
+	volumes = append(volumes, SecretProviderVolumes(otelcol)...)
+	volumes = append(volumes, receiverTLSVolumes(otelcol)...)
+	volumes = append(volumes, exporterTLSVolumes(otelcol)...)
+	volumes = append(volumes, exporterTokenVolumes(otelcol)...)
+
 	if len(otelcol.Spec.Volumes) > 0 {
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
@@ -18,7 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -61,3 +63,65 @@ func TestVolumeAllowsMoreToBeAdded(t *testing.T) {
 	// check that it's the otc-internal volume, with the config map
 	assert.Equal(t, "my-volume", volumes[1].Name)
 }
+
+func TestVolumeSplitConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: largeConfig(30, 100*1024),
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	require.Len(t, volumes, 1)
+	require.NotNil(t, volumes[0].Projected)
+	sources := volumes[0].Projected.Sources
+	require.Len(t, sources, 4)
+	assert.Equal(t, "my-instance-collector", sources[0].ConfigMap.Name)
+	assert.Equal(t, "collector.yaml", sources[0].ConfigMap.Items[0].Path)
+	assert.Equal(t, "my-instance-collector-3", sources[3].ConfigMap.Name)
+	assert.Equal(t, "collector-3.yaml", sources[3].ConfigMap.Items[0].Path)
+}
+
+func TestVolumeSecretProviders(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			SecretProviders: []v1alpha1.SecretProvider{{
+				Name:                "vault",
+				SecretProviderClass: "vault-credentials",
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "my-volume",
+			}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	require.Len(t, volumes, 3)
+	readOnly := true
+	assert.Equal(t, corev1.Volume{
+		Name: "secret-provider-vault",
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   "secrets-store.csi.k8s.io",
+				ReadOnly: &readOnly,
+				VolumeAttributes: map[string]string{
+					"secretProviderClass": "vault-credentials",
+				},
+			},
+		},
+	}, volumes[1])
+	assert.Equal(t, "my-volume", volumes[2].Name)
+}
@@ -28,6 +28,12 @@ func VolumeClaimTemplates(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Pers
 		return []corev1.PersistentVolumeClaim{}
 	}
 
-	// Add all user specified claims.
-	return otelcol.Spec.VolumeClaimTemplates
+	if !remoteWriteWALEnabled(otelcol) {
+		// Add all user specified claims.
+		return otelcol.Spec.VolumeClaimTemplates
+	}
+
+	// Add all user specified claims, along with the claim of the write-ahead log of the remote write preset.
+	claims := append([]corev1.PersistentVolumeClaim{}, otelcol.Spec.VolumeClaimTemplates...)
+	return append(claims, remoteWriteVolumeClaimTemplates(otelcol)...)
 }
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// gcpServiceAccountAnnotation is the annotation of the service account GKE Workload Identity impersonates the
+	// Google service account of.
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+
+	// azureClientIDAnnotation and azureTenantIDAnnotation are the annotations of the service account the Azure
+	// Workload Identity webhook projects the federated token of the application or managed identity for.
+	azureClientIDAnnotation = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation = "azure.workload.identity/tenant-id"
+
+	// azureUseLabel is the label of the pods the Azure Workload Identity webhook injects the federated token into.
+	azureUseLabel = "azure.workload.identity/use"
+)
+
+// workloadIdentityAnnotations returns the annotations of the service account for the AWS, GCP and Azure identities of
+// the given instance.
+func workloadIdentityAnnotations(otelcol v1alpha1.OpenTelemetryCollector) map[string]string {
+	annotations := map[string]string{}
+	if otelcol.Spec.AWSIdentity != nil {
+		annotations[awsRoleARNAnnotation] = otelcol.Spec.AWSIdentity.RoleARN
+	}
+	if otelcol.Spec.GCPIdentity != nil {
+		annotations[gcpServiceAccountAnnotation] = otelcol.Spec.GCPIdentity.ServiceAccount
+	}
+	if otelcol.Spec.AzureIdentity != nil {
+		annotations[azureClientIDAnnotation] = otelcol.Spec.AzureIdentity.ClientID
+		if otelcol.Spec.AzureIdentity.TenantID != "" {
+			annotations[azureTenantIDAnnotation] = otelcol.Spec.AzureIdentity.TenantID
+		}
+	}
+	return annotations
+}
+
+// podLabels returns the labels of the collector pods of the given instance, with the label opting the pods in to the
+// Azure Workload Identity webhook when the instance has an Azure identity.
+func podLabels(otelcol v1alpha1.OpenTelemetryCollector, labels map[string]string) map[string]string {
+	if otelcol.Spec.AzureIdentity == nil {
+		return labels
+	}
+
+	podLabels := map[string]string{}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+	podLabels[azureUseLabel] = "true"
+	return podLabels
+}
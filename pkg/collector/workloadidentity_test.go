@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestWorkloadIdentityServiceAccountAnnotations(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		spec     v1alpha1.OpenTelemetryCollectorSpec
+		expected map[string]string
+	}{
+		{
+			desc: "gcp identity",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				GCPIdentity: &v1alpha1.GCPIdentitySpec{ServiceAccount: "otel-collector@my-project.iam.gserviceaccount.com"},
+			},
+			expected: map[string]string{
+				"iam.gke.io/gcp-service-account": "otel-collector@my-project.iam.gserviceaccount.com",
+			},
+		},
+		{
+			desc: "azure identity",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				AzureIdentity: &v1alpha1.AzureIdentitySpec{ClientID: "client", TenantID: "tenant"},
+			},
+			expected: map[string]string{
+				"azure.workload.identity/client-id": "client",
+				"azure.workload.identity/tenant-id": "tenant",
+			},
+		},
+		{
+			desc: "azure identity without tenant",
+			spec: v1alpha1.OpenTelemetryCollectorSpec{
+				AzureIdentity: &v1alpha1.AzureIdentitySpec{ClientID: "client"},
+			},
+			expected: map[string]string{
+				"azure.workload.identity/client-id": "client",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-instance",
+				},
+				Spec: tt.spec,
+			}
+
+			sa := ServiceAccount(otelcol)
+
+			assert.Equal(t, tt.expected, sa.Annotations)
+		})
+	}
+}
+
+func TestAzureIdentityPodLabels(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	d := Deployment(config.New(), logger, otelcol)
+	assert.NotContains(t, d.Spec.Template.Labels, "azure.workload.identity/use")
+
+	otelcol.Spec.AzureIdentity = &v1alpha1.AzureIdentitySpec{ClientID: "client"}
+
+	d = Deployment(config.New(), logger, otelcol)
+	assert.Equal(t, "true", d.Spec.Template.Labels["azure.workload.identity/use"])
+	assert.NotContains(t, d.Labels, "azure.workload.identity/use")
+
+	ds := DaemonSet(config.New(), logger, otelcol)
+	assert.Equal(t, "true", ds.Spec.Template.Labels["azure.workload.identity/use"])
+
+	ss := StatefulSet(config.New(), logger, otelcol)
+	assert.Equal(t, "true", ss.Spec.Template.Labels["azure.workload.identity/use"])
+}
@@ -25,4 +25,14 @@ const (
 	EnvPodName  = "OTEL_RESOURCE_ATTRIBUTES_POD_NAME"
 	EnvPodUID   = "OTEL_RESOURCE_ATTRIBUTES_POD_UID"
 	EnvNodeName = "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME"
+	EnvNodeIP   = "OTEL_NODE_IP"
+
+	EnvJaegerAgentHost       = "JAEGER_AGENT_HOST"
+	EnvJaegerAgentPort       = "JAEGER_AGENT_PORT"
+	EnvDatadogAgentHost      = "DD_AGENT_HOST"
+	EnvDatadogTraceAgentPort = "DD_TRACE_AGENT_PORT"
+
+	EnvHTTPProxy  = "HTTP_PROXY"
+	EnvHTTPSProxy = "HTTPS_PROXY"
+	EnvNoProxy    = "NO_PROXY"
 )
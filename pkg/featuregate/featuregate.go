@@ -56,6 +56,45 @@ var (
 		"operator.collector.rewritetargetallocator",
 		featuregate.StageAlpha,
 		featuregate.WithRegisterDescription("controls whether the operator should configure the collector's targetAllocator configuration"))
+
+	// EnableTargetAllocatorRelabeledTargets is the feature gate that controls whether the collector should get its targets
+	// from the target allocator with the relabel configs already applied, instead of relabeling them on its own.
+	EnableTargetAllocatorRelabeledTargets = featuregate.GlobalRegistry().MustRegister(
+		"operator.targetallocator.relabeledtargets",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the collector should get targets already relabeled by the target allocator"))
+
+	// EnableNativeSidecarContainers is the feature gate that controls whether the collector sidecar is injected as a
+	// native sidecar container into pods that run to completion, like the ones created by Jobs and CronJobs.
+	// Native sidecar containers require the SidecarContainers feature of Kubernetes 1.28+.
+	EnableNativeSidecarContainers = featuregate.GlobalRegistry().MustRegister(
+		"operator.sidecarcontainers.native",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator injects the collector sidecar as a native sidecar container into pods that run to completion"))
+
+	// EnableStrictConfig is the feature gate that controls whether the unknown keys of the collector configuration,
+	// like a misspelled scrape_configs section of the Prometheus receiver, are rejected by the webhook instead of
+	// being reported as warnings.
+	EnableStrictConfig = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.strictconfig",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the webhook rejects the collector configurations with unknown keys"))
+
+	// EnableLegacyReceivers is the feature gate that controls whether the operator keeps inferring the ports of the
+	// deprecated receivers, the opencensus receiver and the remote sampling of the jaeger receiver, so that the
+	// collectors still using them aren't broken by an upgrade of the operator.
+	EnableLegacyReceivers = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.legacyreceivers",
+		featuregate.StageBeta,
+		featuregate.WithRegisterDescription("controls whether the operator infers the ports of the deprecated receivers"))
+
+	// EnableFIPS is the feature gate that controls whether the operator runs in FIPS mode, in which it deploys the
+	// FIPS validated images, restricts the TLS settings of the collectors and of its webhook server to the FIPS approved
+	// versions and cipher suites, and rejects the collector configurations that aren't FIPS compliant.
+	EnableFIPS = featuregate.GlobalRegistry().MustRegister(
+		"operator.fips",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the operator runs in FIPS mode"))
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
@@ -173,6 +173,12 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 				},
 			},
 		})
+
+		// the configuration refers to the node's IP when the endpoint is resolved to the collector of the node
+		if strings.Contains(otlpEndpoint, nodeIPRef) {
+			initContainer := &pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+			initContainer.Env = append([]corev1.EnvVar{nodeIPEnvVar()}, initContainer.Env...)
+		}
 	}
 
 	return pod
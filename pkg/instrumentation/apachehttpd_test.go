@@ -130,3 +130,19 @@ func TestInjectApacheHttpdagent(t *testing.T) {
 		})
 	}
 }
+
+func TestInjectApacheHttpdagentNodeEndpoint(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "apache"}},
+		},
+	}
+
+	pod = injectApacheHttpdagent(logr.Discard(), v1alpha1.ApacheHttpd{Image: "foo/bar:1"}, pod, 0, "http://$(OTEL_NODE_IP):4317", map[string]string{})
+
+	// the node's IP is defined before the configuration referring to it
+	initContainer := pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+	assert.Equal(t, "OTEL_NODE_IP", initContainer.Env[0].Name)
+	assert.Equal(t, "status.hostIP", initContainer.Env[0].ValueFrom.FieldRef.FieldPath)
+	assert.Contains(t, initContainer.Env[1].Value, "ApacheModuleOtelExporterEndpoint http://$(OTEL_NODE_IP):4317")
+}
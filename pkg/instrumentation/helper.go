@@ -16,8 +16,19 @@ package instrumentation
 
 import (
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
+// injectedSidecars are the containers the operator and the mutating webhooks of other tools, like service meshes,
+// add next to the application's containers. They're only instrumented when named explicitly.
+var injectedSidecars = map[string]bool{
+	naming.Container(): true,
+	sideCarName:        true,
+	"istio-proxy":      true,
+	"linkerd-proxy":    true,
+}
+
 // Calculate if we already inject InitContainers.
 func isInitContainerMissing(pod corev1.Pod) bool {
 	for _, initContainer := range pod.Spec.InitContainers {
@@ -43,3 +54,14 @@ func isAutoInstrumentationInjected(pod corev1.Pod) bool {
 	}
 	return false
 }
+
+// applicationContainerIndex returns the index of the first container of the pod that isn't a sidecar added by the
+// operator or by another mutating webhook, which may have run before, or the first container if there's none.
+func applicationContainerIndex(pod corev1.Pod) int {
+	for i, container := range pod.Spec.Containers {
+		if !injectedSidecars[container.Name] {
+			return i
+		}
+	}
+	return 0
+}
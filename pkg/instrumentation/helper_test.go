@@ -124,3 +124,20 @@ func TestAutoInstrumentationInjected(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationContainerIndex(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		containers []corev1.Container
+		expected   int
+	}{
+		{"application only", []corev1.Container{{Name: "app"}}, 0},
+		{"mesh proxy first", []corev1.Container{{Name: "linkerd-proxy"}, {Name: "app"}}, 1},
+		{"collector sidecar first", []corev1.Container{{Name: "otc-container"}, {Name: "istio-proxy"}, {Name: "app"}}, 2},
+		{"sidecars only", []corev1.Container{{Name: "istio-proxy"}}, 0},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, applicationContainerIndex(corev1.Pod{Spec: corev1.PodSpec{Containers: tt.containers}}))
+		})
+	}
+}
@@ -29,11 +29,6 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 	// caller checks if there is at least one container.
 	container := &pod.Spec.Containers[index]
 
-	err := validateContainerEnv(container.Env, envJavaToolsOptions)
-	if err != nil {
-		return pod, err
-	}
-
 	// inject Java instrumentation spec env vars.
 	for _, env := range javaSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -49,6 +44,7 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 			Value: javaJVMArgument,
 		})
 	} else {
+		container.Env, idx = exposeEnvValueFrom(container.Env, idx)
 		container.Env[idx].Value = container.Env[idx].Value + javaJVMArgument
 	}
 
@@ -76,5 +72,5 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 			}},
 		})
 	}
-	return pod, err
+	return pod, nil
 }
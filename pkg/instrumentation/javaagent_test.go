@@ -15,7 +15,6 @@
 package instrumentation
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -161,19 +160,48 @@ func TestInjectJavaagent(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: volumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    initContainerName,
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "/javaagent.jar", "/otel-auto-instrumentation/javaagent.jar"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      volumeName,
+								MountPath: "/otel-auto-instrumentation",
+							}},
+						},
+					},
 					Containers: []corev1.Container{
 						{
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      volumeName,
+									MountPath: "/otel-auto-instrumentation",
+								},
+							},
 							Env: []corev1.EnvVar{
 								{
-									Name:      "JAVA_TOOL_OPTIONS",
+									Name:      "JAVA_TOOL_OPTIONS_ORIGINAL",
 									ValueFrom: &corev1.EnvVarSource{},
 								},
+								{
+									Name:  "JAVA_TOOL_OPTIONS",
+									Value: "$(JAVA_TOOL_OPTIONS_ORIGINAL)" + javaJVMArgument,
+								},
 							},
 						},
 					},
 				},
 			},
-			err: fmt.Errorf("the container defines env var value via ValueFrom, envVar: %s", envJavaToolsOptions),
+			err: nil,
 		},
 	}
 
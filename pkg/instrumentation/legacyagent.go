@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// The ports the collector receives the data of the vendors' SDKs on by default, the ones of the vendors' agents.
+const (
+	jaegerAgentPort  = 6831
+	datadogAgentPort = 8126
+)
+
+// legacyAgentEnvVars returns the environment variables pointing the SDKs of the given vendors to the host of the given
+// exporter endpoint, in place of the vendors' agents.
+func legacyAgentEnvVars(agents []v1alpha1.LegacyAgent, endpoint string) []corev1.EnvVar {
+	if len(agents) == 0 {
+		return nil
+	}
+	host := endpointHost(endpoint)
+	if len(host) == 0 {
+		return nil
+	}
+
+	var envs []corev1.EnvVar
+	for _, agent := range agents {
+		switch agent.Type {
+		case v1alpha1.LegacyAgentJaeger:
+			envs = append(envs,
+				corev1.EnvVar{Name: constants.EnvJaegerAgentHost, Value: host},
+				corev1.EnvVar{Name: constants.EnvJaegerAgentPort, Value: agentPort(agent, jaegerAgentPort)},
+			)
+		case v1alpha1.LegacyAgentDatadog:
+			envs = append(envs,
+				corev1.EnvVar{Name: constants.EnvDatadogAgentHost, Value: host},
+				corev1.EnvVar{Name: constants.EnvDatadogTraceAgentPort, Value: agentPort(agent, datadogAgentPort)},
+			)
+		}
+	}
+	return envs
+}
+
+// endpointHost returns the host of the given exporter endpoint, which the SDKs accept without a scheme as well, e.g.
+// otel-collector:4317, in which case it's parsed with the default http scheme.
+func endpointHost(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && len(u.Hostname()) > 0 {
+		return u.Hostname()
+	}
+	if u, err := url.Parse("http://" + endpoint); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+// agentPort returns the port of the given agent, or the given default one when it's not set.
+func agentPort(agent v1alpha1.LegacyAgent, defaultPort int32) string {
+	if agent.Port > 0 {
+		return strconv.Itoa(int(agent.Port))
+	}
+	return strconv.Itoa(int(defaultPort))
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestLegacyAgentEnvVars(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		agents   []v1alpha1.LegacyAgent
+		endpoint string
+		expected []corev1.EnvVar
+	}{
+		{
+			"default ports",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentJaeger}, {Type: v1alpha1.LegacyAgentDatadog}},
+			"http://otel-collector.observability:4317",
+			[]corev1.EnvVar{
+				{Name: "JAEGER_AGENT_HOST", Value: "otel-collector.observability"},
+				{Name: "JAEGER_AGENT_PORT", Value: "6831"},
+				{Name: "DD_AGENT_HOST", Value: "otel-collector.observability"},
+				{Name: "DD_TRACE_AGENT_PORT", Value: "8126"},
+			},
+		},
+		{
+			"custom port",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentDatadog, Port: 9126}},
+			"http://localhost:4317",
+			[]corev1.EnvVar{
+				{Name: "DD_AGENT_HOST", Value: "localhost"},
+				{Name: "DD_TRACE_AGENT_PORT", Value: "9126"},
+			},
+		},
+		{
+			"node's collector",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentJaeger}},
+			"http://$(OTEL_NODE_IP):4318",
+			[]corev1.EnvVar{
+				{Name: "JAEGER_AGENT_HOST", Value: "$(OTEL_NODE_IP)"},
+				{Name: "JAEGER_AGENT_PORT", Value: "6831"},
+			},
+		},
+		{
+			"endpoint without scheme",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentJaeger}},
+			"otel-collector.observability:4317",
+			[]corev1.EnvVar{
+				{Name: "JAEGER_AGENT_HOST", Value: "otel-collector.observability"},
+				{Name: "JAEGER_AGENT_PORT", Value: "6831"},
+			},
+		},
+		{
+			"node's collector without scheme",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentDatadog}},
+			"$(OTEL_NODE_IP):4317",
+			[]corev1.EnvVar{
+				{Name: "DD_AGENT_HOST", Value: "$(OTEL_NODE_IP)"},
+				{Name: "DD_TRACE_AGENT_PORT", Value: "8126"},
+			},
+		},
+		{
+			"without endpoint",
+			[]v1alpha1.LegacyAgent{{Type: v1alpha1.LegacyAgentJaeger}},
+			"",
+			nil,
+		},
+		{
+			"without agents",
+			nil,
+			"http://localhost:4317",
+			nil,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, legacyAgentEnvVars(tt.agents, tt.endpoint))
+		})
+	}
+}
@@ -29,11 +29,6 @@ func injectNodeJSSDK(nodeJSSpec v1alpha1.NodeJS, pod corev1.Pod, index int) (cor
 	// caller checks if there is at least one container.
 	container := &pod.Spec.Containers[index]
 
-	err := validateContainerEnv(container.Env, envNodeOptions)
-	if err != nil {
-		return pod, err
-	}
-
 	// inject NodeJS instrumentation spec env vars.
 	for _, env := range nodeJSSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -49,6 +44,7 @@ func injectNodeJSSDK(nodeJSSpec v1alpha1.NodeJS, pod corev1.Pod, index int) (cor
 			Value: nodeRequireArgument,
 		})
 	} else if idx > -1 {
+		container.Env, idx = exposeEnvValueFrom(container.Env, idx)
 		container.Env[idx].Value = container.Env[idx].Value + nodeRequireArgument
 	}
 
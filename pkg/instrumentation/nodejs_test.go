@@ -15,7 +15,6 @@
 package instrumentation
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -161,19 +160,48 @@ func TestInjectNodeJSSDK(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: volumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    initContainerName,
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "-a", "/autoinstrumentation/.", "/otel-auto-instrumentation/"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      volumeName,
+								MountPath: "/otel-auto-instrumentation",
+							}},
+						},
+					},
 					Containers: []corev1.Container{
 						{
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      volumeName,
+									MountPath: "/otel-auto-instrumentation",
+								},
+							},
 							Env: []corev1.EnvVar{
 								{
-									Name:      "NODE_OPTIONS",
+									Name:      "NODE_OPTIONS_ORIGINAL",
 									ValueFrom: &corev1.EnvVarSource{},
 								},
+								{
+									Name:  "NODE_OPTIONS",
+									Value: "$(NODE_OPTIONS_ORIGINAL)" + nodeRequireArgument,
+								},
 							},
 						},
 					},
 				},
 			},
-			err: fmt.Errorf("the container defines env var value via ValueFrom, envVar: %s", envNodeOptions),
+			err: nil,
 		},
 	}
 
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -54,13 +55,15 @@ type languageInstrumentations struct {
 
 var _ webhookhandler.PodMutator = (*instPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, client client.Client, recorder record.EventRecorder) *instPodMutator {
+func NewMutator(logger logr.Logger, config config.Config, client client.Client, recorder record.EventRecorder) *instPodMutator {
 	return &instPodMutator{
 		Logger: logger,
 		Client: client,
 		sdkInjector: &sdkInjector{
-			logger: logger,
-			client: client,
+			config:   config,
+			logger:   logger,
+			client:   client,
+			recorder: recorder,
 		},
 		Recorder: recorder,
 	}
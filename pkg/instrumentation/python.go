@@ -36,11 +36,6 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 	// caller checks if there is at least one container.
 	container := &pod.Spec.Containers[index]
 
-	err := validateContainerEnv(container.Env, envPythonPath)
-	if err != nil {
-		return pod, err
-	}
-
 	// inject Python instrumentation spec env vars.
 	for _, env := range pythonSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -56,6 +51,7 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 			Value: fmt.Sprintf("%s:%s", pythonPathPrefix, pythonPathSuffix),
 		})
 	} else if idx > -1 {
+		container.Env, idx = exposeEnvValueFrom(container.Env, idx)
 		container.Env[idx].Value = fmt.Sprintf("%s:%s:%s", pythonPathPrefix, container.Env[idx].Value, pythonPathSuffix)
 	}
 
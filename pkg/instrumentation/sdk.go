@@ -17,6 +17,7 @@ package instrumentation
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -31,24 +32,52 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/proxy"
 )
 
 const (
 	volumeName        = "opentelemetry-auto-instrumentation"
 	initContainerName = "opentelemetry-auto-instrumentation"
 	sideCarName       = "opentelemetry-auto-instrumentation"
+
+	// envOriginalValueSuffix is appended to the name of env vars holding the original
+	// ValueFrom source of an env var the instrumentation needs to extend.
+	envOriginalValueSuffix = "_ORIGINAL"
+
+	// Knative propagates the labels identifying the service and revision to the workloads it creates.
+	knativeServiceLabel       = "serving.knative.dev/service"
+	knativeConfigurationLabel = "serving.knative.dev/configuration"
+	knativeRevisionLabel      = "serving.knative.dev/revision"
+	argoRolloutsAPIGroup      = "argoproj.io"
+)
+
+// The default OTLP ports the auto endpoint resolves to, for the languages exporting over gRPC and over HTTP.
+const (
+	otlpGRPCPort = 4317
+	otlpHTTPPort = 4318
+)
+
+// Argo Rollouts aren't covered by the semantic conventions, their attributes follow the naming of the Deployment ones.
+const (
+	k8sRolloutNameKey = attribute.Key("k8s.rollout.name")
+	k8sRolloutUIDKey  = attribute.Key("k8s.rollout.uid")
 )
 
 // inject a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 
 type sdkInjector struct {
-	client client.Client
-	logger logr.Logger
+	config   config.Config
+	client   client.Client
+	logger   logr.Logger
+	recorder record.EventRecorder
 }
 
 func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations, ns corev1.Namespace, pod corev1.Pod, containerName string) corev1.Pod {
@@ -57,8 +86,8 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 
 	// We search for specific container to inject variables and if no one is found
-	// We fallback to first container
-	var index = 0
+	// We fallback to first container that isn't a sidecar injected by the operator or another webhook
+	var index = applicationContainerIndex(pod)
 	for idx, ctnair := range pod.Spec.Containers {
 		if ctnair.Name == containerName {
 			index = idx
@@ -66,7 +95,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 
 	if insts.Java != nil {
-		otelinst := *insts.Java
+		otelinst := i.resolveEndpoint(ctx, *insts.Java, pod, otlpGRPCPort)
 		var err error
 		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
@@ -78,7 +107,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 	if insts.NodeJS != nil {
-		otelinst := *insts.NodeJS
+		otelinst := i.resolveEndpoint(ctx, *insts.NodeJS, pod, otlpGRPCPort)
 		var err error
 		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
@@ -90,7 +119,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 	if insts.Python != nil {
-		otelinst := *insts.Python
+		otelinst := i.resolveEndpoint(ctx, *insts.Python, pod, otlpHTTPPort)
 		var err error
 		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index)
@@ -102,7 +131,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 	if insts.DotNet != nil {
-		otelinst := *insts.DotNet
+		otelinst := i.resolveEndpoint(ctx, *insts.DotNet, pod, otlpHTTPPort)
 		var err error
 		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index)
@@ -115,7 +144,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	}
 	if insts.Go != nil {
 		origPod := pod
-		otelinst := *insts.Go
+		otelinst := i.resolveEndpoint(ctx, *insts.Go, pod, otlpGRPCPort)
 		var err error
 		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectGoSDK(otelinst.Spec.Go, pod)
@@ -135,7 +164,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 	if insts.ApacheHttpd != nil {
-		otelinst := *insts.ApacheHttpd
+		otelinst := i.resolveEndpoint(ctx, *insts.ApacheHttpd, pod, otlpGRPCPort)
 		i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		// Apache agent is configured via config files rather than env vars.
 		// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
@@ -144,7 +173,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 	}
 	if insts.Sdk != nil {
-		otelinst := *insts.Sdk
+		otelinst := i.resolveEndpoint(ctx, *insts.Sdk, pod, otlpGRPCPort)
 		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod = i.injectCommonEnvVar(otelinst, pod, index)
 		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
@@ -154,15 +183,103 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 func (i *sdkInjector) injectCommonEnvVar(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int) corev1.Pod {
 	container := &pod.Spec.Containers[index]
+	// the node's IP is defined first, so that the endpoint and the proxy settings can refer to it
+	if strings.Contains(otelinst.Spec.Endpoint, nodeIPRef) && getIndexOfEnv(container.Env, constants.EnvNodeIP) == -1 {
+		container.Env = append(container.Env, nodeIPEnvVar())
+	}
 	for _, env := range otelinst.Spec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
 		if idx == -1 {
 			container.Env = append(container.Env, env)
 		}
 	}
+	for _, env := range legacyAgentEnvVars(otelinst.Spec.LegacyAgents, otelinst.Spec.Endpoint) {
+		if getIndexOfEnv(container.Env, env.Name) == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+	// The SDKs read the proxy from the variables applying to all the traffic of the container, so only the proxy set
+	// explicitly by the instrumentation is injected, never the one of the operator.
+	if otelinst.Spec.Proxy != nil {
+		container.Env = proxy.MergeEnvVars(container.Env, proxy.EnvVars(i.config, otelinst.Spec.Proxy, inClusterHost(otelinst.Spec.Endpoint)))
+	}
 	return pod
 }
 
+// nodeIPRef is the reference to the environment variable holding the IP of the pod's node.
+var nodeIPRef = fmt.Sprintf("$(%s)", constants.EnvNodeIP)
+
+// resolveEndpoint returns the instrumentation with its auto endpoint resolved for the given pod: the collector sidecar
+// of the pod when it has one, or else the collector of the daemonset on the pod's node, on the given port. Without a
+// collector daemonset, the endpoint is left to the default of the SDKs.
+func (i *sdkInjector) resolveEndpoint(ctx context.Context, otelinst v1alpha1.Instrumentation, pod corev1.Pod, port int) v1alpha1.Instrumentation {
+	if otelinst.Spec.Endpoint != v1alpha1.ExporterEndpointAuto {
+		return otelinst
+	}
+	switch {
+	case hasCollectorSidecar(pod):
+		otelinst.Spec.Endpoint = fmt.Sprintf("http://localhost:%d", port)
+	case i.hasCollectorDaemonSet(ctx):
+		otelinst.Spec.Endpoint = fmt.Sprintf("http://%s:%d", nodeIPRef, port)
+	default:
+		i.logger.Info("the pod has no collector sidecar and no collector runs as a daemonset, the exporter endpoint is left to the default of the SDK", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		otelinst.Spec.Endpoint = ""
+	}
+	return otelinst
+}
+
+// hasCollectorDaemonSet checks whether a collector runs as a daemonset, in any namespace. The collector is assumed to
+// run when the collectors can't be listed.
+func (i *sdkInjector) hasCollectorDaemonSet(ctx context.Context) bool {
+	collectors := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := i.client.List(ctx, collectors); err != nil {
+		i.logger.Error(err, "failed to list the collectors, assuming a collector runs as a daemonset")
+		return true
+	}
+	for _, otelcol := range collectors.Items {
+		if otelcol.Spec.Mode == v1alpha1.ModeDaemonSet && !otelcol.Spec.Hibernate {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCollectorSidecar checks whether the collector sidecar was injected into the given pod.
+func hasCollectorSidecar(pod corev1.Pod) bool {
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if container.Name == naming.Container() {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeIPEnvVar returns the environment variable holding the IP of the pod's node.
+func nodeIPEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: constants.EnvNodeIP,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "status.hostIP",
+			},
+		},
+	}
+}
+
+// inClusterHost returns the host of the given endpoint when it's the name of a service of the cluster, which the
+// instrumented containers reach without the proxy.
+func inClusterHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+	if !strings.Contains(host, ".") || strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.") {
+		return host
+	}
+	return ""
+}
+
 // injectCommonSDKConfig adds common SDK configuration environment variables to the necessary pod
 // agentIndex represents the index of the pod the needs the env vars to instrument the application.
 // appIndex represents the index of the pod the will produce the telemetry.
@@ -190,40 +307,21 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 		}
 	}
 
-	// Some attributes might be empty, we should get them via k8s downward API
-	if resourceMap[string(semconv.K8SPodNameKey)] == "" {
-		container.Env = append(container.Env, corev1.EnvVar{
-			Name: constants.EnvPodName,
-			ValueFrom: &corev1.EnvVarSource{
-				FieldRef: &corev1.ObjectFieldSelector{
-					FieldPath: "metadata.name",
-				},
-			},
-		})
+	// Some attributes might be empty, we should get them via k8s downward API.
+	// The attributes the container already has, e.g. when the webhook is invoked again after other webhooks, are kept.
+	existingRes := resourceAttributeKeys(*container)
+	if resourceMap[string(semconv.K8SPodNameKey)] == "" && !existingRes[string(semconv.K8SPodNameKey)] {
+		container.Env = appendDownwardEnv(container.Env, constants.EnvPodName, "metadata.name")
 		resourceMap[string(semconv.K8SPodNameKey)] = fmt.Sprintf("$(%s)", constants.EnvPodName)
 	}
 	if otelinst.Spec.Resource.AddK8sUIDAttributes {
-		if resourceMap[string(semconv.K8SPodUIDKey)] == "" {
-			container.Env = append(container.Env, corev1.EnvVar{
-				Name: constants.EnvPodUID,
-				ValueFrom: &corev1.EnvVarSource{
-					FieldRef: &corev1.ObjectFieldSelector{
-						FieldPath: "metadata.uid",
-					},
-				},
-			})
+		if resourceMap[string(semconv.K8SPodUIDKey)] == "" && !existingRes[string(semconv.K8SPodUIDKey)] {
+			container.Env = appendDownwardEnv(container.Env, constants.EnvPodUID, "metadata.uid")
 			resourceMap[string(semconv.K8SPodUIDKey)] = fmt.Sprintf("$(%s)", constants.EnvPodUID)
 		}
 	}
-	if resourceMap[string(semconv.K8SNodeNameKey)] == "" {
-		container.Env = append(container.Env, corev1.EnvVar{
-			Name: constants.EnvNodeName,
-			ValueFrom: &corev1.EnvVarSource{
-				FieldRef: &corev1.ObjectFieldSelector{
-					FieldPath: "spec.nodeName",
-				},
-			},
-		})
+	if resourceMap[string(semconv.K8SNodeNameKey)] == "" && !existingRes[string(semconv.K8SNodeNameKey)] {
+		container.Env = appendDownwardEnv(container.Env, constants.EnvNodeName, "spec.nodeName")
 		resourceMap[string(semconv.K8SNodeNameKey)] = fmt.Sprintf("$(%s)", constants.EnvNodeName)
 	}
 
@@ -234,7 +332,8 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			Name:  constants.EnvOTELResourceAttrs,
 			Value: resStr,
 		})
-	} else {
+	} else if len(resStr) > 0 {
+		container.Env, idx = exposeEnvValueFrom(container.Env, idx)
 		if !strings.HasSuffix(container.Env[idx].Value, ",") {
 			resStr = "," + resStr
 		}
@@ -281,9 +380,15 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 }
 
 func chooseServiceName(pod corev1.Pod, resources map[string]string, index int) string {
+	if name := resources[string(semconv.FaaSNameKey)]; name != "" {
+		return name
+	}
 	if name := resources[string(semconv.K8SDeploymentNameKey)]; name != "" {
 		return name
 	}
+	if name := resources[string(k8sRolloutNameKey)]; name != "" {
+		return name
+	}
 	if name := resources[string(semconv.K8SStatefulSetNameKey)]; name != "" {
 		return name
 	}
@@ -303,18 +408,7 @@ func chooseServiceName(pod corev1.Pod, resources map[string]string, index int) s
 // User defined attributes (in explicitly set env var) have higher precedence.
 func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int) map[string]string {
 	// get existing resources env var and parse it into a map
-	existingRes := map[string]bool{}
-	existingResourceEnvIdx := getIndexOfEnv(pod.Spec.Containers[index].Env, constants.EnvOTELResourceAttrs)
-	if existingResourceEnvIdx > -1 {
-		existingResArr := strings.Split(pod.Spec.Containers[index].Env[existingResourceEnvIdx].Value, ",")
-		for _, kv := range existingResArr {
-			keyValueArr := strings.Split(strings.TrimSpace(kv), "=")
-			if len(keyValueArr) != 2 {
-				continue
-			}
-			existingRes[keyValueArr[0]] = true
-		}
-	}
+	existingRes := resourceAttributeKeys(pod.Spec.Containers[index])
 
 	res := map[string]string{}
 	for k, v := range otelinst.Spec.Resource.Attributes {
@@ -331,7 +425,8 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 	k8sResources[semconv.K8SPodNameKey] = pod.Name
 	k8sResources[semconv.K8SPodUIDKey] = string(pod.UID)
 	k8sResources[semconv.K8SNodeNameKey] = pod.Spec.NodeName
-	i.addParentResourceLabels(ctx, otelinst.Spec.Resource.AddK8sUIDAttributes, ns, pod.ObjectMeta, k8sResources)
+	i.addKnativeResourceLabels(pod.ObjectMeta, k8sResources)
+	i.addParentResourceLabels(ctx, otelinst.Spec.Resource.AddK8sUIDAttributes, ns, pod, pod.ObjectMeta, k8sResources)
 	for k, v := range k8sResources {
 		if !existingRes[string(k)] && v != "" {
 			res[string(k)] = v
@@ -340,7 +435,42 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 	return res
 }
 
-func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns corev1.Namespace, objectMeta metav1.ObjectMeta, resources map[attribute.Key]string) {
+// resourceAttributeKeys returns the keys of the resource attributes already set in the OTEL_RESOURCE_ATTRIBUTES of the
+// given container.
+func resourceAttributeKeys(container corev1.Container) map[string]bool {
+	keys := map[string]bool{}
+	idx := getIndexOfEnv(container.Env, constants.EnvOTELResourceAttrs)
+	if idx == -1 {
+		return keys
+	}
+	for _, kv := range strings.Split(container.Env[idx].Value, ",") {
+		keyValueArr := strings.Split(strings.TrimSpace(kv), "=")
+		if len(keyValueArr) != 2 {
+			continue
+		}
+		keys[keyValueArr[0]] = true
+	}
+	return keys
+}
+
+// appendDownwardEnv adds the environment variable holding the given field of the pod, unless the container has it.
+func appendDownwardEnv(envs []corev1.EnvVar, name string, fieldPath string) []corev1.EnvVar {
+	if getIndexOfEnv(envs, name) > -1 {
+		return envs
+	}
+	return append(envs, corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fieldPath,
+			},
+		},
+	})
+}
+
+// addParentResourceLabels walks the owner chain of the given object, e.g. Pod -> ReplicaSet -> Deployment or Rollout,
+// and adds the attributes of the owning workloads.
+func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns corev1.Namespace, pod corev1.Pod, objectMeta metav1.ObjectMeta, resources map[attribute.Key]string) {
 	for _, owner := range objectMeta.OwnerReferences {
 		switch strings.ToLower(owner.Kind) {
 		case "replicaset":
@@ -366,7 +496,7 @@ func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns
 			if err != nil {
 				i.logger.Error(err, "failed to get replicaset", "replicaset", nsn.Name, "namespace", nsn.Namespace)
 			}
-			i.addParentResourceLabels(ctx, uid, ns, rs.ObjectMeta, resources)
+			i.addParentResourceLabels(ctx, uid, ns, pod, rs.ObjectMeta, resources)
 		case "deployment":
 			resources[semconv.K8SDeploymentNameKey] = owner.Name
 			if uid {
@@ -392,10 +522,55 @@ func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns
 			if uid {
 				resources[semconv.K8SCronJobUIDKey] = string(owner.UID)
 			}
+		case "rollout":
+			if ownerAPIGroup(owner) != argoRolloutsAPIGroup {
+				i.unknownOwnerKind(pod, owner)
+				continue
+			}
+			resources[k8sRolloutNameKey] = owner.Name
+			if uid {
+				resources[k8sRolloutUIDKey] = string(owner.UID)
+			}
+		case "replicationcontroller", "node":
+			// the pods of the replication controllers and the mirror pods of the static pods have no workload attributes
+		default:
+			i.unknownOwnerKind(pod, owner)
 		}
 	}
 }
 
+// addKnativeResourceLabels adds the attributes of the Knative service and revision the object belongs to.
+// Knative services are represented as FaaS, their pods are owned by a Deployment owned by the Revision.
+func (i *sdkInjector) addKnativeResourceLabels(objectMeta metav1.ObjectMeta, resources map[attribute.Key]string) {
+	revision, ok := objectMeta.Labels[knativeRevisionLabel]
+	if !ok {
+		return
+	}
+	resources[semconv.FaaSVersionKey] = revision
+	if name := objectMeta.Labels[knativeServiceLabel]; name != "" {
+		resources[semconv.FaaSNameKey] = name
+	} else if name := objectMeta.Labels[knativeConfigurationLabel]; name != "" {
+		resources[semconv.FaaSNameKey] = name
+	}
+}
+
+// unknownOwnerKind reports the owners of the given pod the resource attributes can't be derived from.
+func (i *sdkInjector) unknownOwnerKind(pod corev1.Pod, owner metav1.OwnerReference) {
+	i.logger.V(1).Info("unknown owner kind, skipping its resource attributes", "kind", owner.Kind, "apiVersion", owner.APIVersion, "owner", owner.Name)
+	if i.recorder != nil {
+		i.recorder.Event(pod.DeepCopy(), "Warning", "UnknownOwnerKind",
+			fmt.Sprintf("resource attributes can't be derived from the owner %s %q, the service name might not reflect the workload", owner.Kind, owner.Name))
+	}
+}
+
+func ownerAPIGroup(owner metav1.OwnerReference) string {
+	group, _, found := strings.Cut(owner.APIVersion, "/")
+	if !found {
+		return ""
+	}
+	return group
+}
+
 func resourceMapToStr(res map[string]string) string {
 	keys := make([]string, 0, len(res))
 	for k := range res {
@@ -433,16 +608,24 @@ func moveEnvToListEnd(envs []corev1.EnvVar, idx int) []corev1.EnvVar {
 	return envs
 }
 
-func validateContainerEnv(envs []corev1.EnvVar, envsToBeValidated ...string) error {
-	for _, envToBeValidated := range envsToBeValidated {
-		for _, containerEnv := range envs {
-			if containerEnv.Name == envToBeValidated {
-				if containerEnv.ValueFrom != nil {
-					return fmt.Errorf("the container defines env var value via ValueFrom, envVar: %s", containerEnv.Name)
-				}
-				break
-			}
-		}
-	}
-	return nil
+// exposeEnvValueFrom prepares the env var at the given index to have its value extended.
+// When the env var defines its value via ValueFrom, the source is moved to a new env var
+// declared right before it and the original env var is rewritten to reference the new one
+// using the dependent environment variable syntax, e.g. $(JAVA_TOOL_OPTIONS_ORIGINAL).
+// It returns the updated env list and the index of the original env var.
+func exposeEnvValueFrom(envs []corev1.EnvVar, idx int) ([]corev1.EnvVar, int) {
+	if idx < 0 || idx >= len(envs) || envs[idx].ValueFrom == nil {
+		return envs, idx
+	}
+	original := corev1.EnvVar{
+		Name:      envs[idx].Name + envOriginalValueSuffix,
+		ValueFrom: envs[idx].ValueFrom,
+	}
+	envs[idx].ValueFrom = nil
+	envs[idx].Value = fmt.Sprintf("$(%s)", original.Name)
+
+	envs = append(envs, corev1.EnvVar{})
+	copy(envs[idx+1:], envs[idx:])
+	envs[idx] = original
+	return envs, idx + 1
 }
@@ -27,8 +27,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
 var testResourceRequirements = corev1.ResourceRequirements{
@@ -301,6 +306,76 @@ func TestSDKInjection(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "OTEL_RESOURCE_ATTRIBUTES defined via ValueFrom",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{},
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "project1",
+					Name:      "app",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "application-name",
+							Env: []corev1.EnvVar{
+								{
+									Name: "OTEL_RESOURCE_ATTRIBUTES",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: "otel"},
+											Key:                  "resource-attributes",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "project1",
+					Name:      "app",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "application-name",
+							Env: []corev1.EnvVar{
+								{
+									Name: "OTEL_RESOURCE_ATTRIBUTES_ORIGINAL",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: "otel"},
+											Key:                  "resource-attributes",
+										},
+									},
+								},
+								{
+									Name:  "OTEL_SERVICE_NAME",
+									Value: "app",
+								},
+								{
+									Name: "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "spec.nodeName",
+										},
+									},
+								},
+								{
+									Name:  "OTEL_RESOURCE_ATTRIBUTES",
+									Value: "$(OTEL_RESOURCE_ATTRIBUTES_ORIGINAL),k8s.container.name=application-name,k8s.namespace.name=project1,k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME),k8s.pod.name=app",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Empty instrumentation spec",
 			inst: v1alpha1.Instrumentation{
@@ -384,6 +459,181 @@ func TestSDKInjection(t *testing.T) {
 	}
 }
 
+func TestSDKInjectionWorkloadOwners(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workload-owners",
+		},
+	}
+	err := k8sClient.Create(context.Background(), &ns)
+	require.NoError(t, err)
+	rs := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-rollout-5d4f8c",
+			Namespace: "workload-owners",
+			UID:       "rsuid",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "Rollout",
+					APIVersion: "argoproj.io/v1alpha1",
+					Name:       "my-rollout",
+					UID:        "rolloutuid",
+				},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "my"},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "my"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "foo:bar"}},
+				},
+			},
+		},
+	}
+	err = k8sClient.Create(context.Background(), &rs)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		pod                 corev1.Pod
+		expectedServiceName string
+		expectedResources   map[string]string
+		expectedEvents      int
+	}{
+		{
+			name: "argo rollout",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "workload-owners",
+					Name:      "app",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Kind:       "ReplicaSet",
+							APIVersion: "apps/v1",
+							Name:       "my-rollout-5d4f8c",
+							UID:        "rsuid",
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "application-name"}},
+				},
+			},
+			expectedServiceName: "my-rollout",
+			expectedResources: map[string]string{
+				"k8s.container.name":  "application-name",
+				"k8s.namespace.name":  "workload-owners",
+				"k8s.pod.name":        "app",
+				"k8s.replicaset.name": "my-rollout-5d4f8c",
+				"k8s.replicaset.uid":  "rsuid",
+				"k8s.rollout.name":    "my-rollout",
+				"k8s.rollout.uid":     "rolloutuid",
+			},
+		},
+		{
+			name: "knative revision",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "workload-owners",
+					Name:      "app",
+					Labels: map[string]string{
+						"serving.knative.dev/service":       "hello",
+						"serving.knative.dev/configuration": "hello",
+						"serving.knative.dev/revision":      "hello-00001",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "application-name"}},
+				},
+			},
+			expectedServiceName: "hello",
+			expectedResources: map[string]string{
+				"faas.name":          "hello",
+				"faas.version":       "hello-00001",
+				"k8s.container.name": "application-name",
+				"k8s.namespace.name": "workload-owners",
+				"k8s.pod.name":       "app",
+			},
+		},
+		{
+			name: "unknown owner kind",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "workload-owners",
+					Name:      "app",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Kind:       "CloneSet",
+							APIVersion: "apps.kruise.io/v1alpha1",
+							Name:       "my-cloneset",
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "application-name"}},
+				},
+			},
+			expectedServiceName: "app",
+			expectedResources: map[string]string{
+				"k8s.container.name": "application-name",
+				"k8s.namespace.name": "workload-owners",
+				"k8s.pod.name":       "app",
+			},
+			expectedEvents: 1,
+		},
+		{
+			name: "replication controller",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "workload-owners",
+					Name:      "app",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Kind:       "ReplicationController",
+							APIVersion: "v1",
+							Name:       "my-rc",
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "application-name"}},
+				},
+			},
+			expectedServiceName: "app",
+			expectedResources: map[string]string{
+				"k8s.container.name": "application-name",
+				"k8s.namespace.name": "workload-owners",
+				"k8s.pod.name":       "app",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			inj := sdkInjector{
+				client:   k8sClient,
+				logger:   logr.Discard(),
+				recorder: recorder,
+			}
+			inst := v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Resource: v1alpha1.Resource{AddK8sUIDAttributes: true},
+				},
+			}
+			resources := inj.createResourceMap(context.Background(), inst, ns, test.pod, 0)
+			assert.Equal(t, test.expectedResources, resources)
+			assert.Equal(t, test.expectedServiceName, chooseServiceName(test.pod, resources, 0))
+			assert.Len(t, recorder.Events, test.expectedEvents)
+		})
+	}
+}
+
 func TestInjectJava(t *testing.T) {
 	inst := v1alpha1.Instrumentation{
 		Spec: v1alpha1.InstrumentationSpec{
@@ -1316,3 +1566,234 @@ func TestInjectSdkOnly(t *testing.T) {
 		},
 	}, pod)
 }
+
+func TestInjectSdkOnlyReinvoked(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{
+				Endpoint: "https://collector:4318",
+			},
+			Resource: v1alpha1.Resource{
+				AddK8sUIDAttributes: true,
+			},
+		},
+	}
+	insts := languageInstrumentations{
+		Sdk: &inst,
+	}
+
+	inj := sdkInjector{
+		logger: logr.Discard(),
+	}
+	// a service mesh injected its proxy before the application container, after the first invocation of the webhook
+	injected := inj.inject(context.Background(), insts, corev1.Namespace{}, corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}, "")
+	injected.Spec.Containers = append([]corev1.Container{{Name: "linkerd-proxy"}}, injected.Spec.Containers...)
+
+	reinvoked := inj.inject(context.Background(), insts, corev1.Namespace{}, *injected.DeepCopy(), "")
+	assert.Equal(t, injected, reinvoked)
+}
+
+func TestInjectProxy(t *testing.T) {
+	inj := sdkInjector{
+		config: config.New(
+			config.WithHTTPProxy("http://operator-proxy:3128"),
+			config.WithNoProxy(".svc"),
+		),
+		logger: logr.Discard(),
+	}
+	instProxy := &v1alpha1.ProxySpec{HTTPProxy: "http://proxy:3128", NoProxy: ".svc"}
+
+	tests := []struct {
+		name     string
+		inst     v1alpha1.Instrumentation
+		env      []corev1.EnvVar
+		expected []corev1.EnvVar
+	}{
+		{
+			name: "in-cluster endpoint",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "http://otel-collector:4317"},
+					Proxy:    instProxy,
+				},
+			},
+			expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "NO_PROXY", Value: ".svc,otel-collector"},
+			},
+		},
+		{
+			name: "external endpoint",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "https://otlp.example.com:4317"},
+					Proxy:    instProxy,
+				},
+			},
+			expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "NO_PROXY", Value: ".svc"},
+			},
+		},
+		{
+			name: "proxy of the container",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "https://otlp.example.com:4317"},
+					Proxy:    instProxy,
+				},
+			},
+			env: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://app-proxy:3128"},
+			},
+			expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://app-proxy:3128"},
+				{Name: "NO_PROXY", Value: ".svc"},
+			},
+		},
+		{
+			name: "proxy of the operator isn't applied to the application",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "https://otlp.example.com:4317"},
+				},
+			},
+		},
+		{
+			name: "empty proxy of the instrumentation",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "https://otlp.example.com:4317"},
+					Proxy:    &v1alpha1.ProxySpec{},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Env: test.env}},
+				},
+			}
+			pod = inj.injectCommonEnvVar(test.inst, pod, 0)
+			assert.Equal(t, test.expected, pod.Spec.Containers[0].Env)
+		})
+	}
+}
+
+func TestInjectAutoEndpoint(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{
+				Endpoint: v1alpha1.ExporterEndpointAuto,
+			},
+		},
+	}
+	inj := sdkInjector{
+		logger: logr.Discard(),
+		client: clientWithCollectors(t, daemonSetCollector()),
+	}
+
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		expected   []corev1.EnvVar
+	}{
+		{
+			name:       "collector of the node",
+			containers: []corev1.Container{{Name: "app"}},
+			expected: []corev1.EnvVar{
+				{
+					Name: "OTEL_NODE_IP",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.hostIP",
+						},
+					},
+				},
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://$(OTEL_NODE_IP):4317"},
+			},
+		},
+		{
+			name:       "collector sidecar",
+			containers: []corev1.Container{{Name: "app"}, {Name: "otc-container"}},
+			expected: []corev1.EnvVar{
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://localhost:4317"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := inj.inject(context.Background(), languageInstrumentations{Sdk: &inst}, corev1.Namespace{},
+				corev1.Pod{Spec: corev1.PodSpec{Containers: test.containers}}, "app")
+
+			var env []corev1.EnvVar
+			for _, e := range pod.Spec.Containers[0].Env {
+				if e.Name == "OTEL_NODE_IP" || e.Name == "OTEL_EXPORTER_OTLP_ENDPOINT" {
+					env = append(env, e)
+				}
+			}
+			assert.Equal(t, test.expected, env)
+			// the endpoint is resolved for each pod, the instrumentation is left as is
+			assert.Equal(t, v1alpha1.ExporterEndpointAuto, inst.Spec.Endpoint)
+		})
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	auto := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: v1alpha1.ExporterEndpointAuto},
+		},
+	}
+	explicit := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://otel-collector:4317"},
+		},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	nativeSidecar := corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "otc-container"}},
+		Containers:     []corev1.Container{{Name: "app"}},
+	}}
+	inj := sdkInjector{
+		logger: logr.Discard(),
+		client: clientWithCollectors(t, daemonSetCollector()),
+	}
+	ctx := context.Background()
+
+	assert.Equal(t, "http://$(OTEL_NODE_IP):4318", inj.resolveEndpoint(ctx, auto, pod, otlpHTTPPort).Spec.Endpoint)
+	assert.Equal(t, "http://localhost:4318", inj.resolveEndpoint(ctx, auto, nativeSidecar, otlpHTTPPort).Spec.Endpoint)
+	assert.Equal(t, "http://otel-collector:4317", inj.resolveEndpoint(ctx, explicit, pod, otlpHTTPPort).Spec.Endpoint)
+
+	// without a collector daemonset, the endpoint is left to the default of the SDKs
+	hibernated := daemonSetCollector()
+	hibernated.Spec.Hibernate = true
+	deployment := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDeployment},
+	}
+	inj.client = clientWithCollectors(t, hibernated, deployment)
+	assert.Empty(t, inj.resolveEndpoint(ctx, auto, pod, otlpHTTPPort).Spec.Endpoint)
+	assert.Equal(t, "http://localhost:4318", inj.resolveEndpoint(ctx, auto, nativeSidecar, otlpHTTPPort).Spec.Endpoint)
+}
+
+func daemonSetCollector() *v1alpha1.OpenTelemetryCollector {
+	return &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "observability"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: v1alpha1.ModeDaemonSet},
+	}
+}
+
+func clientWithCollectors(t *testing.T, collectors ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(collectors...).Build()
+}
@@ -19,14 +19,56 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
+// Instance builds the value of the app.kubernetes.io/instance label of the objects generated for the given instance,
+// i.e. <namespace>.<name>, truncated to the 63 characters of a label value.
+func Instance(otelcol v1alpha1.OpenTelemetryCollector) string {
+	if !otelcol.HashedNames() {
+		return Truncate("%s.%s", maxNameLength, otelcol.Namespace, otelcol.Name)
+	}
+	return truncateWithHash("%s.%s", otelcol.Namespace, otelcol.Name)
+}
+
+// instanceName builds the name of an object generated for the given instance like Name, unless the instance predates
+// the hashed names: its objects keep the names they were created with, only truncated.
+func instanceName(otelcol v1alpha1.OpenTelemetryCollector, format string, values ...interface{}) string {
+	if !otelcol.HashedNames() {
+		return DNSName(Truncate(format, maxNameLength, values...))
+	}
+	return Name(format, values...)
+}
+
 // ConfigMap builds the name for the config map used in the OpenTelemetryCollector containers.
 func ConfigMap(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// ConfigMapPart builds the name for the config map holding the given part of the collector's configuration. The first
+// part is held by the collector's config map.
+func ConfigMapPart(otelcol v1alpha1.OpenTelemetryCollector, part int) string {
+	if part == 0 {
+		return ConfigMap(otelcol)
+	}
+	return instanceName(otelcol, "%s-collector-%d", otelcol.BaseName(), part)
+}
+
+// ConfigMapNodeProfilePart builds the name for the config map holding the given part of the collector's configuration
+// on the nodes of the given node profile.
+func ConfigMapNodeProfilePart(otelcol v1alpha1.OpenTelemetryCollector, profile string, part int) string {
+	if part == 0 {
+		return instanceName(otelcol, "%s-collector-%s", otelcol.BaseName(), profile)
+	}
+	return instanceName(otelcol, "%s-collector-%s-%d", otelcol.BaseName(), profile, part)
+}
+
+// ConfigMapVersion builds the name for the config map with the given name holding the given version of the configuration
+// of the given instance.
+func ConfigMapVersion(otelcol v1alpha1.OpenTelemetryCollector, name, version string) string {
+	return instanceName(otelcol, "%s-%s", name, version)
 }
 
 // TAConfigMap returns the name for the config map used in the TargetAllocator.
 func TAConfigMap(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-targetallocator", otelcol.BaseName())
 }
 
 // ConfigMapVolume returns the name to use for the config map's volume in the pod.
@@ -34,16 +76,59 @@ func ConfigMapVolume() string {
 	return "otc-internal"
 }
 
+// RemoteWriteWALVolume returns the name to use for the volume claim of the write-ahead log of the remote write preset.
+func RemoteWriteWALVolume() string {
+	return "remote-write-wal"
+}
+
+// SecretProviderVolume returns the name to use for the volume of the given secret provider in the pod.
+func SecretProviderVolume(provider string) string {
+	return Name("secret-provider-%s", provider)
+}
+
+// ReceiverTLSVolume returns the name to use for the volume of the certificate of the given receiver in the pod.
+func ReceiverTLSVolume(receiver string) string {
+	return Name("receiver-tls-%s", ReceiverTLSDir(receiver))
+}
+
+// ReceiverTLSDir returns the name of the directory the certificate of the given receiver is mounted in. It ends with a
+// hash of the receiver name, as receivers only differing by characters that aren't DNS-safe, e.g. otlp/a and otlp-a,
+// would share it otherwise.
+func ReceiverTLSDir(receiver string) string {
+	return Name("%s-%s", receiver, hash(receiver))
+}
+
+// ExporterTLSVolume returns the name to use for the volume of the certificates of the given exporter in the pod.
+func ExporterTLSVolume(exporter string) string {
+	return Name("exporter-tls-%s", exporter)
+}
+
+// ExporterTokenVolume returns the name to use for the volume of the ServiceAccount token of the given exporter in the
+// pod.
+func ExporterTokenVolume(exporter string) string {
+	return Name("exporter-token-%s", exporter)
+}
+
 // TAConfigMapVolume returns the name to use for the config map's volume in the TargetAllocator pod.
 func TAConfigMapVolume() string {
 	return "ta-internal"
 }
 
+// TAAllocationStateVolume returns the name to use for the allocation state's volume in the TargetAllocator pod.
+func TAAllocationStateVolume() string {
+	return "ta-allocation-state"
+}
+
 // Container returns the name to use for the container in the pod.
 func Container() string {
 	return "otc-container"
 }
 
+// DebugContainer returns the name to use for the ephemeral debug container in the pod.
+func DebugContainer() string {
+	return "otc-debug"
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -51,65 +136,178 @@ func TAContainer() string {
 
 // Collector builds the collector (deployment/daemonset) name based on the instance.
 func Collector(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// CollectorColor builds the name of the collector deployment of the given color of the blue/green rollouts based on the
+// instance.
+func CollectorColor(otelcol v1alpha1.OpenTelemetryCollector, color string) string {
+	return instanceName(otelcol, "%s-collector-%s", otelcol.BaseName(), color)
+}
+
+// CollectorNodeProfile builds the collector daemonset name of the given node profile based on the instance.
+func CollectorNodeProfile(otelcol v1alpha1.OpenTelemetryCollector, profile string) string {
+	return instanceName(otelcol, "%s-collector-%s", otelcol.BaseName(), profile)
+}
+
+// CollectorZone builds the collector statefulset name of the given availability zone based on the instance.
+func CollectorZone(otelcol v1alpha1.OpenTelemetryCollector, zone string) string {
+	return instanceName(otelcol, "%s-collector-%s", otelcol.BaseName(), zone)
+}
+
+// ConfigValidationJob builds the name of the job validating the configuration with the given digest based on the
+// instance.
+func ConfigValidationJob(otelcol v1alpha1.OpenTelemetryCollector, digest string) string {
+	return instanceName(otelcol, "%s-validate-%s", otelcol.BaseName(), digest)
+}
+
+// ConfigValidationConfigMap builds the name of the config map holding the given part of the configuration validated
+// by the job with the given digest. The first part is held by the config map named after the job.
+func ConfigValidationConfigMap(otelcol v1alpha1.OpenTelemetryCollector, digest string, part int) string {
+	if part == 0 {
+		return ConfigValidationJob(otelcol, digest)
+	}
+	return instanceName(otelcol, "%s-validate-%s-%d", otelcol.BaseName(), digest, part)
 }
 
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// PodDisruptionBudget builds the pod disruption budget name based on the instance.
+func PodDisruptionBudget(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// VerticalPodAutoscaler builds the vertical pod autoscaler name based on the instance.
+func VerticalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// ServiceEntry builds the istio service entry name based on the instance.
+func ServiceEntry(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector-egress", otelcol.BaseName())
 }
 
-// OpenTelemetryCollector builds the collector (deployment/daemonset) name based on the instance.
+// IstioSidecar builds the istio sidecar name based on the instance.
+func IstioSidecar(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// PeerAuthentication builds the istio peer authentication name based on the instance.
+func PeerAuthentication(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// PodMonitor builds the pod monitor name based on the instance.
+func PodMonitor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
+}
+
+// OpenTelemetryCollector returns the name of the instance itself, e.g. for the objects referencing its scale
+// subresource.
 func OpenTelemetryCollector(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s", 63, otelcol.Name))
+	return otelcol.Name
 }
 
-// OpenTelemetryCollectorName builds the collector (deployment/daemonset) name based on the instance.
+// OpenTelemetryCollectorName returns the name of the instance with the given name itself.
 func OpenTelemetryCollectorName(otelcolName string) string {
-	return DNSName(Truncate("%s", 63, otelcolName))
+	return otelcolName
 }
 
 // TargetAllocator returns the TargetAllocator deployment resource name.
 func TargetAllocator(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-targetallocator", otelcol.BaseName())
+}
+
+// TargetAllocatorZone returns the TargetAllocator deployment resource name of the given availability zone.
+func TargetAllocatorZone(otelcol v1alpha1.OpenTelemetryCollector, zone string) string {
+	return instanceName(otelcol, "%s-targetallocator-%s", otelcol.BaseName(), zone)
+}
+
+// TargetAllocatorShard returns the TargetAllocator deployment resource name of the given job shard.
+func TargetAllocatorShard(otelcol v1alpha1.OpenTelemetryCollector, shard int32) string {
+	return instanceName(otelcol, "%s-targetallocator-%d", otelcol.BaseName(), shard)
 }
 
 // HeadlessService builds the name for the headless service based on the instance.
 func HeadlessService(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-headless", 63, Service(otelcol)))
+	return instanceName(otelcol, "%s-headless", Service(otelcol))
 }
 
 // MonitoringService builds the name for the monitoring service based on the instance.
 func MonitoringService(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-monitoring", 63, Service(otelcol)))
+	return instanceName(otelcol, "%s-monitoring", Service(otelcol))
 }
 
 // Service builds the service name based on the instance.
 func Service(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
 }
 
 // Ingress builds the ingress name based on the instance.
 func Ingress(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-ingress", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-ingress", otelcol.BaseName())
+}
+
+// PortIngress builds the name of the ingress of a port of the instance.
+func PortIngress(otelcol v1alpha1.OpenTelemetryCollector, port string) string {
+	return instanceName(otelcol, "%s-%s-ingress", otelcol.BaseName(), port)
+}
+
+// GRPCIngress builds the name of the ingress of the gRPC ports of the instance exposed by the given host.
+func GRPCIngress(otelcol v1alpha1.OpenTelemetryCollector, host string) string {
+	if len(host) == 0 {
+		return instanceName(otelcol, "%s-grpc-ingress", otelcol.BaseName())
+	}
+	return instanceName(otelcol, "%s-%s-grpc-ingress", otelcol.BaseName(), host)
 }
 
 // Route builds the route name based on the instance.
 func Route(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
-	return DNSName(Truncate("%s-%s-route", 63, prefix, otelcol.Name))
+	return instanceName(otelcol, "%s-%s-route", prefix, otelcol.BaseName())
 }
 
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-targetallocator", otelcol.BaseName())
+}
+
+// TAServiceZone returns the name to use for the TargetAllocator service of the given availability zone.
+func TAServiceZone(otelcol v1alpha1.OpenTelemetryCollector, zone string) string {
+	return instanceName(otelcol, "%s-targetallocator-%s", otelcol.BaseName(), zone)
+}
+
+// TAServiceShard returns the name to use for the TargetAllocator service of the given job shard.
+func TAServiceShard(otelcol v1alpha1.OpenTelemetryCollector, shard int32) string {
+	return instanceName(otelcol, "%s-targetallocator-%d", otelcol.BaseName(), shard)
+}
+
+// ClusterRole builds the name of the cluster role and cluster role binding based on the instance. As they are cluster
+// scoped, the name is built like ClusterObject.
+func ClusterRole(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return clusterObject(otelcol.BaseName(), otelcol.Namespace, otelcol.Name, "collector")
+}
+
+// ClusterObject builds the name of a cluster-scoped object generated for the instance with the given name in the given
+// namespace, i.e. <name>-<namespace>-<suffix>-<hash>. The hash of the namespace and name of the instance keeps apart
+// the instances whose names and namespaces join to the same string, like the instance a-b in the namespace c and the
+// instance a in the namespace b-c, which would otherwise share, and overwrite, their cluster roles.
+func ClusterObject(namespace, name, suffix string) string {
+	return clusterObject(name, namespace, name, suffix)
+}
+
+func clusterObject(baseName, namespace, name, suffix string) string {
+	return Name("%s-%s-%s-%s", baseName, namespace, suffix, hash(namespace+"/"+name))
 }
 
 // ServiceAccount builds the service account name based on the instance.
 func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-collector", otelcol.BaseName())
 }
 
 // TargetAllocatorServiceAccount returns the TargetAllocator service account resource name.
 func TargetAllocatorServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) string {
-	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+	return instanceName(otelcol, "%s-targetallocator", otelcol.BaseName())
 }
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestNameOverride(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "observability"},
+		Spec:       v1alpha1.OpenTelemetryCollectorSpec{NameOverride: "otel"},
+	}
+
+	assert.Equal(t, "otel-collector", Collector(otelcol))
+	assert.Equal(t, "otel-collector", ConfigMap(otelcol))
+	assert.Equal(t, "otel-collector-headless", HeadlessService(otelcol))
+	assert.Equal(t, "otel-targetallocator", TAService(otelcol))
+	assert.Equal(t, "otel-observability-collector-a35d036f", ClusterRole(otelcol))
+	// the instance itself keeps its name
+	assert.Equal(t, "my-instance", OpenTelemetryCollector(otelcol))
+	assert.Equal(t, "observability.my-instance", Instance(otelcol))
+}
+
+func TestClusterRole(t *testing.T) {
+	otelcol := func(namespace, name string) v1alpha1.OpenTelemetryCollector {
+		return v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	// the instance a-b in the namespace c and the instance a in the namespace b-c don't share their cluster role
+	assert.Equal(t, "a-b-c-collector-2fef8b1d", ClusterRole(otelcol("c", "a-b")))
+	assert.Equal(t, "a-b-c-collector-a421ac31", ClusterRole(otelcol("b-c", "a")))
+	assert.Equal(t, "a-b-c-collector-components-2fef8b1d", ClusterObject("c", "a-b", "collector-components"))
+	assert.Equal(t, "a-b-c-collector-components-a421ac31", ClusterObject("b-c", "a", "collector-components"))
+}
+
+func TestReceiverTLS(t *testing.T) {
+	// the receivers otlp/a and otlp-a don't share their certificate volume and directory
+	assert.NotEqual(t, ReceiverTLSDir("otlp/a"), ReceiverTLSDir("otlp-a"))
+	assert.NotEqual(t, ReceiverTLSVolume("otlp/a"), ReceiverTLSVolume("otlp-a"))
+	assert.Regexp(t, "^otlp-a-[0-9a-f]{8}$", ReceiverTLSDir("otlp/a"))
+	assert.Regexp(t, "^receiver-tls-otlp-a-[0-9a-f]{8}$", ReceiverTLSVolume("otlp/a"))
+}
+
+func TestInstance(t *testing.T) {
+	otelcol := func(name string) v1alpha1.OpenTelemetryCollector {
+		return v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "observability",
+			Annotations: map[string]string{v1alpha1.HashedNamesAnnotation: "true"},
+		}}
+	}
+	long := "collector-of-the-telemetry-of-the-payment-services-of-the-europe-region"
+
+	assert.Equal(t, "observability.simplest", Instance(otelcol("simplest")))
+	assert.LessOrEqual(t, len(Instance(otelcol(long+"-a"))), 63)
+	assert.NotEqual(t, Instance(otelcol(long+"-a")), Instance(otelcol(long+"-b")))
+}
+
+func TestUnhashedNames(t *testing.T) {
+	// the instances created before the names got a hash keep the names of their objects
+	otelcol := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{
+		Name:      "collector-of-the-telemetry-of-the-payment-services-of-the-europe-region",
+		Namespace: "observability",
+	}}
+
+	assert.Equal(t, "collector-of-the-telemetry-of-the-payment-services-of-collector", Collector(otelcol))
+	assert.Equal(t, "collector-of-the-telemetry-of-the-payment-services-of--headless", HeadlessService(otelcol))
+	assert.Equal(t, "collector-of-the-telemetry-of-the-payment-services-of-the-euro", Instance(otelcol))
+
+	otelcol.Annotations = map[string]string{v1alpha1.HashedNamesAnnotation: "true"}
+	assert.NotEqual(t, "collector-of-the-telemetry-of-the-payment-services-of-collector", Collector(otelcol))
+	assert.NotEqual(t, "collector-of-the-telemetry-of-the-payment-services-of-the-euro", Instance(otelcol))
+}
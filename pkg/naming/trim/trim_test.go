@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Additional copyrights:
+// Copyright The Jaeger Authors
+
+package trim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimNonAlphaNumeric(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "-%$#ThisIsALabel",
+			expected: "ThisIsALabel",
+		},
+
+		{
+			input:    "label-invalid--_truncated-.",
+			expected: "label-invalid--_truncated",
+		},
+
+		{
+			input:    "--(label-invalid--_truncated-#.1.",
+			expected: "label-invalid--_truncated-#.1",
+		},
+
+		{
+			input:    "12ValidLabel3",
+			expected: "12ValidLabel3",
+		},
+	}
+
+	for _, test := range tests {
+		output := trimNonAlphaNumeric(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
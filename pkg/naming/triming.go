@@ -19,56 +19,43 @@ package naming
 
 import (
 	"fmt"
-	"regexp"
-)
+	"hash/fnv"
 
-var regexpEndReplace, regexpBeginReplace *regexp.Regexp
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming/trim"
+)
 
-func init() {
-	regexpEndReplace, _ = regexp.Compile("[^A-Za-z0-9]+$")
-	regexpBeginReplace, _ = regexp.Compile("^[^A-Za-z0-9]+")
-}
+// maxNameLength is the maximum length of the names of the generated objects, the one of a DNS-1123 label.
+const maxNameLength = 63
 
 // Truncate will shorten the length of the instance name so that it contains at most max chars when combined with the fixed part
-// If the fixed part is already bigger than the max, this function is noop.
-// source: https://github.com/jaegertracing/jaeger-operator/blob/91e3b69ee5c8761bbda9d3cf431400a73fc1112a/pkg/util/truncate.go#L17
+// If the fixed part is already bigger than the max, this function is noop. See trim.Truncate.
 func Truncate(format string, max int, values ...interface{}) string {
-	var truncated []interface{}
-	result := fmt.Sprintf(format, values...)
-	if excess := len(result) - max; excess > 0 {
-		// we try to reduce the first string we find
-		for _, value := range values {
-			if excess == 0 {
-				truncated = append(truncated, value)
-				continue
-			}
-
-			if s, ok := value.(string); ok {
-				if len(s) > excess {
-					value = s[:len(s)-excess]
-					excess = 0
-				} else {
-					value = "" // skip this value entirely
-					excess = excess - len(s)
-				}
-			}
+	return trim.Truncate(format, max, values...)
+}
 
-			truncated = append(truncated, value)
-		}
-		result = fmt.Sprintf(format, truncated...)
-	}
+// Name builds a DNS-safe name of at most 63 characters out of the given format and values, e.g. "%s-collector" and the
+// name of an instance. The names longer than that are truncated like with Truncate, and end with a hash of the whole
+// name, so that the names of instances sharing a long prefix, or the names of an instance only differing by a suffix,
+// don't collide.
+func Name(format string, values ...interface{}) string {
+	return DNSName(truncateWithHash(format, values...))
+}
 
-	// if at this point, the result is still bigger than max, apply a hard cap:
-	if len(result) > max {
-		return result[:max]
+// truncateWithHash truncates the given format and values to at most 63 characters like Truncate, replacing the end of
+// the values with a hash of the whole string when it's longer than that.
+func truncateWithHash(format string, values ...interface{}) string {
+	whole := fmt.Sprintf(format, values...)
+	if len(whole) <= maxNameLength {
+		return Truncate(format, maxNameLength, values...)
 	}
 
-	return trimNonAlphaNumeric(result)
+	suffix := "-" + hash(whole)
+	return Truncate("%s%s", maxNameLength, Truncate(format, maxNameLength-len(suffix), values...), suffix)
 }
 
-// trimNonAlphaNumeric remove all non-alphanumeric values from start and end of the string
-// source: https://github.com/jaegertracing/jaeger-operator/blob/91e3b69ee5c8761bbda9d3cf431400a73fc1112a/pkg/util/truncate.go#L53
-func trimNonAlphaNumeric(text string) string {
-	newText := regexpEndReplace.ReplaceAllString(text, "")
-	return regexpBeginReplace.ReplaceAllString(newText, "")
+// hash returns the 8 hexadecimal digits of the FNV-1a hash of the given string.
+func hash(s string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return fmt.Sprintf("%08x", h.Sum32())
 }
@@ -80,34 +80,23 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
-func TestTrimNonAlphaNumeric(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{
-			input:    "-%$#ThisIsALabel",
-			expected: "ThisIsALabel",
-		},
+func TestName(t *testing.T) {
+	long := "d0c1e62-4d96-11ea-b174-c85b7644b6b5-5d0c1e62-4d96-11ea-b174-c85b7644b6b5"
 
-		{
-			input:    "label-invalid--_truncated-.",
-			expected: "label-invalid--_truncated",
-		},
+	t.Run("short names are left as is", func(t *testing.T) {
+		assert.Equal(t, "simplest-collector", Name("%s-collector", "simplest"))
+		assert.Equal(t, "my-collector-collector", Name("%s-collector", "my.collector"))
+	})
 
-		{
-			input:    "--(label-invalid--_truncated-#.1.",
-			expected: "label-invalid--_truncated-#.1",
-		},
-
-		{
-			input:    "12ValidLabel3",
-			expected: "12ValidLabel3",
-		},
-	}
+	t.Run("long names are truncated and end with a hash", func(t *testing.T) {
+		name := Name("%s-collector", long)
+		assert.Len(t, name, 63)
+		assert.Regexp(t, "^d0c1e62-4d96-11ea-b174-c85b7644b6b5-5d0c1e62-collector-[0-9a-f]{8}$", name)
+		assert.Equal(t, name, Name("%s-collector", long))
+	})
 
-	for _, test := range tests {
-		output := trimNonAlphaNumeric(test.input)
-		assert.Equal(t, test.expected, output)
-	}
+	t.Run("long names sharing a prefix don't collide", func(t *testing.T) {
+		assert.NotEqual(t, Name("%s-collector", long+"-a"), Name("%s-collector", long+"-b"))
+		assert.NotEqual(t, Name("%s-collector-%s", long, "us-east-1a"), Name("%s-collector-%s", long, "us-east-1b"))
+	})
 }
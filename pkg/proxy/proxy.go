@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxy handles the proxy settings of the containers managed by the operator.
+package proxy
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// KubernetesServiceHost expands to the address of the Kubernetes API in the environment variables of a container.
+const KubernetesServiceHost = "$(KUBERNETES_SERVICE_HOST)"
+
+// EnvVars returns the proxy environment variables of the given proxy, or of the proxy of the operator when nil.
+// The given hosts are added to NO_PROXY, so that the in-cluster traffic to them doesn't go through the proxy.
+func EnvVars(cfg config.Config, spec *v1alpha1.ProxySpec, noProxyHosts ...string) []corev1.EnvVar {
+	httpProxy, httpsProxy, noProxy := cfg.HTTPProxy(), cfg.HTTPSProxy(), cfg.NoProxy()
+	if spec != nil {
+		httpProxy, httpsProxy, noProxy = spec.HTTPProxy, spec.HTTPSProxy, spec.NoProxy
+	}
+	if httpProxy == "" && httpsProxy == "" {
+		return nil
+	}
+
+	var envVars []corev1.EnvVar
+	if httpProxy != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: constants.EnvHTTPProxy, Value: httpProxy})
+	}
+	if httpsProxy != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: constants.EnvHTTPSProxy, Value: httpsProxy})
+	}
+	if noProxy = withNoProxyHosts(noProxy, noProxyHosts); noProxy != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: constants.EnvNoProxy, Value: noProxy})
+	}
+	return envVars
+}
+
+// MergeEnvVars adds the given proxy environment variables to the given environment variables, unless they're
+// already set there.
+func MergeEnvVars(envVars []corev1.EnvVar, proxyEnvVars []corev1.EnvVar) []corev1.EnvVar {
+	for _, proxyEnvVar := range proxyEnvVars {
+		if !hasEnvVar(envVars, proxyEnvVar.Name) {
+			envVars = append(envVars, proxyEnvVar)
+		}
+	}
+	return envVars
+}
+
+func hasEnvVar(envVars []corev1.EnvVar, name string) bool {
+	for _, envVar := range envVars {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func withNoProxyHosts(noProxy string, hosts []string) string {
+	entries := map[string]bool{}
+	var noProxies []string
+	for _, entry := range strings.Split(noProxy, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries[entry] = true
+			noProxies = append(noProxies, entry)
+		}
+	}
+	for _, host := range hosts {
+		if host != "" && !entries[host] {
+			entries[host] = true
+			noProxies = append(noProxies, host)
+		}
+	}
+	return strings.Join(noProxies, ",")
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestEnvVars(t *testing.T) {
+	operatorProxy := config.New(
+		config.WithHTTPProxy("http://proxy:3128"),
+		config.WithHTTPSProxy("http://proxy:3129"),
+		config.WithNoProxy(".svc, .cluster.local"),
+	)
+
+	for _, tt := range []struct {
+		desc         string
+		cfg          config.Config
+		spec         *v1alpha1.ProxySpec
+		noProxyHosts []string
+		expected     []corev1.EnvVar
+	}{
+		{
+			desc: "no proxy",
+			cfg:  config.New(),
+		},
+		{
+			desc: "proxy of the operator",
+			cfg:  operatorProxy,
+			expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3129"},
+				{Name: "NO_PROXY", Value: ".svc,.cluster.local"},
+			},
+		},
+		{
+			desc: "proxy of the instance",
+			cfg:  operatorProxy,
+			spec: &v1alpha1.ProxySpec{HTTPSProxy: "http://instance-proxy:3128"},
+			expected: []corev1.EnvVar{
+				{Name: "HTTPS_PROXY", Value: "http://instance-proxy:3128"},
+			},
+		},
+		{
+			desc: "proxy disabled by the instance",
+			cfg:  operatorProxy,
+			spec: &v1alpha1.ProxySpec{},
+		},
+		{
+			desc:         "no proxy hosts",
+			cfg:          operatorProxy,
+			noProxyHosts: []string{KubernetesServiceHost, ".svc", ""},
+			expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3129"},
+				{Name: "NO_PROXY", Value: ".svc,.cluster.local,$(KUBERNETES_SERVICE_HOST)"},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EnvVars(tt.cfg, tt.spec, tt.noProxyHosts...))
+		})
+	}
+}
+
+func TestMergeEnvVars(t *testing.T) {
+	envVars := []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://user-proxy:3128"}}
+	proxyEnvVars := []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+		{Name: "HTTPS_PROXY", Value: "http://proxy:3129"},
+	}
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://user-proxy:3128"},
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+	}, MergeEnvVars(envVars, proxyEnvVars))
+}
@@ -23,6 +23,16 @@ import (
 const (
 	// Annotation contains the annotation name that pods contain, indicating whether a sidecar is desired.
 	Annotation = "sidecar.opentelemetry.io/inject"
+
+	// FlushTimeoutAnnotation contains the annotation name that pods contain, indicating how long the sidecar is
+	// given to flush its data once the pod is terminating, for instance "30s". It raises the pod's
+	// terminationGracePeriodSeconds when needed, and never lowers it.
+	FlushTimeoutAnnotation = "sidecar.opentelemetry.io/flush-timeout"
+
+	// TierAnnotation contains the annotation name that pods contain, indicating the sidecar tier of the
+	// OpenTelemetryCollector the sidecar is sized with, for instance "small". Pods without it, or with a tier the
+	// instance doesn't have, get the sidecar of the instance.
+	TierAnnotation = "sidecar.opentelemetry.io/tier"
 )
 
 // annotationValue returns the effective annotation value, based on the annotations from the pod and namespace.
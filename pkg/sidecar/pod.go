@@ -17,25 +17,35 @@ package sidecar
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 const (
-	label      = "sidecar.opentelemetry.io/injected"
+	label      = collector.SidecarInjectedLabel
 	confEnvVar = "OTEL_CONFIG"
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 func add(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
-	otelColCfg, err := reconcile.ReplaceConfig(otelcol)
+	otelcol, err := tierInstance(logger, otelcol, pod)
+	if err != nil {
+		return pod, err
+	}
+
+	otelColCfg, err := reconcile.ReplaceConfig(cfg, otelcol)
 	if err != nil {
 		return pod, err
 	}
@@ -47,23 +57,115 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCo
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
-	pod.Spec.Containers = append(pod.Spec.Containers, container)
-	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
+
+	switch {
+	case isBatchPod(pod) && featuregate.EnableNativeSidecarContainers.IsEnabled():
+		// a pod running to completion only finishes once all its containers exited, which the collector never does on
+		// its own. Native sidecar containers are started before and terminated after the pod's main containers.
+		pod.Spec.InitContainers = append([]corev1.Container{container}, pod.Spec.InitContainers...)
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[webhookhandler.NativeSidecarsAnnotation] = addToList(pod.Annotations[webhookhandler.NativeSidecarsAnnotation], container.Name)
+	case isBatchPod(pod):
+		logger.Info("the pod runs to completion but the sidecar is injected as a regular container, the pod won't complete while the sidecar is running",
+			"featuregate", featuregate.EnableNativeSidecarContainers.ID())
+		pod.Spec.Containers = append(pod.Spec.Containers, container)
+	default:
+		pod.Spec.Containers = append(pod.Spec.Containers, container)
+	}
+	if pod.Spec.Volumes, err = addVolumes(pod.Spec.Volumes, otelcol.Spec.Volumes...); err != nil {
+		return pod, err
+	}
+
+	if timeout, ok := pod.Annotations[FlushTimeoutAnnotation]; ok {
+		pod = setFlushTimeout(logger, pod, timeout)
+	}
+	if otelcol.Spec.TerminationGracePeriodSeconds != nil {
+		pod = setMinTerminationGracePeriod(pod, *otelcol.Spec.TerminationGracePeriodSeconds)
+	}
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
 	}
-	pod.Labels[label] = fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name)
+	pod.Labels[label] = naming.Instance(otelcol)
 
 	return pod, nil
 }
 
-// remove the sidecar container from the given pod.
+// addVolumes adds the given volumes to the list, failing when it has a volume with the same name already, which the
+// sidecar would otherwise replace for the containers of the workload mounting it.
+func addVolumes(volumes []corev1.Volume, added ...corev1.Volume) ([]corev1.Volume, error) {
+	for _, volume := range added {
+		for _, existing := range volumes {
+			if existing.Name == volume.Name {
+				return volumes, fmt.Errorf("the pod already has a volume named %s, which conflicts with a volume of the sidecar", volume.Name)
+			}
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// tierInstance returns the instance as it runs in the given pod, sized with the sidecar tier of the pod's annotation.
+func tierInstance(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, pod corev1.Pod) (v1alpha1.OpenTelemetryCollector, error) {
+	name, ok := pod.Annotations[TierAnnotation]
+	if !ok {
+		return otelcol, nil
+	}
+	for _, tier := range otelcol.Spec.SidecarTiers {
+		if tier.Name == name {
+			return collector.SidecarTierInstance(otelcol, tier)
+		}
+	}
+	logger.Info("ignoring unknown sidecar tier, the sidecar of the instance is injected", "annotation", TierAnnotation, "value", name,
+		"otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
+	return otelcol, nil
+}
+
+// isBatchPod checks whether the given pod is expected to run to completion, like the ones created by Jobs and CronJobs.
+func isBatchPod(pod corev1.Pod) bool {
+	return pod.Spec.RestartPolicy == corev1.RestartPolicyNever || pod.Spec.RestartPolicy == corev1.RestartPolicyOnFailure
+}
+
+// setFlushTimeout makes sure the pod's termination grace period gives the sidecar at least the given time to flush its data.
+func setFlushTimeout(logger logr.Logger, pod corev1.Pod, timeout string) corev1.Pod {
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration < 0 {
+		logger.Info("ignoring invalid sidecar flush timeout", "annotation", FlushTimeoutAnnotation, "value", timeout)
+		return pod
+	}
+
+	return setMinTerminationGracePeriod(pod, int64(math.Ceil(duration.Seconds())))
+}
+
+// setMinTerminationGracePeriod makes sure the pod's termination grace period is at least the given number of seconds.
+func setMinTerminationGracePeriod(pod corev1.Pod, seconds int64) corev1.Pod {
+	current := int64(corev1.DefaultTerminationGracePeriodSeconds)
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		current = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	if seconds > current {
+		pod.Spec.TerminationGracePeriodSeconds = &seconds
+	}
+	return pod
+}
+
+// remove the sidecar container from the given pod, along with the volumes only the sidecar mounts.
 func remove(pod corev1.Pod) (corev1.Pod, error) {
 	if !existsIn(pod) {
 		return pod, nil
 	}
 
+	sidecarVolumes := map[string]bool{}
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if container.Name == naming.Container() {
+			for _, mount := range container.VolumeMounts {
+				sidecarVolumes[mount.Name] = true
+			}
+		}
+	}
+
 	var containers []corev1.Container
 	for _, container := range pod.Spec.Containers {
 		if container.Name != naming.Container() {
@@ -71,6 +173,36 @@ func remove(pod corev1.Pod) (corev1.Pod, error) {
 		}
 	}
 	pod.Spec.Containers = containers
+
+	var initContainers []corev1.Container
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != naming.Container() {
+			initContainers = append(initContainers, container)
+		}
+	}
+	pod.Spec.InitContainers = initContainers
+
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for _, mount := range container.VolumeMounts {
+			delete(sidecarVolumes, mount.Name)
+		}
+	}
+	var volumes []corev1.Volume
+	for _, volume := range pod.Spec.Volumes {
+		if !sidecarVolumes[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	pod.Spec.Volumes = volumes
+
+	if sidecars, ok := pod.Annotations[webhookhandler.NativeSidecarsAnnotation]; ok {
+		sidecars = removeFromList(sidecars, naming.Container())
+		if len(sidecars) == 0 {
+			delete(pod.Annotations, webhookhandler.NativeSidecarsAnnotation)
+		} else {
+			pod.Annotations[webhookhandler.NativeSidecarsAnnotation] = sidecars
+		}
+	}
 	return pod, nil
 }
 
@@ -81,5 +213,45 @@ func existsIn(pod corev1.Pod) bool {
 			return true
 		}
 	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == naming.Container() {
+			return true
+		}
+	}
 	return false
 }
+
+// injectedIn checks whether the sidecar container of the given pod was injected by the operator, as opposed to a
+// container of the same name that is part of the workload.
+func injectedIn(pod corev1.Pod) bool {
+	return len(pod.Labels[label]) > 0 && existsIn(pod)
+}
+
+// isNew checks whether the given pod is being created, the pods only get a UID once they are persisted.
+func isNew(pod corev1.Pod) bool {
+	return len(pod.UID) == 0
+}
+
+// addToList adds the item to the comma-separated list, unless it's already part of it.
+func addToList(list string, item string) string {
+	if len(list) == 0 {
+		return item
+	}
+	for _, existing := range strings.Split(list, ",") {
+		if strings.TrimSpace(existing) == item {
+			return list
+		}
+	}
+	return list + "," + item
+}
+
+// removeFromList removes all occurrences of the item from the comma-separated list.
+func removeFromList(list string, item string) string {
+	var items []string
+	for _, existing := range strings.Split(list, ",") {
+		if existing = strings.TrimSpace(existing); len(existing) > 0 && existing != item {
+			items = append(items, existing)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -15,16 +15,21 @@
 package sidecar
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
@@ -89,6 +94,7 @@ processors:
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}, changed.Spec.Containers[1])
 }
 
@@ -184,6 +190,93 @@ func TestExistsIn(t *testing.T) {
 	}
 }
 
+func TestInjectedIn(t *testing.T) {
+	injected := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{label: "some-app.otelcol-sample"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}, {Name: naming.Container()}}},
+	}
+	assert.True(t, injectedIn(injected))
+
+	injected.Labels = nil
+	assert.False(t, injectedIn(injected), "a container of the workload with the sidecar's name")
+}
+
+func TestAddSidecarVolumeConflict(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app", VolumeMounts: []corev1.VolumeMount{{Name: "otc-certs", MountPath: "/certs"}}}},
+			Volumes:    []corev1.Volume{{Name: "data"}, {Name: "otc-certs"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Volumes: []corev1.Volume{{Name: "otc-certs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	_, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.ErrorContains(t, err, "the pod already has a volume named otc-certs")
+}
+
+func TestReplaceSidecarVolumes(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+				{Name: naming.Container(), VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}, {Name: "otc-certs", MountPath: "/certs"}}},
+			},
+			Volumes: []corev1.Volume{{Name: "data"}, {Name: "otc-certs"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Volumes: []corev1.Volume{{Name: "otc-certs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	removed, err := remove(pod)
+	require.NoError(t, err)
+	changed, err := add(cfg, logger, otelcol, removed, nil)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.Volume{{Name: "data"}}, removed.Spec.Volumes, "the volumes the workload mounts are kept")
+	assert.Equal(t, []corev1.Volume{{Name: "data"}, otelcol.Spec.Volumes[0]}, changed.Spec.Volumes)
+}
+
+func TestAddSidecarWithoutSecretProviders(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:            v1alpha1.ModeSidecar,
+			SecretProviders: []v1alpha1.SecretProvider{{Name: "vault", SecretProviderClass: "vault-credentials"}},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Empty(t, changed.Spec.Volumes)
+	require.Len(t, changed.Spec.Containers, 2)
+	assert.Empty(t, changed.Spec.Containers[1].VolumeMounts)
+}
+
 func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -217,3 +310,198 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 
 }
+
+func TestAddSidecarToBatchPod(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	for _, tt := range []struct {
+		desc               string
+		native             bool
+		expectedContainers int
+		expectedInit       int
+	}{
+		{"native sidecars enabled", true, 1, 2},
+		{"native sidecars disabled", false, 2, 1},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			originalVal := featuregate.EnableNativeSidecarContainers.IsEnabled()
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), tt.native))
+			t.Cleanup(func() {
+				require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), originalVal))
+			})
+
+			// test
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, changed.Spec.Containers, tt.expectedContainers)
+			assert.Len(t, changed.Spec.InitContainers, tt.expectedInit)
+			assert.True(t, existsIn(changed))
+			if tt.native {
+				assert.Equal(t, naming.Container(), changed.Spec.InitContainers[0].Name)
+				assert.Equal(t, naming.Container(), changed.Annotations[webhookhandler.NativeSidecarsAnnotation])
+			} else {
+				assert.NotContains(t, changed.Annotations, webhookhandler.NativeSidecarsAnnotation)
+			}
+
+			removed, err := remove(changed)
+			assert.NoError(t, err)
+			assert.False(t, existsIn(removed))
+			assert.NotContains(t, removed.Annotations, webhookhandler.NativeSidecarsAnnotation)
+			assert.Len(t, removed.Spec.InitContainers, 1)
+		})
+	}
+}
+
+func TestAddSidecarWithFlushTimeout(t *testing.T) {
+	ten := int64(10)
+	sixty := int64(60)
+	for _, tt := range []struct {
+		desc        string
+		timeout     string
+		gracePeriod *int64
+		expected    *int64
+	}{
+		{"raises the default grace period", "45s", nil, func() *int64 { v := int64(45); return &v }()},
+		{"keeps the default grace period", "10s", nil, nil},
+		{"raises the pod grace period", "1m", &ten, &sixty},
+		{"keeps the pod grace period", "5s", &sixty, &sixty},
+		{"ignores invalid timeout", "soon", &ten, &ten},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{FlushTimeoutAnnotation: tt.timeout},
+				},
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: tt.gracePeriod,
+					Containers: []corev1.Container{
+						{Name: "my-app"},
+					},
+				},
+			}
+			cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+			// test
+			changed, err := add(cfg, logger, v1alpha1.OpenTelemetryCollector{}, pod, nil)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, changed.Spec.TerminationGracePeriodSeconds)
+		})
+	}
+}
+
+func TestAddSidecarWithTerminationGracePeriod(t *testing.T) {
+	ten := int64(10)
+	sixty := int64(60)
+	for _, tt := range []struct {
+		desc        string
+		gracePeriod *int64
+		expected    *int64
+	}{
+		{"raises the default grace period", nil, &sixty},
+		{"raises the pod grace period", &ten, &sixty},
+		{"keeps a longer pod grace period", func() *int64 { v := int64(90); return &v }(), func() *int64 { v := int64(90); return &v }()},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					TerminationGracePeriodSeconds: &sixty,
+				},
+			}
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: tt.gracePeriod,
+					Containers: []corev1.Container{
+						{Name: "my-app"},
+					},
+				},
+			}
+			cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+			// test
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, changed.Spec.TerminationGracePeriodSeconds)
+		})
+	}
+}
+
+func TestAddSidecarWithTier(t *testing.T) {
+	small := corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}
+	large := corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Resources: large,
+			Config:    "service:\n  pipelines:\n    traces:\n      processors: [tail_sampling, batch]\n",
+			SidecarTiers: []v1alpha1.SidecarTier{
+				{Name: "small", Resources: &small, Config: "service:\n  pipelines:\n    traces:\n      processors: [batch]\n"},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		resources   corev1.ResourceRequirements
+		sampling    bool
+	}{
+		{"without tier", nil, large, true},
+		{"with tier", map[string]string{TierAnnotation: "small"}, small, false},
+		{"with unknown tier", map[string]string{TierAnnotation: "medium"}, large, true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-app"},
+					},
+				},
+			}
+			cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+			// test
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, changed.Spec.Containers, 2)
+			sidecar := changed.Spec.Containers[1]
+			assert.Equal(t, tt.resources, sidecar.Resources)
+			var otelColCfg string
+			for _, env := range sidecar.Env {
+				if env.Name == confEnvVar {
+					otelColCfg = env.Value
+				}
+			}
+			assert.Equal(t, tt.sampling, strings.Contains(otelColCfg, "tail_sampling"))
+		})
+	}
+}
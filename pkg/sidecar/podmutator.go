@@ -23,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,6 +36,7 @@ var (
 	errMultipleInstancesPossible = errors.New("multiple OpenTelemetry Collector instances available, cannot determine which one to select")
 	errNoInstancesAvailable      = errors.New("no OpenTelemetry Collector instances available")
 	errInstanceNotSidecar        = errors.New("the OpenTelemetry Collector's mode is not set to sidecar")
+	errNamespaceNotAllowed       = errors.New("the OpenTelemetry Collector doesn't select the pod's namespace in its sidecarNamespaceSelector")
 )
 
 type sidecarPodMutator struct {
@@ -70,8 +72,11 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	}
 
 	// from this point and on, a sidecar is wanted
-	// check whether there's a sidecar already -- return the same pod if that's the case.
-	if existsIn(pod) {
+	// check whether there's a sidecar already -- return the same pod if that's the case, unless the sidecar was injected
+	// in the pod's template, like when a workload is created from a running pod: such a sidecar runs the configuration
+	// the instance had back then and is replaced by the current one. The containers of existing pods can't be changed.
+	replace := isNew(pod) && injectedIn(pod)
+	if existsIn(pod) && !replace {
 		logger.V(1).Info("pod already has sidecar in it, skipping injection")
 		return pod, nil
 	}
@@ -79,7 +84,8 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// which instance should it talk to?
 	otelcol, err := p.getCollectorInstance(ctx, ns, annValue)
 	if err != nil {
-		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) {
+		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) ||
+			errors.Is(err, errNamespaceNotAllowed) {
 			// we still allow the pod to be created, but we log a message to the operator's logs
 			logger.Error(err, "failed to select an OpenTelemetry Collector instance for this pod's sidecar")
 			return pod, nil
@@ -93,11 +99,23 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	references := p.podReferences(ctx, pod.OwnerReferences, ns)
 	attributes := getResourceAttributesEnv(ns, references)
 
+	if replace {
+		logger.V(1).Info("replacing the sidecar injected in the pod's template", "injected", pod.Labels[label])
+		if pod, err = remove(pod); err != nil {
+			return pod, err
+		}
+	}
+
 	// once it's been determined that a sidecar is desired, none exists yet, and we know which instance it should talk to,
 	// we should add the sidecar.
 	logger.V(1).Info("injecting sidecar into pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
 
-	return add(p.config, p.logger, otelcol, pod, attributes)
+	changed, err := add(p.config, p.logger, otelcol, pod, attributes)
+	if err != nil {
+		logger.Error(err, "failed to inject the sidecar into the pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
+		return pod, err
+	}
+	return changed, nil
 }
 
 func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.Namespace, ann string) (v1alpha1.OpenTelemetryCollector, error) {
@@ -122,9 +140,26 @@ func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.
 		return v1alpha1.OpenTelemetryCollector{}, errInstanceNotSidecar
 	}
 
+	// instances of other namespaces have to allow the pod's namespace explicitly
+	if otelcol.Namespace != ns.Name && !namespaceAllowed(otelcol, ns) {
+		return v1alpha1.OpenTelemetryCollector{}, errNamespaceNotAllowed
+	}
+
 	return otelcol, nil
 }
 
+// namespaceAllowed checks whether the sidecar namespace selector of the given instance selects the given namespace.
+func namespaceAllowed(otelcol v1alpha1.OpenTelemetryCollector, ns corev1.Namespace) bool {
+	if otelcol.Spec.SidecarNamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(otelcol.Spec.SidecarNamespaceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
 func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns corev1.Namespace) (v1alpha1.OpenTelemetryCollector, error) {
 	var (
 		otelcols = v1alpha1.OpenTelemetryCollectorList{}
@@ -157,7 +192,7 @@ func (p *sidecarPodMutator) podReferences(ctx context.Context, ownerReferences [
 	replicaSet := p.getReplicaSetReference(ctx, ownerReferences, ns)
 	if replicaSet != nil {
 		references.replicaset = replicaSet
-		deployment := p.getDeploymentReference(ctx, replicaSet)
+		deployment := p.getDeploymentReference(replicaSet)
 		if deployment != nil {
 			references.deployment = deployment
 		}
@@ -177,13 +212,18 @@ func (p *sidecarPodMutator) getReplicaSetReference(ctx context.Context, ownerRef
 	return nil
 }
 
-func (p *sidecarPodMutator) getDeploymentReference(ctx context.Context, replicaSet *appsv1.ReplicaSet) *appsv1.Deployment {
-	deploymentName := findOwnerReferenceKind(replicaSet.OwnerReferences, "Deployment")
-	if deploymentName != "" {
-		deployment := &appsv1.Deployment{}
-		err := p.client.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: replicaSet.Namespace}, deployment)
-		if err == nil {
-			return deployment
+// getDeploymentReference returns the deployment owning the given replica set from its owner reference, as deployments
+// not managed by the operator are missing from its cache.
+func (p *sidecarPodMutator) getDeploymentReference(replicaSet *appsv1.ReplicaSet) *appsv1.Deployment {
+	for _, reference := range replicaSet.OwnerReferences {
+		if reference.Kind == "Deployment" {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      reference.Name,
+					Namespace: replicaSet.Namespace,
+					UID:       reference.UID,
+				},
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+func TestNamespaceAllowed(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "payments",
+			Labels: map[string]string{"kubernetes.io/metadata.name": "payments", "team": "payments"},
+		},
+	}
+	for _, tt := range []struct {
+		desc     string
+		selector *metav1.LabelSelector
+		expected bool
+	}{
+		{"without selector", nil, false},
+		{"empty selector", &metav1.LabelSelector{}, true},
+		{"selected by name", &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "payments"}}, true},
+		{
+			"selected by expression",
+			&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments", "checkout"}},
+			}},
+			true,
+		},
+		{"not selected", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}}, false},
+		{
+			"invalid selector",
+			&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Like"}}},
+			false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blessed-sidecar",
+					Namespace: "observability",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:                     v1alpha1.ModeSidecar,
+					SidecarNamespaceSelector: tt.selector,
+				},
+			}
+			assert.Equal(t, tt.expected, namespaceAllowed(otelcol, ns))
+		})
+	}
+}
+
+func TestMutateReplacesInjectedSidecar(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "sidecar-for-my-app", Namespace: "my-app"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeSidecar,
+			Config: "receivers:\n  otlp:\n",
+		},
+	}
+	mutator := NewMutator(logr.Discard(), config.New(config.WithCollectorImage("some-default-image")),
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol).Build())
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-app"}}
+	stale := corev1.Container{
+		Name: naming.Container(),
+		Env:  []corev1.EnvVar{{Name: confEnvVar, Value: "receivers:\n  jaeger:\n"}},
+	}
+
+	for _, tt := range []struct {
+		desc     string
+		pod      corev1.Pod
+		replaced bool
+	}{
+		{
+			"sidecar injected in the template",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{label: "my-app.sidecar-for-my-app"},
+					Annotations: map[string]string{Annotation: "true"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}, stale}},
+			},
+			true,
+		},
+		{
+			"sidecar of a running pod",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:         "6b5a4f0e",
+					Labels:      map[string]string{label: "my-app.sidecar-for-my-app"},
+					Annotations: map[string]string{Annotation: "true"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}, stale}},
+			},
+			false,
+		},
+		{
+			"container of the workload",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{Annotation: "true"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}, stale}},
+			},
+			false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			changed, err := mutator.Mutate(context.Background(), ns, tt.pod)
+			require.NoError(t, err)
+
+			require.Len(t, changed.Spec.Containers, 2)
+			if !tt.replaced {
+				assert.Equal(t, stale, changed.Spec.Containers[1])
+				return
+			}
+			assert.Equal(t, "some-default-image", changed.Spec.Containers[1].Image)
+			assert.Contains(t, changed.Spec.Containers[1].Env, corev1.EnvVar{Name: confEnvVar, Value: "receivers:\n  otlp:\n"})
+
+			// mutating the pod again doesn't duplicate the sidecar
+			again, err := mutator.Mutate(context.Background(), ns, changed)
+			require.NoError(t, err)
+			assert.Equal(t, changed, again)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// promConfigCacheSize is the number of parsed Prometheus receiver configurations kept.
+	promConfigCacheSize = 256
+	// promConfigCacheTTL is how long a parsed Prometheus receiver configuration is kept.
+	promConfigCacheTTL = 10 * time.Minute
+)
+
+// promConfigCache holds the Prometheus receiver configurations parsed by ConfigToPromConfig, by the sha256 of the
+// collector configuration, so that the reconciliations of unchanged instances don't parse their scrape configurations
+// again.
+var promConfigCache = cache.NewLRUExpireCache(promConfigCacheSize)
+
+func cachedPromConfig(cfg string) (map[string]interface{}, bool) {
+	cached, ok := promConfigCache.Get(sha256.Sum256([]byte(cfg)))
+	if !ok {
+		return nil, false
+	}
+	// the callers modify the configuration they get
+	return copyValue(cached).(map[string]interface{}), true
+}
+
+func cachePromConfig(cfg string, prometheus map[string]interface{}) {
+	promConfigCache.Add(sha256.Sum256([]byte(cfg)), copyValue(prometheus), promConfigCacheTTL)
+}
+
+// copyValue deep copies the maps and lists of a value parsed from YAML.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
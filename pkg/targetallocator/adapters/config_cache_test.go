@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToPromConfigCached(t *testing.T) {
+	// prepare
+	cfg := `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: cached
+          static_configs:
+            - targets: ["0.0.0.0:8888"]
+`
+	first, err := ConfigToPromConfig(cfg)
+	require.NoError(t, err)
+	_, ok := cachedPromConfig(cfg)
+	require.True(t, ok)
+
+	// test
+	first["config"].(map[string]interface{})["scrape_configs"].([]interface{})[0].(map[string]interface{})["job_name"] = "changed"
+	second, err := ConfigToPromConfig(cfg)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "cached", second["config"].(map[string]interface{})["scrape_configs"].([]interface{})[0].(map[string]interface{})["job_name"])
+}
+
+func TestConfigToPromConfigErrorsNotCached(t *testing.T) {
+	// prepare
+	cfg := "receivers:\n  otlp:\n"
+
+	// test
+	_, err := ConfigToPromConfig(cfg)
+
+	// verify
+	assert.Error(t, err)
+	_, ok := cachedPromConfig(cfg)
+	assert.False(t, ok)
+}
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func errorNoComponent(component string) error {
@@ -49,7 +50,11 @@ func errorNotAStringAtIndex(component string, index int) error {
 }
 
 // ConfigToPromConfig converts the incoming configuration object into the Prometheus receiver config.
-func ConfigToPromConfig(cfg string) (map[interface{}]interface{}, error) {
+func ConfigToPromConfig(cfg string) (map[string]interface{}, error) {
+	if prometheus, ok := cachedPromConfig(cfg); ok {
+		return prometheus, nil
+	}
+
 	config, err := adapters.ConfigFromString(cfg)
 	if err != nil {
 		return nil, err
@@ -60,7 +65,7 @@ func ConfigToPromConfig(cfg string) (map[interface{}]interface{}, error) {
 		return nil, errorNoComponent("receivers")
 	}
 
-	receivers, ok := receiversProperty.(map[interface{}]interface{})
+	receivers, ok := receiversProperty.(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("receivers")
 	}
@@ -70,17 +75,18 @@ func ConfigToPromConfig(cfg string) (map[interface{}]interface{}, error) {
 		return nil, errorNoComponent("prometheus")
 	}
 
-	prometheus, ok := prometheusProperty.(map[interface{}]interface{})
+	prometheus, ok := prometheusProperty.(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("prometheus")
 	}
 
+	cachePromConfig(cfg, prometheus)
 	return prometheus, nil
 }
 
 // UnescapeDollarSignsInPromConfig replaces "$$" with "$" in the "replacement" fields of
 // both "relabel_configs" and "metric_relabel_configs" in a Prometheus configuration file.
-func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, error) {
+func UnescapeDollarSignsInPromConfig(cfg string) (map[string]interface{}, error) {
 	prometheus, err := ConfigToPromConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -91,7 +97,7 @@ func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, e
 		return nil, errorNoComponent("prometheusConfig")
 	}
 
-	prometheusConfig, ok := prometheusConfigProperty.(map[interface{}]interface{})
+	prometheusConfig, ok := prometheusConfigProperty.(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("prometheusConfig")
 	}
@@ -107,7 +113,7 @@ func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, e
 	}
 
 	for i, config := range scrapeConfigs {
-		scrapeConfig, ok := config.(map[interface{}]interface{})
+		scrapeConfig, ok := config.(map[string]interface{})
 		if !ok {
 			return nil, errorNotAMapAtIndex("scrape_config", i)
 		}
@@ -123,7 +129,7 @@ func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, e
 		}
 
 		for i, rc := range relabelConfigs {
-			relabelConfig, rcErr := rc.(map[interface{}]interface{})
+			relabelConfig, rcErr := rc.(map[string]interface{})
 			if !rcErr {
 				return nil, errorNotAMapAtIndex("relabel_config", i)
 			}
@@ -152,7 +158,7 @@ func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, e
 		}
 
 		for i, rc := range metricRelabelConfigs {
-			relabelConfig, ok := rc.(map[interface{}]interface{})
+			relabelConfig, ok := rc.(map[string]interface{})
 			if !ok {
 				return nil, errorNotAMapAtIndex("metric_relabel_config", i)
 			}
@@ -178,13 +184,21 @@ func UnescapeDollarSignsInPromConfig(cfg string) (map[interface{}]interface{}, e
 // This function removes any existing service discovery configurations (e.g., `sd_configs`, `dns_sd_configs`, `file_sd_configs`, etc.)
 // from the `scrape_configs` section and adds a single `http_sd_configs` configuration.
 // The `http_sd_configs` points to the TA (Target Allocator) endpoint that provides the list of targets for the given job.
-func AddHTTPSDConfigToPromConfig(prometheus map[interface{}]interface{}, taServiceName string) (map[interface{}]interface{}, error) {
+// If the `EnableTargetAllocatorRelabeledTargets` feature flag is enabled, the targets are requested with their labels
+// already relabeled by the TA and the `relabel_configs` are removed from the scrape configs.
+func AddHTTPSDConfigToPromConfig(prometheus map[string]interface{}, taServiceName string) (map[string]interface{}, error) {
+	return AddShardedHTTPSDConfigToPromConfig(prometheus, func(string) string { return taServiceName })
+}
+
+// AddShardedHTTPSDConfigToPromConfig is like AddHTTPSDConfigToPromConfig, but the `http_sd_configs` of each job points to
+// the TA service returned by taServiceName for the job's name. This is used when the jobs are sharded across several TAs.
+func AddShardedHTTPSDConfigToPromConfig(prometheus map[string]interface{}, taServiceName func(jobName string) string) (map[string]interface{}, error) {
 	prometheusConfigProperty, ok := prometheus["config"]
 	if !ok {
 		return nil, errorNoComponent("prometheusConfig")
 	}
 
-	prometheusConfig, ok := prometheusConfigProperty.(map[interface{}]interface{})
+	prometheusConfig, ok := prometheusConfigProperty.(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("prometheusConfig")
 	}
@@ -202,18 +216,14 @@ func AddHTTPSDConfigToPromConfig(prometheus map[interface{}]interface{}, taServi
 	sdRegex := regexp.MustCompile(`^.*(sd|static)_configs$`)
 
 	for i, config := range scrapeConfigs {
-		scrapeConfig, ok := config.(map[interface{}]interface{})
+		scrapeConfig, ok := config.(map[string]interface{})
 		if !ok {
 			return nil, errorNotAMapAtIndex("scrape_config", i)
 		}
 
 		// Check for other types of service discovery configs (e.g. dns_sd_configs, file_sd_configs, etc.)
 		for key := range scrapeConfig {
-			keyStr, keyErr := key.(string)
-			if !keyErr {
-				continue
-			}
-			if sdRegex.MatchString(keyStr) {
+			if sdRegex.MatchString(key) {
 				delete(scrapeConfig, key)
 			}
 		}
@@ -229,9 +239,15 @@ func AddHTTPSDConfigToPromConfig(prometheus map[interface{}]interface{}, taServi
 		}
 
 		escapedJob := url.QueryEscape(jobName)
+		sdURL := fmt.Sprintf("http://%s:80/jobs/%s/targets?collector_id=$POD_NAME", taServiceName(jobName), escapedJob)
+		// The target allocator applies the relabel configs itself, so the collector doesn't need to run them again.
+		if featuregate.EnableTargetAllocatorRelabeledTargets.IsEnabled() {
+			sdURL += "&include=labels"
+			delete(scrapeConfig, "relabel_configs")
+		}
 		scrapeConfig["http_sd_configs"] = []interface{}{
 			map[string]interface{}{
-				"url": fmt.Sprintf("http://%s:80/jobs/%s/targets?collector_id=$POD_NAME", taServiceName, escapedJob),
+				"url": sdURL,
 			},
 		}
 	}
@@ -242,23 +258,23 @@ func AddHTTPSDConfigToPromConfig(prometheus map[interface{}]interface{}, taServi
 // AddTAConfigToPromConfig adds or updates the target_allocator configuration in the Prometheus configuration.
 // If the `EnableTargetAllocatorRewrite` feature flag for the target allocator is enabled, this function
 // removes the existing scrape_configs from the collector's Prometheus configuration as it's not required.
-func AddTAConfigToPromConfig(prometheus map[interface{}]interface{}, taServiceName string) (map[interface{}]interface{}, error) {
+func AddTAConfigToPromConfig(prometheus map[string]interface{}, taServiceName string) (map[string]interface{}, error) {
 	prometheusConfigProperty, ok := prometheus["config"]
 	if !ok {
 		return nil, errorNoComponent("prometheusConfig")
 	}
 
-	prometheusCfg, ok := prometheusConfigProperty.(map[interface{}]interface{})
+	prometheusCfg, ok := prometheusConfigProperty.(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("prometheusConfig")
 	}
 
 	// Create the TargetAllocConfig dynamically if it doesn't exist
 	if prometheus["target_allocator"] == nil {
-		prometheus["target_allocator"] = make(map[interface{}]interface{})
+		prometheus["target_allocator"] = make(map[string]interface{})
 	}
 
-	targetAllocatorCfg, ok := prometheus["target_allocator"].(map[interface{}]interface{})
+	targetAllocatorCfg, ok := prometheus["target_allocator"].(map[string]interface{})
 	if !ok {
 		return nil, errorNotAMap("target_allocator")
 	}
@@ -273,8 +289,63 @@ func AddTAConfigToPromConfig(prometheus map[interface{}]interface{}, taServiceNa
 	return prometheus, nil
 }
 
+// AttachNodeMetadataToPromConfig sets `attach_metadata.node` in the `kubernetes_sd_configs` discovering pods, endpoints
+// or endpointslices, so that the targets carry the labels of their node, including its zone.
+func AttachNodeMetadataToPromConfig(prometheus map[string]interface{}) (map[string]interface{}, error) {
+	prometheusConfigProperty, ok := prometheus["config"]
+	if !ok {
+		return nil, errorNoComponent("prometheusConfig")
+	}
+
+	prometheusConfig, ok := prometheusConfigProperty.(map[string]interface{})
+	if !ok {
+		return nil, errorNotAMap("prometheusConfig")
+	}
+
+	scrapeConfigsProperty, ok := prometheusConfig["scrape_configs"]
+	if !ok {
+		return nil, errorNoComponent("scrape_configs")
+	}
+
+	scrapeConfigs, ok := scrapeConfigsProperty.([]interface{})
+	if !ok {
+		return nil, errorNotAList("scrape_configs")
+	}
+
+	for i, config := range scrapeConfigs {
+		scrapeConfig, ok := config.(map[string]interface{})
+		if !ok {
+			return nil, errorNotAMapAtIndex("scrape_config", i)
+		}
+
+		sdConfigsProperty, ok := scrapeConfig["kubernetes_sd_configs"]
+		if !ok {
+			continue
+		}
+
+		sdConfigs, ok := sdConfigsProperty.([]interface{})
+		if !ok {
+			return nil, errorNotAListAtIndex("kubernetes_sd_configs", i)
+		}
+
+		for j, sd := range sdConfigs {
+			sdConfig, ok := sd.(map[string]interface{})
+			if !ok {
+				return nil, errorNotAMapAtIndex("kubernetes_sd_config", j)
+			}
+
+			switch sdConfig["role"] {
+			case "pod", "endpoints", "endpointslice":
+				sdConfig["attach_metadata"] = map[string]interface{}{"node": true}
+			}
+		}
+	}
+
+	return prometheus, nil
+}
+
 // ValidatePromConfig checks if the prometheus receiver config is valid given other collector-level settings.
-func ValidatePromConfig(config map[interface{}]interface{}, targetAllocatorEnabled bool, targetAllocatorRewriteEnabled bool) error {
+func ValidatePromConfig(config map[string]interface{}, targetAllocatorEnabled bool, targetAllocatorRewriteEnabled bool) error {
 	_, promConfigExists := config["config"]
 
 	if targetAllocatorEnabled {
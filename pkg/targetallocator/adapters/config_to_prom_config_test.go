@@ -22,7 +22,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -42,9 +45,9 @@ func TestExtractPromConfigFromConfig(t *testing.T) {
       thrift_http:
         endpoint: 0.0.0.0:15268
 `
-	expectedData := map[interface{}]interface{}{
-		"config": map[interface{}]interface{}{
-			"scrape_config": map[interface{}]interface{}{
+	expectedData := map[string]interface{}{
+		"config": map[string]interface{}{
+			"scrape_config": map[string]interface{}{
 				"job_name":        "otel-collector",
 				"scrape_interval": "10s",
 			},
@@ -77,14 +80,14 @@ func TestExtractPromConfigWithTAConfigFromConfig(t *testing.T) {
       thrift_http:
         endpoint: 0.0.0.0:15268
 `
-	expectedData := map[interface{}]interface{}{
-		"config": map[interface{}]interface{}{
-			"scrape_config": map[interface{}]interface{}{
+	expectedData := map[string]interface{}{
+		"config": map[string]interface{}{
+			"scrape_config": map[string]interface{}{
 				"job_name":        "otel-collector",
 				"scrape_interval": "10s",
 			},
 		},
-		"target_allocator": map[interface{}]interface{}{
+		"target_allocator": map[string]interface{}{
 			"endpoint": "test:80",
 		},
 	}
@@ -172,13 +175,13 @@ receivers:
 
 func TestAddHTTPSDConfigToPromConfig(t *testing.T) {
 	t.Run("ValidConfiguration, add http_sd_config", func(t *testing.T) {
-		cfg := map[interface{}]interface{}{
-			"config": map[interface{}]interface{}{
+		cfg := map[string]interface{}{
+			"config": map[string]interface{}{
 				"scrape_configs": []interface{}{
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"job_name": "test_job",
 						"static_configs": []interface{}{
-							map[interface{}]interface{}{
+							map[string]interface{}{
 								"targets": []interface{}{
 									"localhost:9090",
 								},
@@ -189,10 +192,10 @@ func TestAddHTTPSDConfigToPromConfig(t *testing.T) {
 			},
 		}
 		taServiceName := "test-service"
-		expectedCfg := map[interface{}]interface{}{
-			"config": map[interface{}]interface{}{
+		expectedCfg := map[string]interface{}{
+			"config": map[string]interface{}{
 				"scrape_configs": []interface{}{
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"job_name": "test_job",
 						"http_sd_configs": []interface{}{
 							map[string]interface{}{
@@ -209,12 +212,61 @@ func TestAddHTTPSDConfigToPromConfig(t *testing.T) {
 		assert.Equal(t, expectedCfg, actualCfg)
 	})
 
+	t.Run("relabeled targets enabled, add http_sd_config including labels", func(t *testing.T) {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRelabeledTargets.ID(), true))
+		t.Cleanup(func() {
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableTargetAllocatorRelabeledTargets.ID(), false))
+		})
+		cfg := map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{
+						"job_name": "test_job",
+						"static_configs": []interface{}{
+							map[string]interface{}{
+								"targets": []interface{}{
+									"localhost:9090",
+								},
+							},
+						},
+						"relabel_configs": []interface{}{
+							map[string]interface{}{
+								"action":        "keep",
+								"source_labels": []interface{}{"__meta_kubernetes_pod_label_app"},
+								"regex":         "my-app",
+							},
+						},
+					},
+				},
+			},
+		}
+		taServiceName := "test-service"
+		expectedCfg := map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{
+						"job_name": "test_job",
+						"http_sd_configs": []interface{}{
+							map[string]interface{}{
+								"url": fmt.Sprintf("http://%s:80/jobs/%s/targets?collector_id=$POD_NAME&include=labels", taServiceName, url.QueryEscape("test_job")),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		actualCfg, err := ta.AddHTTPSDConfigToPromConfig(cfg, taServiceName)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCfg, actualCfg)
+	})
+
 	t.Run("invalid config property, returns error", func(t *testing.T) {
-		cfg := map[interface{}]interface{}{
-			"config": map[interface{}]interface{}{
+		cfg := map[string]interface{}{
+			"config": map[string]interface{}{
 				"job_name": "test_job",
 				"static_configs": []interface{}{
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"targets": []interface{}{
 							"localhost:9090",
 						},
@@ -229,17 +281,64 @@ func TestAddHTTPSDConfigToPromConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.EqualError(t, err, "no scrape_configs available as part of the configuration")
 	})
+
+	t.Run("sharded jobs, add http_sd_config pointing to each job's service", func(t *testing.T) {
+		cfg := map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{
+						"job_name": "job_a",
+					},
+					map[string]interface{}{
+						"job_name": "job_b",
+					},
+				},
+			},
+		}
+		taServiceNames := map[string]string{
+			"job_a": "test-service-0",
+			"job_b": "test-service-1",
+		}
+		expectedCfg := map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{
+						"job_name": "job_a",
+						"http_sd_configs": []interface{}{
+							map[string]interface{}{
+								"url": "http://test-service-0:80/jobs/job_a/targets?collector_id=$POD_NAME",
+							},
+						},
+					},
+					map[string]interface{}{
+						"job_name": "job_b",
+						"http_sd_configs": []interface{}{
+							map[string]interface{}{
+								"url": "http://test-service-1:80/jobs/job_b/targets?collector_id=$POD_NAME",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		actualCfg, err := ta.AddShardedHTTPSDConfigToPromConfig(cfg, func(jobName string) string {
+			return taServiceNames[jobName]
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCfg, actualCfg)
+	})
 }
 
 func TestAddTAConfigToPromConfig(t *testing.T) {
 	t.Run("should return expected prom config map with TA config", func(t *testing.T) {
-		cfg := map[interface{}]interface{}{
-			"config": map[interface{}]interface{}{
+		cfg := map[string]interface{}{
+			"config": map[string]interface{}{
 				"scrape_configs": []interface{}{
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"job_name": "test_job",
 						"static_configs": []interface{}{
-							map[interface{}]interface{}{
+							map[string]interface{}{
 								"targets": []interface{}{
 									"localhost:9090",
 								},
@@ -252,9 +351,9 @@ func TestAddTAConfigToPromConfig(t *testing.T) {
 
 		taServiceName := "test-targetallocator"
 
-		expectedResult := map[interface{}]interface{}{
-			"config": map[interface{}]interface{}{},
-			"target_allocator": map[interface{}]interface{}{
+		expectedResult := map[string]interface{}{
+			"config": map[string]interface{}{},
+			"target_allocator": map[string]interface{}{
 				"endpoint":     "http://test-targetallocator:80",
 				"interval":     "30s",
 				"collector_id": "${POD_NAME}",
@@ -270,17 +369,17 @@ func TestAddTAConfigToPromConfig(t *testing.T) {
 	t.Run("missing or invalid prometheusConfig property, returns error", func(t *testing.T) {
 		testCases := []struct {
 			name    string
-			cfg     map[interface{}]interface{}
+			cfg     map[string]interface{}
 			errText string
 		}{
 			{
 				name:    "missing config property",
-				cfg:     map[interface{}]interface{}{},
+				cfg:     map[string]interface{}{},
 				errText: "no prometheusConfig available as part of the configuration",
 			},
 			{
 				name: "invalid config property",
-				cfg: map[interface{}]interface{}{
+				cfg: map[string]interface{}{
 					"config": "invalid",
 				},
 				errText: "prometheusConfig property in the configuration doesn't contain valid prometheusConfig",
@@ -300,25 +399,74 @@ func TestAddTAConfigToPromConfig(t *testing.T) {
 	})
 }
 
+func TestAttachNodeMetadataToPromConfig(t *testing.T) {
+	cfg := map[string]interface{}{
+		"config": map[string]interface{}{
+			"scrape_configs": []interface{}{
+				map[string]interface{}{
+					"job_name": "pods",
+					"kubernetes_sd_configs": []interface{}{
+						map[string]interface{}{"role": "pod"},
+						map[string]interface{}{"role": "service"},
+					},
+				},
+				map[string]interface{}{
+					"job_name": "static",
+					"static_configs": []interface{}{
+						map[string]interface{}{
+							"targets": []interface{}{"localhost:9090"},
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedCfg := map[string]interface{}{
+		"config": map[string]interface{}{
+			"scrape_configs": []interface{}{
+				map[string]interface{}{
+					"job_name": "pods",
+					"kubernetes_sd_configs": []interface{}{
+						map[string]interface{}{"role": "pod", "attach_metadata": map[string]interface{}{"node": true}},
+						map[string]interface{}{"role": "service"},
+					},
+				},
+				map[string]interface{}{
+					"job_name": "static",
+					"static_configs": []interface{}{
+						map[string]interface{}{
+							"targets": []interface{}{"localhost:9090"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	actualCfg, err := ta.AttachNodeMetadataToPromConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCfg, actualCfg)
+}
+
 func TestValidatePromConfig(t *testing.T) {
 	testCases := []struct {
 		description                   string
-		config                        map[interface{}]interface{}
+		config                        map[string]interface{}
 		targetAllocatorEnabled        bool
 		targetAllocatorRewriteEnabled bool
 		expectedError                 error
 	}{
 		{
 			description:                   "target_allocator and rewrite enabled",
-			config:                        map[interface{}]interface{}{},
+			config:                        map[string]interface{}{},
 			targetAllocatorEnabled:        true,
 			targetAllocatorRewriteEnabled: true,
 			expectedError:                 nil,
 		},
 		{
 			description: "target_allocator enabled, target_allocator section present",
-			config: map[interface{}]interface{}{
-				"target_allocator": map[interface{}]interface{}{},
+			config: map[string]interface{}{
+				"target_allocator": map[string]interface{}{},
 			},
 			targetAllocatorEnabled:        true,
 			targetAllocatorRewriteEnabled: false,
@@ -326,8 +474,8 @@ func TestValidatePromConfig(t *testing.T) {
 		},
 		{
 			description: "target_allocator enabled, config section present",
-			config: map[interface{}]interface{}{
-				"config": map[interface{}]interface{}{},
+			config: map[string]interface{}{
+				"config": map[string]interface{}{},
 			},
 			targetAllocatorEnabled:        true,
 			targetAllocatorRewriteEnabled: false,
@@ -335,15 +483,15 @@ func TestValidatePromConfig(t *testing.T) {
 		},
 		{
 			description:                   "target_allocator enabled, neither section present",
-			config:                        map[interface{}]interface{}{},
+			config:                        map[string]interface{}{},
 			targetAllocatorEnabled:        true,
 			targetAllocatorRewriteEnabled: false,
 			expectedError:                 errors.New("either target allocator or prometheus config needs to be present"),
 		},
 		{
 			description: "target_allocator disabled, config section present",
-			config: map[interface{}]interface{}{
-				"config": map[interface{}]interface{}{},
+			config: map[string]interface{}{
+				"config": map[string]interface{}{},
 			},
 			targetAllocatorEnabled:        false,
 			targetAllocatorRewriteEnabled: false,
@@ -351,7 +499,7 @@ func TestValidatePromConfig(t *testing.T) {
 		},
 		{
 			description:                   "target_allocator disabled, config section not present",
-			config:                        map[interface{}]interface{}{},
+			config:                        map[string]interface{}{},
 			targetAllocatorEnabled:        false,
 			targetAllocatorRewriteEnabled: false,
 			expectedError:                 fmt.Errorf("no %s available as part of the configuration", "prometheusConfig"),
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"sort"
+)
+
+// knownPromConfigKeys are the top-level sections of the Prometheus configuration.
+var knownPromConfigKeys = map[string]bool{
+	"global":              true,
+	"alerting":            true,
+	"rule_files":          true,
+	"scrape_config_files": true,
+	"scrape_configs":      true,
+	"storage":             true,
+	"tracing":             true,
+	"remote_write":        true,
+	"remote_read":         true,
+}
+
+// knownTargetAllocatorKeys are the settings of the target_allocator section of the Prometheus receiver, including the
+// HTTP client settings of the recent collector releases.
+var knownTargetAllocatorKeys = map[string]bool{
+	"endpoint":       true,
+	"interval":       true,
+	"collector_id":   true,
+	"http_sd_config": true,
+	"tls":            true,
+	"timeout":        true,
+	"headers":        true,
+	"auth":           true,
+}
+
+// PromConfigToUnknownKeys returns the paths of the keys of the Prometheus configuration and of the target_allocator
+// section of the Prometheus receiver config that aren't known, e.g. "config.scrape_config" for a misspelled
+// "config.scrape_configs", which would leave the receiver, and the target allocator, without targets. The paths are
+// sorted.
+func PromConfigToUnknownKeys(prometheus map[string]interface{}) []string {
+	var unknown []string
+	if config, ok := prometheus["config"].(map[string]interface{}); ok {
+		for key := range config {
+			if !knownPromConfigKeys[key] {
+				unknown = append(unknown, "config."+key)
+			}
+		}
+	}
+	if targetAllocator, ok := prometheus["target_allocator"].(map[string]interface{}); ok {
+		for key := range targetAllocator {
+			if !knownTargetAllocatorKeys[key] {
+				unknown = append(unknown, "target_allocator."+key)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
+)
+
+func TestPromConfigToUnknownKeys(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		prometheus map[string]interface{}
+		expected   []string
+	}{
+		{
+			desc: "known keys",
+			prometheus: map[string]interface{}{
+				"config": map[string]interface{}{
+					"global":         map[string]interface{}{"scrape_interval": "30s"},
+					"scrape_configs": []interface{}{},
+				},
+				"target_allocator": map[string]interface{}{
+					"endpoint":     "http://test-targetallocator:80",
+					"interval":     "30s",
+					"collector_id": "${POD_NAME}",
+				},
+			},
+		},
+		{
+			desc: "misspelled scrape configs",
+			prometheus: map[string]interface{}{
+				"config": map[string]interface{}{
+					"scrape_config": map[string]interface{}{"job_name": "otel-collector"},
+				},
+			},
+			expected: []string{"config.scrape_config"},
+		},
+		{
+			desc: "unknown target allocator settings",
+			prometheus: map[string]interface{}{
+				"target_allocator": map[string]interface{}{
+					"endpoint":    "http://test-targetallocator:80",
+					"collectorId": "${POD_NAME}",
+					"intervals":   "30s",
+				},
+			},
+			expected: []string{"target_allocator.collectorId", "target_allocator.intervals"},
+		},
+		{
+			desc:       "no config",
+			prometheus: map[string]interface{}{},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			unknown := ta.PromConfigToUnknownKeys(tt.prometheus)
+
+			// verify
+			assert.Equal(t, tt.expected, unknown)
+		})
+	}
+}
@@ -21,19 +21,26 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/proxy"
 )
 
+// allocationStateDir is where the allocation state volume is mounted in the TargetAllocator container.
+const allocationStateDir = "/allocation-state"
+
 // Container builds a container for the given TargetAllocator.
 func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
-	image := otelcol.Spec.TargetAllocator.Image
-	if len(image) == 0 {
-		image = cfg.TargetAllocatorImage()
-	}
+	image := Image(cfg, otelcol)
 
 	volumeMounts := []corev1.VolumeMount{{
 		Name:      naming.TAConfigMapVolume(),
 		MountPath: "/conf",
 	}}
+	if otelcol.Spec.TargetAllocator.AllocationState != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.TAAllocationStateVolume(),
+			MountPath: allocationStateDir,
+		})
+	}
 
 	envVars := []corev1.EnvVar{}
 
@@ -46,6 +53,10 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		},
 	})
 
+	// The TargetAllocator reaches the Kubernetes API without the proxy. The variables set before take precedence over
+	// the proxy, the same way as in the collector container.
+	envVars = proxy.MergeEnvVars(envVars, proxy.EnvVars(cfg, otelcol.Spec.Proxy, proxy.KubernetesServiceHost))
+
 	var args []string
 	if otelcol.Spec.TargetAllocator.PrometheusCR.Enabled {
 		args = append(args, "--enable-prometheus-cr-watcher")
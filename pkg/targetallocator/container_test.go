@@ -116,3 +116,22 @@ func TestContainerResourceRequirements(t *testing.T) {
 	// verify
 	assert.Equal(t, resourceTest, resourcesValues)
 }
+
+func TestContainerProxy(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Proxy: &v1alpha1.ProxySpec{
+				HTTPSProxy: "http://proxy:3128",
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy:3128"})
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "NO_PROXY", Value: "$(KUBERNETES_SERVICE_HOST)"})
+}
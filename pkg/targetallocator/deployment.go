@@ -15,6 +15,9 @@
 package targetallocator
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +30,67 @@ import (
 
 // Deployment builds the deployment for the given instance.
 func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) appsv1.Deployment {
-	name := naming.TargetAllocator(otelcol)
+	return deployment(cfg, otelcol, naming.TargetAllocator(otelcol), Container(cfg, logger, otelcol))
+}
+
+// Deployments builds the deployments for the given instance, one for each job shard, or one for each availability zone
+// when the instance is topology-aware.
+func Deployments(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []appsv1.Deployment {
+	if zones := Zones(otelcol); len(zones) > 0 {
+		deployments := make([]appsv1.Deployment, 0, len(zones))
+		for _, zone := range zones {
+			// the targets whose zone isn't one of the zones are served by the TargetAllocator of the first zone
+			container := Container(cfg, logger, otelcol)
+			container.Args = append(container.Args, fmt.Sprintf("--zone=%s", zone), fmt.Sprintf("--zones=%s", strings.Join(zones, ",")))
+			deployment := deployment(cfg, otelcol, naming.TargetAllocatorZone(otelcol, zone), container)
+			deployment.Labels = ZoneLabels(deployment.Labels, zone)
+			deployment.Spec.Selector.MatchLabels = ZoneLabels(deployment.Spec.Selector.MatchLabels, zone)
+			deployment.Spec.Template.Labels = ZoneLabels(deployment.Spec.Template.Labels, zone)
+			deployment.Spec.Template.Spec.NodeSelector = ZoneLabels(deployment.Spec.Template.Spec.NodeSelector, zone)
+			deployments = append(deployments, deployment)
+		}
+		return deployments
+	}
+
+	shards := JobShards(otelcol)
+	if shards == 1 {
+		return []appsv1.Deployment{Deployment(cfg, logger, otelcol)}
+	}
+
+	deployments := make([]appsv1.Deployment, 0, shards)
+	for shard := int32(0); shard < shards; shard++ {
+		container := Container(cfg, logger, otelcol)
+		container.Args = append(container.Args, fmt.Sprintf("--job-shard=%d", shard), fmt.Sprintf("--job-shards=%d", shards))
+		deployments = append(deployments, deployment(cfg, otelcol, naming.TargetAllocatorShard(otelcol, shard), container))
+	}
+	return deployments
+}
+
+func deployment(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, name string, container corev1.Container) appsv1.Deployment {
 	labels := Labels(otelcol, name)
+	selectorLabels := SelectorLabels(otelcol, name)
+	podLabels := Labels(otelcol, name)
+	for k, v := range selectorLabels {
+		podLabels[k] = v
+	}
+
+	// each TargetAllocator of the shards or zones has its own file of allocation state, in its own emptyDir volume
+	if otelcol.Spec.TargetAllocator.AllocationState != nil {
+		container.Args = append(container.Args, fmt.Sprintf("--allocation-state-file=%s/%s.json", allocationStateDir, name))
+	}
+
+	// the TargetAllocator of a hibernated instance is scaled to zero along with its collectors
+	replicas := otelcol.Spec.TargetAllocator.Replicas
+	if otelcol.Spec.Hibernate {
+		zero := int32(0)
+		replicas = &zero
+	}
+
+	// the claim of the allocation state can't be attached to the new pod of a rolling update before the old pod is gone
+	var strategy appsv1.DeploymentStrategy
+	if state := otelcol.Spec.TargetAllocator.AllocationState; state != nil && state.PersistentVolumeClaim != "" {
+		strategy.Type = appsv1.RecreateDeploymentStrategyType
+	}
 
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -37,19 +99,22 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: otelcol.Spec.TargetAllocator.Replicas,
+			Replicas: replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
+				MatchLabels: selectorLabels,
 			},
+			Strategy: strategy,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels,
 					Annotations: otelcol.Spec.PodAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol)},
+					Containers:         []corev1.Container{container},
 					Volumes:            Volumes(cfg, otelcol),
+					DNSPolicy:          otelcol.Spec.TargetAllocator.DNSPolicy,
+					DNSConfig:          otelcol.Spec.TargetAllocator.PodDNSConfig,
 				},
 			},
 		},
@@ -15,13 +15,17 @@
 package targetallocator
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 func TestDeploymentNewDefault(t *testing.T) {
@@ -46,7 +50,9 @@ func TestDeploymentNewDefault(t *testing.T) {
 	assert.Empty(t, d.Spec.Template.Annotations)
 
 	// the pod selector should match the pod spec's labels
-	assert.Equal(t, d.Spec.Template.Labels, d.Spec.Selector.MatchLabels)
+	for k, v := range d.Spec.Selector.MatchLabels {
+		assert.Equal(t, v, d.Spec.Template.Labels[k])
+	}
 }
 
 func TestDeploymentPodAnnotations(t *testing.T) {
@@ -69,3 +75,180 @@ func TestDeploymentPodAnnotations(t *testing.T) {
 	assert.Equal(t, "my-instance-targetallocator", ds.Name)
 	assert.Equal(t, testPodAnnotationValues, ds.Spec.Template.Annotations)
 }
+
+func TestDeploymentHibernate(t *testing.T) {
+	// prepare
+	two := int32(2)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Hibernate: true,
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Replicas: &two,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, int32(0), *d.Spec.Replicas)
+}
+
+func TestDeploymentDNS(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				DNSPolicy: corev1.DNSDefault,
+				PodDNSConfig: &corev1.PodDNSConfig{
+					Searches: []string{"example.com"},
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, corev1.DNSDefault, d.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, []string{"example.com"}, d.Spec.Template.Spec.DNSConfig.Searches)
+}
+
+func TestDeploymentsJobShards(t *testing.T) {
+	// prepare
+	three := int32(3)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				JobShards: &three,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	deployments := Deployments(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, deployments, 3)
+	for i, d := range deployments {
+		name := fmt.Sprintf("my-instance-targetallocator-%d", i)
+		assert.Equal(t, name, d.Name)
+		assert.Equal(t, name, d.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+		assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--job-shard=%d", i))
+		assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "--job-shards=3")
+	}
+}
+
+func TestDeploymentsAllocationState(t *testing.T) {
+	// prepare
+	two := int32(2)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				JobShards:       &two,
+				AllocationState: &v1alpha1.TargetAllocatorAllocationState{},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	deployments := Deployments(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, deployments, 2)
+	for i, d := range deployments {
+		container := d.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Args, fmt.Sprintf("--allocation-state-file=/allocation-state/my-instance-targetallocator-%d.json", i))
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: naming.TAAllocationStateVolume(), MountPath: "/allocation-state"})
+		assert.Empty(t, d.Spec.Strategy.Type)
+	}
+}
+
+func TestDeploymentAllocationStateClaim(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				AllocationState: &v1alpha1.TargetAllocatorAllocationState{
+					PersistentVolumeClaim: "my-allocation-state",
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, d.Spec.Strategy.Type)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "--allocation-state-file=/allocation-state/my-instance-targetallocator.json")
+}
+
+func TestDeploymentsNoJobShards(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+	cfg := config.New()
+
+	// test
+	deployments := Deployments(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, []appsv1.Deployment{Deployment(cfg, logger, otelcol)}, deployments)
+}
+
+func TestDeploymentsZones(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:       true,
+				TopologyAware: &v1alpha1.TopologyAwareSpec{Zones: []string{"eu-west-1a", "eu-west-1b"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	deployments := Deployments(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, deployments, 2)
+	for i, zone := range []string{"eu-west-1a", "eu-west-1b"} {
+		d := deployments[i]
+		assert.Equal(t, "my-instance-targetallocator-"+zone, d.Name)
+		assert.Equal(t, zone, d.Spec.Selector.MatchLabels[ZoneLabel])
+		assert.Equal(t, zone, d.Spec.Template.Labels[ZoneLabel])
+		assert.Equal(t, map[string]string{ZoneLabel: zone}, d.Spec.Template.Spec.NodeSelector)
+		assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "--zone="+zone)
+		assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "--zones=eu-west-1a,eu-west-1b")
+	}
+}
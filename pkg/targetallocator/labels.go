@@ -30,7 +30,7 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, name string) map[string]st
 	}
 
 	base["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
-	base["app.kubernetes.io/instance"] = naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name)
+	base["app.kubernetes.io/instance"] = naming.Instance(instance)
 	base["app.kubernetes.io/part-of"] = "opentelemetry"
 	base["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
 
@@ -40,3 +40,15 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, name string) map[string]st
 
 	return base
 }
+
+// SelectorLabels return the labels selecting the pods of the TargetAllocator with the given name. Unlike Labels, they
+// don't depend on the labels of the instance, so that the selectors, which are immutable, don't change along with them.
+func SelectorLabels(instance v1alpha1.OpenTelemetryCollector, name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   naming.Instance(instance),
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  "opentelemetry-targetallocator",
+		"app.kubernetes.io/name":       name,
+	}
+}
@@ -65,3 +65,25 @@ func TestLabelsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", labels["myapp"])
 	assert.Equal(t, "test", labels["app.kubernetes.io/name"])
 }
+
+func TestSelectorLabelsIgnoreInstanceLabels(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"myapp":                  "mycomponent",
+				"app.kubernetes.io/name": "test",
+			},
+		},
+	}
+
+	// test
+	labels := SelectorLabels(otelcol, name)
+
+	// verify
+	assert.Len(t, labels, 5)
+	assert.Equal(t, name, labels["app.kubernetes.io/name"])
+	assert.NotContains(t, labels, "myapp")
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// ScrapeOverrides returns the scrape_overrides section of the TargetAllocator configuration of the given instance, or
+// nil when it has no scrape overrides.
+func ScrapeOverrides(otelcol v1alpha1.OpenTelemetryCollector) map[string]interface{} {
+	spec := otelcol.Spec.TargetAllocator.ScrapeOverrides
+	if spec == nil {
+		return nil
+	}
+	overrides := map[string]interface{}{}
+	if spec.MinScrapeInterval != nil && spec.MinScrapeInterval.Duration > 0 {
+		overrides["min_scrape_interval"] = promDuration(spec.MinScrapeInterval.Duration)
+	}
+	if spec.SampleLimit != nil {
+		overrides["sample_limit"] = *spec.SampleLimit
+	}
+	if spec.LabelLimit != nil {
+		overrides["label_limit"] = *spec.LabelLimit
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	return overrides
+}
+
+// promDuration formats the given duration the way the Prometheus durations are parsed, which don't accept the
+// fractions of time.Duration.String.
+func promDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d/time.Millisecond)
+}
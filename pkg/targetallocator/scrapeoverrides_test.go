@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestScrapeOverrides(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	assert.Nil(t, ScrapeOverrides(otelcol))
+
+	otelcol.Spec.TargetAllocator.ScrapeOverrides = &v1alpha1.TargetAllocatorScrapeOverrides{}
+	assert.Nil(t, ScrapeOverrides(otelcol))
+
+	sampleLimit, labelLimit := int32(10000), int32(50)
+	otelcol.Spec.TargetAllocator.ScrapeOverrides = &v1alpha1.TargetAllocatorScrapeOverrides{
+		MinScrapeInterval: &metav1.Duration{Duration: time.Minute},
+		SampleLimit:       &sampleLimit,
+		LabelLimit:        &labelLimit,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"min_scrape_interval": "60s",
+		"sample_limit":        int32(10000),
+		"label_limit":         int32(50),
+	}, ScrapeOverrides(otelcol))
+
+	otelcol.Spec.TargetAllocator.ScrapeOverrides.MinScrapeInterval.Duration = 1500 * time.Millisecond
+	assert.Equal(t, "1500ms", ScrapeOverrides(otelcol)["min_scrape_interval"])
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"hash/fnv"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// JobShards returns the number of shards the scrape jobs of the given instance are partitioned across.
+func JobShards(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.TargetAllocator.JobShards == nil || *otelcol.Spec.TargetAllocator.JobShards < 1 {
+		return 1
+	}
+	return *otelcol.Spec.TargetAllocator.JobShards
+}
+
+// JobShard returns the shard owning the scrape job with the given name. This has to match the way the target
+// allocator partitions the jobs, see target.JobShard in cmd/otel-allocator.
+func JobShard(jobName string, shards int32) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(jobName))
+	return int32(h.Sum32() % uint32(shards))
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestJobShards(t *testing.T) {
+	zero, three := int32(0), int32(3)
+	for _, tt := range []struct {
+		name      string
+		jobShards *int32
+		expected  int32
+	}{
+		{name: "unset", jobShards: nil, expected: 1},
+		{name: "zero", jobShards: &zero, expected: 1},
+		{name: "set", jobShards: &three, expected: 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-instance",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+						JobShards: tt.jobShards,
+					},
+				},
+			}
+			assert.Equal(t, tt.expected, JobShards(otelcol))
+		})
+	}
+}
+
+func TestJobShard(t *testing.T) {
+	// the expected shards must match the ones of the target allocator's target.JobShard tests
+	for job, expected := range map[string]int32{
+		"prometheus":       2,
+		"kubernetes-pods":  1,
+		"kubernetes-nodes": 0,
+		"node-exporter":    0,
+	} {
+		assert.Equal(t, expected, JobShard(job, 3), job)
+		assert.Equal(t, int32(0), JobShard(job, 1), job)
+	}
+}
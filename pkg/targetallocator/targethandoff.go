@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
+)
+
+const (
+	// defaultTargetAllocatorInterval is the default interval of the target_allocator section of the Prometheus
+	// receiver, which the collectors get their targets at.
+	defaultTargetAllocatorInterval = 30 * time.Second
+	// defaultHTTPSDRefreshInterval is the default refresh interval of the HTTP service discovery, which the collectors
+	// get their targets with otherwise.
+	defaultHTTPSDRefreshInterval = 60 * time.Second
+)
+
+// TargetHandoff returns the target_handoff section of the TargetAllocator configuration of the given instance, whose
+// collectors run with the given configuration, or nil when the targets aren't handed off.
+func TargetHandoff(otelcol v1alpha1.OpenTelemetryCollector, collectorConfig string) (map[string]interface{}, error) {
+	if !otelcol.Spec.TargetAllocator.TargetHandoff {
+		return nil, nil
+	}
+	prometheus, err := adapters.ConfigToPromConfig(collectorConfig)
+	if err != nil {
+		return nil, err
+	}
+	refreshInterval, err := targetsRefreshInterval(prometheus)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"refresh_interval": promDuration(refreshInterval),
+	}, nil
+}
+
+// targetsRefreshInterval returns the interval the collectors get their targets at with the given configuration of the
+// Prometheus receiver: the interval of its target_allocator section, or else the longest refresh interval of the
+// http_sd_configs of its scrape configs.
+func targetsRefreshInterval(prometheus map[string]interface{}) (time.Duration, error) {
+	if targetAllocator, ok := prometheus["target_allocator"].(map[string]interface{}); ok {
+		return parseInterval(targetAllocator["interval"], defaultTargetAllocatorInterval, time.ParseDuration)
+	}
+
+	refreshInterval := defaultHTTPSDRefreshInterval
+	config, _ := prometheus["config"].(map[string]interface{})
+	scrapeConfigs, _ := config["scrape_configs"].([]interface{})
+	for _, scrapeConfig := range scrapeConfigs {
+		scrapeConfig, _ := scrapeConfig.(map[string]interface{})
+		sdConfigs, _ := scrapeConfig["http_sd_configs"].([]interface{})
+		for _, sdConfig := range sdConfigs {
+			sdConfig, _ := sdConfig.(map[string]interface{})
+			interval, err := parseInterval(sdConfig["refresh_interval"], defaultHTTPSDRefreshInterval, parsePromDuration)
+			if err != nil {
+				return 0, err
+			}
+			if interval > refreshInterval {
+				refreshInterval = interval
+			}
+		}
+	}
+	return refreshInterval, nil
+}
+
+// parseInterval parses the given interval of the configuration with the given parser, or returns the given default
+// when it isn't set.
+func parseInterval(value interface{}, defaultInterval time.Duration, parse func(string) (time.Duration, error)) (time.Duration, error) {
+	if value == nil {
+		return defaultInterval, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("the interval %v isn't a duration", value)
+	}
+	interval, err := parse(s)
+	if err != nil {
+		return 0, fmt.Errorf("the interval %s isn't a duration: %w", s, err)
+	}
+	return interval, nil
+}
+
+func parsePromDuration(s string) (time.Duration, error) {
+	d, err := model.ParseDuration(s)
+	return time.Duration(d), err
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestTargetHandoff(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	handoff, err := TargetHandoff(otelcol, "")
+	require.NoError(t, err)
+	assert.Nil(t, handoff)
+
+	otelcol.Spec.TargetAllocator.TargetHandoff = true
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected string
+	}{
+		{
+			desc: "target allocator interval",
+			config: `receivers:
+  prometheus:
+    target_allocator:
+      endpoint: http://test-targetallocator:80
+      interval: 45s
+`,
+			expected: "45s",
+		},
+		{
+			desc: "default target allocator interval",
+			config: `receivers:
+  prometheus:
+    target_allocator:
+      endpoint: http://test-targetallocator:80
+`,
+			expected: "30s",
+		},
+		{
+			desc: "longest http sd refresh interval",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: a
+        http_sd_configs:
+        - url: http://test-targetallocator:80/jobs/a/targets
+      - job_name: b
+        http_sd_configs:
+        - url: http://test-targetallocator:80/jobs/b/targets
+          refresh_interval: 2m
+`,
+			expected: "120s",
+		},
+		{
+			desc: "default http sd refresh interval",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: a
+        http_sd_configs:
+        - url: http://test-targetallocator:80/jobs/a/targets
+`,
+			expected: "60s",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			handoff, err := TargetHandoff(otelcol, tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"refresh_interval": tt.expected}, handoff)
+		})
+	}
+
+	_, err = TargetHandoff(otelcol, `receivers:
+  prometheus:
+    target_allocator:
+      interval: soon
+`)
+	assert.Error(t, err)
+}
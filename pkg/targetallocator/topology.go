@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// ZoneLabel is the label holding the availability zone of the nodes. It's also set on the collector pods and the
+// TargetAllocator pods of topology-aware instances, see the --zone and --zones flags of the target allocator.
+const ZoneLabel = "topology.kubernetes.io/zone"
+
+// Zones returns the availability zones the collectors and the TargetAllocators of the given instance are run in, or
+// nil when the TargetAllocator isn't topology-aware.
+func Zones(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	if !otelcol.Spec.TargetAllocator.Enabled || otelcol.Spec.TargetAllocator.TopologyAware == nil {
+		return nil
+	}
+	return otelcol.Spec.TargetAllocator.TopologyAware.Zones
+}
+
+// ZoneLabels returns a copy of the given labels, or node selector, with the given availability zone.
+func ZoneLabels(labels map[string]string, zone string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[ZoneLabel] = zone
+	return out
+}
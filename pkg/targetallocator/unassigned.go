@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ConditionTypeTargetsAssigned is the type of the status condition reporting whether the TargetAllocator assigns all
+// the targets, or leaves some unassigned as all the collectors reached their maximum number of targets.
+const ConditionTypeTargetsAssigned = "TargetsAssigned"
+
+// Reasons of the TargetsAssigned condition.
+const (
+	ReasonAllTargetsAssigned = "AllTargetsAssigned"
+	ReasonTargetsUnassigned  = "TargetsUnassigned"
+)
+
+// unassignedTargetsMetric is the gauge of the TargetAllocator counting the targets it leaves unassigned, labelled by
+// allocation strategy.
+const unassignedTargetsMetric = "opentelemetry_allocator_targets_unassigned"
+
+// TargetsLimited returns whether the TargetAllocator of the given instance caps the number of targets per collector,
+// which is the only reason it leaves targets unassigned.
+func TargetsLimited(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.TargetAllocator.Enabled && otelcol.Spec.TargetAllocator.MaxTargetsPerCollector != nil
+}
+
+// Services returns the names of the TargetAllocator services of the given instance, one for each availability zone when
+// it's topology-aware, or else one for each job shard.
+func Services(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	if zones := Zones(otelcol); len(zones) > 0 {
+		services := make([]string, 0, len(zones))
+		for _, zone := range zones {
+			services = append(services, naming.TAServiceZone(otelcol, zone))
+		}
+		return services
+	}
+	shards := JobShards(otelcol)
+	if shards == 1 {
+		return []string{naming.TAService(otelcol)}
+	}
+	services := make([]string, 0, shards)
+	for shard := int32(0); shard < shards; shard++ {
+		services = append(services, naming.TAServiceShard(otelcol, shard))
+	}
+	return services
+}
+
+// MetricsURLs returns the URLs of the metrics of the TargetAllocators of the given instance, through their services.
+func MetricsURLs(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	var urls []string
+	for _, service := range Services(otelcol) {
+		urls = append(urls, fmt.Sprintf("http://%s.%s.svc/metrics", service, otelcol.Namespace))
+	}
+	return urls
+}
+
+// UnassignedTargets returns the number of targets left unassigned from the given metrics of a TargetAllocator, in the
+// Prometheus text format. The metric is only exposed once the TargetAllocator assigned targets, so it's 0 when missing.
+func UnassignedTargets(metrics io.Reader) (int, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the metrics of the TargetAllocator: %w", err)
+	}
+	family, ok := families[unassignedTargetsMetric]
+	if !ok {
+		return 0, nil
+	}
+	unassigned := 0.0
+	for _, metric := range family.GetMetric() {
+		unassigned += metric.GetGauge().GetValue()
+	}
+	return int(unassigned), nil
+}
+
+// TargetsAssignedCondition returns the TargetsAssigned condition of the given instance from the number of targets its
+// TargetAllocators leave unassigned, or nil when they don't cap the number of targets per collector.
+func TargetsAssignedCondition(otelcol v1alpha1.OpenTelemetryCollector, unassigned int) *metav1.Condition {
+	if !TargetsLimited(otelcol) {
+		return nil
+	}
+	if unassigned == 0 {
+		return &metav1.Condition{
+			Type:               ConditionTypeTargetsAssigned,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonAllTargetsAssigned,
+			Message:            "the TargetAllocator assigns all the targets to the collectors",
+			ObservedGeneration: otelcol.Generation,
+		}
+	}
+	return &metav1.Condition{
+		Type:   ConditionTypeTargetsAssigned,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonTargetsUnassigned,
+		Message: fmt.Sprintf("the TargetAllocator leaves %d targets unassigned as all the collectors reached the maxTargetsPerCollector limit of %d, add collectors or raise the limit",
+			unassigned, *otelcol.Spec.TargetAllocator.MaxTargetsPerCollector),
+		ObservedGeneration: otelcol.Generation,
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestMetricsURLs(t *testing.T) {
+	shards := int32(2)
+	for _, tt := range []struct {
+		desc     string
+		spec     v1alpha1.OpenTelemetryTargetAllocator
+		expected []string
+	}{
+		{
+			"single",
+			v1alpha1.OpenTelemetryTargetAllocator{Enabled: true},
+			[]string{"http://my-instance-targetallocator.observability.svc/metrics"},
+		},
+		{
+			"job shards",
+			v1alpha1.OpenTelemetryTargetAllocator{Enabled: true, JobShards: &shards},
+			[]string{
+				"http://my-instance-targetallocator-0.observability.svc/metrics",
+				"http://my-instance-targetallocator-1.observability.svc/metrics",
+			},
+		},
+		{
+			"zones",
+			v1alpha1.OpenTelemetryTargetAllocator{Enabled: true, TopologyAware: &v1alpha1.TopologyAwareSpec{Zones: []string{"a", "b"}}},
+			[]string{
+				"http://my-instance-targetallocator-a.observability.svc/metrics",
+				"http://my-instance-targetallocator-b.observability.svc/metrics",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "observability"},
+				Spec:       v1alpha1.OpenTelemetryCollectorSpec{TargetAllocator: tt.spec},
+			}
+			assert.Equal(t, tt.expected, MetricsURLs(otelcol))
+		})
+	}
+}
+
+func TestUnassignedTargets(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		metrics  string
+		expected int
+		err      string
+	}{
+		{
+			"unassigned",
+			`# HELP opentelemetry_allocator_targets_unassigned The number of targets left unassigned because the collectors are at their maximum number of targets.
+# TYPE opentelemetry_allocator_targets_unassigned gauge
+opentelemetry_allocator_targets_unassigned{strategy="consistent-hashing"} 12
+opentelemetry_allocator_targets_unassigned{strategy="least-weighted"} 0
+`,
+			12,
+			"",
+		},
+		{
+			"not exposed",
+			`# TYPE opentelemetry_allocator_targets gauge
+opentelemetry_allocator_targets{job_name="kubelet"} 5
+`,
+			0,
+			"",
+		},
+		{
+			"invalid",
+			"opentelemetry_allocator_targets_unassigned{",
+			0,
+			"failed to parse the metrics of the TargetAllocator",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			unassigned, err := UnassignedTargets(strings.NewReader(tt.metrics))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, unassigned)
+		})
+	}
+}
+
+func TestTargetsAssignedCondition(t *testing.T) {
+	limit := int32(100)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{Enabled: true, MaxTargetsPerCollector: &limit},
+		},
+	}
+
+	t.Run("all targets assigned", func(t *testing.T) {
+		condition := TargetsAssignedCondition(otelcol, 0)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, ReasonAllTargetsAssigned, condition.Reason)
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+	})
+
+	t.Run("targets unassigned", func(t *testing.T) {
+		condition := TargetsAssignedCondition(otelcol, 12)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, ReasonTargetsUnassigned, condition.Reason)
+		assert.Contains(t, condition.Message, "leaves 12 targets unassigned")
+		assert.Contains(t, condition.Message, "limit of 100")
+	})
+
+	t.Run("without limit", func(t *testing.T) {
+		unlimited := *otelcol.DeepCopy()
+		unlimited.Spec.TargetAllocator.MaxTargetsPerCollector = nil
+		assert.Nil(t, TargetsAssignedCondition(unlimited, 12))
+	})
+}
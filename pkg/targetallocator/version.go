@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// ConditionTypeCompatible is the type of the status condition reporting whether the TargetAllocator runs a version
+// compatible with the collector's.
+const ConditionTypeCompatible = "TargetAllocatorCompatible"
+
+// Reasons of the TargetAllocatorCompatible condition.
+const (
+	ReasonVersionsMatch   = "VersionsMatch"
+	ReasonVersionMismatch = "VersionMismatch"
+)
+
+// releasedVersions are the released TargetAllocator versions, the last one of each minor version, since the images are
+// published on ghcr.io. The TargetAllocator is released along with the operator, which skipped some minor versions, and
+// only released patch versions of others, so the tags of all the minor versions can't be inferred.
+var releasedVersions = []string{
+	"0.42.0", "0.43.0", "0.44.0", "0.45.0", "0.46.0", "0.47.0", "0.48.0", "0.49.0", "0.50.0", "0.51.0", "0.52.0",
+	"0.53.0", "0.54.0", "0.55.0", "0.56.0", "0.57.2", "0.58.0", "0.59.0", "0.60.0", "0.61.0", "0.62.1", "0.63.1",
+	"0.64.1", "0.66.0", "0.67.0", "0.68.0", "0.69.0", "0.70.0", "0.71.0", "0.72.0", "0.73.0", "0.74.0", "0.75.0",
+	"0.76.1", "0.77.0",
+}
+
+// CompatibleVersion returns the released TargetAllocator version compatible with the given collector version, or nil
+// when none is known. The minor versions of the operator, and of the TargetAllocator, follow the collector's.
+func CompatibleVersion(collectorVersion *semver.Version) *semver.Version {
+	for _, released := range releasedVersions {
+		if v := semver.MustParse(released); Compatible(collectorVersion, v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// Compatible returns whether the given TargetAllocator version can run along with the given collector version.
+func Compatible(collectorVersion *semver.Version, targetAllocatorVersion *semver.Version) bool {
+	return collectorVersion.Major() == targetAllocatorVersion.Major() && collectorVersion.Minor() == targetAllocatorVersion.Minor()
+}
+
+// Image returns the TargetAllocator image of the given instance. The image set on the instance is used as is. Otherwise,
+// the operator's default image is used, with the tag of the released version compatible with the collector when the
+// collector image is pinned to a version the default image isn't compatible with. The default image is kept when no
+// compatible version was released, and the TargetAllocatorCompatible condition reports the mismatch.
+func Image(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	if len(otelcol.Spec.TargetAllocator.Image) > 0 {
+		return otelcol.Spec.TargetAllocator.Image
+	}
+
+	image := cfg.TargetAllocatorImage()
+	collectorVersion := v1alpha1.ImageVersion(otelcol.Spec.Image)
+	defaultVersion := v1alpha1.ImageVersion(image)
+	if collectorVersion == nil || defaultVersion == nil || Compatible(collectorVersion, defaultVersion) || strings.Contains(image, "@") {
+		return image
+	}
+	compatible := CompatibleVersion(collectorVersion)
+	if compatible == nil {
+		return image
+	}
+	repository := image[:strings.LastIndex(image, ":")]
+	tag := compatible.String()
+	if strings.HasPrefix(image[len(repository)+1:], "v") {
+		tag = "v" + tag
+	}
+	return fmt.Sprintf("%s:%s", repository, tag)
+}
+
+// Version returns the version of the TargetAllocator of the given instance, or nil when it isn't enabled or its image
+// isn't tagged with a version.
+func Version(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *semver.Version {
+	if !otelcol.Spec.TargetAllocator.Enabled {
+		return nil
+	}
+	return v1alpha1.ImageVersion(Image(cfg, otelcol))
+}
+
+// CompatibleCondition returns the TargetAllocatorCompatible condition of the given instance, or nil when the versions
+// of its collector and TargetAllocator images aren't known.
+func CompatibleCondition(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) *metav1.Condition {
+	collectorImage := otelcol.Spec.Image
+	if len(collectorImage) == 0 {
+		collectorImage = cfg.CollectorImage()
+	}
+	collectorVersion := v1alpha1.ImageVersion(collectorImage)
+	targetAllocatorVersion := Version(cfg, otelcol)
+	if collectorVersion == nil || targetAllocatorVersion == nil {
+		return nil
+	}
+
+	if !Compatible(collectorVersion, targetAllocatorVersion) {
+		message := fmt.Sprintf("the TargetAllocator version %s doesn't match the collector version %s, and no compatible TargetAllocator version is known", targetAllocatorVersion, collectorVersion)
+		if compatible := CompatibleVersion(collectorVersion); compatible != nil {
+			message = fmt.Sprintf("the TargetAllocator version %s doesn't match the collector version %s, the compatible TargetAllocator version is %s", targetAllocatorVersion, collectorVersion, compatible)
+		}
+		return &metav1.Condition{
+			Type:    ConditionTypeCompatible,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonVersionMismatch,
+			Message: message,
+		}
+	}
+	return &metav1.Condition{
+		Type:    ConditionTypeCompatible,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonVersionsMatch,
+		Message: fmt.Sprintf("the TargetAllocator version %s matches the collector version %s", targetAllocatorVersion, collectorVersion),
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestImage(t *testing.T) {
+	cfg := config.New(config.WithTargetAllocatorImage("ghcr.io/open-telemetry/target-allocator:0.77.0"))
+
+	for _, tt := range []struct {
+		desc                 string
+		collectorImage       string
+		targetAllocatorImage string
+		expected             string
+	}{
+		{
+			desc:     "default images",
+			expected: "ghcr.io/open-telemetry/target-allocator:0.77.0",
+		},
+		{
+			desc:           "collector pinned to a compatible version",
+			collectorImage: "otel/opentelemetry-collector-contrib:0.77.1",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.77.0",
+		},
+		{
+			desc:           "collector pinned to an older version",
+			collectorImage: "otel/opentelemetry-collector-contrib:0.75.2",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.75.0",
+		},
+		{
+			desc:           "collector pinned to a version only released as a patch version",
+			collectorImage: "otel/opentelemetry-collector-contrib:0.76.3",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.76.1",
+		},
+		{
+			desc:           "collector pinned to a version without a released target allocator",
+			collectorImage: "otel/opentelemetry-collector-contrib:0.65.0",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.77.0",
+		},
+		{
+			desc:           "collector pinned to a newer version",
+			collectorImage: "otel/opentelemetry-collector-contrib:0.80.0",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.77.0",
+		},
+		{
+			desc:           "collector pinned without a version",
+			collectorImage: "otel/opentelemetry-collector-contrib:latest",
+			expected:       "ghcr.io/open-telemetry/target-allocator:0.77.0",
+		},
+		{
+			desc:                 "target allocator overridden",
+			collectorImage:       "otel/opentelemetry-collector-contrib:0.75.2",
+			targetAllocatorImage: "registry.example.com/target-allocator:0.70.0",
+			expected:             "registry.example.com/target-allocator:0.70.0",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Image: tt.collectorImage,
+					TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+						Enabled: true,
+						Image:   tt.targetAllocatorImage,
+					},
+				},
+			}
+			assert.Equal(t, tt.expected, Image(cfg, otelcol))
+		})
+	}
+}
+
+func TestCompatibleCondition(t *testing.T) {
+	cfg := config.New(
+		config.WithCollectorImage("otel/opentelemetry-collector:0.77.0"),
+		config.WithTargetAllocatorImage("ghcr.io/open-telemetry/target-allocator:0.77.0"),
+	)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+			},
+		},
+	}
+
+	// the default images match
+	condition := CompatibleCondition(cfg, otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "0.77.0", Version(cfg, otelcol).String())
+
+	// a pinned collector brings the target allocator along
+	otelcol.Spec.Image = "otel/opentelemetry-collector:0.75.0"
+	condition = CompatibleCondition(cfg, otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "0.75.0", Version(cfg, otelcol).String())
+
+	// an overridden target allocator may be skewed
+	otelcol.Spec.TargetAllocator.Image = "ghcr.io/open-telemetry/target-allocator:0.70.0"
+	condition = CompatibleCondition(cfg, otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonVersionMismatch, condition.Reason)
+
+	// a collector without a released target allocator keeps the default one
+	otelcol.Spec.TargetAllocator.Image = ""
+	otelcol.Spec.Image = "otel/opentelemetry-collector:0.80.0"
+	condition = CompatibleCondition(cfg, otelcol)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "the TargetAllocator version 0.77.0 doesn't match the collector version 0.80.0, and no compatible TargetAllocator version is known", condition.Message)
+
+	// no condition without a target allocator
+	otelcol.Spec.TargetAllocator.Enabled = false
+	assert.Nil(t, CompatibleCondition(cfg, otelcol))
+}
@@ -38,5 +38,15 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		},
 	}}
 
+	if state := otelcol.Spec.TargetAllocator.AllocationState; state != nil {
+		volume := corev1.Volume{Name: naming.TAAllocationStateVolume()}
+		if state.PersistentVolumeClaim != "" {
+			volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: state.PersistentVolumeClaim}
+		} else {
+			volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+		}
+		volumes = append(volumes, volume)
+	}
+
 	return volumes
 }
@@ -41,3 +41,32 @@ func TestVolumeNewDefault(t *testing.T) {
 	// check that it's the ta-internal volume, with the config map
 	assert.Equal(t, naming.TAConfigMapVolume(), volumes[0].Name)
 }
+
+func TestVolumeAllocationState(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				AllocationState: &v1alpha1.TargetAllocatorAllocationState{},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, naming.TAAllocationStateVolume(), volumes[1].Name)
+	assert.NotNil(t, volumes[1].EmptyDir)
+
+	// test
+	otelcol.Spec.TargetAllocator.AllocationState.PersistentVolumeClaim = "my-allocation-state"
+	volumes = Volumes(cfg, otelcol)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Nil(t, volumes[1].EmptyDir)
+	assert.Equal(t, "my-allocation-state", volumes[1].PersistentVolumeClaim.ClaimName)
+}
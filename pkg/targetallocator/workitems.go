@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// WorkItems returns the work_items section of the TargetAllocator configuration of the given instance, or nil when it
+// has no work items.
+func WorkItems(otelcol v1alpha1.OpenTelemetryCollector) []map[string]interface{} {
+	if len(otelcol.Spec.TargetAllocator.WorkItems) == 0 {
+		return nil
+	}
+	items := make([]map[string]interface{}, 0, len(otelcol.Spec.TargetAllocator.WorkItems))
+	for _, workItem := range otelcol.Spec.TargetAllocator.WorkItems {
+		item := map[string]interface{}{
+			"job_name": workItem.JobName,
+			"endpoint": workItem.Endpoint,
+		}
+		if len(workItem.Labels) > 0 {
+			item["labels"] = workItem.Labels
+		}
+		items = append(items, item)
+	}
+	return items
+}
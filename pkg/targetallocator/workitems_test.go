@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestWorkItems(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	assert.Nil(t, WorkItems(otelcol))
+
+	otelcol.Spec.TargetAllocator.WorkItems = []v1alpha1.TargetAllocatorWorkItem{
+		{JobName: "sql-queries", Endpoint: "postgres:5432", Labels: map[string]string{"database": "orders"}},
+		{JobName: "snmp", Endpoint: "10.0.0.1:161"},
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{"job_name": "sql-queries", "endpoint": "postgres:5432", "labels": map[string]string{"database": "orders"}},
+		{"job_name": "snmp", "endpoint": "10.0.0.1:161"},
+	}, WorkItems(otelcol))
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testing renders the objects the operator creates for OpenTelemetryCollector resources in memory, without
+// a cluster, so that the resources can be unit-tested against the operator's rendering logic.
+package testing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
+)
+
+var scheme = k8sruntime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(routev1.AddToScheme(scheme))
+}
+
+type options struct {
+	namespace            string
+	collectorImage       string
+	targetAllocatorImage string
+	strict               bool
+	config               *config.Config
+}
+
+// Option configures the decoding and rendering of the resources.
+type Option func(*options)
+
+// WithNamespace sets the namespace of the resources without one, "default" by default.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithCollectorImage sets the default collector image of the operator.
+func WithCollectorImage(image string) Option {
+	return func(o *options) {
+		o.collectorImage = image
+	}
+}
+
+// WithTargetAllocatorImage sets the default target allocator image of the operator.
+func WithTargetAllocatorImage(image string) Option {
+	return func(o *options) {
+		o.targetAllocatorImage = image
+	}
+}
+
+// WithStrict makes the decoding fail on the unknown attributes of the resources, e.g. a misspelled attribute of
+// spec.targetAllocator, which the API server drops.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithConfig sets the whole configuration of the operator, e.g. the one built from its flags, in place of the images
+// of WithCollectorImage and WithTargetAllocatorImage.
+func WithConfig(cfg config.Config) Option {
+	return func(o *options) {
+		o.config = &cfg
+	}
+}
+
+func newOptions(opts []Option) options {
+	v := version.Get()
+	o := options{
+		namespace:            "default",
+		collectorImage:       fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector),
+		targetAllocatorImage: fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Load reads the OpenTelemetryCollector resources of a YAML file.
+func Load(path string, opts ...Option) ([]v1alpha1.OpenTelemetryCollector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(bytes.NewReader(data), opts...)
+}
+
+// Decode reads the OpenTelemetryCollector resources of a stream of YAML or JSON documents.
+func Decode(r io.Reader, opts ...Option) ([]v1alpha1.OpenTelemetryCollector, error) {
+	o := newOptions(opts)
+	var codecOpts []serializer.CodecFactoryOptionsMutator
+	if o.strict {
+		codecOpts = append(codecOpts, serializer.EnableStrict)
+	}
+	decoder := serializer.NewCodecFactory(scheme, codecOpts...).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var collectors []v1alpha1.OpenTelemetryCollector
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the resources: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the resource: %w", err)
+		}
+		otelcol, ok := obj.(*v1alpha1.OpenTelemetryCollector)
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource kind %s", gvk.Kind)
+		}
+		if len(otelcol.Namespace) == 0 {
+			otelcol.Namespace = o.namespace
+		}
+		collectors = append(collectors, *otelcol)
+	}
+	return collectors, nil
+}
+
+// Manifests holds the objects the operator creates for an OpenTelemetryCollector.
+type Manifests struct {
+	// Objects are the objects, with their type metadata set.
+	Objects []client.Object
+
+	// Config is the collector configuration, as rewritten by the operator.
+	Config string
+
+	// Warnings are the warnings the operator's webhook returns for the resource.
+	Warnings []string
+}
+
+// Render returns the objects the operator creates for the OpenTelemetryCollector. The resource is defaulted and
+// validated like the operator's webhook does, and an invalid resource is reported as an error.
+func Render(otelcol v1alpha1.OpenTelemetryCollector, opts ...Option) (Manifests, error) {
+	o := newOptions(opts)
+	instance := otelcol.DeepCopy()
+	if len(instance.Namespace) == 0 {
+		instance.Namespace = o.namespace
+	}
+
+	instance.Default()
+	warnings, err := instance.ValidateCreate()
+	if err != nil {
+		return Manifests{}, fmt.Errorf("the OpenTelemetryCollector %s is invalid: %w", instance.Name, err)
+	}
+
+	cfg := config.New(
+		config.WithCollectorImage(o.collectorImage),
+		config.WithTargetAllocatorImage(o.targetAllocatorImage),
+	)
+	if o.config != nil {
+		cfg = *o.config
+	}
+	objects, err := reconcile.Render(context.Background(), reconcile.Params{
+		Config:   cfg,
+		Log:      logr.Discard(),
+		Instance: *instance,
+		Scheme:   scheme,
+	})
+	if err != nil {
+		return Manifests{}, fmt.Errorf("failed to render the OpenTelemetryCollector %s: %w", instance.Name, err)
+	}
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return Manifests{}, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	replaced, err := reconcile.ReplaceConfig(cfg, *instance)
+	if err != nil {
+		return Manifests{}, fmt.Errorf("failed to rewrite the configuration of the OpenTelemetryCollector %s: %w", instance.Name, err)
+	}
+
+	return Manifests{
+		Objects:  objects,
+		Config:   replaced,
+		Warnings: warnings,
+	}, nil
+}
+
+// YAML returns the objects as a stream of YAML documents, e.g. to compare them to a golden file.
+func (m Manifests) YAML() ([]byte, error) {
+	encoder := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+
+	var buf bytes.Buffer
+	for _, obj := range m.Objects {
+		buf.WriteString("---\n")
+		if err := encoder.Encode(obj, &buf); err != nil {
+			return nil, fmt.Errorf("failed to encode %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Deployment returns the deployment with the given name, or nil when there is none.
+func (m Manifests) Deployment(name string) *appsv1.Deployment {
+	return find[*appsv1.Deployment](m.Objects, name)
+}
+
+// DaemonSet returns the daemon set with the given name, or nil when there is none.
+func (m Manifests) DaemonSet(name string) *appsv1.DaemonSet {
+	return find[*appsv1.DaemonSet](m.Objects, name)
+}
+
+// StatefulSet returns the stateful set with the given name, or nil when there is none.
+func (m Manifests) StatefulSet(name string) *appsv1.StatefulSet {
+	return find[*appsv1.StatefulSet](m.Objects, name)
+}
+
+// ConfigMap returns the config map with the given name, or nil when there is none.
+func (m Manifests) ConfigMap(name string) *corev1.ConfigMap {
+	return find[*corev1.ConfigMap](m.Objects, name)
+}
+
+// Service returns the service with the given name, or nil when there is none.
+func (m Manifests) Service(name string) *corev1.Service {
+	return find[*corev1.Service](m.Objects, name)
+}
+
+// ServiceAccount returns the service account with the given name, or nil when there is none.
+func (m Manifests) ServiceAccount(name string) *corev1.ServiceAccount {
+	return find[*corev1.ServiceAccount](m.Objects, name)
+}
+
+// PodDisruptionBudget returns the pod disruption budget with the given name, or nil when there is none.
+func (m Manifests) PodDisruptionBudget(name string) *policyv1.PodDisruptionBudget {
+	return find[*policyv1.PodDisruptionBudget](m.Objects, name)
+}
+
+// VerticalPodAutoscaler returns the vertical pod autoscaler with the given name, or nil when there is none.
+func (m Manifests) VerticalPodAutoscaler(name string) *unstructured.Unstructured {
+	for _, obj := range m.Objects {
+		if vpa, ok := obj.(*unstructured.Unstructured); ok && vpa.GroupVersionKind() == collector.VerticalPodAutoscalerGVK && vpa.GetName() == name {
+			return vpa
+		}
+	}
+	return nil
+}
+
+func find[T client.Object](objects []client.Object, name string) T {
+	var zero T
+	for _, obj := range objects {
+		if typed, ok := obj.(T); ok && obj.GetName() == name {
+			return typed
+		}
+	}
+	return zero
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	oteltesting "github.com/open-telemetry/opentelemetry-operator/pkg/testing"
+)
+
+func TestLoad(t *testing.T) {
+	collectors, err := oteltesting.Load("testdata/collector.yaml", oteltesting.WithNamespace("otel"))
+	require.NoError(t, err)
+
+	require.Len(t, collectors, 2)
+	assert.Equal(t, "simplest", collectors[0].Name)
+	assert.Equal(t, "otel", collectors[0].Namespace)
+	assert.Equal(t, "agent", collectors[1].Name)
+	assert.Equal(t, "observability", collectors[1].Namespace)
+	assert.Equal(t, v1alpha1.ModeDaemonSet, collectors[1].Spec.Mode)
+}
+
+func TestDecodeUnsupportedKind(t *testing.T) {
+	_, err := oteltesting.Decode(strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"))
+	assert.ErrorContains(t, err, "unsupported resource kind ConfigMap")
+}
+
+func TestDecodeStrict(t *testing.T) {
+	doc := `apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: test
+spec:
+  mode: statefulset
+  targetAllocator:
+    enabled: true
+    prometheusCr:
+      enabled: true
+`
+	collectors, err := oteltesting.Decode(strings.NewReader(doc))
+	require.NoError(t, err)
+	assert.False(t, collectors[0].Spec.TargetAllocator.PrometheusCR.Enabled)
+
+	_, err = oteltesting.Decode(strings.NewReader(doc), oteltesting.WithStrict())
+	assert.ErrorContains(t, err, `unknown field "spec.targetAllocator.prometheusCr"`)
+}
+
+func TestRender(t *testing.T) {
+	collectors, err := oteltesting.Load("testdata/collector.yaml")
+	require.NoError(t, err)
+
+	t.Run("should render a deployment by default", func(t *testing.T) {
+		manifests, err := oteltesting.Render(collectors[0], oteltesting.WithCollectorImage("collector:test"))
+		require.NoError(t, err)
+
+		deployment := manifests.Deployment("simplest-collector")
+		require.NotNil(t, deployment)
+		assert.Equal(t, "Deployment", deployment.Kind)
+		assert.Equal(t, "default", deployment.Namespace)
+		assert.Equal(t, "collector:test", deployment.Spec.Template.Spec.Containers[0].Image)
+		assert.NotNil(t, manifests.ServiceAccount("simplest-collector"))
+		assert.NotNil(t, manifests.Service("simplest-collector"))
+		assert.Nil(t, manifests.DaemonSet("simplest-collector"))
+		assert.Nil(t, manifests.PodDisruptionBudget("simplest-collector"))
+
+		cm := manifests.ConfigMap("simplest-collector")
+		require.NotNil(t, cm)
+		assert.Equal(t, manifests.Config, cm.Data["collector.yaml"])
+	})
+
+	t.Run("should render a daemon set", func(t *testing.T) {
+		manifests, err := oteltesting.Render(collectors[1])
+		require.NoError(t, err)
+
+		daemonSet := manifests.DaemonSet("agent-collector")
+		require.NotNil(t, daemonSet)
+		assert.Equal(t, "observability", daemonSet.Namespace)
+		assert.Nil(t, manifests.Deployment("agent-collector"))
+	})
+
+	t.Run("should render a vertical pod autoscaler", func(t *testing.T) {
+		otelcol := *collectors[1].DeepCopy()
+		otelcol.Spec.VerticalAutoscaler = &v1alpha1.VerticalAutoscalerSpec{UpdateMode: v1alpha1.VerticalAutoscalerUpdateModeInitial}
+		manifests, err := oteltesting.Render(otelcol)
+		require.NoError(t, err)
+
+		vpa := manifests.VerticalPodAutoscaler("agent-collector")
+		require.NotNil(t, vpa)
+		assert.Equal(t, "VerticalPodAutoscaler", vpa.GetKind())
+
+		out, err := manifests.YAML()
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "---\napiVersion: autoscaling.k8s.io/v1\nkind: VerticalPodAutoscaler\n")
+	})
+
+	t.Run("should report an invalid resource", func(t *testing.T) {
+		otelcol := v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Mode:        v1alpha1.ModeSidecar,
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			},
+		}
+		_, err := oteltesting.Render(otelcol)
+		assert.ErrorContains(t, err, "the OpenTelemetryCollector invalid is invalid")
+	})
+
+	t.Run("should use the configuration of the operator", func(t *testing.T) {
+		cfg := config.New(
+			config.WithCollectorImage("collector:operator"),
+			config.WithHTTPProxy("http://proxy:3128"),
+		)
+		manifests, err := oteltesting.Render(collectors[0], oteltesting.WithConfig(cfg), oteltesting.WithCollectorImage("collector:test"))
+		require.NoError(t, err)
+
+		deployment := manifests.Deployment("simplest-collector")
+		require.NotNil(t, deployment)
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "collector:operator", container.Image)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy:3128"})
+	})
+
+	t.Run("should encode the objects", func(t *testing.T) {
+		manifests, err := oteltesting.Render(collectors[0])
+		require.NoError(t, err)
+
+		out, err := manifests.YAML()
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "---\napiVersion: apps/v1\nkind: Deployment\n")
+		assert.Equal(t, len(manifests.Objects), strings.Count(string(out), "---\n"))
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	oteltesting "github.com/open-telemetry/opentelemetry-operator/pkg/testing"
+)
+
+// render writes the objects the operator creates for the OpenTelemetryCollector resources of the files, using the
+// same builders as the reconciliation, to w or, when dir is set, to a <namespace>/<name>.yaml file per collector
+// in dir, e.g. so that GitOps pipelines can vendor and diff them.
+func render(w io.Writer, files []string, dir string, cfg config.Config) error {
+	opts := []oteltesting.Option{oteltesting.WithConfig(cfg)}
+	for _, file := range files {
+		var (
+			collectors []otelv1alpha1.OpenTelemetryCollector
+			err        error
+		)
+		if file == "-" {
+			collectors, err = oteltesting.Decode(os.Stdin, opts...)
+		} else {
+			collectors, err = oteltesting.Load(file, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		for _, otelcol := range collectors {
+			manifests, err := oteltesting.Render(otelcol, opts...)
+			if err != nil {
+				return err
+			}
+			for _, warning := range manifests.Warnings {
+				setupLog.Info("the collector has a warning", "namespace", otelcol.Namespace, "name", otelcol.Name, "warning", warning)
+			}
+			out, err := manifests.YAML()
+			if err != nil {
+				return err
+			}
+
+			if len(dir) == 0 {
+				if _, err := w.Write(out); err != nil {
+					return err
+				}
+				continue
+			}
+			namespaceDir := filepath.Join(dir, otelcol.Namespace)
+			if err := os.MkdirAll(namespaceDir, 0o755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(namespaceDir, otelcol.Name+".yaml"), out, 0o644); err != nil { // #nosec G306 -- the rendered manifests are meant to be committed
+				return err
+			}
+		}
+	}
+	return nil
+}